@@ -0,0 +1,102 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// wechatctl 是基于本 SDK 的公众号运维命令行工具, 同时也是 SDK 常用接口的使用示例.
+//
+//  wechatctl [-appid APPID] [-secret APPSECRET] COMMAND [ARGS...]
+//
+//  APPID 和 APPSECRET 也可以通过环境变量 WECHAT_APPID 和 WECHAT_APPSECRET 设置;
+//  -base-url 把所有微信接口的请求转发到指定的地址, 比如 mp.Sandbox 启动的测试服务器.
+//
+//  COMMAND:
+//    token                                         获取 access_token
+//    template -to OPENID -id TEMPLATE_ID -data JSON 发送模板消息
+//    custom -to OPENID -text CONTENT               发送文本客服消息
+//    upload -type image|voice|video|thumb FILE     上传临时素材
+//    followers [-o FILE]                           导出所有关注者的 openid, 每行一个
+//    menu get|delete                               查询, 删除自定义菜单
+//    menu sync FILE                                用 JSON 文件里的菜单覆盖自定义菜单
+package main
+
+import (
+	"flag"
+	"fmt"
+	"net/http"
+	"os"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+type command struct {
+	name  string
+	usage string
+	run   func(tokenServer mp.TokenServer, httpClient *http.Client, args []string) error
+}
+
+var commands = []command{
+	{"token", "token", runToken},
+	{"template", "template -to OPENID -id TEMPLATE_ID [-url URL] -data JSON", runTemplate},
+	{"custom", "custom -to OPENID -text CONTENT", runCustom},
+	{"upload", "upload -type image|voice|video|thumb FILE", runUpload},
+	{"followers", "followers [-o FILE]", runFollowers},
+	{"menu", "menu get|delete|sync FILE", runMenu},
+}
+
+func usage() {
+	fmt.Fprintln(os.Stderr, "usage: wechatctl [-appid APPID] [-secret APPSECRET] COMMAND [ARGS...]")
+	fmt.Fprintln(os.Stderr)
+	fmt.Fprintln(os.Stderr, "commands:")
+	for _, cmd := range commands {
+		fmt.Fprintln(os.Stderr, "  "+cmd.usage)
+	}
+	fmt.Fprintln(os.Stderr)
+	flag.PrintDefaults()
+}
+
+func main() {
+	appId := flag.String("appid", os.Getenv("WECHAT_APPID"), "公众号的 AppId, 默认为环境变量 WECHAT_APPID")
+	appSecret := flag.String("secret", os.Getenv("WECHAT_APPSECRET"), "公众号的 AppSecret, 默认为环境变量 WECHAT_APPSECRET")
+	baseURL := flag.String("base-url", "", "把微信接口的请求转发到该地址, 可选")
+	flag.Usage = usage
+	flag.Parse()
+
+	if flag.NArg() == 0 {
+		usage()
+		os.Exit(2)
+	}
+	if *appId == "" || *appSecret == "" {
+		fmt.Fprintln(os.Stderr, "wechatctl: appid and secret are required")
+		os.Exit(2)
+	}
+
+	name, args := flag.Arg(0), flag.Args()[1:]
+	for _, cmd := range commands {
+		if cmd.name != name {
+			continue
+		}
+		httpClient := mp.TextHttpClient
+		if *baseURL != "" {
+			transport, err := mp.NewBaseURLTransport(*baseURL, nil)
+			if err != nil {
+				fmt.Fprintln(os.Stderr, "wechatctl:", err)
+				os.Exit(2)
+			}
+			httpClient = &http.Client{
+				Transport: transport,
+				Timeout:   mp.TextHttpClient.Timeout,
+			}
+		}
+		tokenServer := newTokenServer(*appId, *appSecret, httpClient)
+		if err := cmd.run(tokenServer, httpClient, args); err != nil {
+			fmt.Fprintln(os.Stderr, "wechatctl "+name+":", err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	fmt.Fprintln(os.Stderr, "wechatctl: unknown command "+name)
+	usage()
+	os.Exit(2)
+}
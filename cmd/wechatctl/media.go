@@ -0,0 +1,49 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/media"
+)
+
+func runUpload(tokenServer mp.TokenServer, httpClient *http.Client, args []string) (err error) {
+	flags := flag.NewFlagSet("upload", flag.ExitOnError)
+	mediaType := flags.String("type", media.MediaTypeImage, "素材类型: image, voice, video, thumb")
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		return errors.New("need exactly one FILE")
+	}
+	file := flags.Arg(0)
+
+	clt := media.NewClient(tokenServer, httpClient)
+	clt.StreamUpload = true
+
+	var info *media.MediaInfo
+	switch *mediaType {
+	case media.MediaTypeImage:
+		info, err = clt.UploadImage(file)
+	case media.MediaTypeVoice:
+		info, err = clt.UploadVoice(file)
+	case media.MediaTypeVideo:
+		info, err = clt.UploadVideo(file)
+	case media.MediaTypeThumb:
+		info, err = clt.UploadThumb(file)
+	default:
+		return errors.New("unknown -type " + *mediaType)
+	}
+	if err != nil {
+		return
+	}
+	fmt.Println("media_id:", info.MediaId)
+	return
+}
@@ -0,0 +1,69 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/menu"
+)
+
+// 菜单文件的格式和查询菜单接口返回的 menu 字段相同, 即 menu get 的输出可以直接用于 menu sync.
+func runMenu(tokenServer mp.TokenServer, httpClient *http.Client, args []string) (err error) {
+	if len(args) == 0 {
+		return errors.New("need a sub command: get, delete or sync")
+	}
+
+	clt := menu.NewClient(tokenServer, httpClient)
+	switch args[0] {
+	case "get":
+		mn, err := clt.GetMenu()
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetEscapeHTML(false)
+		enc.SetIndent("", "\t")
+		return enc.Encode(&mn)
+
+	case "delete":
+		if err = clt.DeleteMenu(); err != nil {
+			return
+		}
+		fmt.Println("ok")
+		return
+
+	case "sync":
+		if len(args) != 2 {
+			return errors.New("need exactly one FILE")
+		}
+		data, err := ioutil.ReadFile(args[1])
+		if err != nil {
+			return err
+		}
+		var mn menu.Menu
+		if err = json.Unmarshal(data, &mn); err != nil {
+			return err
+		}
+		if len(mn.Buttons) == 0 {
+			return errors.New("no button in " + args[1])
+		}
+		if err = clt.CreateMenu(mn); err != nil {
+			return err
+		}
+		fmt.Println("ok")
+		return nil
+
+	default:
+		return errors.New("unknown sub command " + args[0])
+	}
+}
@@ -0,0 +1,66 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/message/custom"
+	"github.com/chanxuehong/wechat/mp/message/template"
+)
+
+func runTemplate(tokenServer mp.TokenServer, httpClient *http.Client, args []string) (err error) {
+	flags := flag.NewFlagSet("template", flag.ExitOnError)
+	toUser := flags.String("to", "", "接收者的 openid")
+	templateId := flags.String("id", "", "模板ID")
+	url := flags.String("url", "", "点击模板消息跳转的 URL, 可选")
+	data := flags.String("data", "", `模板数据, JSON 格式, 比如 {"first":{"value":"hello","color":"#173177"}}`)
+	flags.Parse(args)
+
+	if *toUser == "" || *templateId == "" || *data == "" {
+		return errors.New("-to, -id and -data are required")
+	}
+	if !json.Valid([]byte(*data)) {
+		return errors.New("-data is not valid JSON")
+	}
+
+	clt := template.NewClient(tokenServer, httpClient)
+	msgid, err := clt.Send(&template.TemplateMessage{
+		ToUser:      *toUser,
+		TemplateId:  *templateId,
+		URL:         *url,
+		RawJSONData: json.RawMessage(*data),
+	})
+	if err != nil {
+		return
+	}
+	fmt.Println("msgid:", msgid)
+	return
+}
+
+func runCustom(tokenServer mp.TokenServer, httpClient *http.Client, args []string) (err error) {
+	flags := flag.NewFlagSet("custom", flag.ExitOnError)
+	toUser := flags.String("to", "", "接收者的 openid")
+	text := flags.String("text", "", "文本消息的内容")
+	kfAccount := flags.String("kf", "", "以某个客服帐号来发消息, 可选")
+	flags.Parse(args)
+
+	if *toUser == "" || *text == "" {
+		return errors.New("-to and -text are required")
+	}
+
+	clt := custom.NewClient(tokenServer, httpClient)
+	if err = clt.SendText(custom.NewText(*toUser, *text, *kfAccount)); err != nil {
+		return
+	}
+	fmt.Println("ok")
+	return
+}
@@ -0,0 +1,63 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package main
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 命令行只运行很短的时间, 不需要 mp.DefaultTokenServer 的后台刷新, 第一次使用时获取一次即可.
+type tokenServer struct {
+	appId      string
+	appSecret  string
+	httpClient *http.Client
+
+	mutex sync.Mutex
+	token string
+}
+
+func newTokenServer(appId, appSecret string, httpClient *http.Client) *tokenServer {
+	return &tokenServer{
+		appId:      appId,
+		appSecret:  appSecret,
+		httpClient: httpClient,
+	}
+}
+
+func (srv *tokenServer) Token() (token string, err error) {
+	srv.mutex.Lock()
+	token = srv.token
+	srv.mutex.Unlock()
+
+	if token != "" {
+		return
+	}
+	return srv.TokenRefresh()
+}
+
+func (srv *tokenServer) TokenRefresh() (token string, err error) {
+	srv.mutex.Lock()
+	defer srv.mutex.Unlock()
+
+	if token, _, err = mp.FetchToken(srv.appId, srv.appSecret, srv.httpClient); err != nil {
+		return
+	}
+	srv.token = token
+	return
+}
+
+func runToken(tokenServer mp.TokenServer, httpClient *http.Client, args []string) (err error) {
+	token, err := tokenServer.Token()
+	if err != nil {
+		return
+	}
+	fmt.Println(token)
+	return
+}
@@ -0,0 +1,47 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package main
+
+import (
+	"bufio"
+	"flag"
+	"net/http"
+	"os"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/user"
+)
+
+func runFollowers(tokenServer mp.TokenServer, httpClient *http.Client, args []string) (err error) {
+	flags := flag.NewFlagSet("followers", flag.ExitOnError)
+	output := flags.String("o", "", "输出文件, 默认为标准输出")
+	flags.Parse(args)
+
+	out := os.Stdout
+	if *output != "" {
+		if out, err = os.Create(*output); err != nil {
+			return
+		}
+		defer out.Close()
+	}
+	w := bufio.NewWriter(out)
+
+	clt := user.NewClient(tokenServer, httpClient)
+	for nextOpenId := ""; ; {
+		rst, err := clt.UserListStream(nextOpenId, func(openid string) error {
+			w.WriteString(openid)
+			return w.WriteByte('\n')
+		})
+		if err != nil {
+			return err
+		}
+		if rst.NextOpenId == "" || rst.NextOpenId == nextOpenId || rst.GotCount == 0 {
+			break
+		}
+		nextOpenId = rst.NextOpenId
+	}
+	return w.Flush()
+}
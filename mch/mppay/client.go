@@ -0,0 +1,208 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mppay
+
+import (
+	"context"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/chanxuehong/wechat/mch/pay"
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/card"
+	"github.com/chanxuehong/wechat/mp/message/custom"
+	"github.com/chanxuehong/wechat/mp/message/template"
+)
+
+// 公众号和微信支付商户号绑定在一起的 Client, 共享 appid, http.Client, 日志, 统计以及请求的 context.
+//  Logger 和 Metrics 同时记录公众号接口(通过 Template, Card 等返回的 Client 调用)和微信支付接口的调用,
+//  不包括 TokenServer 自己获取 access_token 的请求.
+type Client struct {
+	appId  string
+	mchId  string
+	apiKey string
+
+	tokenServer      mp.TokenServer
+	rawHttpClient    *http.Client // NewClient 传入的 http.Client
+	rawTLSHttpClient *http.Client // SetTLSHttpClient 传入的 http.Client, 可以为 nil
+	httpClient       *http.Client // 调用公众号接口所用的 http.Client, 包装了 ctx, Logger 和 Metrics
+	payClient        *pay.Client
+	ctx              context.Context // 可以为 nil, 见 WithContext
+
+	// 每次调用微信支付接口后的回调, 用于日志和统计, 可以为 nil.
+	//  api 为接口名称, 比如 "unifiedorder"; err 为调用的结果.
+	OnPayCall func(api string, req, resp map[string]string, err error)
+
+	// 可以为 nil, 表示不记录日志; 否则记录每次调用的接口, 耗时以及错误.
+	Logger *log.Logger
+
+	// 可以为 nil, 表示不统计; 多个 Client 可以共用一个 CallMetrics.
+	Metrics *CallMetrics
+}
+
+// 创建一个新的 Client.
+//  appId:       公众号的 appid, 也是支付所用的 appid
+//  mchId:       商户号
+//  apiKey:      商户平台设置的API密钥
+//  tokenServer: 公众号的 access_token 中控服务器
+//  如果 httpClient == nil 则默认用 http.DefaultClient.
+func NewClient(appId, mchId, apiKey string, tokenServer mp.TokenServer, httpClient *http.Client) *Client {
+	if tokenServer == nil {
+		panic("TokenServer == nil")
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	clt := &Client{
+		appId:         appId,
+		mchId:         mchId,
+		apiKey:        apiKey,
+		tokenServer:   tokenServer,
+		rawHttpClient: httpClient,
+	}
+	clt.initHttpClients()
+	return clt
+}
+
+// 用 clt.ctx 和 hooks 包装 http.Client.
+func (clt *Client) initHttpClients() {
+	clt.httpClient = clt.wrapHttpClient(clt.rawHttpClient, true)
+	clt.payClient = pay.NewClient(clt.apiKey, clt.wrapHttpClient(clt.rawHttpClient, false))
+	if clt.rawTLSHttpClient != nil {
+		clt.payClient.SetTLSHttpClient(clt.wrapHttpClient(clt.rawTLSHttpClient, false))
+	}
+}
+
+// 返回 clt 的一个副本, 副本的所有请求(公众号接口和微信支付接口)都使用 ctx:
+//  ctx 取消时正在进行的请求也会取消, RequestDecorator 之类的可以通过 req.Context() 取到 ctx 的值(比如链路追踪的 ID).
+//  副本使用 clt 当前的 OnPayCall, Logger 和 Metrics; 一般在处理每个请求(比如支付结果通知)时调用.
+func (clt *Client) WithContext(ctx context.Context) *Client {
+	if ctx == nil {
+		panic("nil context")
+	}
+	c := *clt
+	c.ctx = ctx
+	c.initHttpClients()
+	return &c
+}
+
+// 返回 WithContext 设置的 context, 没有设置时返回 context.Background().
+func (clt *Client) Context() context.Context {
+	if clt.ctx != nil {
+		return clt.ctx
+	}
+	return context.Background()
+}
+
+func (clt *Client) AppId() string {
+	return clt.appId
+}
+func (clt *Client) MchId() string {
+	return clt.mchId
+}
+
+// 设置请求需要双向证书的支付接口所用的 http.Client, 参考 pay.Client.SetTLSHttpClient.
+func (clt *Client) SetTLSHttpClient(httpClient *http.Client) {
+	clt.rawTLSHttpClient = httpClient
+	clt.payClient.SetTLSHttpClient(clt.wrapHttpClient(httpClient, false))
+}
+
+// 微信支付的 Client.
+func (clt *Client) Pay() *pay.Client {
+	return clt.payClient
+}
+
+// 公众号的 mp.WechatClient, 用于调用没有封装的公众号接口.
+func (clt *Client) WechatClient() *mp.WechatClient {
+	return &mp.WechatClient{
+		TokenServer: clt.tokenServer,
+		HttpClient:  clt.httpClient,
+	}
+}
+
+// 模板消息的 Client.
+func (clt *Client) Template() *template.Client {
+	return template.NewClient(clt.tokenServer, clt.httpClient)
+}
+
+// 客服消息的 Client.
+func (clt *Client) Custom() *custom.Client {
+	return custom.NewClient(clt.tokenServer, clt.httpClient)
+}
+
+// 卡券的 Client.
+func (clt *Client) Card() *card.Client {
+	return card.NewClient(clt.tokenServer, clt.httpClient)
+}
+
+// 补全请求参数 appid 和 mch_id.
+func (clt *Client) fill(req map[string]string) map[string]string {
+	if req == nil {
+		req = make(map[string]string)
+	}
+	if req["appid"] == "" {
+		req["appid"] = clt.appId
+	}
+	if req["mch_id"] == "" {
+		req["mch_id"] = clt.mchId
+	}
+	return req
+}
+
+func (clt *Client) postXML(api string, fn func(map[string]string) (map[string]string, error), req map[string]string) (resp map[string]string, err error) {
+	req = clt.fill(req)
+	start := time.Now()
+	resp, err = fn(req)
+	clt.observe("pay:"+api, start, err)
+	if clt.OnPayCall != nil {
+		clt.OnPayCall(api, req, resp, err)
+	}
+	return
+}
+
+// 统一下单, 自动补全 appid 和 mch_id.
+func (clt *Client) UnifiedOrder(req map[string]string) (resp map[string]string, err error) {
+	return clt.postXML("unifiedorder", clt.payClient.UnifiedOrder, req)
+}
+
+// 订单查询, 自动补全 appid 和 mch_id.
+func (clt *Client) OrderQuery(req map[string]string) (resp map[string]string, err error) {
+	return clt.postXML("orderquery", clt.payClient.OrderQuery, req)
+}
+
+// 关闭订单, 自动补全 appid 和 mch_id.
+func (clt *Client) CloseOrder(req map[string]string) (resp map[string]string, err error) {
+	return clt.postXML("closeorder", clt.payClient.CloseOrder, req)
+}
+
+// 申请退款, 自动补全 appid 和 mch_id.
+//  NOTE: 请求需要双向证书, 参考 SetTLSHttpClient.
+func (clt *Client) Refund(req map[string]string) (resp map[string]string, err error) {
+	return clt.postXML("refund", clt.payClient.Refund, req)
+}
+
+// 退款查询, 自动补全 appid 和 mch_id.
+func (clt *Client) RefundQuery(req map[string]string) (resp map[string]string, err error) {
+	return clt.postXML("refundquery", clt.payClient.RefundQuery, req)
+}
+
+// 根据统一下单返回的 prepay_id 生成 wx.chooseWXPay 的参数.
+func (clt *Client) ChooseWXPayRequest(prepayId, signType string) *pay.ChooseWXPayRequest {
+	return pay.NewChooseWXPayRequest(clt.appId, prepayId, signType, clt.apiKey)
+}
+
+// 根据统一下单返回的 prepay_id 生成 WeixinJSBridge 发起支付的参数.
+func (clt *Client) BridgePayRequest(prepayId, signType string) *pay.BridgePayRequest {
+	return pay.NewBridgePayRequest(clt.appId, prepayId, signType, clt.apiKey)
+}
+
+// 创建处理支付结果通知的 http.Handler, 参考 pay.NewPayNotifyHTTPHandler.
+//  在 handler 的回调中可以通过 clt.Template(), clt.Card() 等调用公众号接口.
+func (clt *Client) PayNotifyHandler(handler *pay.PayNotifyHandler) http.Handler {
+	return pay.NewPayNotifyHTTPHandler(clt.appId, clt.mchId, clt.apiKey, handler)
+}
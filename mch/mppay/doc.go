@@ -0,0 +1,8 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 公众号和微信支付商户号绑定在一起的 Client.
+//  适用于需要同时调用公众号接口和微信支付接口的场景, 比如支付成功后发送模板消息, 退款后发放卡券.
+package mppay
@@ -0,0 +1,194 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mppay
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// 一个接口调用的统计信息
+type CallStats struct {
+	Count     int64         // 调用的次数
+	Errors    int64         // 失败的次数
+	TotalTime time.Duration // 累计的调用时间
+	MaxTime   time.Duration // 最长的调用时间
+}
+
+// 平均调用时间
+func (stats *CallStats) AvgTime() time.Duration {
+	if stats.Count == 0 {
+		return 0
+	}
+	return stats.TotalTime / time.Duration(stats.Count)
+}
+
+// 统计 Client 的接口调用, 公众号接口和微信支付接口共用一个 CallMetrics.
+//  微信支付接口按照 "pay:" + 接口名称 分类, 比如 "pay:unifiedorder";
+//  公众号接口按照 "mp:" + URL 的 path 分类, 比如 "mp:/cgi-bin/message/template/send", 失败是指网络错误或者 http 状态码不是 2xx.
+type CallMetrics struct {
+	// 每次调用结束的回调, 一般用于对接 prometheus 之类的监控系统; 可以为 nil.
+	OnObserve func(api string, elapsed time.Duration, failed bool)
+
+	mutex sync.Mutex
+	stats map[string]*CallStats
+}
+
+func (m *CallMetrics) observe(api string, elapsed time.Duration, failed bool) {
+	m.mutex.Lock()
+	if m.stats == nil {
+		m.stats = make(map[string]*CallStats)
+	}
+	stats := m.stats[api]
+	if stats == nil {
+		stats = new(CallStats)
+		m.stats[api] = stats
+	}
+	stats.Count++
+	if failed {
+		stats.Errors++
+	}
+	stats.TotalTime += elapsed
+	if elapsed > stats.MaxTime {
+		stats.MaxTime = elapsed
+	}
+	m.mutex.Unlock()
+
+	if m.OnObserve != nil {
+		m.OnObserve(api, elapsed, failed)
+	}
+}
+
+// 返回每个接口的统计信息.
+func (m *CallMetrics) Stats() map[string]CallStats {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	stats := make(map[string]CallStats, len(m.stats))
+	for api, s := range m.stats {
+		stats[api] = *s
+	}
+	return stats
+}
+
+// 记录一次调用的日志和统计.
+func (clt *Client) observe(api string, start time.Time, err error) {
+	elapsed := time.Since(start)
+	if clt.Metrics != nil {
+		clt.Metrics.observe(api, elapsed, err != nil)
+	}
+	if clt.Logger != nil {
+		if err != nil {
+			clt.Logger.Printf("mppay call %s failed in %s: %s", api, elapsed, err)
+		} else {
+			clt.Logger.Printf("mppay call %s succeeded in %s", api, elapsed)
+		}
+	}
+}
+
+// 包装 http.Client, 请求使用 clt.ctx, observe 为 true 时记录公众号接口的日志和统计.
+func (clt *Client) wrapHttpClient(httpClient *http.Client, observe bool) *http.Client {
+	if httpClient == nil {
+		return nil
+	}
+	c := *httpClient
+	c.Transport = &hookTransport{
+		clt:       clt,
+		transport: httpClient.Transport,
+		observe:   observe,
+	}
+	return &c
+}
+
+type hookTransport struct {
+	clt       *Client
+	transport http.RoundTripper
+	observe   bool
+}
+
+func (t *hookTransport) RoundTrip(req *http.Request) (resp *http.Response, err error) {
+	transport := t.transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	var cancel context.CancelFunc
+	if ctx := t.clt.ctx; ctx != nil {
+		req, cancel = withMergedContext(req, ctx)
+	}
+	if t.observe {
+		start := time.Now()
+		defer func() {
+			callErr := err
+			if callErr == nil && (resp.StatusCode < 200 || resp.StatusCode >= 300) {
+				callErr = &statusError{resp.Status}
+			}
+			t.clt.observe("mp:"+req.URL.Path, start, callErr)
+		}()
+	}
+
+	resp, err = transport.RoundTrip(req)
+	if cancel != nil {
+		if err != nil {
+			cancel()
+			return
+		}
+		resp.Body = &cancelOnCloseBody{ReadCloser: resp.Body, cancel: cancel}
+	}
+	return
+}
+
+type statusError struct {
+	status string
+}
+
+func (e *statusError) Error() string {
+	return "http.Status: " + e.status
+}
+
+// 返回使用新 context 的请求: 保留 req 原来的 context(比如 http.Client 的超时), 同时 ctx 取消时也取消请求,
+// 并且可以从请求的 context 里取到 ctx 的值(比如链路追踪的 ID).
+func withMergedContext(req *http.Request, ctx context.Context) (*http.Request, context.CancelFunc) {
+	merged, cancel := context.WithCancel(valuesContext{Context: req.Context(), values: ctx})
+	if done := ctx.Done(); done != nil {
+		go func() {
+			select {
+			case <-done:
+				cancel()
+			case <-merged.Done():
+			}
+		}()
+	}
+	return req.WithContext(merged), cancel
+}
+
+// 先查找 Context 的值, 然后是 values 的值.
+type valuesContext struct {
+	context.Context
+	values context.Context
+}
+
+func (ctx valuesContext) Value(key interface{}) interface{} {
+	if v := ctx.Context.Value(key); v != nil {
+		return v
+	}
+	return ctx.values.Value(key)
+}
+
+// 关闭 Body 时取消请求的 context, 释放 withMergedContext 的 goroutine.
+type cancelOnCloseBody struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+func (body *cancelOnCloseBody) Close() error {
+	err := body.ReadCloser.Close()
+	body.cancel()
+	return err
+}
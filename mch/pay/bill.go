@@ -0,0 +1,315 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package pay
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/chanxuehong/util"
+)
+
+// 下载对账单, 返回对账单内容的 io.ReadCloser, 如果 tar_type 为 GZIP 则返回的是解压后的内容.
+//  和 DownloadBill 不同, 对账单不会全部读到内存, 适合配合 NewBillReader 流式解析大的对账单.
+//  NOTE: 调用者使用完毕后需要关闭返回的 io.ReadCloser.
+func (clt *Client) DownloadBillStream(req map[string]string) (body io.ReadCloser, err error) {
+	return clt.postXMLStream("https://api.mch.weixin.qq.com/pay/downloadbill", req)
+}
+
+// 下载资金账单, 返回账单内容的 io.ReadCloser, 如果 tar_type 为 GZIP 则返回的是解压后的内容.
+//  NOTE: 请求需要双向证书, 签名类型只支持 HMAC-SHA256, 如果没有设置 sign_type 则默认为 HMAC-SHA256;
+//  调用者使用完毕后需要关闭返回的 io.ReadCloser.
+func (clt *Client) DownloadFundFlow(req map[string]string) (body io.ReadCloser, err error) {
+	if req != nil && req["sign_type"] == "" {
+		req["sign_type"] = SignTypeHMACSHA256
+	}
+	return clt.postXMLStream("https://api.mch.weixin.qq.com/pay/downloadfundflow", req)
+}
+
+type readCloser struct {
+	io.Reader
+	io.Closer
+}
+
+func (clt *Client) postXMLStream(url string, req map[string]string) (body io.ReadCloser, err error) {
+	url = clt.requestURL(url)
+	clt.prepareRequest(req)
+
+	bodyBuf := textBufferPool.Get().(*bytes.Buffer)
+	bodyBuf.Reset()
+	defer textBufferPool.Put(bodyBuf)
+
+	if err = util.FormatMapToXML(bodyBuf, req); err != nil {
+		return
+	}
+
+	httpResp, err := clt.httpClientFor(url).Post(url, "text/xml; charset=utf-8", bodyBuf)
+	if err != nil {
+		return
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		httpResp.Body.Close()
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
+	}
+
+	// 失败时返回的是 XML, 成功时返回的是文本或者 GZIP 压缩的文本
+	bufReader := bufio.NewReader(httpResp.Body)
+	head, _ := bufReader.Peek(5)
+	switch {
+	case bytes.Equal(head, []byte("<xml>")):
+		defer httpResp.Body.Close()
+
+		var result Error
+		if err = xml.NewDecoder(bufReader).Decode(&result); err != nil {
+			return
+		}
+		err = &result
+		return
+	case len(head) >= 2 && head[0] == 0x1f && head[1] == 0x8b:
+		gzipReader, err := gzip.NewReader(bufReader)
+		if err != nil {
+			httpResp.Body.Close()
+			return nil, err
+		}
+		body = readCloser{Reader: gzipReader, Closer: httpResp.Body}
+		return body, nil
+	default:
+		body = readCloser{Reader: bufReader, Closer: httpResp.Body}
+		return
+	}
+}
+
+// 对账单的流式解析器.
+//  对账单第一行为表头, 接着是明细数据(每个字段以 ` 开头), 最后是汇总表头和汇总数据;
+//  交易账单和资金账单都是这个格式.
+type BillReader struct {
+	csvReader *csv.Reader
+	header    []string
+	index     map[string]int
+
+	summary map[string]string
+	eof     bool
+}
+
+// 创建对账单的流式解析器, 会读取对账单的表头.
+func NewBillReader(r io.Reader) (br *BillReader, err error) {
+	csvReader := csv.NewReader(r)
+	csvReader.FieldsPerRecord = -1
+	csvReader.LazyQuotes = true
+	csvReader.TrimLeadingSpace = true
+
+	header, err := csvReader.Read()
+	if err != nil {
+		if err == io.EOF {
+			err = errors.New("empty bill")
+		}
+		return
+	}
+	if len(header) > 0 {
+		header[0] = strings.TrimPrefix(header[0], "\ufeff")
+	}
+
+	index := make(map[string]int, len(header))
+	for i, name := range header {
+		index[strings.TrimSpace(name)] = i
+	}
+
+	br = &BillReader{
+		csvReader: csvReader,
+		header:    header,
+		index:     index,
+	}
+	return
+}
+
+// 对账单明细的表头.
+func (br *BillReader) Header() []string {
+	return br.header
+}
+
+// 读取下一条明细数据, 明细数据读取完毕返回 io.EOF, 之后可以通过 Summary 获取汇总数据.
+func (br *BillReader) Next() (record *BillRecord, err error) {
+	if br.eof {
+		err = io.EOF
+		return
+	}
+
+	fields, err := br.csvReader.Read()
+	if err != nil {
+		if err == io.EOF {
+			br.eof = true
+		}
+		return
+	}
+
+	// 不以 ` 开头是汇总表头, 下一行是汇总数据
+	if len(fields) > 0 && !strings.HasPrefix(fields[0], "`") {
+		br.eof = true
+		br.readSummary(fields)
+		err = io.EOF
+		return
+	}
+
+	for i := range fields {
+		fields[i] = strings.TrimPrefix(fields[i], "`")
+	}
+	record = &BillRecord{
+		Fields: fields,
+		index:  br.index,
+	}
+	return
+}
+
+func (br *BillReader) readSummary(header []string) {
+	values, err := br.csvReader.Read()
+	if err != nil {
+		return
+	}
+
+	summary := make(map[string]string, len(header))
+	for i, name := range header {
+		if i < len(values) {
+			summary[strings.TrimSpace(name)] = strings.TrimPrefix(values[i], "`")
+		}
+	}
+	br.summary = summary
+}
+
+// 对账单的汇总数据, 表头 => 数值; 只有 Next 返回 io.EOF 后才有效, 没有汇总数据返回 nil.
+func (br *BillReader) Summary() map[string]string {
+	return br.summary
+}
+
+// 对账单的一条明细数据
+type BillRecord struct {
+	Fields []string // 已经去掉了前缀 ` 的字段
+	index  map[string]int
+}
+
+// 根据表头名称获取字段, 比如 "商户订单号", 不存在返回 "".
+func (record *BillRecord) Get(column string) string {
+	i, ok := record.index[column]
+	if !ok || i >= len(record.Fields) {
+		return ""
+	}
+	return record.Fields[i]
+}
+
+// 获取 columns 中第一个存在的字段, 用于兼容全角和半角括号的表头.
+func (record *BillRecord) getAny(columns ...string) string {
+	for _, column := range columns {
+		if _, ok := record.index[column]; ok {
+			return record.Get(column)
+		}
+	}
+	return ""
+}
+
+// 交易账单的明细, 金额的单位为元.
+//  不同 bill_type 的账单字段不同, 不存在的字段为 "".
+type TradeBill struct {
+	TradeTime          string // 交易时间
+	AppId              string // 公众账号ID
+	MchId              string // 商户号
+	SubMchId           string // 特约商户号
+	DeviceInfo         string // 设备号
+	TransactionId      string // 微信订单号
+	OutTradeNo         string // 商户订单号
+	OpenId             string // 用户标识
+	TradeType          string // 交易类型
+	TradeState         string // 交易状态
+	BankType           string // 付款银行
+	FeeType            string // 货币种类
+	SettlementTotalFee string // 应结订单金额
+	CouponFee          string // 代金券金额
+	RefundId           string // 微信退款单号
+	OutRefundNo        string // 商户退款单号
+	RefundFee          string // 退款金额
+	CouponRefundFee    string // 充值券退款金额
+	RefundType         string // 退款类型
+	RefundStatus       string // 退款状态
+	Body               string // 商品名称
+	Attach             string // 商户数据包
+	Poundage           string // 手续费
+	Rate               string // 费率
+	TotalFee           string // 订单金额
+	ApplyRefundFee     string // 申请退款金额
+	RateRemark         string // 费率备注
+}
+
+// 把明细数据转换为交易账单的明细.
+func (record *BillRecord) TradeBill() *TradeBill {
+	return &TradeBill{
+		TradeTime:          record.Get("交易时间"),
+		AppId:              record.Get("公众账号ID"),
+		MchId:              record.Get("商户号"),
+		SubMchId:           record.Get("特约商户号"),
+		DeviceInfo:         record.Get("设备号"),
+		TransactionId:      record.Get("微信订单号"),
+		OutTradeNo:         record.Get("商户订单号"),
+		OpenId:             record.Get("用户标识"),
+		TradeType:          record.Get("交易类型"),
+		TradeState:         record.Get("交易状态"),
+		BankType:           record.Get("付款银行"),
+		FeeType:            record.Get("货币种类"),
+		SettlementTotalFee: record.Get("应结订单金额"),
+		CouponFee:          record.Get("代金券金额"),
+		RefundId:           record.Get("微信退款单号"),
+		OutRefundNo:        record.Get("商户退款单号"),
+		RefundFee:          record.Get("退款金额"),
+		CouponRefundFee:    record.Get("充值券退款金额"),
+		RefundType:         record.Get("退款类型"),
+		RefundStatus:       record.Get("退款状态"),
+		Body:               record.Get("商品名称"),
+		Attach:             record.Get("商户数据包"),
+		Poundage:           record.Get("手续费"),
+		Rate:               record.Get("费率"),
+		TotalFee:           record.Get("订单金额"),
+		ApplyRefundFee:     record.Get("申请退款金额"),
+		RateRemark:         record.Get("费率备注"),
+	}
+}
+
+// 资金账单的明细, 金额的单位为元.
+type FundFlow struct {
+	AccountingTime string // 记账时间
+	TransactionId  string // 微信支付业务单号
+	FlowId         string // 资金流水单号
+	BizName        string // 业务名称
+	BizType        string // 业务类型
+	InOutType      string // 收支类型
+	Amount         string // 收支金额
+	Balance        string // 账户结余
+	Applicant      string // 资金变更提交申请人
+	Remark         string // 备注
+	VoucherNo      string // 业务凭证号
+}
+
+// 把明细数据转换为资金账单的明细.
+func (record *BillRecord) FundFlow() *FundFlow {
+	return &FundFlow{
+		AccountingTime: record.Get("记账时间"),
+		TransactionId:  record.Get("微信支付业务单号"),
+		FlowId:         record.Get("资金流水单号"),
+		BizName:        record.Get("业务名称"),
+		BizType:        record.Get("业务类型"),
+		InOutType:      record.Get("收支类型"),
+		Amount:         record.getAny("收支金额(元)", "收支金额（元）"),
+		Balance:        record.getAny("账户结余(元)", "账户结余（元）"),
+		Applicant:      record.Get("资金变更提交申请人"),
+		Remark:         record.Get("备注"),
+		VoucherNo:      record.Get("业务凭证号"),
+	}
+}
@@ -17,8 +17,10 @@ import (
 )
 
 type Client struct {
-	apiKey     string
-	httpClient *http.Client
+	apiKey        string
+	httpClient    *http.Client
+	tlsHttpClient *http.Client // 请求需要双向证书的接口所用的 http.Client, 可以为 nil
+	sandbox       bool         // 是否为仿真测试环境
 }
 
 // 创建一个新的 Client.
@@ -35,8 +37,12 @@ func NewClient(apiKey string, httpClient *http.Client) *Client {
 }
 
 // 微信支付通用请求方法.
+//  如果 req 没有设置 nonce_str 和 sign, 则会自动生成; 签名类型由 req["sign_type"] 决定, 默认为 MD5.
 //  注意: err == nil 表示协议状态都为 SUCCESS.
 func (clt *Client) PostXML(url string, req map[string]string) (resp map[string]string, err error) {
+	url = clt.requestURL(url)
+	clt.prepareRequest(req)
+
 	bodyBuf := textBufferPool.Get().(*bytes.Buffer)
 	bodyBuf.Reset()
 	defer textBufferPool.Put(bodyBuf)
@@ -45,7 +51,7 @@ func (clt *Client) PostXML(url string, req map[string]string) (resp map[string]s
 		return
 	}
 
-	httpResp, err := clt.httpClient.Post(url, "text/xml; charset=utf-8", bodyBuf)
+	httpResp, err := clt.httpClientFor(url).Post(url, "text/xml; charset=utf-8", bodyBuf)
 	if err != nil {
 		return
 	}
@@ -80,7 +86,7 @@ func (clt *Client) PostXML(url string, req map[string]string) (resp map[string]s
 		err = errors.New("no sign parameter")
 		return
 	}
-	signature2 := Sign(resp, clt.apiKey, nil)
+	signature2 := SignWithType(resp, clt.apiKey, req["sign_type"])
 	if signature1 != signature2 {
 		err = fmt.Errorf("check signature failed, \r\ninput: %q, \r\nlocal: %q", signature1, signature2)
 		return
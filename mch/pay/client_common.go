@@ -53,6 +53,8 @@ func (clt *Client) ShortURL(req map[string]string) (resp map[string]string, err
 
 // 下载对账单.
 func (clt *Client) DownloadBill(req map[string]string) (data []byte, err error) {
+	clt.prepareRequest(req)
+
 	bodyBuf := textBufferPool.Get().(*bytes.Buffer)
 	bodyBuf.Reset()
 	defer textBufferPool.Put(bodyBuf)
@@ -61,8 +63,8 @@ func (clt *Client) DownloadBill(req map[string]string) (data []byte, err error)
 		return
 	}
 
-	url := "https://api.mch.weixin.qq.com/pay/downloadbill"
-	httpResp, err := clt.httpClient.Post(url, "text/xml; charset=utf-8", bodyBuf)
+	url := clt.requestURL("https://api.mch.weixin.qq.com/pay/downloadbill")
+	httpResp, err := clt.httpClientFor(url).Post(url, "text/xml; charset=utf-8", bodyBuf)
 	if err != nil {
 		return
 	}
@@ -0,0 +1,70 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package pay
+
+import (
+	"net/http"
+	"strings"
+)
+
+const (
+	apiURLPrefix        = "https://api.mch.weixin.qq.com/"
+	sandboxAPIURLPrefix = "https://api.mch.weixin.qq.com/sandboxnew/"
+)
+
+// 设置请求需要双向证书的接口(申请退款, 撤销订单, 发红包等)所用的 http.Client.
+//  httpClient 一般由 NewTLSHttpClient 创建, 如果没有设置则这些接口也用 NewClient 传入的 httpClient.
+func (clt *Client) SetTLSHttpClient(httpClient *http.Client) {
+	clt.tlsHttpClient = httpClient
+}
+
+// 创建一个仿真测试环境的 Client, 所有接口的请求都会发送到仿真测试系统.
+//  sandboxSignKey: 仿真测试系统的签名密钥, 通过 GetSandboxSignKey 获取, 不是商户平台设置的API密钥;
+//  如果 httpClient == nil 则默认用 http.DefaultClient.
+func NewSandboxClient(sandboxSignKey string, httpClient *http.Client) *Client {
+	clt := NewClient(sandboxSignKey, httpClient)
+	clt.sandbox = true
+	return clt
+}
+
+// 如果是仿真测试环境, 则把正式环境的 url 转换为仿真测试环境的 url.
+func (clt *Client) requestURL(url string) string {
+	if !clt.sandbox || strings.HasPrefix(url, sandboxAPIURLPrefix) {
+		return url
+	}
+	if strings.HasPrefix(url, apiURLPrefix) {
+		return sandboxAPIURLPrefix + url[len(apiURLPrefix):]
+	}
+	return url
+}
+
+// 获取请求 url 所用的 http.Client, 需要双向证书的接口优先用 tlsHttpClient.
+func (clt *Client) httpClientFor(url string) *http.Client {
+	if clt.tlsHttpClient != nil && needTLS(url) {
+		return clt.tlsHttpClient
+	}
+	return clt.httpClient
+}
+
+// 判断 url 对应的接口是否需要双向证书.
+func needTLS(url string) bool {
+	return strings.Contains(url, "/secapi/") ||
+		strings.Contains(url, "/mmpaymkttransfers/") ||
+		strings.HasSuffix(url, "/pay/downloadfundflow")
+}
+
+// 补全请求参数 nonce_str 和 sign.
+func (clt *Client) prepareRequest(req map[string]string) {
+	if req == nil {
+		return
+	}
+	if req["nonce_str"] == "" {
+		req["nonce_str"] = NonceStr()
+	}
+	if req["sign"] == "" {
+		req["sign"] = SignWithType(req, clt.apiKey, req["sign_type"])
+	}
+}
@@ -18,11 +18,15 @@ func NewTLSHttpClient(certFile, keyFile string) (httpClient *http.Client, err er
 	if err != nil {
 		return
 	}
+	return newTLSHttpClient(cert), nil
+}
+
+func newTLSHttpClient(cert tls.Certificate) *http.Client {
 	tlsConfig := &tls.Config{
 		Certificates: []tls.Certificate{cert},
 	}
 
-	httpClient = &http.Client{
+	return &http.Client{
 		Transport: &http.Transport{
 			Proxy: http.ProxyFromEnvironment,
 			Dial: (&net.Dialer{
@@ -34,5 +38,13 @@ func NewTLSHttpClient(certFile, keyFile string) (httpClient *http.Client, err er
 		},
 		Timeout: 15 * time.Second,
 	}
-	return
+}
+
+// NewTLSHttpClientFromPEM 根据 PEM 编码的证书和私钥创建支持双向证书认证的 http.Client
+func NewTLSHttpClientFromPEM(certPEMBlock, keyPEMBlock []byte) (httpClient *http.Client, err error) {
+	cert, err := tls.X509KeyPair(certPEMBlock, keyPEMBlock)
+	if err != nil {
+		return
+	}
+	return newTLSHttpClient(cert), nil
 }
@@ -0,0 +1,77 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package pay
+
+import (
+	"strconv"
+	"time"
+)
+
+// 公众号内 WeixinJSBridge.invoke('getBrandWCPayRequest', ...) 所需的参数.
+type BridgePayRequest struct {
+	AppId     string `json:"appId"`
+	TimeStamp string `json:"timeStamp"`
+	NonceStr  string `json:"nonceStr"`
+	Package   string `json:"package"`
+	SignType  string `json:"signType"`
+	PaySign   string `json:"paySign"`
+}
+
+// JS-SDK wx.chooseWXPay 所需的参数.
+//  NOTE: 注意 timestamp 是全小写的, 和 WeixinJSBridge 的 timeStamp 不同.
+type ChooseWXPayRequest struct {
+	Timestamp string `json:"timestamp"`
+	NonceStr  string `json:"nonceStr"`
+	Package   string `json:"package"`
+	SignType  string `json:"signType"`
+	PaySign   string `json:"paySign"`
+}
+
+// 根据统一下单返回的 prepay_id 生成 WeixinJSBridge 发起支付的参数.
+//  appId:    公众号的 appid, 必须和统一下单时的 appid 一致
+//  prepayId: 统一下单返回的 prepay_id
+//  signType: SignTypeMD5 或 SignTypeHMACSHA256, 如果为空 "" 则默认为 SignTypeMD5, 必须和统一下单时的签名类型一致
+//  apiKey:   API密钥
+func NewBridgePayRequest(appId, prepayId, signType, apiKey string) *BridgePayRequest {
+	if signType == "" {
+		signType = SignTypeMD5
+	}
+
+	req := &BridgePayRequest{
+		AppId:     appId,
+		TimeStamp: strconv.FormatInt(time.Now().Unix(), 10),
+		NonceStr:  NonceStr(),
+		Package:   "prepay_id=" + prepayId,
+		SignType:  signType,
+	}
+	req.PaySign = JSAPIPaySign(req.AppId, req.TimeStamp, req.NonceStr, req.Package, req.SignType, apiKey)
+	return req
+}
+
+// 根据统一下单返回的 prepay_id 生成 wx.chooseWXPay 的参数, 参数同 NewBridgePayRequest.
+func NewChooseWXPayRequest(appId, prepayId, signType, apiKey string) *ChooseWXPayRequest {
+	req := NewBridgePayRequest(appId, prepayId, signType, apiKey)
+	return &ChooseWXPayRequest{
+		Timestamp: req.TimeStamp,
+		NonceStr:  req.NonceStr,
+		Package:   req.Package,
+		SignType:  req.SignType,
+		PaySign:   req.PaySign,
+	}
+}
+
+// 网页端发起支付的签名 paySign.
+//  参与签名的字段为 appId, timeStamp, nonceStr, package, signType.
+func JSAPIPaySign(appId, timeStamp, nonceStr, packageStr, signType, apiKey string) string {
+	m := make(map[string]string, 5)
+	m["appId"] = appId
+	m["timeStamp"] = timeStamp
+	m["nonceStr"] = nonceStr
+	m["package"] = packageStr
+	m["signType"] = signType
+
+	return SignWithType(m, apiKey, signType)
+}
@@ -5,8 +5,204 @@
 
 package pay
 
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/chanxuehong/util"
+)
+
 // 红包发放API.
 //  NOTE: 请求需要双向证书
 func (clt *Client) SendRedPack(req map[string]string) (resp map[string]string, err error) {
 	return clt.PostXML("https://api.mch.weixin.qq.com/mmpaymkttransfers/sendredpack", req)
 }
+
+// 发放裂变红包.
+//  NOTE: 请求需要双向证书; 应答没有签名, 所以不校验签名, 业务结果需要调用者判断 result_code.
+func (clt *Client) SendGroupRedPack(req map[string]string) (resp map[string]string, err error) {
+	respBody, err := clt.postXML("https://api.mch.weixin.qq.com/mmpaymkttransfers/sendgroupredpack", req)
+	if err != nil {
+		return
+	}
+	if resp, err = util.ParseXMLToMap(bytes.NewReader(respBody)); err != nil {
+		return
+	}
+
+	// 判断协议状态
+	ReturnCode, ok := resp["return_code"]
+	if !ok {
+		err = errors.New("no return_code parameter")
+		return
+	}
+	if ReturnCode != ReturnCodeSuccess {
+		err = &Error{
+			ReturnCode: ReturnCode,
+			ReturnMsg:  resp["return_msg"],
+		}
+		return
+	}
+	return
+}
+
+// 红包的领取信息
+type RedPackReceiver struct {
+	OpenId  string `xml:"openid"   json:"openid"`   // 领取红包的 openid
+	Amount  int    `xml:"amount"   json:"amount"`   // 领取金额, 单位分
+	RcvTime string `xml:"rcv_time" json:"rcv_time"` // 领取红包的时间
+}
+
+// 红包信息
+type RedPackInfo struct {
+	MchBillNo    string            `xml:"mch_billno"    json:"mch_billno"`              // 商户订单号
+	DetailId     string            `xml:"detail_id"     json:"detail_id"`               // 红包单号
+	Status       string            `xml:"status"        json:"status"`                  // 红包状态, SENDING, SENT, FAILED, RECEIVED, RFUND_ING, REFUND
+	SendType     string            `xml:"send_type"     json:"send_type"`               // 发放类型, API, UPLOAD, ACTIVITY
+	HBType       string            `xml:"hb_type"       json:"hb_type"`                 // 红包类型, GROUP: 裂变红包, NORMAL: 普通红包
+	TotalNum     int               `xml:"total_num"     json:"total_num"`               // 红包个数
+	TotalAmount  int               `xml:"total_amount"  json:"total_amount"`            // 红包总金额, 单位分
+	Reason       string            `xml:"reason"        json:"reason,omitempty"`        // 发送失败原因
+	SendTime     string            `xml:"send_time"     json:"send_time"`               // 红包发送时间
+	RefundTime   string            `xml:"refund_time"   json:"refund_time,omitempty"`   // 红包退款时间
+	RefundAmount int               `xml:"refund_amount" json:"refund_amount,omitempty"` // 红包退款金额
+	Wishing      string            `xml:"wishing"       json:"wishing"`                 // 祝福语
+	Remark       string            `xml:"remark"        json:"remark"`                  // 活动描述
+	ActName      string            `xml:"act_name"      json:"act_name"`                // 活动名称
+	HBList       []RedPackReceiver `xml:"hblist>hbinfo" json:"hblist,omitempty"`        // 裂变红包的领取列表
+}
+
+// 查询红包记录.
+//  NOTE: 请求需要双向证书
+func (clt *Client) GetHBInfo(req map[string]string) (info *RedPackInfo, err error) {
+	var result struct {
+		XMLName struct{} `xml:"xml"`
+		Error
+		BizError
+		RedPackInfo
+	}
+	if err = clt.postXMLUnmarshal("https://api.mch.weixin.qq.com/mmpaymkttransfers/gethbinfo", req, &result); err != nil {
+		return
+	}
+
+	if result.ReturnCode != ReturnCodeSuccess {
+		err = &result.Error
+		return
+	}
+	if result.ResultCode != ResultCodeSuccess {
+		err = &result.BizError
+		return
+	}
+	info = &result.RedPackInfo
+	return
+}
+
+// 企业付款的结果
+type TransferResult struct {
+	PartnerTradeNo string `xml:"partner_trade_no" json:"partner_trade_no"` // 商户订单号
+	PaymentNo      string `xml:"payment_no"       json:"payment_no"`       // 微信订单号
+	PaymentTime    string `xml:"payment_time"     json:"payment_time"`     // 微信支付成功时间
+}
+
+// 企业付款到零钱.
+//  NOTE: 请求需要双向证书, 请求参数用 mch_appid 和 mchid, 而不是 appid 和 mch_id.
+func (clt *Client) Transfers(req map[string]string) (rst *TransferResult, err error) {
+	var result struct {
+		XMLName struct{} `xml:"xml"`
+		Error
+		BizError
+		TransferResult
+	}
+	if err = clt.postXMLUnmarshal("https://api.mch.weixin.qq.com/mmpaymkttransfers/promotion/transfers", req, &result); err != nil {
+		return
+	}
+
+	if result.ReturnCode != ReturnCodeSuccess {
+		err = &result.Error
+		return
+	}
+	if result.ResultCode != ResultCodeSuccess {
+		err = &result.BizError
+		return
+	}
+	rst = &result.TransferResult
+	return
+}
+
+// 企业付款的信息
+type TransferInfo struct {
+	PartnerTradeNo string `xml:"partner_trade_no" json:"partner_trade_no"`
+	DetailId       string `xml:"detail_id"        json:"detail_id"`        // 付款单号
+	Status         string `xml:"status"           json:"status"`           // 转账状态, SUCCESS, FAILED, PROCESSING
+	Reason         string `xml:"reason"           json:"reason,omitempty"` // 失败原因
+	OpenId         string `xml:"openid"           json:"openid"`
+	TransferName   string `xml:"transfer_name"    json:"transfer_name,omitempty"` // 收款用户姓名
+	PaymentAmount  int    `xml:"payment_amount"   json:"payment_amount"`          // 付款金额, 单位分
+	TransferTime   string `xml:"transfer_time"    json:"transfer_time"`           // 转账时间
+	PaymentTime    string `xml:"payment_time"     json:"payment_time"`            // 付款成功时间
+	Desc           string `xml:"desc"             json:"desc"`                    // 付款备注
+}
+
+// 查询企业付款.
+//  NOTE: 请求需要双向证书
+func (clt *Client) GetTransferInfo(req map[string]string) (info *TransferInfo, err error) {
+	var result struct {
+		XMLName struct{} `xml:"xml"`
+		Error
+		BizError
+		TransferInfo
+	}
+	if err = clt.postXMLUnmarshal("https://api.mch.weixin.qq.com/mmpaymkttransfers/gettransferinfo", req, &result); err != nil {
+		return
+	}
+
+	if result.ReturnCode != ReturnCodeSuccess {
+		err = &result.Error
+		return
+	}
+	if result.ResultCode != ResultCodeSuccess {
+		err = &result.BizError
+		return
+	}
+	info = &result.TransferInfo
+	return
+}
+
+// 发送请求并把应答的 XML 解码到 v, 不校验应答的签名(这些接口的应答没有签名).
+func (clt *Client) postXMLUnmarshal(url string, req map[string]string, v interface{}) (err error) {
+	respBody, err := clt.postXML(url, req)
+	if err != nil {
+		return
+	}
+	return xml.Unmarshal(respBody, v)
+}
+
+// 发送请求, 返回应答的报文主体.
+func (clt *Client) postXML(url string, req map[string]string) (respBody []byte, err error) {
+	url = clt.requestURL(url)
+	clt.prepareRequest(req)
+
+	bodyBuf := textBufferPool.Get().(*bytes.Buffer)
+	bodyBuf.Reset()
+	defer textBufferPool.Put(bodyBuf)
+
+	if err = util.FormatMapToXML(bodyBuf, req); err != nil {
+		return
+	}
+
+	httpResp, err := clt.httpClientFor(url).Post(url, "text/xml; charset=utf-8", bodyBuf)
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
+	}
+
+	return ioutil.ReadAll(httpResp.Body)
+}
@@ -0,0 +1,23 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package pay
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// 生成随机字符串, 32 个字符, 用于请求参数 nonce_str.
+func NonceStr() string {
+	var nonce [16]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		// crypto/rand 失败的情况极少, 退化为时间戳
+		return strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+	return hex.EncodeToString(nonce[:])
+}
@@ -0,0 +1,246 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package pay
+
+import (
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/util"
+)
+
+// 回复微信支付的通知.
+//  returnCode: ReturnCodeSuccess 或 ReturnCodeFail
+//  returnMsg:  返回信息, 如非空则为错误原因
+func WriteNotifyResponse(w io.Writer, returnCode, returnMsg string) error {
+	return util.FormatMapToXML(w, map[string]string{
+		"return_code": returnCode,
+		"return_msg":  returnMsg,
+	})
+}
+
+// 支付结果通知的去重存储接口.
+//  微信支付会多次推送同一个通知, 而且可能同时推送, 需要保证同一个订单只处理一次.
+//  NOTE: 实现需要是并发安全的.
+type NotifyStore interface {
+	// 判断 key 对应的通知是否已经处理过
+	IsProcessed(key string) (bool, error)
+	// 原子地认领 key: 没有处理过并且没有其他请求正在处理时返回 true, 之后必须调用 SetProcessed 或者 Release.
+	//  多进程的实现可以用 redis 的 SET NX 等, 认领需要有过期时间, 避免进程退出以后一直处于处理中.
+	TryClaim(key string) (bool, error)
+	// 放弃认领, 处理失败时调用, 之后的通知可以重新认领
+	Release(key string) error
+	// 标记 key 对应的通知已经处理
+	SetProcessed(key string) error
+}
+
+const (
+	notifyClaimTimeout  = time.Minute // MemoryNotifyStore 认领的过期时间
+	notifySweepInterval = time.Minute // MemoryNotifyStore 清理过期 key 的最小间隔
+)
+
+var _ NotifyStore = (*MemoryNotifyStore)(nil)
+
+// 基于内存的 NotifyStore, 只适用于单进程的服务.
+type MemoryNotifyStore struct {
+	rwmutex   sync.RWMutex
+	expire    time.Duration
+	keys      map[string]time.Time // 已经处理的 key => 过期时间
+	claims    map[string]time.Time // 正在处理的 key => 认领的过期时间
+	lastSweep time.Time
+}
+
+// 创建一个 MemoryNotifyStore.
+//  expire: 已处理的 key 保存的时间, 如果 expire <= 0 则默认为 24 小时.
+func NewMemoryNotifyStore(expire time.Duration) *MemoryNotifyStore {
+	if expire <= 0 {
+		expire = 24 * time.Hour
+	}
+	return &MemoryNotifyStore{
+		expire: expire,
+		keys:   make(map[string]time.Time),
+		claims: make(map[string]time.Time),
+	}
+}
+
+func (store *MemoryNotifyStore) IsProcessed(key string) (bool, error) {
+	store.rwmutex.RLock()
+	expiresAt, ok := store.keys[key]
+	store.rwmutex.RUnlock()
+
+	return ok && time.Now().Before(expiresAt), nil
+}
+
+func (store *MemoryNotifyStore) TryClaim(key string) (bool, error) {
+	now := time.Now()
+
+	store.rwmutex.Lock()
+	defer store.rwmutex.Unlock()
+
+	store.sweep(now)
+	if expiresAt, ok := store.keys[key]; ok && now.Before(expiresAt) {
+		return false, nil
+	}
+	if expiresAt, ok := store.claims[key]; ok && now.Before(expiresAt) {
+		return false, nil
+	}
+	store.claims[key] = now.Add(notifyClaimTimeout)
+	return true, nil
+}
+
+func (store *MemoryNotifyStore) Release(key string) error {
+	store.rwmutex.Lock()
+	delete(store.claims, key)
+	store.rwmutex.Unlock()
+	return nil
+}
+
+func (store *MemoryNotifyStore) SetProcessed(key string) error {
+	now := time.Now()
+
+	store.rwmutex.Lock()
+	defer store.rwmutex.Unlock()
+
+	store.sweep(now)
+	delete(store.claims, key)
+	store.keys[key] = now.Add(store.expire)
+	return nil
+}
+
+// 清理过期的 key, 每 notifySweepInterval 最多清理一次, 避免每次调用都遍历所有的 key.
+//  NOTE: 调用者需要持有写锁.
+func (store *MemoryNotifyStore) sweep(now time.Time) {
+	if now.Sub(store.lastSweep) < notifySweepInterval {
+		return
+	}
+	store.lastSweep = now
+	for k, expiresAt := range store.keys {
+		if !now.Before(expiresAt) {
+			delete(store.keys, k)
+		}
+	}
+	for k, expiresAt := range store.claims {
+		if !now.Before(expiresAt) {
+			delete(store.claims, k)
+		}
+	}
+}
+
+var _ MessageHandler = (*PayNotifyHandler)(nil)
+
+// 支付结果通知的 MessageHandler.
+//  签名认证由 ServeHTTP 完成, PayNotifyHandler 负责去重, 回调以及回复微信支付.
+type PayNotifyHandler struct {
+	Store NotifyStore // 去重存储, 如果为 nil 则不去重
+
+	// 支付成功(result_code == SUCCESS)的回调, 返回 nil 表示处理成功
+	OnSuccess func(r *Request) error
+	// 支付失败(result_code != SUCCESS)的回调, 返回 nil 表示处理成功, 可以为 nil; 失败的通知不记录到 Store
+	OnFail func(r *Request) error
+	// Store 或者回调返回错误时的回调, 可以为 nil
+	OnError func(r *Request, err error)
+}
+
+// 通知的去重 key, 优先用 transaction_id, 其次用 out_trade_no.
+func notifyKey(msg map[string]string) string {
+	if key := msg["transaction_id"]; key != "" {
+		return key
+	}
+	return msg["out_trade_no"]
+}
+
+func (handler *PayNotifyHandler) ServeMessage(w http.ResponseWriter, r *Request) {
+	msg := r.Msg
+
+	// 通信失败的通知不是支付结果, 直接确认
+	if msg["return_code"] != ReturnCodeSuccess {
+		WriteNotifyResponse(w, ReturnCodeSuccess, "OK")
+		return
+	}
+
+	key := notifyKey(msg)
+	succeeded := msg["result_code"] == ResultCodeSuccess
+	claimed := false
+	if handler.Store != nil && key != "" {
+		if succeeded {
+			var err error
+			if claimed, err = handler.Store.TryClaim(key); err != nil {
+				handler.fail(w, r, err)
+				return
+			}
+		}
+		if !claimed {
+			processed, err := handler.Store.IsProcessed(key)
+			if err != nil {
+				handler.fail(w, r, err)
+				return
+			}
+			if processed {
+				WriteNotifyResponse(w, ReturnCodeSuccess, "OK")
+				return
+			}
+			if succeeded {
+				// 相同的通知正在被其他请求处理, 回复 FAIL 让微信支付稍后重新推送
+				WriteNotifyResponse(w, ReturnCodeFail, "processing")
+				return
+			}
+		}
+	}
+
+	var err error
+	if succeeded {
+		if handler.OnSuccess != nil {
+			err = handler.OnSuccess(r)
+		}
+	} else {
+		if handler.OnFail != nil {
+			err = handler.OnFail(r)
+		}
+	}
+	if err != nil {
+		if claimed {
+			handler.Store.Release(key)
+		}
+		handler.fail(w, r, err)
+		return
+	}
+
+	// 只记录支付成功的通知, 同一个订单失败之后还可能再推送成功的通知
+	if claimed {
+		if err = handler.Store.SetProcessed(key); err != nil {
+			// 已经处理成功了, 只报告错误, 重复的通知由回调自己保证幂等
+			if handler.OnError != nil {
+				handler.OnError(r, err)
+			}
+		}
+	}
+	WriteNotifyResponse(w, ReturnCodeSuccess, "OK")
+}
+
+func (handler *PayNotifyHandler) fail(w http.ResponseWriter, r *Request, err error) {
+	if handler.OnError != nil {
+		handler.OnError(r, err)
+	}
+	WriteNotifyResponse(w, ReturnCodeFail, err.Error())
+}
+
+// 回复 FAIL 的 InvalidRequestHandler, 微信支付收到 FAIL 后会重新推送通知.
+var NotifyFailInvalidRequestHandler = InvalidRequestHandlerFunc(func(w http.ResponseWriter, r *http.Request, err error) {
+	WriteNotifyResponse(w, ReturnCodeFail, err.Error())
+})
+
+// 创建处理支付结果通知的 http.Handler.
+//  解析通知的 XML, 校验 appid, mch_id 和签名, 然后交给 handler 处理;
+//  请求无效时回复 FAIL.
+func NewPayNotifyHTTPHandler(appId, mchId, apiKey string, handler *PayNotifyHandler) http.Handler {
+	if handler == nil {
+		panic("pay: nil PayNotifyHandler")
+	}
+	server := NewDefaultMessageServer(appId, mchId, apiKey, handler)
+	return NewMessageServerFrontend(server, NotifyFailInvalidRequestHandler)
+}
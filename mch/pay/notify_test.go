@@ -0,0 +1,197 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package pay
+
+import (
+	"errors"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPayNotifyHandler(t *testing.T) {
+	newMsg := func(resultCode string) map[string]string {
+		return map[string]string{
+			"return_code":    ReturnCodeSuccess,
+			"result_code":    resultCode,
+			"transaction_id": "4200000001",
+			"out_trade_no":   "order-1",
+		}
+	}
+
+	tests := []struct {
+		name        string
+		msgs        []map[string]string
+		successErr  error
+		wantSuccess int
+		wantFail    int
+		wantErrors  int // OnError 的调用次数, 出错时回复 FAIL
+	}{
+		{
+			name:        "重复的成功通知只处理一次",
+			msgs:        []map[string]string{newMsg(ResultCodeSuccess), newMsg(ResultCodeSuccess)},
+			wantSuccess: 1,
+		},
+		{
+			name:        "失败之后的成功通知还要处理",
+			msgs:        []map[string]string{newMsg(ResultCodeFail), newMsg(ResultCodeSuccess)},
+			wantSuccess: 1,
+			wantFail:    1,
+		},
+		{
+			name:     "重复的失败通知都要处理",
+			msgs:     []map[string]string{newMsg(ResultCodeFail), newMsg(ResultCodeFail)},
+			wantFail: 2,
+		},
+		{
+			name:        "回调出错时不记录, 下次重新处理",
+			msgs:        []map[string]string{newMsg(ResultCodeSuccess), newMsg(ResultCodeSuccess)},
+			successErr:  errors.New("db error"),
+			wantSuccess: 2,
+			wantErrors:  2,
+		},
+		{
+			name: "通信失败的通知直接确认",
+			msgs: []map[string]string{{"return_code": ReturnCodeFail}},
+		},
+	}
+
+	for _, tt := range tests {
+		var successCount, failCount, errorCount int
+		handler := &PayNotifyHandler{
+			Store: NewMemoryNotifyStore(time.Hour),
+			OnSuccess: func(r *Request) error {
+				successCount++
+				return tt.successErr
+			},
+			OnFail: func(r *Request) error {
+				failCount++
+				return nil
+			},
+			OnError: func(r *Request, err error) {
+				errorCount++
+			},
+		}
+
+		for _, msg := range tt.msgs {
+			handler.ServeMessage(httptest.NewRecorder(), &Request{Msg: msg})
+		}
+		if successCount != tt.wantSuccess || failCount != tt.wantFail {
+			t.Errorf("%s: OnSuccess %d, OnFail %d; want %d, %d", tt.name, successCount, failCount, tt.wantSuccess, tt.wantFail)
+		}
+		if errorCount != tt.wantErrors {
+			t.Errorf("%s: OnError %d, want %d", tt.name, errorCount, tt.wantErrors)
+		}
+	}
+}
+
+func TestMemoryNotifyStore(t *testing.T) {
+	store := NewMemoryNotifyStore(50 * time.Millisecond)
+
+	if processed, _ := store.IsProcessed("a"); processed {
+		t.Error("没有处理过的 key 不应该是已处理")
+		return
+	}
+	if err := store.SetProcessed("a"); err != nil {
+		t.Error(err)
+		return
+	}
+	if processed, _ := store.IsProcessed("a"); !processed {
+		t.Error("SetProcessed 之后应该是已处理")
+		return
+	}
+	time.Sleep(60 * time.Millisecond)
+	if processed, _ := store.IsProcessed("a"); processed {
+		t.Error("过期之后不应该是已处理")
+		return
+	}
+	store.SetProcessed("b")
+	if _, ok := store.keys["a"]; !ok {
+		t.Error("距离上次清理不到 notifySweepInterval 时不应该清理")
+	}
+	store.lastSweep = time.Now().Add(-notifySweepInterval)
+	store.SetProcessed("b")
+	if _, ok := store.keys["a"]; ok {
+		t.Error("SetProcessed 应该清理过期的 key")
+	}
+}
+
+func TestMemoryNotifyStoreTryClaim(t *testing.T) {
+	store := NewMemoryNotifyStore(time.Hour)
+
+	tests := []struct {
+		name string
+		op   func() (bool, error)
+		want bool
+	}{
+		{"第一次认领", func() (bool, error) { return store.TryClaim("a") }, true},
+		{"正在处理时不能认领", func() (bool, error) { return store.TryClaim("a") }, false},
+		{"Release 以后可以重新认领", func() (bool, error) { store.Release("a"); return store.TryClaim("a") }, true},
+		{"处理完成以后不能认领", func() (bool, error) { store.SetProcessed("a"); return store.TryClaim("a") }, false},
+		{"认领过期以后可以重新认领", func() (bool, error) {
+			store.TryClaim("b")
+			store.claims["b"] = time.Now().Add(-time.Second)
+			return store.TryClaim("b")
+		}, true},
+	}
+	for _, tt := range tests {
+		have, err := tt.op()
+		if err != nil || have != tt.want {
+			t.Errorf("%s: have %v, %v; want %v", tt.name, have, err, tt.want)
+		}
+	}
+}
+
+func TestPayNotifyHandlerConcurrent(t *testing.T) {
+	var (
+		mutex        sync.Mutex
+		successCount int
+		started      = make(chan struct{})
+		release      = make(chan struct{})
+	)
+	handler := &PayNotifyHandler{
+		Store: NewMemoryNotifyStore(time.Hour),
+		OnSuccess: func(r *Request) error {
+			mutex.Lock()
+			successCount++
+			mutex.Unlock()
+			close(started)
+			<-release
+			return nil
+		},
+	}
+	msg := map[string]string{
+		"return_code":    ReturnCodeSuccess,
+		"result_code":    ResultCodeSuccess,
+		"transaction_id": "4200000001",
+	}
+
+	done := make(chan struct{})
+	go func() {
+		handler.ServeMessage(httptest.NewRecorder(), &Request{Msg: msg})
+		close(done)
+	}()
+	<-started
+
+	// 第一个通知还在处理中, 重复的通知不能再调用 OnSuccess
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			handler.ServeMessage(httptest.NewRecorder(), &Request{Msg: msg})
+		}()
+	}
+	wg.Wait()
+	close(release)
+	<-done
+
+	handler.ServeMessage(httptest.NewRecorder(), &Request{Msg: msg})
+	if successCount != 1 {
+		t.Errorf("OnSuccess 调用了 %d 次, want 1", successCount)
+	}
+}
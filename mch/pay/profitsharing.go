@@ -0,0 +1,58 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package pay
+
+// 分账接口的签名类型只支持 HMAC-SHA256, 如果没有设置 sign_type 则默认为 HMAC-SHA256.
+func (clt *Client) postProfitSharing(url string, req map[string]string) (resp map[string]string, err error) {
+	if req != nil && req["sign_type"] == "" {
+		req["sign_type"] = SignTypeHMACSHA256
+	}
+	return clt.PostXML(url, req)
+}
+
+// 添加分账接收方.
+func (clt *Client) ProfitSharingAddReceiver(req map[string]string) (resp map[string]string, err error) {
+	return clt.postProfitSharing("https://api.mch.weixin.qq.com/pay/profitsharingaddreceiver", req)
+}
+
+// 删除分账接收方.
+func (clt *Client) ProfitSharingRemoveReceiver(req map[string]string) (resp map[string]string, err error) {
+	return clt.postProfitSharing("https://api.mch.weixin.qq.com/pay/profitsharingremovereceiver", req)
+}
+
+// 请求单次分账.
+//  NOTE: 请求需要双向证书.
+func (clt *Client) ProfitSharing(req map[string]string) (resp map[string]string, err error) {
+	return clt.postProfitSharing("https://api.mch.weixin.qq.com/secapi/pay/profitsharing", req)
+}
+
+// 请求多次分账.
+//  NOTE: 请求需要双向证书.
+func (clt *Client) MultiProfitSharing(req map[string]string) (resp map[string]string, err error) {
+	return clt.postProfitSharing("https://api.mch.weixin.qq.com/secapi/pay/multiprofitsharing", req)
+}
+
+// 查询分账结果.
+func (clt *Client) ProfitSharingQuery(req map[string]string) (resp map[string]string, err error) {
+	return clt.postProfitSharing("https://api.mch.weixin.qq.com/pay/profitsharingquery", req)
+}
+
+// 完结分账.
+//  NOTE: 请求需要双向证书.
+func (clt *Client) ProfitSharingFinish(req map[string]string) (resp map[string]string, err error) {
+	return clt.postProfitSharing("https://api.mch.weixin.qq.com/secapi/pay/profitsharingfinish", req)
+}
+
+// 分账回退.
+//  NOTE: 请求需要双向证书.
+func (clt *Client) ProfitSharingReturn(req map[string]string) (resp map[string]string, err error) {
+	return clt.postProfitSharing("https://api.mch.weixin.qq.com/secapi/pay/profitsharingreturn", req)
+}
+
+// 回退结果查询.
+func (clt *Client) ProfitSharingReturnQuery(req map[string]string) (resp map[string]string, err error) {
+	return clt.postProfitSharing("https://api.mch.weixin.qq.com/pay/profitsharingreturnquery", req)
+}
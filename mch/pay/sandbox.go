@@ -0,0 +1,77 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package pay
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/chanxuehong/util"
+)
+
+// 获取仿真测试系统的签名密钥.
+//  mchId:  商户号
+//  apiKey: 商户平台设置的API密钥
+//  如果 httpClient == nil 则默认用 http.DefaultClient.
+func GetSandboxSignKey(mchId, apiKey string, httpClient *http.Client) (sandboxSignKey string, err error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	req := map[string]string{
+		"mch_id":    mchId,
+		"nonce_str": NonceStr(),
+	}
+	req["sign"] = Sign(req, apiKey, nil)
+
+	bodyBuf := textBufferPool.Get().(*bytes.Buffer)
+	bodyBuf.Reset()
+	defer textBufferPool.Put(bodyBuf)
+
+	if err = util.FormatMapToXML(bodyBuf, req); err != nil {
+		return
+	}
+
+	url := sandboxAPIURLPrefix + "pay/getsignkey"
+	httpResp, err := httpClient.Post(url, "text/xml; charset=utf-8", bodyBuf)
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
+	}
+
+	resp, err := util.ParseXMLToMap(httpResp.Body)
+	if err != nil {
+		return
+	}
+
+	// 判断协议状态
+	ReturnCode, ok := resp["return_code"]
+	if !ok {
+		err = errors.New("no return_code parameter")
+		return
+	}
+	if ReturnCode != ReturnCodeSuccess {
+		err = &Error{
+			ReturnCode: ReturnCode,
+			ReturnMsg:  resp["return_msg"],
+		}
+		return
+	}
+
+	sandboxSignKey = resp["sandbox_signkey"]
+	if sandboxSignKey == "" {
+		err = errors.New("no sandbox_signkey parameter")
+		return
+	}
+	return
+}
@@ -69,7 +69,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, urlValues url.Values,
 				invalidRequestHandler.ServeInvalidRequest(w, r, err)
 				return
 			}
-			signature2 := Sign(msg, messageServer.APIKey(), nil)
+			signature2 := SignWithType(msg, messageServer.APIKey(), msg["sign_type"])
 			if len(signature1) != len(signature2) {
 				err = fmt.Errorf("check signature failed, \r\ninput: %q, \r\nlocal: %q", signature1, signature2)
 				invalidRequestHandler.ServeInvalidRequest(w, r, err)
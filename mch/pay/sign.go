@@ -7,7 +7,9 @@ package pay
 
 import (
 	"bytes"
+	"crypto/hmac"
 	"crypto/md5"
+	"crypto/sha256"
 	"encoding/hex"
 	"hash"
 	"sort"
@@ -49,3 +51,19 @@ func Sign(parameters map[string]string, apiKey string, fn func() hash.Hash) stri
 	hex.Encode(signature, h.Sum(nil))
 	return string(bytes.ToUpper(signature))
 }
+
+// 根据签名类型对参数签名.
+//  signType: SignTypeMD5 或 SignTypeHMACSHA256, 如果为空 "" 则默认为 SignTypeMD5
+func SignWithType(parameters map[string]string, apiKey, signType string) string {
+	if signType == SignTypeHMACSHA256 {
+		return SignHMACSHA256(parameters, apiKey)
+	}
+	return Sign(parameters, apiKey, nil)
+}
+
+// HMAC-SHA256 签名, 以API密钥作为 HMAC 的 key.
+func SignHMACSHA256(parameters map[string]string, apiKey string) string {
+	return Sign(parameters, apiKey, func() hash.Hash {
+		return hmac.New(sha256.New, []byte(apiKey))
+	})
+}
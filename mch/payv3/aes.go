@@ -0,0 +1,61 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"errors"
+)
+
+// 加密的资源数据, 用于回调通知和平台证书下载.
+type EncryptedResource struct {
+	Algorithm      string `json:"algorithm"`                 // 加密算法, 目前只支持 AEAD_AES_256_GCM
+	Ciphertext     string `json:"ciphertext"`                // base64 编码的密文
+	AssociatedData string `json:"associated_data,omitempty"` // 附加数据
+	Nonce          string `json:"nonce"`                     // 加密使用的随机串
+	OriginalType   string `json:"original_type,omitempty"`   // 原始回调类型
+}
+
+// 用 APIv3 密钥解密 AEAD_AES_256_GCM 加密的资源数据.
+func DecryptResource(resource *EncryptedResource, apiV3Key string) (plaintext []byte, err error) {
+	if resource == nil {
+		err = errors.New("nil EncryptedResource")
+		return
+	}
+	if resource.Algorithm != "" && resource.Algorithm != "AEAD_AES_256_GCM" {
+		err = errors.New("unsupported algorithm: " + resource.Algorithm)
+		return
+	}
+	return DecryptAES256GCM(apiV3Key, resource.Nonce, resource.AssociatedData, resource.Ciphertext)
+}
+
+// AEAD_AES_256_GCM 解密.
+//  apiV3Key:       APIv3 密钥, 32 字节
+//  nonce:          随机串
+//  associatedData: 附加数据, 可以为空
+//  ciphertext:     base64 编码的密文
+func DecryptAES256GCM(apiV3Key, nonce, associatedData, ciphertext string) (plaintext []byte, err error) {
+	if len(apiV3Key) != 32 {
+		err = errors.New("the length of apiV3Key must be 32")
+		return
+	}
+	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	if err != nil {
+		return
+	}
+
+	block, err := aes.NewCipher([]byte(apiV3Key))
+	if err != nil {
+		return
+	}
+	aead, err := cipher.NewGCMWithNonceSize(block, len(nonce))
+	if err != nil {
+		return
+	}
+	return aead.Open(nil, []byte(nonce), data, []byte(associatedData))
+}
@@ -0,0 +1,90 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"encoding/base64"
+	"testing"
+)
+
+const testAPIv3Key = "0123456789abcdef0123456789abcdef"
+
+// 用 AEAD_AES_256_GCM 加密, 模拟微信支付加密回调通知.
+func testEncryptAES256GCM(t *testing.T, apiV3Key, nonce, associatedData, plaintext string) string {
+	block, err := aes.NewCipher([]byte(apiV3Key))
+	if err != nil {
+		t.Fatal(err)
+	}
+	aead, err := cipher.NewGCMWithNonceSize(block, len(nonce))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return base64.StdEncoding.EncodeToString(aead.Seal(nil, []byte(nonce), []byte(plaintext), []byte(associatedData)))
+}
+
+func TestDecryptAES256GCM(t *testing.T) {
+	const (
+		nonce          = "fdasflkja484"
+		associatedData = "transaction"
+		plaintext      = `{"out_trade_no":"1217752501201407033233368018"}`
+	)
+	ciphertext := testEncryptAES256GCM(t, testAPIv3Key, nonce, associatedData, plaintext)
+
+	tests := []struct {
+		name           string
+		apiV3Key       string
+		nonce          string
+		associatedData string
+		ciphertext     string
+		wantErr        bool
+	}{
+		{"正确的密文", testAPIv3Key, nonce, associatedData, ciphertext, false},
+		{"错误的密钥", "fedcba9876543210fedcba9876543210", nonce, associatedData, ciphertext, true},
+		{"密钥长度不对", testAPIv3Key[:16], nonce, associatedData, ciphertext, true},
+		{"错误的随机串", testAPIv3Key, "fdasflkja485", associatedData, ciphertext, true},
+		{"错误的附加数据", testAPIv3Key, nonce, "certificate", ciphertext, true},
+		{"密文不是 base64", testAPIv3Key, nonce, associatedData, "!!!", true},
+		{"密文被截断", testAPIv3Key, nonce, associatedData, ciphertext[:8], true},
+	}
+	for _, tt := range tests {
+		have, err := DecryptAES256GCM(tt.apiV3Key, tt.nonce, tt.associatedData, tt.ciphertext)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: want error", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if string(have) != plaintext {
+			t.Errorf("%s: have %s, want %s", tt.name, have, plaintext)
+		}
+	}
+}
+
+func TestDecryptResource(t *testing.T) {
+	resource := &EncryptedResource{
+		Algorithm:      "AEAD_AES_256_GCM",
+		Ciphertext:     testEncryptAES256GCM(t, testAPIv3Key, "nonce1234567", "", "hello"),
+		AssociatedData: "",
+		Nonce:          "nonce1234567",
+	}
+	if have, err := DecryptResource(resource, testAPIv3Key); err != nil || string(have) != "hello" {
+		t.Errorf("DecryptResource: have %q, %v", have, err)
+	}
+
+	resource.Algorithm = "AEAD_SM4_GCM"
+	if _, err := DecryptResource(resource, testAPIv3Key); err == nil {
+		t.Error("不支持的算法应该返回错误")
+	}
+	if _, err := DecryptResource(nil, testAPIv3Key); err == nil {
+		t.Error("nil EncryptedResource 应该返回错误")
+	}
+}
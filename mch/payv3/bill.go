@@ -0,0 +1,160 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"bufio"
+	"compress/gzip"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// 申请账单返回的下载信息
+type BillDownloadInfo struct {
+	HashType    string `json:"hash_type"`    // 原始账单(gzip需要解压缩)的摘要算法, 比如 SHA1
+	HashValue   string `json:"hash_value"`   // 原始账单(gzip需要解压缩)的摘要值
+	DownloadURL string `json:"download_url"` // 账单下载地址, 30 秒内有效
+}
+
+// 申请交易账单.
+//  billDate: 账单日期, 格式 YYYY-MM-DD
+//  billType: 账单类型, ALL, SUCCESS, REFUND, 如果为空 "" 则默认为 ALL
+//  tarType:  压缩类型, GZIP, 如果为空 "" 则不压缩
+func (clt *Client) TradeBill(billDate, billType, tarType string) (info *BillDownloadInfo, err error) {
+	if billDate == "" {
+		err = errors.New("empty billDate")
+		return
+	}
+
+	query := url.Values{}
+	query.Set("bill_date", billDate)
+	if billType != "" {
+		query.Set("bill_type", billType)
+	}
+	if tarType != "" {
+		query.Set("tar_type", tarType)
+	}
+
+	info = &BillDownloadInfo{}
+	if err = clt.Do("GET", "/v3/bill/tradebill?"+query.Encode(), nil, info); err != nil {
+		info = nil
+		return
+	}
+	return
+}
+
+// 申请资金账单.
+//  billDate:    账单日期, 格式 YYYY-MM-DD
+//  accountType: 资金账户类型, BASIC, OPERATION, FEES, 如果为空 "" 则默认为 BASIC
+//  tarType:     压缩类型, GZIP, 如果为空 "" 则不压缩
+func (clt *Client) FundFlowBill(billDate, accountType, tarType string) (info *BillDownloadInfo, err error) {
+	if billDate == "" {
+		err = errors.New("empty billDate")
+		return
+	}
+
+	query := url.Values{}
+	query.Set("bill_date", billDate)
+	if accountType != "" {
+		query.Set("account_type", accountType)
+	}
+	if tarType != "" {
+		query.Set("tar_type", tarType)
+	}
+
+	info = &BillDownloadInfo{}
+	if err = clt.Do("GET", "/v3/bill/fundflowbill?"+query.Encode(), nil, info); err != nil {
+		info = nil
+		return
+	}
+	return
+}
+
+// 下载账单, 返回账单内容的 io.ReadCloser, GZIP 压缩的账单会自动解压.
+//  读取到末尾时会校验账单的摘要, 摘要不一致 Read 会返回错误;
+//  返回的内容可以用 pay.NewBillReader 流式解析.
+//  NOTE: 调用者使用完毕后需要关闭返回的 io.ReadCloser.
+func (clt *Client) DownloadBill(info *BillDownloadInfo) (body io.ReadCloser, err error) {
+	if info == nil {
+		err = errors.New("nil BillDownloadInfo")
+		return
+	}
+
+	httpReq, err := http.NewRequest("GET", info.DownloadURL, nil)
+	if err != nil {
+		return
+	}
+	if err = clt.setHeader(httpReq, nil); err != nil {
+		return
+	}
+
+	httpResp, err := clt.httpClient.Do(httpReq)
+	if err != nil {
+		return
+	}
+	if httpResp.StatusCode != http.StatusOK {
+		httpResp.Body.Close()
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
+	}
+
+	var reader io.Reader = bufio.NewReader(httpResp.Body)
+	if head, _ := reader.(*bufio.Reader).Peek(2); len(head) == 2 && head[0] == 0x1f && head[1] == 0x8b {
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			httpResp.Body.Close()
+			return nil, err
+		}
+		reader = gzipReader
+	}
+
+	var h hash.Hash
+	switch strings.ToUpper(info.HashType) {
+	case "SHA1":
+		h = sha1.New()
+	case "SHA256":
+		h = sha256.New()
+	}
+	if h == nil || info.HashValue == "" {
+		return &billReadCloser{reader: reader, closer: httpResp.Body}, nil
+	}
+	return &billReadCloser{reader: reader, closer: httpResp.Body, hash: h, hashValue: info.HashValue}, nil
+}
+
+// 读取账单内容的同时计算摘要, 读取到末尾时校验摘要.
+type billReadCloser struct {
+	reader    io.Reader
+	closer    io.Closer
+	hash      hash.Hash
+	hashValue string
+}
+
+func (rc *billReadCloser) Read(p []byte) (n int, err error) {
+	n, err = rc.reader.Read(p)
+	if rc.hash == nil {
+		return
+	}
+	rc.hash.Write(p[:n])
+	if err == io.EOF {
+		if have := hex.EncodeToString(rc.hash.Sum(nil)); !strings.EqualFold(have, rc.hashValue) {
+			err = fmt.Errorf("bill hash mismatch, have: %s, want: %s", have, rc.hashValue)
+		}
+		rc.hash = nil
+	}
+	return
+}
+
+func (rc *billReadCloser) Close() error {
+	return rc.closer.Close()
+}
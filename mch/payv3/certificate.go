@@ -0,0 +1,117 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// 平台证书的更新间隔, 微信支付会提前 24 小时启用新证书.
+const certificatesRefreshInterval = 12 * time.Hour
+
+// 平台证书信息
+type certificateInfo struct {
+	SerialNo           string            `json:"serial_no"`
+	EffectiveTime      string            `json:"effective_time"`
+	ExpireTime         string            `json:"expire_time"`
+	EncryptCertificate EncryptedResource `json:"encrypt_certificate"`
+}
+
+// 获取当前可用的平台证书, serial_no => 证书.
+//  如果证书过期会自动下载新的证书.
+func (clt *Client) PlatformCertificates() (certs map[string]*x509.Certificate, err error) {
+	clt.certMutex.Lock()
+	defer clt.certMutex.Unlock()
+
+	if clt.certs == nil || time.Since(clt.certsUpdateAt) > certificatesRefreshInterval {
+		if err = clt.refreshCertificates(); err != nil {
+			return
+		}
+	}
+	certs = make(map[string]*x509.Certificate, len(clt.certs))
+	for serialNo, cert := range clt.certs {
+		certs[serialNo] = cert
+	}
+	return
+}
+
+// 获取序列号为 serialNo 的平台证书.
+//  如果本地没有该证书或者证书已经需要更新, 则重新下载平台证书.
+func (clt *Client) platformCertificate(serialNo string) (cert *x509.Certificate, err error) {
+	clt.certMutex.Lock()
+	defer clt.certMutex.Unlock()
+
+	cert = clt.certs[serialNo]
+	if cert != nil && time.Since(clt.certsUpdateAt) <= certificatesRefreshInterval {
+		return
+	}
+	if err = clt.refreshCertificates(); err != nil {
+		return
+	}
+	if cert = clt.certs[serialNo]; cert == nil {
+		err = fmt.Errorf("not found platform certificate with serial_no: %s", serialNo)
+		return
+	}
+	return
+}
+
+// 下载平台证书, 调用者需要持有 clt.certMutex.
+func (clt *Client) refreshCertificates() (err error) {
+	header, statusCode, respBody, err := clt.doRequest("GET", "/v3/certificates", nil, nil)
+	if err != nil {
+		return
+	}
+	if statusCode < 200 || statusCode >= 300 {
+		result := &Error{StatusCode: statusCode}
+		json.Unmarshal(respBody, result)
+		return result
+	}
+
+	var result struct {
+		Data []certificateInfo `json:"data"`
+	}
+	if err = json.Unmarshal(respBody, &result); err != nil {
+		return
+	}
+
+	certs := make(map[string]*x509.Certificate, len(result.Data))
+	for i := range result.Data {
+		info := &result.Data[i]
+
+		certPEM, err := DecryptResource(&info.EncryptCertificate, clt.apiV3Key)
+		if err != nil {
+			return err
+		}
+		block, _ := pem.Decode(certPEM)
+		if block == nil {
+			return errors.New("invalid platform certificate pem")
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			return err
+		}
+		certs[info.SerialNo] = cert
+	}
+
+	// 下载证书的应答也需要用平台证书验签, 这里用新下载的证书校验
+	cert := certs[header.Get("Wechatpay-Serial")]
+	if cert == nil {
+		return errors.New("the certificates response is not signed by any downloaded platform certificate")
+	}
+	if err = verifyWithCertificate(cert, header.Get("Wechatpay-Timestamp"), header.Get("Wechatpay-Nonce"),
+		header.Get("Wechatpay-Signature"), respBody); err != nil {
+		return
+	}
+
+	clt.certs = certs
+	clt.certsUpdateAt = time.Now()
+	return
+}
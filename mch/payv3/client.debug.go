@@ -0,0 +1,54 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// +build wechatdebug
+
+package payv3
+
+import (
+	"bytes"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"runtime"
+)
+
+// 发送 http 请求, 返回应答的 http.Header, http 状态码和报文主体.
+//  header 为额外的请求头, 可以为 nil.
+func (clt *Client) doRequest(method, path string, body []byte, header http.Header) (respHeader http.Header, statusCode int, respBody []byte, err error) {
+	debugPrefix := "payv3.Client.doRequest"
+	if _, file, line, ok := runtime.Caller(2); ok {
+		debugPrefix += fmt.Sprintf("(called at %s:%d)", file, line)
+	}
+	fmt.Println(debugPrefix, "request url:", method, apiURLPrefix+path)
+	fmt.Println(debugPrefix, "request body:", string(body))
+
+	httpReq, err := http.NewRequest(method, apiURLPrefix+path, bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	if err = clt.setHeader(httpReq, body); err != nil {
+		return
+	}
+	for k, vs := range header {
+		httpReq.Header[k] = vs
+	}
+
+	httpResp, err := clt.httpClient.Do(httpReq)
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if respBody, err = ioutil.ReadAll(httpResp.Body); err != nil {
+		return
+	}
+	fmt.Println(debugPrefix, "response status:", httpResp.Status)
+	fmt.Println(debugPrefix, "response body:", string(respBody))
+
+	respHeader = httpResp.Header
+	statusCode = httpResp.StatusCode
+	return
+}
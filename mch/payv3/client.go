@@ -0,0 +1,42 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// +build !wechatdebug
+
+package payv3
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+)
+
+// 发送 http 请求, 返回应答的 http.Header, http 状态码和报文主体.
+//  header 为额外的请求头, 可以为 nil.
+func (clt *Client) doRequest(method, path string, body []byte, header http.Header) (respHeader http.Header, statusCode int, respBody []byte, err error) {
+	httpReq, err := http.NewRequest(method, apiURLPrefix+path, bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	if err = clt.setHeader(httpReq, body); err != nil {
+		return
+	}
+	for k, vs := range header {
+		httpReq.Header[k] = vs
+	}
+
+	httpResp, err := clt.httpClient.Do(httpReq)
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if respBody, err = ioutil.ReadAll(httpResp.Body); err != nil {
+		return
+	}
+	respHeader = httpResp.Header
+	statusCode = httpResp.StatusCode
+	return
+}
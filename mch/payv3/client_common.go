@@ -0,0 +1,178 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"bytes"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	wechatjson "github.com/chanxuehong/wechat/json"
+)
+
+const apiURLPrefix = "https://api.mch.weixin.qq.com"
+
+type Client struct {
+	mchId      string          // 商户号
+	serialNo   string          // 商户API证书序列号
+	privateKey *rsa.PrivateKey // 商户API私钥
+	apiV3Key   string          // APIv3 密钥
+	httpClient *http.Client
+
+	// 平台证书, 按需下载和更新
+	certMutex     sync.Mutex
+	certs         map[string]*x509.Certificate // serial_no => 证书
+	certsUpdateAt time.Time
+}
+
+// 创建一个新的 Client.
+//  mchId:      商户号
+//  serialNo:   商户API证书的序列号
+//  privateKey: 商户API证书的私钥, 可以通过 LoadPrivateKey 获取
+//  apiV3Key:   APIv3 密钥, 用于解密回调通知和平台证书
+//  如果 httpClient == nil 则默认用 http.DefaultClient.
+func NewClient(mchId, serialNo string, privateKey *rsa.PrivateKey, apiV3Key string, httpClient *http.Client) *Client {
+	if privateKey == nil {
+		panic("payv3: nil privateKey")
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &Client{
+		mchId:      mchId,
+		serialNo:   serialNo,
+		privateKey: privateKey,
+		apiV3Key:   apiV3Key,
+		httpClient: httpClient,
+	}
+}
+
+func (clt *Client) MchId() string {
+	return clt.mchId
+}
+
+// 设置请求头, 包括签名认证信息 Authorization.
+func (clt *Client) setHeader(httpReq *http.Request, body []byte) (err error) {
+	nonce, err := nonceStr()
+	if err != nil {
+		return
+	}
+	timestamp := time.Now().Unix()
+
+	signature, err := signSHA256WithRSA(requestMessage(httpReq.Method, httpReq.URL.RequestURI(), timestamp, nonce, body), clt.privateKey)
+	if err != nil {
+		return
+	}
+
+	httpReq.Header.Set("Authorization", fmt.Sprintf(`%s mchid="%s",nonce_str="%s",signature="%s",timestamp="%d",serial_no="%s"`,
+		authorizationSchema, clt.mchId, nonce, signature, timestamp, clt.serialNo))
+	httpReq.Header.Set("Accept", "application/json")
+	if len(body) > 0 {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+	return
+}
+
+// APIv3 通用请求方法.
+//  method:   http 方法, 比如 "GET", "POST"
+//  path:     请求路径, 包括查询参数, 比如 "/v3/pay/transactions/jsapi"
+//  request:  请求参数, 会被编码为 JSON, 如果为 nil 则请求没有报文主体
+//  response: 应答的 JSON 解码对象, 如果为 nil 则忽略应答的报文主体
+//  应答的签名会用平台证书校验; http 状态码非 2xx 时返回 *Error.
+func (clt *Client) Do(method, path string, request, response interface{}) (err error) {
+	return clt.do(method, path, request, response, nil)
+}
+
+// 请求参数包含用平台证书加密的敏感信息时, 需要在请求头 Wechatpay-Serial 中指明平台证书的序列号.
+func (clt *Client) doWithSerial(method, path string, request, response interface{}, serialNo string) (err error) {
+	header := make(http.Header, 1)
+	header.Set("Wechatpay-Serial", serialNo)
+	return clt.do(method, path, request, response, header)
+}
+
+func (clt *Client) do(method, path string, request, response interface{}, extraHeader http.Header) (err error) {
+	var body []byte
+	if request != nil {
+		buf := bytes.NewBuffer(make([]byte, 0, 1024))
+		if err = wechatjson.NewEncoder(buf).Encode(request); err != nil {
+			return
+		}
+		body = bytes.TrimRight(buf.Bytes(), "\n")
+	}
+
+	header, statusCode, respBody, err := clt.doRequest(method, path, body, extraHeader)
+	if err != nil {
+		return
+	}
+
+	if statusCode < 200 || statusCode >= 300 {
+		result := &Error{StatusCode: statusCode}
+		if len(respBody) > 0 {
+			json.Unmarshal(respBody, result)
+		}
+		return result
+	}
+
+	if err = clt.verify(header, respBody); err != nil {
+		return
+	}
+	if response == nil || len(respBody) == 0 {
+		return
+	}
+	return json.Unmarshal(respBody, response)
+}
+
+// 用平台证书校验应答或回调通知的签名.
+func (clt *Client) verify(header http.Header, body []byte) (err error) {
+	serialNo := header.Get("Wechatpay-Serial")
+	signature := header.Get("Wechatpay-Signature")
+	timestamp := header.Get("Wechatpay-Timestamp")
+	nonce := header.Get("Wechatpay-Nonce")
+	if serialNo == "" || signature == "" || timestamp == "" || nonce == "" {
+		return errors.New("missing Wechatpay-* signature headers")
+	}
+
+	cert, err := clt.platformCertificate(serialNo)
+	if err != nil {
+		return
+	}
+	return verifyWithCertificate(cert, timestamp, nonce, signature, body)
+}
+
+func verifyWithCertificate(cert *x509.Certificate, timestamp, nonce, signature string, body []byte) (err error) {
+	publicKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		return errors.New("the public key of platform certificate is not a RSA public key")
+	}
+	if err = verifySHA256WithRSA(responseMessage(timestamp, nonce, body), signature, publicKey); err != nil {
+		return fmt.Errorf("check signature failed: %s", err)
+	}
+	return
+}
+
+// 校验时间戳是否在允许的范围之内, 防止重放.
+func checkTimestamp(timestamp string, maxDelta time.Duration) (err error) {
+	n, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return
+	}
+	delta := time.Now().Sub(time.Unix(n, 0))
+	if delta < 0 {
+		delta = -delta
+	}
+	if delta > maxDelta {
+		return fmt.Errorf("the timestamp %s is expired", timestamp)
+	}
+	return
+}
@@ -0,0 +1,22 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"fmt"
+)
+
+// APIv3 接口返回的错误, http 状态码非 2xx 时返回.
+type Error struct {
+	StatusCode int         `json:"-"`                // http 状态码
+	Code       string      `json:"code"`             // 详细错误码
+	Message    string      `json:"message"`          // 错误描述
+	Detail     interface{} `json:"detail,omitempty"` // 错误详情
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("http.StatusCode: %d, code: %s, message: %s", e.StatusCode, e.Code, e.Message)
+}
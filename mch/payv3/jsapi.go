@@ -0,0 +1,44 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"strconv"
+	"time"
+)
+
+// 网页端 WeixinJSBridge/wx.chooseWXPay 和 APP 调起支付所需的参数, signType 为 RSA.
+type JSAPIPayParams struct {
+	AppId     string `json:"appId"`
+	TimeStamp string `json:"timeStamp"`
+	NonceStr  string `json:"nonceStr"`
+	Package   string `json:"package"`
+	SignType  string `json:"signType"`
+	PaySign   string `json:"paySign"`
+}
+
+// 根据 JSAPI 下单返回的 prepay_id 生成调起支付的参数.
+//  签名串为 appId\n timeStamp\n nonceStr\n package\n, 用商户私钥签名.
+func (clt *Client) JSAPIPayParams(appId, prepayId string) (params *JSAPIPayParams, err error) {
+	nonce, err := nonceStr()
+	if err != nil {
+		return
+	}
+
+	params = &JSAPIPayParams{
+		AppId:     appId,
+		TimeStamp: strconv.FormatInt(time.Now().Unix(), 10),
+		NonceStr:  nonce,
+		Package:   "prepay_id=" + prepayId,
+		SignType:  "RSA",
+	}
+	message := params.AppId + "\n" + params.TimeStamp + "\n" + params.NonceStr + "\n" + params.Package + "\n"
+	if params.PaySign, err = signSHA256WithRSA(message, clt.privateKey); err != nil {
+		params = nil
+		return
+	}
+	return
+}
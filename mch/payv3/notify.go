@@ -0,0 +1,99 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	wechatjson "github.com/chanxuehong/wechat/json"
+)
+
+// 回调通知的时间戳允许的最大偏差
+const notifyTimestampMaxDelta = 5 * time.Minute
+
+// 回调通知
+type Notify struct {
+	Id           string             `json:"id"`            // 通知ID
+	CreateTime   string             `json:"create_time"`   // 通知创建时间, rfc3339 格式
+	EventType    string             `json:"event_type"`    // 通知类型, 比如 TRANSACTION.SUCCESS
+	ResourceType string             `json:"resource_type"` // 通知数据类型, 比如 encrypt-resource
+	Resource     *EncryptedResource `json:"resource"`      // 加密的通知数据
+	Summary      string             `json:"summary"`       // 回调摘要
+}
+
+const (
+	EventTypeTransactionSuccess = "TRANSACTION.SUCCESS" // 支付成功通知
+	EventTypeRefundSuccess      = "REFUND.SUCCESS"      // 退款成功通知
+	EventTypeRefundAbnormal     = "REFUND.ABNORMAL"     // 退款异常通知
+	EventTypeRefundClosed       = "REFUND.CLOSED"       // 退款关闭通知
+)
+
+// 解析回调通知, 并用平台证书校验签名.
+func (clt *Client) ParseNotify(r *http.Request) (notify *Notify, err error) {
+	body, err := ioutil.ReadAll(r.Body)
+	if err != nil {
+		return
+	}
+
+	timestamp := r.Header.Get("Wechatpay-Timestamp")
+	if err = checkTimestamp(timestamp, notifyTimestampMaxDelta); err != nil {
+		return
+	}
+	if err = clt.verify(r.Header, body); err != nil {
+		return
+	}
+
+	notify = &Notify{}
+	if err = json.Unmarshal(body, notify); err != nil {
+		notify = nil
+		return
+	}
+	return
+}
+
+// 解密回调通知的数据, 并 JSON 解码到 v.
+func (clt *Client) DecryptNotify(notify *Notify, v interface{}) (err error) {
+	plaintext, err := DecryptResource(notify.Resource, clt.apiV3Key)
+	if err != nil {
+		return
+	}
+	return json.Unmarshal(plaintext, v)
+}
+
+// 解析支付成功的回调通知.
+func (clt *Client) ParseTransactionNotify(r *http.Request) (notify *Notify, transaction *Transaction, err error) {
+	if notify, err = clt.ParseNotify(r); err != nil {
+		return
+	}
+	transaction = &Transaction{}
+	if err = clt.DecryptNotify(notify, transaction); err != nil {
+		transaction = nil
+		return
+	}
+	return
+}
+
+// 回复回调通知.
+//  如果 err == nil 表示处理成功, 否则表示处理失败, 微信支付会重新发送通知.
+func WriteNotifyResponse(w http.ResponseWriter, err error) error {
+	var response = struct {
+		Code    string `json:"code"`
+		Message string `json:"message"`
+	}{
+		Code:    "SUCCESS",
+		Message: "成功",
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err != nil {
+		response.Code = "FAIL"
+		response.Message = err.Error()
+		w.WriteHeader(http.StatusInternalServerError)
+	}
+	return wechatjson.NewEncoder(w).Encode(&response)
+}
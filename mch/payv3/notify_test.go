@@ -0,0 +1,94 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"bytes"
+	"crypto/x509"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/chanxuehong/wechat/json"
+)
+
+func TestParseNotify(t *testing.T) {
+	key, cert := testKeyAndCert(t)
+
+	const serialNo = "5157F09EFDC096DE15EBE81A47057A7232F1B8E1"
+	clt := NewClient("1900000109", "merchant-serial", key, testAPIv3Key, nil)
+	clt.certs = map[string]*x509.Certificate{serialNo: cert}
+	clt.certsUpdateAt = time.Now()
+
+	nonce := "a1b2c3d4e5f6"
+	notify := Notify{
+		Id:           "EV-2018022511223320873",
+		EventType:    EventTypeTransactionSuccess,
+		ResourceType: "encrypt-resource",
+		Resource: &EncryptedResource{
+			Algorithm:      "AEAD_AES_256_GCM",
+			Ciphertext:     testEncryptAES256GCM(t, testAPIv3Key, nonce, "transaction", `{"out_trade_no":"order-1","trade_state":"SUCCESS"}`),
+			AssociatedData: "transaction",
+			Nonce:          nonce,
+		},
+	}
+	body, err := json.Marshal(&notify)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		timestamp int64
+		serialNo  string
+		sign      []byte // 签名使用的报文主体
+		body      []byte // 实际发送的报文主体
+		wantErr   bool
+	}{
+		{"正确的通知", time.Now().Unix(), serialNo, body, body, false},
+		{"报文主体被修改", time.Now().Unix(), serialNo, body, bytes.Replace(body, []byte("EV-"), []byte("XX-"), 1), true},
+		{"过期的时间戳", time.Now().Add(-10 * time.Minute).Unix(), serialNo, body, body, true},
+		{"没有证书序列号", time.Now().Unix(), "", body, body, true},
+	}
+	for _, tt := range tests {
+		timestamp := strconv.FormatInt(tt.timestamp, 10)
+		signature, err := signSHA256WithRSA(responseMessage(timestamp, "notify-nonce", tt.sign), key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		r := httptest.NewRequest("POST", "/notify", bytes.NewReader(tt.body))
+		r.Header.Set("Wechatpay-Serial", tt.serialNo)
+		r.Header.Set("Wechatpay-Signature", signature)
+		r.Header.Set("Wechatpay-Timestamp", timestamp)
+		r.Header.Set("Wechatpay-Nonce", "notify-nonce")
+
+		have, err := clt.ParseNotify(r)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: want error", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if have.Id != notify.Id || have.EventType != notify.EventType {
+			t.Errorf("%s: have %+v", tt.name, have)
+			continue
+		}
+
+		var transaction map[string]string
+		if err = clt.DecryptNotify(have, &transaction); err != nil {
+			t.Errorf("%s: DecryptNotify: %v", tt.name, err)
+			continue
+		}
+		if transaction["out_trade_no"] != "order-1" {
+			t.Errorf("%s: DecryptNotify: have %v", tt.name, transaction)
+		}
+	}
+}
@@ -0,0 +1,337 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"errors"
+	"net/url"
+)
+
+// 后付费项目
+type PostPayment struct {
+	Name        string `json:"name,omitempty"`        // 付费项目名称
+	Amount      int    `json:"amount,omitempty"`      // 金额, 单位为分
+	Description string `json:"description,omitempty"` // 计费说明
+	Count       int    `json:"count,omitempty"`       // 付费数量
+}
+
+// 后付费商户优惠
+type PostDiscount struct {
+	Name        string `json:"name,omitempty"`        // 优惠名称
+	Description string `json:"description,omitempty"` // 优惠说明
+	Amount      int    `json:"amount,omitempty"`      // 优惠金额, 单位为分
+	Count       int    `json:"count,omitempty"`       // 优惠数量
+}
+
+// 服务时间段
+type TimeRange struct {
+	StartTime       string `json:"start_time,omitempty"`        // 服务开始时间, 格式 yyyyMMddHHmmss 或者 OnAccept
+	StartTimeRemark string `json:"start_time_remark,omitempty"` // 服务开始时间备注
+	EndTime         string `json:"end_time,omitempty"`          // 预计服务结束时间, 格式 yyyyMMddHHmmss
+	EndTimeRemark   string `json:"end_time_remark,omitempty"`   // 预计服务结束时间备注
+}
+
+// 服务位置
+type Location struct {
+	StartLocation string `json:"start_location,omitempty"` // 服务开始地点
+	EndLocation   string `json:"end_location,omitempty"`   // 预计服务结束位置
+}
+
+// 订单风险金
+type RiskFund struct {
+	Name        string `json:"name"`                  // 风险金名称, DEPOSIT, ADVANCE, CASH_DEPOSIT, ESTIMATE_ORDER_COST
+	Amount      int    `json:"amount"`                // 风险金额, 单位为分
+	Description string `json:"description,omitempty"` // 风险说明
+}
+
+// 支付分服务订单, 既用于请求参数也用于返回结果
+type ServiceOrder struct {
+	OutOrderNo          string         `json:"out_order_no,omitempty"`         // 商户服务订单号
+	AppId               string         `json:"appid,omitempty"`                // 应用ID
+	ServiceId           string         `json:"service_id,omitempty"`           // 服务ID
+	ServiceIntroduction string         `json:"service_introduction,omitempty"` // 服务信息
+	PostPayments        []PostPayment  `json:"post_payments,omitempty"`        // 后付费项目
+	PostDiscounts       []PostDiscount `json:"post_discounts,omitempty"`       // 后付费商户优惠
+	TimeRange           *TimeRange     `json:"time_range,omitempty"`           // 服务时间段
+	Location            *Location      `json:"location,omitempty"`             // 服务位置
+	RiskFund            *RiskFund      `json:"risk_fund,omitempty"`            // 订单风险金
+	Attach              string         `json:"attach,omitempty"`               // 商户数据包
+	NotifyURL           string         `json:"notify_url,omitempty"`           // 商户回调地址
+	OpenId              string         `json:"openid,omitempty"`               // 用户标识
+	NeedUserConfirm     *bool          `json:"need_user_confirm,omitempty"`    // 是否需要用户确认
+	TotalAmount         int            `json:"total_amount,omitempty"`         // 总金额, 单位为分
+	ProfitSharing       *bool          `json:"profit_sharing,omitempty"`       // 微信支付服务分账标记
+
+	// 以下字段只在返回结果中出现
+	MchId            string `json:"mchid,omitempty"`
+	State            string `json:"state,omitempty"`             // 服务订单状态, CREATED, DOING, DONE, REVOKED, EXPIRED
+	StateDescription string `json:"state_description,omitempty"` // 订单状态说明, USER_CONFIRM, MCH_COMPLETE
+	OrderId          string `json:"order_id,omitempty"`          // 微信支付服务订单号
+	Package          string `json:"package,omitempty"`           // 用于跳转到微信侧小程序订单数据
+	NeedCollection   *bool  `json:"need_collection,omitempty"`   // 是否需要收款
+	Collection       *struct {
+		State        string `json:"state"`         // 收款状态, USER_PAYING, USER_PAID
+		TotalAmount  int    `json:"total_amount"`  // 总收款金额
+		PayingAmount int    `json:"paying_amount"` // 待收金额
+		PaidAmount   int    `json:"paid_amount"`   // 已收金额
+		Details      []struct {
+			Seq           int    `json:"seq"`
+			Amount        int    `json:"amount"`
+			PaidType      string `json:"paid_type"` // 收款成功渠道, MCH, NEWTON
+			PaidTime      string `json:"paid_time"`
+			TransactionId string `json:"transaction_id"`
+		} `json:"details"`
+	} `json:"collection,omitempty"` // 收款信息
+}
+
+// 创建支付分订单.
+func (clt *Client) PayScoreCreateServiceOrder(order *ServiceOrder) (rst *ServiceOrder, err error) {
+	if order == nil {
+		err = errors.New("nil ServiceOrder")
+		return
+	}
+
+	rst = &ServiceOrder{}
+	if err = clt.Do("POST", "/v3/payscore/serviceorder", order, rst); err != nil {
+		rst = nil
+		return
+	}
+	return
+}
+
+// 查询支付分订单.
+//  outOrderNo 和 queryId 二选一, 另一个为空 "".
+func (clt *Client) PayScoreQueryServiceOrder(appId, serviceId, outOrderNo, queryId string) (rst *ServiceOrder, err error) {
+	if outOrderNo == "" && queryId == "" {
+		err = errors.New("empty outOrderNo and queryId")
+		return
+	}
+
+	query := url.Values{}
+	query.Set("service_id", serviceId)
+	query.Set("appid", appId)
+	if outOrderNo != "" {
+		query.Set("out_order_no", outOrderNo)
+	} else {
+		query.Set("query_id", queryId)
+	}
+
+	rst = &ServiceOrder{}
+	if err = clt.Do("GET", "/v3/payscore/serviceorder?"+query.Encode(), nil, rst); err != nil {
+		rst = nil
+		return
+	}
+	return
+}
+
+// 取消支付分订单.
+//  reason: 取消原因, 最长50个字符
+func (clt *Client) PayScoreCancelServiceOrder(appId, serviceId, outOrderNo, reason string) (rst *ServiceOrder, err error) {
+	var request = struct {
+		AppId     string `json:"appid"`
+		ServiceId string `json:"service_id"`
+		Reason    string `json:"reason"`
+	}{
+		AppId:     appId,
+		ServiceId: serviceId,
+		Reason:    reason,
+	}
+	return clt.payScoreServiceOrderAction(outOrderNo, "cancel", &request)
+}
+
+// 修改订单金额.
+//  order 需要填写 AppId, ServiceId, PostPayments, PostDiscounts, TotalAmount; reason 为修改原因.
+func (clt *Client) PayScoreModifyServiceOrder(outOrderNo string, order *ServiceOrder, reason string) (rst *ServiceOrder, err error) {
+	if order == nil {
+		err = errors.New("nil ServiceOrder")
+		return
+	}
+
+	var request = struct {
+		AppId         string         `json:"appid"`
+		ServiceId     string         `json:"service_id"`
+		PostPayments  []PostPayment  `json:"post_payments"`
+		PostDiscounts []PostDiscount `json:"post_discounts,omitempty"`
+		TotalAmount   int            `json:"total_amount"`
+		Reason        string         `json:"reason"`
+	}{
+		AppId:         order.AppId,
+		ServiceId:     order.ServiceId,
+		PostPayments:  order.PostPayments,
+		PostDiscounts: order.PostDiscounts,
+		TotalAmount:   order.TotalAmount,
+		Reason:        reason,
+	}
+	return clt.payScoreServiceOrderAction(outOrderNo, "modify", &request)
+}
+
+// 完结支付分订单.
+//  order 需要填写 AppId, ServiceId, PostPayments, TotalAmount, 可选填写 PostDiscounts, TimeRange, Location, ProfitSharing.
+func (clt *Client) PayScoreCompleteServiceOrder(outOrderNo string, order *ServiceOrder) (rst *ServiceOrder, err error) {
+	if order == nil {
+		err = errors.New("nil ServiceOrder")
+		return
+	}
+
+	var request = struct {
+		AppId         string         `json:"appid"`
+		ServiceId     string         `json:"service_id"`
+		PostPayments  []PostPayment  `json:"post_payments"`
+		PostDiscounts []PostDiscount `json:"post_discounts,omitempty"`
+		TotalAmount   int            `json:"total_amount"`
+		TimeRange     *TimeRange     `json:"time_range,omitempty"`
+		Location      *Location      `json:"location,omitempty"`
+		ProfitSharing *bool          `json:"profit_sharing,omitempty"`
+	}{
+		AppId:         order.AppId,
+		ServiceId:     order.ServiceId,
+		PostPayments:  order.PostPayments,
+		PostDiscounts: order.PostDiscounts,
+		TotalAmount:   order.TotalAmount,
+		TimeRange:     order.TimeRange,
+		Location:      order.Location,
+		ProfitSharing: order.ProfitSharing,
+	}
+	return clt.payScoreServiceOrderAction(outOrderNo, "complete", &request)
+}
+
+// 同步服务订单信息, 用于用户通过其他渠道支付后同步订单状态.
+//  paidTime: 收款成功时间, 格式 yyyyMMddHHmmss
+func (clt *Client) PayScoreSyncServiceOrder(appId, serviceId, outOrderNo, paidTime string) (rst *ServiceOrder, err error) {
+	var request struct {
+		AppId     string `json:"appid"`
+		ServiceId string `json:"service_id"`
+		Type      string `json:"type"`
+		Detail    struct {
+			PaidTime string `json:"paid_time"`
+		} `json:"detail"`
+	}
+	request.AppId = appId
+	request.ServiceId = serviceId
+	request.Type = "Order_Paid"
+	request.Detail.PaidTime = paidTime
+
+	return clt.payScoreServiceOrderAction(outOrderNo, "sync", &request)
+}
+
+func (clt *Client) payScoreServiceOrderAction(outOrderNo, action string, request interface{}) (rst *ServiceOrder, err error) {
+	if outOrderNo == "" {
+		err = errors.New("empty outOrderNo")
+		return
+	}
+
+	rst = &ServiceOrder{}
+	if err = clt.Do("POST", "/v3/payscore/serviceorder/"+url.PathEscape(outOrderNo)+"/"+action, request, rst); err != nil {
+		rst = nil
+		return
+	}
+	return
+}
+
+// 用户的授权信息
+type PayScorePermissions struct {
+	ServiceId                string `json:"service_id"`
+	AppId                    string `json:"appid"`
+	MchId                    string `json:"mchid"`
+	OpenId                   string `json:"openid"`
+	AuthorizationCode        string `json:"authorization_code"`  // 授权协议号
+	AuthorizationState       string `json:"authorization_state"` // 授权状态, UNAVAILABLE, AVAILABLE
+	NotifyURL                string `json:"notify_url"`
+	CancelAuthorizationTime  string `json:"cancel_authorization_time"`  // 最近一次解除授权时间
+	AuthorizationSuccessTime string `json:"authorization_success_time"` // 最近一次授权成功时间
+}
+
+// 商户预授权, 返回用于跳转到微信侧授权页面的 apply_permissions_token.
+//  authorizationCode: 授权协议号, 商户侧用户的唯一标识
+func (clt *Client) PayScoreApplyPermissions(appId, serviceId, authorizationCode, notifyURL string) (token string, err error) {
+	var request = struct {
+		ServiceId         string `json:"service_id"`
+		AppId             string `json:"appid"`
+		AuthorizationCode string `json:"authorization_code"`
+		NotifyURL         string `json:"notify_url,omitempty"`
+	}{
+		ServiceId:         serviceId,
+		AppId:             appId,
+		AuthorizationCode: authorizationCode,
+		NotifyURL:         notifyURL,
+	}
+
+	var result struct {
+		ApplyPermissionsToken string `json:"apply_permissions_token"`
+	}
+	if err = clt.Do("POST", "/v3/payscore/permissions", &request, &result); err != nil {
+		return
+	}
+	token = result.ApplyPermissionsToken
+	return
+}
+
+// 通过授权协议号查询用户的授权信息.
+func (clt *Client) PayScoreQueryPermissionsByCode(serviceId, authorizationCode string) (perm *PayScorePermissions, err error) {
+	if authorizationCode == "" {
+		err = errors.New("empty authorizationCode")
+		return
+	}
+
+	perm = &PayScorePermissions{}
+	path := "/v3/payscore/permissions/authorization-code/" + url.PathEscape(authorizationCode) + "?service_id=" + url.QueryEscape(serviceId)
+	if err = clt.Do("GET", path, nil, perm); err != nil {
+		perm = nil
+		return
+	}
+	return
+}
+
+// 通过授权协议号解除用户授权.
+func (clt *Client) PayScoreTerminatePermissionsByCode(serviceId, authorizationCode, reason string) (err error) {
+	if authorizationCode == "" {
+		return errors.New("empty authorizationCode")
+	}
+
+	var request = struct {
+		ServiceId string `json:"service_id"`
+		Reason    string `json:"reason"`
+	}{
+		ServiceId: serviceId,
+		Reason:    reason,
+	}
+	return clt.Do("POST", "/v3/payscore/permissions/authorization-code/"+url.PathEscape(authorizationCode)+"/terminate", &request, nil)
+}
+
+// 通过 openid 查询用户的授权信息.
+func (clt *Client) PayScoreQueryPermissionsByOpenId(appId, serviceId, openId string) (perm *PayScorePermissions, err error) {
+	if openId == "" {
+		err = errors.New("empty openId")
+		return
+	}
+
+	perm = &PayScorePermissions{}
+	path := "/v3/payscore/permissions/openid/" + url.PathEscape(openId) +
+		"?appid=" + url.QueryEscape(appId) + "&service_id=" + url.QueryEscape(serviceId)
+	if err = clt.Do("GET", path, nil, perm); err != nil {
+		perm = nil
+		return
+	}
+	return
+}
+
+// 通过 openid 解除用户授权.
+func (clt *Client) PayScoreTerminatePermissionsByOpenId(appId, serviceId, openId, reason string) (err error) {
+	if openId == "" {
+		return errors.New("empty openId")
+	}
+
+	var request = struct {
+		ServiceId string `json:"service_id"`
+		AppId     string `json:"appid"`
+		Reason    string `json:"reason"`
+	}{
+		ServiceId: serviceId,
+		AppId:     appId,
+		Reason:    reason,
+	}
+	return clt.Do("POST", "/v3/payscore/permissions/openid/"+url.PathEscape(openId)+"/terminate", &request, nil)
+}
@@ -0,0 +1,199 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"errors"
+	"net/url"
+)
+
+const (
+	ReceiverTypeMerchantId     = "MERCHANT_ID"     // 分账接收方为商户号
+	ReceiverTypePersonalOpenId = "PERSONAL_OPENID" // 分账接收方为个人 openid
+)
+
+// 分账接收方
+type ProfitSharingReceiver struct {
+	AppId          string `json:"appid,omitempty"`
+	Type           string `json:"type"`                      // 分账接收方类型, ReceiverTypeMerchantId 或 ReceiverTypePersonalOpenId
+	Account        string `json:"account"`                   // 分账接收方账号
+	Name           string `json:"name,omitempty"`            // 分账接收方全称, 需要用平台证书加密, 参考 Client.EncryptSensitive
+	RelationType   string `json:"relation_type,omitempty"`   // 与分账方的关系类型, 比如 STORE, STAFF, PARTNER, CUSTOM
+	CustomRelation string `json:"custom_relation,omitempty"` // 自定义的分账关系, relation_type 为 CUSTOM 时必填
+}
+
+// 添加分账接收方.
+//  nameSerialNo: 加密 receiver.Name 所用平台证书的序列号, 如果 receiver.Name 为空则可以为空.
+func (clt *Client) ProfitSharingAddReceiver(receiver *ProfitSharingReceiver, nameSerialNo string) (err error) {
+	if receiver == nil {
+		return errors.New("nil ProfitSharingReceiver")
+	}
+	if nameSerialNo != "" {
+		return clt.doWithSerial("POST", "/v3/profitsharing/receivers/add", receiver, nil, nameSerialNo)
+	}
+	return clt.Do("POST", "/v3/profitsharing/receivers/add", receiver, nil)
+}
+
+// 删除分账接收方.
+func (clt *Client) ProfitSharingDeleteReceiver(appId, receiverType, account string) (err error) {
+	var request = struct {
+		AppId   string `json:"appid"`
+		Type    string `json:"type"`
+		Account string `json:"account"`
+	}{
+		AppId:   appId,
+		Type:    receiverType,
+		Account: account,
+	}
+	return clt.Do("POST", "/v3/profitsharing/receivers/delete", &request, nil)
+}
+
+// 分账请求中的接收方
+type ProfitSharingOrderReceiver struct {
+	Type        string `json:"type"`
+	Account     string `json:"account"`
+	Name        string `json:"name,omitempty"` // 分账个人接收方姓名, 需要用平台证书加密
+	Amount      int    `json:"amount"`         // 分账金额, 单位为分
+	Description string `json:"description"`    // 分账描述
+
+	// 以下字段只在查询结果中返回
+	Result     string `json:"result,omitempty"`      // 分账结果, PENDING, SUCCESS, CLOSED
+	FailReason string `json:"fail_reason,omitempty"` // 分账失败原因
+	DetailId   string `json:"detail_id,omitempty"`   // 分账明细单号
+	CreateTime string `json:"create_time,omitempty"`
+	FinishTime string `json:"finish_time,omitempty"`
+}
+
+// 请求分账的参数
+type ProfitSharingRequest struct {
+	AppId           string                       `json:"appid"`
+	TransactionId   string                       `json:"transaction_id"` // 微信订单号
+	OutOrderNo      string                       `json:"out_order_no"`   // 商户分账单号
+	Receivers       []ProfitSharingOrderReceiver `json:"receivers"`
+	UnfreezeUnsplit bool                         `json:"unfreeze_unsplit"` // 是否解冻剩余未分资金
+}
+
+// 分账单
+type ProfitSharingOrder struct {
+	TransactionId string                       `json:"transaction_id"`
+	OutOrderNo    string                       `json:"out_order_no"`
+	OrderId       string                       `json:"order_id"` // 微信分账单号
+	State         string                       `json:"state"`    // 分账单状态, PROCESSING, FINISHED
+	Receivers     []ProfitSharingOrderReceiver `json:"receivers"`
+}
+
+// 请求分账.
+//  nameSerialNo: 加密接收方姓名所用平台证书的序列号, 如果没有姓名则可以为空.
+func (clt *Client) ProfitSharingCreateOrder(req *ProfitSharingRequest, nameSerialNo string) (order *ProfitSharingOrder, err error) {
+	if req == nil {
+		err = errors.New("nil ProfitSharingRequest")
+		return
+	}
+
+	order = &ProfitSharingOrder{}
+	if nameSerialNo != "" {
+		err = clt.doWithSerial("POST", "/v3/profitsharing/orders", req, order, nameSerialNo)
+	} else {
+		err = clt.Do("POST", "/v3/profitsharing/orders", req, order)
+	}
+	if err != nil {
+		order = nil
+		return
+	}
+	return
+}
+
+// 查询分账结果.
+func (clt *Client) ProfitSharingQueryOrder(transactionId, outOrderNo string) (order *ProfitSharingOrder, err error) {
+	if outOrderNo == "" {
+		err = errors.New("empty outOrderNo")
+		return
+	}
+
+	order = &ProfitSharingOrder{}
+	path := "/v3/profitsharing/orders/" + url.PathEscape(outOrderNo) + "?transaction_id=" + url.QueryEscape(transactionId)
+	if err = clt.Do("GET", path, nil, order); err != nil {
+		order = nil
+		return
+	}
+	return
+}
+
+// 解冻剩余资金(完结分账).
+func (clt *Client) ProfitSharingUnfreeze(transactionId, outOrderNo, description string) (order *ProfitSharingOrder, err error) {
+	var request = struct {
+		TransactionId string `json:"transaction_id"`
+		OutOrderNo    string `json:"out_order_no"`
+		Description   string `json:"description"`
+	}{
+		TransactionId: transactionId,
+		OutOrderNo:    outOrderNo,
+		Description:   description,
+	}
+
+	order = &ProfitSharingOrder{}
+	if err = clt.Do("POST", "/v3/profitsharing/orders/unfreeze", &request, order); err != nil {
+		order = nil
+		return
+	}
+	return
+}
+
+// 请求分账回退的参数, OrderId 和 OutOrderNo 二选一
+type ProfitSharingReturnRequest struct {
+	OrderId     string `json:"order_id,omitempty"`     // 微信分账单号
+	OutOrderNo  string `json:"out_order_no,omitempty"` // 商户分账单号
+	OutReturnNo string `json:"out_return_no"`          // 商户回退单号
+	ReturnMchId string `json:"return_mchid"`           // 回退商户号
+	Amount      int    `json:"amount"`                 // 回退金额, 单位为分
+	Description string `json:"description"`
+}
+
+// 分账回退单
+type ProfitSharingReturnOrder struct {
+	OrderId     string `json:"order_id"`
+	OutOrderNo  string `json:"out_order_no"`
+	OutReturnNo string `json:"out_return_no"`
+	ReturnId    string `json:"return_id"` // 微信回退单号
+	ReturnMchId string `json:"return_mchid"`
+	Amount      int    `json:"amount"`
+	Description string `json:"description"`
+	Result      string `json:"result"`      // 回退结果, PROCESSING, SUCCESS, FAILED
+	FailReason  string `json:"fail_reason"` // 失败原因
+	CreateTime  string `json:"create_time"`
+	FinishTime  string `json:"finish_time"`
+}
+
+// 请求分账回退.
+func (clt *Client) ProfitSharingReturn(req *ProfitSharingReturnRequest) (order *ProfitSharingReturnOrder, err error) {
+	if req == nil {
+		err = errors.New("nil ProfitSharingReturnRequest")
+		return
+	}
+
+	order = &ProfitSharingReturnOrder{}
+	if err = clt.Do("POST", "/v3/profitsharing/return-orders", req, order); err != nil {
+		order = nil
+		return
+	}
+	return
+}
+
+// 查询分账回退结果.
+func (clt *Client) ProfitSharingQueryReturn(outOrderNo, outReturnNo string) (order *ProfitSharingReturnOrder, err error) {
+	if outReturnNo == "" {
+		err = errors.New("empty outReturnNo")
+		return
+	}
+
+	order = &ProfitSharingReturnOrder{}
+	path := "/v3/profitsharing/return-orders/" + url.PathEscape(outReturnNo) + "?out_order_no=" + url.QueryEscape(outOrderNo)
+	if err = clt.Do("GET", path, nil, order); err != nil {
+		order = nil
+		return
+	}
+	return
+}
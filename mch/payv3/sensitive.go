@@ -0,0 +1,47 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+)
+
+// 用平台证书加密敏感信息(比如姓名, 手机号), 返回 base64 编码的密文和所用平台证书的序列号.
+//  加密算法为 RSAES-OAEP, 选用有效期最晚的平台证书; 请求时需要在请求头 Wechatpay-Serial 中指明 serialNo.
+func (clt *Client) EncryptSensitive(plaintext string) (ciphertext, serialNo string, err error) {
+	certs, err := clt.PlatformCertificates()
+	if err != nil {
+		return
+	}
+
+	var cert *x509.Certificate
+	for no, c := range certs {
+		if cert == nil || c.NotAfter.After(cert.NotAfter) {
+			cert, serialNo = c, no
+		}
+	}
+	if cert == nil {
+		err = errors.New("no platform certificate")
+		return
+	}
+	publicKey, ok := cert.PublicKey.(*rsa.PublicKey)
+	if !ok {
+		err = errors.New("the public key of platform certificate is not a RSA public key")
+		return
+	}
+
+	data, err := rsa.EncryptOAEP(sha1.New(), rand.Reader, publicKey, []byte(plaintext), nil)
+	if err != nil {
+		return
+	}
+	ciphertext = base64.StdEncoding.EncodeToString(data)
+	return
+}
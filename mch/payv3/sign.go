@@ -0,0 +1,102 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"io/ioutil"
+	"strconv"
+)
+
+const authorizationSchema = "WECHATPAY2-SHA256-RSA2048"
+
+// 解析 PEM 编码的商户私钥(apiclient_key.pem), 支持 PKCS#1 和 PKCS#8 格式.
+func ParsePrivateKey(pemBlock []byte) (privateKey *rsa.PrivateKey, err error) {
+	block, _ := pem.Decode(pemBlock)
+	if block == nil {
+		err = errors.New("invalid private key pem")
+		return
+	}
+	if privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return
+	}
+	privateKey, ok := key.(*rsa.PrivateKey)
+	if !ok {
+		err = errors.New("private key is not a RSA private key")
+		return
+	}
+	return
+}
+
+// 从文件中读取商户私钥, 参考 ParsePrivateKey.
+func LoadPrivateKey(filename string) (privateKey *rsa.PrivateKey, err error) {
+	pemBlock, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return
+	}
+	return ParsePrivateKey(pemBlock)
+}
+
+// SHA256withRSA 签名, 返回 base64 编码的签名.
+func signSHA256WithRSA(message string, privateKey *rsa.PrivateKey) (signature string, err error) {
+	hashed := sha256.Sum256([]byte(message))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, privateKey, crypto.SHA256, hashed[:])
+	if err != nil {
+		return
+	}
+	signature = base64.StdEncoding.EncodeToString(sig)
+	return
+}
+
+// 校验 SHA256withRSA 签名, signature 为 base64 编码的签名.
+func verifySHA256WithRSA(message, signature string, publicKey *rsa.PublicKey) (err error) {
+	sig, err := base64.StdEncoding.DecodeString(signature)
+	if err != nil {
+		return
+	}
+	hashed := sha256.Sum256([]byte(message))
+	return rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, hashed[:], sig)
+}
+
+// 请求的签名串.
+//  HTTP请求方法\n URL\n 请求时间戳\n 请求随机串\n 请求报文主体\n
+func requestMessage(method, canonicalURL string, timestamp int64, nonceStr string, body []byte) string {
+	return method + "\n" +
+		canonicalURL + "\n" +
+		strconv.FormatInt(timestamp, 10) + "\n" +
+		nonceStr + "\n" +
+		string(body) + "\n"
+}
+
+// 应答和回调通知的签名串.
+//  应答时间戳\n 应答随机串\n 应答报文主体\n
+func responseMessage(timestamp, nonceStr string, body []byte) string {
+	return timestamp + "\n" +
+		nonceStr + "\n" +
+		string(body) + "\n"
+}
+
+// 生成 32 个字符的随机串.
+func nonceStr() (string, error) {
+	var nonce [16]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(nonce[:]), nil
+}
@@ -0,0 +1,187 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+var (
+	testKeyOnce sync.Once
+	testKey     *rsa.PrivateKey
+	testCert    *x509.Certificate
+)
+
+// 测试用的 RSA 私钥和对应的自签名证书, 充当商户私钥和平台证书.
+func testKeyAndCert(t *testing.T) (*rsa.PrivateKey, *x509.Certificate) {
+	testKeyOnce.Do(func() {
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		if err != nil {
+			t.Fatal(err)
+		}
+		template := &x509.Certificate{
+			SerialNumber: big.NewInt(1),
+			Subject:      pkix.Name{CommonName: "Tenpay.com Root CA"},
+			NotBefore:    time.Now().Add(-time.Hour),
+			NotAfter:     time.Now().Add(time.Hour),
+		}
+		der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+		if err != nil {
+			t.Fatal(err)
+		}
+		cert, err := x509.ParseCertificate(der)
+		if err != nil {
+			t.Fatal(err)
+		}
+		testKey, testCert = key, cert
+	})
+	if testKey == nil {
+		t.Fatal("failed to generate the test key")
+	}
+	return testKey, testCert
+}
+
+func TestParsePrivateKey(t *testing.T) {
+	key, _ := testKeyAndCert(t)
+
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPKCS8, err := x509.MarshalPKCS8PrivateKey(ecKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		pem     []byte
+		wantErr bool
+	}{
+		{"PKCS#1", pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}), false},
+		{"PKCS#8", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}), false},
+		{"不是 RSA 私钥", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: ecPKCS8}), true},
+		{"不是 PEM", []byte("not a pem"), true},
+		{"损坏的私钥", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: []byte("broken")}), true},
+	}
+	for _, tt := range tests {
+		privateKey, err := ParsePrivateKey(tt.pem)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: want error", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %v", tt.name, err)
+			continue
+		}
+		if privateKey.N.Cmp(key.N) != 0 {
+			t.Errorf("%s: parsed a different key", tt.name)
+		}
+	}
+}
+
+func TestRequestMessage(t *testing.T) {
+	have := requestMessage("POST", "/v3/pay/transactions/jsapi", 1554208460, "593BEC0C930BF1AFEB40B4A08C8FB242", []byte(`{"appid":"wxd678efh567hg6787"}`))
+	want := "POST\n/v3/pay/transactions/jsapi\n1554208460\n593BEC0C930BF1AFEB40B4A08C8FB242\n{\"appid\":\"wxd678efh567hg6787\"}\n"
+	if have != want {
+		t.Errorf("requestMessage:\nhave %q\nwant %q", have, want)
+	}
+
+	// GET 请求没有报文主体, 最后也要有一个 \n
+	have = requestMessage("GET", "/v3/certificates", 1554208460, "nonce", nil)
+	want = "GET\n/v3/certificates\n1554208460\nnonce\n\n"
+	if have != want {
+		t.Errorf("requestMessage:\nhave %q\nwant %q", have, want)
+	}
+}
+
+func TestSignAndVerifySHA256WithRSA(t *testing.T) {
+	key, cert := testKeyAndCert(t)
+
+	body := []byte(`{"code_url":"weixin://wxpay/bizpayurl?pr=p4lpSuKzz"}`)
+	signature, err := signSHA256WithRSA(responseMessage("1554209980", "c5ac7061fccab6bf3e254dcf98995b8c", body), key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		timestamp string
+		nonce     string
+		signature string
+		body      []byte
+		wantErr   bool
+	}{
+		{"正确的签名", "1554209980", "c5ac7061fccab6bf3e254dcf98995b8c", signature, body, false},
+		{"报文主体被修改", "1554209980", "c5ac7061fccab6bf3e254dcf98995b8c", signature, []byte(`{"code_url":"weixin://evil"}`), true},
+		{"时间戳被修改", "1554209981", "c5ac7061fccab6bf3e254dcf98995b8c", signature, body, true},
+		{"随机串被修改", "1554209980", "c5ac7061fccab6bf3e254dcf98995b8d", signature, body, true},
+		{"签名不是 base64", "1554209980", "c5ac7061fccab6bf3e254dcf98995b8c", "!!!", body, true},
+		{"空签名", "1554209980", "c5ac7061fccab6bf3e254dcf98995b8c", "", body, true},
+	}
+	for _, tt := range tests {
+		err := verifyWithCertificate(cert, tt.timestamp, tt.nonce, tt.signature, tt.body)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: err = %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCheckTimestamp(t *testing.T) {
+	now := time.Now().Unix()
+	tests := []struct {
+		timestamp string
+		wantErr   bool
+	}{
+		{strconv.FormatInt(now, 10), false},
+		{strconv.FormatInt(now-60, 10), false},
+		{strconv.FormatInt(now+60, 10), false},
+		{strconv.FormatInt(now-600, 10), true},
+		{strconv.FormatInt(now+600, 10), true},
+		{"", true},
+		{"abc", true},
+	}
+	for _, tt := range tests {
+		err := checkTimestamp(tt.timestamp, 5*time.Minute)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("checkTimestamp(%q): err = %v, wantErr %v", tt.timestamp, err, tt.wantErr)
+		}
+	}
+}
+
+func TestNonceStr(t *testing.T) {
+	seen := make(map[string]bool)
+	for i := 0; i < 100; i++ {
+		nonce, err := nonceStr()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(nonce) != 32 {
+			t.Errorf("len(nonceStr()) = %d, want 32", len(nonce))
+		}
+		if seen[nonce] {
+			t.Errorf("duplicate nonce: %s", nonce)
+		}
+		seen[nonce] = true
+	}
+}
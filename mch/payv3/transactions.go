@@ -0,0 +1,229 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package payv3
+
+import (
+	"errors"
+	"net/url"
+)
+
+// 订单金额
+type Amount struct {
+	Total         int    `json:"total"`                    // 订单总金额, 单位为分
+	Currency      string `json:"currency,omitempty"`       // 货币类型, CNY: 人民币, 默认为 CNY
+	PayerTotal    int    `json:"payer_total,omitempty"`    // 用户支付金额, 单位为分, 只在查询和通知中返回
+	PayerCurrency string `json:"payer_currency,omitempty"` // 用户支付币种, 只在查询和通知中返回
+}
+
+// 支付者
+type Payer struct {
+	OpenId string `json:"openid"` // 用户在商户 appid 下的唯一标识
+}
+
+// H5 场景信息
+type H5Info struct {
+	Type        string `json:"type"`                   // 场景类型, 比如 iOS, Android, Wap
+	AppName     string `json:"app_name,omitempty"`     // 应用名称
+	AppURL      string `json:"app_url,omitempty"`      // 网站URL
+	BundleId    string `json:"bundle_id,omitempty"`    // iOS 平台 BundleID
+	PackageName string `json:"package_name,omitempty"` // Android 平台 PackageName
+}
+
+// 门店信息
+type StoreInfo struct {
+	Id       string `json:"id"`                  // 商户侧门店编号
+	Name     string `json:"name,omitempty"`      // 商户侧门店名称
+	AreaCode string `json:"area_code,omitempty"` // 地区编码
+	Address  string `json:"address,omitempty"`   // 详细的商户门店地址
+}
+
+// 场景信息
+type SceneInfo struct {
+	PayerClientIP string     `json:"payer_client_ip"`      // 用户终端IP
+	DeviceId      string     `json:"device_id,omitempty"`  // 商户端设备号
+	StoreInfo     *StoreInfo `json:"store_info,omitempty"` // 商户门店信息
+	H5Info        *H5Info    `json:"h5_info,omitempty"`    // H5 场景信息, H5 下单时必填
+}
+
+// 下单请求参数
+type PrepayRequest struct {
+	AppId       string     `json:"appid"`                 // 应用ID
+	MchId       string     `json:"mchid"`                 // 直连商户号, 如果为空则默认用 Client 的商户号
+	Description string     `json:"description"`           // 商品描述
+	OutTradeNo  string     `json:"out_trade_no"`          // 商户订单号
+	TimeExpire  string     `json:"time_expire,omitempty"` // 交易结束时间, rfc3339 格式
+	Attach      string     `json:"attach,omitempty"`      // 附加数据
+	NotifyURL   string     `json:"notify_url"`            // 通知地址
+	GoodsTag    string     `json:"goods_tag,omitempty"`   // 订单优惠标记
+	Amount      Amount     `json:"amount"`                // 订单金额
+	Payer       *Payer     `json:"payer,omitempty"`       // 支付者, JSAPI 下单时必填
+	SceneInfo   *SceneInfo `json:"scene_info,omitempty"`  // 场景信息, H5 下单时必填
+}
+
+// JSAPI 下单, 返回预支付交易会话标识 prepay_id.
+//  req.Payer 必须填写.
+func (clt *Client) PrepayJSAPI(req *PrepayRequest) (prepayId string, err error) {
+	if req == nil {
+		err = errors.New("nil PrepayRequest")
+		return
+	}
+	if req.Payer == nil {
+		err = errors.New("nil PrepayRequest.Payer")
+		return
+	}
+
+	var result struct {
+		PrepayId string `json:"prepay_id"`
+	}
+	if err = clt.prepay("/v3/pay/transactions/jsapi", req, &result); err != nil {
+		return
+	}
+	prepayId = result.PrepayId
+	return
+}
+
+// APP 下单, 返回预支付交易会话标识 prepay_id.
+func (clt *Client) PrepayApp(req *PrepayRequest) (prepayId string, err error) {
+	if req == nil {
+		err = errors.New("nil PrepayRequest")
+		return
+	}
+
+	var result struct {
+		PrepayId string `json:"prepay_id"`
+	}
+	if err = clt.prepay("/v3/pay/transactions/app", req, &result); err != nil {
+		return
+	}
+	prepayId = result.PrepayId
+	return
+}
+
+// Native 下单, 返回二维码链接 code_url.
+func (clt *Client) PrepayNative(req *PrepayRequest) (codeURL string, err error) {
+	if req == nil {
+		err = errors.New("nil PrepayRequest")
+		return
+	}
+
+	var result struct {
+		CodeURL string `json:"code_url"`
+	}
+	if err = clt.prepay("/v3/pay/transactions/native", req, &result); err != nil {
+		return
+	}
+	codeURL = result.CodeURL
+	return
+}
+
+// H5 下单, 返回支付跳转链接 h5_url.
+//  req.SceneInfo 和 req.SceneInfo.H5Info 必须填写.
+func (clt *Client) PrepayH5(req *PrepayRequest) (h5URL string, err error) {
+	if req == nil {
+		err = errors.New("nil PrepayRequest")
+		return
+	}
+	if req.SceneInfo == nil || req.SceneInfo.H5Info == nil {
+		err = errors.New("nil PrepayRequest.SceneInfo.H5Info")
+		return
+	}
+
+	var result struct {
+		H5URL string `json:"h5_url"`
+	}
+	if err = clt.prepay("/v3/pay/transactions/h5", req, &result); err != nil {
+		return
+	}
+	h5URL = result.H5URL
+	return
+}
+
+func (clt *Client) prepay(path string, req *PrepayRequest, response interface{}) error {
+	if req.MchId == "" {
+		req.MchId = clt.mchId
+	}
+	return clt.Do("POST", path, req, response)
+}
+
+// 订单信息, 用于查询订单和支付成功通知
+type Transaction struct {
+	AppId           string     `json:"appid"`
+	MchId           string     `json:"mchid"`
+	OutTradeNo      string     `json:"out_trade_no"`
+	TransactionId   string     `json:"transaction_id"`   // 微信支付订单号
+	TradeType       string     `json:"trade_type"`       // 交易类型, JSAPI, NATIVE, APP, MICROPAY, MWEB, FACEPAY
+	TradeState      string     `json:"trade_state"`      // 交易状态, 参考 TradeStateXXX
+	TradeStateDesc  string     `json:"trade_state_desc"` // 交易状态描述
+	BankType        string     `json:"bank_type"`        // 付款银行
+	Attach          string     `json:"attach"`
+	SuccessTime     string     `json:"success_time"` // 支付完成时间, rfc3339 格式
+	Payer           *Payer     `json:"payer"`
+	Amount          *Amount    `json:"amount"`
+	SceneInfo       *SceneInfo `json:"scene_info"`
+	PromotionDetail []struct {
+		CouponId string `json:"coupon_id"`
+		Name     string `json:"name"`
+		Scope    string `json:"scope"`
+		Type     string `json:"type"`
+		Amount   int    `json:"amount"`
+	} `json:"promotion_detail"` // 优惠功能
+}
+
+const (
+	TradeStateSuccess    = "SUCCESS"    // 支付成功
+	TradeStateRefund     = "REFUND"     // 转入退款
+	TradeStateNotPay     = "NOTPAY"     // 未支付
+	TradeStateClosed     = "CLOSED"     // 已关闭
+	TradeStateRevoked    = "REVOKED"    // 已撤销(付款码支付)
+	TradeStateUserPaying = "USERPAYING" // 用户支付中(付款码支付)
+	TradeStatePayError   = "PAYERROR"   // 支付失败
+)
+
+// 根据微信支付订单号查询订单.
+func (clt *Client) QueryTransactionById(transactionId string) (transaction *Transaction, err error) {
+	if transactionId == "" {
+		err = errors.New("empty transactionId")
+		return
+	}
+
+	transaction = &Transaction{}
+	path := "/v3/pay/transactions/id/" + url.PathEscape(transactionId) + "?mchid=" + url.QueryEscape(clt.mchId)
+	if err = clt.Do("GET", path, nil, transaction); err != nil {
+		transaction = nil
+		return
+	}
+	return
+}
+
+// 根据商户订单号查询订单.
+func (clt *Client) QueryTransactionByOutTradeNo(outTradeNo string) (transaction *Transaction, err error) {
+	if outTradeNo == "" {
+		err = errors.New("empty outTradeNo")
+		return
+	}
+
+	transaction = &Transaction{}
+	path := "/v3/pay/transactions/out-trade-no/" + url.PathEscape(outTradeNo) + "?mchid=" + url.QueryEscape(clt.mchId)
+	if err = clt.Do("GET", path, nil, transaction); err != nil {
+		transaction = nil
+		return
+	}
+	return
+}
+
+// 关闭订单.
+func (clt *Client) CloseTransaction(outTradeNo string) (err error) {
+	if outTradeNo == "" {
+		return errors.New("empty outTradeNo")
+	}
+
+	var request = struct {
+		MchId string `json:"mchid"`
+	}{
+		MchId: clt.mchId,
+	}
+	return clt.Do("POST", "/v3/pay/transactions/out-trade-no/"+url.PathEscape(outTradeNo)+"/close", &request, nil)
+}
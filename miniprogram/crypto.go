@@ -0,0 +1,142 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package miniprogram
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// 校验 wx.getUserInfo 返回的数据签名.
+//  signature = sha1(rawData + session_key)
+func VerifySignature(rawData, signature, sessionKey string) bool {
+	hashsum := sha1.Sum([]byte(rawData + sessionKey))
+	signature2 := hex.EncodeToString(hashsum[:])
+	return subtle.ConstantTimeCompare([]byte(signature), []byte(signature2)) == 1
+}
+
+// 解密开放数据 encryptedData, 算法为 AES-128-CBC, PKCS#7 填充.
+//  sessionKey, encryptedData, iv 都是 base64 编码的.
+func Decrypt(sessionKey, encryptedData, iv string) (plaintext []byte, err error) {
+	key, err := base64.StdEncoding.DecodeString(sessionKey)
+	if err != nil {
+		return
+	}
+	ciphertext, err := base64.StdEncoding.DecodeString(encryptedData)
+	if err != nil {
+		return
+	}
+	ivBytes, err := base64.StdEncoding.DecodeString(iv)
+	if err != nil {
+		return
+	}
+
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return
+	}
+	if len(ivBytes) != block.BlockSize() {
+		err = fmt.Errorf("the length of iv must be %d", block.BlockSize())
+		return
+	}
+	if len(ciphertext) == 0 || len(ciphertext)%block.BlockSize() != 0 {
+		err = errors.New("encryptedData is not a multiple of the block size")
+		return
+	}
+
+	plaintext = make([]byte, len(ciphertext))
+	cipher.NewCBCDecrypter(block, ivBytes).CryptBlocks(plaintext, ciphertext)
+
+	// PKCS#7 去除补位
+	amountToPad := int(plaintext[len(plaintext)-1])
+	if amountToPad < 1 || amountToPad > block.BlockSize() || amountToPad > len(plaintext) {
+		err = errors.New("invalid PKCS#7 padding")
+		plaintext = nil
+		return
+	}
+	plaintext = plaintext[:len(plaintext)-amountToPad]
+	return
+}
+
+// 开放数据的水印
+type Watermark struct {
+	AppId     string `json:"appid"`     // 敏感数据归属的 appid
+	Timestamp int64  `json:"timestamp"` // 敏感数据获取的时间戳
+}
+
+// 解密后的用户信息
+type UserInfo struct {
+	OpenId    string    `json:"openId"`
+	UnionId   string    `json:"unionId"`
+	Nickname  string    `json:"nickName"`
+	Gender    int       `json:"gender"` // 0: 未知, 1: 男, 2: 女
+	City      string    `json:"city"`
+	Province  string    `json:"province"`
+	Country   string    `json:"country"`
+	AvatarURL string    `json:"avatarUrl"`
+	Language  string    `json:"language"`
+	Watermark Watermark `json:"watermark"`
+}
+
+// 解密后的手机号信息
+type PhoneInfo struct {
+	PhoneNumber     string    `json:"phoneNumber"`     // 用户绑定的手机号, 国外手机号会有区号
+	PurePhoneNumber string    `json:"purePhoneNumber"` // 没有区号的手机号
+	CountryCode     string    `json:"countryCode"`     // 区号
+	Watermark       Watermark `json:"watermark"`
+}
+
+// 解密 wx.getUserInfo 返回的 encryptedData, 并校验水印的 appid.
+func DecryptUserInfo(appId, sessionKey, encryptedData, iv string) (info *UserInfo, err error) {
+	plaintext, err := Decrypt(sessionKey, encryptedData, iv)
+	if err != nil {
+		return
+	}
+
+	info = &UserInfo{}
+	if err = json.Unmarshal(plaintext, info); err != nil {
+		info = nil
+		return
+	}
+	if err = info.Watermark.check(appId); err != nil {
+		info = nil
+		return
+	}
+	return
+}
+
+// 解密 getPhoneNumber 返回的 encryptedData, 并校验水印的 appid.
+func DecryptPhoneInfo(appId, sessionKey, encryptedData, iv string) (info *PhoneInfo, err error) {
+	plaintext, err := Decrypt(sessionKey, encryptedData, iv)
+	if err != nil {
+		return
+	}
+
+	info = &PhoneInfo{}
+	if err = json.Unmarshal(plaintext, info); err != nil {
+		info = nil
+		return
+	}
+	if err = info.Watermark.check(appId); err != nil {
+		info = nil
+		return
+	}
+	return
+}
+
+func (watermark *Watermark) check(appId string) error {
+	if watermark.AppId != appId {
+		return fmt.Errorf("the watermark's appid mismatch, have: %s, want: %s", watermark.AppId, appId)
+	}
+	return nil
+}
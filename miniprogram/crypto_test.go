@@ -0,0 +1,134 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package miniprogram
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+)
+
+var (
+	testSessionKey = base64.StdEncoding.EncodeToString([]byte("0123456789abcdef"))
+	testIV         = base64.StdEncoding.EncodeToString([]byte("fedcba9876543210"))
+)
+
+// 用 AES-128-CBC, PKCS#7 填充加密, 模拟微信返回的 encryptedData.
+func testEncrypt(t *testing.T, sessionKey, iv string, plaintext []byte) string {
+	key, _ := base64.StdEncoding.DecodeString(sessionKey)
+	ivBytes, _ := base64.StdEncoding.DecodeString(iv)
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	amountToPad := block.BlockSize() - len(plaintext)%block.BlockSize()
+	src := append(append([]byte(nil), plaintext...), bytes.Repeat([]byte{byte(amountToPad)}, amountToPad)...)
+	ciphertext := make([]byte, len(src))
+	cipher.NewCBCEncrypter(block, ivBytes).CryptBlocks(ciphertext, src)
+	return base64.StdEncoding.EncodeToString(ciphertext)
+}
+
+func TestVerifySignature(t *testing.T) {
+	const rawData = `{"nickName":"Band","gender":1}`
+	hashsum := sha1.Sum([]byte(rawData + "session_key"))
+	signature := hex.EncodeToString(hashsum[:])
+
+	tests := []struct {
+		name       string
+		rawData    string
+		signature  string
+		sessionKey string
+		want       bool
+	}{
+		{"正确的签名", rawData, signature, "session_key", true},
+		{"错误的 session_key", rawData, signature, "session_key2", false},
+		{"数据被篡改", rawData + " ", signature, "session_key", false},
+		{"空签名", rawData, "", "session_key", false},
+	}
+	for _, tt := range tests {
+		if have := VerifySignature(tt.rawData, tt.signature, tt.sessionKey); have != tt.want {
+			t.Errorf("%s: have %v, want %v", tt.name, have, tt.want)
+		}
+	}
+}
+
+func TestDecrypt(t *testing.T) {
+	plaintext := []byte(`{"phoneNumber":"13580006666"}`)
+	encryptedData := testEncrypt(t, testSessionKey, testIV, plaintext)
+	// 刚好是 block 整数倍的明文会多补一个 block
+	fullBlock := testEncrypt(t, testSessionKey, testIV, []byte("0123456789abcdef"))
+
+	tests := []struct {
+		name          string
+		sessionKey    string
+		encryptedData string
+		iv            string
+		want          []byte
+		wantErr       bool
+	}{
+		{"正确的密文", testSessionKey, encryptedData, testIV, plaintext, false},
+		{"整数倍的明文", testSessionKey, fullBlock, testIV, []byte("0123456789abcdef"), false},
+		{"session_key 不是 base64", "!!!", encryptedData, testIV, nil, true},
+		{"session_key 长度不对", base64.StdEncoding.EncodeToString([]byte("short")), encryptedData, testIV, nil, true},
+		{"iv 长度不对", testSessionKey, encryptedData, base64.StdEncoding.EncodeToString([]byte("short")), nil, true},
+		{"密文被截断", testSessionKey, encryptedData[:8], testIV, nil, true},
+		{"空密文", testSessionKey, "", testIV, nil, true},
+		{"错误的 session_key", base64.StdEncoding.EncodeToString([]byte("abcdef0123456789")), encryptedData, testIV, nil, true},
+	}
+	for _, tt := range tests {
+		have, err := Decrypt(tt.sessionKey, tt.encryptedData, tt.iv)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: want error, have %q", tt.name, have)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		if !bytes.Equal(have, tt.want) {
+			t.Errorf("%s: have %q, want %q", tt.name, have, tt.want)
+		}
+	}
+}
+
+func TestDecryptPhoneInfo(t *testing.T) {
+	plaintext := []byte(`{"phoneNumber":"+86 13580006666","purePhoneNumber":"13580006666","countryCode":"86","watermark":{"appid":"wx4f4bc4dec97d474b","timestamp":1477314187}}`)
+	encryptedData := testEncrypt(t, testSessionKey, testIV, plaintext)
+	notJSON := testEncrypt(t, testSessionKey, testIV, []byte("not json"))
+
+	tests := []struct {
+		name          string
+		appId         string
+		encryptedData string
+		wantErr       bool
+	}{
+		{"正确的 appid", "wx4f4bc4dec97d474b", encryptedData, false},
+		{"水印的 appid 不对", "wx0000000000000000", encryptedData, true},
+		{"明文不是 json", "wx4f4bc4dec97d474b", notJSON, true},
+	}
+	for _, tt := range tests {
+		info, err := DecryptPhoneInfo(tt.appId, testSessionKey, tt.encryptedData, testIV)
+		if tt.wantErr {
+			if err == nil || info != nil {
+				t.Errorf("%s: want error and nil info, have %v, %v", tt.name, info, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		if info.PurePhoneNumber != "13580006666" || info.CountryCode != "86" || info.Watermark.Timestamp != 1477314187 {
+			t.Errorf("%s: have %+v", tt.name, info)
+		}
+	}
+}
@@ -0,0 +1,131 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package miniprogram
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/message/custom"
+)
+
+const MsgTypeLink = "link" // 图文链接消息, 只用于小程序客服消息
+
+// 小程序客服的图文链接消息
+type Link struct {
+	custom.CommonMessageHeader
+
+	Link struct {
+		Title       string `json:"title"`       // 消息标题
+		Description string `json:"description"` // 图文链接消息
+		URL         string `json:"url"`         // 图文链接消息被点击后跳转的链接
+		ThumbURL    string `json:"thumb_url"`   // 图文链接消息的图片链接, 支持 JPG, PNG 格式, 较好的效果为大图 640 X 320, 小图 80 X 80
+	} `json:"link"`
+}
+
+// 新建图文链接消息.
+func NewLink(toUser, title, description, url, thumbURL string) (link *Link) {
+	link = &Link{
+		CommonMessageHeader: custom.CommonMessageHeader{
+			ToUser:  toUser,
+			MsgType: MsgTypeLink,
+		},
+	}
+	link.Link.Title = title
+	link.Link.Description = description
+	link.Link.URL = url
+	link.Link.ThumbURL = thumbURL
+	return
+}
+
+// 发送客服消息, 文本.
+func (clt *Client) SendCustomText(toUser, content string, opts ...mp.CallOption) error {
+	return clt.sendCustom(custom.NewText(toUser, content, ""), opts...)
+}
+
+// 发送客服消息, 图片, mediaId 通过 UploadTempImage 得到.
+func (clt *Client) SendCustomImage(toUser, mediaId string, opts ...mp.CallOption) error {
+	return clt.sendCustom(custom.NewImage(toUser, mediaId, ""), opts...)
+}
+
+// 发送客服消息, 图文链接.
+func (clt *Client) SendCustomLink(msg *Link, opts ...mp.CallOption) error {
+	if msg == nil {
+		return errors.New("msg == nil")
+	}
+	return clt.sendCustom(msg, opts...)
+}
+
+// 发送客服消息, 小程序卡片, 只能跳转到当前小程序.
+//  thumbMediaId 通过 UploadTempImage 得到.
+func (clt *Client) SendCustomMiniProgramPage(toUser, title, pagePath, thumbMediaId string, opts ...mp.CallOption) error {
+	if pagePath == "" {
+		return errors.New("empty pagePath")
+	}
+	return clt.sendCustom(custom.NewMiniProgramPage(toUser, title, "", pagePath, thumbMediaId, ""), opts...)
+}
+
+func (clt *Client) sendCustom(msg interface{}, opts ...mp.CallOption) (err error) {
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/message/custom/send?access_token="
+	if err = clt.PostJSON(incompleteURL, msg, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 上传客服消息所用的临时图片素材, 返回 mediaId, 有效期为 3 天.
+func (clt *Client) UploadTempImage(_filepath string) (mediaId string, err error) {
+	file, err := os.Open(_filepath)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	return clt.uploadTempImageFromReader(filepath.Base(_filepath), file)
+}
+
+// 上传客服消息所用的临时图片素材, 返回 mediaId, 有效期为 3 天.
+//  NOTE: 参数 filename 不是文件路径, 是指定 multipart/form-data 里面文件名称
+func (clt *Client) UploadTempImageFromReader(filename string, reader io.Reader) (mediaId string, err error) {
+	if filename == "" {
+		err = errors.New("empty filename")
+		return
+	}
+	if reader == nil {
+		err = errors.New("nil reader")
+		return
+	}
+	return clt.uploadTempImageFromReader(filename, reader)
+}
+
+func (clt *Client) uploadTempImageFromReader(filename string, reader io.Reader) (mediaId string, err error) {
+	var result struct {
+		mp.Error
+		MediaId string `json:"media_id"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/media/upload?type=image&access_token="
+	if err = clt.UploadFromReader(incompleteURL, "media", filename, reader, "", nil, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	mediaId = result.MediaId
+	return
+}
@@ -0,0 +1,8 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 微信小程序接口.
+//  小程序获取 access_token 的方式和公众号相同, 所以可以直接用 mp.DefaultTokenServer 作为中控服务器.
+package miniprogram
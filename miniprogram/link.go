@@ -0,0 +1,160 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package miniprogram
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	ExpireTypeTime     = 0 // 到期失效, 由 LinkExpire.ExpireTime 指定失效时间
+	ExpireTypeInterval = 1 // 间隔天数失效, 由 LinkExpire.ExpireInterval 指定失效的天数
+)
+
+// URL Scheme 和 URL Link 的失效设置.
+//  如果为 nil 则生成的链接长期有效(受接口的数量限制).
+type LinkExpire struct {
+	ExpireType     int   // ExpireTypeTime 或 ExpireTypeInterval
+	ExpireTime     int64 // 到期失效的时间戳, ExpireType 为 ExpireTypeTime 时有效, 最长有效期为 30 天
+	ExpireInterval int   // 失效的天数, ExpireType 为 ExpireTypeInterval 时有效, 最长间隔天数为 30 天
+}
+
+// 跳转到的小程序页面
+type LinkTarget struct {
+	Path       string `json:"path,omitempty"`        // 小程序页面路径, 必须是已经发布的小程序存在的页面, 不可携带 query, 为空则跳转主页
+	Query      string `json:"query,omitempty"`       // 小程序页面的 query, 最大 1024 个字符
+	EnvVersion string `json:"env_version,omitempty"` // 要打开的小程序版本, EnvVersionRelease, EnvVersionTrial, EnvVersionDevelop
+}
+
+// 返回请求参数 is_expire, expire_type, expire_time, expire_interval 的值.
+func (expire *LinkExpire) values() (isExpire bool, expireType int, expireTime int64, expireInterval int) {
+	if expire == nil {
+		return
+	}
+	isExpire = true
+	expireType = expire.ExpireType
+	switch expire.ExpireType {
+	case ExpireTypeTime:
+		expireTime = expire.ExpireTime
+	case ExpireTypeInterval:
+		expireInterval = expire.ExpireInterval
+	}
+	return
+}
+
+// 获取小程序 URL Scheme, 形如 weixin://dl/business/?t=XXX, 用于短信, 邮件, 外部网页等打开小程序.
+//  target: 跳转到的小程序页面, 如果为 nil 则跳转主页
+//  expire: 失效设置, 如果为 nil 则长期有效
+func (clt *Client) GenerateScheme(target *LinkTarget, expire *LinkExpire, opts ...mp.CallOption) (openLink string, err error) {
+	var request = struct {
+		JumpWxa        *LinkTarget `json:"jump_wxa,omitempty"`
+		IsExpire       bool        `json:"is_expire"`
+		ExpireType     int         `json:"expire_type,omitempty"`
+		ExpireTime     int64       `json:"expire_time,omitempty"`
+		ExpireInterval int         `json:"expire_interval,omitempty"`
+	}{
+		JumpWxa: target,
+	}
+	request.IsExpire, request.ExpireType, request.ExpireTime, request.ExpireInterval = expire.values()
+
+	var result struct {
+		mp.Error
+		OpenLink string `json:"openlink"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/generatescheme?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	openLink = result.OpenLink
+	return
+}
+
+// 获取小程序 URL Link, 形如 https://wxaurl.cn/*TQL, 用于短信, 邮件, 网页, 微信内等拉起小程序.
+//  target: 跳转到的小程序页面, 如果为 nil 则跳转主页
+//  expire: 失效设置, 如果为 nil 则长期有效
+func (clt *Client) GenerateURLLink(target *LinkTarget, expire *LinkExpire, opts ...mp.CallOption) (urlLink string, err error) {
+	if target == nil {
+		target = &LinkTarget{}
+	}
+
+	var request = struct {
+		Path           string `json:"path,omitempty"`
+		Query          string `json:"query,omitempty"`
+		EnvVersion     string `json:"env_version,omitempty"`
+		IsExpire       bool   `json:"is_expire"`
+		ExpireType     int    `json:"expire_type,omitempty"`
+		ExpireTime     int64  `json:"expire_time,omitempty"`
+		ExpireInterval int    `json:"expire_interval,omitempty"`
+	}{
+		Path:       target.Path,
+		Query:      target.Query,
+		EnvVersion: target.EnvVersion,
+	}
+	request.IsExpire, request.ExpireType, request.ExpireTime, request.ExpireInterval = expire.values()
+
+	var result struct {
+		mp.Error
+		URLLink string `json:"url_link"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/generate_urllink?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	urlLink = result.URLLink
+	return
+}
+
+// 获取小程序 Short Link, 形如 #小程序://小程序名称/页面标题/短链, 适用于微信内拉起小程序.
+//  pageURL:     通过 Short Link 进入的小程序页面路径, 必须是已经发布的小程序存在的页面, 可携带 query, 最大 1024 个字符
+//  pageTitle:   页面标题, 不能包含违法信息, 超过 20 字符会用... 截断代替
+//  isPermanent: 生成的 Short Link 类型, 短期有效(30天)为 false, 永久有效为 true
+func (clt *Client) GenerateShortLink(pageURL, pageTitle string, isPermanent bool, opts ...mp.CallOption) (link string, err error) {
+	if pageURL == "" {
+		err = errors.New("empty pageURL")
+		return
+	}
+
+	var request = struct {
+		PageURL     string `json:"page_url"`
+		PageTitle   string `json:"page_title,omitempty"`
+		IsPermanent bool   `json:"is_permanent"`
+	}{
+		PageURL:     pageURL,
+		PageTitle:   pageTitle,
+		IsPermanent: isPermanent,
+	}
+
+	var result struct {
+		mp.Error
+		Link string `json:"link"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/genwxashortlink?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	link = result.Link
+	return
+}
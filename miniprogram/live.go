@@ -0,0 +1,423 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package miniprogram
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 创建直播间的参数
+type LiveRoom struct {
+	Name            string `json:"name"`                      // 直播间名字, 最短 3 个汉字, 最长 17 个汉字
+	CoverImg        string `json:"coverImg"`                  // 背景图, 填入 mediaID, 通过素材接口上传
+	StartTime       int64  `json:"startTime"`                 // 直播计划开始时间, 开播时间需要在当前时间的 10 分钟后并且在 6 个月以内
+	EndTime         int64  `json:"endTime"`                   // 直播计划结束时间, 开播时间和结束时间间隔不得短于 30 分钟, 不得超过 24 小时
+	AnchorName      string `json:"anchorName"`                // 主播昵称
+	AnchorWechat    string `json:"anchorWechat"`              // 主播微信号
+	SubAnchorWechat string `json:"subAnchorWechat,omitempty"` // 主播副号微信号
+	CreaterWechat   string `json:"createrWechat,omitempty"`   // 创建者微信号
+	ShareImg        string `json:"shareImg"`                  // 分享图, 填入 mediaID
+	FeedsImg        string `json:"feedsImg"`                  // 购物直播频道封面图, 填入 mediaID
+	IsFeedsPublic   int    `json:"isFeedsPublic"`             // 是否开启官方收录, 1: 开启, 0: 关闭
+	Type            int    `json:"type"`                      // 直播间类型, 1: 推流, 0: 手机直播
+	CloseLike       int    `json:"closeLike"`                 // 是否关闭点赞, 0: 开启, 1: 关闭
+	CloseGoods      int    `json:"closeGoods"`                // 是否关闭货架, 0: 开启, 1: 关闭
+	CloseComment    int    `json:"closeComment"`              // 是否关闭评论, 0: 开启, 1: 关闭
+	CloseReplay     int    `json:"closeReplay"`               // 是否关闭回放, 0: 开启, 1: 关闭
+	CloseShare      int    `json:"closeShare"`                // 是否关闭分享, 0: 开启, 1: 关闭
+	CloseKf         int    `json:"closeKf"`                   // 是否关闭客服, 0: 开启, 1: 关闭
+}
+
+// 创建直播间, 返回直播间 id.
+//  如果主播微信号没有实名认证, 返回的 qrcodeURL 为实名认证的二维码地址.
+func (clt *Client) LiveCreateRoom(room *LiveRoom, opts ...mp.CallOption) (roomId int64, qrcodeURL string, err error) {
+	if room == nil {
+		err = errors.New("nil LiveRoom")
+		return
+	}
+
+	var result struct {
+		mp.Error
+		RoomId    int64  `json:"roomId"`
+		QRCodeURL string `json:"qrcode_url"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxaapi/broadcast/room/create?access_token="
+	if err = clt.PostJSON(incompleteURL, room, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	roomId = result.RoomId
+	qrcodeURL = result.QRCodeURL
+	return
+}
+
+// 删除直播间.
+func (clt *Client) LiveDeleteRoom(roomId int64, opts ...mp.CallOption) (err error) {
+	var request = struct {
+		Id int64 `json:"id"`
+	}{
+		Id: roomId,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/wxaapi/broadcast/room/deleteroom?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 直播间的商品
+type LiveRoomGoods struct {
+	CoverImg        string `json:"cover_img"`
+	URL             string `json:"url"`
+	Name            string `json:"name"`
+	Price           int    `json:"price"`
+	Price2          int    `json:"price2"`
+	PriceType       int    `json:"price_type"`
+	GoodsId         int64  `json:"goods_id"`
+	ThirdPartyAppid string `json:"third_party_appid"`
+}
+
+// 直播间信息
+type LiveRoomInfo struct {
+	Name          string          `json:"name"`
+	RoomId        int64           `json:"roomid"`
+	CoverImg      string          `json:"cover_img"`
+	ShareImg      string          `json:"share_img"`
+	LiveStatus    int             `json:"live_status"` // 101: 直播中, 102: 未开始, 103: 已结束, 104: 禁播, 105: 暂停, 106: 异常, 107: 已过期
+	StartTime     int64           `json:"start_time"`
+	EndTime       int64           `json:"end_time"`
+	AnchorName    string          `json:"anchor_name"`
+	Goods         []LiveRoomGoods `json:"goods"`
+	LiveType      int             `json:"live_type"`
+	CloseLike     int             `json:"close_like"`
+	CloseGoods    int             `json:"close_goods"`
+	CloseComment  int             `json:"close_comment"`
+	CloseKf       int             `json:"close_kf"`
+	CloseReplay   int             `json:"close_replay"`
+	IsFeedsPublic int             `json:"is_feeds_public"`
+	CreaterOpenId string          `json:"creater_openid"`
+	FeedsImg      string          `json:"feeds_img"`
+}
+
+// 获取直播间列表.
+//  start: 起始拉取房间, start = 0 表示从第 1 个房间开始拉取
+//  limit: 每次拉取的个数上限, 建议 100 以内
+func (clt *Client) LiveGetRooms(start, limit int, opts ...mp.CallOption) (rooms []LiveRoomInfo, total int, err error) {
+	if limit <= 0 {
+		err = errors.New("limit should be greater than 0")
+		return
+	}
+
+	var request = struct {
+		Start int `json:"start"`
+		Limit int `json:"limit"`
+	}{
+		Start: start,
+		Limit: limit,
+	}
+
+	var result struct {
+		mp.Error
+		RoomInfo []LiveRoomInfo `json:"room_info"`
+		Total    int            `json:"total"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/business/getliveinfo?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rooms = result.RoomInfo
+	total = result.Total
+	return
+}
+
+// 直播回放的视频片段
+type LiveReplay struct {
+	ExpireTime string `json:"expire_time"` // 回放视频 url 过期时间
+	CreateTime string `json:"create_time"` // 回放视频创建时间
+	MediaURL   string `json:"media_url"`   // 回放视频链接
+}
+
+// 获取直播间的回放.
+//  start, limit 的含义同 LiveGetRooms.
+func (clt *Client) LiveGetReplay(roomId int64, start, limit int, opts ...mp.CallOption) (replays []LiveReplay, total int, err error) {
+	if limit <= 0 {
+		err = errors.New("limit should be greater than 0")
+		return
+	}
+
+	var request = struct {
+		Action string `json:"action"`
+		RoomId int64  `json:"room_id"`
+		Start  int    `json:"start"`
+		Limit  int    `json:"limit"`
+	}{
+		Action: "get_replay",
+		RoomId: roomId,
+		Start:  start,
+		Limit:  limit,
+	}
+
+	var result struct {
+		mp.Error
+		LiveReplay []LiveReplay `json:"live_replay"`
+		Total      int          `json:"total"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/business/getliveinfo?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	replays = result.LiveReplay
+	total = result.Total
+	return
+}
+
+// 往直播间导入已经审核通过的商品.
+func (clt *Client) LiveRoomAddGoods(roomId int64, goodsIds []int64, opts ...mp.CallOption) (err error) {
+	if len(goodsIds) == 0 {
+		return errors.New("empty goodsIds")
+	}
+
+	var request = struct {
+		Ids    []int64 `json:"ids"`
+		RoomId int64   `json:"roomId"`
+	}{
+		Ids:    goodsIds,
+		RoomId: roomId,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/wxaapi/broadcast/room/addgoods?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 直播商品
+type LiveGoods struct {
+	GoodsId         int64   `json:"goodsId,omitempty"`         // 商品 id, 更新商品时必填
+	CoverImgURL     string  `json:"coverImgUrl,omitempty"`     // 商品图片, 填入 mediaID
+	Name            string  `json:"name,omitempty"`            // 商品名称, 最长 14 个汉字
+	PriceType       int     `json:"priceType,omitempty"`       // 价格类型, 1: 一口价, 2: 价格区间, 3: 显示折扣价
+	Price           float64 `json:"price,omitempty"`           // 价格, 单位为元
+	Price2          float64 `json:"price2,omitempty"`          // 价格区间的右边界或者折扣价, 单位为元
+	URL             string  `json:"url,omitempty"`             // 商品详情页的小程序路径
+	ThirdPartyAppid string  `json:"thirdPartyAppid,omitempty"` // 当商品为第三方小程序的商品则填写为对应第三方小程序的 appid
+}
+
+// 添加商品并提审, 返回商品 id 和审核单 id.
+func (clt *Client) LiveAddGoods(goods *LiveGoods, opts ...mp.CallOption) (goodsId, auditId int64, err error) {
+	if goods == nil {
+		err = errors.New("nil LiveGoods")
+		return
+	}
+
+	var request = struct {
+		GoodsInfo *LiveGoods `json:"goodsInfo"`
+	}{
+		GoodsInfo: goods,
+	}
+
+	var result struct {
+		mp.Error
+		GoodsId int64 `json:"goodsId"`
+		AuditId int64 `json:"auditId"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxaapi/broadcast/goods/add?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	goodsId = result.GoodsId
+	auditId = result.AuditId
+	return
+}
+
+// 撤回商品的审核.
+func (clt *Client) LiveResetAuditGoods(goodsId, auditId int64, opts ...mp.CallOption) (err error) {
+	var request = struct {
+		AuditId int64 `json:"auditId"`
+		GoodsId int64 `json:"goodsId"`
+	}{
+		AuditId: auditId,
+		GoodsId: goodsId,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/wxaapi/broadcast/goods/resetaudit?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 重新提交审核, 返回审核单 id.
+func (clt *Client) LiveAuditGoods(goodsId int64, opts ...mp.CallOption) (auditId int64, err error) {
+	var request = struct {
+		GoodsId int64 `json:"goodsId"`
+	}{
+		GoodsId: goodsId,
+	}
+
+	var result struct {
+		mp.Error
+		AuditId int64 `json:"auditId"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxaapi/broadcast/goods/audit?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	auditId = result.AuditId
+	return
+}
+
+// 删除商品.
+func (clt *Client) LiveDeleteGoods(goodsId int64, opts ...mp.CallOption) (err error) {
+	var request = struct {
+		GoodsId int64 `json:"goodsId"`
+	}{
+		GoodsId: goodsId,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/wxaapi/broadcast/goods/delete?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 更新商品.
+//  审核通过的商品仅允许更新价格类型与价格, 审核中的商品不允许更新, 未审核的商品允许更新所有字段.
+func (clt *Client) LiveUpdateGoods(goods *LiveGoods, opts ...mp.CallOption) (err error) {
+	if goods == nil {
+		return errors.New("nil LiveGoods")
+	}
+	if goods.GoodsId == 0 {
+		return errors.New("GoodsId should not be zero")
+	}
+
+	var request = struct {
+		GoodsInfo *LiveGoods `json:"goodsInfo"`
+	}{
+		GoodsInfo: goods,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/wxaapi/broadcast/goods/update?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 商品的审核状态
+type LiveGoodsStatus struct {
+	GoodsId         int64   `json:"goods_id"`
+	CoverImgURL     string  `json:"cover_img_url"`
+	Name            string  `json:"name"`
+	AuditStatus     int     `json:"audit_status"` // 0: 未审核, 1: 审核中, 2: 审核通过, 3: 审核失败
+	URL             string  `json:"url"`
+	Price           float64 `json:"price"`
+	Price2          float64 `json:"price2"`
+	PriceType       int     `json:"price_type"`
+	ThirdPartyAppid string  `json:"third_party_appid"`
+}
+
+// 获取商品的信息与审核状态.
+func (clt *Client) LiveGetGoodsStatus(goodsIds []int64, opts ...mp.CallOption) (goods []LiveGoodsStatus, total int, err error) {
+	if len(goodsIds) == 0 {
+		err = errors.New("empty goodsIds")
+		return
+	}
+
+	var request = struct {
+		GoodsIds []int64 `json:"goods_ids"`
+	}{
+		GoodsIds: goodsIds,
+	}
+
+	var result struct {
+		mp.Error
+		Goods []LiveGoodsStatus `json:"goods"`
+		Total int               `json:"total"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/business/getgoodswarehouse?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	goods = result.Goods
+	total = result.Total
+	return
+}
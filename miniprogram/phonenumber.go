@@ -0,0 +1,50 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package miniprogram
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 通过手机号快速验证组件返回的动态令牌 code 获取用户手机号.
+//  code:  getPhoneNumber 事件返回的动态令牌, 只能使用一次, 5 分钟内有效
+//  appId: 小程序的 appid, 用于校验水印, 如果为空 "" 则不校验
+func (clt *Client) GetUserPhoneNumber(code, appId string, opts ...mp.CallOption) (info *PhoneInfo, err error) {
+	if code == "" {
+		err = errors.New("empty code")
+		return
+	}
+
+	var request = struct {
+		Code string `json:"code"`
+	}{
+		Code: code,
+	}
+
+	var result struct {
+		mp.Error
+		PhoneInfo PhoneInfo `json:"phone_info"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/business/getuserphonenumber?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	if appId != "" {
+		if err = result.PhoneInfo.Watermark.check(appId); err != nil {
+			return
+		}
+	}
+	info = &result.PhoneInfo
+	return
+}
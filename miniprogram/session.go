@@ -0,0 +1,64 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package miniprogram
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 登录凭证校验的结果
+type Session struct {
+	OpenId     string `json:"openid"`      // 用户唯一标识
+	SessionKey string `json:"session_key"` // 会话密钥, 不应该下发到小程序, 也不应该对外提供
+	UnionId    string `json:"unionid"`     // 用户在开放平台的唯一标识符, 满足 UnionID 下发条件时返回
+}
+
+// 登录凭证校验, 通过 wx.login 获得的临时登录凭证 code 换取 openid 和 session_key.
+//  appId:     小程序的 appid
+//  appSecret: 小程序的 appsecret
+//  jsCode:    wx.login 获取的 code
+//  如果 httpClient == nil 则默认用 http.DefaultClient.
+func Code2Session(appId, appSecret, jsCode string, httpClient *http.Client) (session *Session, err error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	_url := "https://api.weixin.qq.com/sns/jscode2session" +
+		"?appid=" + url.QueryEscape(appId) +
+		"&secret=" + url.QueryEscape(appSecret) +
+		"&js_code=" + url.QueryEscape(jsCode) +
+		"&grant_type=authorization_code"
+	httpResp, err := httpClient.Get(_url)
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
+	}
+
+	var result struct {
+		mp.Error
+		Session
+	}
+	if err = json.NewDecoder(httpResp.Body).Decode(&result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	session = &result.Session
+	return
+}
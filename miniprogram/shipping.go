@@ -0,0 +1,443 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package miniprogram
+
+import (
+	"errors"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 订单单号类型
+const (
+	OrderNumberTypeOutTradeNo    = 1 // 使用下单商户号和商户侧单号
+	OrderNumberTypeTransactionId = 2 // 使用微信支付单号
+)
+
+// 物流模式
+const (
+	LogisticsTypeExpress    = 1 // 实体物流配送, 采用快递公司进行实体物流配送
+	LogisticsTypeLocal      = 2 // 同城配送
+	LogisticsTypeVirtual    = 3 // 虚拟商品, 例如话费充值, 点卡等, 无实体配送形式
+	LogisticsTypeSelfPickup = 4 // 用户自提
+)
+
+// 发货模式
+const (
+	DeliveryModeUnified = 1 // 统一发货
+	DeliveryModeSplit   = 2 // 分拆发货
+)
+
+// 订单, 通过 OrderNumberType 指定使用微信支付单号还是商户号+商户侧单号
+type OrderKey struct {
+	OrderNumberType int    `json:"order_number_type"`        // OrderNumberTypeOutTradeNo 或 OrderNumberTypeTransactionId
+	TransactionId   string `json:"transaction_id,omitempty"` // 微信支付单号
+	MchId           string `json:"mchid,omitempty"`          // 支付下单商户的商户号
+	OutTradeNo      string `json:"out_trade_no,omitempty"`   // 商户系统内部订单号
+}
+
+func (key *OrderKey) check() error {
+	if key == nil {
+		return errors.New("nil OrderKey")
+	}
+	switch key.OrderNumberType {
+	case OrderNumberTypeTransactionId:
+		if key.TransactionId == "" {
+			return errors.New("empty TransactionId")
+		}
+	case OrderNumberTypeOutTradeNo:
+		if key.MchId == "" || key.OutTradeNo == "" {
+			return errors.New("empty MchId or OutTradeNo")
+		}
+	default:
+		return errors.New("invalid OrderNumberType")
+	}
+	return nil
+}
+
+// 联系方式, 当使用顺丰速运时必填其中一个, 需要掩码处理, 如 189****1234
+type ShippingContact struct {
+	ConsignorContact string `json:"consignor_contact,omitempty"` // 寄件人联系方式
+	ReceiverContact  string `json:"receiver_contact,omitempty"`  // 收件人联系方式
+}
+
+// 物流信息
+type ShippingItem struct {
+	TrackingNo     string           `json:"tracking_no,omitempty"`     // 物流单号, 物流快递发货时必填
+	ExpressCompany string           `json:"express_company,omitempty"` // 物流公司编码, 物流快递发货时必填
+	ItemDesc       string           `json:"item_desc"`                 // 商品信息, 例如: 微信红包抱枕*1个, 限120个字以内
+	Contact        *ShippingContact `json:"contact,omitempty"`
+}
+
+// 发货信息
+type ShippingInfo struct {
+	OrderKey       OrderKey       // 订单
+	LogisticsType  int            // 物流模式
+	DeliveryMode   int            // 发货模式
+	IsAllDelivered bool           // 分拆发货模式时是否已全部发货完成
+	ShippingList   []ShippingItem // 物流信息列表, 统一发货模式下只能有一条, 分拆发货模式最多10条
+	UploadTime     time.Time      // 上传时间, 为零值时使用当前时间
+	PayerOpenId    string         // 支付者 openid
+}
+
+func checkShippingList(logisticsType, deliveryMode int, list []ShippingItem) error {
+	switch deliveryMode {
+	case DeliveryModeUnified:
+		if len(list) != 1 {
+			return errors.New("ShippingList must have exactly 1 item in unified delivery mode")
+		}
+	case DeliveryModeSplit:
+		if n := len(list); n <= 0 || n > 10 {
+			return errors.New("ShippingList must have 1 to 10 items in split delivery mode")
+		}
+	default:
+		return errors.New("invalid DeliveryMode")
+	}
+	for i := range list {
+		if list[i].ItemDesc == "" {
+			return errors.New("empty ItemDesc")
+		}
+		if logisticsType == LogisticsTypeExpress && (list[i].TrackingNo == "" || list[i].ExpressCompany == "") {
+			return errors.New("TrackingNo and ExpressCompany are required for express logistics")
+		}
+	}
+	return nil
+}
+
+func uploadTime(t time.Time) string {
+	if t.IsZero() {
+		t = time.Now()
+	}
+	return t.Format(time.RFC3339)
+}
+
+type shippingPayer struct {
+	OpenId string `json:"openid"`
+}
+
+// 发货信息录入.
+//  用户支付完成后, 需要在10天内上传发货信息, 否则会影响资金结算.
+func (clt *Client) UploadShippingInfo(info *ShippingInfo, opts ...mp.CallOption) (err error) {
+	if info == nil {
+		return errors.New("nil ShippingInfo")
+	}
+	if err = info.OrderKey.check(); err != nil {
+		return
+	}
+	if err = checkShippingList(info.LogisticsType, info.DeliveryMode, info.ShippingList); err != nil {
+		return
+	}
+	if info.PayerOpenId == "" {
+		return errors.New("empty PayerOpenId")
+	}
+
+	var request = struct {
+		OrderKey       *OrderKey      `json:"order_key"`
+		LogisticsType  int            `json:"logistics_type"`
+		DeliveryMode   int            `json:"delivery_mode"`
+		IsAllDelivered bool           `json:"is_all_delivered,omitempty"`
+		ShippingList   []ShippingItem `json:"shipping_list"`
+		UploadTime     string         `json:"upload_time"`
+		Payer          shippingPayer  `json:"payer"`
+	}{
+		OrderKey:       &info.OrderKey,
+		LogisticsType:  info.LogisticsType,
+		DeliveryMode:   info.DeliveryMode,
+		IsAllDelivered: info.IsAllDelivered,
+		ShippingList:   info.ShippingList,
+		UploadTime:     uploadTime(info.UploadTime),
+		Payer:          shippingPayer{OpenId: info.PayerOpenId},
+	}
+	return clt.postShipping("https://api.weixin.qq.com/wxa/sec/order/upload_shipping_info?access_token=", &request, opts...)
+}
+
+// 合单支付的子单发货信息
+type SubOrderShippingInfo struct {
+	OrderKey       OrderKey
+	DeliveryMode   int
+	IsAllDelivered bool
+	ShippingList   []ShippingItem
+}
+
+// 合单支付的发货信息
+type CombinedShippingInfo struct {
+	OrderKey      OrderKey // 合单订单, 需要使用合单商户号+合单商户侧单号或者合单微信支付单号
+	LogisticsType int
+	SubOrders     []SubOrderShippingInfo // 子单物流详情
+	UploadTime    time.Time
+	PayerOpenId   string
+}
+
+// 合单支付的发货信息录入.
+func (clt *Client) UploadCombinedShippingInfo(info *CombinedShippingInfo, opts ...mp.CallOption) (err error) {
+	if info == nil {
+		return errors.New("nil CombinedShippingInfo")
+	}
+	if err = info.OrderKey.check(); err != nil {
+		return
+	}
+	if len(info.SubOrders) == 0 {
+		return errors.New("empty SubOrders")
+	}
+	if info.PayerOpenId == "" {
+		return errors.New("empty PayerOpenId")
+	}
+
+	type subOrder struct {
+		OrderKey       *OrderKey      `json:"order_key"`
+		DeliveryMode   int            `json:"delivery_mode"`
+		IsAllDelivered bool           `json:"is_all_delivered,omitempty"`
+		ShippingList   []ShippingItem `json:"shipping_list"`
+	}
+	subOrders := make([]subOrder, len(info.SubOrders))
+	for i := range info.SubOrders {
+		sub := &info.SubOrders[i]
+		if err = sub.OrderKey.check(); err != nil {
+			return
+		}
+		if err = checkShippingList(info.LogisticsType, sub.DeliveryMode, sub.ShippingList); err != nil {
+			return
+		}
+		subOrders[i] = subOrder{
+			OrderKey:       &sub.OrderKey,
+			DeliveryMode:   sub.DeliveryMode,
+			IsAllDelivered: sub.IsAllDelivered,
+			ShippingList:   sub.ShippingList,
+		}
+	}
+
+	var request = struct {
+		OrderKey      *OrderKey     `json:"order_key"`
+		LogisticsType int           `json:"logistics_type"`
+		SubOrders     []subOrder    `json:"sub_orders"`
+		UploadTime    string        `json:"upload_time"`
+		Payer         shippingPayer `json:"payer"`
+	}{
+		OrderKey:      &info.OrderKey,
+		LogisticsType: info.LogisticsType,
+		SubOrders:     subOrders,
+		UploadTime:    uploadTime(info.UploadTime),
+		Payer:         shippingPayer{OpenId: info.PayerOpenId},
+	}
+	return clt.postShipping("https://api.weixin.qq.com/wxa/sec/order/upload_combined_shipping_info?access_token=", &request, opts...)
+}
+
+// 订单状态
+const (
+	ShippingOrderStateToBeShipped = 1 // 待发货
+	ShippingOrderStateShipped     = 2 // 已发货
+	ShippingOrderStateConfirmed   = 3 // 确认收货
+	ShippingOrderStateCompleted   = 4 // 交易完成
+	ShippingOrderStateRefunded    = 5 // 已退款
+)
+
+// 订单的发货信息
+type ShippingOrder struct {
+	TransactionId   string `json:"transaction_id"`
+	MerchantId      string `json:"merchant_id"`
+	SubMerchantId   string `json:"sub_merchant_id"`
+	MerchantTradeNo string `json:"merchant_trade_no"`
+	Description     string `json:"description"`
+	PaidAmount      int64  `json:"paid_amount"` // 支付单实际支付金额, 单位为分
+	OpenId          string `json:"openid"`
+	TradeCreateTime int64  `json:"trade_create_time"`
+	PayTime         int64  `json:"pay_time"`
+	OrderState      int    `json:"order_state"`
+	InComplaint     bool   `json:"in_complaint"`
+	Shipping        struct {
+		DeliveryMode        int    `json:"delivery_mode"`
+		LogisticsType       int    `json:"logistics_type"`
+		FinishShipping      bool   `json:"finish_shipping"`
+		GoodsDesc           string `json:"goods_desc"`
+		FinishShippingCount int    `json:"finish_shipping_count"`
+		ShippingList        []struct {
+			TrackingNo     string `json:"tracking_no"`
+			ExpressCompany string `json:"express_company"`
+			GoodsDesc      string `json:"goods_desc"`
+			UploadTime     int64  `json:"upload_time"`
+		} `json:"shipping_list"`
+	} `json:"shipping"`
+}
+
+// 查询订单发货状态.
+//  transactionId 和 merchantId+merchantTradeNo 二选一
+func (clt *Client) GetShippingOrder(transactionId, merchantId, merchantTradeNo string, opts ...mp.CallOption) (order *ShippingOrder, err error) {
+	if transactionId == "" && (merchantId == "" || merchantTradeNo == "") {
+		err = errors.New("transactionId or merchantId+merchantTradeNo is required")
+		return
+	}
+
+	var request = struct {
+		TransactionId   string `json:"transaction_id,omitempty"`
+		MerchantId      string `json:"merchant_id,omitempty"`
+		MerchantTradeNo string `json:"merchant_trade_no,omitempty"`
+	}{
+		TransactionId:   transactionId,
+		MerchantId:      merchantId,
+		MerchantTradeNo: merchantTradeNo,
+	}
+
+	var result struct {
+		mp.Error
+		Order ShippingOrder `json:"order"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/sec/order/get_order?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	order = &result.Order
+	return
+}
+
+// 查询订单列表的条件, 字段都可以为零值
+type ShippingOrderListFilter struct {
+	PayTimeBegin int64  // 支付时间范围的开始时间戳
+	PayTimeEnd   int64  // 支付时间范围的结束时间戳
+	OrderState   int    // 订单状态
+	OpenId       string // 支付者 openid
+	LastIndex    string // 翻页时使用, 获取第一页时不用传入, 之后使用上一页返回的 lastIndex
+	PageSize     int    // 每页的数量, 最大100, 为0时默认100
+}
+
+// 查询订单列表.
+func (clt *Client) GetShippingOrderList(filter *ShippingOrderListFilter, opts ...mp.CallOption) (orders []ShippingOrder, lastIndex string, hasMore bool, err error) {
+	if filter == nil {
+		filter = &ShippingOrderListFilter{}
+	}
+
+	type timeRange struct {
+		BeginTime int64 `json:"begin_time,omitempty"`
+		EndTime   int64 `json:"end_time,omitempty"`
+	}
+	var request = struct {
+		PayTimeRange *timeRange `json:"pay_time_range,omitempty"`
+		OrderState   int        `json:"order_state,omitempty"`
+		OpenId       string     `json:"openid,omitempty"`
+		LastIndex    string     `json:"last_index,omitempty"`
+		PageSize     int        `json:"page_size,omitempty"`
+	}{
+		OrderState: filter.OrderState,
+		OpenId:     filter.OpenId,
+		LastIndex:  filter.LastIndex,
+		PageSize:   filter.PageSize,
+	}
+	if filter.PayTimeBegin != 0 || filter.PayTimeEnd != 0 {
+		request.PayTimeRange = &timeRange{
+			BeginTime: filter.PayTimeBegin,
+			EndTime:   filter.PayTimeEnd,
+		}
+	}
+
+	var result struct {
+		mp.Error
+		LastIndex string          `json:"last_index"`
+		HasMore   bool            `json:"has_more"`
+		OrderList []ShippingOrder `json:"order_list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/sec/order/get_order_list?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	orders = result.OrderList
+	lastIndex = result.LastIndex
+	hasMore = result.HasMore
+	return
+}
+
+// 确认收货提醒, 同城配送等场景下商家确认送达后提醒用户确认收货, 每个订单只能调用一次.
+//  transactionId 和 merchantId+merchantTradeNo 二选一
+//  receivedTime: 快递签收时间戳
+func (clt *Client) NotifyConfirmReceive(transactionId, merchantId, merchantTradeNo string, receivedTime int64, opts ...mp.CallOption) (err error) {
+	if transactionId == "" && (merchantId == "" || merchantTradeNo == "") {
+		return errors.New("transactionId or merchantId+merchantTradeNo is required")
+	}
+
+	var request = struct {
+		TransactionId   string `json:"transaction_id,omitempty"`
+		MerchantId      string `json:"merchant_id,omitempty"`
+		MerchantTradeNo string `json:"merchant_trade_no,omitempty"`
+		ReceivedTime    int64  `json:"received_time"`
+	}{
+		TransactionId:   transactionId,
+		MerchantId:      merchantId,
+		MerchantTradeNo: merchantTradeNo,
+		ReceivedTime:    receivedTime,
+	}
+	return clt.postShipping("https://api.weixin.qq.com/wxa/sec/order/notify_confirm_receive?access_token=", &request, opts...)
+}
+
+// 设置消息跳转路径, 用户点击发货消息时跳转到小程序的该页面.
+//  path: 小程序页面路径, 例如 pages/index/index, 可以带参数
+func (clt *Client) SetMsgJumpPath(path string, opts ...mp.CallOption) (err error) {
+	if path == "" {
+		return errors.New("empty path")
+	}
+
+	var request = struct {
+		Path string `json:"path"`
+	}{
+		Path: path,
+	}
+	return clt.postShipping("https://api.weixin.qq.com/wxa/sec/order/set_msg_jump_path?access_token=", &request, opts...)
+}
+
+// 查询小程序是否已开通发货信息管理服务.
+func (clt *Client) IsTradeManaged(appId string, opts ...mp.CallOption) (isManaged bool, err error) {
+	if appId == "" {
+		err = errors.New("empty appId")
+		return
+	}
+
+	var request = struct {
+		AppId string `json:"appid"`
+	}{
+		AppId: appId,
+	}
+
+	var result struct {
+		mp.Error
+		IsTradeManaged bool `json:"is_trade_managed"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxa/sec/order/is_trade_managed?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	isManaged = result.IsTradeManaged
+	return
+}
+
+func (clt *Client) postShipping(incompleteURL string, request interface{}, opts ...mp.CallOption) (err error) {
+	var result mp.Error
+
+	if err = clt.PostJSON(incompleteURL, request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
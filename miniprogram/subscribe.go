@@ -0,0 +1,341 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package miniprogram
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"unicode/utf8"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	MiniprogramStateDeveloper = "developer" // 开发版
+	MiniprogramStateTrial     = "trial"     // 体验版
+	MiniprogramStateFormal    = "formal"    // 正式版
+)
+
+// 订阅消息的模板数据
+type SubscribeDataItem struct {
+	Value string `json:"value"`
+}
+
+// 订阅消息
+type SubscribeMessage struct {
+	ToUser           string                       `json:"touser"`                      // 接收者(用户)的 openid
+	TemplateId       string                       `json:"template_id"`                 // 所需下发的订阅模板id
+	Page             string                       `json:"page,omitempty"`              // 点击模板卡片后的跳转页面, 仅限本小程序内的页面, 支持带参数
+	MiniprogramState string                       `json:"miniprogram_state,omitempty"` // 跳转小程序类型, 默认为正式版
+	Lang             string                       `json:"lang,omitempty"`              // 进入小程序查看的语言类型, 支持 zh_CN, en_US, zh_HK, zh_TW, 默认为 zh_CN
+	Data             map[string]SubscribeDataItem `json:"data"`                        // 模板内容, 格式形如 { "key1": { "value": any }, "key2": { "value": any } }
+}
+
+// 发送订阅消息.
+//  发送之前会用 ValidateSubscribeData 校验模板数据, 以避免 47003 错误.
+func (clt *Client) SendSubscribeMessage(msg *SubscribeMessage, opts ...mp.CallOption) (err error) {
+	if msg == nil {
+		return errors.New("nil SubscribeMessage")
+	}
+	if err = ValidateSubscribeData(msg.Data); err != nil {
+		return
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/message/subscribe/send?access_token="
+	if err = clt.PostJSON(incompleteURL, msg, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+var (
+	subscribeKeyRegexp = regexp.MustCompile(`^([a-z_]+)[0-9]+$`)
+
+	subscribeNumberRegexp   = regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+	subscribeLetterRegexp   = regexp.MustCompile(`^[a-zA-Z]+$`)
+	subscribeAmountRegexp   = regexp.MustCompile(`^[^0-9.]?[0-9]{1,10}(\.[0-9]{1,2})?[^0-9.]?$`)
+	subscribePhoneRegexp    = regexp.MustCompile(`^[0-9+\-() ]+$`)
+	subscribeCharacterRegex = regexp.MustCompile(`^[0-9a-zA-Z!-/:-@\[-\x60{-~]+$`)
+)
+
+// 校验订阅消息的模板数据.
+//  模板数据的 key 形如 thing1, number2, 前缀为参数类型, 不同类型的参数有不同的长度和格式限制,
+//  不满足限制时微信会返回 47003 错误. 无法识别的参数类型不校验.
+func ValidateSubscribeData(data map[string]SubscribeDataItem) error {
+	for key, item := range data {
+		m := subscribeKeyRegexp.FindStringSubmatch(key)
+		if m == nil {
+			continue
+		}
+		if err := validateSubscribeValue(m[1], item.Value); err != nil {
+			return fmt.Errorf("invalid subscribe data %s: %q, %s", key, item.Value, err)
+		}
+	}
+	return nil
+}
+
+func validateSubscribeValue(typ, value string) error {
+	n := utf8.RuneCountInString(value)
+	if n == 0 {
+		return errors.New("empty value")
+	}
+
+	switch typ {
+	case "thing": // 事物, 20 个以内字符
+		return maxRunes(n, 20)
+	case "number": // 数字, 32 位以内数字, 只能数字, 可带小数
+		if !subscribeNumberRegexp.MatchString(value) {
+			return errors.New("number must be digits with optional decimals")
+		}
+		return maxRunes(n, 32)
+	case "letter": // 字母, 32 位以内字母
+		if !subscribeLetterRegexp.MatchString(value) {
+			return errors.New("letter must be letters")
+		}
+		return maxRunes(n, 32)
+	case "symbol": // 符号, 5 位以内符号
+		return maxRunes(n, 5)
+	case "character_string": // 字符串, 32 位以内数字, 字母或符号
+		if !subscribeCharacterRegex.MatchString(value) {
+			return errors.New("character_string must be digits, letters or symbols")
+		}
+		return maxRunes(n, 32)
+	case "time", "date": // 时间或日期, 24 小时制时间格式(支持+年月日), 支持填时间段, 两个时间点之间用 "~" 符号连接
+		return maxRunes(n, 64)
+	case "amount": // 金额, 1 个币种符号 + 10 位以内纯数字, 可带小数, 结尾可带"元"
+		if !subscribeAmountRegexp.MatchString(value) {
+			return errors.New("amount must be an optional currency symbol followed by at most 10 digits")
+		}
+		return nil
+	case "phone_number": // 电话, 17 位以内, 数字, 符号
+		if !subscribePhoneRegexp.MatchString(value) {
+			return errors.New("phone_number must be digits or symbols")
+		}
+		return maxRunes(n, 17)
+	case "car_number": // 车牌, 8 位以内, 第一位与最后一位可为汉字, 其余为字母或数字
+		return maxRunes(n, 8)
+	case "name": // 姓名, 10 个以内纯汉字或 20 个以内纯字母或符号
+		if isASCII(value) {
+			return maxRunes(n, 20)
+		}
+		return maxRunes(n, 10)
+	case "phrase": // 汉字, 5 个以内汉字
+		return maxRunes(n, 5)
+	}
+	return nil
+}
+
+func maxRunes(n, max int) error {
+	if n > max {
+		return errors.New("the length must not exceed " + strconv.Itoa(max))
+	}
+	return nil
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
+
+// 小程序账号的类目
+type Category struct {
+	Id   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// 获取小程序账号的类目.
+func (clt *Client) GetCategory(opts ...mp.CallOption) (categories []Category, err error) {
+	var result struct {
+		mp.Error
+		Data []Category `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxaapi/newtmpl/getcategory?access_token="
+	if err = clt.GetJSON(incompleteURL, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	categories = result.Data
+	return
+}
+
+// 公共模板库的模板标题
+type PubTemplateTitle struct {
+	Tid        int    `json:"tid"`        // 模版标题 id
+	Title      string `json:"title"`      // 模版标题
+	Type       int    `json:"type"`       // 模版类型, 2 为一次性订阅, 3 为长期订阅
+	CategoryId string `json:"categoryId"` // 模版所属类目 id
+}
+
+// 获取帐号所属类目下的公共模板标题.
+//  ids:   类目 id, 多个用逗号隔开
+//  start: 用于分页, 表示从 start 开始, 从 0 开始计数
+//  limit: 用于分页, 表示拉取 limit 条记录, 最大为 30
+func (clt *Client) GetPubTemplateTitles(ids string, start, limit int, opts ...mp.CallOption) (titles []PubTemplateTitle, count int, err error) {
+	if limit <= 0 {
+		err = errors.New("limit should be greater than 0")
+		return
+	}
+
+	var result struct {
+		mp.Error
+		Count int                `json:"count"`
+		Data  []PubTemplateTitle `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxaapi/newtmpl/getpubtemplatetitles?ids=" + url.QueryEscape(ids) +
+		"&start=" + strconv.Itoa(start) + "&limit=" + strconv.Itoa(limit) + "&access_token="
+	if err = clt.GetJSON(incompleteURL, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	titles = result.Data
+	count = result.Count
+	return
+}
+
+// 公共模板库的模板关键词
+type PubTemplateKeyword struct {
+	Kid     int    `json:"kid"`     // 关键词 id, 选用模板时需要
+	Name    string `json:"name"`    // 关键词内容
+	Example string `json:"example"` // 关键词内容对应的示例
+	Rule    string `json:"rule"`    // 参数类型, 比如 thing, number
+}
+
+// 获取模板标题下的关键词列表.
+func (clt *Client) GetPubTemplateKeywords(tid int, opts ...mp.CallOption) (keywords []PubTemplateKeyword, err error) {
+	var result struct {
+		mp.Error
+		Data []PubTemplateKeyword `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxaapi/newtmpl/getpubtemplatekeywords?tid=" + strconv.Itoa(tid) + "&access_token="
+	if err = clt.GetJSON(incompleteURL, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	keywords = result.Data
+	return
+}
+
+// 组合模板并添加至帐号下的个人模板库, 返回添加至帐号下的模板 id.
+//  tid:       模板标题 id
+//  kidList:   模板关键词列表, 最多支持 5 个, 最少 2 个关键词组合
+//  sceneDesc: 服务场景描述, 15 个字以内
+func (clt *Client) AddTemplate(tid int, kidList []int, sceneDesc string, opts ...mp.CallOption) (priTmplId string, err error) {
+	if len(kidList) == 0 {
+		err = errors.New("empty kidList")
+		return
+	}
+
+	var request = struct {
+		Tid       string `json:"tid"`
+		KidList   []int  `json:"kidList"`
+		SceneDesc string `json:"sceneDesc,omitempty"`
+	}{
+		Tid:       strconv.Itoa(tid),
+		KidList:   kidList,
+		SceneDesc: sceneDesc,
+	}
+
+	var result struct {
+		mp.Error
+		PriTmplId string `json:"priTmplId"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxaapi/newtmpl/addtemplate?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	priTmplId = result.PriTmplId
+	return
+}
+
+// 删除帐号下的个人模板.
+func (clt *Client) DeleteTemplate(priTmplId string, opts ...mp.CallOption) (err error) {
+	if priTmplId == "" {
+		return errors.New("empty priTmplId")
+	}
+
+	var request = struct {
+		PriTmplId string `json:"priTmplId"`
+	}{
+		PriTmplId: priTmplId,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/wxaapi/newtmpl/deltemplate?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 个人模板
+type Template struct {
+	PriTmplId string `json:"priTmplId"` // 添加至帐号下的模板 id, 发送小程序订阅消息时所需
+	Title     string `json:"title"`     // 模版标题
+	Content   string `json:"content"`   // 模版内容
+	Example   string `json:"example"`   // 模板内容示例
+	Type      int    `json:"type"`      // 模版类型, 2 为一次性订阅, 3 为长期订阅
+}
+
+// 获取当前帐号下的个人模板列表.
+func (clt *Client) GetTemplateList(opts ...mp.CallOption) (templates []Template, err error) {
+	var result struct {
+		mp.Error
+		Data []Template `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/wxaapi/newtmpl/gettemplate?access_token="
+	if err = clt.GetJSON(incompleteURL, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	templates = result.Data
+	return
+}
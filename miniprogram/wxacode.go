@@ -0,0 +1,238 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package miniprogram
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+
+	wechatjson "github.com/chanxuehong/wechat/json"
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	EnvVersionRelease = "release" // 正式版
+	EnvVersionTrial   = "trial"   // 体验版
+	EnvVersionDevelop = "develop" // 开发版
+)
+
+// 小程序码线条的颜色
+type LineColor struct {
+	R int `json:"r"`
+	G int `json:"g"`
+	B int `json:"b"`
+}
+
+// 获取小程序码的可选参数
+type WXACodeOptions struct {
+	Page       string     // 页面路径, 只用于 GetWXACodeUnlimit, 不能携带参数, 如果为空 "" 则默认为主页
+	CheckPath  *bool      // 检查 Page 是否存在, 只用于 GetWXACodeUnlimit, 如果为 nil 则默认为 true
+	EnvVersion string     // 要打开的小程序版本, EnvVersionRelease, EnvVersionTrial, EnvVersionDevelop, 如果为空 "" 则默认为正式版
+	Width      int        // 二维码的宽度, 单位 px, 最小 280px, 最大 1280px, 如果为 0 则默认为 430px
+	AutoColor  bool       // 自动配置线条颜色
+	LineColor  *LineColor // AutoColor 为 false 时生效, 线条的颜色
+	IsHyaline  bool       // 是否需要透明底色
+}
+
+// 获取小程序码, 适用于需要的码数量较少的业务场景, 与 CreateWXAQRCode 总共生成的码数量限制为 100,000.
+//  path: 扫码进入的小程序页面路径, 最大长度 1024 个字符, 可以携带参数
+//  opts: 可选参数, 可以为 nil
+func (clt *Client) GetWXACode(path string, opts *WXACodeOptions) (image []byte, err error) {
+	buf := bytes.NewBuffer(make([]byte, 0, 32<<10))
+	if err = clt.GetWXACodeToWriter(path, opts, buf); err != nil {
+		return
+	}
+	image = buf.Bytes()
+	return
+}
+
+// 获取小程序码, 写入到 writer, 参考 GetWXACode.
+//
+//wechat:endpoint quota=wxacode
+func (clt *Client) GetWXACodeToWriter(path string, opts *WXACodeOptions, writer io.Writer) (err error) {
+	if path == "" {
+		return errors.New("empty path")
+	}
+	if writer == nil {
+		return errors.New("nil writer")
+	}
+	if opts == nil {
+		opts = &WXACodeOptions{}
+	}
+
+	var request = struct {
+		Path       string     `json:"path"`
+		EnvVersion string     `json:"env_version,omitempty"`
+		Width      int        `json:"width,omitempty"`
+		AutoColor  bool       `json:"auto_color,omitempty"`
+		LineColor  *LineColor `json:"line_color,omitempty"`
+		IsHyaline  bool       `json:"is_hyaline,omitempty"`
+	}{
+		Path:       path,
+		EnvVersion: opts.EnvVersion,
+		Width:      opts.Width,
+		AutoColor:  opts.AutoColor,
+		LineColor:  opts.LineColor,
+		IsHyaline:  opts.IsHyaline,
+	}
+	return clt.postImageToWriter("https://api.weixin.qq.com/wxa/getwxacode?access_token=", &request, writer)
+}
+
+// 获取小程序码, 适用于需要的码数量极多的业务场景, 生成的小程序码永久有效, 数量暂无限制.
+//  scene: 最大 32 个可见字符, 只支持数字, 大小写英文以及部分特殊字符 !#$&'()*+,/:;=?@-._~
+//  opts:  可选参数, 可以为 nil, 页面路径用 opts.Page 指定
+func (clt *Client) GetWXACodeUnlimit(scene string, opts *WXACodeOptions) (image []byte, err error) {
+	buf := bytes.NewBuffer(make([]byte, 0, 32<<10))
+	if err = clt.GetWXACodeUnlimitToWriter(scene, opts, buf); err != nil {
+		return
+	}
+	image = buf.Bytes()
+	return
+}
+
+// 获取不限制数量的小程序码, 写入到 writer, 参考 GetWXACodeUnlimit.
+func (clt *Client) GetWXACodeUnlimitToWriter(scene string, opts *WXACodeOptions, writer io.Writer) (err error) {
+	if scene == "" {
+		return errors.New("empty scene")
+	}
+	if writer == nil {
+		return errors.New("nil writer")
+	}
+	if opts == nil {
+		opts = &WXACodeOptions{}
+	}
+
+	var request = struct {
+		Scene      string     `json:"scene"`
+		Page       string     `json:"page,omitempty"`
+		CheckPath  *bool      `json:"check_path,omitempty"`
+		EnvVersion string     `json:"env_version,omitempty"`
+		Width      int        `json:"width,omitempty"`
+		AutoColor  bool       `json:"auto_color,omitempty"`
+		LineColor  *LineColor `json:"line_color,omitempty"`
+		IsHyaline  bool       `json:"is_hyaline,omitempty"`
+	}{
+		Scene:      scene,
+		Page:       opts.Page,
+		CheckPath:  opts.CheckPath,
+		EnvVersion: opts.EnvVersion,
+		Width:      opts.Width,
+		AutoColor:  opts.AutoColor,
+		LineColor:  opts.LineColor,
+		IsHyaline:  opts.IsHyaline,
+	}
+	return clt.postImageToWriter("https://api.weixin.qq.com/wxa/getwxacodeunlimit?access_token=", &request, writer)
+}
+
+// 获取小程序二维码, 适用于需要的码数量较少的业务场景, 与 GetWXACode 总共生成的码数量限制为 100,000.
+//  path:  扫码进入的小程序页面路径, 最大长度 128 字节, 可以携带参数
+//  width: 二维码的宽度, 单位 px, 最小 280px, 最大 1280px, 如果为 0 则默认为 430px
+func (clt *Client) CreateWXAQRCode(path string, width int) (image []byte, err error) {
+	buf := bytes.NewBuffer(make([]byte, 0, 32<<10))
+	if err = clt.CreateWXAQRCodeToWriter(path, width, buf); err != nil {
+		return
+	}
+	image = buf.Bytes()
+	return
+}
+
+// 获取小程序二维码, 写入到 writer, 参考 CreateWXAQRCode.
+//
+//wechat:endpoint quota=wxacode
+func (clt *Client) CreateWXAQRCodeToWriter(path string, width int, writer io.Writer) (err error) {
+	if path == "" {
+		return errors.New("empty path")
+	}
+	if writer == nil {
+		return errors.New("nil writer")
+	}
+
+	var request = struct {
+		Path  string `json:"path"`
+		Width int    `json:"width,omitempty"`
+	}{
+		Path:  path,
+		Width: width,
+	}
+	return clt.postImageToWriter("https://api.weixin.qq.com/cgi-bin/wxaapp/createwxaqrcode?access_token=", &request, writer)
+}
+
+// 请求返回图片的接口, 成功时把图片写入到 writer.
+//  出错时接口返回的是 JSON, 但是 Content-Type 有时候也是图片类型, 所以还需要根据内容判断.
+func (clt *Client) postImageToWriter(incompleteURL string, request interface{}, writer io.Writer) (err error) {
+	buf := new(bytes.Buffer)
+	if err = wechatjson.NewEncoder(buf).Encode(request); err != nil {
+		return
+	}
+	requestBody := buf.Bytes()
+
+	token, err := clt.Token()
+	if err != nil {
+		return
+	}
+
+	hasRetried := false
+RETRY:
+	finalURL := incompleteURL + url.QueryEscape(token)
+
+	httpResp, err := clt.MediaClient().Post(finalURL, "application/json; charset=utf-8", bytes.NewReader(requestBody))
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		return fmt.Errorf("http.Status: %s", httpResp.Status)
+	}
+
+	body := bufio.NewReader(httpResp.Body)
+	if !isJSONResponse(httpResp.Header.Get("Content-Type"), body) { // 返回的是图片
+		_, err = io.Copy(writer, body)
+		return
+	}
+
+	// 返回的是错误信息
+	var result mp.Error
+	if err = json.NewDecoder(body).Decode(&result); err != nil {
+		return
+	}
+
+	switch result.ErrCode {
+	case mp.ErrCodeOK:
+		return // 基本不会出现
+	case mp.ErrCodeInvalidCredential, mp.ErrCodeTimeout: // 失效(过期)重试一次
+		if !hasRetried {
+			hasRetried = true
+			httpResp.Body.Close()
+
+			if token, err = clt.TokenRefresh(); err != nil {
+				return
+			}
+			goto RETRY
+		}
+		fallthrough
+	default:
+		err = &result
+		return
+	}
+}
+
+// 判断返回的是不是 JSON, 图片的第一个字节不会是 '{'.
+func isJSONResponse(contentType string, body *bufio.Reader) bool {
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "application/json" || mediaType == "text/plain" {
+		return true
+	}
+	head, err := body.Peek(1)
+	return err == nil && head[0] == '{'
+}
@@ -39,7 +39,7 @@ type TemporaryQRCode struct {
 // 创建临时二维码
 //  SceneId:       场景值ID，为32位非0整型
 //  ExpireSeconds: 二维码有效时间，以秒为单位。 最大不超过1800。
-func (clt *Client) CreateTemporaryQRCode(SceneId uint32, ExpireSeconds int) (qrcode *TemporaryQRCode, err error) {
+func (clt *Client) CreateTemporaryQRCode(SceneId uint32, ExpireSeconds int, opts ...mp.CallOption) (qrcode *TemporaryQRCode, err error) {
 	if SceneId == 0 {
 		err = errors.New("SceneId should be greater than 0")
 		return
@@ -67,7 +67,7 @@ func (clt *Client) CreateTemporaryQRCode(SceneId uint32, ExpireSeconds int) (qrc
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/qrcode/create?access_token="
-	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
 		return
 	}
 
@@ -82,7 +82,7 @@ func (clt *Client) CreateTemporaryQRCode(SceneId uint32, ExpireSeconds int) (qrc
 
 // 创建永久二维码
 //  SceneId: 场景值ID，目前参数只支持1--100000
-func (clt *Client) CreatePermanentQRCode(SceneId uint32) (qrcode *PermanentQRCode, err error) {
+func (clt *Client) CreatePermanentQRCode(SceneId uint32, opts ...mp.CallOption) (qrcode *PermanentQRCode, err error) {
 	if SceneId == 0 {
 		err = errors.New("SceneId should be greater than 0")
 		return
@@ -104,7 +104,7 @@ func (clt *Client) CreatePermanentQRCode(SceneId uint32) (qrcode *PermanentQRCod
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/qrcode/create?access_token="
-	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
 		return
 	}
 
@@ -119,7 +119,7 @@ func (clt *Client) CreatePermanentQRCode(SceneId uint32) (qrcode *PermanentQRCod
 
 // 创建永久二维码
 //  SceneString: 场景值ID（字符串形式的ID），字符串类型，长度限制为1到64
-func (clt *Client) CreatePermanentQRCodeWithSceneString(SceneString string) (qrcode *PermanentQRCode, err error) {
+func (clt *Client) CreatePermanentQRCodeWithSceneString(SceneString string, opts ...mp.CallOption) (qrcode *PermanentQRCode, err error) {
 	if SceneString == "" {
 		err = errors.New("SceneString should not be empty")
 		return
@@ -141,7 +141,7 @@ func (clt *Client) CreatePermanentQRCodeWithSceneString(SceneString string) (qrc
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/qrcode/create?access_token="
-	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
 		return
 	}
 
@@ -165,7 +165,7 @@ func (clt *Client) QRCodeDownloadToWriter(ticket string, writer io.Writer) (err
 	if clt.HttpClient == nil {
 		clt.HttpClient = http.DefaultClient
 	}
-	return qrcodeDownloadToWriter(ticket, writer, clt.HttpClient)
+	return qrcodeDownloadToWriter(ticket, writer, clt.MediaClient())
 }
 
 // 通过ticket换取二维码, 写入到 filepath 路径的文件.
@@ -183,7 +183,7 @@ func (clt *Client) QRCodeDownload(ticket, filepath string) (err error) {
 	if clt.HttpClient == nil {
 		clt.HttpClient = http.DefaultClient
 	}
-	return qrcodeDownloadToWriter(ticket, file, clt.HttpClient)
+	return qrcodeDownloadToWriter(ticket, file, clt.MediaClient())
 }
 
 // 通过ticket换取二维码, 写入到 writer.
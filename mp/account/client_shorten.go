@@ -0,0 +1,97 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package account
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	ShortKeyDefaultExpireSeconds = 2592000 // short_key 默认的有效期, 30天
+	ShortKeyMaxExpireSeconds     = 2592000 // short_key 最长的有效期, 30天
+	ShortKeyLongDataLengthLimit  = 4096    // long_data 的最大长度, 4KB
+)
+
+// 短 key 托管: 把不超过 4KB 的长信息转成短 key, 用于二维码等场景, 以后用 ShortKeyFetch 换回长信息.
+//  expireSeconds: 过期秒数, 最大值为 2592000 (即30天), 小于等于 0 时默认为 2592000
+func (clt *Client) ShortKeyGen(longData string, expireSeconds int, opts ...mp.CallOption) (shortKey string, err error) {
+	if longData == "" {
+		err = errors.New("empty longData")
+		return
+	}
+	if len(longData) > ShortKeyLongDataLengthLimit {
+		err = errors.New("longData too long")
+		return
+	}
+	if expireSeconds <= 0 || expireSeconds > ShortKeyMaxExpireSeconds {
+		expireSeconds = ShortKeyDefaultExpireSeconds
+	}
+
+	var request = struct {
+		LongData      string `json:"long_data"`
+		ExpireSeconds int    `json:"expire_seconds"`
+	}{
+		LongData:      longData,
+		ExpireSeconds: expireSeconds,
+	}
+
+	var result struct {
+		mp.Error
+		ShortKey string `json:"short_key"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/shorten/gen?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	shortKey = result.ShortKey
+	return
+}
+
+// short_key 对应的长信息
+type ShortKeyInfo struct {
+	LongData      string `json:"long_data"`      // 长信息
+	CreateTime    int64  `json:"create_time"`    // 创建的时间戳
+	ExpireSeconds int64  `json:"expire_seconds"` // 剩余的过期秒数
+}
+
+// 用 short_key 换回长信息.
+func (clt *Client) ShortKeyFetch(shortKey string, opts ...mp.CallOption) (info *ShortKeyInfo, err error) {
+	if shortKey == "" {
+		err = errors.New("empty shortKey")
+		return
+	}
+
+	var request = struct {
+		ShortKey string `json:"short_key"`
+	}{
+		ShortKey: shortKey,
+	}
+
+	var result struct {
+		mp.Error
+		ShortKeyInfo
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/shorten/fetch?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	info = &result.ShortKeyInfo
+	return
+}
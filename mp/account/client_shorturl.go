@@ -13,7 +13,7 @@ import (
 //  主要使用场景：
 //  开发者用于生成二维码的原链接（商品、支付二维码等）太长导致扫码速度和成功率下降，
 //  将原长链接通过此接口转成短链接再生成二维码将大大提升扫码速度和成功率。
-func (clt *Client) ShortURL(LongURL string) (ShortURL string, err error) {
+func (clt *Client) ShortURL(LongURL string, opts ...mp.CallOption) (ShortURL string, err error) {
 	var request = struct {
 		Action  string `json:"action"`
 		LongURL string `json:"long_url"`
@@ -28,7 +28,7 @@ func (clt *Client) ShortURL(LongURL string) (ShortURL string, err error) {
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/shorturl?access_token="
-	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
 		return
 	}
 
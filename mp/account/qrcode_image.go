@@ -0,0 +1,191 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package account
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	"image/png"
+	"io"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 二维码图片的一个处理步骤.
+type QRCodeImageStep interface {
+	Apply(img image.Image) (image.Image, error)
+}
+
+type QRCodeImageStepFunc func(img image.Image) (image.Image, error)
+
+func (fn QRCodeImageStepFunc) Apply(img image.Image) (image.Image, error) { return fn(img) }
+
+// 把二维码缩放成 size*size 像素.
+func QRCodeResize(size int) QRCodeImageStep {
+	return QRCodeImageStepFunc(func(img image.Image) (image.Image, error) {
+		if size <= 0 {
+			return nil, fmt.Errorf("invalid size: %d", size)
+		}
+		return mp.ResizeImage(img, size, size), nil
+	})
+}
+
+const (
+	DefaultQRCodeLogoRatio = 0.2 // logo 默认占二维码边长的比例
+	MaxQRCodeLogoRatio     = 0.3 // logo 太大二维码会扫不出来
+)
+
+// 在二维码中间叠加 logo.
+type QRCodeLogo struct {
+	Logo    image.Image
+	Ratio   float64     // logo 的边长占二维码边长的比例, 默认为 DefaultQRCodeLogoRatio, 最大为 MaxQRCodeLogoRatio
+	Border  int         // logo 四周的边框宽度(像素), 0 表示没有边框
+	BgColor color.Color // 边框的颜色, 默认为白色
+}
+
+var _ QRCodeImageStep = (*QRCodeLogo)(nil)
+
+func (l *QRCodeLogo) Apply(img image.Image) (image.Image, error) {
+	if l.Logo == nil {
+		return nil, errors.New("nil Logo")
+	}
+	ratio := l.Ratio
+	switch {
+	case ratio <= 0:
+		ratio = DefaultQRCodeLogoRatio
+	case ratio > MaxQRCodeLogoRatio:
+		ratio = MaxQRCodeLogoRatio
+	}
+	bgColor := l.BgColor
+	if bgColor == nil {
+		bgColor = color.White
+	}
+
+	bounds := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), img, bounds.Min, draw.Src)
+
+	// logo 等比缩放到 ratio 大小
+	side := int(float64(minInt(bounds.Dx(), bounds.Dy())) * ratio)
+	logoBounds := l.Logo.Bounds()
+	w, h := side, side
+	if logoBounds.Dx() >= logoBounds.Dy() {
+		h = side * logoBounds.Dy() / logoBounds.Dx()
+	} else {
+		w = side * logoBounds.Dx() / logoBounds.Dy()
+	}
+	if w < 1 || h < 1 {
+		return dst, nil
+	}
+	logo := mp.ResizeImage(l.Logo, w, h)
+
+	x := (bounds.Dx() - w) / 2
+	y := (bounds.Dy() - h) / 2
+	if l.Border > 0 {
+		rect := image.Rect(x-l.Border, y-l.Border, x+w+l.Border, y+h+l.Border)
+		draw.Draw(dst, rect, image.NewUniform(bgColor), image.ZP, draw.Src)
+	}
+	draw.Draw(dst, image.Rect(x, y, x+w, y+h), logo, image.ZP, draw.Over)
+	return dst, nil
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// 二维码图片的处理流程: 依次执行 Steps, 然后按 Format 编码.
+//
+//  pipeline := &account.QRCodeImagePipeline{
+//      Steps:  []account.QRCodeImageStep{account.QRCodeResize(860), &account.QRCodeLogo{Logo: logo, Border: 8}},
+//      Format: mp.ImageFormatPNG,
+//  }
+//  err := clt.QRCodeDownloadProcessed(ticket, file, pipeline)
+type QRCodeImagePipeline struct {
+	Steps       []QRCodeImageStep
+	Format      string // 输出格式, mp.ImageFormatPNG(默认) 或 mp.ImageFormatJPEG
+	JPEGQuality int    // 输出 JPEG 时的质量, 默认为 90
+}
+
+// 处理 reader 里的二维码图片, 结果写入 writer.
+func (p *QRCodeImagePipeline) Process(reader io.Reader, writer io.Writer) (err error) {
+	img, _, err := image.Decode(reader)
+	if err != nil {
+		return
+	}
+	return p.process(img, p.Steps, writer)
+}
+
+func (p *QRCodeImagePipeline) process(img image.Image, steps []QRCodeImageStep, writer io.Writer) (err error) {
+	for _, step := range steps {
+		if img, err = step.Apply(img); err != nil {
+			return
+		}
+	}
+	return p.encode(img, writer)
+}
+
+func (p *QRCodeImagePipeline) encode(img image.Image, writer io.Writer) error {
+	switch p.Format {
+	case "", mp.ImageFormatPNG:
+		return png.Encode(writer, img)
+	case mp.ImageFormatJPEG:
+		quality := p.JPEGQuality
+		if quality <= 0 || quality > 100 {
+			quality = 90
+		}
+		bounds := img.Bounds()
+		flat := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+		draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.ZP, draw.Src)
+		draw.Draw(flat, flat.Bounds(), img, bounds.Min, draw.Over)
+		return jpeg.Encode(writer, flat, &jpeg.Options{Quality: quality})
+	default:
+		return errors.New("unsupported format: " + p.Format)
+	}
+}
+
+// 生成多个尺寸的二维码, 返回 尺寸 --> 编码后的图片.
+//  每个尺寸都是先缩放到 size*size 再执行 Steps, 这样 logo 等叠加的内容在每个尺寸上都是清晰的.
+func (p *QRCodeImagePipeline) Variants(reader io.Reader, sizes ...int) (variants map[int][]byte, err error) {
+	img, _, err := image.Decode(reader)
+	if err != nil {
+		return
+	}
+	variants = make(map[int][]byte, len(sizes))
+	steps := make([]QRCodeImageStep, 0, len(p.Steps)+1)
+	for _, size := range sizes {
+		var buf bytes.Buffer
+		steps = append(steps[:0], QRCodeResize(size))
+		steps = append(steps, p.Steps...)
+		if err = p.process(img, steps, &buf); err != nil {
+			return nil, err
+		}
+		variants[size] = buf.Bytes()
+	}
+	return
+}
+
+// 通过ticket换取二维码, 经过 pipeline 处理后写入到 writer.
+func (clt *Client) QRCodeDownloadProcessed(ticket string, writer io.Writer, pipeline *QRCodeImagePipeline) (err error) {
+	if pipeline == nil {
+		return errors.New("nil QRCodeImagePipeline")
+	}
+	if writer == nil {
+		return errors.New("nil writer")
+	}
+	var buf bytes.Buffer
+	if err = clt.QRCodeDownloadToWriter(ticket, &buf); err != nil {
+		return
+	}
+	return pipeline.Process(&buf, writer)
+}
@@ -0,0 +1,338 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package account
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/message/request"
+)
+
+const PermanentQRCodeSceneStringLengthLimit = 64 // 永久二维码 scene_str 长度限制
+
+var (
+	ErrSceneExists      = errors.New("qrcode scene already registered")
+	ErrSceneNotFound    = errors.New("qrcode scene not found")
+	ErrSceneIdExhausted = errors.New("no free permanent qrcode scene_id")
+)
+
+// 永久二维码场景的登记信息.
+type SceneEntry struct {
+	// 下面两个字段同时只有一个有效, 非zero值表示有效.
+	SceneId     uint32 `json:"scene_id,omitempty"`
+	SceneString string `json:"scene_str,omitempty"`
+
+	Target string `json:"target"` // 场景对应的业务对象, 由调用者定义, 比如 "store:1024", "campaign:spring"
+
+	Ticket string `json:"ticket,omitempty"` // SceneRegistry.Client 不为 nil 时登记的同时创建二维码
+	URL    string `json:"url,omitempty"`
+
+	CreatedAt  time.Time `json:"created_at"`
+	LastScanAt time.Time `json:"last_scan_at,omitempty"` // 最后一次扫码的时间, 零值表示还没有扫过
+	ScanCount  int64     `json:"scan_count"`
+}
+
+// 场景的 key, 同 SceneKey.
+func (entry *SceneEntry) Key() string {
+	if entry.SceneString != "" {
+		return SceneStringKey(entry.SceneString)
+	}
+	return SceneIdKey(entry.SceneId)
+}
+
+// 整数场景值的 key, SceneStore 用 key 区分不同的场景, 整数和字符串的场景值不会冲突.
+func SceneIdKey(sceneId uint32) string { return "id:" + strconv.FormatUint(uint64(sceneId), 10) }
+
+// 字符串场景值的 key.
+func SceneStringKey(sceneString string) string { return "str:" + sceneString }
+
+// 场景登记信息的存储接口, 可以用 redis, 数据库等实现以便多个进程共享.
+type SceneStore interface {
+	// 保存新的场景, key 已经存在时返回 ErrSceneExists; 必须是原子操作, SceneRegistry 依赖它检测冲突.
+	Create(entry *SceneEntry) error
+	// 获取场景, 不存在时返回 ErrSceneNotFound.
+	Get(key string) (*SceneEntry, error)
+	// 更新已经存在的场景, 不存在时返回 ErrSceneNotFound.
+	Update(entry *SceneEntry) error
+	// 删除场景, 不存在时返回 nil.
+	Delete(key string) error
+	// 遍历所有的场景, fn 返回错误时停止遍历并返回该错误.
+	List(fn func(entry *SceneEntry) error) error
+	// 记录一次扫码: ScanCount 加一, LastScanAt 早于 at 时更新为 at; 不存在时返回 ErrSceneNotFound.
+	// 必须是原子操作, 多个进程同时处理扫码事件时不能丢失计数.
+	IncrScan(key string, at time.Time) error
+}
+
+var _ SceneStore = (*MemorySceneStore)(nil)
+
+// 内存里的 SceneStore, 只适用于单进程和测试.
+type MemorySceneStore struct {
+	mu      sync.RWMutex
+	entries map[string]SceneEntry
+}
+
+func NewMemorySceneStore() *MemorySceneStore {
+	return &MemorySceneStore{
+		entries: make(map[string]SceneEntry),
+	}
+}
+
+func (s *MemorySceneStore) Create(entry *SceneEntry) error {
+	key := entry.Key()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.entries[key]; ok {
+		return ErrSceneExists
+	}
+	s.entries[key] = *entry
+	return nil
+}
+
+func (s *MemorySceneStore) Get(key string) (*SceneEntry, error) {
+	s.mu.RLock()
+	entry, ok := s.entries[key]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, ErrSceneNotFound
+	}
+	return &entry, nil
+}
+
+func (s *MemorySceneStore) Update(entry *SceneEntry) error {
+	key := entry.Key()
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.entries[key]; !ok {
+		return ErrSceneNotFound
+	}
+	s.entries[key] = *entry
+	return nil
+}
+
+func (s *MemorySceneStore) Delete(key string) error {
+	s.mu.Lock()
+	delete(s.entries, key)
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *MemorySceneStore) IncrScan(key string, at time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.entries[key]
+	if !ok {
+		return ErrSceneNotFound
+	}
+	entry.ScanCount++
+	if entry.LastScanAt.Before(at) {
+		entry.LastScanAt = at
+	}
+	s.entries[key] = entry
+	return nil
+}
+
+// 按 key 的顺序遍历.
+func (s *MemorySceneStore) List(fn func(entry *SceneEntry) error) error {
+	s.mu.RLock()
+	entries := make([]SceneEntry, 0, len(s.entries))
+	for _, entry := range s.entries {
+		entries = append(entries, entry)
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Key() < entries[j].Key() })
+	for i := range entries {
+		if err := fn(&entries[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// 永久二维码场景值的登记处.
+//  永久二维码的整数场景值只有 1--100000, 而且二维码不能删除, 所以需要统一分配和登记每个场景值对应的业务对象,
+//  避免不同的业务使用了相同的场景值. 扫码事件通过 HandleEvent 统计, 用于找出不再使用的场景.
+type SceneRegistry struct {
+	Client *Client // 不为 nil 时登记场景的同时创建永久二维码
+	Store  SceneStore
+
+	mu     sync.Mutex
+	nextId uint32 // 下一次分配时开始尝试的 scene_id
+}
+
+// 创建 SceneRegistry, store 为 nil 时使用 NewMemorySceneStore().
+func NewSceneRegistry(clt *Client, store SceneStore) *SceneRegistry {
+	if store == nil {
+		store = NewMemorySceneStore()
+	}
+	return &SceneRegistry{
+		Client: clt,
+		Store:  store,
+		nextId: 1,
+	}
+}
+
+// 分配一个未使用的整数场景值给 target.
+//  从上一次分配的下一个场景值开始依次尝试 SceneStore.Create, 同一个 SceneRegistry 里连续分配时一般一次就成功;
+//  但是新创建的 SceneRegistry 或者多个进程共享 SceneStore 时, 已经登记的场景值越多需要尝试的次数越多,
+//  最坏的情况下(场景值快用完时)要调用 PermanentQRCodeSceneIdLimit(100000) 次 Create, 对于远程的 SceneStore 请考虑用 RegisterId 指定场景值.
+func (r *SceneRegistry) AllocateId(target string, opts ...mp.CallOption) (entry *SceneEntry, err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	start := r.nextId
+	if start == 0 || start > PermanentQRCodeSceneIdLimit {
+		start = 1
+	}
+	for i := uint32(0); i < PermanentQRCodeSceneIdLimit; i++ {
+		sceneId := (start-1+i)%PermanentQRCodeSceneIdLimit + 1
+		entry, err = r.register(&SceneEntry{SceneId: sceneId, Target: target}, opts...)
+		if err == ErrSceneExists {
+			continue
+		}
+		if err == nil {
+			r.nextId = sceneId%PermanentQRCodeSceneIdLimit + 1
+		}
+		return
+	}
+	return nil, ErrSceneIdExhausted
+}
+
+// 登记指定的整数场景值, 已经被登记时返回 ErrSceneExists.
+func (r *SceneRegistry) RegisterId(sceneId uint32, target string, opts ...mp.CallOption) (entry *SceneEntry, err error) {
+	if sceneId == 0 || sceneId > PermanentQRCodeSceneIdLimit {
+		return nil, errors.New("SceneId should be in 1--100000")
+	}
+	return r.register(&SceneEntry{SceneId: sceneId, Target: target}, opts...)
+}
+
+// 登记字符串场景值, 已经被登记时返回 ErrSceneExists.
+func (r *SceneRegistry) RegisterString(sceneString, target string, opts ...mp.CallOption) (entry *SceneEntry, err error) {
+	if sceneString == "" {
+		return nil, errors.New("SceneString should not be empty")
+	}
+	if len(sceneString) > PermanentQRCodeSceneStringLengthLimit {
+		return nil, errors.New("SceneString too long")
+	}
+	return r.register(&SceneEntry{SceneString: sceneString, Target: target}, opts...)
+}
+
+func (r *SceneRegistry) register(entry *SceneEntry, opts ...mp.CallOption) (*SceneEntry, error) {
+	entry.CreatedAt = time.Now()
+	if err := r.Store.Create(entry); err != nil {
+		return nil, err
+	}
+	if r.Client == nil {
+		return entry, nil
+	}
+
+	var (
+		qrcode *PermanentQRCode
+		err    error
+	)
+	if entry.SceneString != "" {
+		qrcode, err = r.Client.CreatePermanentQRCodeWithSceneString(entry.SceneString, opts...)
+	} else {
+		qrcode, err = r.Client.CreatePermanentQRCode(entry.SceneId, opts...)
+	}
+	if err != nil {
+		r.Store.Delete(entry.Key()) // 创建二维码失败, 释放场景值
+		return nil, err
+	}
+	entry.Ticket = qrcode.Ticket
+	entry.URL = qrcode.URL
+	if err = r.Store.Update(entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
+
+// 获取场景的登记信息, key 见 SceneIdKey, SceneStringKey.
+func (r *SceneRegistry) Lookup(key string) (*SceneEntry, error) {
+	return r.Store.Get(key)
+}
+
+// 根据扫码事件的 EventKey 获取场景的登记信息.
+//  关注事件的 EventKey 为 qrscene_ 前缀加场景值, SCAN 事件为场景值; 字符串场景值先按字符串查找.
+func (r *SceneRegistry) LookupEventKey(eventKey string) (*SceneEntry, error) {
+	scene := strings.TrimPrefix(eventKey, "qrscene_")
+	if scene == "" {
+		return nil, ErrSceneNotFound
+	}
+	entry, err := r.Store.Get(SceneStringKey(scene))
+	if err != ErrSceneNotFound {
+		return entry, err
+	}
+	sceneId, parseErr := strconv.ParseUint(scene, 10, 32)
+	if parseErr != nil {
+		return nil, ErrSceneNotFound
+	}
+	return r.Store.Get(SceneIdKey(uint32(sceneId)))
+}
+
+// 处理扫码关注和 SCAN 事件, 统计场景的扫码次数; 其他事件和未登记的场景忽略.
+func (r *SceneRegistry) HandleEvent(msg *mp.MixedMessage) error {
+	if msg.MsgType != request.MsgTypeEvent {
+		return nil
+	}
+	switch msg.Event {
+	case request.EventTypeSubscribe:
+		if !strings.HasPrefix(msg.EventKey, "qrscene_") {
+			return nil
+		}
+	case request.EventTypeScan:
+	default:
+		return nil
+	}
+
+	entry, err := r.LookupEventKey(msg.EventKey)
+	if err != nil {
+		if err == ErrSceneNotFound {
+			return nil
+		}
+		return err
+	}
+	scanAt := mp.UnixToTime(msg.CreateTime)
+	if scanAt.IsZero() {
+		scanAt = time.Now()
+	}
+	if err = r.Store.IncrScan(entry.Key(), scanAt); err == ErrSceneNotFound { // 刚刚被回收
+		return nil
+	}
+	return err
+}
+
+// 遍历所有登记的场景.
+func (r *SceneRegistry) List(fn func(entry *SceneEntry) error) error {
+	return r.Store.List(fn)
+}
+
+// 返回 since 之后没有被扫过的场景(登记时间也早于 since), 可以考虑回收.
+func (r *SceneRegistry) Unused(since time.Time) (entries []*SceneEntry, err error) {
+	err = r.Store.List(func(entry *SceneEntry) error {
+		if entry.CreatedAt.Before(since) && entry.LastScanAt.Before(since) {
+			e := *entry
+			entries = append(entries, &e)
+		}
+		return nil
+	})
+	return
+}
+
+// 回收场景, 之后可以重新分配给其他业务.
+//  NOTE: 永久二维码不能删除, 回收后旧的二维码扫码时仍然会带上这个场景值, 所以请确认旧的二维码已经不再使用.
+func (r *SceneRegistry) Reclaim(key string) error {
+	if _, err := r.Store.Get(key); err != nil {
+		return err
+	}
+	return r.Store.Delete(key)
+}
@@ -0,0 +1,125 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package account
+
+import (
+	"context"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	DefaultShortenWorkers      = 4
+	DefaultShortenRetries      = mp.DefaultRetries
+	DefaultShortenRetryBackoff = mp.DefaultRetryBackoff
+)
+
+// 批量生成短链接或者短 key, 用于活动需要成千上万个码的场景.
+//  相同的长信息只会生成一次; 某一条失败不影响其他条, 系统繁忙, 频率限制和网络错误会重试, 见 mp.IsRetryable.
+type ShortenBatch struct {
+	Client       *Client
+	Workers      int            // 并发数, 默认为 DefaultShortenWorkers
+	Limiter      mp.RateLimiter // 可以为 nil, 表示不限流
+	MaxRetries   int            // 每一条的重试次数, 默认为 DefaultShortenRetries, 小于 0 表示不重试
+	RetryBackoff time.Duration  // 第一次重试前等待的时间, 之后每次加倍, 默认为 DefaultShortenRetryBackoff
+}
+
+func NewShortenBatch(clt *Client, limiter mp.RateLimiter) *ShortenBatch {
+	if clt == nil {
+		panic("nil Client")
+	}
+	return &ShortenBatch{
+		Client:  clt,
+		Limiter: limiter,
+	}
+}
+
+// 批量生成的结果, 可以直接 json.Marshal 保存下来.
+type ShortenResult struct {
+	Mapping  map[string]string `json:"mapping"`            // 长链接(长信息) --> 短链接(短 key)
+	Failures []ShortenFailure  `json:"failures,omitempty"` // 重试之后仍然失败的
+}
+
+type ShortenFailure struct {
+	Long     string `json:"long"`
+	Attempts int    `json:"attempts"`
+	Error    string `json:"error"`
+	Err      error  `json:"-"`
+}
+
+// 批量把长链接转成短链接, 参考 Client.ShortURL.
+func (b *ShortenBatch) ShortURLs(longURLs []string, opts ...mp.CallOption) *ShortenResult {
+	return b.run(longURLs, opts, func(long string, opts ...mp.CallOption) (string, error) {
+		return b.Client.ShortURL(long, opts...)
+	})
+}
+
+// 批量生成短 key, 参考 Client.ShortKeyGen.
+func (b *ShortenBatch) ShortKeys(longData []string, expireSeconds int, opts ...mp.CallOption) *ShortenResult {
+	return b.run(longData, opts, func(long string, opts ...mp.CallOption) (string, error) {
+		return b.Client.ShortKeyGen(long, expireSeconds, opts...)
+	})
+}
+
+func (b *ShortenBatch) run(longs []string, opts []mp.CallOption, shorten func(long string, opts ...mp.CallOption) (string, error)) *ShortenResult {
+	workers := b.Workers
+	if workers <= 0 {
+		workers = DefaultShortenWorkers
+	}
+	policy := mp.RetryPolicy{
+		MaxRetries: b.MaxRetries,
+		Backoff:    b.RetryBackoff,
+		Limiter:    b.Limiter, // 第一次调用之前 Batch 已经 Wait 过了
+	}
+	opts = policy.CallOptions(opts)
+
+	// 去重
+	uniq := make([]string, 0, len(longs))
+	seen := make(map[string]struct{}, len(longs))
+	for _, long := range longs {
+		if long == "" {
+			continue
+		}
+		if _, ok := seen[long]; ok {
+			continue
+		}
+		seen[long] = struct{}{}
+		uniq = append(uniq, long)
+	}
+
+	shorts := make([]string, len(uniq))
+	attempts := make([]int, len(uniq))
+	batch := mp.NewBatch(workers, b.Limiter)
+	for i := range uniq {
+		i := i
+		batch.Add(func() (err error) {
+			attempts[i], err = policy.Do(context.Background(), func() (err error) {
+				shorts[i], err = shorten(uniq[i], opts...)
+				return
+			})
+			return
+		})
+	}
+	errs := batch.Run()
+
+	result := &ShortenResult{
+		Mapping: make(map[string]string, len(uniq)),
+	}
+	for i, err := range errs {
+		if err != nil {
+			result.Failures = append(result.Failures, ShortenFailure{
+				Long:     uniq[i],
+				Attempts: attempts[i],
+				Error:    err.Error(),
+				Err:      err,
+			})
+			continue
+		}
+		result.Mapping[uniq[i]] = shorts[i]
+	}
+	return result
+}
@@ -0,0 +1,71 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package adapter
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+)
+
+// 用 body 重置 r.Body, 使得 body 被框架的中间件读取过以后仍然可以被 handler 再次读取.
+func RestoreBody(r *http.Request, body []byte) {
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	r.ContentLength = int64(len(body))
+}
+
+// 用内存中的请求调用 handler, 返回 handler 写入的响应.
+//  给没有基于 net/http 的框架(比如 fasthttp)使用; requestURI 是请求行里的 URI, 比如 /wechat?signature=xxx&timestamp=xxx.
+//  body 在 handler 返回之前不能被修改.
+func ServeRequest(handler http.Handler, method, requestURI, remoteAddr string, header http.Header,
+	body []byte) (statusCode int, respHeader http.Header, respBody []byte, err error) {
+
+	r, err := http.NewRequest(method, requestURI, bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	r.RequestURI = requestURI
+	r.RemoteAddr = remoteAddr
+	if header != nil {
+		r.Header = header
+	}
+	r.Host = r.Header.Get("Host")
+
+	w := &responseRecorder{
+		header: make(http.Header),
+	}
+	handler.ServeHTTP(w, r)
+
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	return w.statusCode, w.header, w.body.Bytes(), nil
+}
+
+// 在内存中记录响应的 http.ResponseWriter.
+type responseRecorder struct {
+	header     http.Header
+	statusCode int
+	body       bytes.Buffer
+}
+
+func (w *responseRecorder) Header() http.Header {
+	return w.header
+}
+
+func (w *responseRecorder) WriteHeader(statusCode int) {
+	if w.statusCode != 0 {
+		return
+	}
+	w.statusCode = statusCode
+}
+
+func (w *responseRecorder) Write(p []byte) (int, error) {
+	if w.statusCode == 0 {
+		w.statusCode = http.StatusOK
+	}
+	return w.body.Write(p)
+}
@@ -0,0 +1,25 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 把消息(事件)回调服务挂载到常用的 web 框架上.
+//  mp.WechatServerFrontend, mp.MultiWechatServerFrontend 都实现了 http.Handler, 这里提供 gin, echo, fasthttp 的薄封装,
+//  避免手工转换 context 时出现 body 已经被读取, 响应头丢失之类的问题.
+//
+//  为了不给 SDK 引入额外的依赖, 各框架的适配代码需要用对应的 build tag 编译:
+//      go build -tags gin      // Gin, MountGin
+//      go build -tags echo     // Echo, MountEcho
+//      go build -tags fasthttp // FastHTTP
+//
+//  使用示例:
+//      frontend := mp.NewWechatServerFrontend(wechatServer, nil)
+//
+//      r := gin.Default()
+//      adapter.MountGin(r, "/wechat", frontend)
+//
+//      e := echo.New()
+//      adapter.MountEcho(e, "/wechat", frontend)
+//
+//      fasthttp.ListenAndServe(":80", adapter.FastHTTP(frontend))
+package adapter
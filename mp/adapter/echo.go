@@ -0,0 +1,32 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// +build echo
+
+package adapter
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// 把 handler 转换为 echo.HandlerFunc.
+func Echo(handler http.Handler) echo.HandlerFunc {
+	if handler == nil {
+		panic("nil handler")
+	}
+	return func(c echo.Context) error {
+		handler.ServeHTTP(c.Response(), c.Request())
+		return nil
+	}
+}
+
+// 在 path 上挂载 handler, 微信服务器验证 URL 用 GET, 推送消息(事件)用 POST.
+func MountEcho(e *echo.Echo, path string, handler http.Handler, m ...echo.MiddlewareFunc) {
+	h := Echo(handler)
+	e.GET(path, h, m...)
+	e.POST(path, h, m...)
+}
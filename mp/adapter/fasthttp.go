@@ -0,0 +1,44 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// +build fasthttp
+
+package adapter
+
+import (
+	"net/http"
+
+	"github.com/valyala/fasthttp"
+)
+
+// 把 handler 转换为 fasthttp.RequestHandler.
+//  fasthttp 会复用请求的缓冲区, 这里会先把 body 复制一份再交给 handler.
+func FastHTTP(handler http.Handler) fasthttp.RequestHandler {
+	if handler == nil {
+		panic("nil handler")
+	}
+	return func(ctx *fasthttp.RequestCtx) {
+		header := make(http.Header)
+		ctx.Request.Header.VisitAll(func(key, value []byte) {
+			header.Add(string(key), string(value))
+		})
+		body := append([]byte(nil), ctx.PostBody()...)
+
+		statusCode, respHeader, respBody, err := ServeRequest(handler, string(ctx.Method()),
+			string(ctx.RequestURI()), ctx.RemoteAddr().String(), header, body)
+		if err != nil {
+			ctx.Error(err.Error(), fasthttp.StatusBadRequest)
+			return
+		}
+
+		for key, values := range respHeader {
+			for _, value := range values {
+				ctx.Response.Header.Add(key, value)
+			}
+		}
+		ctx.SetStatusCode(statusCode)
+		ctx.SetBody(respBody)
+	}
+}
@@ -0,0 +1,37 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// +build gin
+
+package adapter
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+// 把 handler 转换为 gin.HandlerFunc.
+//  如果之前的中间件通过 c.ShouldBindBodyWith 读取过 body, 会用缓存的 body 重置 c.Request.Body.
+func Gin(handler http.Handler) gin.HandlerFunc {
+	if handler == nil {
+		panic("nil handler")
+	}
+	return func(c *gin.Context) {
+		if v, ok := c.Get(gin.BodyBytesKey); ok {
+			if body, ok := v.([]byte); ok {
+				RestoreBody(c.Request, body)
+			}
+		}
+		handler.ServeHTTP(c.Writer, c.Request)
+	}
+}
+
+// 在 relativePath 上挂载 handler, 微信服务器验证 URL 用 GET, 推送消息(事件)用 POST.
+func MountGin(r gin.IRoutes, relativePath string, handler http.Handler) {
+	h := Gin(handler)
+	r.GET(relativePath, h)
+	r.POST(relativePath, h)
+}
@@ -0,0 +1,90 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package archive
+
+import (
+	"context"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+var _ mp.MessageHandler = (*Archiver)(nil)
+
+// 实现了 mp.MessageHandler, 先把消息写入 Store 再交给 Handler 处理.
+//  归档失败不影响消息的处理, 错误交给 OnError.
+type Archiver struct {
+	Store   Store
+	Handler mp.MessageHandler // 可以为 nil, 此时只归档不处理
+	OnError func(err error)   // 可以为 nil
+}
+
+func NewArchiver(store Store, handler mp.MessageHandler) *Archiver {
+	if store == nil {
+		panic("nil Store")
+	}
+	return &Archiver{
+		Store:   store,
+		Handler: handler,
+	}
+}
+
+func (a *Archiver) ServeMessage(w http.ResponseWriter, r *mp.Request) {
+	record := &Record{
+		Time:        time.Now(),
+		WechatId:    r.WechatId,
+		WechatAppId: r.WechatAppId,
+		RawMsgXML:   r.RawMsgXML,
+		MixedMsg:    r.MixedMsg,
+	}
+	if err := a.Store.Append(record); err != nil && a.OnError != nil {
+		a.OnError(err)
+	}
+
+	if a.Handler != nil {
+		a.Handler.ServeMessage(w, r)
+	}
+}
+
+// 把 from <= Time < to 的归档消息按时间顺序交给 handler 重新处理, 返回处理的消息数量.
+//  消息会用 RawMsgXML 重新解析, 这样修复了 MixedMessage 的解析问题以后重放也能得到正确的结果;
+//  重放时 Request.HttpRequest 为 nil, handler 写入的回复会被丢弃, handler 需要自己判断是否要重复执行有副作用的操作.
+func (a *Archiver) Replay(ctx context.Context, from, to time.Time, handler mp.MessageHandler) (n int, err error) {
+	if handler == nil {
+		handler = a.Handler
+	}
+	if handler == nil {
+		panic("nil MessageHandler")
+	}
+
+	w := mp.HttpResponseWriter(ioutil.Discard)
+	err = a.Store.Scan(ctx, from, to, func(record *Record) error {
+		msg := record.MixedMsg
+		if len(record.RawMsgXML) > 0 {
+			msg = new(mp.MixedMessage)
+			if err := xml.Unmarshal(record.RawMsgXML, msg); err != nil {
+				return err
+			}
+		}
+		if msg == nil {
+			return nil
+		}
+
+		handler.ServeMessage(w, &mp.Request{
+			RawMsgXML:   record.RawMsgXML,
+			MixedMsg:    msg,
+			TimeStamp:   msg.CreateTime,
+			WechatId:    record.WechatId,
+			WechatAppId: record.WechatAppId,
+		})
+		n++
+		return nil
+	})
+	return
+}
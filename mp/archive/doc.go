@@ -0,0 +1,12 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 回调消息(事件)归档.
+//  Archiver 包装 mp.MessageHandler, 把每个通过签名验证的消息(原始 XML 和解析后的 MixedMessage)写入 Store,
+//  修复了消息处理的 bug 以后, 可以用 Archiver.Replay 把某段时间内的历史消息重新处理一遍.
+//
+//  Store 有 MemoryStore, FileStore(按小时分段的文件) 和 ObjectStore(S3 兼容的对象存储) 三种实现,
+//  保存到数据库等其他存储可以自己实现 Store 接口.
+package archive
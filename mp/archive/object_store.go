@@ -0,0 +1,89 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package archive
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+)
+
+// S3 兼容的对象存储客户端需要实现的接口, 可以用 aws-sdk, minio 等 SDK 简单包装实现.
+type ObjectClient interface {
+	PutObject(key string, data []byte) error
+	// 返回所有以 prefix 开头的 key
+	ListObjects(prefix string) (keys []string, err error)
+	GetObject(key string) (data []byte, err error)
+}
+
+var _ Store = (*ObjectStore)(nil)
+
+// 把每条消息保存为对象存储里面的一个对象, key 的格式为 Prefix + "2006/01/02/15/" + 纳秒时间戳 + 序号 + ".json",
+// 遍历时按小时列出对象.
+type ObjectStore struct {
+	Client ObjectClient
+	Prefix string // 比如 "wechat/archive/"
+
+	seq uint64
+}
+
+func NewObjectStore(client ObjectClient, prefix string) *ObjectStore {
+	if client == nil {
+		panic("nil ObjectClient")
+	}
+	return &ObjectStore{
+		Client: client,
+		Prefix: prefix,
+	}
+}
+
+func (s *ObjectStore) hourPrefix(t time.Time) string {
+	return s.Prefix + t.UTC().Format("2006/01/02/15/")
+}
+
+func (s *ObjectStore) Append(record *Record) (err error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+	key := fmt.Sprintf("%s%019d-%06d.json", s.hourPrefix(record.Time), record.Time.UnixNano(), atomic.AddUint64(&s.seq, 1)%1000000)
+	return s.Client.PutObject(key, data)
+}
+
+func (s *ObjectStore) Scan(ctx context.Context, from, to time.Time, fn func(*Record) error) error {
+	for hour := from.UTC().Truncate(time.Hour); hour.Before(to); hour = hour.Add(time.Hour) {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		keys, err := s.Client.ListObjects(s.hourPrefix(hour))
+		if err != nil {
+			return err
+		}
+		sort.Strings(keys)
+
+		records := make([]*Record, 0, len(keys))
+		for _, key := range keys {
+			data, err := s.Client.GetObject(key)
+			if err != nil {
+				return err
+			}
+			record := new(Record)
+			if err = json.Unmarshal(data, record); err != nil {
+				return err
+			}
+			if inRange(record.Time, from, to) {
+				records = append(records, record)
+			}
+		}
+		if err = scan(ctx, records, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
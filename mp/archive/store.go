@@ -0,0 +1,214 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package archive
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 归档的消息(事件)
+type Record struct {
+	Time        time.Time        `json:"time"`                // 收到消息的时间
+	WechatId    string           `json:"wechat_id"`           // 消息所属公众号的原始 ID
+	WechatAppId string           `json:"wechat_appid"`        // 消息所属公众号的 AppId
+	RawMsgXML   []byte           `json:"raw_msg_xml"`         // "明文"消息的 XML 文本
+	MixedMsg    *mp.MixedMessage `json:"mixed_msg,omitempty"` // 归档时 RawMsgXML 解析后的消息
+}
+
+// 保存归档消息的存储接口.
+type Store interface {
+	// 追加一条消息
+	Append(record *Record) error
+	// 按时间顺序遍历 from <= Time < to 的消息, fn 返回错误或者 ctx 结束时停止遍历并返回对应的错误
+	Scan(ctx context.Context, from, to time.Time, fn func(*Record) error) error
+}
+
+var _ Store = (*MemoryStore)(nil)
+
+// Store 的简单实现, 用于测试和单进程环境, 进程退出后消息会丢失.
+type MemoryStore struct {
+	mutex   sync.Mutex
+	records []*Record
+}
+
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{}
+}
+
+func (s *MemoryStore) Append(record *Record) error {
+	s.mutex.Lock()
+	s.records = append(s.records, record)
+	s.mutex.Unlock()
+	return nil
+}
+
+func (s *MemoryStore) Scan(ctx context.Context, from, to time.Time, fn func(*Record) error) error {
+	s.mutex.Lock()
+	records := make([]*Record, 0, len(s.records))
+	for _, record := range s.records {
+		if inRange(record.Time, from, to) {
+			records = append(records, record)
+		}
+	}
+	s.mutex.Unlock()
+
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Time.Before(records[j].Time) })
+	return scan(ctx, records, fn)
+}
+
+const (
+	segmentLayout = "2006010215" // 按小时(UTC)分段
+	segmentExt    = ".jsonl"
+)
+
+var _ Store = (*FileStore)(nil)
+
+// 把消息按小时分段写入 dir 目录下的文件, 每个文件一行一条 JSON 格式的 Record.
+type FileStore struct {
+	dir string
+
+	mutex   sync.Mutex
+	segment string   // 当前打开的分段名称
+	file    *os.File // 当前打开的分段文件
+}
+
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+	return &FileStore{dir: dir}, nil
+}
+
+func (s *FileStore) Append(record *Record) (err error) {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return
+	}
+	data = append(data, '\n')
+
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	segment := record.Time.UTC().Format(segmentLayout)
+	if s.file == nil || s.segment != segment {
+		if s.file != nil {
+			s.file.Close()
+			s.file = nil
+		}
+		file, err := os.OpenFile(filepath.Join(s.dir, segment+segmentExt), os.O_WRONLY|os.O_CREATE|os.O_APPEND, 0644)
+		if err != nil {
+			return err
+		}
+		s.segment = segment
+		s.file = file
+	}
+	_, err = s.file.Write(data)
+	return
+}
+
+// 关闭当前打开的分段文件.
+func (s *FileStore) Close() (err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.file != nil {
+		err = s.file.Close()
+		s.file = nil
+	}
+	return
+}
+
+func (s *FileStore) Scan(ctx context.Context, from, to time.Time, fn func(*Record) error) (err error) {
+	infos, err := ioutil.ReadDir(s.dir)
+	if err != nil {
+		return
+	}
+	var segments []string
+	for _, info := range infos {
+		name := info.Name()
+		if info.IsDir() || !strings.HasSuffix(name, segmentExt) {
+			continue
+		}
+		begin, err := time.Parse(segmentLayout, strings.TrimSuffix(name, segmentExt))
+		if err != nil {
+			continue
+		}
+		if begin.Before(to) && begin.Add(time.Hour).After(from) {
+			segments = append(segments, name)
+		}
+	}
+	sort.Strings(segments) // 分段名称的顺序就是时间顺序
+
+	for _, segment := range segments {
+		records, err := s.readSegment(segment, from, to)
+		if err != nil {
+			return err
+		}
+		if err = scan(ctx, records, fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *FileStore) readSegment(segment string, from, to time.Time) (records []*Record, err error) {
+	s.mutex.Lock() // 避免读到正在写入的半行
+	defer s.mutex.Unlock()
+
+	file, err := os.Open(filepath.Join(s.dir, segment))
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 16<<20)
+	for scanner.Scan() {
+		line := scanner.Bytes()
+		if len(line) == 0 {
+			continue
+		}
+		record := new(Record)
+		if err = json.Unmarshal(line, record); err != nil {
+			return
+		}
+		if inRange(record.Time, from, to) {
+			records = append(records, record)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return
+	}
+	sort.SliceStable(records, func(i, j int) bool { return records[i].Time.Before(records[j].Time) })
+	return
+}
+
+func inRange(t, from, to time.Time) bool {
+	return !t.Before(from) && t.Before(to)
+}
+
+func scan(ctx context.Context, records []*Record, fn func(*Record) error) error {
+	for _, record := range records {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := fn(record); err != nil {
+			return err
+		}
+	}
+	return nil
+}
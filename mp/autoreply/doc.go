@@ -0,0 +1,41 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 关键词自动回复.
+//  和公众平台后台的 "自动回复" 功能类似: 按优先级匹配文本消息的关键词(完全匹配, 包含, 正则表达式, 或者自定义的 Matcher),
+//  然后被动回复文本, 图片, 语音, 视频或者图文消息; 规则可以在代码里配置, 也可以从 JSON 文件加载并且热更新.
+//
+//  JSON 格式:
+//      {
+//          "rules": [
+//              {
+//                  "name": "hello",
+//                  "priority": 10,
+//                  "match": "exact",
+//                  "keywords": ["你好", "hello"],
+//                  "ignore_case": true,
+//                  "reply": {"type": "text", "content": "你好, 你发送的是 {{.Content}}"}
+//              },
+//              {
+//                  "name": "order",
+//                  "match": "regex",
+//                  "keywords": ["^订单(\\d+)$"],
+//                  "reply": {"type": "text", "content": "订单 {{index .Groups 1}} 正在处理"}
+//              }
+//          ],
+//          "default": {"type": "text", "content": "没有找到相关内容"}
+//      }
+//
+//  使用示例:
+//      engine := autoreply.NewEngine()
+//      if err := engine.LoadFile("autoreply.json"); err != nil {
+//          // TODO
+//      }
+//      reloader := autoreply.NewFileReloader(engine, "autoreply.json")
+//      reloader.Start(nil)
+//
+//      mux := mp.NewMessageServeMux()
+//      mux.MessageHandle(request.MsgTypeText, engine)
+package autoreply
@@ -0,0 +1,153 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package autoreply
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"sync"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/message/request"
+)
+
+var _ mp.MessageHandler = (*Engine)(nil)
+
+// 关键词自动回复, 实现了 mp.MessageHandler, 可以直接登记到 mp.MessageServeMux 处理文本消息.
+type Engine struct {
+	// 没有匹配到规则(并且没有 Config.Default)或者不是文本消息时交给 Fallback 处理, 可以为 nil
+	Fallback mp.MessageHandler
+	// 生成或者写入回复失败时调用, 可以为 nil
+	OnError func(r *mp.Request, err error)
+
+	rwmutex      sync.RWMutex
+	rules        []*Rule
+	defaultReply *Reply
+}
+
+func NewEngine() *Engine {
+	return &Engine{}
+}
+
+// 用 config 替换当前所有的规则, config 无效时不修改当前的规则.
+func (e *Engine) SetConfig(config *Config) (err error) {
+	rules := make([]*Rule, len(config.Rules))
+	for i := range config.Rules {
+		rule := config.Rules[i]
+		if err = rule.compile(); err != nil {
+			return fmt.Errorf("rule %d(%s): %v", i, rule.Name, err)
+		}
+		rules[i] = &rule
+	}
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].Priority > rules[j].Priority })
+
+	var defaultReply *Reply
+	if config.Default != nil {
+		reply := *config.Default
+		if err = reply.compile(); err != nil {
+			return fmt.Errorf("default reply: %v", err)
+		}
+		defaultReply = &reply
+	}
+
+	e.rwmutex.Lock()
+	e.rules = rules
+	e.defaultReply = defaultReply
+	e.rwmutex.Unlock()
+	return
+}
+
+// 添加一条规则.
+func (e *Engine) AddRule(rule Rule) (err error) {
+	if err = rule.compile(); err != nil {
+		return
+	}
+
+	e.rwmutex.Lock()
+	defer e.rwmutex.Unlock()
+
+	rules := make([]*Rule, len(e.rules), len(e.rules)+1)
+	copy(rules, e.rules)
+	rules = append(rules, &rule)
+	sort.SliceStable(rules, func(i, j int) bool { return rules[i].Priority > rules[j].Priority })
+	e.rules = rules
+	return
+}
+
+// 从 JSON 读取规则, 替换当前所有的规则.
+func (e *Engine) Load(r io.Reader) (err error) {
+	var config Config
+	if err = json.NewDecoder(r).Decode(&config); err != nil {
+		return
+	}
+	return e.SetConfig(&config)
+}
+
+// 从 JSON 文件读取规则, 替换当前所有的规则.
+func (e *Engine) LoadFile(path string) (err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	return e.Load(file)
+}
+
+// 按优先级匹配 content, 返回第一个匹配的规则; 没有匹配的规则时返回 Config.Default, 此时 rule == nil.
+func (e *Engine) Match(content string) (rule *Rule, reply *Reply, groups []string) {
+	e.rwmutex.RLock()
+	rules, defaultReply := e.rules, e.defaultReply
+	e.rwmutex.RUnlock()
+
+	for _, rule := range rules {
+		if groups, matched := rule.Matcher.Match(content); matched {
+			return rule, &rule.Reply, groups
+		}
+	}
+	return nil, defaultReply, nil
+}
+
+// 生成 msg 的被动回复消息, 没有匹配的规则时返回 nil.
+func (e *Engine) Reply(msg *mp.MixedMessage) (resp interface{}, err error) {
+	if msg.MsgType != request.MsgTypeText {
+		return
+	}
+	_, reply, groups := e.Match(msg.Content)
+	if reply == nil {
+		return
+	}
+	return reply.build(msg.FromUserName, msg.ToUserName, msg.CreateTime, &ReplyData{
+		OpenId:  msg.FromUserName,
+		Content: msg.Content,
+		Groups:  groups,
+	})
+}
+
+func (e *Engine) ServeMessage(w http.ResponseWriter, r *mp.Request) {
+	resp, err := e.Reply(r.MixedMsg)
+	if err == nil && resp == nil {
+		if e.Fallback != nil {
+			e.Fallback.ServeMessage(w, r)
+		}
+		return
+	}
+
+	if err == nil {
+		if r.EncryptType == "aes" {
+			err = mp.WriteAESResponse(w, r, resp)
+		} else {
+			err = mp.WriteRawResponse(w, r, resp)
+		}
+	}
+	if err != nil && e.OnError != nil {
+		e.OnError(r, err)
+	}
+}
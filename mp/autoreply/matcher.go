@@ -0,0 +1,129 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package autoreply
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// 内置的匹配方式
+const (
+	MatchExact    = "exact"    // 完全匹配
+	MatchContains = "contains" // 包含关键词
+	MatchPrefix   = "prefix"   // 以关键词开头
+	MatchRegex    = "regex"    // 正则表达式
+)
+
+// 关键词匹配接口.
+//  matched 为 true 时, groups 是匹配的结果, groups[0] 为匹配到的关键词, 正则表达式的子匹配依次放在后面.
+type Matcher interface {
+	Match(content string) (groups []string, matched bool)
+}
+
+type MatcherFunc func(content string) (groups []string, matched bool)
+
+func (fn MatcherFunc) Match(content string) ([]string, bool) {
+	return fn(content)
+}
+
+// 根据关键词创建 Matcher
+type MatcherFactory func(keywords []string, ignoreCase bool) (Matcher, error)
+
+var matcherRegistry = struct {
+	sync.RWMutex
+	factories map[string]MatcherFactory
+}{
+	factories: map[string]MatcherFactory{
+		MatchExact: func(keywords []string, ignoreCase bool) (Matcher, error) {
+			return keywordMatcher(keywords, ignoreCase, func(content, keyword string) bool { return content == keyword }), nil
+		},
+		MatchContains: func(keywords []string, ignoreCase bool) (Matcher, error) {
+			return keywordMatcher(keywords, ignoreCase, strings.Contains), nil
+		},
+		MatchPrefix: func(keywords []string, ignoreCase bool) (Matcher, error) {
+			return keywordMatcher(keywords, ignoreCase, strings.HasPrefix), nil
+		},
+		MatchRegex: regexMatcher,
+	},
+}
+
+// 登记自定义的匹配方式, 之后可以在 Rule.Match 里使用, 比如拼音匹配, 分词匹配.
+func RegisterMatcher(match string, factory MatcherFactory) {
+	if match == "" {
+		panic("empty match")
+	}
+	if factory == nil {
+		panic("nil MatcherFactory")
+	}
+	matcherRegistry.Lock()
+	matcherRegistry.factories[match] = factory
+	matcherRegistry.Unlock()
+}
+
+func newMatcher(match string, keywords []string, ignoreCase bool) (Matcher, error) {
+	if match == "" {
+		match = MatchExact
+	}
+	matcherRegistry.RLock()
+	factory := matcherRegistry.factories[match]
+	matcherRegistry.RUnlock()
+
+	if factory == nil {
+		return nil, errors.New("unknown match: " + match)
+	}
+	if len(keywords) == 0 {
+		return nil, errors.New("empty keywords")
+	}
+	return factory(keywords, ignoreCase)
+}
+
+func keywordMatcher(keywords []string, ignoreCase bool, match func(content, keyword string) bool) Matcher {
+	if ignoreCase {
+		lower := make([]string, len(keywords))
+		for i, keyword := range keywords {
+			lower[i] = strings.ToLower(keyword)
+		}
+		keywords = lower
+	}
+	return MatcherFunc(func(content string) ([]string, bool) {
+		content = strings.TrimSpace(content)
+		if ignoreCase {
+			content = strings.ToLower(content)
+		}
+		for _, keyword := range keywords {
+			if match(content, keyword) {
+				return []string{keyword}, true
+			}
+		}
+		return nil, false
+	})
+}
+
+func regexMatcher(keywords []string, ignoreCase bool) (Matcher, error) {
+	regexps := make([]*regexp.Regexp, len(keywords))
+	for i, keyword := range keywords {
+		if ignoreCase {
+			keyword = "(?i)" + keyword
+		}
+		re, err := regexp.Compile(keyword)
+		if err != nil {
+			return nil, err
+		}
+		regexps[i] = re
+	}
+	return MatcherFunc(func(content string) ([]string, bool) {
+		content = strings.TrimSpace(content)
+		for _, re := range regexps {
+			if groups := re.FindStringSubmatch(content); groups != nil {
+				return groups, true
+			}
+		}
+		return nil, false
+	}), nil
+}
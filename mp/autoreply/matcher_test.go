@@ -0,0 +1,142 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package autoreply
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/message/request"
+	"github.com/chanxuehong/wechat/mp/message/response"
+)
+
+func TestMatcher(t *testing.T) {
+	tests := []struct {
+		name        string
+		match       string
+		keywords    []string
+		ignoreCase  bool
+		content     string
+		wantGroups  []string
+		wantMatched bool
+	}{
+		{"默认完全匹配", "", []string{"你好"}, false, " 你好\n", []string{"你好"}, true},
+		{"完全匹配失败", MatchExact, []string{"你好"}, false, "你好啊", nil, false},
+		{"完全匹配忽略大小写", MatchExact, []string{"Help"}, true, "HELP", []string{"help"}, true},
+		{"完全匹配区分大小写", MatchExact, []string{"Help"}, false, "HELP", nil, false},
+		{"包含关键词", MatchContains, []string{"价格", "多少钱"}, false, "这个多少钱", []string{"多少钱"}, true},
+		{"不包含关键词", MatchContains, []string{"价格"}, false, "你好", nil, false},
+		{"前缀匹配", MatchPrefix, []string{"查询"}, false, "查询订单", []string{"查询"}, true},
+		{"前缀不匹配", MatchPrefix, []string{"查询"}, false, "我要查询", nil, false},
+		{"正则子匹配", MatchRegex, []string{`^订单(\d+)$`}, false, "订单123", []string{"订单123", "123"}, true},
+		{"正则忽略大小写", MatchRegex, []string{`^id:(\w+)$`}, true, "ID:abc", []string{"ID:abc", "abc"}, true},
+		{"正则不匹配", MatchRegex, []string{`^\d+$`}, false, "abc", nil, false},
+	}
+	for _, tt := range tests {
+		matcher, err := newMatcher(tt.match, tt.keywords, tt.ignoreCase)
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		groups, matched := matcher.Match(tt.content)
+		if matched != tt.wantMatched || !reflect.DeepEqual(groups, tt.wantGroups) {
+			t.Errorf("%s: have %q %v, want %q %v", tt.name, groups, matched, tt.wantGroups, tt.wantMatched)
+		}
+	}
+}
+
+func TestNewMatcherError(t *testing.T) {
+	tests := []struct {
+		name     string
+		match    string
+		keywords []string
+	}{
+		{"未知的匹配方式", "unknown", []string{"a"}},
+		{"没有关键词", MatchExact, nil},
+		{"无效的正则", MatchRegex, []string{"("}},
+	}
+	for _, tt := range tests {
+		if _, err := newMatcher(tt.match, tt.keywords, false); err == nil {
+			t.Errorf("%s: want error", tt.name)
+		}
+	}
+}
+
+func TestRegisterMatcher(t *testing.T) {
+	const match = "test_suffix"
+	RegisterMatcher(match, func(keywords []string, ignoreCase bool) (Matcher, error) {
+		return keywordMatcher(keywords, ignoreCase, strings.HasSuffix), nil
+	})
+	defer func() {
+		matcherRegistry.Lock()
+		delete(matcherRegistry.factories, match)
+		matcherRegistry.Unlock()
+	}()
+
+	engine := NewEngine()
+	err := engine.AddRule(Rule{
+		Name:     "suffix",
+		Match:    match,
+		Keywords: []string{"吗"},
+		Reply:    Reply{Type: ReplyTypeText, Content: "是的"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rule, _, _ := engine.Match("在吗"); rule == nil || rule.Name != "suffix" {
+		t.Errorf("自定义的匹配方式没有生效: %v", rule)
+	}
+}
+
+func TestEngineMatchPriority(t *testing.T) {
+	engine := NewEngine()
+	err := engine.SetConfig(&Config{
+		Rules: []Rule{
+			{Name: "contains", Match: MatchContains, Keywords: []string{"订单"}, Reply: Reply{Type: ReplyTypeText, Content: "contains"}},
+			{Name: "regex", Priority: 1, Match: MatchRegex, Keywords: []string{`订单(\d+)`}, Reply: Reply{Type: ReplyTypeText, Content: "订单号 {{index .Groups 1}}"}},
+			{Name: "exact", Match: MatchExact, Keywords: []string{"订单"}, Reply: Reply{Type: ReplyTypeText, Content: "exact"}},
+		},
+		Default: &Reply{Type: ReplyTypeText, Content: "default"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		content     string
+		wantContent string
+	}{
+		{"优先级高的先匹配", "订单123", "订单号 123"},
+		{"相同优先级按照添加的顺序", "订单", "contains"},
+		{"没有匹配的规则", "你好", "default"},
+	}
+	for _, tt := range tests {
+		msg := &mp.MixedMessage{
+			CommonMessageHeader: mp.CommonMessageHeader{
+				ToUserName:   "gh_test",
+				FromUserName: "openid",
+				MsgType:      request.MsgTypeText,
+			},
+			Content: tt.content,
+		}
+		resp, err := engine.Reply(msg)
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		text, ok := resp.(*response.Text)
+		if !ok {
+			t.Errorf("%s: have %T, want *response.Text", tt.name, resp)
+			continue
+		}
+		if text.Content != tt.wantContent || text.ToUserName != "openid" || text.FromUserName != "gh_test" {
+			t.Errorf("%s: have %+v, want Content %q", tt.name, text, tt.wantContent)
+		}
+	}
+}
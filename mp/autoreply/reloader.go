@@ -0,0 +1,106 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package autoreply
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+const defaultReloadInterval = 10 * time.Second
+
+// 定期检查 JSON 文件的修改时间, 文件被修改以后重新加载规则.
+//  新的规则无效时继续使用原来的规则, 错误交给 Start 的 onError, 直到文件再次被修改.
+type FileReloader struct {
+	Engine   *Engine
+	Path     string
+	Interval time.Duration // 检查的时间间隔, 默认为 10 秒
+
+	mutex   sync.Mutex
+	modTime time.Time
+	started bool
+
+	startOnce sync.Once
+	stopOnce  sync.Once
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+func NewFileReloader(engine *Engine, path string) *FileReloader {
+	if engine == nil {
+		panic("nil Engine")
+	}
+	return &FileReloader{
+		Engine: engine,
+		Path:   path,
+		stop:   make(chan struct{}),
+		done:   make(chan struct{}),
+	}
+}
+
+// 检查一次文件, 如果文件被修改过则重新加载, 返回是否重新加载了规则.
+func (r *FileReloader) Check() (reloaded bool, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	info, err := os.Stat(r.Path)
+	if err != nil {
+		return
+	}
+	if info.ModTime().Equal(r.modTime) {
+		return
+	}
+	r.modTime = info.ModTime() // 加载失败时等文件再次修改以后才重试, 避免重复报告同一个错误
+	if err = r.Engine.LoadFile(r.Path); err != nil {
+		return
+	}
+	return true, nil
+}
+
+// 启动后台的检查, onError 可以为 nil.
+func (r *FileReloader) Start(onError func(error)) {
+	r.startOnce.Do(func() {
+		r.mutex.Lock()
+		r.started = true
+		r.mutex.Unlock()
+		go r.loop(onError)
+	})
+}
+
+func (r *FileReloader) loop(onError func(error)) {
+	defer close(r.done)
+
+	interval := r.Interval
+	if interval <= 0 {
+		interval = defaultReloadInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := r.Check(); err != nil && onError != nil {
+			onError(err)
+		}
+		select {
+		case <-r.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// 停止后台的检查. 没有调用过 Start 时直接返回.
+func (r *FileReloader) Stop() {
+	r.stopOnce.Do(func() { close(r.stop) })
+
+	r.mutex.Lock()
+	started := r.started
+	r.mutex.Unlock()
+	if started {
+		<-r.done
+	}
+}
@@ -0,0 +1,123 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package autoreply
+
+import (
+	"bytes"
+	"errors"
+	"text/template"
+
+	"github.com/chanxuehong/wechat/mp/message/response"
+)
+
+// 回复的类型
+const (
+	ReplyTypeText  = response.MsgTypeText
+	ReplyTypeImage = response.MsgTypeImage
+	ReplyTypeVoice = response.MsgTypeVoice
+	ReplyTypeVideo = response.MsgTypeVideo
+	ReplyTypeNews  = response.MsgTypeNews
+)
+
+// 回复的内容
+type Reply struct {
+	Type string `json:"type"` // ReplyTypeText, ReplyTypeImage, ReplyTypeVoice, ReplyTypeVideo, ReplyTypeNews
+
+	// 文本消息的内容, text/template 模板, 数据为 *ReplyData
+	Content string `json:"content,omitempty"`
+
+	// 图片, 语音, 视频消息的素材
+	MediaId     string `json:"media_id,omitempty"`
+	Title       string `json:"title,omitempty"`       // 视频消息的标题
+	Description string `json:"description,omitempty"` // 视频消息的描述
+
+	// 图文消息的文章, 不能超过 response.NewsArticleCountLimit 篇
+	Articles []response.Article `json:"articles,omitempty"`
+
+	tmpl *template.Template
+}
+
+// 渲染回复模板的数据
+type ReplyData struct {
+	OpenId  string   // 发送消息的用户
+	Content string   // 用户发送的内容
+	Groups  []string // Matcher 匹配的结果, 见 Matcher
+}
+
+func (reply *Reply) compile() (err error) {
+	switch reply.Type {
+	case ReplyTypeText:
+		if reply.Content == "" {
+			return errors.New("empty content")
+		}
+		reply.tmpl, err = template.New("").Option("missingkey=error").Parse(reply.Content)
+		return
+	case ReplyTypeImage, ReplyTypeVoice, ReplyTypeVideo:
+		if reply.MediaId == "" {
+			return errors.New("empty media_id")
+		}
+		return
+	case ReplyTypeNews:
+		if len(reply.Articles) == 0 {
+			return errors.New("empty articles")
+		}
+		if len(reply.Articles) > response.NewsArticleCountLimit {
+			return errors.New("too many articles")
+		}
+		return
+	default:
+		return errors.New("unknown reply type: " + reply.Type)
+	}
+}
+
+// 生成被动回复的消息, to 为用户的 openid, from 为公众号的原始 ID.
+func (reply *Reply) build(to, from string, timestamp int64, data *ReplyData) (msg interface{}, err error) {
+	switch reply.Type {
+	case ReplyTypeText:
+		var buf bytes.Buffer
+		if err = reply.tmpl.Execute(&buf, data); err != nil {
+			return
+		}
+		msg = response.NewText(to, from, timestamp, buf.String())
+	case ReplyTypeImage:
+		msg = response.NewImage(to, from, timestamp, reply.MediaId)
+	case ReplyTypeVoice:
+		msg = response.NewVoice(to, from, timestamp, reply.MediaId)
+	case ReplyTypeVideo:
+		msg = response.NewVideo(to, from, timestamp, reply.MediaId, reply.Title, reply.Description)
+	case ReplyTypeNews:
+		msg = response.NewNews(to, from, timestamp, reply.Articles)
+	}
+	return
+}
+
+// 自动回复规则
+type Rule struct {
+	Name       string   `json:"name"`
+	Priority   int      `json:"priority,omitempty"` // 越大越先匹配, 相同的按照添加的顺序
+	Match      string   `json:"match,omitempty"`    // 匹配方式, 默认为 MatchExact
+	Keywords   []string `json:"keywords,omitempty"`
+	IgnoreCase bool     `json:"ignore_case,omitempty"`
+	Reply      Reply    `json:"reply"`
+
+	// 在代码里配置规则时可以直接指定 Matcher, 此时忽略 Match, Keywords, IgnoreCase
+	Matcher Matcher `json:"-"`
+}
+
+func (rule *Rule) compile() (err error) {
+	if rule.Matcher == nil {
+		if rule.Matcher, err = newMatcher(rule.Match, rule.Keywords, rule.IgnoreCase); err != nil {
+			return
+		}
+	}
+	return rule.Reply.compile()
+}
+
+// 规则的配置, 对应 JSON 文件的格式
+type Config struct {
+	Rules   []Rule `json:"rules"`
+	Default *Reply `json:"default,omitempty"` // 没有匹配到任何规则时的回复, 可以为 nil
+}
@@ -0,0 +1,124 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"fmt"
+	"sync"
+)
+
+// Batch 并发执行一批(不同类型的)接口调用, 共享并发数限制和 RateLimiter.
+//
+//  调用的结果通过闭包写回调用者自己的变量, 错误按照 Add 的顺序返回, 比如:
+//
+//  var (
+//      m      menu.Menu
+//      groups []user.Group
+//  )
+//  batch := mp.NewBatch(4, limiter)
+//  batch.Add(func() (err error) { m, err = menuClient.GetMenu(); return })
+//  batch.Add(func() (err error) { groups, err = userClient.GroupList(); return })
+//  errs := batch.Run()
+//
+//  NOTE: Batch 不是并发安全的, Run 之后不能再 Add.
+type Batch struct {
+	workers int
+	limiter RateLimiter
+	calls   []func() error
+}
+
+// 创建一个新的 Batch.
+//  workers: 最大并发数, 小于 1 时按 1 处理
+//  limiter: 可以为 nil, 表示不限流; 多个 Batch 共享同一个 limiter 时整体限流
+func NewBatch(workers int, limiter RateLimiter) *Batch {
+	if workers < 1 {
+		workers = 1
+	}
+	return &Batch{
+		workers: workers,
+		limiter: limiter,
+	}
+}
+
+// 添加一个调用, 返回该调用在 Run 返回值中的下标.
+func (b *Batch) Add(call func() error) (index int) {
+	if call == nil {
+		panic("nil call")
+	}
+	index = len(b.calls)
+	b.calls = append(b.calls, call)
+	return
+}
+
+// 调用的数量
+func (b *Batch) Len() int {
+	return len(b.calls)
+}
+
+// 执行所有的调用, 等待全部完成后返回, errs[i] 对应第 i 个添加的调用.
+//  某个调用 panic 不会影响其他调用, panic 会被转换为 *BatchPanicError.
+func (b *Batch) Run() (errs []error) {
+	errs = make([]error, len(b.calls))
+	if len(b.calls) == 0 {
+		return
+	}
+
+	workers := b.workers
+	if workers > len(b.calls) {
+		workers = len(b.calls)
+	}
+
+	indexChan := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go func() {
+			defer wg.Done()
+			for index := range indexChan {
+				errs[index] = b.call(index)
+			}
+		}()
+	}
+	for index := range b.calls {
+		indexChan <- index
+	}
+	close(indexChan)
+	wg.Wait()
+	return
+}
+
+func (b *Batch) call(index int) (err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = &BatchPanicError{Index: index, Value: v}
+		}
+	}()
+
+	if b.limiter != nil {
+		b.limiter.Wait()
+	}
+	return b.calls[index]()
+}
+
+// 返回 errs 中第一个不为 nil 的错误, 没有则返回 nil.
+func FirstError(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Batch 中某个调用 panic 时返回的错误
+type BatchPanicError struct {
+	Index int         // 调用的下标
+	Value interface{} // recover() 的返回值
+}
+
+func (e *BatchPanicError) Error() string {
+	return fmt.Sprintf("batch call %d panic: %v", e.Index, e.Value)
+}
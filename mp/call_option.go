@@ -0,0 +1,83 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"net/http"
+	"time"
+)
+
+// 单次调用的选项, 只对这一次调用有效, 覆盖 WechatClient 上的设置, 不需要为了个别调用再创建一个 Client.
+//
+//  info, err := clt.UserInfo(openId, "", mp.WithTimeout(2*time.Second), mp.WithNoCache())
+type CallOption func(*callOptions)
+
+type callOptions struct {
+	timeout    time.Duration
+	maxRetries int
+	noCache    bool
+	decorators []RequestDecorator
+	limits     *ConcurrencyLimits
+}
+
+// 设置这次调用的超时时间(包括 access_token 过期后重试的时间), 覆盖 http.Client 的 Timeout.
+func WithTimeout(timeout time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = timeout
+	}
+}
+
+// 设置这次调用在网络错误或者 5xx 时的重试次数, 覆盖 WechatClient.MaxRetries; 同样只重试 IsIdempotent 的接口.
+func WithMaxRetries(n int) CallOption {
+	return func(o *callOptions) {
+		o.maxRetries = n
+	}
+}
+
+// 这次调用不读取 WechatClient.Cache 里的缓存, 直接请求微信服务器; 成功的结果依然会更新缓存.
+func WithNoCache() CallOption {
+	return func(o *callOptions) {
+		o.noCache = true
+	}
+}
+
+func (clt *WechatClient) callOptions(opts []CallOption) (o callOptions) {
+	o.maxRetries = clt.MaxRetries
+	o.limits = clt.ConcurrencyLimits
+	if clt.RequestDecorator != nil {
+		o.decorators = []RequestDecorator{clt.RequestDecorator}
+	}
+	for _, opt := range opts {
+		if opt != nil {
+			opt(&o)
+		}
+	}
+	return
+}
+
+// 返回这次调用使用的 http.Client, 设置了超时时间, RequestDecorator 或者 ConcurrencyLimits 时返回 httpClient 的一个副本.
+func (o *callOptions) httpClient(httpClient *http.Client) *http.Client {
+	if o.timeout <= 0 && len(o.decorators) == 0 && o.limits == nil {
+		return httpClient
+	}
+	c := *httpClient
+	if o.timeout > 0 {
+		c.Timeout = o.timeout
+	}
+	if len(o.decorators) > 0 {
+		c.Transport = &decoratorTransport{
+			transport:  c.Transport,
+			decorators: o.decorators,
+		}
+	}
+	if o.limits != nil {
+		c.Transport = &concurrencyTransport{
+			transport: c.Transport,
+			limits:    o.limits,
+		}
+	}
+	return &c
+}
@@ -0,0 +1,74 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     gaowenbin(gaowenbinmarr@gmail.com), chanxuehong(chanxuehong@gmail.com)
+
+package card
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 设置买单接口.
+//  设置后, 用户可以在卡券详情页直接使用微信支付买单, 买单时自动核销卡券;
+//  卡券需要设置了门店(location_id_list), 并且公众号开通了微信支付.
+func (clt *Client) PayCellSet(cardId string, isOpen bool, opts ...mp.CallOption) (err error) {
+	if cardId == "" {
+		return errors.New("empty cardId")
+	}
+
+	var request = struct {
+		CardId string `json:"card_id"`
+		IsOpen bool   `json:"is_open"`
+	}{
+		CardId: cardId,
+		IsOpen: isOpen,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/card/paycell/set?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 自助核销的参数
+type SelfConsumeCellSetParameters struct {
+	CardId           string `json:"card_id"`            // 必须; 卡券ID
+	IsOpen           bool   `json:"is_open"`            // 必须; 是否开启自助核销功能
+	NeedVerifyCod    bool   `json:"need_verify_cod"`    // 可选; 用户核销时是否需要输入验证码
+	NeedRemarkAmount bool   `json:"need_remark_amount"` // 可选; 用户核销时是否需要备注核销金额
+}
+
+// 设置自助核销接口.
+//  设置后, 用户可以在卡券详情页点击"立即核销"自行核销卡券, 核销后推送 user_consume_card 事件.
+func (clt *Client) SelfConsumeCellSet(para *SelfConsumeCellSetParameters, opts ...mp.CallOption) (err error) {
+	if para == nil {
+		return errors.New("nil SelfConsumeCellSetParameters")
+	}
+	if para.CardId == "" {
+		return errors.New("empty CardId")
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/card/selfconsumecell/set?access_token="
+	if err = clt.PostJSON(incompleteURL, para, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
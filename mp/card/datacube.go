@@ -0,0 +1,246 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     gaowenbin(gaowenbinmarr@gmail.com), chanxuehong(chanxuehong@gmail.com)
+
+package card
+
+import (
+	"errors"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 卡券数据的来源
+const (
+	CondSourceMP  = 0 // 公众平台创建的卡券数据
+	CondSourceAPI = 1 // API 创建的卡券数据
+)
+
+// 卡券统计数据的日期格式, 跟 ref_date 一样是 YYYY-MM-DD 格式.
+const DatacubeDateLayout = "2006-01-02"
+
+// 卡券统计数据的最大时间跨度, 单位为天.
+const DatacubeMaxDays = 62
+
+// 卡券统计数据共有的计数.
+type CardStat struct {
+	ViewCnt     int `json:"view_cnt"`     // 浏览次数
+	ViewUser    int `json:"view_user"`    // 浏览人数
+	ReceiveCnt  int `json:"receive_cnt"`  // 领取次数
+	ReceiveUser int `json:"receive_user"` // 领取人数
+	VerifyCnt   int `json:"verify_cnt"`   // 使用次数
+	VerifyUser  int `json:"verify_user"`  // 使用人数
+	GivenCnt    int `json:"given_cnt"`    // 转赠次数
+	GivenUser   int `json:"given_user"`   // 转赠人数
+	ExpireCnt   int `json:"expire_cnt"`   // 过期次数
+	ExpireUser  int `json:"expire_user"`  // 过期人数
+}
+
+// 把 x 累加到 stat 上, 用于计算一段时间的合计; 注意人数累加后是人次, 不是去重后的人数.
+func (stat *CardStat) Add(x CardStat) {
+	stat.ViewCnt += x.ViewCnt
+	stat.ViewUser += x.ViewUser
+	stat.ReceiveCnt += x.ReceiveCnt
+	stat.ReceiveUser += x.ReceiveUser
+	stat.VerifyCnt += x.VerifyCnt
+	stat.VerifyUser += x.VerifyUser
+	stat.GivenCnt += x.GivenCnt
+	stat.GivenUser += x.GivenUser
+	stat.ExpireCnt += x.ExpireCnt
+	stat.ExpireUser += x.ExpireUser
+}
+
+// 卡券概况数据
+type CardBizUinInfo struct {
+	RefDate string `json:"ref_date"` // 数据的日期, YYYY-MM-DD 格式
+	CardStat
+}
+
+// 免费券数据
+type CardCardInfo struct {
+	RefDate  string `json:"ref_date"`  // 数据的日期, YYYY-MM-DD 格式
+	CardId   string `json:"card_id"`   // 卡券ID
+	CardType int    `json:"card_type"` // 卡券类型, 0: 折扣券, 1: 代金券, 2: 礼品券, 3: 优惠券, 4: 团购券(暂不支持拉取特殊票券类型数据)
+	CardStat
+}
+
+// 会员卡概况数据
+type MemberCardInfo struct {
+	RefDate          string `json:"ref_date"`           // 数据的日期, YYYY-MM-DD 格式
+	ViewCnt          int    `json:"view_cnt"`           // 浏览次数
+	ViewUser         int    `json:"view_user"`          // 浏览人数
+	ReceiveCnt       int    `json:"receive_cnt"`        // 领取次数
+	ReceiveUser      int    `json:"receive_user"`       // 领取人数
+	ActiveUser       int    `json:"active_user"`        // 激活人数
+	VerifyCnt        int    `json:"verify_cnt"`         // 使用次数
+	VerifyUser       int    `json:"verify_user"`        // 使用人数
+	TotalUser        int    `json:"total_user"`         // 有效会员总人数
+	TotalReceiveUser int    `json:"total_receive_user"` // 历史领取会员卡总人数
+}
+
+// 单张会员卡数据
+type MemberCardDetail struct {
+	RefDate        string `json:"ref_date"`       // 数据的日期, YYYY-MM-DD 格式
+	MerchantType   int    `json:"merchanttype"`   // 子商户类型
+	CardId         string `json:"cardid"`         // 会员卡ID
+	SubMerchantId  int64  `json:"submerchantid"`  // 子商户ID
+	ViewCnt        int    `json:"view_cnt"`       // 浏览次数
+	ViewUser       int    `json:"view_user"`      // 浏览人数
+	ReceiveCnt     int    `json:"receive_cnt"`    // 领取次数
+	ReceiveUser    int    `json:"receive_user"`   // 领取人数
+	VerifyCnt      int    `json:"verify_cnt"`     // 使用次数
+	VerifyUser     int    `json:"verify_user"`    // 使用人数
+	ActiveCnt      int    `json:"active_cnt"`     // 激活次数
+	ActiveUser     int    `json:"active_user"`    // 激活人数
+	TotalUser      int    `json:"total_user"`     // 有效会员总人数
+	NewUser        int    `json:"new_user"`       // 新增会员人数
+	PayOriginalFee int    `json:"payOriginalFee"` // 应付金额, 单位为分
+	Fee            int    `json:"fee"`            // 实付金额, 单位为分
+}
+
+type datacubeRequest struct {
+	BeginDate  string `json:"begin_date"`
+	EndDate    string `json:"end_date"`
+	CondSource *int   `json:"cond_source,omitempty"`
+	CardId     string `json:"card_id,omitempty"`
+}
+
+// 请注意 beginDate, endDate 的 Location.
+func newDatacubeRequest(beginDate, endDate time.Time) (req *datacubeRequest, err error) {
+	begin := beginDate.Format(DatacubeDateLayout)
+	end := endDate.Format(DatacubeDateLayout)
+	if end < begin {
+		err = errors.New("endDate is before beginDate")
+		return
+	}
+	b, _ := time.Parse(DatacubeDateLayout, begin)
+	e, _ := time.Parse(DatacubeDateLayout, end)
+	if e.Sub(b) >= DatacubeMaxDays*24*time.Hour {
+		err = errors.New("the range of date can not exceed DatacubeMaxDays")
+		return
+	}
+	req = &datacubeRequest{
+		BeginDate: begin,
+		EndDate:   end,
+	}
+	return
+}
+
+// 拉取卡券概况数据.
+//  获取公众号下所有卡券(不包括会员卡)的每日统计数据; 查询的时间跨度不能超过 DatacubeMaxDays 天, endDate 最大为昨日.
+//  condSource: 卡券来源, CondSourceMP 或 CondSourceAPI
+func (clt *Client) GetCardBizUinInfo(beginDate, endDate time.Time, condSource int, opts ...mp.CallOption) (list []CardBizUinInfo, err error) {
+	request, err := newDatacubeRequest(beginDate, endDate)
+	if err != nil {
+		return
+	}
+	request.CondSource = &condSource
+
+	var result struct {
+		mp.Error
+		List []CardBizUinInfo `json:"list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/datacube/getcardbizuininfo?access_token="
+	if err = clt.PostJSON(incompleteURL, request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	list = result.List
+	return
+}
+
+// 获取免费券数据.
+//  获取某一张(cardId 不为空)或者全部免费券(优惠券, 团购券, 折扣券, 礼品券)的每日统计数据;
+//  查询的时间跨度不能超过 DatacubeMaxDays 天, endDate 最大为昨日.
+//  condSource: 卡券来源, CondSourceMP 或 CondSourceAPI
+func (clt *Client) GetCardCardInfo(beginDate, endDate time.Time, condSource int, cardId string, opts ...mp.CallOption) (list []CardCardInfo, err error) {
+	request, err := newDatacubeRequest(beginDate, endDate)
+	if err != nil {
+		return
+	}
+	request.CondSource = &condSource
+	request.CardId = cardId
+
+	var result struct {
+		mp.Error
+		List []CardCardInfo `json:"list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/datacube/getcardcardinfo?access_token="
+	if err = clt.PostJSON(incompleteURL, request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	list = result.List
+	return
+}
+
+// 拉取会员卡概况数据.
+//  获取公众号下所有会员卡的每日统计数据; 查询的时间跨度不能超过 DatacubeMaxDays 天, endDate 最大为昨日.
+//  condSource: 卡券来源, CondSourceMP 或 CondSourceAPI
+func (clt *Client) GetCardMemberCardInfo(beginDate, endDate time.Time, condSource int, opts ...mp.CallOption) (list []MemberCardInfo, err error) {
+	request, err := newDatacubeRequest(beginDate, endDate)
+	if err != nil {
+		return
+	}
+	request.CondSource = &condSource
+
+	var result struct {
+		mp.Error
+		List []MemberCardInfo `json:"list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/datacube/getcardmembercardinfo?access_token="
+	if err = clt.PostJSON(incompleteURL, request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	list = result.List
+	return
+}
+
+// 拉取单张会员卡数据.
+//  获取一张会员卡的每日统计数据, 包括支付的金额; 查询的时间跨度不能超过 DatacubeMaxDays 天, endDate 最大为昨日.
+func (clt *Client) GetCardMemberCardDetail(beginDate, endDate time.Time, cardId string, opts ...mp.CallOption) (list []MemberCardDetail, err error) {
+	if cardId == "" {
+		err = errors.New("empty cardId")
+		return
+	}
+	request, err := newDatacubeRequest(beginDate, endDate)
+	if err != nil {
+		return
+	}
+	request.CardId = cardId
+
+	var result struct {
+		mp.Error
+		List []MemberCardDetail `json:"list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/datacube/getcardmembercarddetail?access_token="
+	if err = clt.PostJSON(incompleteURL, request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	list = result.List
+	return
+}
@@ -15,6 +15,12 @@ const (
 	EventTypeCardNotPassCheck = "card_not_pass_check" // 卡券未通过审核
 	EventTypeUserGetCard      = "user_get_card"       // 领取卡券事件
 	EventTypeUserDelCard      = "user_del_card"       // 删除卡券事件
+
+	EventTypeGiftCardPayDone      = "giftcard_pay_done"       // 用户购买礼品卡付款成功
+	EventTypeGiftCardSendToFriend = "giftcard_send_to_friend" // 用户购买后赠送礼品卡
+	EventTypeGiftCardUserAccept   = "giftcard_user_accept"    // 用户领取礼品卡成功
+
+	EventTypeSubmitMemberCardUserInfo = "submit_membercard_user_info" // 用户通过一键激活提交开卡资料
 )
 
 // 卡券通过审核，微信会把这个事件推送到开发者填写的URL
@@ -94,3 +100,84 @@ func GetUserDelCardEvent(msg *mp.MixedMessage) *UserDelCardEvent {
 		UserCardCode:        msg.UserCardCode,
 	}
 }
+
+// 用户购买礼品卡付款成功后, 微信会把这个事件推送到开发者填写的URL, 可以用 OrderId 调用 GiftCardOrderGet 查询订单.
+type GiftCardPayDoneEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	mp.CommonMessageHeader
+
+	Event   string `xml:"Event"   json:"Event"`   // 事件类型, giftcard_pay_done
+	PageId  string `xml:"PageId"  json:"PageId"`  // 货架ID
+	OrderId string `xml:"OrderId" json:"OrderId"` // 订单号
+}
+
+func GetGiftCardPayDoneEvent(msg *mp.MixedMessage) *GiftCardPayDoneEvent {
+	return &GiftCardPayDoneEvent{
+		CommonMessageHeader: msg.CommonMessageHeader,
+		Event:               msg.Event,
+		PageId:              msg.PageId,
+		OrderId:             msg.OrderId,
+	}
+}
+
+// 用户购买礼品卡后赠送给朋友时, 微信会把这个事件推送到开发者填写的URL.
+type GiftCardSendToFriendEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	mp.CommonMessageHeader
+
+	Event        string `xml:"Event"        json:"Event"`        // 事件类型, giftcard_send_to_friend
+	PageId       string `xml:"PageId"       json:"PageId"`       // 货架ID
+	OrderId      string `xml:"OrderId"      json:"OrderId"`      // 订单号
+	IsReturnBack bool   `xml:"IsReturnBack" json:"IsReturnBack"` // 是否转赠退回, 朋友 24 小时内没有领取时退回给购买者
+	IsChatRoom   bool   `xml:"IsChatRoom"   json:"IsChatRoom"`   // 是否发送到群
+}
+
+func GetGiftCardSendToFriendEvent(msg *mp.MixedMessage) *GiftCardSendToFriendEvent {
+	return &GiftCardSendToFriendEvent{
+		CommonMessageHeader: msg.CommonMessageHeader,
+		Event:               msg.Event,
+		PageId:              msg.PageId,
+		OrderId:             msg.OrderId,
+		IsReturnBack:        msg.IsReturnBack,
+		IsChatRoom:          msg.IsChatRoom,
+	}
+}
+
+// 用户领取礼品卡成功后, 微信会把这个事件推送到开发者填写的URL.
+type GiftCardUserAcceptEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	mp.CommonMessageHeader
+
+	Event   string `xml:"Event"   json:"Event"`   // 事件类型, giftcard_user_accept
+	PageId  string `xml:"PageId"  json:"PageId"`  // 货架ID
+	OrderId string `xml:"OrderId" json:"OrderId"` // 订单号
+}
+
+func GetGiftCardUserAcceptEvent(msg *mp.MixedMessage) *GiftCardUserAcceptEvent {
+	return &GiftCardUserAcceptEvent{
+		CommonMessageHeader: msg.CommonMessageHeader,
+		Event:               msg.Event,
+		PageId:              msg.PageId,
+		OrderId:             msg.OrderId,
+	}
+}
+
+// 用户通过一键激活的开卡页面提交资料后, 微信会把这个事件推送到开发者填写的URL.
+//  这时用户还在开卡页面, 跳转到商户页面后再用 MemberCardActivateFromRedirect 处理开卡申请.
+type SubmitMemberCardUserInfoEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	mp.CommonMessageHeader
+
+	Event        string `xml:"Event"        json:"Event"`        // 事件类型, submit_membercard_user_info
+	CardId       string `xml:"CardId"       json:"CardId"`       // 会员卡ID
+	UserCardCode string `xml:"UserCardCode" json:"UserCardCode"` // 会员卡 code
+}
+
+func GetSubmitMemberCardUserInfoEvent(msg *mp.MixedMessage) *SubmitMemberCardUserInfoEvent {
+	return &SubmitMemberCardUserInfoEvent{
+		CommonMessageHeader: msg.CommonMessageHeader,
+		Event:               msg.Event,
+		CardId:              msg.CardId,
+		UserCardCode:        msg.UserCardCode,
+	}
+}
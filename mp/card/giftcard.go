@@ -0,0 +1,337 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     gaowenbin(gaowenbinmarr@gmail.com), chanxuehong(chanxuehong@gmail.com)
+
+package card
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 礼品卡货架的自定义入口
+type GiftCardCell struct {
+	Title string `json:"title"` // 入口的名称
+	URL   string `json:"url"`   // 入口跳转的链接
+}
+
+// 礼品卡货架主题里的一张卡
+type GiftCardThemeItem struct {
+	CardId string `json:"card_id"`         // 必须; 礼品卡的卡券ID
+	Title  string `json:"title,omitempty"` // 可选; 商品名, 不填默认为卡券的 title
+}
+
+// 礼品卡货架主题里的一张卡面
+type GiftCardPicItem struct {
+	BackgroundPicURL  string `json:"background_pic_url"`            // 必须; 卡面图片, 须调用 UploadImage 上传; 图片尺寸为 1000*600
+	OuterImgId        string `json:"outer_img_id,omitempty"`        // 可选; 自定义的卡面的标识
+	DefaultGiftingMsg string `json:"default_gifting_msg,omitempty"` // 可选; 该卡面对应的默认祝福语
+}
+
+// 礼品卡货架的主题
+type GiftCardTheme struct {
+	ThemePicURL       string              `json:"theme_pic_url"`                  // 必须; 主题的封面图片, 须调用 UploadImage 上传; 图片尺寸为 750*630
+	Title             string              `json:"title"`                          // 必须; 主题名称
+	TitleColor        string              `json:"title_color,omitempty"`          // 可选; 主题字体颜色, 比如 #FB966E
+	ItemList          []GiftCardThemeItem `json:"item_list"`                      // 必须; 礼品卡列表, 标识该主题可选择的面额
+	PicItemList       []GiftCardPicItem   `json:"pic_item_list"`                  // 必须; 卡面列表
+	CategoryIndex     int                 `json:"category_index,omitempty"`       // 可选; 该主题所在的分类在 CategoryList 里的下标
+	ShowSkuTitleFirst bool                `json:"show_sku_title_first,omitempty"` // 可选; 是否将当前主题设置为 sku 标题优先展示
+	IsBanner          bool                `json:"is_banner,omitempty"`            // 可选; 是否为 banner 主题, 一个货架只能有一个 banner 主题
+}
+
+// 礼品卡货架的主题分类
+type GiftCardCategory struct {
+	Title string `json:"title"` // 分类的名称
+}
+
+// 礼品卡货架
+type GiftCardPage struct {
+	PageId            string             `json:"page_id,omitempty"`       // 货架ID, 创建时不填, 更新时必须
+	PageTitle         string             `json:"page_title"`              // 必须; 礼品卡货架名称
+	SupportMulti      bool               `json:"support_multi"`           // 可选; 是否支持一次购买多张及发送至群, 填 true 或者 false
+	SupportBuyForSelf bool               `json:"support_buy_for_self"`    // 可选; 是否支持购买给自己
+	BannerPicURL      string             `json:"banner_pic_url"`          // 必须; 礼品卡货架主题页顶部的 banner 图片, 须调用 UploadImage 上传
+	ThemeList         []GiftCardTheme    `json:"theme_list"`              // 必须; 主题列表
+	CategoryList      []GiftCardCategory `json:"category_list,omitempty"` // 可选; 主题分类列表
+	Address           string             `json:"address"`                 // 必须; 商家地址
+	ServicePhone      string             `json:"service_phone"`           // 必须; 商家服务电话
+	BizDescription    string             `json:"biz_description"`         // 可选; 商家使用说明, 用于描述退款, 发票等流程
+	NeedReceipt       bool               `json:"need_receipt"`            // 可选; 该货架的订单是否支持开发票
+	Cell1             *GiftCardCell      `json:"cell_1,omitempty"`        // 可选; 商家自定义入口1
+	Cell2             *GiftCardCell      `json:"cell_2,omitempty"`        // 可选; 商家自定义入口2
+}
+
+// 创建礼品卡货架.
+//  礼品卡需要先用 CardCreate 创建, 卡券类型为 GENERAL_CARD 或者 MEMBER_CARD, 并且设置 sub_card_type 为 GIFT_CARD.
+func (clt *Client) GiftCardPageAdd(page *GiftCardPage, opts ...mp.CallOption) (pageId string, err error) {
+	if page == nil {
+		err = errors.New("nil GiftCardPage")
+		return
+	}
+
+	var request = struct {
+		Page *GiftCardPage `json:"page"`
+	}{
+		Page: page,
+	}
+
+	var result struct {
+		mp.Error
+		PageId string `json:"page_id"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/card/giftcard/page/add?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	pageId = result.PageId
+	return
+}
+
+// 查询礼品卡货架信息.
+func (clt *Client) GiftCardPageGet(pageId string, opts ...mp.CallOption) (page *GiftCardPage, err error) {
+	var request = struct {
+		PageId string `json:"page_id"`
+	}{
+		PageId: pageId,
+	}
+
+	var result struct {
+		mp.Error
+		Page GiftCardPage `json:"page"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/card/giftcard/page/get?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	page = &result.Page
+	return
+}
+
+// 修改礼品卡货架信息.
+//  page.PageId 不能为空, 其他字段跟 GiftCardPageAdd 一样, 修改后覆盖原来的货架信息.
+func (clt *Client) GiftCardPageUpdate(page *GiftCardPage, opts ...mp.CallOption) (err error) {
+	if page == nil {
+		return errors.New("nil GiftCardPage")
+	}
+	if page.PageId == "" {
+		return errors.New("empty GiftCardPage.PageId")
+	}
+
+	var request = struct {
+		Page *GiftCardPage `json:"page"`
+	}{
+		Page: page,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/card/giftcard/page/update?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 查询礼品卡货架列表.
+func (clt *Client) GiftCardPageBatchGet(opts ...mp.CallOption) (pageIdList []string, err error) {
+	var request = struct{}{}
+
+	var result struct {
+		mp.Error
+		PageIdList []string `json:"page_id_list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/card/giftcard/page/batchget?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	pageIdList = result.PageIdList
+	return
+}
+
+// 下架或者重新上架一个礼品卡货架.
+//  maintain: true 表示下架(货架进入维护状态, 用户不能购买), false 表示恢复上架
+func (clt *Client) GiftCardMaintainSet(pageId string, maintain bool, opts ...mp.CallOption) (err error) {
+	if pageId == "" {
+		return errors.New("empty pageId")
+	}
+
+	var request = struct {
+		PageId   string `json:"page_id"`
+		Maintain bool   `json:"maintain"`
+	}{
+		PageId:   pageId,
+		Maintain: maintain,
+	}
+	return clt.giftCardMaintainSet(&request, opts...)
+}
+
+// 下架或者重新上架所有的礼品卡货架, 参数同 GiftCardMaintainSet.
+func (clt *Client) GiftCardMaintainSetAll(maintain bool, opts ...mp.CallOption) (err error) {
+	var request = struct {
+		All      bool `json:"all"`
+		Maintain bool `json:"maintain"`
+	}{
+		All:      true,
+		Maintain: maintain,
+	}
+	return clt.giftCardMaintainSet(&request, opts...)
+}
+
+func (clt *Client) giftCardMaintainSet(request interface{}, opts ...mp.CallOption) (err error) {
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/card/giftcard/maintain/set?access_token="
+	if err = clt.PostJSON(incompleteURL, request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 礼品卡订单里的一张卡
+type GiftCardOrderCard struct {
+	CardId            string `json:"card_id"`             // 卡券ID
+	Price             int    `json:"price"`               // 卡的面额, 单位为分
+	Code              string `json:"code"`                // 卡的 code
+	DefaultGiftingMsg string `json:"default_gifting_msg"` // 默认的祝福语
+	AcceptTime        int64  `json:"accept_time"`         // 领取的时间, 未领取时为 0
+}
+
+// 礼品卡订单
+type GiftCardOrder struct {
+	OrderId        string              `json:"order_id"`        // 订单号
+	PageId         string              `json:"page_id"`         // 订单所属的货架ID
+	TransId        string              `json:"trans_id"`        // 微信支付交易订单号
+	CreateTime     int64               `json:"create_time"`     // 订单创建时间, unixtime
+	PayFinishTime  int64               `json:"pay_finish_time"` // 支付完成时间, unixtime
+	TotalPrice     int                 `json:"total_price"`     // 订单总价, 单位为分
+	OpenId         string              `json:"open_id"`         // 购买者的 openid
+	AccepterOpenId string              `json:"accepter_openid"` // 接收者的 openid
+	CardList       []GiftCardOrderCard `json:"card_list"`       // 订单里的卡
+	OuterStr       string              `json:"outer_str"`       // 购买时货架链接上的 outer_str 参数, 用于区分渠道
+}
+
+// 查询单个礼品卡订单信息.
+//  一般收到 giftcard_pay_done 事件后调用, 见 GetGiftCardPayDoneEvent.
+func (clt *Client) GiftCardOrderGet(orderId string, opts ...mp.CallOption) (order *GiftCardOrder, err error) {
+	if orderId == "" {
+		err = errors.New("empty orderId")
+		return
+	}
+
+	var request = struct {
+		OrderId string `json:"order_id"`
+	}{
+		OrderId: orderId,
+	}
+
+	var result struct {
+		mp.Error
+		Order GiftCardOrder `json:"order"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/card/giftcard/order/get?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	order = &result.Order
+	return
+}
+
+// 批量查询礼品卡订单的参数
+type GiftCardOrderBatchGetParameters struct {
+	BeginTime int64  `json:"begin_time"`          // 必须; 查询的时间起点, unixtime
+	EndTime   int64  `json:"end_time"`            // 必须; 查询的时间终点, unixtime
+	SortType  string `json:"sort_type,omitempty"` // 可选; 按照订单创建时间排序, "ASC" 或者 "DESC", 默认为 "ASC"
+	Offset    int    `json:"offset"`              // 必须; 查询的订单偏移量
+	Count     int    `json:"count"`               // 必须; 查询的订单数量, 最大为 100
+}
+
+// 批量查询礼品卡订单信息.
+func (clt *Client) GiftCardOrderBatchGet(para *GiftCardOrderBatchGetParameters, opts ...mp.CallOption) (orderList []GiftCardOrder, totalCount int, err error) {
+	if para == nil {
+		err = errors.New("nil GiftCardOrderBatchGetParameters")
+		return
+	}
+
+	var result struct {
+		mp.Error
+		OrderList  []GiftCardOrder `json:"order_list"`
+		TotalCount int             `json:"total_count"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/card/giftcard/order/batchget?access_token="
+	if err = clt.PostJSON(incompleteURL, para, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	orderList = result.OrderList
+	totalCount = result.TotalCount
+	return
+}
+
+// 礼品卡订单退款.
+//  退款后订单里所有的卡都会失效, 已经被领取的卡也不例外.
+func (clt *Client) GiftCardOrderRefund(orderId string, opts ...mp.CallOption) (err error) {
+	if orderId == "" {
+		return errors.New("empty orderId")
+	}
+
+	var request = struct {
+		OrderId string `json:"order_id"`
+	}{
+		OrderId: orderId,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/card/giftcard/order/refund?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
@@ -22,21 +22,21 @@ type ImageInfo struct {
 // 上传图片, 用于卡卷的 logo_url.
 //  1.上传的图片限制文件大小限制1MB，像素为300*300，支持JPG 格式。
 //  2.调用接口获取的logo_url 进支持在微信相关业务下使用，否则会做相应处理。
-func (clt *Client) UploadImage(imgPath string) (info ImageInfo, err error) {
+func (clt *Client) UploadImage(imgPath string, opts ...mp.CallOption) (info ImageInfo, err error) {
 	file, err := os.Open(imgPath)
 	if err != nil {
 		return
 	}
 	defer file.Close()
 
-	return clt.uploadImageFromReader(filepath.Base(imgPath), file)
+	return clt.uploadImageFromReader(filepath.Base(imgPath), file, opts...)
 }
 
 // 上传图片, 用于卡卷的 logo_url.
 //  1.上传的图片限制文件大小限制1MB，像素为300*300，支持JPG 格式。
 //  2.调用接口获取的logo_url 进支持在微信相关业务下使用，否则会做相应处理。
 //  3.注意参数 filename 不是文件路径, 是指定 multipart/form-data 里面文件名称
-func (clt *Client) UploadImageFromReader(filename string, reader io.Reader) (info ImageInfo, err error) {
+func (clt *Client) UploadImageFromReader(filename string, reader io.Reader, opts ...mp.CallOption) (info ImageInfo, err error) {
 	if filename == "" {
 		err = errors.New("empty filename")
 		return
@@ -46,17 +46,17 @@ func (clt *Client) UploadImageFromReader(filename string, reader io.Reader) (inf
 		return
 	}
 
-	return clt.uploadImageFromReader(filename, reader)
+	return clt.uploadImageFromReader(filename, reader, opts...)
 }
 
-func (clt *Client) uploadImageFromReader(filename string, reader io.Reader) (info ImageInfo, err error) {
+func (clt *Client) uploadImageFromReader(filename string, reader io.Reader, opts ...mp.CallOption) (info ImageInfo, err error) {
 	var result struct {
 		mp.Error
 		ImageInfo
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/media/uploadimg?access_token="
-	if err = clt.UploadFromReader(incompleteURL, "buffer", filename, reader, "", nil, &result); err != nil {
+	if err = clt.UploadFromReader(incompleteURL, "buffer", filename, reader, "", nil, &result, opts...); err != nil {
 		return
 	}
 
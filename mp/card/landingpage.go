@@ -0,0 +1,70 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     gaowenbin(gaowenbinmarr@gmail.com), chanxuehong(chanxuehong@gmail.com)
+
+package card
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 货架的投放场景
+const (
+	LandingPageSceneNearBy         = "SCENE_NEAR_BY"          // 附近
+	LandingPageSceneMenu           = "SCENE_MENU"             // 自定义菜单
+	LandingPageSceneQRCode         = "SCENE_QRCODE"           // 二维码
+	LandingPageSceneArticle        = "SCENE_ARTICLE"          // 公众号文章
+	LandingPageSceneH5             = "SCENE_H5"               // H5 页面
+	LandingPageSceneIVR            = "SCENE_IVR"              // 自动回复
+	LandingPageSceneCardCustomCell = "SCENE_CARD_CUSTOM_CELL" // 卡券自定义 cell
+)
+
+// 货架上的一张卡券
+type LandingPageCard struct {
+	CardId   string `json:"card_id"`   // 必须; 卡券ID
+	ThumbURL string `json:"thumb_url"` // 必须; 卡券的缩略图 URL, 建议上传 UploadImage 得到
+}
+
+// 创建货架的参数
+type LandingPage struct {
+	Banner    string            `json:"banner"`     // 必须; 页面的 banner 图片链接, 须调用 UploadImage 上传; 图片尺寸为 640*340
+	PageTitle string            `json:"page_title"` // 必须; 页面的 title
+	CanShare  bool              `json:"can_share"`  // 必须; 页面是否可以分享
+	Scene     string            `json:"scene"`      // 必须; 投放页面的场景值, 见 LandingPageSceneXXX
+	CardList  []LandingPageCard `json:"card_list"`  // 必须; 卡券列表
+}
+
+// 创建货架接口.
+//  创建一个包含多张卡券的投放页面, 返回页面的 URL(可以设置到菜单, 文章, 二维码等) 和 pageId.
+func (clt *Client) LandingPageCreate(page *LandingPage, opts ...mp.CallOption) (pageURL string, pageId int64, err error) {
+	if page == nil {
+		err = errors.New("nil LandingPage")
+		return
+	}
+	if len(page.CardList) == 0 {
+		err = errors.New("empty LandingPage.CardList")
+		return
+	}
+
+	var result struct {
+		mp.Error
+		URL    string `json:"url"`
+		PageId int64  `json:"page_id"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/card/landingpage/create?access_token="
+	if err = clt.PostJSON(incompleteURL, page, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	pageURL = result.URL
+	pageId = result.PageId
+	return
+}
@@ -31,7 +31,7 @@ type LocationAddParameters struct {
 //    门店信息未通过审核，则会被剔除出门店列表。
 //  2.LocationList 和 LocationIdList 长度相等, 如果 LocationList 某个门店导入失败,
 //    那么 LocationIdList 对应的位置就是等于 -1
-func (clt *Client) LocationBatchAdd(LocationList []LocationAddParameters) (LocationIdList []int64, err error) {
+func (clt *Client) LocationBatchAdd(LocationList []LocationAddParameters, opts ...mp.CallOption) (LocationIdList []int64, err error) {
 	if len(LocationList) <= 0 {
 		return
 	}
@@ -48,7 +48,7 @@ func (clt *Client) LocationBatchAdd(LocationList []LocationAddParameters) (Locat
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/card/location/batchadd?access_token="
-	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
 		return
 	}
 
@@ -73,7 +73,7 @@ type Location struct {
 //  offset: 偏移量，0 开始
 //  count:  拉取数量
 //  注：“offset”，“count”为0 时默认拉取全部门店。
-func (clt *Client) LocationBatchGet(offset, count int) (LocationList []Location, err error) {
+func (clt *Client) LocationBatchGet(offset, count int, opts ...mp.CallOption) (LocationList []Location, err error) {
 	if offset < 0 {
 		err = fmt.Errorf("invalid offset: %d", offset)
 		return
@@ -98,7 +98,7 @@ func (clt *Client) LocationBatchGet(offset, count int) (LocationList []Location,
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/card/location/batchget?access_token="
-	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
 		return
 	}
 
@@ -22,7 +22,7 @@ type MeetingTicketUpdateUserParameters struct {
 
 // 更新电影票.
 //  领取电影票后通过调用“更新电影票”接口update 电影信息及用户选座信息
-func (clt *Client) MeetingTicketUpdateUser(para *MeetingTicketUpdateUserParameters) (err error) {
+func (clt *Client) MeetingTicketUpdateUser(para *MeetingTicketUpdateUserParameters, opts ...mp.CallOption) (err error) {
 	if para == nil {
 		return errors.New("nil MeetingTicketUpdateUserParameters")
 	}
@@ -30,7 +30,7 @@ func (clt *Client) MeetingTicketUpdateUser(para *MeetingTicketUpdateUserParamete
 	var result mp.Error
 
 	incompleteURL := "https://api.weixin.qq.com/card/meetingticket/updateuser?access_token="
-	if err = clt.PostJSON(incompleteURL, para, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, para, &result, opts...); err != nil {
 		return
 	}
 
@@ -0,0 +1,262 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     gaowenbin(gaowenbinmarr@gmail.com), chanxuehong(chanxuehong@gmail.com)
+
+package card
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 开卡表单的通用字段
+const (
+	UserFormInfoFlagMobile              = "USER_FORM_INFO_FLAG_MOBILE"            // 手机号
+	UserFormInfoFlagSex                 = "USER_FORM_INFO_FLAG_SEX"               // 性别
+	UserFormInfoFlagName                = "USER_FORM_INFO_FLAG_NAME"              // 姓名
+	UserFormInfoFlagBirthday            = "USER_FORM_INFO_FLAG_BIRTHDAY"          // 生日
+	UserFormInfoFlagIdCard              = "USER_FORM_INFO_FLAG_IDCARD"            // 身份证
+	UserFormInfoFlagEmail               = "USER_FORM_INFO_FLAG_EMAIL"             // 邮箱
+	UserFormInfoFlagLocation            = "USER_FORM_INFO_FLAG_LOCATION"          // 详细地址
+	UserFormInfoFlagEducationBackground = "USER_FORM_INFO_FLAG_EDUCATION_BACKGRO" // 教育背景
+	UserFormInfoFlagIndustry            = "USER_FORM_INFO_FLAG_INDUSTRY"          // 行业
+	UserFormInfoFlagIncome              = "USER_FORM_INFO_FLAG_INCOME"            // 收入
+	UserFormInfoFlagHabit               = "USER_FORM_INFO_FLAG_HABIT"             // 兴趣爱好
+)
+
+// 开卡表单的富文本字段类型
+const (
+	FormFieldRadio    = "FORM_FIELD_RADIO"     // 自定义单选
+	FormFieldSelect   = "FORM_FIELD_SELECT"    // 自定义选择项
+	FormFieldCheckBox = "FORM_FIELD_CHECK_BOX" // 自定义多选
+)
+
+// 开卡表单的富文本字段
+type ActivateRichField struct {
+	Type   string   `json:"type"`   // 字段类型, 见 FormFieldXXX
+	Name   string   `json:"name"`   // 字段名
+	Values []string `json:"values"` // 选择项
+}
+
+// 开卡表单
+type ActivateForm struct {
+	CanModify         bool                `json:"can_modify"`                     // 用户提交后, 在会员卡详情页是否可以修改
+	CommonFieldIdList []string            `json:"common_field_id_list,omitempty"` // 通用字段, 见 UserFormInfoFlagXXX
+	CustomFieldList   []string            `json:"custom_field_list,omitempty"`    // 自定义字段名称, 最多 5 个
+	RichFieldList     []ActivateRichField `json:"rich_field_list,omitempty"`      // 自定义富文本字段
+}
+
+// 开卡页面上的链接
+type ActivateFormLink struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// 设置开卡字段的参数
+type ActivateUserFormSetParameters struct {
+	CardId           string            `json:"card_id"`                     // 必须; 会员卡ID
+	ServiceStatement *ActivateFormLink `json:"service_statement,omitempty"` // 可选; 服务声明, 用于放置商户会员卡守则
+	BindOldCard      *ActivateFormLink `json:"bind_old_card,omitempty"`     // 可选; 绑定老会员的链接
+	RequiredForm     *ActivateForm     `json:"required_form,omitempty"`     // 可选; 必填的字段
+	OptionalForm     *ActivateForm     `json:"optional_form,omitempty"`     // 可选; 选填的字段
+}
+
+// 设置开卡字段接口.
+//  会员卡需要设置 wx_activate 为 true(一键激活), 设置后用户领卡时在微信的开卡页面填写这些字段.
+func (clt *Client) MemberCardActivateUserFormSet(para *ActivateUserFormSetParameters, opts ...mp.CallOption) (err error) {
+	if para == nil {
+		return errors.New("nil ActivateUserFormSetParameters")
+	}
+	if para.CardId == "" {
+		return errors.New("empty CardId")
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/card/membercard/activateuserform/set?access_token="
+	if err = clt.PostJSON(incompleteURL, para, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 获取开卡组件链接接口.
+//  用户打开链接后领取会员卡并且填写开卡字段, 一般用于公众号菜单或者图文消息里.
+//  outerStr: 渠道值, 用于统计本次领取的渠道, 会带到开卡后跳转的页面上
+func (clt *Client) MemberCardActivateGetURL(cardId, outerStr string, opts ...mp.CallOption) (activateURL string, err error) {
+	if cardId == "" {
+		err = errors.New("empty cardId")
+		return
+	}
+
+	var request = struct {
+		CardId   string `json:"card_id"`
+		OuterStr string `json:"outer_str,omitempty"`
+	}{
+		CardId:   cardId,
+		OuterStr: outerStr,
+	}
+
+	var result struct {
+		mp.Error
+		URL string `json:"url"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/card/membercard/activate/geturl?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	activateURL = result.URL
+	return
+}
+
+// 用户填写的一个开卡字段
+type ActivateFieldValue struct {
+	Name      string   `json:"name"`                 // 字段名, 通用字段为 UserFormInfoFlagXXX
+	Value     string   `json:"value"`                // 字段值
+	ValueList []string `json:"value_list,omitempty"` // 多选字段的值
+}
+
+// 用户提交的开卡资料
+type ActivateTempInfo struct {
+	CommonFieldList []ActivateFieldValue `json:"common_field_list"` // 通用字段
+	CustomFieldList []ActivateFieldValue `json:"custom_field_list"` // 自定义字段和富文本字段
+}
+
+// 返回字段 name 的值, 先查找通用字段, 然后是自定义字段; 没有找到返回 "".
+func (info *ActivateTempInfo) Field(name string) string {
+	for _, field := range info.CommonFieldList {
+		if field.Name == name {
+			return field.Value
+		}
+	}
+	for _, field := range info.CustomFieldList {
+		if field.Name == name {
+			if field.Value == "" && len(field.ValueList) > 0 {
+				return strings.Join(field.ValueList, ",")
+			}
+			return field.Value
+		}
+	}
+	return ""
+}
+
+// 获取用户提交资料.
+//  activateTicket: 开卡后跳转到商户页面时 URL 上的 activate_ticket, 见 ParseActivateRedirect
+func (clt *Client) MemberCardActivateTempInfoGet(activateTicket string, opts ...mp.CallOption) (info *ActivateTempInfo, err error) {
+	if activateTicket == "" {
+		err = errors.New("empty activateTicket")
+		return
+	}
+
+	var request = struct {
+		ActivateTicket string `json:"activate_ticket"`
+	}{
+		ActivateTicket: activateTicket,
+	}
+
+	var result struct {
+		mp.Error
+		Info ActivateTempInfo `json:"info"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/card/membercard/activatetempinfo/get?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	info = &result.Info
+	return
+}
+
+// 用户提交开卡资料后, 微信跳转到商户页面(会员卡的 wx_activate_after_submit_url)时带的参数.
+type ActivateRedirect struct {
+	CardId         string // 会员卡ID
+	EncryptCode    string // 加密的 code, 用 CardCodeDecrypt 解码
+	OuterStr       string // MemberCardActivateGetURL 时的渠道值
+	OpenId         string // 用户的 openid
+	ActivateTicket string // 用于 MemberCardActivateTempInfoGet
+}
+
+// 解析开卡后跳转到商户页面时 URL 上的参数.
+//  activate_ticket 在 URL 上是编码了两次的, 这里会解码到可以直接使用的值.
+func ParseActivateRedirect(query url.Values) (redirect *ActivateRedirect, err error) {
+	redirect = &ActivateRedirect{
+		CardId:         query.Get("card_id"),
+		EncryptCode:    query.Get("encrypt_code"),
+		OuterStr:       query.Get("outer_str"),
+		OpenId:         query.Get("openid"),
+		ActivateTicket: query.Get("activate_ticket"),
+	}
+	if redirect.CardId == "" {
+		return nil, errors.New("card_id not found in query")
+	}
+	if redirect.EncryptCode == "" {
+		return nil, errors.New("encrypt_code not found in query")
+	}
+	if redirect.ActivateTicket == "" {
+		return nil, errors.New("activate_ticket not found in query")
+	}
+	if strings.IndexByte(redirect.ActivateTicket, '%') >= 0 {
+		if redirect.ActivateTicket, err = url.QueryUnescape(redirect.ActivateTicket); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+// 用户提交的开卡申请, 见 MemberCardActivateFromRedirect.
+type ActivateSubmission struct {
+	ActivateRedirect
+	Code string            // 解码后的会员卡 code
+	Info *ActivateTempInfo // 用户填写的开卡资料
+}
+
+// 处理开卡后跳转到商户页面的请求: 解码 code 并且获取用户提交的资料.
+//  商户校验资料(比如手机号)后, 调用 MemberCardActivate 激活会员卡:
+//
+//  sub, err := clt.MemberCardActivateFromRedirect(r.URL.Query())
+//  ...
+//  err = clt.MemberCardActivate(&card.MemberCardActivateParameters{
+//      Code:             sub.Code,
+//      CardId:           sub.CardId,
+//      MembershipNumber: sub.Code,
+//  })
+func (clt *Client) MemberCardActivateFromRedirect(query url.Values, opts ...mp.CallOption) (sub *ActivateSubmission, err error) {
+	redirect, err := ParseActivateRedirect(query)
+	if err != nil {
+		return
+	}
+	code, err := clt.CardCodeDecrypt(redirect.EncryptCode, opts...)
+	if err != nil {
+		return
+	}
+	info, err := clt.MemberCardActivateTempInfoGet(redirect.ActivateTicket, opts...)
+	if err != nil {
+		return
+	}
+	sub = &ActivateSubmission{
+		ActivateRedirect: *redirect,
+		Code:             code,
+		Info:             info,
+	}
+	return
+}
@@ -24,7 +24,7 @@ type MovieTicketUpdateUserParameters struct {
 
 // 更新电影票.
 //  领取电影票后通过调用“更新电影票”接口update 电影信息及用户选座信息
-func (clt *Client) MovieTicketUpdateUser(para *MovieTicketUpdateUserParameters) (err error) {
+func (clt *Client) MovieTicketUpdateUser(para *MovieTicketUpdateUserParameters, opts ...mp.CallOption) (err error) {
 	if para == nil {
 		return errors.New("nil MovieTicketUpdateUserParameters")
 	}
@@ -32,7 +32,7 @@ func (clt *Client) MovieTicketUpdateUser(para *MovieTicketUpdateUserParameters)
 	var result mp.Error
 
 	incompleteURL := "https://api.weixin.qq.com/card/movieticket/updateuser?access_token="
-	if err = clt.PostJSON(incompleteURL, para, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, para, &result, opts...); err != nil {
 		return
 	}
 
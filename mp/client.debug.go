@@ -9,41 +9,82 @@ package mp
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"net/url"
 	"reflect"
 	"runtime"
-
-	wechatjson "github.com/chanxuehong/wechat/json"
+	"time"
 )
 
 // 微信公众号"主动"请求功能的基本封装.
 type WechatClient struct {
 	TokenServer
 	HttpClient *http.Client
+
+	// 多媒体上传下载使用的 http.Client, 一般需要更长的超时时间, 比如 MediaHttpClient;
+	// 可以为 nil, 表示也使用 HttpClient.
+	MediaHttpClient *http.Client
+
+	// 为 true 时 UploadFromReader 使用 UploadStreamFromReader 流式上传, 不在内存里缓存整个文件.
+	StreamUpload bool
+
+	// 可以为 nil, 表示不处理; 否则上传到登记过限制的图片接口(见 ImageLimitFor)前, 先用它处理图片.
+	ImageProcessor ImageProcessor
+
+	Codec Codec // 可以为 nil, 表示使用 DefaultCodec
+
+	// 网络错误或者微信服务器返回 5xx 时的重试次数, 只重试 IsIdempotent 的接口, 0 表示不重试;
+	// access_token 过期的重试不受影响, 因为此时微信服务器并没有处理请求.
+	MaxRetries int
+	// 第一次重试前等待的时间, 之后每次加倍, 默认为 DefaultRetryBackoff; 立即重试一般还是会失败.
+	RetryBackoff time.Duration
+
+	DryRun *DryRun // 可以为 nil, 表示正常请求微信服务器
+
+	// 可以为 nil; 否则所有发往微信服务器的请求(包括多媒体上传下载)发送前都先用它修改, 比如增加代理网关的认证头.
+	RequestDecorator RequestDecorator
+
+	Cache *ResponseCache // 可以为 nil, 表示不缓存只读接口的结果
+
+	HealthReporters []HealthReporter // Health 时依次调用, 可以为空
+
+	// 可以为 nil, 表示不限制; 否则按接口类别限制同时进行的请求数, 比如同时最多 4 个多媒体下载.
+	ConcurrencyLimits *ConcurrencyLimits
 }
 
-// 用 encoding/json 把 request marshal 为 JSON, 放入 http 请求的 body 中,
-// POST 到微信服务器, 然后将微信服务器返回的 JSON 用 encoding/json 解析到 response.
+// 用 clt.Codec 把 request marshal 为 JSON, 放入 http 请求的 body 中,
+// POST 到微信服务器, 然后将微信服务器返回的 JSON 用 clt.Codec 解析到 response.
 //
 //  NOTE:
 //  1. 一般不用调用这个方法, 请直接调用高层次的封装方法;
 //  2. 最终的 URL == incompleteURL + access_token;
 //  3. response 要求是 struct 的指针, 并且该 struct 拥有属性:
 //     ErrCode int `json:"errcode"` (可以是直接属性, 也可以是匿名属性里的属性)
-func (clt *WechatClient) PostJSON(incompleteURL string, request interface{}, response interface{}) (err error) {
+//  4. opts 只对这一次调用有效, 见 CallOption.
+func (clt *WechatClient) PostJSON(incompleteURL string, request interface{}, response interface{}, opts ...CallOption) (err error) {
+	o := clt.callOptions(opts)
+
 	buf := textBufferPool.Get().(*bytes.Buffer)
 	buf.Reset()
 	defer textBufferPool.Put(buf)
 
-	if err = wechatjson.NewEncoder(buf).Encode(request); err != nil {
+	if err = clt.codec().Encode(buf, request); err != nil {
 		return
 	}
 	requestBytes := buf.Bytes()
 
+	if handled, err := clt.dryRun(incompleteURL, requestBytes, response); handled {
+		return err
+	}
+
+	cacheKey, cacheTTL := clt.Cache.lookup(incompleteURL, requestBytes)
+	if cacheTTL > 0 && !o.noCache {
+		if cached, ok := clt.Cache.get(cacheKey); ok {
+			return clt.unmarshal(cached, response)
+		}
+	}
+
 	token, err := clt.Token()
 	if err != nil {
 		return
@@ -55,13 +96,20 @@ func (clt *WechatClient) PostJSON(incompleteURL string, request interface{}, res
 	}
 
 	hasRetried := false
+	retries := 0
 RETRY:
-	finalURL := incompleteURL + url.QueryEscape(token)
+	finalURL := TokenURL(incompleteURL, token)
 
 	fmt.Println(debugPrefix, "request url:", finalURL)
 	fmt.Println(debugPrefix, "request json:", string(requestBytes))
 
-	httpResp, err := clt.HttpClient.Post(finalURL, "application/json; charset=utf-8", bytes.NewReader(requestBytes))
+	httpResp, err := o.httpClient(clt.HttpClient).Post(finalURL, "application/json; charset=utf-8", bytes.NewReader(requestBytes))
+	if clt.shouldRetry(incompleteURL, httpResp, err, o.maxRetries, &retries) {
+		if err == nil {
+			httpResp.Body.Close()
+		}
+		goto RETRY
+	}
 	if err != nil {
 		return
 	}
@@ -77,7 +125,7 @@ RETRY:
 	}
 	fmt.Println(debugPrefix, "response json:", string(respBody))
 
-	if err = json.Unmarshal(respBody, response); err != nil {
+	if err = clt.unmarshal(respBody, response); err != nil {
 		return
 	}
 
@@ -96,6 +144,7 @@ RETRY:
 
 	switch ErrCode {
 	case ErrCodeOK:
+		clt.Cache.done(incompleteURL, cacheKey, cacheTTL, respBody)
 		return
 	case ErrCodeInvalidCredential, ErrCodeTimeout:
 		if !hasRetried {
@@ -112,14 +161,28 @@ RETRY:
 	}
 }
 
-// GET 微信资源, 然后将微信服务器返回的 JSON 用 encoding/json 解析到 response.
+// GET 微信资源, 然后将微信服务器返回的 JSON 用 clt.Codec 解析到 response.
 //
 //  NOTE:
 //  1. 一般不用调用这个方法, 请直接调用高层次的封装方法;
 //  2. 最终的 URL == incompleteURL + access_token;
 //  3. response 要求是 struct 的指针, 并且该 struct 拥有属性:
 //     ErrCode int `json:"errcode"` (可以是直接属性, 也可以是匿名属性里的属性)
-func (clt *WechatClient) GetJSON(incompleteURL string, response interface{}) (err error) {
+//  4. opts 只对这一次调用有效, 见 CallOption.
+func (clt *WechatClient) GetJSON(incompleteURL string, response interface{}, opts ...CallOption) (err error) {
+	o := clt.callOptions(opts)
+
+	if handled, err := clt.dryRun(incompleteURL, nil, response); handled {
+		return err
+	}
+
+	cacheKey, cacheTTL := clt.Cache.lookup(incompleteURL, nil)
+	if cacheTTL > 0 && !o.noCache {
+		if cached, ok := clt.Cache.get(cacheKey); ok {
+			return clt.unmarshal(cached, response)
+		}
+	}
+
 	token, err := clt.Token()
 	if err != nil {
 		return
@@ -131,10 +194,17 @@ func (clt *WechatClient) GetJSON(incompleteURL string, response interface{}) (er
 	}
 
 	hasRetried := false
+	retries := 0
 RETRY:
-	finalURL := incompleteURL + url.QueryEscape(token)
+	finalURL := TokenURL(incompleteURL, token)
 
-	httpResp, err := clt.HttpClient.Get(finalURL)
+	httpResp, err := o.httpClient(clt.HttpClient).Get(finalURL)
+	if clt.shouldRetry(incompleteURL, httpResp, err, o.maxRetries, &retries) {
+		if err == nil {
+			httpResp.Body.Close()
+		}
+		goto RETRY
+	}
 	if err != nil {
 		return
 	}
@@ -151,7 +221,7 @@ RETRY:
 	fmt.Println(debugPrefix, "request url:", finalURL)
 	fmt.Println(debugPrefix, "response json:", string(respBody))
 
-	if err = json.Unmarshal(respBody, response); err != nil {
+	if err = clt.unmarshal(respBody, response); err != nil {
 		return
 	}
 
@@ -170,6 +240,7 @@ RETRY:
 
 	switch ErrCode {
 	case ErrCodeOK:
+		clt.Cache.done(incompleteURL, cacheKey, cacheTTL, respBody)
 		return
 	case ErrCodeInvalidCredential, ErrCodeTimeout:
 		if !hasRetried {
@@ -8,14 +8,14 @@ package mp
 // 获取微信服务器IP地址.
 //  如果公众号基于安全等考虑，需要获知微信服务器的IP地址列表，以便进行相关限制，
 //  可以通过该接口获得微信服务器IP地址列表。
-func (clt *WechatClient) GetCallbackIP() (ipList []string, err error) {
+func (clt *WechatClient) GetCallbackIP(opts ...CallOption) (ipList []string, err error) {
 	var result struct {
 		Error
 		IPList []string `json:"ip_list"`
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/getcallbackip?access_token="
-	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+	if err = clt.GetJSON(incompleteURL, &result, opts...); err != nil {
 		return
 	}
 
@@ -0,0 +1,213 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+)
+
+// 流式解析时每个数组元素的回调函数, 用 dec.Decode 读取当前的元素.
+//  NOTE: 必须恰好调用一次 dec.Decode, 返回非 nil 的 error 会终止解析并把该 error 返回给调用者.
+type JSONStreamItemFunc func(dec *json.Decoder) error
+
+// 流式解析 JSON 对象 r, arrayPath 指定的数组的元素逐个回调 onItem, 其他的顶层字段解析到 response.
+//
+//  arrayPath: 数组在 JSON 对象里的路径, 比如 cgi-bin/user/get 是 []string{"data", "openid"},
+//             cgi-bin/material/batchget_material 是 []string{"item"}
+//  response:  可以为 nil; arrayPath 经过的中间对象里的其他字段会被丢弃
+func DecodeJSONStream(r io.Reader, arrayPath []string, onItem JSONStreamItemFunc, response interface{}) (err error) {
+	if len(arrayPath) == 0 {
+		return errors.New("empty arrayPath")
+	}
+	if onItem == nil {
+		return errors.New("nil onItem")
+	}
+
+	dec := json.NewDecoder(r)
+	fields := make(map[string]json.RawMessage)
+	if err = decodeJSONStreamObject(dec, arrayPath, onItem, fields); err != nil {
+		return
+	}
+	if response == nil {
+		return
+	}
+
+	fieldsBytes, err := json.Marshal(fields)
+	if err != nil {
+		return
+	}
+	return json.Unmarshal(fieldsBytes, response)
+}
+
+func decodeJSONStreamObject(dec *json.Decoder, path []string, onItem JSONStreamItemFunc, fields map[string]json.RawMessage) (err error) {
+	if err = expectJSONDelim(dec, '{'); err != nil {
+		return
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("invalid JSON object key: %v", tok)
+		}
+
+		if key != path[0] {
+			var raw json.RawMessage
+			if err = dec.Decode(&raw); err != nil {
+				return err
+			}
+			if fields != nil {
+				fields[key] = raw
+			}
+			continue
+		}
+
+		if len(path) > 1 {
+			err = decodeJSONStreamObject(dec, path[1:], onItem, nil)
+		} else {
+			err = decodeJSONStreamArray(dec, onItem)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return expectJSONDelim(dec, '}')
+}
+
+func decodeJSONStreamArray(dec *json.Decoder, onItem JSONStreamItemFunc) (err error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return
+	}
+	if tok == nil { // null
+		return
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '[' {
+		return fmt.Errorf("expect JSON array, but got: %v", tok)
+	}
+	for dec.More() {
+		if err = onItem(dec); err != nil {
+			return
+		}
+	}
+	return expectJSONDelim(dec, ']')
+}
+
+func expectJSONDelim(dec *json.Decoder, want json.Delim) (err error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != want {
+		return fmt.Errorf("expect JSON delim %q, but got: %v", want, tok)
+	}
+	return
+}
+
+// 同 PostJSON, 但是流式的解析微信服务器返回的 JSON, 适用于返回数据非常大的接口.
+//  arrayPath, onItem, response 参考 DecodeJSONStream, 其中 response 的要求同 PostJSON.
+//
+//  NOTE: 不使用 Cache; Codec 只用于编码 request, 流式解析固定使用 encoding/json.
+func (clt *WechatClient) PostJSONStream(incompleteURL string, request interface{}, arrayPath []string,
+	onItem JSONStreamItemFunc, response interface{}, opts ...CallOption) (err error) {
+
+	buf := textBufferPool.Get().(*bytes.Buffer)
+	buf.Reset()
+	defer textBufferPool.Put(buf)
+
+	if err = clt.codec().Encode(buf, request); err != nil {
+		return
+	}
+	requestBytes := buf.Bytes()
+
+	if handled, err := clt.dryRun(incompleteURL, requestBytes, response); handled {
+		return err
+	}
+
+	o := clt.callOptions(opts)
+	return clt.doJSONStream(func(finalURL string) (*http.Response, error) {
+		return o.httpClient(clt.HttpClient).Post(finalURL, "application/json; charset=utf-8", bytes.NewReader(requestBytes))
+	}, &o, incompleteURL, arrayPath, onItem, response)
+}
+
+// 同 GetJSON, 但是流式的解析微信服务器返回的 JSON, 适用于返回数据非常大的接口.
+//  arrayPath, onItem, response 参考 DecodeJSONStream, 其中 response 的要求同 GetJSON.
+//
+//  NOTE: 不使用 Cache; Codec 只用于编码 request, 流式解析固定使用 encoding/json.
+func (clt *WechatClient) GetJSONStream(incompleteURL string, arrayPath []string,
+	onItem JSONStreamItemFunc, response interface{}, opts ...CallOption) (err error) {
+
+	if handled, err := clt.dryRun(incompleteURL, nil, response); handled {
+		return err
+	}
+	o := clt.callOptions(opts)
+	return clt.doJSONStream(o.httpClient(clt.HttpClient).Get, &o, incompleteURL, arrayPath, onItem, response)
+}
+
+func (clt *WechatClient) doJSONStream(do func(finalURL string) (*http.Response, error), o *callOptions,
+	incompleteURL string, arrayPath []string, onItem JSONStreamItemFunc, response interface{}) (err error) {
+
+	token, err := clt.Token()
+	if err != nil {
+		return
+	}
+
+	hasRetried := false
+	retries := 0
+RETRY:
+	finalURL := TokenURL(incompleteURL, token)
+
+	httpResp, err := do(finalURL)
+	if clt.shouldRetry(incompleteURL, httpResp, err, o.maxRetries, &retries) {
+		if err == nil {
+			httpResp.Body.Close()
+		}
+		goto RETRY
+	}
+	if err != nil {
+		return
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		httpResp.Body.Close()
+		return fmt.Errorf("http.Status: %s", httpResp.Status)
+	}
+
+	err = DecodeJSONStream(httpResp.Body, arrayPath, onItem, response)
+	httpResp.Body.Close()
+	if err != nil {
+		return
+	}
+
+	// 同 PostJSON, 这里的 response 也要求是 struct { Error; XXX } 的结构;
+	// 出错的时候微信服务器不会返回数组, 所以重试不会重复回调 onItem.
+	ErrCode := reflect.ValueOf(response).Elem().FieldByName("ErrCode").Int()
+
+	switch ErrCode {
+	case ErrCodeOK:
+		return
+	case ErrCodeInvalidCredential, ErrCodeTimeout:
+		if !hasRetried {
+			hasRetried = true
+
+			if token, err = clt.TokenRefresh(); err != nil {
+				return
+			}
+			goto RETRY
+		}
+		fallthrough
+	default:
+		return
+	}
+}
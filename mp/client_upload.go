@@ -9,12 +9,10 @@ package mp
 
 import (
 	"bytes"
-	"encoding/json"
 	"fmt"
 	"io"
 	"mime/multipart"
 	"net/http"
-	"net/url"
 	"reflect"
 )
 
@@ -36,12 +34,30 @@ import (
 //  1. 一般不需要调用这个方法, 请直接调用高层次的封装方法;
 //  2. 最终的 URL == incompleteURL + access_token;
 //  3. part1 是一个文件, part2 是普通的字符串(如果不需要 part2 则把 part2FieldName 留空);
-//  4. response 要求是 struct 的指针, 并且该 struct 拥有属性:
+//  4. clt.StreamUpload 为 true 时使用 UploadStreamFromReader, 设置了 clt.ImageProcessor 时先处理图片;
+//  5. opts 只对这一次调用有效, 见 CallOption;
+//  6. response 要求是 struct 的指针, 并且该 struct 拥有属性:
 //     ErrCode int `json:"errcode"` (可以是直接属性, 也可以是匿名属性里的属性)
 func (clt *WechatClient) UploadFromReader(incompleteURL,
 	part1FieldName, part1FileName string, part1ValueReader io.Reader,
 	part2FieldName string, part2Value []byte,
-	response interface{}) (err error) {
+	response interface{}, opts ...CallOption) (err error) {
+
+	if clt.StreamUpload {
+		return clt.UploadStreamFromReader(incompleteURL, part1FieldName, part1FileName, part1ValueReader,
+			part2FieldName, part2Value, response, opts...)
+	}
+
+	o := clt.callOptions(opts)
+
+	part1FileName, part1ValueReader, err = clt.processImage(incompleteURL, part1FileName, part1ValueReader)
+	if err != nil {
+		return
+	}
+
+	if handled, err := clt.dryRun(incompleteURL, nil, response); handled {
+		return err
+	}
 
 	// 构造 multipart/form-data, 存入一个字节数组里
 
@@ -82,9 +98,9 @@ func (clt *WechatClient) UploadFromReader(incompleteURL,
 
 	hasRetried := false
 RETRY:
-	finalURL := incompleteURL + url.QueryEscape(token)
+	finalURL := TokenURL(incompleteURL, token)
 
-	httpResp, err := clt.HttpClient.Post(finalURL, multipartWriter.FormDataContentType(), bytes.NewReader(bodyBytes))
+	httpResp, err := o.httpClient(clt.mediaClient()).Post(finalURL, multipartWriter.FormDataContentType(), bytes.NewReader(bodyBytes))
 	if err != nil {
 		return
 	}
@@ -95,7 +111,7 @@ RETRY:
 		return
 	}
 
-	if err = json.NewDecoder(httpResp.Body).Decode(response); err != nil {
+	if err = clt.codec().Decode(httpResp.Body, response); err != nil {
 		return
 	}
 
@@ -114,6 +130,7 @@ RETRY:
 
 	switch ErrCode {
 	case ErrCodeOK:
+		clt.Cache.done(incompleteURL, "", 0, nil)
 		return
 	case ErrCodeInvalidCredential, ErrCodeTimeout:
 		if !hasRetried {
@@ -0,0 +1,137 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"reflect"
+)
+
+// 同 UploadFromReader, 但是不把 multipart/form-data 整个构造在内存里,
+// 而是通过 io.Pipe 边读 part1ValueReader 边发送, 内存占用和文件大小无关.
+//
+//  NOTE:
+//  1. 请求没有 Content-Length, 使用 chunked 编码发送;
+//  2. access_token 过期需要重新上传时要求 part1ValueReader 实现了 io.Seeker (比如 *os.File),
+//     否则直接返回微信服务器的错误;
+//  3. opts 只对这一次调用有效, 见 CallOption.
+func (clt *WechatClient) UploadStreamFromReader(incompleteURL,
+	part1FieldName, part1FileName string, part1ValueReader io.Reader,
+	part2FieldName string, part2Value []byte,
+	response interface{}, opts ...CallOption) (err error) {
+
+	o := clt.callOptions(opts)
+
+	part1FileName, part1ValueReader, err = clt.processImage(incompleteURL, part1FileName, part1ValueReader)
+	if err != nil {
+		return
+	}
+
+	if handled, err := clt.dryRun(incompleteURL, nil, response); handled {
+		return err
+	}
+
+	seeker, _ := part1ValueReader.(io.Seeker)
+	var part1Offset int64
+	if seeker != nil {
+		if part1Offset, err = seeker.Seek(0, io.SeekCurrent); err != nil {
+			seeker, err = nil, nil
+		}
+	}
+
+	token, err := clt.Token()
+	if err != nil {
+		return
+	}
+
+	hasRetried := false
+RETRY:
+	finalURL := TokenURL(incompleteURL, token)
+
+	pipeReader, pipeWriter := io.Pipe()
+	multipartWriter := multipart.NewWriter(pipeWriter)
+
+	writeDone := make(chan struct{})
+	go func() {
+		defer close(writeDone)
+		pipeWriter.CloseWithError(writeMultipartForm(multipartWriter,
+			part1FieldName, part1FileName, part1ValueReader, part2FieldName, part2Value))
+	}()
+
+	httpResp, err := o.httpClient(clt.mediaClient()).Post(finalURL, multipartWriter.FormDataContentType(), pipeReader)
+	if err != nil {
+		pipeReader.CloseWithError(err)
+		<-writeDone
+		return
+	}
+
+	if httpResp.StatusCode != http.StatusOK {
+		httpResp.Body.Close()
+		pipeReader.Close()
+		<-writeDone
+		return fmt.Errorf("http.Status: %s", httpResp.Status)
+	}
+
+	err = clt.codec().Decode(httpResp.Body, response)
+	httpResp.Body.Close()
+	pipeReader.Close()
+	<-writeDone // 保证不会有 goroutine 还在读 part1ValueReader
+	if err != nil {
+		return
+	}
+
+	// 同 UploadFromReader, 这里的 response 也要求是 struct { Error; XXX } 的结构.
+	ErrCode := reflect.ValueOf(response).Elem().FieldByName("ErrCode").Int()
+
+	switch ErrCode {
+	case ErrCodeOK:
+		clt.Cache.done(incompleteURL, "", 0, nil)
+		return
+	case ErrCodeInvalidCredential, ErrCodeTimeout:
+		if !hasRetried && seeker != nil {
+			hasRetried = true
+
+			if _, err = seeker.Seek(part1Offset, io.SeekStart); err != nil {
+				return
+			}
+			if token, err = clt.TokenRefresh(); err != nil {
+				return
+			}
+			goto RETRY
+		}
+		fallthrough
+	default:
+		return
+	}
+}
+
+func writeMultipartForm(multipartWriter *multipart.Writer,
+	part1FieldName, part1FileName string, part1ValueReader io.Reader,
+	part2FieldName string, part2Value []byte) (err error) {
+
+	part1Writer, err := multipartWriter.CreateFormFile(part1FieldName, part1FileName)
+	if err != nil {
+		return
+	}
+	if _, err = io.Copy(part1Writer, part1ValueReader); err != nil {
+		return
+	}
+
+	if part2FieldName != "" && len(part2Value) > 0 {
+		part2Writer, err := multipartWriter.CreateFormField(part2FieldName)
+		if err != nil {
+			return err
+		}
+		if _, err = part2Writer.Write(part2Value); err != nil {
+			return err
+		}
+	}
+
+	return multipartWriter.Close()
+}
@@ -0,0 +1,49 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+
+	wechatjson "github.com/chanxuehong/wechat/json"
+)
+
+// WechatClient 使用的 JSON 编解码器, 可以替换为更快的实现, 比如 jsoniter, sonic.
+//
+//  NOTE:
+//  1. Encode 不能把 <, >, & 转义为 \u003c, \u003e, \u0026, 否则微信服务器显示不正确;
+//  2. 实现必须是并发安全的.
+type Codec interface {
+	Encode(w io.Writer, v interface{}) error
+	Decode(r io.Reader, v interface{}) error
+}
+
+// 默认的 Codec, 编码用 github.com/chanxuehong/wechat/json, 解码用 encoding/json.
+var DefaultCodec Codec = defaultCodec{}
+
+type defaultCodec struct{}
+
+func (defaultCodec) Encode(w io.Writer, v interface{}) error {
+	return wechatjson.NewEncoder(w).Encode(v)
+}
+
+func (defaultCodec) Decode(r io.Reader, v interface{}) error {
+	return json.NewDecoder(r).Decode(v)
+}
+
+// 返回 clt 使用的 Codec, 如果 clt.Codec == nil 则返回 DefaultCodec.
+func (clt *WechatClient) codec() Codec {
+	if clt.Codec != nil {
+		return clt.Codec
+	}
+	return DefaultCodec
+}
+
+func (clt *WechatClient) unmarshal(data []byte, v interface{}) error {
+	return clt.codec().Decode(bytes.NewReader(data), v)
+}
@@ -0,0 +1,118 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package comment
+
+import (
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	CommentPageSizeLimit = 49 // 每次拉取的评论个数最大值, 文档说 count >= 50 会被拒绝
+)
+
+const (
+	CommentTypeAll    = 0 // 普通评论和精选评论
+	CommentTypeNormal = 1 // 普通评论
+	CommentTypeElect  = 2 // 精选评论
+)
+
+// 作者的回复
+type Reply struct {
+	Content    string `json:"content"`
+	CreateTime int64  `json:"create_time"`
+}
+
+// 一条评论
+type Comment struct {
+	UserCommentId int64  `json:"user_comment_id"` // 用户评论id
+	OpenId        string `json:"openid"`
+	CreateTime    int64  `json:"create_time"`
+	Content       string `json:"content"`
+	CommentType   int    `json:"comment_type"`    // 是否精选评论, 0 为即非精选, 1 为精选评论
+	Reply         *Reply `json:"reply,omitempty"` // 没有回复时为 nil
+}
+
+// 查看指定文章的评论数据.
+//  msgDataId: 群发返回的 msg_data_id
+//  index:     多图文时, 用来指定第几篇图文, 从 0 开始
+//  begin:     起始位置, count: 获取数目, 不能超过 CommentPageSizeLimit
+//  commentType: CommentTypeAll, CommentTypeNormal 或 CommentTypeElect
+func (clt *Client) List(msgDataId int64, index, begin, count, commentType int, opts ...mp.CallOption) (total int, comments []Comment, err error) {
+	var request = struct {
+		MsgDataId int64 `json:"msg_data_id"`
+		Index     int   `json:"index"`
+		Begin     int   `json:"begin"`
+		Count     int   `json:"count"`
+		Type      int   `json:"type"`
+	}{
+		MsgDataId: msgDataId,
+		Index:     index,
+		Begin:     begin,
+		Count:     count,
+		Type:      commentType,
+	}
+
+	var result struct {
+		mp.Error
+		Total    int       `json:"total"`
+		Comments []Comment `json:"comment"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/comment/list?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	total = result.Total
+	comments = result.Comments
+	return
+}
+
+// 评论遍历器, 用法同 mp.Pager, Cursor.Offset 为下一页的 begin.
+type CommentIterator struct {
+	pager    *mp.Pager
+	total    int
+	comments []Comment
+}
+
+func (iter *CommentIterator) HasNext() bool     { return iter.pager.HasNext() }
+func (iter *CommentIterator) Cursor() mp.Cursor { return iter.pager.Cursor() }
+
+// 评论的总数, 调用 NextPage 之后才有效.
+func (iter *CommentIterator) Total() int { return iter.total }
+
+func (iter *CommentIterator) NextPage() (comments []Comment, err error) {
+	if err = iter.pager.NextPage(); err != nil {
+		return
+	}
+	comments = iter.comments
+	return
+}
+
+// 获取文章的评论遍历器, 从 begin 开始每次拉取 count 条评论; count <= 0 时使用 CommentPageSizeLimit.
+//  参数的含义同 List.
+func (clt *Client) CommentIterator(msgDataId int64, index, begin, count, commentType int, opts ...mp.CallOption) *CommentIterator {
+	if count <= 0 || count > CommentPageSizeLimit {
+		count = CommentPageSizeLimit
+	}
+	iter := &CommentIterator{}
+	fetch := func(offset, count int) (n, total int, err error) {
+		total, comments, err := clt.List(msgDataId, index, offset, count, commentType, opts...)
+		if err != nil {
+			return
+		}
+		iter.total = total
+		iter.comments = comments
+		n = len(comments)
+		return
+	}
+	iter.pager = mp.NewPager(mp.Cursor{Offset: begin}, mp.OffsetPageFunc(count, fetch))
+	return iter
+}
@@ -0,0 +1,184 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"bufio"
+	"compress/flate"
+	"compress/gzip"
+	"compress/zlib"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+var _ http.RoundTripper = (*CompressionTransport)(nil)
+
+// CompressionTransport 请求时带上 Accept-Encoding: gzip, deflate, 并且透明的解压响应,
+// 用于粉丝列表, 素材列表, 数据统计等返回数据比较大的接口.
+//
+//  NOTE:
+//  1. net/http 默认的 Transport 只会自动处理 gzip, 并且在调用者设置了 Accept-Encoding 时不处理;
+//  2. 个别微信接口在开启压缩时返回不正确, 可以通过 SetEnabled 整体关闭, 或者通过 DisableFor 按接口关闭.
+type CompressionTransport struct {
+	Transport http.RoundTripper // 实际发送请求的 RoundTripper, 为 nil 时使用 http.DefaultTransport
+
+	rwmutex  sync.RWMutex
+	disabled bool
+	excluded map[string]bool // 不开启压缩的接口, URL 的 path
+}
+
+// 创建一个新的 CompressionTransport, 默认开启压缩.
+//  transport 为 nil 时使用 http.DefaultTransport
+func NewCompressionTransport(transport http.RoundTripper) *CompressionTransport {
+	return &CompressionTransport{
+		Transport: transport,
+		excluded:  make(map[string]bool),
+	}
+}
+
+// 返回一个新的 http.Client, 除了 Transport 被 CompressionTransport 包装之外, 其他设置和 httpClient 一样.
+//  httpClient 为 nil 时使用 http.DefaultClient
+func NewCompressionHttpClient(httpClient *http.Client) (*http.Client, *CompressionTransport) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	transport := NewCompressionTransport(httpClient.Transport)
+	newClient := *httpClient
+	newClient.Transport = transport
+	return &newClient, transport
+}
+
+// 开启或关闭压缩.
+func (t *CompressionTransport) SetEnabled(enabled bool) {
+	t.rwmutex.Lock()
+	t.disabled = !enabled
+	t.rwmutex.Unlock()
+}
+
+// 对指定的接口关闭压缩.
+//  endpoints: 接口 URL 的 path, 比如 "/cgi-bin/user/get"
+func (t *CompressionTransport) DisableFor(endpoints ...string) {
+	t.rwmutex.Lock()
+	if t.excluded == nil {
+		t.excluded = make(map[string]bool)
+	}
+	for _, endpoint := range endpoints {
+		t.excluded[endpoint] = true
+	}
+	t.rwmutex.Unlock()
+}
+
+// 对指定的接口重新开启压缩.
+func (t *CompressionTransport) EnableFor(endpoints ...string) {
+	t.rwmutex.Lock()
+	for _, endpoint := range endpoints {
+		delete(t.excluded, endpoint)
+	}
+	t.rwmutex.Unlock()
+}
+
+func (t *CompressionTransport) enabledFor(endpoint string) bool {
+	t.rwmutex.RLock()
+	defer t.rwmutex.RUnlock()
+	return !t.disabled && !t.excluded[endpoint]
+}
+
+func (t *CompressionTransport) transport() http.RoundTripper {
+	if t.Transport == nil {
+		return http.DefaultTransport
+	}
+	return t.Transport
+}
+
+func (t *CompressionTransport) RoundTrip(req *http.Request) (resp *http.Response, err error) {
+	if req.Header.Get("Accept-Encoding") != "" || req.Header.Get("Range") != "" || !t.enabledFor(req.URL.Path) {
+		return t.transport().RoundTrip(req)
+	}
+
+	// RoundTripper 不能修改请求, 所以复制一份
+	newReq := new(http.Request)
+	*newReq = *req
+	newReq.Header = make(http.Header, len(req.Header)+1)
+	for k, v := range req.Header {
+		newReq.Header[k] = v
+	}
+	newReq.Header.Set("Accept-Encoding", "gzip, deflate")
+
+	if resp, err = t.transport().RoundTrip(newReq); err != nil {
+		return
+	}
+
+	var body io.ReadCloser
+	switch strings.ToLower(strings.TrimSpace(resp.Header.Get("Content-Encoding"))) {
+	case "gzip":
+		body = &gzipReadCloser{body: resp.Body}
+	case "deflate":
+		body = &deflateReadCloser{body: resp.Body}
+	default:
+		return
+	}
+
+	resp.Body = body
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return
+}
+
+// 第一次 Read 的时候才创建 gzip.Reader, 以便 http.Response.Body 可以不读直接 Close
+type gzipReadCloser struct {
+	body   io.ReadCloser
+	reader io.Reader
+	err    error
+}
+
+func (r *gzipReadCloser) Read(p []byte) (n int, err error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	if r.reader == nil {
+		if r.reader, r.err = gzip.NewReader(r.body); r.err != nil {
+			return 0, r.err
+		}
+	}
+	return r.reader.Read(p)
+}
+
+func (r *gzipReadCloser) Close() error {
+	return r.body.Close()
+}
+
+// "deflate" 按照 RFC 应该是 zlib 格式, 但是有的服务器返回的是裸的 deflate 数据, 这里两种都支持
+type deflateReadCloser struct {
+	body   io.ReadCloser
+	reader io.Reader
+	err    error
+}
+
+func (r *deflateReadCloser) Read(p []byte) (n int, err error) {
+	if r.err != nil {
+		return 0, r.err
+	}
+	if r.reader == nil {
+		bufReader := bufio.NewReader(r.body)
+		header, _ := bufReader.Peek(2)
+		if len(header) == 2 && header[0]&0x0f == 8 && (uint16(header[0])<<8|uint16(header[1]))%31 == 0 {
+			if r.reader, r.err = zlib.NewReader(bufReader); r.err != nil {
+				return 0, r.err
+			}
+		} else {
+			r.reader = flate.NewReader(bufReader)
+		}
+	}
+	return r.reader.Read(p)
+}
+
+func (r *deflateReadCloser) Close() error {
+	return r.body.Close()
+}
@@ -0,0 +1,170 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// 接口的类别, 用于按类别限制并发数.
+const (
+	EndpointClassJSON          = "json"           // 一般的 JSON 接口
+	EndpointClassMediaUpload   = "media_upload"   // 多媒体上传
+	EndpointClassMediaDownload = "media_download" // 多媒体下载
+)
+
+// 接口的类别, key 为 URL 的 path, 规则同 idempotencyRegistry; 没有登记的接口为 EndpointClassJSON.
+var endpointClassRegistry = struct {
+	sync.RWMutex
+	m map[string]string
+}{
+	m: map[string]string{
+		"/cgi-bin/media/upload":          EndpointClassMediaUpload,
+		"/cgi-bin/media/uploadimg":       EndpointClassMediaUpload,
+		"/cgi-bin/material/add_material": EndpointClassMediaUpload,
+		"/shakearound/material/add":      EndpointClassMediaUpload,
+		"/cgi-bin/media/get":             EndpointClassMediaDownload,
+		"/cgi-bin/media/get/jssdk":       EndpointClassMediaDownload,
+		"/cgi-bin/material/get_material": EndpointClassMediaDownload,
+		"/cgi-bin/showqrcode":            EndpointClassMediaDownload,
+	},
+}
+
+// 登记接口的类别, 覆盖默认的设置.
+//  path: URL 的 path, 比如 "/cgi-bin/media/get"; 以 / 结尾表示前缀
+func RegisterEndpointClass(path, class string) {
+	endpointClassRegistry.Lock()
+	endpointClassRegistry.m[path] = class
+	endpointClassRegistry.Unlock()
+}
+
+// 返回接口的类别, 精确匹配优先, 然后是最长的前缀匹配.
+//  incompleteURL: 可以是完整的 URL, 也可以只是 path
+func EndpointClass(incompleteURL string) string {
+	path := endpointPath(incompleteURL)
+
+	endpointClassRegistry.RLock()
+	defer endpointClassRegistry.RUnlock()
+
+	if class, ok := endpointClassRegistry.m[path]; ok {
+		return class
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i] != '/' {
+			continue
+		}
+		if class, ok := endpointClassRegistry.m[path[:i+1]]; ok {
+			return class
+		}
+	}
+	return EndpointClassJSON
+}
+
+type concurrencySemaphore struct {
+	slots   chan struct{}
+	waiting int64
+}
+
+// 按接口类别限制同时进行的请求数, 可以被多个 WechatClient 共享.
+//  多媒体下载的请求在响应的 Body 关闭以后才释放, 所以限制的是同时进行的下载数.
+type ConcurrencyLimits struct {
+	semaphores map[string]*concurrencySemaphore
+}
+
+// 创建 ConcurrencyLimits, limits 为 接口类别 --> 最大并发数, 没有设置或者 <= 0 的类别不限制; 比如:
+//  mp.NewConcurrencyLimits(map[string]int{mp.EndpointClassMediaDownload: 4})
+func NewConcurrencyLimits(limits map[string]int) *ConcurrencyLimits {
+	l := &ConcurrencyLimits{
+		semaphores: make(map[string]*concurrencySemaphore, len(limits)),
+	}
+	for class, limit := range limits {
+		if limit > 0 {
+			l.semaphores[class] = &concurrencySemaphore{slots: make(chan struct{}, limit)}
+		}
+	}
+	return l
+}
+
+// 等待 class 的一个空位, 返回释放空位的函数; done 关闭时放弃等待, ok == false.
+func (l *ConcurrencyLimits) acquire(class string, done <-chan struct{}) (release func(), ok bool) {
+	sem := l.semaphores[class]
+	if sem == nil {
+		return func() {}, true
+	}
+
+	select {
+	case sem.slots <- struct{}{}:
+	default:
+		atomic.AddInt64(&sem.waiting, 1)
+		defer atomic.AddInt64(&sem.waiting, -1)
+
+		select {
+		case sem.slots <- struct{}{}:
+		case <-done:
+			return nil, false
+		}
+	}
+
+	var once sync.Once
+	return func() { once.Do(func() { <-sem.slots }) }, true
+}
+
+var _ HealthReporter = (*ConcurrencyLimits)(nil)
+
+// 报告每个类别排队等待的请求数, 名称为 "concurrency:" + 类别.
+func (l *ConcurrencyLimits) ReportHealth(h *Health) {
+	classes := make([]string, 0, len(l.semaphores))
+	for class := range l.semaphores {
+		classes = append(classes, class)
+	}
+	sort.Strings(classes)
+	for _, class := range classes {
+		h.SetQueue("concurrency:"+class, int(atomic.LoadInt64(&l.semaphores[class].waiting)))
+	}
+}
+
+// 按照 limits 限制并发数, 然后交给 Transport 发送.
+type concurrencyTransport struct {
+	transport http.RoundTripper
+	limits    *ConcurrencyLimits
+}
+
+func (t *concurrencyTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	release, ok := t.limits.acquire(EndpointClass(req.URL.Path), req.Context().Done())
+	if !ok {
+		if req.Body != nil {
+			req.Body.Close()
+		}
+		return nil, req.Context().Err()
+	}
+
+	transport := t.transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		release()
+		return nil, err
+	}
+	resp.Body = &releaseOnCloseBody{ReadCloser: resp.Body, release: release}
+	return resp, nil
+}
+
+type releaseOnCloseBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (body *releaseOnCloseBody) Close() error {
+	err := body.ReadCloser.Close()
+	body.release()
+	return err
+}
@@ -35,7 +35,7 @@ type ArticleSummaryData struct {
 }
 
 // 获取图文群发每日数据.
-func (clt *Client) GetArticleSummary(req *Request) (list []ArticleSummaryData, err error) {
+func (clt *Client) GetArticleSummary(req *Request, opts ...mp.CallOption) (list []ArticleSummaryData, err error) {
 	if req == nil {
 		err = errors.New("nil Request")
 		return
@@ -47,7 +47,7 @@ func (clt *Client) GetArticleSummary(req *Request) (list []ArticleSummaryData, e
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/datacube/getarticlesummary?access_token="
-	if err = clt.PostJSON(incompleteURL, req, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, req, &result, opts...); err != nil {
 		return
 	}
 
@@ -72,7 +72,7 @@ type ArticleTotalData struct {
 }
 
 // 获取图文群发总数据.
-func (clt *Client) GetArticleTotal(req *Request) (list []ArticleTotalData, err error) {
+func (clt *Client) GetArticleTotal(req *Request, opts ...mp.CallOption) (list []ArticleTotalData, err error) {
 	if req == nil {
 		err = errors.New("nil Request")
 		return
@@ -84,7 +84,7 @@ func (clt *Client) GetArticleTotal(req *Request) (list []ArticleTotalData, err e
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/datacube/getarticletotal?access_token="
-	if err = clt.PostJSON(incompleteURL, req, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, req, &result, opts...); err != nil {
 		return
 	}
 
@@ -103,7 +103,7 @@ type UserReadData struct {
 }
 
 // 获取图文统计数据.
-func (clt *Client) GetUserRead(req *Request) (list []UserReadData, err error) {
+func (clt *Client) GetUserRead(req *Request, opts ...mp.CallOption) (list []UserReadData, err error) {
 	if req == nil {
 		err = errors.New("nil Request")
 		return
@@ -115,7 +115,7 @@ func (clt *Client) GetUserRead(req *Request) (list []UserReadData, err error) {
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/datacube/getuserread?access_token="
-	if err = clt.PostJSON(incompleteURL, req, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, req, &result, opts...); err != nil {
 		return
 	}
 
@@ -134,7 +134,7 @@ type UserReadHourData struct {
 }
 
 // 获取图文统计分时数据.
-func (clt *Client) GetUserReadHour(req *Request) (list []UserReadHourData, err error) {
+func (clt *Client) GetUserReadHour(req *Request, opts ...mp.CallOption) (list []UserReadHourData, err error) {
 	if req == nil {
 		err = errors.New("nil Request")
 		return
@@ -146,7 +146,7 @@ func (clt *Client) GetUserReadHour(req *Request) (list []UserReadHourData, err e
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/datacube/getuserreadhour?access_token="
-	if err = clt.PostJSON(incompleteURL, req, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, req, &result, opts...); err != nil {
 		return
 	}
 
@@ -167,7 +167,7 @@ type UserShareData struct {
 }
 
 // 获取图文分享转发数据.
-func (clt *Client) GetUserShare(req *Request) (list []UserShareData, err error) {
+func (clt *Client) GetUserShare(req *Request, opts ...mp.CallOption) (list []UserShareData, err error) {
 	if req == nil {
 		err = errors.New("nil Request")
 		return
@@ -179,7 +179,7 @@ func (clt *Client) GetUserShare(req *Request) (list []UserShareData, err error)
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/datacube/getusershare?access_token="
-	if err = clt.PostJSON(incompleteURL, req, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, req, &result, opts...); err != nil {
 		return
 	}
 
@@ -198,7 +198,7 @@ type UserShareHourData struct {
 }
 
 // 获取图文分享转发分时数据.
-func (clt *Client) GetUserShareHour(req *Request) (list []UserShareHourData, err error) {
+func (clt *Client) GetUserShareHour(req *Request, opts ...mp.CallOption) (list []UserShareHourData, err error) {
 	if req == nil {
 		err = errors.New("nil Request")
 		return
@@ -210,7 +210,7 @@ func (clt *Client) GetUserShareHour(req *Request) (list []UserShareHourData, err
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/datacube/getusersharehour?access_token="
-	if err = clt.PostJSON(incompleteURL, req, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, req, &result, opts...); err != nil {
 		return
 	}
 
@@ -29,7 +29,7 @@ type UserSummaryData struct {
 }
 
 // 获取用户增减数据.
-func (clt *Client) GetUserSummary(req *Request) (list []UserSummaryData, err error) {
+func (clt *Client) GetUserSummary(req *Request, opts ...mp.CallOption) (list []UserSummaryData, err error) {
 	if req == nil {
 		err = errors.New("nil Request")
 		return
@@ -41,7 +41,7 @@ func (clt *Client) GetUserSummary(req *Request) (list []UserSummaryData, err err
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/datacube/getusersummary?access_token="
-	if err = clt.PostJSON(incompleteURL, req, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, req, &result, opts...); err != nil {
 		return
 	}
 
@@ -60,7 +60,7 @@ type UserCumulateData struct {
 }
 
 // 获取累计用户数据.
-func (clt *Client) GetUserCumulate(req *Request) (list []UserCumulateData, err error) {
+func (clt *Client) GetUserCumulate(req *Request, opts ...mp.CallOption) (list []UserCumulateData, err error) {
 	if req == nil {
 		err = errors.New("nil Request")
 		return
@@ -72,7 +72,7 @@ func (clt *Client) GetUserCumulate(req *Request) (list []UserCumulateData, err e
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/datacube/getusercumulate?access_token="
-	if err = clt.PostJSON(incompleteURL, req, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, req, &result, opts...); err != nil {
 		return
 	}
 
@@ -0,0 +1,373 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package device
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/url"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 绑定/解绑类接口返回的错误信息
+type baseResp struct {
+	ErrCode int    `json:"errcode"`
+	ErrMsg  string `json:"errmsg"`
+}
+
+// 主动发送消息给设备.
+//  deviceType: 设备类型, 目前为"公众账号原始ID"
+//  deviceId:   设备ID
+//  openId:     微信用户账号的 openid
+//  data:       发送给设备的原始数据, 内部会做 base64 编码
+func (clt *Client) TransMsg(deviceType, deviceId, openId string, data []byte, opts ...mp.CallOption) (err error) {
+	if deviceType == "" || deviceId == "" || openId == "" {
+		return errors.New("empty deviceType, deviceId or openId")
+	}
+
+	var request = struct {
+		DeviceType string `json:"device_type"`
+		DeviceId   string `json:"device_id"`
+		OpenId     string `json:"open_id"`
+		Content    string `json:"content"`
+	}{
+		DeviceType: deviceType,
+		DeviceId:   deviceId,
+		OpenId:     openId,
+		Content:    base64.StdEncoding.EncodeToString(data),
+	}
+
+	var result struct {
+		mp.Error
+		Ret     int    `json:"ret"`
+		RetInfo string `json:"ret_info"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/device/transmsg?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	if result.Ret != 0 {
+		err = &mp.Error{ErrCode: result.Ret, ErrMsg: result.RetInfo}
+		return
+	}
+	return
+}
+
+const (
+	DeviceStatusNotAuthorized = 0 // 未授权
+	DeviceStatusAuthorized    = 1 // 已经授权(尚未被用户绑定)
+	DeviceStatusBound         = 2 // 已经被用户绑定
+	DeviceStatusNotExist      = 3 // 属性未设置
+)
+
+// 查询设备状态.
+func (clt *Client) GetStat(deviceId string, opts ...mp.CallOption) (status int, statusInfo string, err error) {
+	if deviceId == "" {
+		err = errors.New("empty deviceId")
+		return
+	}
+
+	var result struct {
+		mp.Error
+		Status     int    `json:"status"`
+		StatusInfo string `json:"status_info"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/device/get_stat?device_id=" +
+		url.QueryEscape(deviceId) + "&access_token="
+	if err = clt.GetJSON(incompleteURL, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	status = result.Status
+	statusInfo = result.StatusInfo
+	return
+}
+
+// 设备属性
+type DeviceInfo struct {
+	Id                string `json:"id"`                            // 设备ID
+	MAC               string `json:"mac"`                           // 设备的 mac 地址, 格式采用16进制串的方式(长度为12字节)
+	ConnectProtocol   string `json:"connect_protocol"`              // 支持的连接协议, 1 android classic bluetooth, 2 ios classic bluetooth, 3 ble, 4 wifi; 多个以"|"连接
+	AuthKey           string `json:"auth_key"`                      // 加密 key, 1 个 16 字节的字符串, 不加密时为空
+	CloseStrategy     string `json:"close_strategy"`                // 断开策略, 1 退出公众号页面时即断开连接, 2 退出公众号之后保持连接不断开
+	ConnStrategy      string `json:"conn_strategy"`                 // 连接策略, 多个以"|"连接
+	CryptMethod       string `json:"crypt_method"`                  // auth 加密方法, 0 不加密, 1 AES 加密
+	AuthVer           string `json:"auth_ver"`                      // auth version, 0 不加密的 version, 1 version 1
+	ManuMacPos        string `json:"manu_mac_pos"`                  // 低功耗蓝牙必须字段, 表示 mac 地址在厂商广播 manufature data 里含有 mac 地址的偏移
+	SerMacPos         string `json:"ser_mac_pos"`                   // 低功耗蓝牙必须字段, 表示 mac 地址在厂商 serial number 里含有 mac 地址的偏移
+	BLESimpleProtocol string `json:"ble_simple_protocol,omitempty"` // 精简协议类型, 1 计步设备精简协议
+}
+
+// 设备授权的结果
+type AuthorizeResult struct {
+	BaseInfo struct {
+		DeviceType string `json:"device_type"`
+		DeviceId   string `json:"device_id"`
+	} `json:"base_info"`
+	ErrCode int    `json:"errcode"`
+	ErrMsg  string `json:"errmsg"`
+}
+
+const (
+	AuthorizeOpTypeCreate = "0" // 设备授权
+	AuthorizeOpTypeUpdate = "1" // 设备更新
+)
+
+// 设备授权, 一次请求最多授权 100 个设备.
+//  productId: 设备的产品编号
+//  opType:    AuthorizeOpTypeCreate 或 AuthorizeOpTypeUpdate
+func (clt *Client) AuthorizeDevice(productId, opType string, devices []DeviceInfo, opts ...mp.CallOption) (results []AuthorizeResult, err error) {
+	if len(devices) == 0 {
+		err = errors.New("empty devices")
+		return
+	}
+
+	var request = struct {
+		DeviceNum  int          `json:"device_num"`
+		DeviceList []DeviceInfo `json:"device_list"`
+		OpType     string       `json:"op_type"`
+		ProductId  string       `json:"product_id,omitempty"`
+	}{
+		DeviceNum:  len(devices),
+		DeviceList: devices,
+		OpType:     opType,
+		ProductId:  productId,
+	}
+
+	var result struct {
+		mp.Error
+		Resp []AuthorizeResult `json:"resp"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/device/authorize_device?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	results = result.Resp
+	return
+}
+
+// 获取设备二维码, 同时获取一个新的 deviceid.
+//  productId: 设备的产品编号
+//  返回的 deviceId 需要再调用 AuthorizeDevice 授权, qrTicket 用于生成二维码.
+func (clt *Client) GetQRCode(productId string, opts ...mp.CallOption) (deviceId, qrTicket string, err error) {
+	if productId == "" {
+		err = errors.New("empty productId")
+		return
+	}
+
+	var result struct {
+		mp.Error
+		BaseResp baseResp `json:"base_resp"`
+		DeviceId string   `json:"deviceid"`
+		QRTicket string   `json:"qrticket"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/device/getqrcode?product_id=" +
+		url.QueryEscape(productId) + "&access_token="
+	if err = clt.GetJSON(incompleteURL, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	if result.BaseResp.ErrCode != mp.ErrCodeOK {
+		err = &mp.Error{ErrCode: result.BaseResp.ErrCode, ErrMsg: result.BaseResp.ErrMsg}
+		return
+	}
+	deviceId = result.DeviceId
+	qrTicket = result.QRTicket
+	return
+}
+
+// 设备二维码
+type QRCode struct {
+	DeviceId string `json:"device_id"`
+	Ticket   string `json:"ticket"` // 用于生成二维码
+}
+
+// 为已授权的设备批量生成二维码.
+func (clt *Client) CreateQRCode(deviceIds []string, opts ...mp.CallOption) (codes []QRCode, err error) {
+	if len(deviceIds) == 0 {
+		err = errors.New("empty deviceIds")
+		return
+	}
+
+	var request = struct {
+		DeviceNum    int      `json:"device_num"`
+		DeviceIdList []string `json:"device_id_list"`
+	}{
+		DeviceNum:    len(deviceIds),
+		DeviceIdList: deviceIds,
+	}
+
+	var result struct {
+		mp.Error
+		CodeList []QRCode `json:"code_list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/device/create_qrcode?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	codes = result.CodeList
+	return
+}
+
+// 绑定设备, 需要用户在设备上确认过的 ticket.
+//  ticket: 绑定操作合法性的凭证, 由微信后台生成, 第三方 H5 通过客户端 jsapi 获得
+func (clt *Client) Bind(ticket, deviceId, openId string, opts ...mp.CallOption) (err error) {
+	if ticket == "" {
+		return errors.New("empty ticket")
+	}
+	return clt.bindOrUnbind("https://api.weixin.qq.com/device/bind?access_token=", ticket, deviceId, openId, opts...)
+}
+
+// 解绑设备.
+func (clt *Client) Unbind(ticket, deviceId, openId string, opts ...mp.CallOption) (err error) {
+	if ticket == "" {
+		return errors.New("empty ticket")
+	}
+	return clt.bindOrUnbind("https://api.weixin.qq.com/device/unbind?access_token=", ticket, deviceId, openId, opts...)
+}
+
+// 强制绑定用户和设备, 不需要 ticket.
+func (clt *Client) CompelBind(deviceId, openId string, opts ...mp.CallOption) (err error) {
+	return clt.bindOrUnbind("https://api.weixin.qq.com/device/compel_bind?access_token=", "", deviceId, openId, opts...)
+}
+
+// 强制解绑用户和设备, 不需要 ticket.
+func (clt *Client) CompelUnbind(deviceId, openId string, opts ...mp.CallOption) (err error) {
+	return clt.bindOrUnbind("https://api.weixin.qq.com/device/compel_unbind?access_token=", "", deviceId, openId, opts...)
+}
+
+func (clt *Client) bindOrUnbind(incompleteURL, ticket, deviceId, openId string, opts ...mp.CallOption) (err error) {
+	if deviceId == "" {
+		return errors.New("empty deviceId")
+	}
+	if openId == "" {
+		return errors.New("empty openId")
+	}
+
+	var request = struct {
+		Ticket   string `json:"ticket,omitempty"`
+		DeviceId string `json:"device_id"`
+		OpenId   string `json:"openid"`
+	}{
+		Ticket:   ticket,
+		DeviceId: deviceId,
+		OpenId:   openId,
+	}
+
+	var result struct {
+		mp.Error
+		BaseResp baseResp `json:"base_resp"`
+	}
+
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	if result.BaseResp.ErrCode != mp.ErrCodeOK {
+		err = &mp.Error{ErrCode: result.BaseResp.ErrCode, ErrMsg: result.BaseResp.ErrMsg}
+		return
+	}
+	return
+}
+
+// 用户绑定的设备
+type BindDevice struct {
+	DeviceType string `json:"device_type"`
+	DeviceId   string `json:"device_id"`
+}
+
+// 通过 openid 获取用户绑定的设备.
+func (clt *Client) GetBindDevice(openId string, opts ...mp.CallOption) (devices []BindDevice, err error) {
+	if openId == "" {
+		err = errors.New("empty openId")
+		return
+	}
+
+	var result struct {
+		mp.Error
+		RespMsg struct {
+			RetCode int    `json:"ret_code"`
+			ErrMsg  string `json:"error_info"`
+		} `json:"resp_msg"`
+		DeviceList []BindDevice `json:"device_list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/device/get_bind_device?openid=" +
+		url.QueryEscape(openId) + "&access_token="
+	if err = clt.GetJSON(incompleteURL, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	if result.RespMsg.RetCode != 0 {
+		err = &mp.Error{ErrCode: result.RespMsg.RetCode, ErrMsg: result.RespMsg.ErrMsg}
+		return
+	}
+	devices = result.DeviceList
+	return
+}
+
+// 获取设备绑定的用户 openid 列表.
+func (clt *Client) GetOpenId(deviceType, deviceId string, opts ...mp.CallOption) (openIds []string, err error) {
+	if deviceType == "" || deviceId == "" {
+		err = errors.New("empty deviceType or deviceId")
+		return
+	}
+
+	var result struct {
+		mp.Error
+		OpenId []string `json:"open_id"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/device/get_openid?device_type=" +
+		url.QueryEscape(deviceType) + "&device_id=" + url.QueryEscape(deviceId) + "&access_token="
+	if err = clt.GetJSON(incompleteURL, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	openIds = result.OpenId
+	return
+}
@@ -0,0 +1,131 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package device
+
+import (
+	"encoding/base64"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	MsgTypeDeviceText  = "device_text"  // 设备消息, 设备通过微信发送给厂商的数据
+	MsgTypeDeviceEvent = "device_event" // 设备事件
+
+	// 设备事件的 Event
+	EventTypeBind              = "bind"               // 绑定
+	EventTypeUnbind            = "unbind"             // 解绑
+	EventTypeSubscribeStatus   = "subscribe_status"   // 订阅设备状态
+	EventTypeUnsubscribeStatus = "unsubscribe_status" // 退订设备状态
+)
+
+// 设备消息, 用 mp.MessageServeMux.MessageHandle(MsgTypeDeviceText, handler) 处理.
+type DeviceText struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	mp.CommonMessageHeader
+
+	DeviceType string `xml:"DeviceType" json:"DeviceType"` // 设备类型, 目前为"公众账号原始ID"
+	DeviceID   string `xml:"DeviceID"   json:"DeviceID"`   // 设备ID
+	SessionID  int64  `xml:"SessionID"  json:"SessionID"`  // 微信客户端生成的 session id, 用于 request 和 response 对应
+	Content    string `xml:"Content"    json:"Content"`    // 消息内容, 设备上报的数据经过 base64 编码
+	OpenID     string `xml:"OpenID"     json:"OpenID"`     // 微信用户账号的 OpenID
+}
+
+func GetDeviceText(msg *mp.MixedMessage) *DeviceText {
+	return &DeviceText{
+		CommonMessageHeader: msg.CommonMessageHeader,
+		DeviceType:          msg.DeviceType,
+		DeviceID:            msg.DeviceID,
+		SessionID:           msg.SessionID,
+		Content:             msg.Content,
+		OpenID:              msg.OpenID,
+	}
+}
+
+// 解码设备上报的数据.
+func (msg *DeviceText) Data() ([]byte, error) {
+	return base64.StdEncoding.DecodeString(msg.Content)
+}
+
+// 设备事件, 用 mp.MessageServeMux.MessageHandle(MsgTypeDeviceEvent, handler) 处理.
+type DeviceEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	mp.CommonMessageHeader
+
+	Event      string `xml:"Event"      json:"Event"`      // EventTypeBind, EventTypeUnbind, EventTypeSubscribeStatus, EventTypeUnsubscribeStatus
+	DeviceType string `xml:"DeviceType" json:"DeviceType"` // 设备类型, 目前为"公众账号原始ID"
+	DeviceID   string `xml:"DeviceID"   json:"DeviceID"`   // 设备ID
+	SessionID  int64  `xml:"SessionID"  json:"SessionID"`  // 微信客户端生成的 session id
+	Content    string `xml:"Content"    json:"Content"`    // 绑定/解绑事件时为二维码中附带的 extinfo 的 base64 编码
+	OpenID     string `xml:"OpenID"     json:"OpenID"`     // 微信用户账号的 OpenID
+	OpType     int    `xml:"OpType"     json:"OpType"`     // 订阅类型, 仅订阅/退订设备状态事件有效, 1 表示 WIFI 设备状态
+}
+
+func GetDeviceEvent(msg *mp.MixedMessage) *DeviceEvent {
+	return &DeviceEvent{
+		CommonMessageHeader: msg.CommonMessageHeader,
+		Event:               msg.Event,
+		DeviceType:          msg.DeviceType,
+		DeviceID:            msg.DeviceID,
+		SessionID:           msg.SessionID,
+		Content:             msg.Content,
+		OpenID:              msg.OpenID,
+		OpType:              msg.OpType,
+	}
+}
+
+// 回复设备消息, 把数据发送给设备.
+type ResponseDeviceText struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	mp.CommonMessageHeader
+
+	DeviceType string `xml:"DeviceType" json:"DeviceType"`
+	DeviceID   string `xml:"DeviceID"   json:"DeviceID"`
+	SessionID  int64  `xml:"SessionID"  json:"SessionID"`
+	Content    string `xml:"Content"    json:"Content"` // 数据的 base64 编码
+}
+
+// 新建回复设备消息.
+//  msg:  收到的设备消息
+//  data: 发送给设备的原始数据, 内部会做 base64 编码
+func NewResponseDeviceText(msg *DeviceText, timestamp int64, data []byte) *ResponseDeviceText {
+	return &ResponseDeviceText{
+		CommonMessageHeader: mp.CommonMessageHeader{
+			ToUserName:   msg.FromUserName,
+			FromUserName: msg.ToUserName,
+			CreateTime:   timestamp,
+			MsgType:      MsgTypeDeviceText,
+		},
+		DeviceType: msg.DeviceType,
+		DeviceID:   msg.DeviceID,
+		SessionID:  msg.SessionID,
+		Content:    base64.StdEncoding.EncodeToString(data),
+	}
+}
+
+// 回复设备状态订阅事件.
+type ResponseDeviceStatus struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	mp.CommonMessageHeader
+
+	DeviceType   string `xml:"DeviceType"   json:"DeviceType"`
+	DeviceID     string `xml:"DeviceID"     json:"DeviceID"`
+	DeviceStatus int    `xml:"DeviceStatus" json:"DeviceStatus"` // 0 未连接, 1 已连接
+}
+
+func NewResponseDeviceStatus(event *DeviceEvent, timestamp int64, deviceStatus int) *ResponseDeviceStatus {
+	return &ResponseDeviceStatus{
+		CommonMessageHeader: mp.CommonMessageHeader{
+			ToUserName:   event.FromUserName,
+			FromUserName: event.ToUserName,
+			CreateTime:   timestamp,
+			MsgType:      "device_status",
+		},
+		DeviceType:   event.DeviceType,
+		DeviceID:     event.DeviceID,
+		DeviceStatus: deviceStatus,
+	}
+}
@@ -65,14 +65,14 @@ func (info *KfInfo) HeadImageSize() (size int, err error) {
 }
 
 // 获取客服基本信息.
-func (clt *Client) KfList() (KfList []KfInfo, err error) {
+func (clt *Client) KfList(opts ...mp.CallOption) (KfList []KfInfo, err error) {
 	var result struct {
 		mp.Error
 		KfList []KfInfo `json:"kf_list"`
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/customservice/getkflist?access_token="
-	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+	if err = clt.GetJSON(incompleteURL, &result, opts...); err != nil {
 		return
 	}
 
@@ -100,14 +100,14 @@ type OnlineKfInfo struct {
 }
 
 // 获取在线客服接待信息.
-func (clt *Client) OnlineKfList() (KfList []OnlineKfInfo, err error) {
+func (clt *Client) OnlineKfList(opts ...mp.CallOption) (KfList []OnlineKfInfo, err error) {
 	var result struct {
 		mp.Error
 		KfList []OnlineKfInfo `json:"kf_online_list"`
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/customservice/getonlinekflist?access_token="
-	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+	if err = clt.GetJSON(incompleteURL, &result, opts...); err != nil {
 		return
 	}
 
@@ -124,7 +124,7 @@ func (clt *Client) OnlineKfList() (KfList []OnlineKfInfo, err error) {
 //  nickname:   客服昵称，最长6个汉字或12个英文字符
 //  password:   客服账号登录密码
 //  isPwdPlain: 标识 password 是否为明文格式, true 表示是明文密码, false 表示是密文密码.
-func (clt *Client) AddKfAccount(account, nickname, password string, isPwdPlain bool) (err error) {
+func (clt *Client) AddKfAccount(account, nickname, password string, isPwdPlain bool, opts ...mp.CallOption) (err error) {
 	if isPwdPlain {
 		md5Sum := md5.Sum([]byte(password))
 		password = hex.EncodeToString(md5Sum[:])
@@ -143,7 +143,7 @@ func (clt *Client) AddKfAccount(account, nickname, password string, isPwdPlain b
 	var result mp.Error
 
 	incompleteURL := "https://api.weixin.qq.com/customservice/kfaccount/add?access_token="
-	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
 		return
 	}
 
@@ -159,7 +159,7 @@ func (clt *Client) AddKfAccount(account, nickname, password string, isPwdPlain b
 //  nickname:   客服昵称，最长6个汉字或12个英文字符
 //  password:   客服账号登录密码
 //  isPwdPlain: 标识 password 是否为明文格式, true 表示是明文密码, false 表示是密文密码.
-func (clt *Client) SetKfAccount(account, nickname, password string, isPwdPlain bool) (err error) {
+func (clt *Client) SetKfAccount(account, nickname, password string, isPwdPlain bool, opts ...mp.CallOption) (err error) {
 	if isPwdPlain {
 		md5Sum := md5.Sum([]byte(password))
 		password = hex.EncodeToString(md5Sum[:])
@@ -178,7 +178,7 @@ func (clt *Client) SetKfAccount(account, nickname, password string, isPwdPlain b
 	var result mp.Error
 
 	incompleteURL := "https://api.weixin.qq.com/customservice/kfaccount/update?access_token="
-	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
 		return
 	}
 
@@ -191,7 +191,7 @@ func (clt *Client) SetKfAccount(account, nickname, password string, isPwdPlain b
 
 // 上传客服头像.
 //  开发者可调用本接口来上传图片作为客服人员的头像，头像图片文件必须是jpg格式，推荐使用640*640大小的图片以达到最佳效果。
-func (clt *Client) UploadKfHeadImage(kfAccount, imagePath string) (err error) {
+func (clt *Client) UploadKfHeadImage(kfAccount, imagePath string, opts ...mp.CallOption) (err error) {
 	if kfAccount == "" {
 		return errors.New("empty kfAccount")
 	}
@@ -201,13 +201,13 @@ func (clt *Client) UploadKfHeadImage(kfAccount, imagePath string) (err error) {
 	}
 	defer file.Close()
 
-	return clt.uploadKfHeadImageFromReader(kfAccount, filepath.Base(imagePath), file)
+	return clt.uploadKfHeadImageFromReader(kfAccount, filepath.Base(imagePath), file, opts...)
 }
 
 // 上传客服头像.
 //  开发者可调用本接口来上传图片作为客服人员的头像，头像图片文件必须是jpg格式，推荐使用640*640大小的图片以达到最佳效果。
 //  注意参数 filename 不是文件路径, 是指定 multipart/form-data 里面文件名称
-func (clt *Client) UploadKfHeadImageFromReader(kfAccount, filename string, reader io.Reader) (err error) {
+func (clt *Client) UploadKfHeadImageFromReader(kfAccount, filename string, reader io.Reader, opts ...mp.CallOption) (err error) {
 	if kfAccount == "" {
 		return errors.New("empty kfAccount")
 	}
@@ -218,17 +218,17 @@ func (clt *Client) UploadKfHeadImageFromReader(kfAccount, filename string, reade
 		return errors.New("nil reader")
 	}
 
-	return clt.uploadKfHeadImageFromReader(kfAccount, filename, reader)
+	return clt.uploadKfHeadImageFromReader(kfAccount, filename, reader, opts...)
 }
 
 // 上传客服头像.
 //  注意参数 filename 不是文件路径, 是指定 multipart/form-data 里面文件名称
-func (clt *Client) uploadKfHeadImageFromReader(kfAccount, filename string, reader io.Reader) (err error) {
+func (clt *Client) uploadKfHeadImageFromReader(kfAccount, filename string, reader io.Reader, opts ...mp.CallOption) (err error) {
 	var result mp.Error
 
 	incompleteURL := "http://api.weixin.qq.com/customservice/kfaccount/uploadheadimg?kf_account=" +
 		url.QueryEscape(kfAccount) + "&access_token="
-	if err = clt.UploadFromReader(incompleteURL, "media", filename, reader, "", nil, &result); err != nil {
+	if err = clt.UploadFromReader(incompleteURL, "media", filename, reader, "", nil, &result, opts...); err != nil {
 		return
 	}
 
@@ -240,12 +240,12 @@ func (clt *Client) uploadKfHeadImageFromReader(kfAccount, filename string, reade
 }
 
 // 删除客服账号
-func (clt *Client) DeleteKfAccount(kfAccount string) (err error) {
+func (clt *Client) DeleteKfAccount(kfAccount string, opts ...mp.CallOption) (err error) {
 	var result mp.Error
 
 	incompleteURL := "https://api.weixin.qq.com/customservice/kfaccount/del?kf_account=" +
 		url.QueryEscape(kfAccount) + "&access_token="
-	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+	if err = clt.GetJSON(incompleteURL, &result, opts...); err != nil {
 		return
 	}
 
@@ -44,7 +44,7 @@ type GetRecordRequest struct {
 }
 
 // 获取客服聊天记录
-func (clt *Client) GetRecord(request *GetRecordRequest) (recordList []Record, err error) {
+func (clt *Client) GetRecord(request *GetRecordRequest, opts ...mp.CallOption) (recordList []Record, err error) {
 	if request == nil {
 		err = errors.New("nil request")
 		return
@@ -62,7 +62,7 @@ func (clt *Client) GetRecord(request *GetRecordRequest) (recordList []Record, er
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/customservice/getrecord?access_token="
-	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
 		return
 	}
 
@@ -92,8 +92,9 @@ type RecordIterator struct {
 	lastGetRecordRequest *GetRecordRequest // 上一次查询的 request
 	lastGetRecordResult  []Record          // 上一次查询的 result
 
-	wechatClient   *Client // 关联的微信 Client
-	nextPageCalled bool    // NextPage() 是否调用过
+	wechatClient   *Client         // 关联的微信 Client
+	opts           []mp.CallOption // 创建时的 opts, 每次拉取都使用
+	nextPageCalled bool            // NextPage() 是否调用过
 }
 
 func (iter *RecordIterator) HasNext() bool {
@@ -114,7 +115,7 @@ func (iter *RecordIterator) NextPage() (records []Record, err error) {
 
 	// 不是第一次调用的都要从服务器拉取数据
 	iter.lastGetRecordRequest.PageIndex++
-	records, err = iter.wechatClient.GetRecord(iter.lastGetRecordRequest)
+	records, err = iter.wechatClient.GetRecord(iter.lastGetRecordRequest, iter.opts...)
 	if err != nil {
 		iter.lastGetRecordRequest.PageIndex-- //
 		return
@@ -124,9 +125,17 @@ func (iter *RecordIterator) NextPage() (records []Record, err error) {
 	return
 }
 
+// 下一页的位置, Cursor.Offset 为下一页的 PageIndex, 可以用来创建新的 RecordIterator 继续遍历.
+func (iter *RecordIterator) Cursor() mp.Cursor {
+	if !iter.nextPageCalled {
+		return mp.Cursor{Offset: iter.lastGetRecordRequest.PageIndex}
+	}
+	return mp.Cursor{Offset: iter.lastGetRecordRequest.PageIndex + 1}
+}
+
 // 获取聊天记录遍历器.
-func (clt *Client) RecordIterator(request *GetRecordRequest) (iter *RecordIterator, err error) {
-	records, err := clt.GetRecord(request)
+func (clt *Client) RecordIterator(request *GetRecordRequest, opts ...mp.CallOption) (iter *RecordIterator, err error) {
+	records, err := clt.GetRecord(request, opts...)
 	if err != nil {
 		return
 	}
@@ -135,6 +144,7 @@ func (clt *Client) RecordIterator(request *GetRecordRequest) (iter *RecordIterat
 		lastGetRecordRequest: request,
 		lastGetRecordResult:  records,
 		wechatClient:         clt,
+		opts:                 opts,
 		nextPageCalled:       false,
 	}
 	return
@@ -0,0 +1,140 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log"
+	"sync"
+)
+
+// 对 request 做本地校验的函数, request 是 JSON 对象解析后的结果.
+type DryRunValidator func(request map[string]interface{}) error
+
+// 要求 request 中存在 fields 指定的顶层字段并且不为空.
+func RequireFields(fields ...string) DryRunValidator {
+	return func(request map[string]interface{}) error {
+		for _, field := range fields {
+			switch v := request[field].(type) {
+			case nil:
+				return errors.New("dry run: missing field " + field)
+			case string:
+				if v == "" {
+					return errors.New("dry run: empty field " + field)
+				}
+			case []interface{}:
+				if len(v) == 0 {
+					return errors.New("dry run: empty field " + field)
+				}
+			}
+		}
+		return nil
+	}
+}
+
+// 各接口的本地校验规则, key 为 URL 的 path, 规则同 idempotencyRegistry.
+var dryRunValidatorRegistry = struct {
+	sync.RWMutex
+	m map[string]DryRunValidator
+}{
+	m: map[string]DryRunValidator{
+		"/cgi-bin/message/custom/send":       RequireFields("touser", "msgtype"),
+		"/cgi-bin/message/template/send":     RequireFields("touser", "template_id"),
+		"/cgi-bin/message/subscribe/send":    RequireFields("touser", "template_id"),
+		"/cgi-bin/message/subscribe/bizsend": RequireFields("touser", "template_id"),
+		"/cgi-bin/message/mass/sendall":      RequireFields("filter", "msgtype"),
+		"/cgi-bin/message/mass/send":         RequireFields("touser", "msgtype"),
+		"/cgi-bin/message/mass/preview":      RequireFields("msgtype"),
+		"/cgi-bin/menu/create":               RequireFields("button"),
+	},
+}
+
+// 登记接口的本地校验规则, validator 为 nil 表示删除.
+//  path: URL 的 path, 比如 "/cgi-bin/message/custom/send"; 以 / 结尾表示前缀
+func RegisterDryRunValidator(path string, validator DryRunValidator) {
+	dryRunValidatorRegistry.Lock()
+	if validator == nil {
+		delete(dryRunValidatorRegistry.m, path)
+	} else {
+		dryRunValidatorRegistry.m[path] = validator
+	}
+	dryRunValidatorRegistry.Unlock()
+}
+
+func lookupDryRunValidator(path string) DryRunValidator {
+	dryRunValidatorRegistry.RLock()
+	defer dryRunValidatorRegistry.RUnlock()
+
+	if validator, ok := dryRunValidatorRegistry.m[path]; ok {
+		return validator
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i] != '/' {
+			continue
+		}
+		if validator, ok := dryRunValidatorRegistry.m[path[:i+1]]; ok {
+			return validator
+		}
+	}
+	return nil
+}
+
+// dry-run 模式的设置, 请求只在本地校验并记录日志, 不发送到微信服务器, 返回模拟的成功结果.
+// 用于不能给真实用户发消息的测试环境.
+type DryRun struct {
+	// 为 true 时幂等的接口(参考 IsIdempotent)照常请求微信服务器, 只拦截有副作用的接口.
+	WritesOnly bool
+
+	// 记录被拦截的请求, 为 nil 时使用 log 包默认的 Logger.
+	Logger *log.Logger
+
+	// 返回模拟的微信服务器响应, 为 nil 或者返回 nil 时使用 {"errcode":0,"errmsg":"ok"}.
+	Response func(incompleteURL string, request []byte) []byte
+}
+
+var dryRunDefaultResponse = []byte(`{"errcode":0,"errmsg":"ok"}`)
+
+// 如果 clt 处于 dry-run 模式并且需要拦截该请求, 则校验 request 并把模拟的结果写入 response.
+//  request 为 nil 表示没有 JSON 请求体, 比如 GET 和上传文件.
+func (clt *WechatClient) dryRun(incompleteURL string, request []byte, response interface{}) (handled bool, err error) {
+	dryRun := clt.DryRun
+	if dryRun == nil || dryRun.WritesOnly && IsIdempotent(incompleteURL) {
+		return
+	}
+	handled = true
+
+	path := endpointPath(incompleteURL)
+	if request != nil {
+		if validator := lookupDryRunValidator(path); validator != nil {
+			var fields map[string]interface{}
+			if err = json.Unmarshal(request, &fields); err != nil {
+				err = errors.New("dry run: invalid request JSON: " + err.Error())
+				return
+			}
+			if err = validator(fields); err != nil {
+				return
+			}
+		}
+	}
+
+	if dryRun.Logger != nil {
+		dryRun.Logger.Printf("mp dry run: %s request: %s", path, bytes.TrimSpace(request))
+	} else {
+		log.Printf("mp dry run: %s request: %s", path, bytes.TrimSpace(request))
+	}
+
+	var respBody []byte
+	if dryRun.Response != nil {
+		respBody = dryRun.Response(incompleteURL, request)
+	}
+	if respBody == nil {
+		respBody = dryRunDefaultResponse
+	}
+	err = clt.unmarshal(respBody, response)
+	return
+}
@@ -0,0 +1,340 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"sync"
+)
+
+//go:generate go run ../tools/endpoints/main.go -root .. -o endpoints_gen.go
+
+// 几个接口共享的每日调用次数额度, 用于 Endpoint.QuotaClass 和 NewQuotaLimiters;
+//  封装函数的注释里用 //wechat:endpoint quota=xxx 指定, 由 tools/endpoints 生成到 endpoints_gen.go.
+const (
+	QuotaClassWXACode  = "wxacode"   // 小程序码 getwxacode 和小程序二维码 createwxaqrcode, 总共 100,000 个
+	QuotaClassMassSend = "mass_send" // 按标签(或全部用户)群发和按 openid 列表群发
+)
+
+func (idempotency Idempotency) String() string {
+	switch idempotency {
+	case Idempotent:
+		return "idempotent"
+	case NonIdempotent:
+		return "non_idempotent"
+	default:
+		return "unknown"
+	}
+}
+
+// 一个微信接口的描述.
+type Endpoint struct {
+	Path        string      // URL 的 path, 比如 "/cgi-bin/user/info"
+	Name        string      // 接口的名称, 比如 "获取用户基本信息"
+	Methods     []string    // 封装这个接口的函数, 比如 "mp/user.Client.UserInfo"; 为空表示本 SDK 还没有封装
+	QuotaClass  string      // 接口每日调用次数所属的额度类别, 为空时每个接口单独计算, 见 EndpointQuotaClass
+	Idempotency Idempotency // 不为 IdempotencyUnknown 时调用 RegisterIdempotency
+	Class       string      // 接口的类别(比如 EndpointClassMediaUpload), 不为空时调用 RegisterEndpointClass
+}
+
+// 已经封装的接口, key 为 URL 的 path.
+var endpointRegistry = struct {
+	sync.RWMutex
+	m map[string]*Endpoint
+}{
+	m: make(map[string]*Endpoint, len(generatedEndpoints)),
+}
+
+func init() {
+	for i := range generatedEndpoints {
+		ep := generatedEndpoints[i]
+		endpointRegistry.m[ep.Path] = &ep
+	}
+}
+
+// 登记一个封装的接口, 比如自己封装了本 SDK 还没有的接口; 已经登记的同名接口会被合并.
+//  ep.Idempotency 和 ep.Class 同时登记到重试和并发限制使用的表里.
+func RegisterEndpoint(ep Endpoint) {
+	if ep.Path == "" {
+		panic("empty Endpoint.Path")
+	}
+	if ep.Idempotency != IdempotencyUnknown {
+		RegisterIdempotency(ep.Path, ep.Idempotency)
+	}
+	if ep.Class != "" {
+		RegisterEndpointClass(ep.Path, ep.Class)
+	}
+
+	endpointRegistry.Lock()
+	defer endpointRegistry.Unlock()
+
+	old := endpointRegistry.m[ep.Path]
+	if old == nil {
+		ep.Methods = append([]string(nil), ep.Methods...)
+		endpointRegistry.m[ep.Path] = &ep
+		return
+	}
+	if ep.Name != "" {
+		old.Name = ep.Name
+	}
+	if ep.QuotaClass != "" {
+		old.QuotaClass = ep.QuotaClass
+	}
+	if ep.Idempotency != IdempotencyUnknown {
+		old.Idempotency = ep.Idempotency
+	}
+	if ep.Class != "" {
+		old.Class = ep.Class
+	}
+NextMethod:
+	for _, method := range ep.Methods {
+		for _, m := range old.Methods {
+			if m == method {
+				continue NextMethod
+			}
+		}
+		old.Methods = append(old.Methods, method)
+	}
+}
+
+// 查询登记的接口, 没有登记返回 false.
+//  incompleteURL: 可以是完整的 URL, 也可以只是 path
+func LookupEndpoint(incompleteURL string) (ep Endpoint, ok bool) {
+	endpointRegistry.RLock()
+	defer endpointRegistry.RUnlock()
+
+	p := endpointRegistry.m[endpointPath(incompleteURL)]
+	if p == nil {
+		return
+	}
+	ep = *p
+	ep.Methods = append([]string(nil), p.Methods...)
+	return ep, true
+}
+
+// 返回所有登记的接口, 按照 Path 排序.
+func Endpoints() []Endpoint {
+	endpointRegistry.RLock()
+	endpoints := make([]Endpoint, 0, len(endpointRegistry.m))
+	for _, p := range endpointRegistry.m {
+		ep := *p
+		ep.Methods = append([]string(nil), p.Methods...)
+		endpoints = append(endpoints, ep)
+	}
+	endpointRegistry.RUnlock()
+
+	sort.Slice(endpoints, func(i, j int) bool { return endpoints[i].Path < endpoints[j].Path })
+	return endpoints
+}
+
+// 返回接口的额度类别, 没有登记 QuotaClass 的接口单独计算, 返回接口的 path.
+//  incompleteURL: 可以是完整的 URL, 也可以只是 path
+func EndpointQuotaClass(incompleteURL string) string {
+	path := endpointPath(incompleteURL)
+
+	endpointRegistry.RLock()
+	defer endpointRegistry.RUnlock()
+
+	if ep := endpointRegistry.m[path]; ep != nil && ep.QuotaClass != "" {
+		return ep.QuotaClass
+	}
+	return path
+}
+
+// 按照接口的额度类别限制调用的频率.
+type QuotaLimiters struct {
+	limiters map[string]RateLimiter
+	fallback RateLimiter
+}
+
+// 创建一个新的 QuotaLimiters.
+//  limiters: 额度类别(见 EndpointQuotaClass) --> RateLimiter
+//  fallback: 没有在 limiters 里的接口使用的 RateLimiter, 可以为 nil, 表示不限制
+func NewQuotaLimiters(limiters map[string]RateLimiter, fallback RateLimiter) *QuotaLimiters {
+	m := make(map[string]RateLimiter, len(limiters))
+	for class, limiter := range limiters {
+		if limiter != nil {
+			m[class] = limiter
+		}
+	}
+	return &QuotaLimiters{
+		limiters: m,
+		fallback: fallback,
+	}
+}
+
+// 返回类别 class 的 RateLimiter, 没有则返回 nil.
+func (l *QuotaLimiters) Limiter(class string) RateLimiter {
+	if limiter, ok := l.limiters[class]; ok {
+		return limiter
+	}
+	return l.fallback
+}
+
+// 返回在发送请求前等待额度的 RequestDecorator, 可以设置为 WechatClient.RequestDecorator.
+//  重试的请求也会等待额度.
+func (l *QuotaLimiters) Decorator() RequestDecorator {
+	return func(req *http.Request) error {
+		if limiter := l.Limiter(EndpointQuotaClass(req.URL.Path)); limiter != nil {
+			limiter.Wait()
+		}
+		return nil
+	}
+}
+
+// 接口覆盖报告里的一个接口.
+type EndpointCoverage struct {
+	Path        string   `json:"path"`
+	Name        string   `json:"name,omitempty"`
+	Covered     bool     `json:"covered"`
+	Methods     []string `json:"methods,omitempty"`
+	QuotaClass  string   `json:"quota_class"`
+	Idempotency string   `json:"idempotency"`
+	Class       string   `json:"class"`
+}
+
+// 接口覆盖报告, 用于查看本 SDK 还有哪些微信接口没有封装.
+type CoverageReport struct {
+	Total     int                `json:"total"`
+	Covered   int                `json:"covered"`
+	Missing   int                `json:"missing"`
+	Endpoints []EndpointCoverage `json:"endpoints"`
+}
+
+// 生成接口覆盖报告.
+//  catalog 为微信官方的接口列表, 为空时使用 EndpointCatalog; 报告包括 catalog 和所有登记的接口,
+//  在 catalog 里但是没有登记(或者没有 Methods)的接口为没有封装.
+func NewCoverageReport(catalog ...Endpoint) *CoverageReport {
+	if len(catalog) == 0 {
+		catalog = EndpointCatalog
+	}
+
+	merged := make(map[string]Endpoint)
+	for _, ep := range Endpoints() {
+		merged[ep.Path] = ep
+	}
+	for _, ep := range catalog {
+		if old, ok := merged[ep.Path]; ok {
+			if old.Name == "" {
+				old.Name = ep.Name
+				merged[ep.Path] = old
+			}
+			continue
+		}
+		merged[ep.Path] = Endpoint{Path: ep.Path, Name: ep.Name}
+	}
+
+	report := &CoverageReport{
+		Endpoints: make([]EndpointCoverage, 0, len(merged)),
+	}
+	for _, ep := range merged {
+		item := EndpointCoverage{
+			Path:        ep.Path,
+			Name:        ep.Name,
+			Covered:     len(ep.Methods) > 0,
+			Methods:     ep.Methods,
+			QuotaClass:  EndpointQuotaClass(ep.Path),
+			Idempotency: EndpointIdempotency(ep.Path).String(),
+			Class:       EndpointClass(ep.Path),
+		}
+		if item.Covered {
+			report.Covered++
+		} else {
+			report.Missing++
+		}
+		report.Endpoints = append(report.Endpoints, item)
+	}
+	report.Total = len(report.Endpoints)
+	sort.Slice(report.Endpoints, func(i, j int) bool { return report.Endpoints[i].Path < report.Endpoints[j].Path })
+	return report
+}
+
+// 返回没有封装的接口.
+func (report *CoverageReport) MissingEndpoints() []EndpointCoverage {
+	var missing []EndpointCoverage
+	for _, ep := range report.Endpoints {
+		if !ep.Covered {
+			missing = append(missing, ep)
+		}
+	}
+	return missing
+}
+
+// 以 JSON 格式输出报告.
+func (report *CoverageReport) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(report)
+}
+
+// 微信公众平台官方的接口列表(不完整), 用于 NewCoverageReport; 已经封装的接口不需要列在这里.
+var EndpointCatalog = []Endpoint{
+	{Path: "/cgi-bin/clear_quota", Name: "公众号调用或第三方平台帮公众号调用对公众号的所有api调用次数进行清零"},
+	{Path: "/cgi-bin/openapi/quota/get", Name: "查询openAPI调用quota"},
+	{Path: "/cgi-bin/openapi/rid/get", Name: "查询rid信息"},
+	{Path: "/cgi-bin/get_api_domain_ip", Name: "获取微信API接口 IP地址"},
+	{Path: "/cgi-bin/callback/check", Name: "网络检测"},
+	{Path: "/cgi-bin/menu/addconditional", Name: "创建个性化菜单"},
+	{Path: "/cgi-bin/menu/delconditional", Name: "删除个性化菜单"},
+	{Path: "/cgi-bin/menu/trymatch", Name: "测试个性化菜单匹配结果"},
+	{Path: "/cgi-bin/get_current_selfmenu_info", Name: "查询自定义菜单"},
+	{Path: "/cgi-bin/tags/create", Name: "创建标签"},
+	{Path: "/cgi-bin/tags/update", Name: "编辑标签"},
+	{Path: "/cgi-bin/tags/delete", Name: "删除标签"},
+	{Path: "/cgi-bin/user/tag/get", Name: "获取标签下粉丝列表"},
+	{Path: "/cgi-bin/tags/members/batchtagging", Name: "批量为用户打标签"},
+	{Path: "/cgi-bin/tags/members/batchuntagging", Name: "批量为用户取消标签"},
+	{Path: "/cgi-bin/tags/getidlist", Name: "获取用户身上的标签列表"},
+	{Path: "/cgi-bin/tags/members/batchblacklist", Name: "拉黑用户"},
+	{Path: "/cgi-bin/tags/members/batchunblacklist", Name: "取消拉黑用户"},
+	{Path: "/cgi-bin/user/info/batchget", Name: "批量获取用户基本信息"},
+	{Path: "/cgi-bin/draft/add", Name: "新建草稿"},
+	{Path: "/cgi-bin/draft/get", Name: "获取草稿"},
+	{Path: "/cgi-bin/draft/delete", Name: "删除草稿"},
+	{Path: "/cgi-bin/draft/update", Name: "修改草稿"},
+	{Path: "/cgi-bin/draft/count", Name: "获取草稿总数"},
+	{Path: "/cgi-bin/draft/batchget", Name: "获取草稿列表"},
+	{Path: "/cgi-bin/freepublish/getarticle", Name: "通过 article_id 获取已发布文章"},
+	{Path: "/cgi-bin/freepublish/batchget", Name: "获取成功发布列表"},
+	{Path: "/cgi-bin/comment/open", Name: "打开已群发文章评论"},
+	{Path: "/cgi-bin/comment/close", Name: "关闭已群发文章评论"},
+	{Path: "/cgi-bin/comment/list", Name: "查看指定文章的评论数据"},
+	{Path: "/cgi-bin/comment/markelect", Name: "将评论标记精选"},
+	{Path: "/cgi-bin/comment/unmarkelect", Name: "将评论取消精选"},
+	{Path: "/cgi-bin/comment/delete", Name: "删除评论"},
+	{Path: "/cgi-bin/comment/reply/add", Name: "回复评论"},
+	{Path: "/cgi-bin/comment/reply/delete", Name: "删除回复"},
+	{Path: "/cgi-bin/template/del_private_template", Name: "删除模板"},
+	{Path: "/cgi-bin/template/get_industry", Name: "获取设置的行业信息"},
+	{Path: "/cgi-bin/message/custom/typing", Name: "客服输入状态"},
+	{Path: "/customservice/kfaccount/inviteworker", Name: "邀请绑定客服帐号"},
+	{Path: "/customservice/kfsession/create", Name: "创建会话"},
+	{Path: "/customservice/kfsession/close", Name: "关闭会话"},
+	{Path: "/customservice/kfsession/getsession", Name: "获取客户会话状态"},
+	{Path: "/customservice/kfsession/getsessionlist", Name: "获取客服会话列表"},
+	{Path: "/customservice/kfsession/getwaitcase", Name: "获取未接入会话列表"},
+	{Path: "/customservice/msgrecord/getmsglist", Name: "获取聊天记录"},
+	{Path: "/cgi-bin/media/get/jssdk", Name: "获取高清语音素材"},
+	{Path: "/cgi-bin/material/update_news", Name: "修改永久图文素材"},
+	{Path: "/card/code/deposit", Name: "导入自定义code"},
+	{Path: "/card/code/getdepositcount", Name: "查询导入code数目"},
+	{Path: "/card/code/checkcode", Name: "核查code接口"},
+	{Path: "/card/mpnews/gethtml", Name: "图文消息群发卡券"},
+	{Path: "/card/user/getcardlist", Name: "获取用户已领取卡券"},
+	{Path: "/card/membercard/userinfo/get", Name: "拉取会员信息"},
+	{Path: "/semantic/semproxy/search", Name: "语义理解"},
+	{Path: "/cgi-bin/media/voice/addvoicetorecofortext", Name: "提交语音"},
+	{Path: "/cgi-bin/media/voice/queryrecoresultfortext", Name: "获取语音识别结果"},
+	{Path: "/cgi-bin/media/voice/translatecontent", Name: "微信翻译"},
+	{Path: "/cv/img/aicrop", Name: "图片智能裁剪"},
+	{Path: "/cv/img/qrcode", Name: "条码/二维码识别"},
+	{Path: "/cv/img/superresolution", Name: "图片高清化"},
+	{Path: "/cv/ocr/idcard", Name: "身份证OCR识别"},
+	{Path: "/cv/ocr/bankcard", Name: "银行卡OCR识别"},
+}
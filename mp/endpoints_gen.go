@@ -0,0 +1,229 @@
+// Code generated by tools/endpoints; DO NOT EDIT.
+
+package mp
+
+// 本 SDK 已经封装的接口, 由 tools/endpoints 扫描源代码生成.
+var generatedEndpoints = []Endpoint{
+	{Path: "/card/batchget", Name: "批量查询卡列表", Methods: []string{"mp/card.Client.CardBatchGet"}, Idempotency: Idempotent},
+	{Path: "/card/boardingpass/checkin", Name: "在线值机接口", Methods: []string{"mp/card.Client.BoardingPassCheckin"}},
+	{Path: "/card/code/consume", Name: "卡券核销", Methods: []string{"mp/card.Client.CardCodeConsume"}, Idempotency: NonIdempotent},
+	{Path: "/card/code/decrypt", Name: "code 解码接口", Methods: []string{"mp/card.Client.CardCodeDecrypt"}, Idempotency: Idempotent},
+	{Path: "/card/code/get", Name: "查询code", Methods: []string{"mp/card.Client.CardCodeGet"}, Idempotency: Idempotent},
+	{Path: "/card/code/unavailable", Name: "设置卡券失效接口", Methods: []string{"mp/card.Client.CardCodeUnavailable"}},
+	{Path: "/card/code/update", Name: "更改code", Methods: []string{"mp/card.Client.CardCodeUpdate"}},
+	{Path: "/card/create", Name: "创建卡券接口", Methods: []string{"mp/card.Client.CardCreate"}, Idempotency: NonIdempotent},
+	{Path: "/card/delete", Name: "删除卡券", Methods: []string{"mp/card.Client.CardDelete"}},
+	{Path: "/card/get", Name: "查询卡券详情", Methods: []string{"mp/card.Client.CardGet"}, Idempotency: Idempotent},
+	{Path: "/card/getcolors", Name: "获得卡券的最新颜色列表，用于卡券创建", Methods: []string{"mp/card.Client.GetColors"}, Idempotency: Idempotent},
+	{Path: "/card/giftcard/maintain/set", Name: "", Methods: []string{"mp/card.Client.giftCardMaintainSet"}, Idempotency: Idempotent},
+	{Path: "/card/giftcard/order/batchget", Name: "批量查询礼品卡订单信息", Methods: []string{"mp/card.Client.GiftCardOrderBatchGet"}, Idempotency: Idempotent},
+	{Path: "/card/giftcard/order/get", Name: "查询单个礼品卡订单信息", Methods: []string{"mp/card.Client.GiftCardOrderGet"}, Idempotency: Idempotent},
+	{Path: "/card/giftcard/order/refund", Name: "礼品卡订单退款", Methods: []string{"mp/card.Client.GiftCardOrderRefund"}, Idempotency: NonIdempotent},
+	{Path: "/card/giftcard/page/add", Name: "创建礼品卡货架", Methods: []string{"mp/card.Client.GiftCardPageAdd"}, Idempotency: NonIdempotent},
+	{Path: "/card/giftcard/page/batchget", Name: "查询礼品卡货架列表", Methods: []string{"mp/card.Client.GiftCardPageBatchGet"}, Idempotency: Idempotent},
+	{Path: "/card/giftcard/page/get", Name: "查询礼品卡货架信息", Methods: []string{"mp/card.Client.GiftCardPageGet"}, Idempotency: Idempotent},
+	{Path: "/card/giftcard/page/update", Name: "修改礼品卡货架信息", Methods: []string{"mp/card.Client.GiftCardPageUpdate"}, Idempotency: Idempotent},
+	{Path: "/card/landingpage/create", Name: "创建货架接口", Methods: []string{"mp/card.Client.LandingPageCreate"}, Idempotency: NonIdempotent},
+	{Path: "/card/location/batchadd", Name: "批量导入门店信息", Methods: []string{"mp/card.Client.LocationBatchAdd"}},
+	{Path: "/card/location/batchget", Name: "拉取门店列表", Methods: []string{"mp/card.Client.LocationBatchGet"}, Idempotency: Idempotent},
+	{Path: "/card/luckymoney/updateuserbalance", Name: "更新红包金额", Methods: []string{"mp/card.Client.LuckyMoneyUpdateUserBalance"}},
+	{Path: "/card/meetingticket/updateuser", Name: "更新电影票", Methods: []string{"mp/card.Client.MeetingTicketUpdateUser"}},
+	{Path: "/card/membercard/activate", Name: "激活/绑定会员卡", Methods: []string{"mp/card.Client.MemberCardActivate"}},
+	{Path: "/card/membercard/activate/geturl", Name: "获取开卡组件链接接口", Methods: []string{"mp/card.Client.MemberCardActivateGetURL"}, Idempotency: Idempotent},
+	{Path: "/card/membercard/activatetempinfo/get", Name: "获取用户提交资料", Methods: []string{"mp/card.Client.MemberCardActivateTempInfoGet"}, Idempotency: Idempotent},
+	{Path: "/card/membercard/activateuserform/set", Name: "设置开卡字段接口", Methods: []string{"mp/card.Client.MemberCardActivateUserFormSet"}, Idempotency: Idempotent},
+	{Path: "/card/membercard/updateuser", Name: "会员卡交易", Methods: []string{"mp/card.Client.MemberCardUpdateUser"}},
+	{Path: "/card/modifystock", Name: "库存修改接口", Methods: []string{"mp/card.Client.CardModifyStock"}, Idempotency: NonIdempotent},
+	{Path: "/card/movieticket/updateuser", Name: "更新电影票", Methods: []string{"mp/card.Client.MovieTicketUpdateUser"}},
+	{Path: "/card/paycell/set", Name: "设置买单接口", Methods: []string{"mp/card.Client.PayCellSet"}, Idempotency: Idempotent},
+	{Path: "/card/qrcode/create", Name: "卡券投放", Methods: []string{"mp/card.Client.CardQRCodeCreate"}},
+	{Path: "/card/selfconsumecell/set", Name: "设置自助核销接口", Methods: []string{"mp/card.Client.SelfConsumeCellSet"}, Idempotency: Idempotent},
+	{Path: "/card/testwhitelist/set", Name: "设置测试用户白名单", Methods: []string{"mp/card.Client.TestWhiteListSet"}},
+	{Path: "/card/update", Name: "更改卡券信息接口", Methods: []string{"mp/card.Client.CardUpdate"}},
+	{Path: "/cgi-bin/changeopenid", Name: "公众号迁移(主体变更)后", Methods: []string{"mp/user.Client.ChangeOpenId"}},
+	{Path: "/cgi-bin/comment/list", Name: "查看指定文章的评论数据", Methods: []string{"mp/comment.Client.List"}},
+	{Path: "/cgi-bin/component/api_authorizer_token", Name: "使用 authorizer_refresh_token 获取(刷新)授权方的 authorizer_access_token", Methods: []string{"open/component.Client.RefreshAuthorizerToken"}},
+	{Path: "/cgi-bin/component/api_component_token", Name: "从微信服务器获取 component_access_token", Methods: []string{"open/component.ComponentTokenServer.getToken"}},
+	{Path: "/cgi-bin/component/api_create_preauthcode", Name: "获取预授权码 pre_auth_code", Methods: []string{"open/component.Client.CreatePreAuthCode"}},
+	{Path: "/cgi-bin/component/api_query_auth", Name: "使用授权码换取授权方的授权信息(authorizer_access_token, authorizer_refresh_token 等)", Methods: []string{"open/component.Client.QueryAuth"}},
+	{Path: "/cgi-bin/customservice/getkflist", Name: "获取客服基本信息", Methods: []string{"mp/dkf.Client.KfList"}, Idempotency: Idempotent},
+	{Path: "/cgi-bin/customservice/getonlinekflist", Name: "获取在线客服接待信息", Methods: []string{"mp/dkf.Client.OnlineKfList"}, Idempotency: Idempotent},
+	{Path: "/cgi-bin/customservice/getrecord", Name: "获取客服聊天记录", Methods: []string{"mp/dkf.Client.GetRecord"}},
+	{Path: "/cgi-bin/freepublish/delete", Name: "删除发布的文章", Methods: []string{"mp/freepublish.Client.Delete"}},
+	{Path: "/cgi-bin/freepublish/get", Name: "查询发布任务的状态", Methods: []string{"mp/freepublish.Client.Get"}, Idempotency: Idempotent},
+	{Path: "/cgi-bin/freepublish/submit", Name: "发布草稿", Methods: []string{"mp/freepublish.Client.Submit"}, Idempotency: NonIdempotent},
+	{Path: "/cgi-bin/getcallbackip", Name: "获取微信服务器IP地址", Methods: []string{"mp.WechatClient.GetCallbackIP"}, Idempotency: Idempotent},
+	{Path: "/cgi-bin/groups/create", Name: "创建分组", Methods: []string{"mp/user.Client.GroupCreate"}},
+	{Path: "/cgi-bin/groups/get", Name: "查询所有分组", Methods: []string{"mp/user.Client.GroupList"}, Idempotency: Idempotent},
+	{Path: "/cgi-bin/groups/getid", Name: "查询用户所在分组", Methods: []string{"mp/user.Client.UserInWhichGroup"}, Idempotency: Idempotent},
+	{Path: "/cgi-bin/groups/members/batchupdate", Name: "批量移动用户分组", Methods: []string{"mp/user.Client.MoveUsersToGroup"}},
+	{Path: "/cgi-bin/groups/members/update", Name: "移动用户分组", Methods: []string{"mp/user.Client.MoveUserToGroup"}},
+	{Path: "/cgi-bin/groups/update", Name: "修改分组名", Methods: []string{"mp/user.Client.GroupUpdate"}},
+	{Path: "/cgi-bin/guide/addguideacct", Name: "添加顾问", Methods: []string{"mp/guide.Client.AddAccount"}},
+	{Path: "/cgi-bin/guide/addguidebuyerrelation", Name: "为顾问分配客户", Methods: []string{"mp/guide.Client.AddBuyerRelation"}},
+	{Path: "/cgi-bin/guide/addguidebuyertag", Name: "为顾问的客户设置标签", Methods: []string{"mp/guide.Client.AddBuyerTag"}},
+	{Path: "/cgi-bin/guide/addguidetagoption", Name: "为客户标签类型添加可选值", Methods: []string{"mp/guide.Client.AddTagOption"}},
+	{Path: "/cgi-bin/guide/delguideacct", Name: "删除顾问", Methods: []string{"mp/guide.Client.DeleteAccount"}},
+	{Path: "/cgi-bin/guide/delguidebuyerrelation", Name: "为顾问移除客户", Methods: []string{"mp/guide.Client.DeleteBuyerRelation"}},
+	{Path: "/cgi-bin/guide/delguidebuyertag", Name: "删除顾问的客户的标签", Methods: []string{"mp/guide.Client.DeleteBuyerTag"}},
+	{Path: "/cgi-bin/guide/delguidecardmaterial", Name: "删除小程序卡片素材", Methods: []string{"mp/guide.Client.DeleteCardMaterial"}},
+	{Path: "/cgi-bin/guide/delguideimagematerial", Name: "删除图片素材", Methods: []string{"mp/guide.Client.DeleteImageMaterial"}},
+	{Path: "/cgi-bin/guide/delguidetagoption", Name: "删除客户标签类型", Methods: []string{"mp/guide.Client.DeleteTagOption"}},
+	{Path: "/cgi-bin/guide/delguidewordmaterial", Name: "删除文字素材", Methods: []string{"mp/guide.Client.DeleteWordMaterial"}},
+	{Path: "/cgi-bin/guide/getguideacct", Name: "获取顾问信息", Methods: []string{"mp/guide.Client.GetAccount"}},
+	{Path: "/cgi-bin/guide/getguideacctlist", Name: "获取服务号的顾问列表", Methods: []string{"mp/guide.Client.ListAccount"}},
+	{Path: "/cgi-bin/guide/getguidebuyerrelationbybuyer", Name: "查询客户所属的顾问", Methods: []string{"mp/guide.Client.GetBuyerRelationByBuyer"}},
+	{Path: "/cgi-bin/guide/getguidebuyerrelationlist", Name: "获取顾问的客户列表", Methods: []string{"mp/guide.Client.ListBuyerRelation"}},
+	{Path: "/cgi-bin/guide/getguidebuyertag", Name: "查询顾问的客户的标签", Methods: []string{"mp/guide.Client.GetBuyerTag"}},
+	{Path: "/cgi-bin/guide/getguidecardmaterial", Name: "查询小程序卡片素材", Methods: []string{"mp/guide.Client.GetCardMaterial"}},
+	{Path: "/cgi-bin/guide/getguideimagematerial", Name: "分页查询图片素材", Methods: []string{"mp/guide.Client.GetImageMaterial"}},
+	{Path: "/cgi-bin/guide/getguidetagoption", Name: "获取全部客户标签类型", Methods: []string{"mp/guide.Client.GetTagOption"}},
+	{Path: "/cgi-bin/guide/getguidewordmaterial", Name: "分页查询文字素材", Methods: []string{"mp/guide.Client.GetWordMaterial"}},
+	{Path: "/cgi-bin/guide/newguidetagoption", Name: "新建客户标签类型", Methods: []string{"mp/guide.Client.NewTagOption"}},
+	{Path: "/cgi-bin/guide/queryguidebuyerbytag", Name: "根据标签值筛选顾问的客户", Methods: []string{"mp/guide.Client.QueryBuyerByTag"}},
+	{Path: "/cgi-bin/guide/rebindguideacctforbuyer", Name: "将客户从一个顾问转移到另一个顾问", Methods: []string{"mp/guide.Client.RebindBuyer"}},
+	{Path: "/cgi-bin/guide/setguidecardmaterial", Name: "添加小程序卡片素材", Methods: []string{"mp/guide.Client.SetCardMaterial"}},
+	{Path: "/cgi-bin/guide/setguideimagematerial", Name: "添加图片素材", Methods: []string{"mp/guide.Client.SetImageMaterial"}},
+	{Path: "/cgi-bin/guide/setguidewordmaterial", Name: "添加文字素材", Methods: []string{"mp/guide.Client.SetWordMaterial"}},
+	{Path: "/cgi-bin/guide/updateguideacct", Name: "修改顾问的昵称或头像", Methods: []string{"mp/guide.Client.UpdateAccount"}},
+	{Path: "/cgi-bin/material/add_material", Name: "", Methods: []string{"mp/material.Client.uploadMaterialFromReader", "mp/material.Client.uploadVideoFromReader"}, Idempotency: NonIdempotent, Class: EndpointClassMediaUpload},
+	{Path: "/cgi-bin/material/add_news", Name: "新增永久图文素材", Methods: []string{"mp/material.Client.AddNews"}, Idempotency: NonIdempotent},
+	{Path: "/cgi-bin/material/batchget_material", Name: "获取素材列表", Methods: []string{"mp/material.Client.BatchGetMaterial", "mp/material.Client.BatchGetMaterialStream", "mp/material.Client.BatchGetNews", "mp/material.Client.BatchGetNewsStream"}, Idempotency: Idempotent},
+	{Path: "/cgi-bin/material/del_material", Name: "删除永久素材", Methods: []string{"mp/material.Client.DeleteMaterial"}},
+	{Path: "/cgi-bin/material/get_material", Name: "下载多媒体到 io.Writer", Methods: []string{"mp/material.Client.GetNews", "mp/material.Client.GetVideo", "mp/material.Client.downloadMaterialToWriter"}, Idempotency: Idempotent, Class: EndpointClassMediaDownload},
+	{Path: "/cgi-bin/material/get_materialcount", Name: "获取素材总数", Methods: []string{"mp/material.Client.GetMaterialCount"}, Idempotency: Idempotent},
+	{Path: "/cgi-bin/media/get", Name: "下载多媒体到 io.Writer", Methods: []string{"mp/media.Client.GetVideoURL", "mp/media.Client.downloadMediaToWriter"}, Idempotency: Idempotent, Class: EndpointClassMediaDownload},
+	{Path: "/cgi-bin/media/upload", Name: "", Methods: []string{"miniprogram.Client.uploadTempImageFromReader", "mp/media.Client.uploadMediaFromReader", "mp/media.Client.uploadThumbFromReader"}, Idempotency: NonIdempotent, Class: EndpointClassMediaUpload},
+	{Path: "/cgi-bin/media/uploadimg", Name: "", Methods: []string{"mp/card.Client.uploadImageFromReader", "mp/material.Client.uploadArticleImageFromReader"}, Idempotency: NonIdempotent, Class: EndpointClassMediaUpload},
+	{Path: "/cgi-bin/media/uploadnews", Name: "创建图文消息素材", Methods: []string{"mp/media.Client.CreateNews"}, Idempotency: NonIdempotent},
+	{Path: "/cgi-bin/media/uploadvideo", Name: "创建视频素材", Methods: []string{"mp/media.Client.CreateVideo"}},
+	{Path: "/cgi-bin/menu/create", Name: "创建自定义菜单", Methods: []string{"mp/menu.Client.CreateMenu"}, Idempotency: Idempotent},
+	{Path: "/cgi-bin/menu/delete", Name: "删除自定义菜单", Methods: []string{"mp/menu.Client.DeleteMenu"}, Idempotency: Idempotent},
+	{Path: "/cgi-bin/menu/get", Name: "获取自定义菜单", Methods: []string{"mp/menu.Client.GetMenu"}, Idempotency: Idempotent},
+	{Path: "/cgi-bin/message/custom/send", Name: "", Methods: []string{"miniprogram.Client.sendCustom", "mp/message/custom.Client.send"}, Idempotency: NonIdempotent},
+	{Path: "/cgi-bin/message/mass/delete", Name: "删除群发", Methods: []string{"mp/message/mass.Client.DeleteMass"}, Idempotency: NonIdempotent},
+	{Path: "/cgi-bin/message/mass/get", Name: "查询群发消息发送状态", Methods: []string{"mp/message/mass.Client.GetMassStatus"}, Idempotency: Idempotent},
+	{Path: "/cgi-bin/message/mass/preview", Name: "", Methods: []string{"mp/message/mass/preview.Client.send"}, Idempotency: NonIdempotent},
+	{Path: "/cgi-bin/message/mass/send", Name: "根据 openid 列表群发消息", Methods: []string{"mp/message/mass/mass2users.Client.send"}, QuotaClass: QuotaClassMassSend, Idempotency: NonIdempotent},
+	{Path: "/cgi-bin/message/mass/sendall", Name: "根据标签或者全部用户群发消息", Methods: []string{"mp/message/mass/mass2all.Client.send", "mp/message/mass/mass2group.Client.send"}, QuotaClass: QuotaClassMassSend, Idempotency: NonIdempotent},
+	{Path: "/cgi-bin/message/mass/speed/get", Name: "获取群发速度", Methods: []string{"mp/message/mass.Client.GetMassSpeed"}, Idempotency: Idempotent},
+	{Path: "/cgi-bin/message/mass/speed/set", Name: "设置群发速度", Methods: []string{"mp/message/mass.Client.SetMassSpeed"}, Idempotency: Idempotent},
+	{Path: "/cgi-bin/message/subscribe/bizsend", Name: "发送订阅通知", Methods: []string{"mp/message/subscribe.Client.Send"}, Idempotency: NonIdempotent},
+	{Path: "/cgi-bin/message/subscribe/send", Name: "发送订阅消息", Methods: []string{"miniprogram.Client.SendSubscribeMessage"}, Idempotency: NonIdempotent},
+	{Path: "/cgi-bin/message/template/send", Name: "发送模板消息", Methods: []string{"mp/message/template.Client.Send"}, Idempotency: NonIdempotent},
+	{Path: "/cgi-bin/open/bind", Name: "将公众号/小程序绑定到开放平台帐号下", Methods: []string{"open/account.Client.Bind"}},
+	{Path: "/cgi-bin/open/create", Name: "创建开放平台帐号并绑定公众号/小程序", Methods: []string{"open/account.Client.Create"}},
+	{Path: "/cgi-bin/open/get", Name: "获取公众号/小程序所绑定的开放平台帐号 appid", Methods: []string{"open/account.Client.Get"}},
+	{Path: "/cgi-bin/open/unbind", Name: "将公众号/小程序从开放平台帐号下解绑", Methods: []string{"open/account.Client.Unbind"}},
+	{Path: "/cgi-bin/qrcode/create", Name: "创建临时二维码", Methods: []string{"mp/account.Client.CreatePermanentQRCode", "mp/account.Client.CreatePermanentQRCodeWithSceneString", "mp/account.Client.CreateTemporaryQRCode"}, Idempotency: NonIdempotent},
+	{Path: "/cgi-bin/shorten/fetch", Name: "用 short_key 换回长信息", Methods: []string{"mp/account.Client.ShortKeyFetch"}, Idempotency: Idempotent},
+	{Path: "/cgi-bin/shorten/gen", Name: "短 key 托管: 把不超过 4KB 的长信息转成短 key", Methods: []string{"mp/account.Client.ShortKeyGen"}},
+	{Path: "/cgi-bin/shorturl", Name: "将一条长链接转成短链接", Methods: []string{"mp/account.Client.ShortURL"}},
+	{Path: "/cgi-bin/tags/get", Name: "获取公众号已创建的标签", Methods: []string{"mp/user.Client.TagList"}, Idempotency: Idempotent},
+	{Path: "/cgi-bin/tags/members/getblacklist", Name: "获取公众号的黑名单列表", Methods: []string{"mp/user.Client.BlacklistList"}, Idempotency: Idempotent},
+	{Path: "/cgi-bin/template/api_add_template", Name: "从行业模板库选择模板添加到账号后台", Methods: []string{"mp/message/template.Client.AddTemplate"}},
+	{Path: "/cgi-bin/template/api_set_industry", Name: "设置所属行业", Methods: []string{"mp/message/template.Client.SetIndustry"}, Idempotency: Idempotent},
+	{Path: "/cgi-bin/template/get_all_private_template", Name: "获取已添加至帐号下所有模板列表", Methods: []string{"mp/message/template.Client.GetAllPrivateTemplate"}, Idempotency: Idempotent},
+	{Path: "/cgi-bin/ticket/getticket", Name: "获取 type 为 ticketType 的临时票据", Methods: []string{"mp.WechatClient.GetTicket"}, Idempotency: Idempotent},
+	{Path: "/cgi-bin/token", Name: "从微信服务器获取 access_token", Methods: []string{"mp.FetchToken"}},
+	{Path: "/cgi-bin/user/get", Name: "获取关注者列表", Methods: []string{"mp/user.Client.UserList", "mp/user.Client.UserListStream"}, Idempotency: Idempotent},
+	{Path: "/cgi-bin/user/info", Name: "获取用户基本信息", Methods: []string{"mp/user.Client.UserInfo"}, Idempotency: Idempotent},
+	{Path: "/cgi-bin/user/info/updateremark", Name: "开发者可以通过该接口对指定用户设置备注名", Methods: []string{"mp/user.Client.UserUpdateRemark"}, Idempotency: Idempotent},
+	{Path: "/cgi-bin/user/tag/get", Name: "获取标签下粉丝列表", Methods: []string{"mp/user.Client.TagUserList"}, Idempotency: Idempotent},
+	{Path: "/cgi-bin/wxaapp/createwxaqrcode", Name: "获取小程序二维码", Methods: []string{"miniprogram.Client.CreateWXAQRCodeToWriter"}, QuotaClass: QuotaClassWXACode},
+	{Path: "/customservice/kfaccount/add", Name: "添加客服账号", Methods: []string{"mp/dkf.Client.AddKfAccount"}},
+	{Path: "/customservice/kfaccount/del", Name: "删除客服账号", Methods: []string{"mp/dkf.Client.DeleteKfAccount"}},
+	{Path: "/customservice/kfaccount/update", Name: "设置客服信息", Methods: []string{"mp/dkf.Client.SetKfAccount"}},
+	{Path: "/customservice/kfaccount/uploadheadimg", Name: "上传客服头像", Methods: []string{"mp/dkf.Client.uploadKfHeadImageFromReader"}},
+	{Path: "/datacube/getarticlesummary", Name: "获取图文群发每日数据", Methods: []string{"mp/datacube.Client.GetArticleSummary"}, Idempotency: Idempotent},
+	{Path: "/datacube/getarticletotal", Name: "获取图文群发总数据", Methods: []string{"mp/datacube.Client.GetArticleTotal"}, Idempotency: Idempotent},
+	{Path: "/datacube/getcardbizuininfo", Name: "拉取卡券概况数据", Methods: []string{"mp/card.Client.GetCardBizUinInfo"}, Idempotency: Idempotent},
+	{Path: "/datacube/getcardcardinfo", Name: "获取免费券数据", Methods: []string{"mp/card.Client.GetCardCardInfo"}, Idempotency: Idempotent},
+	{Path: "/datacube/getcardmembercarddetail", Name: "拉取单张会员卡数据", Methods: []string{"mp/card.Client.GetCardMemberCardDetail"}, Idempotency: Idempotent},
+	{Path: "/datacube/getcardmembercardinfo", Name: "拉取会员卡概况数据", Methods: []string{"mp/card.Client.GetCardMemberCardInfo"}, Idempotency: Idempotent},
+	{Path: "/datacube/getinterfacesummary", Name: "获取接口分析数据", Methods: []string{"mp/datacube.Client.GetInterfaceSummary"}, Idempotency: Idempotent},
+	{Path: "/datacube/getinterfacesummaryhour", Name: "获取接口分析分时数据", Methods: []string{"mp/datacube.Client.GetInterfaceSummaryHour"}, Idempotency: Idempotent},
+	{Path: "/datacube/getupstreammsg", Name: "获取消息发送概况数据", Methods: []string{"mp/datacube.Client.GetUpstreamMsg"}, Idempotency: Idempotent},
+	{Path: "/datacube/getupstreammsgdist", Name: "获取消息发送分布数据", Methods: []string{"mp/datacube.Client.GetUpstreamMsgDist"}, Idempotency: Idempotent},
+	{Path: "/datacube/getupstreammsgdistmonth", Name: "获取消息发送分布月数据", Methods: []string{"mp/datacube.Client.GetUpstreamMsgDistMonth"}, Idempotency: Idempotent},
+	{Path: "/datacube/getupstreammsgdistweek", Name: "获取消息发送分布周数据", Methods: []string{"mp/datacube.Client.GetUpstreamMsgDistWeek"}, Idempotency: Idempotent},
+	{Path: "/datacube/getupstreammsghour", Name: "获取消息分送分时数据", Methods: []string{"mp/datacube.Client.GetUpstreamMsgHour"}, Idempotency: Idempotent},
+	{Path: "/datacube/getupstreammsgmonth", Name: "获取消息发送月数据", Methods: []string{"mp/datacube.Client.GetUpstreamMsgMonth"}, Idempotency: Idempotent},
+	{Path: "/datacube/getupstreammsgweek", Name: "获取消息发送周数据", Methods: []string{"mp/datacube.Client.GetUpstreamMsgWeek"}, Idempotency: Idempotent},
+	{Path: "/datacube/getusercumulate", Name: "获取累计用户数据", Methods: []string{"mp/datacube.Client.GetUserCumulate"}, Idempotency: Idempotent},
+	{Path: "/datacube/getuserread", Name: "获取图文统计数据", Methods: []string{"mp/datacube.Client.GetUserRead"}, Idempotency: Idempotent},
+	{Path: "/datacube/getuserreadhour", Name: "获取图文统计分时数据", Methods: []string{"mp/datacube.Client.GetUserReadHour"}, Idempotency: Idempotent},
+	{Path: "/datacube/getusershare", Name: "获取图文分享转发数据", Methods: []string{"mp/datacube.Client.GetUserShare"}, Idempotency: Idempotent},
+	{Path: "/datacube/getusersharehour", Name: "获取图文分享转发分时数据", Methods: []string{"mp/datacube.Client.GetUserShareHour"}, Idempotency: Idempotent},
+	{Path: "/datacube/getusersummary", Name: "获取用户增减数据", Methods: []string{"mp/datacube.Client.GetUserSummary"}, Idempotency: Idempotent},
+	{Path: "/device/authorize_device", Name: "设备授权", Methods: []string{"mp/device.Client.AuthorizeDevice"}},
+	{Path: "/device/bind", Name: "绑定设备", Methods: []string{"mp/device.Client.Bind"}},
+	{Path: "/device/compel_bind", Name: "强制绑定用户和设备", Methods: []string{"mp/device.Client.CompelBind"}},
+	{Path: "/device/compel_unbind", Name: "强制解绑用户和设备", Methods: []string{"mp/device.Client.CompelUnbind"}},
+	{Path: "/device/create_qrcode", Name: "为已授权的设备批量生成二维码", Methods: []string{"mp/device.Client.CreateQRCode"}},
+	{Path: "/device/get_bind_device", Name: "通过 openid 获取用户绑定的设备", Methods: []string{"mp/device.Client.GetBindDevice"}, Idempotency: Idempotent},
+	{Path: "/device/get_openid", Name: "获取设备绑定的用户 openid 列表", Methods: []string{"mp/device.Client.GetOpenId"}, Idempotency: Idempotent},
+	{Path: "/device/get_stat", Name: "查询设备状态", Methods: []string{"mp/device.Client.GetStat"}, Idempotency: Idempotent},
+	{Path: "/device/getqrcode", Name: "获取设备二维码", Methods: []string{"mp/device.Client.GetQRCode"}},
+	{Path: "/device/transmsg", Name: "主动发送消息给设备", Methods: []string{"mp/device.Client.TransMsg"}, Idempotency: NonIdempotent},
+	{Path: "/device/unbind", Name: "解绑设备", Methods: []string{"mp/device.Client.Unbind"}},
+	{Path: "/scan/merchantinfo/get", Name: "获取商户信息", Methods: []string{"mp/scan.Client.GetMerchantInfo"}, Idempotency: Idempotent},
+	{Path: "/scan/product/clear", Name: "清除商品信息", Methods: []string{"mp/scan.Client.ProductClear"}},
+	{Path: "/scan/product/create", Name: "创建商品", Methods: []string{"mp/scan.Client.ProductCreate"}},
+	{Path: "/scan/product/get", Name: "查询商品信息", Methods: []string{"mp/scan.Client.ProductGet"}, Idempotency: Idempotent},
+	{Path: "/scan/product/getlist", Name: "批量查询商品信息", Methods: []string{"mp/scan.Client.ProductGetList"}, Idempotency: Idempotent},
+	{Path: "/scan/product/getqrcode", Name: "获取商品二维码", Methods: []string{"mp/scan.Client.ProductGetQRCode"}},
+	{Path: "/scan/product/modstatus", Name: "提交审核/取消发布商品", Methods: []string{"mp/scan.Client.ProductModStatus"}},
+	{Path: "/scan/product/update", Name: "更新商品信息", Methods: []string{"mp/scan.Client.ProductUpdate"}},
+	{Path: "/scan/scanticket/check", Name: "检查 wxticket 参数", Methods: []string{"mp/scan.Client.CheckTicket"}},
+	{Path: "/scan/testwhitelist/set", Name: "设置测试人员白名单", Methods: []string{"mp/scan.Client.SetTestWhiteList"}},
+	{Path: "/shakearound/device/applyid", Name: "申请设备ID", Methods: []string{"mp/shakearound.Client.DeviceApplyId"}},
+	{Path: "/shakearound/device/bindlocation", Name: "配置设备与门店的关联关系", Methods: []string{"mp/shakearound.Client.DeviceBindLocation"}},
+	{Path: "/shakearound/device/bindpage", Name: "配置设备与页面的关联关系", Methods: []string{"mp/shakearound.Client.DeviceBindPage"}},
+	{Path: "/shakearound/device/search", Name: "", Methods: []string{"mp/shakearound.Client.deviceSearch"}, Idempotency: Idempotent},
+	{Path: "/shakearound/device/update", Name: "编辑设备的备注信息", Methods: []string{"mp/shakearound.Client.DeviceUpdate"}},
+	{Path: "/shakearound/material/add", Name: "", Methods: []string{"mp/shakearound.Client.materialAddFromReader"}, Class: EndpointClassMediaUpload},
+	{Path: "/shakearound/page/add", Name: "新增页面", Methods: []string{"mp/shakearound.Client.PageAdd"}},
+	{Path: "/shakearound/page/delete", Name: "删除页面", Methods: []string{"mp/shakearound.Client.PageDelete"}},
+	{Path: "/shakearound/page/search", Name: "", Methods: []string{"mp/shakearound.Client.pageSearch"}, Idempotency: Idempotent},
+	{Path: "/shakearound/page/update", Name: "编辑页面信息", Methods: []string{"mp/shakearound.Client.PageUpdate"}},
+	{Path: "/shakearound/statistics/device", Name: "以设备为维度的数据统计", Methods: []string{"mp/shakearound.Client.StatisticsDevice"}, Idempotency: Idempotent},
+	{Path: "/shakearound/statistics/devicelist", Name: "批量查询设备统计数据", Methods: []string{"mp/shakearound.Client.StatisticsDeviceList"}, Idempotency: Idempotent},
+	{Path: "/shakearound/statistics/page", Name: "以页面为维度的数据统计", Methods: []string{"mp/shakearound.Client.StatisticsPage"}, Idempotency: Idempotent},
+	{Path: "/shakearound/statistics/pagelist", Name: "批量查询页面统计数据", Methods: []string{"mp/shakearound.Client.StatisticsPageList"}, Idempotency: Idempotent},
+	{Path: "/shakearound/user/getshakeinfo", Name: "获取设备信息", Methods: []string{"mp/shakearound.Client.UserGetShakeInfo"}},
+	{Path: "/sns/auth", Name: "检验授权凭证（access_token）是否有效", Methods: []string{"mp/user/oauth2.Client.CheckAccessTokenValid"}},
+	{Path: "/sns/jscode2session", Name: "登录凭证校验", Methods: []string{"miniprogram.Code2Session"}},
+	{Path: "/sns/oauth2/access_token", Name: "通过code换取网页授权access_token", Methods: []string{"mp/user/oauth2.Client.Exchange"}},
+	{Path: "/sns/oauth2/refresh_token", Name: "刷新access_token（如果需要）", Methods: []string{"mp/user/oauth2.Client.TokenRefresh"}},
+	{Path: "/sns/userinfo", Name: "获取用户信息(需scope为 snsapi_userinfo)", Methods: []string{"mp/user/oauth2.Client.UserInfo"}},
+	{Path: "/wxa/business/getgoodswarehouse", Name: "获取商品的信息与审核状态", Methods: []string{"miniprogram.Client.LiveGetGoodsStatus"}},
+	{Path: "/wxa/business/getliveinfo", Name: "获取直播间列表", Methods: []string{"miniprogram.Client.LiveGetReplay", "miniprogram.Client.LiveGetRooms"}},
+	{Path: "/wxa/business/getuserphonenumber", Name: "通过手机号快速验证组件返回的动态令牌 code 获取用户手机号", Methods: []string{"miniprogram.Client.GetUserPhoneNumber"}},
+	{Path: "/wxa/generate_urllink", Name: "获取小程序 URL Link", Methods: []string{"miniprogram.Client.GenerateURLLink"}},
+	{Path: "/wxa/generatescheme", Name: "获取小程序 URL Scheme", Methods: []string{"miniprogram.Client.GenerateScheme"}},
+	{Path: "/wxa/genwxashortlink", Name: "获取小程序 Short Link", Methods: []string{"miniprogram.Client.GenerateShortLink"}},
+	{Path: "/wxa/getwxacode", Name: "获取小程序码", Methods: []string{"miniprogram.Client.GetWXACodeToWriter"}, QuotaClass: QuotaClassWXACode},
+	{Path: "/wxa/getwxacodeunlimit", Name: "获取不限制数量的小程序码", Methods: []string{"miniprogram.Client.GetWXACodeUnlimitToWriter"}},
+	{Path: "/wxa/sec/order/get_order", Name: "查询订单发货状态", Methods: []string{"miniprogram.Client.GetShippingOrder"}, Idempotency: Idempotent},
+	{Path: "/wxa/sec/order/get_order_list", Name: "查询订单列表", Methods: []string{"miniprogram.Client.GetShippingOrderList"}, Idempotency: Idempotent},
+	{Path: "/wxa/sec/order/is_trade_managed", Name: "查询小程序是否已开通发货信息管理服务", Methods: []string{"miniprogram.Client.IsTradeManaged"}, Idempotency: Idempotent},
+	{Path: "/wxa/sec/order/notify_confirm_receive", Name: "确认收货提醒", Methods: []string{"miniprogram.Client.NotifyConfirmReceive"}},
+	{Path: "/wxa/sec/order/set_msg_jump_path", Name: "设置消息跳转路径", Methods: []string{"miniprogram.Client.SetMsgJumpPath"}, Idempotency: Idempotent},
+	{Path: "/wxa/sec/order/upload_combined_shipping_info", Name: "合单支付的发货信息录入", Methods: []string{"miniprogram.Client.UploadCombinedShippingInfo"}},
+	{Path: "/wxa/sec/order/upload_shipping_info", Name: "发货信息录入", Methods: []string{"miniprogram.Client.UploadShippingInfo"}, Idempotency: NonIdempotent},
+	{Path: "/wxaapi/broadcast/goods/add", Name: "添加商品并提审", Methods: []string{"miniprogram.Client.LiveAddGoods"}},
+	{Path: "/wxaapi/broadcast/goods/audit", Name: "重新提交审核", Methods: []string{"miniprogram.Client.LiveAuditGoods"}},
+	{Path: "/wxaapi/broadcast/goods/delete", Name: "删除商品", Methods: []string{"miniprogram.Client.LiveDeleteGoods"}},
+	{Path: "/wxaapi/broadcast/goods/resetaudit", Name: "撤回商品的审核", Methods: []string{"miniprogram.Client.LiveResetAuditGoods"}},
+	{Path: "/wxaapi/broadcast/goods/update", Name: "更新商品", Methods: []string{"miniprogram.Client.LiveUpdateGoods"}},
+	{Path: "/wxaapi/broadcast/room/addgoods", Name: "往直播间导入已经审核通过的商品", Methods: []string{"miniprogram.Client.LiveRoomAddGoods"}},
+	{Path: "/wxaapi/broadcast/room/create", Name: "创建直播间", Methods: []string{"miniprogram.Client.LiveCreateRoom"}},
+	{Path: "/wxaapi/broadcast/room/deleteroom", Name: "删除直播间", Methods: []string{"miniprogram.Client.LiveDeleteRoom"}},
+	{Path: "/wxaapi/newtmpl/addtemplate", Name: "组合模板并添加至帐号下的个人模板库", Methods: []string{"miniprogram.Client.AddTemplate"}},
+	{Path: "/wxaapi/newtmpl/deltemplate", Name: "删除帐号下的个人模板", Methods: []string{"miniprogram.Client.DeleteTemplate"}},
+	{Path: "/wxaapi/newtmpl/getcategory", Name: "获取小程序账号的类目", Methods: []string{"miniprogram.Client.GetCategory"}},
+	{Path: "/wxaapi/newtmpl/getpubtemplatekeywords", Name: "获取模板标题下的关键词列表", Methods: []string{"miniprogram.Client.GetPubTemplateKeywords"}},
+	{Path: "/wxaapi/newtmpl/getpubtemplatetitles", Name: "获取帐号所属类目下的公共模板标题", Methods: []string{"miniprogram.Client.GetPubTemplateTitles"}},
+	{Path: "/wxaapi/newtmpl/gettemplate", Name: "获取当前帐号下的个人模板列表", Methods: []string{"miniprogram.Client.GetTemplateList"}},
+}
@@ -0,0 +1,300 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// Code generated by tools/errcode; DO NOT EDIT.
+
+package mp
+
+// 全局返回码
+const (
+	ErrCodeSystemBusy                 = -1    // 系统繁忙，此时请开发者稍候再试
+	ErrCodeOK                         = 0     // 请求成功
+	ErrCodeInvalidCredential          = 40001 // 获取access_token时AppSecret错误，或者access_token无效。请开发者认真比对AppSecret的正确性，或查看是否正在为恰当的公众号调用接口
+	ErrCodeInvalidGrantType           = 40002 // 不合法的凭证类型
+	ErrCodeInvalidOpenId              = 40003 // 不合法的OpenID，请开发者确认OpenID（该用户）是否已关注公众号，或是否是其他公众号的OpenID
+	ErrCodeInvalidMediaType           = 40004 // 不合法的媒体文件类型
+	ErrCodeInvalidFileType            = 40005 // 不合法的文件类型
+	ErrCodeInvalidFileSize            = 40006 // 不合法的文件大小
+	ErrCodeInvalidMediaId             = 40007 // 不合法的媒体文件id
+	ErrCodeInvalidMessageType         = 40008 // 不合法的消息类型
+	ErrCodeInvalidImageSize           = 40009 // 不合法的图片文件大小
+	ErrCodeInvalidVoiceSize           = 40010 // 不合法的语音文件大小
+	ErrCodeInvalidVideoSize           = 40011 // 不合法的视频文件大小
+	ErrCodeInvalidThumbSize           = 40012 // 不合法的缩略图文件大小
+	ErrCodeInvalidAppId               = 40013 // 不合法的AppID，请开发者检查AppID的正确性，避免异常字符，注意大小写
+	ErrCodeInvalidAccessToken         = 40014 // 不合法的access_token，请开发者认真比对access_token的有效性（如是否过期），或查看是否正在为恰当的公众号调用接口
+	ErrCodeInvalidMenuType            = 40015 // 不合法的菜单类型
+	ErrCodeInvalidButtonCount         = 40016 // 不合法的按钮个数
+	ErrCodeInvalidButtonType          = 40017 // 不合法的按钮个数
+	ErrCodeInvalidButtonNameLength    = 40018 // 不合法的按钮名字长度
+	ErrCodeInvalidButtonKeyLength     = 40019 // 不合法的按钮KEY长度
+	ErrCodeInvalidButtonURLLength     = 40020 // 不合法的按钮URL长度
+	ErrCodeInvalidMenuVersion         = 40021 // 不合法的菜单版本号
+	ErrCodeInvalidSubMenuLevel        = 40022 // 不合法的子菜单级数
+	ErrCodeInvalidSubButtonCount      = 40023 // 不合法的子菜单按钮个数
+	ErrCodeInvalidSubButtonType       = 40024 // 不合法的子菜单按钮类型
+	ErrCodeInvalidSubButtonNameLength = 40025 // 不合法的子菜单按钮名字长度
+	ErrCodeInvalidSubButtonKeyLength  = 40026 // 不合法的子菜单按钮KEY长度
+	ErrCodeInvalidSubButtonURLLength  = 40027 // 不合法的子菜单按钮URL长度
+	ErrCodeInvalidMenuUser            = 40028 // 不合法的自定义菜单使用用户
+	ErrCodeInvalidOAuthCode           = 40029 // 不合法的oauth_code
+	ErrCodeInvalidRefreshToken        = 40030 // 不合法的refresh_token
+	ErrCodeInvalidOpenIdList          = 40031 // 不合法的openid列表
+	ErrCodeInvalidOpenIdListLength    = 40032 // 不合法的openid列表长度
+	ErrCodeInvalidCharacter           = 40033 // 不合法的请求字符，不能包含\uxxxx格式的字符
+	ErrCodeInvalidParameter           = 40035 // 不合法的参数
+	ErrCodeInvalidRequestFormat       = 40038 // 不合法的请求格式
+	ErrCodeInvalidURLLength           = 40039 // 不合法的URL长度
+	ErrCodeInvalidGroupId             = 40050 // 不合法的分组id
+	ErrCodeInvalidGroupName           = 40051 // 分组名字不合法
+	ErrCodeMissingAccessToken         = 41001 // 缺少access_token参数
+	ErrCodeMissingAppId               = 41002 // 缺少appid参数
+	ErrCodeMissingRefreshToken        = 41003 // 缺少refresh_token参数
+	ErrCodeMissingSecret              = 41004 // 缺少secret参数
+	ErrCodeMissingMediaData           = 41005 // 缺少多媒体文件数据
+	ErrCodeMissingMediaId             = 41006 // 缺少media_id参数
+	ErrCodeMissingSubMenu             = 41007 // 缺少子菜单数据
+	ErrCodeMissingOAuthCode           = 41008 // 缺少oauth code
+	ErrCodeMissingOpenId              = 41009 // 缺少openid
+	ErrCodeTimeout                    = 42001 // access_token超时，请检查access_token的有效期，请参考基础支持-获取access_token中，对access_token的详细机制说明
+	ErrCodeRefreshTokenTimeout        = 42002 // refresh_token超时
+	ErrCodeOAuthCodeTimeout           = 42003 // oauth_code超时
+	ErrCodeRequireGET                 = 43001 // 需要GET请求
+	ErrCodeRequirePOST                = 43002 // 需要POST请求
+	ErrCodeRequireHTTPS               = 43003 // 需要HTTPS请求
+	ErrCodeRequireSubscribe           = 43004 // 需要接收者关注
+	ErrCodeRequireFriend              = 43005 // 需要好友关系
+	ErrCodeUserRefuseToAcceptMsg      = 43101 // 用户拒绝接受消息, 用户没有订阅或者取消了订阅
+	ErrCodeEmptyMedia                 = 44001 // 多媒体文件为空
+	ErrCodeEmptyPostData              = 44002 // POST的数据包为空
+	ErrCodeEmptyNews                  = 44003 // 图文消息内容为空
+	ErrCodeEmptyText                  = 44004 // 文本消息内容为空
+	ErrCodeMediaSizeLimit             = 45001 // 多媒体文件大小超过限制
+	ErrCodeContentSizeLimit           = 45002 // 消息内容超过限制
+	ErrCodeTitleSizeLimit             = 45003 // 标题字段超过限制
+	ErrCodeDescriptionSizeLimit       = 45004 // 描述字段超过限制
+	ErrCodeURLSizeLimit               = 45005 // 链接字段超过限制
+	ErrCodePicURLSizeLimit            = 45006 // 图片链接字段超过限制
+	ErrCodeVoicePlaytimeLimit         = 45007 // 语音播放时间超过限制
+	ErrCodeArticleCountLimit          = 45008 // 图文消息超过限制
+	ErrCodeAPIFreqLimit               = 45009 // 接口调用超过限制
+	ErrCodeMenuCountLimit             = 45010 // 创建菜单个数超过限制
+	ErrCodeResponseTimeLimit          = 45015 // 回复时间超过限制
+	ErrCodeSystemGroupReadOnly        = 45016 // 系统分组，不允许修改
+	ErrCodeGroupNameTooLong           = 45017 // 分组名字过长
+	ErrCodeGroupCountLimit            = 45018 // 分组数量超过上限
+	ErrCodeResponseCountLimit         = 45047 // 客服接口下行条数超过上限
+	ErrCodeMediaNotExist              = 46001 // 不存在媒体数据
+	ErrCodeMenuVersionNotExist        = 46002 // 不存在的菜单版本
+	ErrCodeMenuNotExist               = 46003 // 不存在的菜单数据
+	ErrCodeUserNotExist               = 46004 // 不存在的用户
+	ErrCodeParseError                 = 47001 // 解析JSON/XML内容错误
+	ErrCodeAPIUnauthorized            = 48001 // api功能未授权，请确认公众号已获得该接口，可以在公众平台官网-开发者中心页中查看接口权限
+	ErrCodeUserUnauthorized           = 50001 // 用户未授权该api
+	ErrCodeSystemError                = 61450 // 系统错误(system error)
+	ErrCodeInvalidKfParameter         = 61451 // 参数错误(invalid parameter)
+	ErrCodeInvalidKfAccount           = 61452 // 无效客服账号(invalid kf_account)
+	ErrCodeKfAccountExisted           = 61453 // 客服帐号已存在(kf_account exsited)
+	ErrCodeInvalidKfAccountLength     = 61454 // 客服帐号名长度超过限制(仅允许10个英文字符，不包括@及@后的公众号的微信号)(invalid kf_acount length)
+	ErrCodeInvalidKfAccountCharacter  = 61455 // 客服帐号名包含非法字符(仅允许英文+数字)(illegal character in kf_account)
+	ErrCodeKfAccountCountLimit        = 61456 // 客服帐号个数超过限制(10个客服账号)(kf_account count exceeded)
+	ErrCodeInvalidKfHeadImgType       = 61457 // 无效头像文件类型(invalid file type)
+	ErrCodeInvalidDateFormat          = 61500 // 日期格式错误
+	ErrCodeInvalidDateRange           = 61501 // 日期范围错误
+)
+
+// 全局返回码对应的 error, 可以用 errors.Is(err, ErrXxx) 判断
+var (
+	ErrSystemBusy                 = &Error{ErrCode: ErrCodeSystemBusy, ErrMsg: "系统繁忙，此时请开发者稍候再试"}
+	ErrInvalidCredential          = &Error{ErrCode: ErrCodeInvalidCredential, ErrMsg: "获取access_token时AppSecret错误，或者access_token无效。请开发者认真比对AppSecret的正确性，或查看是否正在为恰当的公众号调用接口"}
+	ErrInvalidGrantType           = &Error{ErrCode: ErrCodeInvalidGrantType, ErrMsg: "不合法的凭证类型"}
+	ErrInvalidOpenId              = &Error{ErrCode: ErrCodeInvalidOpenId, ErrMsg: "不合法的OpenID，请开发者确认OpenID（该用户）是否已关注公众号，或是否是其他公众号的OpenID"}
+	ErrInvalidMediaType           = &Error{ErrCode: ErrCodeInvalidMediaType, ErrMsg: "不合法的媒体文件类型"}
+	ErrInvalidFileType            = &Error{ErrCode: ErrCodeInvalidFileType, ErrMsg: "不合法的文件类型"}
+	ErrInvalidFileSize            = &Error{ErrCode: ErrCodeInvalidFileSize, ErrMsg: "不合法的文件大小"}
+	ErrInvalidMediaId             = &Error{ErrCode: ErrCodeInvalidMediaId, ErrMsg: "不合法的媒体文件id"}
+	ErrInvalidMessageType         = &Error{ErrCode: ErrCodeInvalidMessageType, ErrMsg: "不合法的消息类型"}
+	ErrInvalidImageSize           = &Error{ErrCode: ErrCodeInvalidImageSize, ErrMsg: "不合法的图片文件大小"}
+	ErrInvalidVoiceSize           = &Error{ErrCode: ErrCodeInvalidVoiceSize, ErrMsg: "不合法的语音文件大小"}
+	ErrInvalidVideoSize           = &Error{ErrCode: ErrCodeInvalidVideoSize, ErrMsg: "不合法的视频文件大小"}
+	ErrInvalidThumbSize           = &Error{ErrCode: ErrCodeInvalidThumbSize, ErrMsg: "不合法的缩略图文件大小"}
+	ErrInvalidAppId               = &Error{ErrCode: ErrCodeInvalidAppId, ErrMsg: "不合法的AppID，请开发者检查AppID的正确性，避免异常字符，注意大小写"}
+	ErrInvalidAccessToken         = &Error{ErrCode: ErrCodeInvalidAccessToken, ErrMsg: "不合法的access_token，请开发者认真比对access_token的有效性（如是否过期），或查看是否正在为恰当的公众号调用接口"}
+	ErrInvalidMenuType            = &Error{ErrCode: ErrCodeInvalidMenuType, ErrMsg: "不合法的菜单类型"}
+	ErrInvalidButtonCount         = &Error{ErrCode: ErrCodeInvalidButtonCount, ErrMsg: "不合法的按钮个数"}
+	ErrInvalidButtonType          = &Error{ErrCode: ErrCodeInvalidButtonType, ErrMsg: "不合法的按钮个数"}
+	ErrInvalidButtonNameLength    = &Error{ErrCode: ErrCodeInvalidButtonNameLength, ErrMsg: "不合法的按钮名字长度"}
+	ErrInvalidButtonKeyLength     = &Error{ErrCode: ErrCodeInvalidButtonKeyLength, ErrMsg: "不合法的按钮KEY长度"}
+	ErrInvalidButtonURLLength     = &Error{ErrCode: ErrCodeInvalidButtonURLLength, ErrMsg: "不合法的按钮URL长度"}
+	ErrInvalidMenuVersion         = &Error{ErrCode: ErrCodeInvalidMenuVersion, ErrMsg: "不合法的菜单版本号"}
+	ErrInvalidSubMenuLevel        = &Error{ErrCode: ErrCodeInvalidSubMenuLevel, ErrMsg: "不合法的子菜单级数"}
+	ErrInvalidSubButtonCount      = &Error{ErrCode: ErrCodeInvalidSubButtonCount, ErrMsg: "不合法的子菜单按钮个数"}
+	ErrInvalidSubButtonType       = &Error{ErrCode: ErrCodeInvalidSubButtonType, ErrMsg: "不合法的子菜单按钮类型"}
+	ErrInvalidSubButtonNameLength = &Error{ErrCode: ErrCodeInvalidSubButtonNameLength, ErrMsg: "不合法的子菜单按钮名字长度"}
+	ErrInvalidSubButtonKeyLength  = &Error{ErrCode: ErrCodeInvalidSubButtonKeyLength, ErrMsg: "不合法的子菜单按钮KEY长度"}
+	ErrInvalidSubButtonURLLength  = &Error{ErrCode: ErrCodeInvalidSubButtonURLLength, ErrMsg: "不合法的子菜单按钮URL长度"}
+	ErrInvalidMenuUser            = &Error{ErrCode: ErrCodeInvalidMenuUser, ErrMsg: "不合法的自定义菜单使用用户"}
+	ErrInvalidOAuthCode           = &Error{ErrCode: ErrCodeInvalidOAuthCode, ErrMsg: "不合法的oauth_code"}
+	ErrInvalidRefreshToken        = &Error{ErrCode: ErrCodeInvalidRefreshToken, ErrMsg: "不合法的refresh_token"}
+	ErrInvalidOpenIdList          = &Error{ErrCode: ErrCodeInvalidOpenIdList, ErrMsg: "不合法的openid列表"}
+	ErrInvalidOpenIdListLength    = &Error{ErrCode: ErrCodeInvalidOpenIdListLength, ErrMsg: "不合法的openid列表长度"}
+	ErrInvalidCharacter           = &Error{ErrCode: ErrCodeInvalidCharacter, ErrMsg: "不合法的请求字符，不能包含\\uxxxx格式的字符"}
+	ErrInvalidParameter           = &Error{ErrCode: ErrCodeInvalidParameter, ErrMsg: "不合法的参数"}
+	ErrInvalidRequestFormat       = &Error{ErrCode: ErrCodeInvalidRequestFormat, ErrMsg: "不合法的请求格式"}
+	ErrInvalidURLLength           = &Error{ErrCode: ErrCodeInvalidURLLength, ErrMsg: "不合法的URL长度"}
+	ErrInvalidGroupId             = &Error{ErrCode: ErrCodeInvalidGroupId, ErrMsg: "不合法的分组id"}
+	ErrInvalidGroupName           = &Error{ErrCode: ErrCodeInvalidGroupName, ErrMsg: "分组名字不合法"}
+	ErrMissingAccessToken         = &Error{ErrCode: ErrCodeMissingAccessToken, ErrMsg: "缺少access_token参数"}
+	ErrMissingAppId               = &Error{ErrCode: ErrCodeMissingAppId, ErrMsg: "缺少appid参数"}
+	ErrMissingRefreshToken        = &Error{ErrCode: ErrCodeMissingRefreshToken, ErrMsg: "缺少refresh_token参数"}
+	ErrMissingSecret              = &Error{ErrCode: ErrCodeMissingSecret, ErrMsg: "缺少secret参数"}
+	ErrMissingMediaData           = &Error{ErrCode: ErrCodeMissingMediaData, ErrMsg: "缺少多媒体文件数据"}
+	ErrMissingMediaId             = &Error{ErrCode: ErrCodeMissingMediaId, ErrMsg: "缺少media_id参数"}
+	ErrMissingSubMenu             = &Error{ErrCode: ErrCodeMissingSubMenu, ErrMsg: "缺少子菜单数据"}
+	ErrMissingOAuthCode           = &Error{ErrCode: ErrCodeMissingOAuthCode, ErrMsg: "缺少oauth code"}
+	ErrMissingOpenId              = &Error{ErrCode: ErrCodeMissingOpenId, ErrMsg: "缺少openid"}
+	ErrTimeout                    = &Error{ErrCode: ErrCodeTimeout, ErrMsg: "access_token超时，请检查access_token的有效期，请参考基础支持-获取access_token中，对access_token的详细机制说明"}
+	ErrRefreshTokenTimeout        = &Error{ErrCode: ErrCodeRefreshTokenTimeout, ErrMsg: "refresh_token超时"}
+	ErrOAuthCodeTimeout           = &Error{ErrCode: ErrCodeOAuthCodeTimeout, ErrMsg: "oauth_code超时"}
+	ErrRequireGET                 = &Error{ErrCode: ErrCodeRequireGET, ErrMsg: "需要GET请求"}
+	ErrRequirePOST                = &Error{ErrCode: ErrCodeRequirePOST, ErrMsg: "需要POST请求"}
+	ErrRequireHTTPS               = &Error{ErrCode: ErrCodeRequireHTTPS, ErrMsg: "需要HTTPS请求"}
+	ErrRequireSubscribe           = &Error{ErrCode: ErrCodeRequireSubscribe, ErrMsg: "需要接收者关注"}
+	ErrRequireFriend              = &Error{ErrCode: ErrCodeRequireFriend, ErrMsg: "需要好友关系"}
+	ErrUserRefuseToAcceptMsg      = &Error{ErrCode: ErrCodeUserRefuseToAcceptMsg, ErrMsg: "用户拒绝接受消息, 用户没有订阅或者取消了订阅"}
+	ErrEmptyMedia                 = &Error{ErrCode: ErrCodeEmptyMedia, ErrMsg: "多媒体文件为空"}
+	ErrEmptyPostData              = &Error{ErrCode: ErrCodeEmptyPostData, ErrMsg: "POST的数据包为空"}
+	ErrEmptyNews                  = &Error{ErrCode: ErrCodeEmptyNews, ErrMsg: "图文消息内容为空"}
+	ErrEmptyText                  = &Error{ErrCode: ErrCodeEmptyText, ErrMsg: "文本消息内容为空"}
+	ErrMediaSizeLimit             = &Error{ErrCode: ErrCodeMediaSizeLimit, ErrMsg: "多媒体文件大小超过限制"}
+	ErrContentSizeLimit           = &Error{ErrCode: ErrCodeContentSizeLimit, ErrMsg: "消息内容超过限制"}
+	ErrTitleSizeLimit             = &Error{ErrCode: ErrCodeTitleSizeLimit, ErrMsg: "标题字段超过限制"}
+	ErrDescriptionSizeLimit       = &Error{ErrCode: ErrCodeDescriptionSizeLimit, ErrMsg: "描述字段超过限制"}
+	ErrURLSizeLimit               = &Error{ErrCode: ErrCodeURLSizeLimit, ErrMsg: "链接字段超过限制"}
+	ErrPicURLSizeLimit            = &Error{ErrCode: ErrCodePicURLSizeLimit, ErrMsg: "图片链接字段超过限制"}
+	ErrVoicePlaytimeLimit         = &Error{ErrCode: ErrCodeVoicePlaytimeLimit, ErrMsg: "语音播放时间超过限制"}
+	ErrArticleCountLimit          = &Error{ErrCode: ErrCodeArticleCountLimit, ErrMsg: "图文消息超过限制"}
+	ErrAPIFreqLimit               = &Error{ErrCode: ErrCodeAPIFreqLimit, ErrMsg: "接口调用超过限制"}
+	ErrMenuCountLimit             = &Error{ErrCode: ErrCodeMenuCountLimit, ErrMsg: "创建菜单个数超过限制"}
+	ErrResponseTimeLimit          = &Error{ErrCode: ErrCodeResponseTimeLimit, ErrMsg: "回复时间超过限制"}
+	ErrSystemGroupReadOnly        = &Error{ErrCode: ErrCodeSystemGroupReadOnly, ErrMsg: "系统分组，不允许修改"}
+	ErrGroupNameTooLong           = &Error{ErrCode: ErrCodeGroupNameTooLong, ErrMsg: "分组名字过长"}
+	ErrGroupCountLimit            = &Error{ErrCode: ErrCodeGroupCountLimit, ErrMsg: "分组数量超过上限"}
+	ErrResponseCountLimit         = &Error{ErrCode: ErrCodeResponseCountLimit, ErrMsg: "客服接口下行条数超过上限"}
+	ErrMediaNotExist              = &Error{ErrCode: ErrCodeMediaNotExist, ErrMsg: "不存在媒体数据"}
+	ErrMenuVersionNotExist        = &Error{ErrCode: ErrCodeMenuVersionNotExist, ErrMsg: "不存在的菜单版本"}
+	ErrMenuNotExist               = &Error{ErrCode: ErrCodeMenuNotExist, ErrMsg: "不存在的菜单数据"}
+	ErrUserNotExist               = &Error{ErrCode: ErrCodeUserNotExist, ErrMsg: "不存在的用户"}
+	ErrParseError                 = &Error{ErrCode: ErrCodeParseError, ErrMsg: "解析JSON/XML内容错误"}
+	ErrAPIUnauthorized            = &Error{ErrCode: ErrCodeAPIUnauthorized, ErrMsg: "api功能未授权，请确认公众号已获得该接口，可以在公众平台官网-开发者中心页中查看接口权限"}
+	ErrUserUnauthorized           = &Error{ErrCode: ErrCodeUserUnauthorized, ErrMsg: "用户未授权该api"}
+	ErrSystemError                = &Error{ErrCode: ErrCodeSystemError, ErrMsg: "系统错误(system error)"}
+	ErrInvalidKfParameter         = &Error{ErrCode: ErrCodeInvalidKfParameter, ErrMsg: "参数错误(invalid parameter)"}
+	ErrInvalidKfAccount           = &Error{ErrCode: ErrCodeInvalidKfAccount, ErrMsg: "无效客服账号(invalid kf_account)"}
+	ErrKfAccountExisted           = &Error{ErrCode: ErrCodeKfAccountExisted, ErrMsg: "客服帐号已存在(kf_account exsited)"}
+	ErrInvalidKfAccountLength     = &Error{ErrCode: ErrCodeInvalidKfAccountLength, ErrMsg: "客服帐号名长度超过限制(仅允许10个英文字符，不包括@及@后的公众号的微信号)(invalid kf_acount length)"}
+	ErrInvalidKfAccountCharacter  = &Error{ErrCode: ErrCodeInvalidKfAccountCharacter, ErrMsg: "客服帐号名包含非法字符(仅允许英文+数字)(illegal character in kf_account)"}
+	ErrKfAccountCountLimit        = &Error{ErrCode: ErrCodeKfAccountCountLimit, ErrMsg: "客服帐号个数超过限制(10个客服账号)(kf_account count exceeded)"}
+	ErrInvalidKfHeadImgType       = &Error{ErrCode: ErrCodeInvalidKfHeadImgType, ErrMsg: "无效头像文件类型(invalid file type)"}
+	ErrInvalidDateFormat          = &Error{ErrCode: ErrCodeInvalidDateFormat, ErrMsg: "日期格式错误"}
+	ErrInvalidDateRange           = &Error{ErrCode: ErrCodeInvalidDateRange, ErrMsg: "日期范围错误"}
+)
+
+var errcodeTable = map[int]errcodeInfo{
+	ErrCodeSystemBusy:                 {"系统繁忙，此时请开发者稍候再试", CategorySystem},
+	ErrCodeOK:                         {"请求成功", CategoryNone},
+	ErrCodeInvalidCredential:          {"获取access_token时AppSecret错误，或者access_token无效。请开发者认真比对AppSecret的正确性，或查看是否正在为恰当的公众号调用接口", CategoryCredential},
+	ErrCodeInvalidGrantType:           {"不合法的凭证类型", CategoryCredential},
+	ErrCodeInvalidOpenId:              {"不合法的OpenID，请开发者确认OpenID（该用户）是否已关注公众号，或是否是其他公众号的OpenID", CategoryParameter},
+	ErrCodeInvalidMediaType:           {"不合法的媒体文件类型", CategoryParameter},
+	ErrCodeInvalidFileType:            {"不合法的文件类型", CategoryParameter},
+	ErrCodeInvalidFileSize:            {"不合法的文件大小", CategoryParameter},
+	ErrCodeInvalidMediaId:             {"不合法的媒体文件id", CategoryParameter},
+	ErrCodeInvalidMessageType:         {"不合法的消息类型", CategoryParameter},
+	ErrCodeInvalidImageSize:           {"不合法的图片文件大小", CategoryParameter},
+	ErrCodeInvalidVoiceSize:           {"不合法的语音文件大小", CategoryParameter},
+	ErrCodeInvalidVideoSize:           {"不合法的视频文件大小", CategoryParameter},
+	ErrCodeInvalidThumbSize:           {"不合法的缩略图文件大小", CategoryParameter},
+	ErrCodeInvalidAppId:               {"不合法的AppID，请开发者检查AppID的正确性，避免异常字符，注意大小写", CategoryCredential},
+	ErrCodeInvalidAccessToken:         {"不合法的access_token，请开发者认真比对access_token的有效性（如是否过期），或查看是否正在为恰当的公众号调用接口", CategoryCredential},
+	ErrCodeInvalidMenuType:            {"不合法的菜单类型", CategoryParameter},
+	ErrCodeInvalidButtonCount:         {"不合法的按钮个数", CategoryParameter},
+	ErrCodeInvalidButtonType:          {"不合法的按钮个数", CategoryParameter},
+	ErrCodeInvalidButtonNameLength:    {"不合法的按钮名字长度", CategoryParameter},
+	ErrCodeInvalidButtonKeyLength:     {"不合法的按钮KEY长度", CategoryParameter},
+	ErrCodeInvalidButtonURLLength:     {"不合法的按钮URL长度", CategoryParameter},
+	ErrCodeInvalidMenuVersion:         {"不合法的菜单版本号", CategoryParameter},
+	ErrCodeInvalidSubMenuLevel:        {"不合法的子菜单级数", CategoryParameter},
+	ErrCodeInvalidSubButtonCount:      {"不合法的子菜单按钮个数", CategoryParameter},
+	ErrCodeInvalidSubButtonType:       {"不合法的子菜单按钮类型", CategoryParameter},
+	ErrCodeInvalidSubButtonNameLength: {"不合法的子菜单按钮名字长度", CategoryParameter},
+	ErrCodeInvalidSubButtonKeyLength:  {"不合法的子菜单按钮KEY长度", CategoryParameter},
+	ErrCodeInvalidSubButtonURLLength:  {"不合法的子菜单按钮URL长度", CategoryParameter},
+	ErrCodeInvalidMenuUser:            {"不合法的自定义菜单使用用户", CategoryParameter},
+	ErrCodeInvalidOAuthCode:           {"不合法的oauth_code", CategoryCredential},
+	ErrCodeInvalidRefreshToken:        {"不合法的refresh_token", CategoryCredential},
+	ErrCodeInvalidOpenIdList:          {"不合法的openid列表", CategoryParameter},
+	ErrCodeInvalidOpenIdListLength:    {"不合法的openid列表长度", CategoryParameter},
+	ErrCodeInvalidCharacter:           {"不合法的请求字符，不能包含\\uxxxx格式的字符", CategoryParameter},
+	ErrCodeInvalidParameter:           {"不合法的参数", CategoryParameter},
+	ErrCodeInvalidRequestFormat:       {"不合法的请求格式", CategoryParameter},
+	ErrCodeInvalidURLLength:           {"不合法的URL长度", CategoryParameter},
+	ErrCodeInvalidGroupId:             {"不合法的分组id", CategoryParameter},
+	ErrCodeInvalidGroupName:           {"分组名字不合法", CategoryParameter},
+	ErrCodeMissingAccessToken:         {"缺少access_token参数", CategoryCredential},
+	ErrCodeMissingAppId:               {"缺少appid参数", CategoryCredential},
+	ErrCodeMissingRefreshToken:        {"缺少refresh_token参数", CategoryCredential},
+	ErrCodeMissingSecret:              {"缺少secret参数", CategoryCredential},
+	ErrCodeMissingMediaData:           {"缺少多媒体文件数据", CategoryParameter},
+	ErrCodeMissingMediaId:             {"缺少media_id参数", CategoryParameter},
+	ErrCodeMissingSubMenu:             {"缺少子菜单数据", CategoryParameter},
+	ErrCodeMissingOAuthCode:           {"缺少oauth code", CategoryCredential},
+	ErrCodeMissingOpenId:              {"缺少openid", CategoryParameter},
+	ErrCodeTimeout:                    {"access_token超时，请检查access_token的有效期，请参考基础支持-获取access_token中，对access_token的详细机制说明", CategoryCredential},
+	ErrCodeRefreshTokenTimeout:        {"refresh_token超时", CategoryCredential},
+	ErrCodeOAuthCodeTimeout:           {"oauth_code超时", CategoryCredential},
+	ErrCodeRequireGET:                 {"需要GET请求", CategoryParameter},
+	ErrCodeRequirePOST:                {"需要POST请求", CategoryParameter},
+	ErrCodeRequireHTTPS:               {"需要HTTPS请求", CategoryParameter},
+	ErrCodeRequireSubscribe:           {"需要接收者关注", CategoryPermission},
+	ErrCodeRequireFriend:              {"需要好友关系", CategoryPermission},
+	ErrCodeUserRefuseToAcceptMsg:      {"用户拒绝接受消息, 用户没有订阅或者取消了订阅", CategoryPermission},
+	ErrCodeEmptyMedia:                 {"多媒体文件为空", CategoryParameter},
+	ErrCodeEmptyPostData:              {"POST的数据包为空", CategoryParameter},
+	ErrCodeEmptyNews:                  {"图文消息内容为空", CategoryParameter},
+	ErrCodeEmptyText:                  {"文本消息内容为空", CategoryParameter},
+	ErrCodeMediaSizeLimit:             {"多媒体文件大小超过限制", CategoryLimit},
+	ErrCodeContentSizeLimit:           {"消息内容超过限制", CategoryLimit},
+	ErrCodeTitleSizeLimit:             {"标题字段超过限制", CategoryLimit},
+	ErrCodeDescriptionSizeLimit:       {"描述字段超过限制", CategoryLimit},
+	ErrCodeURLSizeLimit:               {"链接字段超过限制", CategoryLimit},
+	ErrCodePicURLSizeLimit:            {"图片链接字段超过限制", CategoryLimit},
+	ErrCodeVoicePlaytimeLimit:         {"语音播放时间超过限制", CategoryLimit},
+	ErrCodeArticleCountLimit:          {"图文消息超过限制", CategoryLimit},
+	ErrCodeAPIFreqLimit:               {"接口调用超过限制", CategoryFrequency},
+	ErrCodeMenuCountLimit:             {"创建菜单个数超过限制", CategoryLimit},
+	ErrCodeResponseTimeLimit:          {"回复时间超过限制", CategoryLimit},
+	ErrCodeSystemGroupReadOnly:        {"系统分组，不允许修改", CategoryLimit},
+	ErrCodeGroupNameTooLong:           {"分组名字过长", CategoryLimit},
+	ErrCodeGroupCountLimit:            {"分组数量超过上限", CategoryLimit},
+	ErrCodeResponseCountLimit:         {"客服接口下行条数超过上限", CategoryLimit},
+	ErrCodeMediaNotExist:              {"不存在媒体数据", CategoryNotFound},
+	ErrCodeMenuVersionNotExist:        {"不存在的菜单版本", CategoryNotFound},
+	ErrCodeMenuNotExist:               {"不存在的菜单数据", CategoryNotFound},
+	ErrCodeUserNotExist:               {"不存在的用户", CategoryNotFound},
+	ErrCodeParseError:                 {"解析JSON/XML内容错误", CategoryParameter},
+	ErrCodeAPIUnauthorized:            {"api功能未授权，请确认公众号已获得该接口，可以在公众平台官网-开发者中心页中查看接口权限", CategoryPermission},
+	ErrCodeUserUnauthorized:           {"用户未授权该api", CategoryPermission},
+	ErrCodeSystemError:                {"系统错误(system error)", CategorySystem},
+	ErrCodeInvalidKfParameter:         {"参数错误(invalid parameter)", CategoryParameter},
+	ErrCodeInvalidKfAccount:           {"无效客服账号(invalid kf_account)", CategoryParameter},
+	ErrCodeKfAccountExisted:           {"客服帐号已存在(kf_account exsited)", CategoryParameter},
+	ErrCodeInvalidKfAccountLength:     {"客服帐号名长度超过限制(仅允许10个英文字符，不包括@及@后的公众号的微信号)(invalid kf_acount length)", CategoryParameter},
+	ErrCodeInvalidKfAccountCharacter:  {"客服帐号名包含非法字符(仅允许英文+数字)(illegal character in kf_account)", CategoryParameter},
+	ErrCodeKfAccountCountLimit:        {"客服帐号个数超过限制(10个客服账号)(kf_account count exceeded)", CategoryParameter},
+	ErrCodeInvalidKfHeadImgType:       {"无效头像文件类型(invalid file type)", CategoryParameter},
+	ErrCodeInvalidDateFormat:          {"日期格式错误", CategoryParameter},
+	ErrCodeInvalidDateRange:           {"日期范围错误", CategoryParameter},
+}
@@ -7,11 +7,10 @@ package mp
 
 import "fmt"
 
-const (
-	ErrCodeOK                = 0
-	ErrCodeInvalidCredential = 40001 // access_token 过期（无效）返回这个错误
-	ErrCodeTimeout           = 42001 // access_token 过期（无效）返回这个错误（maybe!!!）
-)
+//go:generate go run ../tools/errcode/main.go -table ../tools/errcode/errcode.txt -o errcode.go
+
+// ErrCodeInvalidCredential(40001) 和 ErrCodeTimeout(42001) 表示 access_token 过期(无效),
+// WechatClient 会刷新 access_token 之后重试.
 
 type Error struct {
 	ErrCode int    `json:"errcode"`
@@ -21,3 +20,78 @@ type Error struct {
 func (e *Error) Error() string {
 	return fmt.Sprintf("errcode: %d, errmsg: %s", e.ErrCode, e.ErrMsg)
 }
+
+// 支持 errors.Is(err, target):
+//  1. target 是 *Error 时比较 ErrCode, 比如 errors.Is(err, ErrInvalidOpenId);
+//  2. target 是 ErrorCategory 时比较分类, 比如 errors.Is(err, CategoryCredential).
+func (e *Error) Is(target error) bool {
+	switch target := target.(type) {
+	case *Error:
+		return target != nil && e.ErrCode == target.ErrCode
+	case ErrorCategory:
+		return ErrCodeCategory(e.ErrCode) == target
+	default:
+		return false
+	}
+}
+
+// 返回码的分类.
+func (e *Error) Category() ErrorCategory {
+	return ErrCodeCategory(e.ErrCode)
+}
+
+// 返回码的分类, 实现了 error 接口, 以便用于 errors.Is 的 target.
+type ErrorCategory int
+
+const (
+	CategoryUnknown    ErrorCategory = iota // 未登记的返回码
+	CategoryNone                            // 请求成功
+	CategorySystem                          // 微信服务器系统错误, 可以稍后重试
+	CategoryCredential                      // access_token, AppSecret 等凭证错误
+	CategoryParameter                       // 请求参数错误
+	CategoryLimit                           // 内容大小, 个数等超过限制
+	CategoryFrequency                       // 接口调用频率超过限制
+	CategoryNotFound                        // 请求的资源不存在
+	CategoryPermission                      // 没有权限, 比如接口未授权, 用户未关注
+)
+
+var errorCategoryNames = [...]string{
+	CategoryUnknown:    "unknown",
+	CategoryNone:       "none",
+	CategorySystem:     "system",
+	CategoryCredential: "credential",
+	CategoryParameter:  "parameter",
+	CategoryLimit:      "limit",
+	CategoryFrequency:  "frequency",
+	CategoryNotFound:   "not found",
+	CategoryPermission: "permission",
+}
+
+func (c ErrorCategory) String() string {
+	if c >= 0 && int(c) < len(errorCategoryNames) {
+		return errorCategoryNames[c]
+	}
+	return fmt.Sprintf("ErrorCategory(%d)", int(c))
+}
+
+func (c ErrorCategory) Error() string {
+	return "wechat error category: " + c.String()
+}
+
+type errcodeInfo struct {
+	Message  string
+	Category ErrorCategory
+}
+
+// 返回全局返回码的说明, 未登记的返回码返回空字符串.
+func ErrCodeMessage(code int) string {
+	return errcodeTable[code].Message
+}
+
+// 返回全局返回码的分类, 未登记的返回码返回 CategoryUnknown.
+func ErrCodeCategory(code int) ErrorCategory {
+	if info, ok := errcodeTable[code]; ok {
+		return info.Category
+	}
+	return CategoryUnknown
+}
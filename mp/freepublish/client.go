@@ -0,0 +1,131 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package freepublish
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+type Client struct {
+	mp.WechatClient
+}
+
+// 创建一个新的 Client.
+//  如果 HttpClient == nil 则默认用 http.DefaultClient
+func NewClient(TokenServer mp.TokenServer, HttpClient *http.Client) *Client {
+	if TokenServer == nil {
+		panic("TokenServer == nil")
+	}
+	if HttpClient == nil {
+		HttpClient = http.DefaultClient
+	}
+
+	return &Client{
+		WechatClient: mp.WechatClient{
+			TokenServer: TokenServer,
+			HttpClient:  HttpClient,
+		},
+	}
+}
+
+// 发布草稿, 返回发布任务的 id.
+//  mediaId: 要发布的草稿的 media_id
+//  NOTE: 返回成功只表示发布任务提交成功, 发布的结果通过 PUBLISHJOBFINISH 事件推送, 或者通过 Get 查询.
+func (clt *Client) Submit(mediaId string, opts ...mp.CallOption) (publishId string, err error) {
+	if mediaId == "" {
+		err = errors.New("empty mediaId")
+		return
+	}
+
+	var request = struct {
+		MediaId string `json:"media_id"`
+	}{
+		MediaId: mediaId,
+	}
+
+	var result struct {
+		mp.Error
+		PublishId string `json:"publish_id"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/freepublish/submit?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	publishId = result.PublishId
+	return
+}
+
+// 查询发布任务的状态.
+func (clt *Client) Get(publishId string, opts ...mp.CallOption) (rst *PublishResult, err error) {
+	if publishId == "" {
+		err = errors.New("empty publishId")
+		return
+	}
+
+	var request = struct {
+		PublishId string `json:"publish_id"`
+	}{
+		PublishId: publishId,
+	}
+
+	var result struct {
+		mp.Error
+		PublishResult
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/freepublish/get?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	result.PublishResult.PublishId = publishId
+	rst = &result.PublishResult
+	return
+}
+
+// 删除发布的文章.
+//  articleId: 发布成功时返回的 article_id;
+//  index:     要删除的文章在图文消息中的位置, 第一篇编号为 1, 该字段不填或填 0 会删除全部文章.
+func (clt *Client) Delete(articleId string, index int, opts ...mp.CallOption) (err error) {
+	if articleId == "" {
+		err = errors.New("empty articleId")
+		return
+	}
+
+	var request = struct {
+		ArticleId string `json:"article_id"`
+		Index     int    `json:"index,omitempty"`
+	}{
+		ArticleId: articleId,
+		Index:     index,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/freepublish/delete?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
@@ -0,0 +1,8 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 发布能力.
+//  草稿通过 Submit 提交发布后, 发布的结果通过 PUBLISHJOBFINISH 事件推送, 可以用 Tracker 等待发布的结果.
+package freepublish
@@ -0,0 +1,84 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package freepublish
+
+import (
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	EventTypePublishJobFinish = "PUBLISHJOBFINISH"
+)
+
+// 发布任务的状态
+const (
+	PublishStatusSuccess        = 0 // 发布成功
+	PublishStatusPublishing     = 1 // 发布中
+	PublishStatusOriginalFail   = 2 // 原创失败
+	PublishStatusFail           = 3 // 常规失败
+	PublishStatusAuditRefused   = 4 // 平台审核不通过
+	PublishStatusDeletedByUser  = 5 // 成功后用户删除所有文章
+	PublishStatusBannedBySystem = 6 // 成功后系统封禁所有文章
+)
+
+// 发布成功的文章
+type ArticleItem struct {
+	Idx        int    `json:"idx"`         // 文章在图文消息中的位置, 从 1 开始
+	ArticleURL string `json:"article_url"` // 文章的永久链接
+}
+
+// 发布任务的结果, Get 的返回值和 PUBLISHJOBFINISH 事件都会转换成 PublishResult.
+type PublishResult struct {
+	PublishId     string `json:"publish_id"`
+	PublishStatus int    `json:"publish_status"` // 发布任务的状态, 见 PublishStatusXXX
+	ArticleId     string `json:"article_id"`     // 发布成功时返回, 用于删除发布的文章
+	ArticleDetail struct {
+		Count int           `json:"count"`
+		Items []ArticleItem `json:"item,omitempty"`
+	} `json:"article_detail"`
+	FailIdx []int `json:"fail_idx,omitempty"` // 原创审核不通过时, 审核不通过的文章编号, 从 1 开始
+}
+
+// 发布任务是否已经结束(不再是发布中).
+func (rst *PublishResult) Finished() bool {
+	return rst.PublishStatus != PublishStatusPublishing
+}
+
+// 是否发布成功.
+func (rst *PublishResult) Succeeded() bool {
+	return rst.PublishStatus == PublishStatusSuccess
+}
+
+// 发布能力, 事件推送发布结果
+type PublishJobFinishEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	mp.CommonMessageHeader
+
+	Event string `xml:"Event" json:"Event"` // 事件信息, 此处为 PUBLISHJOBFINISH
+
+	PublishResult
+}
+
+func GetPublishJobFinishEvent(msg *mp.MixedMessage) *PublishJobFinishEvent {
+	info := &msg.PublishEventInfo
+
+	event := &PublishJobFinishEvent{
+		CommonMessageHeader: msg.CommonMessageHeader,
+		Event:               msg.Event,
+	}
+	event.PublishId = info.PublishId
+	event.PublishStatus = info.PublishStatus
+	event.ArticleId = info.ArticleId
+	event.ArticleDetail.Count = info.ArticleDetail.Count
+	if len(info.ArticleDetail.Items) > 0 {
+		event.ArticleDetail.Items = make([]ArticleItem, len(info.ArticleDetail.Items))
+		for i, item := range info.ArticleDetail.Items {
+			event.ArticleDetail.Items[i] = ArticleItem{Idx: item.Idx, ArticleURL: item.ArticleURL}
+		}
+	}
+	event.FailIdx = info.FailIdx
+	return event
+}
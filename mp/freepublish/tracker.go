@@ -0,0 +1,153 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package freepublish
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+var ErrWaitTimeout = errors.New("wait for publish result timeout")
+
+type publishWaiter struct {
+	done   chan struct{}
+	result *PublishResult
+}
+
+// 发布任务跟踪器, 把 Submit 返回的 publish_id 和 PUBLISHJOBFINISH 事件对应起来.
+//  提交发布以后调用 Wait 等待发布的结果, 同时把收到的事件交给 HandleEvent(一般在 mp.MessageHandler 里调用).
+//  事件可能丢失(比如回调服务器暂时不可用), PollInterval > 0 时等待的过程中会定期调用 Get 查询发布的状态.
+//
+//  NOTE: 只跟踪通过 Submit 提交或者正在 Wait 的发布任务, 其他 publish_id 的事件直接忽略;
+//  事件比 Wait 先到达时结果会暂存起来, 直到 Wait 或者 Forget, 所以不会等待的发布任务请不要用 Tracker.Submit 提交.
+type Tracker struct {
+	clt *Client
+
+	PollInterval time.Duration // 轮询发布状态的间隔, 0 表示只等待事件
+
+	mutex   sync.Mutex
+	waiters map[string]*publishWaiter
+}
+
+// 创建发布任务跟踪器.
+func NewTracker(clt *Client) *Tracker {
+	if clt == nil {
+		panic("nil Client")
+	}
+	return &Tracker{
+		clt:     clt,
+		waiters: make(map[string]*publishWaiter),
+	}
+}
+
+func (t *Tracker) waiter(publishId string) *publishWaiter {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	w := t.waiters[publishId]
+	if w == nil {
+		w = &publishWaiter{done: make(chan struct{})}
+		t.waiters[publishId] = w
+	}
+	return w
+}
+
+// 记录发布的结果, 只处理 Submit 或者 Wait 登记过的发布任务.
+func (t *Tracker) finish(rst *PublishResult) {
+	if !rst.Finished() {
+		return
+	}
+
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	w := t.waiters[rst.PublishId]
+	if w == nil {
+		return // 不是通过 Tracker 跟踪的发布任务, 或者已经 Forget
+	}
+	if w.result == nil {
+		w.result = rst
+		close(w.done)
+	}
+}
+
+// 提交发布并开始跟踪该发布任务.
+func (t *Tracker) Submit(mediaId string, opts ...mp.CallOption) (publishId string, err error) {
+	if publishId, err = t.clt.Submit(mediaId, opts...); err != nil {
+		return
+	}
+	t.waiter(publishId)
+	return
+}
+
+// 处理 PUBLISHJOBFINISH 事件, 其他消息直接忽略, 返回是否为 PUBLISHJOBFINISH 事件.
+func (t *Tracker) HandleEvent(msg *mp.MixedMessage) bool {
+	if msg == nil || msg.Event != EventTypePublishJobFinish {
+		return false
+	}
+	event := GetPublishJobFinishEvent(msg)
+	t.finish(&event.PublishResult)
+	return true
+}
+
+// 等待发布任务结束, 返回发布的结果, 发布失败不会返回 error, 请检查 rst.Succeeded().
+//  timeout: 最长等待的时间, <= 0 表示一直等待; 超时返回 ErrWaitTimeout, 之后还可以继续 Wait.
+func (t *Tracker) Wait(publishId string, timeout time.Duration) (rst *PublishResult, err error) {
+	if publishId == "" {
+		err = errors.New("empty publishId")
+		return
+	}
+	w := t.waiter(publishId)
+
+	var timeoutChan <-chan time.Time
+	if timeout > 0 {
+		timer := time.NewTimer(timeout)
+		defer timer.Stop()
+		timeoutChan = timer.C
+	}
+	var pollChan <-chan time.Time
+	if t.PollInterval > 0 {
+		ticker := time.NewTicker(t.PollInterval)
+		defer ticker.Stop()
+		pollChan = ticker.C
+	}
+
+	for {
+		select {
+		case <-w.done:
+			t.Forget(publishId)
+			rst = w.result
+			return
+		case <-pollChan:
+			// 查询失败时等下一次轮询或者事件
+			if result, err := t.clt.Get(publishId); err == nil {
+				t.finish(result)
+			}
+		case <-timeoutChan:
+			err = ErrWaitTimeout
+			return
+		}
+	}
+}
+
+// 提交发布并等待发布的结果.
+func (t *Tracker) SubmitAndWait(mediaId string, timeout time.Duration, opts ...mp.CallOption) (rst *PublishResult, err error) {
+	publishId, err := t.Submit(mediaId, opts...)
+	if err != nil {
+		return
+	}
+	return t.Wait(publishId, timeout)
+}
+
+// 停止跟踪发布任务, 丢弃暂存的结果; 正在 Wait 的调用会继续等待直到超时.
+func (t *Tracker) Forget(publishId string) {
+	t.mutex.Lock()
+	delete(t.waiters, publishId)
+	t.mutex.Unlock()
+}
@@ -0,0 +1,76 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package freepublish
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+func TestTrackerHandleEvent(t *testing.T) {
+	newEvent := func(publishId string, status int) *mp.MixedMessage {
+		msg := &mp.MixedMessage{}
+		msg.Event = EventTypePublishJobFinish
+		msg.PublishEventInfo.PublishId = publishId
+		msg.PublishEventInfo.PublishStatus = status
+		return msg
+	}
+
+	tests := []struct {
+		name        string
+		registered  bool // 是否已经 Submit 或者 Wait
+		status      int
+		wantWaiters int
+		wantResult  bool
+	}{
+		{"未跟踪的发布任务", false, PublishStatusSuccess, 0, false},
+		{"已跟踪的发布任务", true, PublishStatusSuccess, 1, true},
+		{"已跟踪的发布任务失败", true, PublishStatusFail, 1, true},
+		{"发布中的事件忽略", true, PublishStatusPublishing, 1, false},
+	}
+	for _, tt := range tests {
+		tracker := NewTracker(&Client{})
+		if tt.registered {
+			tracker.waiter("100")
+		}
+		if !tracker.HandleEvent(newEvent("100", tt.status)) {
+			t.Errorf("%s: HandleEvent 返回 false", tt.name)
+		}
+		if n := len(tracker.waiters); n != tt.wantWaiters {
+			t.Errorf("%s: have %d waiters, want %d", tt.name, n, tt.wantWaiters)
+		}
+		if !tt.registered {
+			continue
+		}
+
+		rst, err := tracker.Wait("100", 10*time.Millisecond)
+		if !tt.wantResult {
+			if err != ErrWaitTimeout {
+				t.Errorf("%s: have %v, want ErrWaitTimeout", tt.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		if rst.PublishId != "100" || rst.PublishStatus != tt.status {
+			t.Errorf("%s: have %+v", tt.name, rst)
+		}
+		if len(tracker.waiters) != 0 {
+			t.Errorf("%s: Wait 之后没有 Forget", tt.name)
+		}
+	}
+
+	tracker := NewTracker(&Client{})
+	msg := &mp.MixedMessage{}
+	msg.Event = "subscribe"
+	if tracker.HandleEvent(msg) {
+		t.Error("其他事件: HandleEvent 返回 true")
+	}
+}
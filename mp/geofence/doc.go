@@ -0,0 +1,24 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 地理围栏.
+//  用户允许公众号获取地理位置以后, 微信服务器会定期推送 LOCATION 事件, Monitor 根据上报的位置判断用户是否在
+//  注册的围栏(圆形或者多边形)以内, 并回调 OnEnter, OnInside, OnExit, 用于门店附近推送优惠之类的 O2O 场景.
+//
+//  NOTE: LOCATION 事件的坐标是 GCJ-02(火星坐标), 注册围栏时也要使用 GCJ-02 坐标.
+//
+//  使用示例:
+//      monitor := geofence.NewMonitor()
+//      monitor.Register(&geofence.Geofence{
+//          Name:  "store-001",
+//          Fence: geofence.Circle{Center: geofence.Point{Latitude: 23.1291, Longitude: 113.2644}, Radius: 500},
+//          OnEnter: func(e *geofence.Event) {
+//              // 给 e.OpenId 发送门店的优惠券
+//          },
+//      })
+//
+//      // 在 mp.MessageHandler 里
+//      monitor.HandleEvent(r.MixedMsg)
+package geofence
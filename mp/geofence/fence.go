@@ -0,0 +1,64 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package geofence
+
+import (
+	"math"
+)
+
+const earthRadius = 6371000 // 地球平均半径, 单位为米
+
+// 坐标点
+type Point struct {
+	Latitude  float64 `json:"latitude"`  // 纬度
+	Longitude float64 `json:"longitude"` // 经度
+}
+
+// 两点之间的球面距离(haversine 公式), 单位为米.
+func Distance(a, b Point) float64 {
+	lat1 := a.Latitude * math.Pi / 180
+	lat2 := b.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLng := (b.Longitude - a.Longitude) * math.Pi / 180
+
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLng/2)*math.Sin(dLng/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(math.Min(1, h)))
+}
+
+// 围栏的形状
+type Fence interface {
+	Contains(p Point) bool
+}
+
+// 圆形围栏
+type Circle struct {
+	Center Point   `json:"center"`
+	Radius float64 `json:"radius"` // 半径, 单位为米
+}
+
+func (c Circle) Contains(p Point) bool {
+	return Distance(c.Center, p) <= c.Radius
+}
+
+// 多边形围栏, 顶点按顺序排列(顺时针或者逆时针都可以), 不需要重复第一个顶点.
+//  适用于城市街区这种小范围的区域, 不处理跨越 180 度经线的多边形.
+type Polygon []Point
+
+// 射线法判断点是否在多边形以内, 点在边上的情况不保证结果.
+func (polygon Polygon) Contains(p Point) bool {
+	if len(polygon) < 3 {
+		return false
+	}
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		a, b := polygon[i], polygon[j]
+		if (a.Latitude > p.Latitude) != (b.Latitude > p.Latitude) &&
+			p.Longitude < (b.Longitude-a.Longitude)*(p.Latitude-a.Latitude)/(b.Latitude-a.Latitude)+a.Longitude {
+			inside = !inside
+		}
+	}
+	return inside
+}
@@ -0,0 +1,174 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package geofence
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/message/request"
+)
+
+// 一次位置上报与围栏的匹配结果
+type Event struct {
+	Geofence  *Geofence
+	OpenId    string
+	Point     Point
+	Precision float64   // 位置精度, 单位为米
+	Time      time.Time // 上报的时间
+}
+
+type Callback func(e *Event)
+
+// 注册的围栏
+type Geofence struct {
+	Name  string
+	Fence Fence
+
+	OnEnter  Callback // 用户从围栏外(或者第一次上报)进入围栏时回调, 可以为 nil
+	OnInside Callback // 每次上报的位置在围栏以内时回调, 可以为 nil
+	OnExit   Callback // 用户离开围栏时回调, 可以为 nil
+}
+
+// 根据 LOCATION 事件回调用户所在的围栏.
+//  用户在哪些围栏以内的状态保存在内存中, 用户取消关注时清除.
+type Monitor struct {
+	rwmutex   sync.RWMutex
+	geofences map[string]*Geofence
+
+	mutex  sync.Mutex
+	inside map[string]map[string]struct{} // openid --> 所在的围栏名称
+}
+
+func NewMonitor() *Monitor {
+	return &Monitor{
+		geofences: make(map[string]*Geofence),
+		inside:    make(map[string]map[string]struct{}),
+	}
+}
+
+// 注册围栏, 相同名称的围栏会被替换.
+func (m *Monitor) Register(geofence *Geofence) error {
+	if geofence == nil {
+		return errors.New("nil Geofence")
+	}
+	if geofence.Name == "" {
+		return errors.New("empty geofence name")
+	}
+	if geofence.Fence == nil {
+		return errors.New("nil Fence")
+	}
+	m.rwmutex.Lock()
+	m.geofences[geofence.Name] = geofence
+	m.rwmutex.Unlock()
+	return nil
+}
+
+// 删除围栏, 不会回调 OnExit.
+func (m *Monitor) Unregister(name string) {
+	m.rwmutex.Lock()
+	delete(m.geofences, name)
+	m.rwmutex.Unlock()
+
+	m.mutex.Lock()
+	for _, names := range m.inside {
+		delete(names, name)
+	}
+	m.mutex.Unlock()
+}
+
+// 清除用户的状态, 下次上报的位置在围栏以内时会再次回调 OnEnter.
+func (m *Monitor) Forget(openid string) {
+	m.mutex.Lock()
+	delete(m.inside, openid)
+	m.mutex.Unlock()
+}
+
+// 处理 LOCATION 事件和取消关注事件, 其他消息直接忽略, 一般在 mp.MessageHandler 里调用.
+//  返回用户当前所在的围栏.
+func (m *Monitor) HandleEvent(msg *mp.MixedMessage) (geofences []*Geofence) {
+	if msg.MsgType != request.MsgTypeEvent || msg.FromUserName == "" {
+		return
+	}
+	switch msg.Event {
+	case request.EventTypeLocation:
+		reportTime := time.Now()
+		if msg.CreateTime > 0 {
+			reportTime = time.Unix(msg.CreateTime, 0)
+		}
+		return m.Report(msg.FromUserName, Point{Latitude: msg.Latitude, Longitude: msg.Longitude}, msg.Precision, reportTime)
+	case request.EventTypeUnsubscribe:
+		m.Forget(msg.FromUserName)
+	}
+	return
+}
+
+// 处理一次位置上报, 也可以用于其他途径得到的位置(比如 JS-SDK 的 getLocation).
+//  返回用户当前所在的围栏.
+func (m *Monitor) Report(openid string, p Point, precision float64, reportTime time.Time) (geofences []*Geofence) {
+	var entered, exited []*Geofence
+
+	m.rwmutex.RLock()
+	all := make(map[string]*Geofence, len(m.geofences))
+	for name, geofence := range m.geofences {
+		all[name] = geofence
+		if geofence.Fence.Contains(p) {
+			geofences = append(geofences, geofence)
+		}
+	}
+	m.rwmutex.RUnlock()
+
+	m.mutex.Lock()
+	last := m.inside[openid]
+	current := make(map[string]struct{}, len(geofences))
+	for _, geofence := range geofences {
+		current[geofence.Name] = struct{}{}
+		if _, ok := last[geofence.Name]; !ok {
+			entered = append(entered, geofence)
+		}
+	}
+	for name := range last {
+		if _, ok := current[name]; !ok {
+			if geofence := all[name]; geofence != nil {
+				exited = append(exited, geofence)
+			}
+		}
+	}
+	if len(current) == 0 {
+		delete(m.inside, openid)
+	} else {
+		m.inside[openid] = current
+	}
+	m.mutex.Unlock()
+
+	newEvent := func(geofence *Geofence) *Event {
+		return &Event{
+			Geofence:  geofence,
+			OpenId:    openid,
+			Point:     p,
+			Precision: precision,
+			Time:      reportTime,
+		}
+	}
+	for _, geofence := range exited {
+		if geofence.OnExit != nil {
+			geofence.OnExit(newEvent(geofence))
+		}
+	}
+	for _, geofence := range entered {
+		if geofence.OnEnter != nil {
+			geofence.OnEnter(newEvent(geofence))
+		}
+	}
+	for _, geofence := range geofences {
+		if geofence.OnInside != nil {
+			geofence.OnInside(newEvent(geofence))
+		}
+	}
+	return
+}
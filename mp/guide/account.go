@@ -0,0 +1,171 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package guide
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 顾问信息
+type Account struct {
+	Account    string `json:"guide_account"`              // 顾问微信号
+	OpenId     string `json:"guide_openid"`               // 顾问 openid 或者 unionid
+	HeadImgURL string `json:"guide_headimgurl,omitempty"` // 顾问头像
+	Nickname   string `json:"guide_nickname,omitempty"`   // 顾问昵称
+	Status     int    `json:"status,omitempty"`           // 顾问状态, 1 未验证, 2 验证中, 3 通过, 4 失败, 5 已取消绑定
+	CreateTime int64  `json:"create_time,omitempty"`      // 创建时间
+}
+
+// 添加顾问.
+//  account 或 openId 二选一
+//  headImgURL, nickname 可以为空, 为空时使用微信头像和昵称
+func (clt *Client) AddAccount(account, openId, headImgURL, nickname string, opts ...mp.CallOption) (err error) {
+	if account == "" && openId == "" {
+		return errors.New("account and openId cannot both be empty")
+	}
+
+	var request = struct {
+		Account    string `json:"guide_account,omitempty"`
+		OpenId     string `json:"guide_openid,omitempty"`
+		HeadImgURL string `json:"guide_headimgurl,omitempty"`
+		Nickname   string `json:"guide_nickname,omitempty"`
+	}{
+		Account:    account,
+		OpenId:     openId,
+		HeadImgURL: headImgURL,
+		Nickname:   nickname,
+	}
+	return clt.postGuide("https://api.weixin.qq.com/cgi-bin/guide/addguideacct?access_token=", &request, opts...)
+}
+
+// 修改顾问的昵称或头像.
+func (clt *Client) UpdateAccount(account, openId, headImgURL, nickname string, opts ...mp.CallOption) (err error) {
+	if account == "" && openId == "" {
+		return errors.New("account and openId cannot both be empty")
+	}
+
+	var request = struct {
+		Account    string `json:"guide_account,omitempty"`
+		OpenId     string `json:"guide_openid,omitempty"`
+		HeadImgURL string `json:"guide_headimgurl,omitempty"`
+		Nickname   string `json:"guide_nickname,omitempty"`
+	}{
+		Account:    account,
+		OpenId:     openId,
+		HeadImgURL: headImgURL,
+		Nickname:   nickname,
+	}
+	return clt.postGuide("https://api.weixin.qq.com/cgi-bin/guide/updateguideacct?access_token=", &request, opts...)
+}
+
+// 获取顾问信息.
+//  account 或 openId 二选一
+func (clt *Client) GetAccount(account, openId string, opts ...mp.CallOption) (info *Account, err error) {
+	if account == "" && openId == "" {
+		err = errors.New("account and openId cannot both be empty")
+		return
+	}
+
+	var request = struct {
+		Account string `json:"guide_account,omitempty"`
+		OpenId  string `json:"guide_openid,omitempty"`
+	}{
+		Account: account,
+		OpenId:  openId,
+	}
+
+	var result struct {
+		mp.Error
+		Account
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/guide/getguideacct?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	info = &result.Account
+	return
+}
+
+// 删除顾问, 删除后顾问与客户的绑定关系也会被删除.
+//  account 或 openId 二选一
+func (clt *Client) DeleteAccount(account, openId string, opts ...mp.CallOption) (err error) {
+	if account == "" && openId == "" {
+		return errors.New("account and openId cannot both be empty")
+	}
+
+	var request = struct {
+		Account string `json:"guide_account,omitempty"`
+		OpenId  string `json:"guide_openid,omitempty"`
+	}{
+		Account: account,
+		OpenId:  openId,
+	}
+	return clt.postGuide("https://api.weixin.qq.com/cgi-bin/guide/delguideacct?access_token=", &request, opts...)
+}
+
+// 获取服务号的顾问列表.
+//  page: 分页页数, 从0开始
+//  num:  每页数量
+func (clt *Client) ListAccount(page, num int, opts ...mp.CallOption) (total int, accounts []Account, err error) {
+	if page < 0 {
+		err = errors.New("page should not be less than 0")
+		return
+	}
+	if num <= 0 {
+		err = errors.New("num should be greater than 0")
+		return
+	}
+
+	var request = struct {
+		Page int `json:"page"`
+		Num  int `json:"num"`
+	}{
+		Page: page,
+		Num:  num,
+	}
+
+	var result struct {
+		mp.Error
+		TotalNum int       `json:"total_num"`
+		List     []Account `json:"list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/guide/getguideacctlist?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	total = result.TotalNum
+	accounts = result.List
+	return
+}
+
+// 只返回 mp.Error 的接口的通用处理
+func (clt *Client) postGuide(incompleteURL string, request interface{}, opts ...mp.CallOption) (err error) {
+	var result mp.Error
+
+	if err = clt.PostJSON(incompleteURL, request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
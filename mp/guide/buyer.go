@@ -0,0 +1,210 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package guide
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 要绑定到顾问的客户
+type Buyer struct {
+	OpenId   string `json:"openid"`                   // 客户 openid
+	Nickname string `json:"buyer_nickname,omitempty"` // 客户昵称
+}
+
+// 批量操作中单个客户的处理结果
+type BuyerResult struct {
+	OpenId  string `json:"openid"`
+	ErrCode int    `json:"errcode"`
+	ErrMsg  string `json:"errmsg"`
+}
+
+// 为顾问分配客户, 一次最多200个.
+//  account 或 openId 二选一, 表示顾问
+func (clt *Client) AddBuyerRelation(account, openId string, buyers []Buyer, opts ...mp.CallOption) (results []BuyerResult, err error) {
+	if account == "" && openId == "" {
+		err = errors.New("account and openId cannot both be empty")
+		return
+	}
+	if len(buyers) == 0 {
+		err = errors.New("empty buyers")
+		return
+	}
+
+	var request = struct {
+		Account   string  `json:"guide_account,omitempty"`
+		OpenId    string  `json:"guide_openid,omitempty"`
+		BuyerList []Buyer `json:"buyer_list"`
+	}{
+		Account:   account,
+		OpenId:    openId,
+		BuyerList: buyers,
+	}
+	return clt.postBuyerList("https://api.weixin.qq.com/cgi-bin/guide/addguidebuyerrelation?access_token=", &request, opts...)
+}
+
+// 为顾问移除客户, 一次最多200个.
+func (clt *Client) DeleteBuyerRelation(account, openId string, openIdList []string, opts ...mp.CallOption) (results []BuyerResult, err error) {
+	if account == "" && openId == "" {
+		err = errors.New("account and openId cannot both be empty")
+		return
+	}
+	if len(openIdList) == 0 {
+		err = errors.New("empty openIdList")
+		return
+	}
+
+	var request = struct {
+		Account    string   `json:"guide_account,omitempty"`
+		OpenId     string   `json:"guide_openid,omitempty"`
+		OpenIdList []string `json:"openid_list"`
+	}{
+		Account:    account,
+		OpenId:     openId,
+		OpenIdList: openIdList,
+	}
+	return clt.postBuyerList("https://api.weixin.qq.com/cgi-bin/guide/delguidebuyerrelation?access_token=", &request, opts...)
+}
+
+// 将客户从一个顾问转移到另一个顾问, 一次最多200个.
+//  oldAccount, newAccount: 原顾问和新顾问的微信号
+func (clt *Client) RebindBuyer(oldAccount, newAccount string, openIdList []string, opts ...mp.CallOption) (results []BuyerResult, err error) {
+	if oldAccount == "" || newAccount == "" {
+		err = errors.New("empty oldAccount or newAccount")
+		return
+	}
+	if len(openIdList) == 0 {
+		err = errors.New("empty openIdList")
+		return
+	}
+
+	var request = struct {
+		OldAccount string   `json:"old_guide_account"`
+		NewAccount string   `json:"new_guide_account"`
+		OpenIdList []string `json:"openid_list"`
+	}{
+		OldAccount: oldAccount,
+		NewAccount: newAccount,
+		OpenIdList: openIdList,
+	}
+	return clt.postBuyerList("https://api.weixin.qq.com/cgi-bin/guide/rebindguideacctforbuyer?access_token=", &request, opts...)
+}
+
+func (clt *Client) postBuyerList(incompleteURL string, request interface{}, opts ...mp.CallOption) (results []BuyerResult, err error) {
+	var result struct {
+		mp.Error
+		List []BuyerResult `json:"list"`
+	}
+
+	if err = clt.PostJSON(incompleteURL, request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	results = result.List
+	return
+}
+
+// 顾问与客户的绑定关系
+type BuyerRelation struct {
+	Account    string `json:"guide_account,omitempty"` // 顾问微信号, 按客户查询时返回
+	OpenId     string `json:"openid"`                  // 客户 openid
+	Nickname   string `json:"buyer_nickname"`          // 客户昵称
+	CreateTime int64  `json:"create_time"`             // 绑定时间
+}
+
+// 获取顾问的客户列表.
+//  page: 分页页数, 从0开始
+//  num:  每页数量
+func (clt *Client) ListBuyerRelation(account, openId string, page, num int, opts ...mp.CallOption) (total int, relations []BuyerRelation, err error) {
+	if account == "" && openId == "" {
+		err = errors.New("account and openId cannot both be empty")
+		return
+	}
+	if page < 0 {
+		err = errors.New("page should not be less than 0")
+		return
+	}
+	if num <= 0 {
+		err = errors.New("num should be greater than 0")
+		return
+	}
+
+	var request = struct {
+		Account string `json:"guide_account,omitempty"`
+		OpenId  string `json:"guide_openid,omitempty"`
+		Page    int    `json:"page"`
+		Num     int    `json:"num"`
+	}{
+		Account: account,
+		OpenId:  openId,
+		Page:    page,
+		Num:     num,
+	}
+
+	var result struct {
+		mp.Error
+		TotalNum int             `json:"total_num"`
+		List     []BuyerRelation `json:"list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/guide/getguidebuyerrelationlist?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	total = result.TotalNum
+	relations = result.List
+	return
+}
+
+// 查询客户所属的顾问.
+//  buyerOpenId: 客户 openid
+func (clt *Client) GetBuyerRelationByBuyer(buyerOpenId string, opts ...mp.CallOption) (relation *BuyerRelation, err error) {
+	if buyerOpenId == "" {
+		err = errors.New("empty buyerOpenId")
+		return
+	}
+
+	var request = struct {
+		OpenId string `json:"openid"`
+	}{
+		OpenId: buyerOpenId,
+	}
+
+	var result struct {
+		mp.Error
+		Account    string `json:"guide_account"`
+		Nickname   string `json:"buyer_nickname"`
+		CreateTime int64  `json:"create_time"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/guide/getguidebuyerrelationbybuyer?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	relation = &BuyerRelation{
+		Account:    result.Account,
+		OpenId:     buyerOpenId,
+		Nickname:   result.Nickname,
+		CreateTime: result.CreateTime,
+	}
+	return
+}
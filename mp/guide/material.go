@@ -0,0 +1,252 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package guide
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 素材类型, 顾问素材按类型分组
+const (
+	MaterialTypeGuide   = 0 // 顾问自己的素材
+	MaterialTypeAccount = 1 // 服务号的素材
+)
+
+// 小程序卡片素材
+type CardMaterial struct {
+	Title   string `json:"title"`    // 小程序卡片标题
+	AppId   string `json:"appid"`    // 小程序 appid
+	Path    string `json:"path"`     // 小程序路径
+	MediaId string `json:"media_id"` // 卡片图片的永久素材 media_id, 设置时使用
+}
+
+// 添加小程序卡片素材.
+//  materialType: MaterialTypeGuide 或 MaterialTypeAccount
+func (clt *Client) SetCardMaterial(materialType int, card *CardMaterial, opts ...mp.CallOption) (err error) {
+	if card == nil {
+		return errors.New("nil CardMaterial")
+	}
+
+	var request = struct {
+		Type    int    `json:"type"`
+		Title   string `json:"title"`
+		AppId   string `json:"appid"`
+		Path    string `json:"path"`
+		MediaId string `json:"media_id"`
+	}{
+		Type:    materialType,
+		Title:   card.Title,
+		AppId:   card.AppId,
+		Path:    card.Path,
+		MediaId: card.MediaId,
+	}
+	return clt.postGuide("https://api.weixin.qq.com/cgi-bin/guide/setguidecardmaterial?access_token=", &request, opts...)
+}
+
+// 查询小程序卡片素材.
+func (clt *Client) GetCardMaterial(materialType int, opts ...mp.CallOption) (cards []CardMaterial, err error) {
+	var request = struct {
+		Type int `json:"type"`
+	}{
+		Type: materialType,
+	}
+
+	var result struct {
+		mp.Error
+		CardList []CardMaterial `json:"card_list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/guide/getguidecardmaterial?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	cards = result.CardList
+	return
+}
+
+// 删除小程序卡片素材.
+func (clt *Client) DeleteCardMaterial(materialType int, title, appId, path string, opts ...mp.CallOption) (err error) {
+	var request = struct {
+		Type  int    `json:"type"`
+		Title string `json:"title"`
+		AppId string `json:"appid"`
+		Path  string `json:"path"`
+	}{
+		Type:  materialType,
+		Title: title,
+		AppId: appId,
+		Path:  path,
+	}
+	return clt.postGuide("https://api.weixin.qq.com/cgi-bin/guide/delguidecardmaterial?access_token=", &request, opts...)
+}
+
+// 添加图片素材.
+//  mediaId: 图片的永久素材 media_id
+func (clt *Client) SetImageMaterial(materialType int, mediaId string, opts ...mp.CallOption) (err error) {
+	if mediaId == "" {
+		return errors.New("empty mediaId")
+	}
+
+	var request = struct {
+		Type    int    `json:"type"`
+		MediaId string `json:"media_id"`
+	}{
+		Type:    materialType,
+		MediaId: mediaId,
+	}
+	return clt.postGuide("https://api.weixin.qq.com/cgi-bin/guide/setguideimagematerial?access_token=", &request, opts...)
+}
+
+// 分页查询图片素材, 返回素材总数和图片 URL 列表.
+//  start: 起始位置, 从0开始
+//  num:   查询数量
+func (clt *Client) GetImageMaterial(materialType, start, num int, opts ...mp.CallOption) (total int, picURLs []string, err error) {
+	if start < 0 {
+		err = errors.New("start should not be less than 0")
+		return
+	}
+	if num <= 0 {
+		err = errors.New("num should be greater than 0")
+		return
+	}
+
+	var request = struct {
+		Type  int `json:"type"`
+		Start int `json:"start"`
+		Num   int `json:"num"`
+	}{
+		Type:  materialType,
+		Start: start,
+		Num:   num,
+	}
+
+	var result struct {
+		mp.Error
+		TotalNum  int `json:"total_num"`
+		ModelList []struct {
+			PicURL string `json:"picurl"`
+		} `json:"model_list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/guide/getguideimagematerial?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	total = result.TotalNum
+	picURLs = make([]string, 0, len(result.ModelList))
+	for _, v := range result.ModelList {
+		picURLs = append(picURLs, v.PicURL)
+	}
+	return
+}
+
+// 删除图片素材.
+//  picURL: GetImageMaterial 返回的图片 URL
+func (clt *Client) DeleteImageMaterial(materialType int, picURL string, opts ...mp.CallOption) (err error) {
+	if picURL == "" {
+		return errors.New("empty picURL")
+	}
+
+	var request = struct {
+		Type   int    `json:"type"`
+		PicURL string `json:"picurl"`
+	}{
+		Type:   materialType,
+		PicURL: picURL,
+	}
+	return clt.postGuide("https://api.weixin.qq.com/cgi-bin/guide/delguideimagematerial?access_token=", &request, opts...)
+}
+
+// 添加文字素材.
+//  word: 文字素材内容, 不超过300个字
+func (clt *Client) SetWordMaterial(materialType int, word string, opts ...mp.CallOption) (err error) {
+	if word == "" {
+		return errors.New("empty word")
+	}
+
+	var request = struct {
+		Type int    `json:"type"`
+		Word string `json:"word"`
+	}{
+		Type: materialType,
+		Word: word,
+	}
+	return clt.postGuide("https://api.weixin.qq.com/cgi-bin/guide/setguidewordmaterial?access_token=", &request, opts...)
+}
+
+// 分页查询文字素材, 返回素材总数和文字列表.
+func (clt *Client) GetWordMaterial(materialType, start, num int, opts ...mp.CallOption) (total int, words []string, err error) {
+	if start < 0 {
+		err = errors.New("start should not be less than 0")
+		return
+	}
+	if num <= 0 {
+		err = errors.New("num should be greater than 0")
+		return
+	}
+
+	var request = struct {
+		Type  int `json:"type"`
+		Start int `json:"start"`
+		Num   int `json:"num"`
+	}{
+		Type:  materialType,
+		Start: start,
+		Num:   num,
+	}
+
+	var result struct {
+		mp.Error
+		TotalNum int `json:"total_num"`
+		WordList []struct {
+			Word string `json:"word"`
+		} `json:"word_list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/guide/getguidewordmaterial?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	total = result.TotalNum
+	words = make([]string, 0, len(result.WordList))
+	for _, v := range result.WordList {
+		words = append(words, v.Word)
+	}
+	return
+}
+
+// 删除文字素材.
+func (clt *Client) DeleteWordMaterial(materialType int, word string, opts ...mp.CallOption) (err error) {
+	if word == "" {
+		return errors.New("empty word")
+	}
+
+	var request = struct {
+		Type int    `json:"type"`
+		Word string `json:"word"`
+	}{
+		Type: materialType,
+		Word: word,
+	}
+	return clt.postGuide("https://api.weixin.qq.com/cgi-bin/guide/delguidewordmaterial?access_token=", &request, opts...)
+}
@@ -0,0 +1,209 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package guide
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 客户标签类型及其可选值
+type TagOption struct {
+	TagName   string   `json:"tag_name"`
+	TagValues []string `json:"tag_values"`
+}
+
+// 新建客户标签类型, 最多4类标签, 每类标签最多100个可选值.
+func (clt *Client) NewTagOption(tagName string, tagValues []string, opts ...mp.CallOption) (err error) {
+	if tagName == "" {
+		return errors.New("empty tagName")
+	}
+	if len(tagValues) == 0 {
+		return errors.New("empty tagValues")
+	}
+
+	var request = struct {
+		TagName   string   `json:"tag_name"`
+		TagValues []string `json:"tag_values"`
+	}{
+		TagName:   tagName,
+		TagValues: tagValues,
+	}
+	return clt.postGuide("https://api.weixin.qq.com/cgi-bin/guide/newguidetagoption?access_token=", &request, opts...)
+}
+
+// 为客户标签类型添加可选值.
+func (clt *Client) AddTagOption(tagName string, tagValues []string, opts ...mp.CallOption) (err error) {
+	if tagName == "" {
+		return errors.New("empty tagName")
+	}
+	if len(tagValues) == 0 {
+		return errors.New("empty tagValues")
+	}
+
+	var request = struct {
+		TagName   string   `json:"tag_name"`
+		TagValues []string `json:"tag_values"`
+	}{
+		TagName:   tagName,
+		TagValues: tagValues,
+	}
+	return clt.postGuide("https://api.weixin.qq.com/cgi-bin/guide/addguidetagoption?access_token=", &request, opts...)
+}
+
+// 删除客户标签类型.
+func (clt *Client) DeleteTagOption(tagName string, opts ...mp.CallOption) (err error) {
+	if tagName == "" {
+		return errors.New("empty tagName")
+	}
+
+	var request = struct {
+		TagName string `json:"tag_name"`
+	}{
+		TagName: tagName,
+	}
+	return clt.postGuide("https://api.weixin.qq.com/cgi-bin/guide/delguidetagoption?access_token=", &request, opts...)
+}
+
+// 获取全部客户标签类型.
+func (clt *Client) GetTagOption(opts ...mp.CallOption) (options []TagOption, err error) {
+	var request struct{}
+
+	var result struct {
+		mp.Error
+		Options []TagOption `json:"options"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/guide/getguidetagoption?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	options = result.Options
+	return
+}
+
+// 为顾问的客户设置标签, 一次最多200个客户.
+//  account 或 openId 二选一, 表示顾问
+//  tagValue: 标签可选值
+func (clt *Client) AddBuyerTag(account, openId, tagValue string, openIdList []string, opts ...mp.CallOption) (results []BuyerResult, err error) {
+	return clt.buyerTag("https://api.weixin.qq.com/cgi-bin/guide/addguidebuyertag?access_token=", account, openId, tagValue, openIdList, opts...)
+}
+
+// 删除顾问的客户的标签, 一次最多200个客户.
+func (clt *Client) DeleteBuyerTag(account, openId, tagValue string, openIdList []string, opts ...mp.CallOption) (results []BuyerResult, err error) {
+	return clt.buyerTag("https://api.weixin.qq.com/cgi-bin/guide/delguidebuyertag?access_token=", account, openId, tagValue, openIdList, opts...)
+}
+
+func (clt *Client) buyerTag(incompleteURL, account, openId, tagValue string, openIdList []string, opts ...mp.CallOption) (results []BuyerResult, err error) {
+	if account == "" && openId == "" {
+		err = errors.New("account and openId cannot both be empty")
+		return
+	}
+	if tagValue == "" {
+		err = errors.New("empty tagValue")
+		return
+	}
+	if len(openIdList) == 0 {
+		err = errors.New("empty openIdList")
+		return
+	}
+
+	var request = struct {
+		Account    string   `json:"guide_account,omitempty"`
+		OpenId     string   `json:"guide_openid,omitempty"`
+		TagValue   string   `json:"tag_value"`
+		OpenIdList []string `json:"openid_list"`
+	}{
+		Account:    account,
+		OpenId:     openId,
+		TagValue:   tagValue,
+		OpenIdList: openIdList,
+	}
+	return clt.postBuyerList(incompleteURL, &request, opts...)
+}
+
+// 查询顾问的客户的标签.
+func (clt *Client) GetBuyerTag(account, openId, buyerOpenId string, opts ...mp.CallOption) (tagValues []string, err error) {
+	if account == "" && openId == "" {
+		err = errors.New("account and openId cannot both be empty")
+		return
+	}
+	if buyerOpenId == "" {
+		err = errors.New("empty buyerOpenId")
+		return
+	}
+
+	var request = struct {
+		Account     string `json:"guide_account,omitempty"`
+		OpenId      string `json:"guide_openid,omitempty"`
+		BuyerOpenId string `json:"openid"`
+	}{
+		Account:     account,
+		OpenId:      openId,
+		BuyerOpenId: buyerOpenId,
+	}
+
+	var result struct {
+		mp.Error
+		TagValues []string `json:"tag_values"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/guide/getguidebuyertag?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	tagValues = result.TagValues
+	return
+}
+
+// 根据标签值筛选顾问的客户.
+//  pushCount: 筛选当天推送次数小于该值的客户, 0 表示不限制
+func (clt *Client) QueryBuyerByTag(account, openId string, pushCount int, tagValues []string, opts ...mp.CallOption) (openIdList []string, err error) {
+	if account == "" && openId == "" {
+		err = errors.New("account and openId cannot both be empty")
+		return
+	}
+
+	var request = struct {
+		Account   string   `json:"guide_account,omitempty"`
+		OpenId    string   `json:"guide_openid,omitempty"`
+		PushCount int      `json:"push_count,omitempty"`
+		TagValues []string `json:"tag_values,omitempty"`
+	}{
+		Account:   account,
+		OpenId:    openId,
+		PushCount: pushCount,
+		TagValues: tagValues,
+	}
+
+	var result struct {
+		mp.Error
+		OpenIdList []string `json:"openid_list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/guide/queryguidebuyerbytag?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	openIdList = result.OpenIdList
+	return
+}
@@ -0,0 +1,136 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// 熔断器的状态
+const (
+	CircuitClosed   = "closed"    // 正常
+	CircuitOpen     = "open"      // 熔断中, 请求直接失败
+	CircuitHalfOpen = "half-open" // 试探恢复中
+)
+
+// access_token 的健康状态
+type TokenHealth struct {
+	Fresh               bool      `json:"fresh"`                // access_token 是否还没有过期
+	LastRefreshTime     time.Time `json:"last_refresh_time"`    // 最后一次成功刷新的时间, 零值表示还没有成功过
+	ExpiresAt           time.Time `json:"expires_at"`           // 当前 access_token 的过期时间
+	ConsecutiveFailures int       `json:"consecutive_failures"` // 最后一次成功之后连续失败的次数
+	LastError           string    `json:"last_error,omitempty"` // 最后一次刷新失败的错误
+}
+
+// SDK 内部的健康状态, 用于存活/就绪探针.
+//  Circuits 和 Queues 由各组件通过 HealthReporter 填写, 没有对应组件时为空.
+type Health struct {
+	Healthy   bool              `json:"healthy"`
+	CheckedAt time.Time         `json:"checked_at"`
+	Token     *TokenHealth      `json:"token,omitempty"`    // TokenServer 没有统计信息(没有 Metrics 方法)时为 nil
+	Circuits  map[string]string `json:"circuits,omitempty"` // 组件名 --> 熔断器的状态, 见 CircuitXXX
+	Queues    map[string]int    `json:"queues,omitempty"`   // 组件名 --> 排队等待的数量
+	Problems  []string          `json:"problems,omitempty"` // 导致 Healthy == false 的原因
+}
+
+// 设置熔断器的状态, 状态不是 CircuitClosed 时标记为不健康.
+func (h *Health) SetCircuit(name, state string) {
+	if h.Circuits == nil {
+		h.Circuits = make(map[string]string)
+	}
+	h.Circuits[name] = state
+	if state != CircuitClosed {
+		h.AddProblem("circuit " + name + " is " + state)
+	}
+}
+
+// 设置队列的长度.
+func (h *Health) SetQueue(name string, depth int) {
+	if h.Queues == nil {
+		h.Queues = make(map[string]int)
+	}
+	h.Queues[name] = depth
+}
+
+// 标记为不健康, 并记录原因.
+func (h *Health) AddProblem(problem string) {
+	h.Healthy = false
+	h.Problems = append(h.Problems, problem)
+}
+
+// 向 Health 报告自己状态的组件, 比如熔断器, 队列, 并发限制.
+type HealthReporter interface {
+	ReportHealth(h *Health)
+}
+
+type HealthReporterFunc func(h *Health)
+
+func (fn HealthReporterFunc) ReportHealth(h *Health) {
+	fn(h)
+}
+
+// 有统计信息的 TokenServer, 比如 *DefaultTokenServer.
+type TokenMetricsReporter interface {
+	Metrics() TokenMetrics
+}
+
+// 返回 SDK 内部的健康状态.
+//  TokenServer 实现了 TokenMetricsReporter 时检查 access_token 是否过期, 然后依次调用 clt.HealthReporters.
+func (clt *WechatClient) Health() (h Health) {
+	h = Health{
+		Healthy:   true,
+		CheckedAt: time.Now(),
+	}
+
+	if reporter, ok := clt.TokenServer.(TokenMetricsReporter); ok {
+		metrics := reporter.Metrics()
+		h.Token = &TokenHealth{
+			Fresh:               !metrics.LastRefreshTime.IsZero() && metrics.ExpiryMargin > 0,
+			LastRefreshTime:     metrics.LastRefreshTime,
+			ExpiresAt:           metrics.ExpiresAt,
+			ConsecutiveFailures: metrics.ConsecutiveFailures,
+		}
+		if metrics.LastError != nil {
+			h.Token.LastError = metrics.LastError.Error()
+		}
+		if !h.Token.Fresh {
+			if metrics.LastRefreshTime.IsZero() && metrics.LastError == nil {
+				h.AddProblem("access_token has not been fetched yet")
+			} else {
+				h.AddProblem("access_token is expired")
+			}
+		}
+	}
+
+	for _, reporter := range clt.HealthReporters {
+		if reporter != nil {
+			reporter.ReportHealth(&h)
+		}
+	}
+	return
+}
+
+// 返回以 JSON 格式输出 health() 结果的 http.Handler, 不健康时状态码为 503.
+//  比如 http.Handle("/healthz", mp.HealthHandler(clt.Health)).
+func HealthHandler(health func() Health) http.Handler {
+	if health == nil {
+		panic("nil health func")
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		h := health()
+
+		w.Header().Set("Content-Type", "application/json; charset=utf-8")
+		w.Header().Set("Cache-Control", "no-cache")
+		if h.Healthy {
+			w.WriteHeader(http.StatusOK)
+		} else {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+		json.NewEncoder(w).Encode(&h)
+	})
+}
@@ -33,28 +33,78 @@ import (
 //     TLSHandshakeTimeout: 10 * time.Second,
 // }
 
-// 一般请求的 http.Client
-var TextHttpClient = &http.Client{
-	Transport: &http.Transport{
-		Proxy: http.ProxyFromEnvironment,
-		Dial: (&net.Dialer{
-			Timeout:   5 * time.Second,
-			KeepAlive: 30 * time.Second,
-		}).Dial,
-		TLSHandshakeTimeout: 5 * time.Second,
-	},
-	Timeout: 15 * time.Second,
+// http 请求各阶段的超时时间, 0 表示不限制.
+type Timeouts struct {
+	Dial           time.Duration // 建立 TCP 连接
+	TLSHandshake   time.Duration // TLS 握手
+	ResponseHeader time.Duration // 请求(包括 body)发送完以后等待响应头
+	Total          time.Duration // 整个请求, 包括读取响应的 body
 }
 
-// 多媒体上传下载请求的 http.Client
-var MediaHttpClient = &http.Client{
-	Transport: &http.Transport{
+// 各类接口推荐的超时时间
+var (
+	// 一般的 JSON 接口
+	TextTimeouts = Timeouts{
+		Dial:           5 * time.Second,
+		TLSHandshake:   5 * time.Second,
+		ResponseHeader: 10 * time.Second,
+		Total:          15 * time.Second,
+	}
+
+	// 多媒体上传, 发送文件的时间计入 Total, 发送完以后微信服务器处理文件可能比较慢
+	MediaUploadTimeouts = Timeouts{
+		Dial:           5 * time.Second,
+		TLSHandshake:   5 * time.Second,
+		ResponseHeader: 60 * time.Second,
+		Total:          300 * time.Second, // 因为目前微信支持最大的文件是 10MB, 请求超时时间保守设置为 300 秒
+	}
+
+	// 多媒体下载, 响应头应该很快返回, 读取文件的时间计入 Total
+	MediaDownloadTimeouts = Timeouts{
+		Dial:           5 * time.Second,
+		TLSHandshake:   5 * time.Second,
+		ResponseHeader: 15 * time.Second,
+		Total:          300 * time.Second,
+	}
+)
+
+// 返回按照 t 设置超时时间的 http.Transport, 其他设置同 http.DefaultTransport.
+func (t Timeouts) Transport() *http.Transport {
+	return &http.Transport{
 		Proxy: http.ProxyFromEnvironment,
 		Dial: (&net.Dialer{
-			Timeout:   5 * time.Second,
+			Timeout:   t.Dial,
 			KeepAlive: 30 * time.Second,
 		}).Dial,
-		TLSHandshakeTimeout: 5 * time.Second,
-	},
-	Timeout: 300 * time.Second, // 因为目前微信支持最大的文件是 10MB, 请求超时时间保守设置为 300 秒
+		TLSHandshakeTimeout:   t.TLSHandshake,
+		ResponseHeaderTimeout: t.ResponseHeader,
+	}
+}
+
+// 创建按照 t 设置超时时间的 http.Client.
+func NewHttpClient(t Timeouts) *http.Client {
+	return &http.Client{
+		Transport: t.Transport(),
+		Timeout:   t.Total,
+	}
+}
+
+// 一般请求的 http.Client
+var TextHttpClient = NewHttpClient(TextTimeouts)
+
+// 多媒体上传下载请求的 http.Client, 上传和下载使用不同的 http.Client 时, 下载可以用 NewHttpClient(MediaDownloadTimeouts)
+var MediaHttpClient = NewHttpClient(MediaUploadTimeouts)
+
+// 返回多媒体上传下载使用的 http.Client, 如果 clt.MediaHttpClient == nil 则返回 clt.HttpClient;
+// 设置了 clt.RequestDecorator 时返回的 http.Client 会先用它修改请求.
+func (clt *WechatClient) MediaClient() *http.Client {
+	o := clt.callOptions(nil)
+	return o.httpClient(clt.mediaClient())
+}
+
+func (clt *WechatClient) mediaClient() *http.Client {
+	if clt.MediaHttpClient != nil {
+		return clt.MediaHttpClient
+	}
+	return clt.HttpClient
 }
@@ -0,0 +1,196 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// 接口的幂等性, 决定网络错误时能否安全的重试.
+type Idempotency int
+
+const (
+	IdempotencyUnknown Idempotency = iota // 未登记的接口, 按照非幂等处理
+	Idempotent                            // 幂等的接口(一般是查询接口), 重复请求没有副作用
+	NonIdempotent                         // 非幂等的接口(比如发送客服消息, 群发), 重复请求会重复发送
+)
+
+// 查询接口的幂等性.
+//  key 为 URL 的 path, 以 / 结尾的表示前缀, 比如 "/datacube/" 表示所有的数据统计接口.
+var idempotencyRegistry = struct {
+	sync.RWMutex
+	m map[string]Idempotency
+}{
+	m: map[string]Idempotency{
+		// 查询接口
+		"/cgi-bin/getcallbackip":                     Idempotent,
+		"/cgi-bin/user/get":                          Idempotent,
+		"/cgi-bin/user/info":                         Idempotent,
+		"/cgi-bin/user/info/batchget":                Idempotent,
+		"/cgi-bin/groups/get":                        Idempotent,
+		"/cgi-bin/groups/getid":                      Idempotent,
+		"/cgi-bin/tags/get":                          Idempotent,
+		"/cgi-bin/user/tag/get":                      Idempotent,
+		"/cgi-bin/tags/members/getblacklist":         Idempotent,
+		"/cgi-bin/menu/get":                          Idempotent,
+		"/cgi-bin/get_current_selfmenu_info":         Idempotent,
+		"/cgi-bin/material/get_materialcount":        Idempotent,
+		"/cgi-bin/material/batchget_material":        Idempotent,
+		"/cgi-bin/material/get_material":             Idempotent,
+		"/cgi-bin/media/get":                         Idempotent,
+		"/cgi-bin/message/mass/get":                  Idempotent,
+		"/cgi-bin/message/mass/speed/get":            Idempotent,
+		"/cgi-bin/customservice/getkflist":           Idempotent,
+		"/cgi-bin/customservice/getonlinekflist":     Idempotent,
+		"/cgi-bin/template/get_industry":             Idempotent,
+		"/cgi-bin/template/get_all_private_template": Idempotent,
+		"/cgi-bin/freepublish/get":                   Idempotent,
+		"/cgi-bin/ticket/getticket":                  Idempotent,
+		"/cgi-bin/shorten/fetch":                     Idempotent,
+		"/datacube/":                                 Idempotent,
+		"/card/get":                                  Idempotent,
+		"/card/batchget":                             Idempotent,
+		"/card/getcolors":                            Idempotent,
+		"/card/code/get":                             Idempotent,
+		"/card/code/decrypt":                         Idempotent,
+		"/card/location/batchget":                    Idempotent,
+		"/card/giftcard/page/get":                    Idempotent,
+		"/card/giftcard/page/batchget":               Idempotent,
+		"/card/giftcard/order/get":                   Idempotent,
+		"/card/giftcard/order/batchget":              Idempotent,
+		"/card/membercard/activatetempinfo/get":      Idempotent,
+		"/card/membercard/activate/geturl":           Idempotent,
+		"/shakearound/statistics/":                   Idempotent,
+		"/shakearound/page/search":                   Idempotent,
+		"/shakearound/device/search":                 Idempotent,
+		"/device/get_stat":                           Idempotent,
+		"/device/get_bind_device":                    Idempotent,
+		"/device/get_openid":                         Idempotent,
+		"/scan/merchantinfo/get":                     Idempotent,
+		"/scan/product/get":                          Idempotent,
+		"/scan/product/getlist":                      Idempotent,
+		"/wxa/sec/order/get_order":                   Idempotent,
+		"/wxa/sec/order/get_order_list":              Idempotent,
+		"/wxa/sec/order/is_trade_managed":            Idempotent,
+
+		// 覆盖式的修改接口, 重复请求的结果一样
+		"/cgi-bin/menu/create":                  Idempotent,
+		"/cgi-bin/menu/delete":                  Idempotent,
+		"/cgi-bin/user/info/updateremark":       Idempotent,
+		"/cgi-bin/template/api_set_industry":    Idempotent,
+		"/cgi-bin/message/mass/speed/set":       Idempotent,
+		"/wxa/sec/order/set_msg_jump_path":      Idempotent,
+		"/card/paycell/set":                     Idempotent,
+		"/card/selfconsumecell/set":             Idempotent,
+		"/card/giftcard/page/update":            Idempotent,
+		"/card/giftcard/maintain/set":           Idempotent,
+		"/card/membercard/activateuserform/set": Idempotent,
+
+		// 发送消息, 创建资源等接口
+		"/cgi-bin/message/custom/send":        NonIdempotent,
+		"/cgi-bin/message/mass/":              NonIdempotent,
+		"/cgi-bin/message/template/send":      NonIdempotent,
+		"/cgi-bin/message/subscribe/send":     NonIdempotent,
+		"/cgi-bin/message/subscribe/bizsend":  NonIdempotent,
+		"/cgi-bin/media/upload":               NonIdempotent,
+		"/cgi-bin/media/uploadimg":            NonIdempotent,
+		"/cgi-bin/media/uploadnews":           NonIdempotent,
+		"/cgi-bin/material/add_material":      NonIdempotent,
+		"/cgi-bin/material/add_news":          NonIdempotent,
+		"/cgi-bin/freepublish/submit":         NonIdempotent,
+		"/cgi-bin/qrcode/create":              NonIdempotent,
+		"/card/create":                        NonIdempotent,
+		"/card/code/consume":                  NonIdempotent,
+		"/card/modifystock":                   NonIdempotent,
+		"/card/landingpage/create":            NonIdempotent,
+		"/card/giftcard/page/add":             NonIdempotent,
+		"/card/giftcard/order/refund":         NonIdempotent,
+		"/device/transmsg":                    NonIdempotent,
+		"/wxa/sec/order/upload_shipping_info": NonIdempotent,
+	},
+}
+
+// 登记接口的幂等性, 覆盖默认的设置.
+//  path: URL 的 path, 比如 "/cgi-bin/user/info"; 以 / 结尾表示前缀, 比如 "/datacube/"
+func RegisterIdempotency(path string, idempotency Idempotency) {
+	idempotencyRegistry.Lock()
+	idempotencyRegistry.m[path] = idempotency
+	idempotencyRegistry.Unlock()
+}
+
+// 返回接口的幂等性, 精确匹配优先, 然后是最长的前缀匹配.
+//  incompleteURL: 可以是完整的 URL, 也可以只是 path
+func EndpointIdempotency(incompleteURL string) Idempotency {
+	path := endpointPath(incompleteURL)
+
+	idempotencyRegistry.RLock()
+	defer idempotencyRegistry.RUnlock()
+
+	if idempotency, ok := idempotencyRegistry.m[path]; ok {
+		return idempotency
+	}
+	for i := strings.LastIndex(path, "/"); i >= 0; i = strings.LastIndex(path, "/") {
+		path = path[:i]
+		if idempotency, ok := idempotencyRegistry.m[path+"/"]; ok {
+			return idempotency
+		}
+	}
+	return IdempotencyUnknown
+}
+
+// 判断接口是否可以安全的重试.
+func IsIdempotent(incompleteURL string) bool {
+	return EndpointIdempotency(incompleteURL) == Idempotent
+}
+
+// 返回 URL 的 path, 不做转义处理.
+func endpointPath(incompleteURL string) string {
+	path := incompleteURL
+	if i := strings.Index(path, "://"); i >= 0 {
+		path = path[i+3:]
+		if j := strings.IndexByte(path, '/'); j >= 0 {
+			path = path[j:]
+		} else {
+			path = "/"
+		}
+	}
+	if i := strings.IndexAny(path, "?#"); i >= 0 {
+		path = path[:i]
+	}
+	return path
+}
+
+// 网络错误或者微信服务器返回 5xx 的时候, 判断是否需要重试; 需要重试时 *retries 加一, 并且等待 RetryBackoff 之后再返回.
+func (clt *WechatClient) shouldRetry(incompleteURL string, httpResp *http.Response, err error, maxRetries int, retries *int) bool {
+	if *retries >= maxRetries {
+		return false
+	}
+	if err == nil && httpResp.StatusCode < http.StatusInternalServerError {
+		return false
+	}
+	if !IsIdempotent(incompleteURL) {
+		return false
+	}
+	*retries++
+	policy := RetryPolicy{Backoff: clt.RetryBackoff}
+	time.Sleep(policy.backoff(*retries))
+	return true
+}
+
+// 生成一个随机的 clientmsgid, 用于群发接口的去重.
+//  微信服务器 24 小时内对相同 clientmsgid 的群发请求只会发送一次, 所以重试的时候要使用同一个 clientmsgid.
+func NewClientMsgId() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		panic(err)
+	}
+	return hex.EncodeToString(b[:])
+}
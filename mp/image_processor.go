@@ -0,0 +1,274 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	_ "image/gif" // image.Decode 支持 GIF
+	"image/jpeg"
+	"image/png"
+	"io"
+	"io/ioutil"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+const (
+	ImageFormatJPEG = "jpeg"
+	ImageFormatPNG  = "png"
+	ImageFormatGIF  = "gif"
+)
+
+// 上传图片接口对图片的限制.
+type ImageLimit struct {
+	MaxBytes int64    // 文件大小的上限
+	Formats  []string // 支持的格式, ImageFormatJPEG, ImageFormatPNG, ImageFormatGIF
+}
+
+func (limit *ImageLimit) allow(format string) bool {
+	for _, f := range limit.Formats {
+		if f == format {
+			return true
+		}
+	}
+	return false
+}
+
+// 上传前处理图片, 使之满足接口的限制.
+type ImageProcessor interface {
+	// 处理文件名为 filename 的图片, 返回满足 limit 的图片和新的文件名(格式改变时需要修改扩展名).
+	Process(filename string, reader io.Reader, limit ImageLimit) (newFilename string, newReader io.Reader, err error)
+}
+
+// 上传图片接口的限制.
+//  key 为 URL 的 path, 区分 type 参数的接口为 path?type=TYPE.
+var imageLimitRegistry = struct {
+	sync.RWMutex
+	m map[string]ImageLimit
+}{
+	m: map[string]ImageLimit{
+		"/cgi-bin/media/upload?type=image":          {2 << 20, []string{ImageFormatJPEG, ImageFormatPNG, ImageFormatGIF}},
+		"/cgi-bin/media/upload?type=thumb":          {64 << 10, []string{ImageFormatJPEG}},
+		"/cgi-bin/material/add_material?type=image": {2 << 20, []string{ImageFormatJPEG, ImageFormatPNG, ImageFormatGIF}},
+		"/cgi-bin/material/add_material?type=thumb": {64 << 10, []string{ImageFormatJPEG}},
+		"/cgi-bin/media/uploadimg":                  {1 << 20, []string{ImageFormatJPEG, ImageFormatPNG}},
+		"/customservice/kfaccount/uploadheadimg":    {2 << 20, []string{ImageFormatJPEG}},
+		"/shakearound/material/add":                 {200 << 10, []string{ImageFormatJPEG, ImageFormatPNG, ImageFormatGIF}},
+	},
+}
+
+// 登记上传接口对图片的限制, 已经登记的接口会被覆盖.
+//  path 为 URL 的 path, 区分 type 参数的接口为 path?type=TYPE, 比如 "/cgi-bin/media/upload?type=thumb".
+func RegisterImageLimit(path string, limit ImageLimit) {
+	imageLimitRegistry.Lock()
+	imageLimitRegistry.m[path] = limit
+	imageLimitRegistry.Unlock()
+}
+
+// 查询上传接口对图片的限制, 没有登记的接口返回 false.
+func ImageLimitFor(incompleteURL string) (limit ImageLimit, ok bool) {
+	path := endpointPath(incompleteURL)
+
+	imageLimitRegistry.RLock()
+	defer imageLimitRegistry.RUnlock()
+
+	if i := strings.IndexByte(incompleteURL, '?'); i >= 0 {
+		if query, err := url.ParseQuery(incompleteURL[i+1:]); err == nil && query.Get("type") != "" {
+			if limit, ok = imageLimitRegistry.m[path+"?type="+query.Get("type")]; ok {
+				return
+			}
+		}
+	}
+	limit, ok = imageLimitRegistry.m[path]
+	return
+}
+
+// 如果设置了 clt.ImageProcessor 并且 incompleteURL 是登记过的图片上传接口, 上传前处理图片.
+func (clt *WechatClient) processImage(incompleteURL, filename string, reader io.Reader) (string, io.Reader, error) {
+	if clt.ImageProcessor == nil {
+		return filename, reader, nil
+	}
+	limit, ok := ImageLimitFor(incompleteURL)
+	if !ok {
+		return filename, reader, nil
+	}
+	return clt.ImageProcessor.Process(filename, reader, limit)
+}
+
+// 纯 Go 实现的 ImageProcessor.
+//  满足限制的图片原样上传; 格式不支持的转换成支持的格式(优先 JPEG); 超过大小的先逐步降低 JPEG 质量,
+//  还不满足时按比例缩小尺寸后重试.
+//  NOTE: 处理过的 GIF 只保留第一帧; 转换成 JPEG 时透明部分填充为白色.
+type DefaultImageProcessor struct {
+	MaxDimension int // 图片宽高的上限, 超过时先等比缩小, 为 0 表示不限制
+	MinQuality   int // 降低 JPEG 质量的下限, 为 0 时默认为 40
+}
+
+var _ ImageProcessor = (*DefaultImageProcessor)(nil)
+
+func (p *DefaultImageProcessor) Process(filename string, reader io.Reader, limit ImageLimit) (newFilename string, newReader io.Reader, err error) {
+	data, err := ioutil.ReadAll(reader)
+	if err != nil {
+		return
+	}
+
+	config, format, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		err = fmt.Errorf("decode image %s: %v", filename, err)
+		return
+	}
+	if (limit.MaxBytes <= 0 || int64(len(data)) <= limit.MaxBytes) && limit.allow(format) &&
+		(p.MaxDimension <= 0 || (config.Width <= p.MaxDimension && config.Height <= p.MaxDimension)) {
+		return filename, bytes.NewReader(data), nil
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		err = fmt.Errorf("decode image %s: %v", filename, err)
+		return
+	}
+	if p.MaxDimension > 0 {
+		img = fitImage(img, p.MaxDimension)
+	}
+
+	var outFormat string
+	switch {
+	case limit.allow(ImageFormatJPEG):
+		outFormat = ImageFormatJPEG
+	case limit.allow(ImageFormatPNG):
+		outFormat = ImageFormatPNG
+	default:
+		err = errors.New("no supported output format for " + filename)
+		return
+	}
+	if outFormat == ImageFormatJPEG {
+		img = flattenImage(img)
+	}
+
+	minQuality := p.MinQuality
+	if minQuality <= 0 {
+		minQuality = 40
+	}
+
+	var buf bytes.Buffer
+	for {
+		if outFormat == ImageFormatJPEG {
+			for quality := 90; ; quality -= 10 {
+				if quality < minQuality {
+					quality = minQuality
+				}
+				buf.Reset()
+				if err = jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality}); err != nil {
+					return
+				}
+				if limit.MaxBytes <= 0 || int64(buf.Len()) <= limit.MaxBytes || quality == minQuality {
+					break
+				}
+			}
+		} else {
+			buf.Reset()
+			if err = png.Encode(&buf, img); err != nil {
+				return
+			}
+		}
+		if limit.MaxBytes <= 0 || int64(buf.Len()) <= limit.MaxBytes {
+			break
+		}
+
+		bounds := img.Bounds()
+		if bounds.Dx() <= 16 || bounds.Dy() <= 16 {
+			err = fmt.Errorf("can not compress image %s to %d bytes", filename, limit.MaxBytes)
+			return
+		}
+		img = resizeImage(img, bounds.Dx()*3/4, bounds.Dy()*3/4)
+	}
+
+	newFilename = filename
+	if outFormat != format {
+		ext := ".jpg"
+		if outFormat == ImageFormatPNG {
+			ext = ".png"
+		}
+		newFilename = strings.TrimSuffix(filename, filepath.Ext(filename)) + ext
+	}
+	newReader = bytes.NewReader(buf.Bytes())
+	return
+}
+
+// 等比缩小 img 使宽高都不超过 maxDimension.
+func fitImage(img image.Image, maxDimension int) image.Image {
+	bounds := img.Bounds()
+	w, h := bounds.Dx(), bounds.Dy()
+	if w <= maxDimension && h <= maxDimension {
+		return img
+	}
+	if w >= h {
+		return resizeImage(img, maxDimension, h*maxDimension/w)
+	}
+	return resizeImage(img, w*maxDimension/h, maxDimension)
+}
+
+// 把 img 画到白色背景上, 去掉透明通道.
+func flattenImage(img image.Image) image.Image {
+	bounds := img.Bounds()
+	dst := image.NewRGBA(image.Rect(0, 0, bounds.Dx(), bounds.Dy()))
+	draw.Draw(dst, dst.Bounds(), image.NewUniform(color.White), image.ZP, draw.Src)
+	draw.Draw(dst, dst.Bounds(), img, bounds.Min, draw.Over)
+	return dst
+}
+
+// 把 img 缩放到 width*height, 缩小时使用区域平均, 放大时相当于最近邻插值(适合二维码这类需要保持边缘清晰的图片).
+func ResizeImage(img image.Image, width, height int) image.Image {
+	return resizeImage(img, width, height)
+}
+
+// 用区域平均的方法把 img 缩小到 width*height.
+func resizeImage(img image.Image, width, height int) image.Image {
+	if width < 1 {
+		width = 1
+	}
+	if height < 1 {
+		height = 1
+	}
+	bounds := img.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+
+	for y := 0; y < height; y++ {
+		y0 := bounds.Min.Y + y*srcH/height
+		y1 := bounds.Min.Y + (y+1)*srcH/height
+		if y1 <= y0 {
+			y1 = y0 + 1
+		}
+		for x := 0; x < width; x++ {
+			x0 := bounds.Min.X + x*srcW/width
+			x1 := bounds.Min.X + (x+1)*srcW/width
+			if x1 <= x0 {
+				x1 = x0 + 1
+			}
+			var r, g, b, a, n uint64
+			for sy := y0; sy < y1; sy++ {
+				for sx := x0; sx < x1; sx++ {
+					cr, cg, cb, ca := img.At(sx, sy).RGBA()
+					r += uint64(cr)
+					g += uint64(cg)
+					b += uint64(cb)
+					a += uint64(ca)
+					n++
+				}
+			}
+			dst.Set(x, y, color.RGBA64{uint16(r / n), uint16(g / n), uint16(b / n), uint16(a / n)})
+		}
+	}
+	return dst
+}
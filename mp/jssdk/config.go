@@ -0,0 +1,110 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package jssdk
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"html/template"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// wx.config 的参数, 用 json.Marshal 序列化后可以直接传给 wx.config.
+type Config struct {
+	Debug       bool     `json:"debug"`                 // 开启调试模式, 调用的所有 api 的返回值会在客户端 alert 出来
+	AppId       string   `json:"appId"`                 // 公众号的唯一标识
+	Timestamp   int64    `json:"timestamp"`             // 生成签名的时间戳
+	NonceStr    string   `json:"nonceStr"`              // 生成签名的随机串
+	Signature   string   `json:"signature"`             // 签名
+	JSApiList   []string `json:"jsApiList"`             // 需要使用的 JS 接口列表
+	OpenTagList []string `json:"openTagList,omitempty"` // 需要使用的开放标签列表, 例如 ['wx-open-launch-app']
+}
+
+// 生成 wx.config 的参数.
+//  pageURL: 当前网页的 URL, 不包含 # 及其后面部分(有的话会自动去掉)
+func NewConfig(jsapiTicket, appId, pageURL string, jsApiList ...string) *Config {
+	if i := strings.IndexByte(pageURL, '#'); i >= 0 {
+		pageURL = pageURL[:i]
+	}
+	if jsApiList == nil {
+		jsApiList = []string{} // wx.config 要求是数组, 不能是 null
+	}
+
+	timestamp := time.Now().Unix()
+	nonceStr := newNonceStr()
+	return &Config{
+		AppId:     appId,
+		Timestamp: timestamp,
+		NonceStr:  nonceStr,
+		Signature: WXConfigSign(jsapiTicket, nonceStr, strconv.FormatInt(timestamp, 10), pageURL),
+		JSApiList: jsApiList,
+	}
+}
+
+// 返回 JSON 格式的参数, 可以作为接口的返回值给前端使用.
+func (cfg *Config) JSON() ([]byte, error) {
+	return json.Marshal(cfg)
+}
+
+// 返回可以直接嵌入 html/template 的参数, 比如模板里 <script>wx.config({{.WXConfig}});</script>.
+func (cfg *Config) JS() template.JS {
+	b, err := json.Marshal(cfg)
+	if err != nil {
+		return "{}" // Config 的字段都是可以序列化的, 基本不会出现
+	}
+	return template.JS(b)
+}
+
+// 使用 TicketServer 获取 jsapi_ticket 生成 wx.config 的参数.
+type ConfigBuilder struct {
+	AppId        string
+	TicketServer TicketServer
+	Debug        bool     // 生成的 Config.Debug, 一般只在测试环境打开
+	JSApiList    []string // 默认的 JS 接口列表, 调用 Config 时没有指定 jsApiList 则使用这个
+}
+
+func NewConfigBuilder(appId string, ticketServer TicketServer, jsApiList ...string) *ConfigBuilder {
+	if ticketServer == nil {
+		panic("nil TicketServer")
+	}
+	return &ConfigBuilder{
+		AppId:        appId,
+		TicketServer: ticketServer,
+		JSApiList:    jsApiList,
+	}
+}
+
+// 生成 pageURL 页面的 wx.config 参数, jsApiList 为空时使用 ConfigBuilder.JSApiList.
+func (b *ConfigBuilder) Config(pageURL string, jsApiList ...string) (cfg *Config, err error) {
+	if pageURL == "" {
+		err = errors.New("empty pageURL")
+		return
+	}
+	ticket, err := b.TicketServer.Ticket()
+	if err != nil {
+		return
+	}
+	if len(jsApiList) == 0 {
+		jsApiList = b.JSApiList
+	}
+	cfg = NewConfig(ticket, b.AppId, pageURL, jsApiList...)
+	cfg.Debug = b.Debug
+	return
+}
+
+// 生成随机字符串, 32 个字符.
+func newNonceStr() string {
+	var nonce [16]byte
+	if _, err := rand.Read(nonce[:]); err != nil {
+		// crypto/rand 失败的情况极少, 退化为时间戳
+		return strconv.FormatInt(time.Now().UnixNano(), 10)
+	}
+	return hex.EncodeToString(nonce[:])
+}
@@ -0,0 +1,137 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package jssdk
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// 给 H5 页面签名 wx.config 参数的 http.Handler.
+//
+//  前端请求 GET /jssdk/config?url=encodeURIComponent(location.href.split('#')[0])&jsApiList=updateAppMessageShareData,updateTimelineShareData
+//  (也可以用 POST 表单, 参数相同), 返回 JSON 格式的 Config; 出错时返回 {"error": "..."} 和 4xx/5xx 状态码.
+//
+//  只有 url 的 host 在 AllowedHosts 里才签名, 防止别人用你的公众号给任意页面签名.
+//  请求头 Origin 的 host 也在 AllowedHosts 里时会设置 Access-Control-Allow-Origin, 以便跨域调用.
+type SignHandler struct {
+	Builder      *ConfigBuilder
+	AllowedHosts []string        // 允许签名的 host, 比如 "m.example.com"; "*.example.com" 匹配所有子域名(不包括 example.com 本身)
+	OnError      func(err error) // 获取 jsapi_ticket 失败时调用, 可以为 nil
+}
+
+func NewSignHandler(builder *ConfigBuilder, allowedHosts ...string) *SignHandler {
+	if builder == nil {
+		panic("nil ConfigBuilder")
+	}
+	return &SignHandler{
+		Builder:      builder,
+		AllowedHosts: allowedHosts,
+	}
+}
+
+var errHostNotAllowed = errors.New("host not allowed")
+
+func (h *SignHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "GET" && r.Method != "POST" {
+		w.Header().Set("Allow", "GET, POST")
+		writeSignError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+		return
+	}
+	if origin := r.Header.Get("Origin"); origin != "" {
+		if u, err := url.Parse(origin); err == nil && h.hostAllowed(u.Host) {
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+			w.Header().Add("Vary", "Origin")
+		}
+	}
+
+	pageURL := r.FormValue("url")
+	if err := h.checkURL(pageURL); err != nil {
+		status := http.StatusBadRequest
+		if err == errHostNotAllowed {
+			status = http.StatusForbidden
+		}
+		writeSignError(w, status, err)
+		return
+	}
+
+	var jsApiList []string
+	if s := r.FormValue("jsApiList"); s != "" {
+		for _, api := range strings.Split(s, ",") {
+			if api = strings.TrimSpace(api); api != "" {
+				jsApiList = append(jsApiList, api)
+			}
+		}
+	}
+
+	cfg, err := h.Builder.Config(pageURL, jsApiList...)
+	if err != nil {
+		if h.OnError != nil {
+			h.OnError(err)
+		}
+		writeSignError(w, http.StatusInternalServerError, errors.New("get jsapi_ticket failed"))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	json.NewEncoder(w).Encode(cfg)
+}
+
+// 检查 pageURL 是否可以签名.
+func (h *SignHandler) checkURL(pageURL string) error {
+	if pageURL == "" {
+		return errors.New("empty url")
+	}
+	u, err := url.Parse(pageURL)
+	if err != nil {
+		return errors.New("invalid url")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.New("invalid url scheme")
+	}
+	if u.User != nil {
+		return errors.New("invalid url")
+	}
+	if !h.hostAllowed(u.Host) {
+		return errHostNotAllowed
+	}
+	return nil
+}
+
+// host 可以带端口, 比较的时候忽略端口和大小写.
+func (h *SignHandler) hostAllowed(host string) bool {
+	if hostname, _, err := net.SplitHostPort(host); err == nil {
+		host = hostname
+	}
+	host = strings.ToLower(strings.TrimSuffix(host, "."))
+	if host == "" {
+		return false
+	}
+	for _, allowed := range h.AllowedHosts {
+		allowed = strings.ToLower(allowed)
+		if strings.HasPrefix(allowed, "*.") {
+			if strings.HasSuffix(host, allowed[1:]) {
+				return true
+			}
+			continue
+		}
+		if host == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func writeSignError(w http.ResponseWriter, status int, err error) {
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+}
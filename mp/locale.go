@@ -0,0 +1,90 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+const (
+	Language_zh_CN = "zh_CN" // 简体中文
+	Language_zh_TW = "zh_TW" // 繁体中文
+	Language_en    = "en"    // 英文
+)
+
+// 把各种写法的语言参数规范成微信接口接受的 zh_CN, zh_TW 或 en.
+//  大小写和分隔符('-', '_')不敏感, 比如 "zh-cn", "zh-Hans" 规范成 zh_CN, "zh-HK", "zh-Hant" 规范成 zh_TW,
+//  "en-US", "en_GB" 规范成 en; 空字符串规范成默认的 zh_CN; 其他的语言返回错误.
+func NormalizeLanguage(lang string) (string, error) {
+	if lang == "" {
+		return Language_zh_CN, nil
+	}
+	s := strings.ToLower(strings.Replace(lang, "-", "_", -1))
+	switch {
+	case s == "zh", s == "zh_cn", s == "zh_sg", s == "zh_hans", strings.HasPrefix(s, "zh_hans_"):
+		return Language_zh_CN, nil
+	case s == "zh_tw", s == "zh_hk", s == "zh_mo", s == "zh_hant", strings.HasPrefix(s, "zh_hant_"):
+		return Language_zh_TW, nil
+	case s == "en", strings.HasPrefix(s, "en_"):
+		return Language_en, nil
+	}
+	return "", errors.New("invalid lang: " + lang)
+}
+
+// 微信接口的时间戳和日期字符串使用的时区, 即北京时间(Asia/Shanghai).
+//  系统里没有时区数据库时使用固定的 UTC+8.
+var Location = loadLocation()
+
+func loadLocation() *time.Location {
+	if loc, err := time.LoadLocation("Asia/Shanghai"); err == nil {
+		return loc
+	}
+	return time.FixedZone("CST", 8*60*60)
+}
+
+const (
+	DateLayout     = "2006-01-02"          // 微信接口的日期格式, 比如数据统计接口的 begin_date
+	DateTimeLayout = "2006-01-02 15:04:05" // 微信接口的日期时间格式
+)
+
+// 把微信接口返回的 Unix 时间戳(秒)转换成北京时间的 time.Time; 0 表示没有设置, 返回零值.
+func UnixToTime(sec int64) time.Time {
+	if sec == 0 {
+		return time.Time{}
+	}
+	return time.Unix(sec, 0).In(Location)
+}
+
+// 把 time.Time 转换成微信接口的 Unix 时间戳(秒); 零值返回 0.
+func TimeToUnix(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+// 按北京时间解析 YYYY-MM-DD 格式的日期.
+func ParseDate(s string) (time.Time, error) {
+	return time.ParseInLocation(DateLayout, s, Location)
+}
+
+// 按北京时间解析 YYYY-MM-DD hh:mm:ss 格式的日期时间.
+func ParseDateTime(s string) (time.Time, error) {
+	return time.ParseInLocation(DateTimeLayout, s, Location)
+}
+
+// 返回 t 在北京时间的 YYYY-MM-DD 格式的日期.
+//  NOTE: t 会先转换成北京时间, 比如 UTC 的 2016-01-01 20:00:00 返回 2016-01-02.
+func FormatDate(t time.Time) string {
+	return t.In(Location).Format(DateLayout)
+}
+
+// 返回 t 在北京时间的 YYYY-MM-DD hh:mm:ss 格式的日期时间.
+func FormatDateTime(t time.Time) string {
+	return t.In(Location).Format(DateTimeLayout)
+}
@@ -32,3 +32,12 @@ func NewClient(TokenServer mp.TokenServer, HttpClient *http.Client) *Client {
 		},
 	}
 }
+
+// 创建一个新的 Client, JSON 请求使用 HttpClient, 多媒体上传下载使用 MediaHttpClient.
+//  如果 HttpClient == nil 则默认用 http.DefaultClient;
+//  如果 MediaHttpClient == nil 则多媒体上传下载也使用 HttpClient.
+func NewClientWithMediaHttpClient(TokenServer mp.TokenServer, HttpClient, MediaHttpClient *http.Client) *Client {
+	clt := NewClient(TokenServer, HttpClient)
+	clt.MediaHttpClient = MediaHttpClient
+	return clt
+}
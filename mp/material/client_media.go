@@ -6,11 +6,14 @@
 package material
 
 import (
+	"encoding/json"
+	"errors"
+
 	"github.com/chanxuehong/wechat/mp"
 )
 
 // 删除永久素材.
-func (clt *Client) DeleteMaterial(mediaId string) (err error) {
+func (clt *Client) DeleteMaterial(mediaId string, opts ...mp.CallOption) (err error) {
 	var request = struct {
 		MediaId string `json:"media_id"`
 	}{
@@ -20,7 +23,7 @@ func (clt *Client) DeleteMaterial(mediaId string) (err error) {
 	var result mp.Error
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/material/del_material?access_token="
-	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
 		return
 	}
 
@@ -40,14 +43,14 @@ type MaterialCountInfo struct {
 }
 
 // 获取素材总数.
-func (clt *Client) GetMaterialCount() (info *MaterialCountInfo, err error) {
+func (clt *Client) GetMaterialCount(opts ...mp.CallOption) (info *MaterialCountInfo, err error) {
 	var result struct {
 		mp.Error
 		MaterialCountInfo
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/material/get_materialcount?access_token="
-	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+	if err = clt.GetJSON(incompleteURL, &result, opts...); err != nil {
 		return
 	}
 
@@ -74,7 +77,7 @@ type MaterialInfo struct {
 //  TotalCount:   该类型的素材的总数
 //  ItemCount:    本次调用获取的素材的数量
 //  Items:        本次调用获取的素材
-func (clt *Client) BatchGetMaterial(materialType string, offset, count int) (TotalCount, ItemCount int, Items []MaterialInfo, err error) {
+func (clt *Client) BatchGetMaterial(materialType string, offset, count int, opts ...mp.CallOption) (TotalCount, ItemCount int, Items []MaterialInfo, err error) {
 	var request = struct {
 		MaterialType string `json:"type"`
 		Offset       int    `json:"offset"`
@@ -93,7 +96,7 @@ func (clt *Client) BatchGetMaterial(materialType string, offset, count int) (Tot
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/material/batchget_material?access_token="
-	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
 		return
 	}
 
@@ -106,3 +109,49 @@ func (clt *Client) BatchGetMaterial(materialType string, offset, count int) (Tot
 	Items = result.Items
 	return
 }
+
+// 流式获取素材列表, 每个素材回调一次 fn, 参数参考 BatchGetMaterial.
+//  fn 返回非 nil 的 error 会终止获取并返回该 error.
+func (clt *Client) BatchGetMaterialStream(materialType string, offset, count int, fn func(info *MaterialInfo) error, opts ...mp.CallOption) (TotalCount, ItemCount int, err error) {
+	if fn == nil {
+		err = errors.New("nil fn")
+		return
+	}
+
+	var request = struct {
+		MaterialType string `json:"type"`
+		Offset       int    `json:"offset"`
+		Count        int    `json:"count"`
+	}{
+		MaterialType: materialType,
+		Offset:       offset,
+		Count:        count,
+	}
+
+	var result struct {
+		mp.Error
+		TotalCount int `json:"total_count"`
+		ItemCount  int `json:"item_count"`
+	}
+
+	onItem := func(dec *json.Decoder) (err error) {
+		var info MaterialInfo
+		if err = dec.Decode(&info); err != nil {
+			return
+		}
+		return fn(&info)
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/material/batchget_material?access_token="
+	if err = clt.PostJSONStream(incompleteURL, &request, []string{"item"}, onItem, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	TotalCount = result.TotalCount
+	ItemCount = result.ItemCount
+	return
+}
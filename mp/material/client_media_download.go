@@ -27,7 +27,8 @@ func (clt *Client) DownloadMaterial(mediaId, filepath string) (err error) {
 	}
 	defer file.Close()
 
-	return clt.downloadMaterialToWriter(mediaId, file)
+	_, err = clt.downloadMaterialToWriter(mediaId, file)
+	return
 }
 
 // 下载多媒体到 io.Writer.
@@ -35,11 +36,12 @@ func (clt *Client) DownloadMaterialToWriter(mediaId string, writer io.Writer) er
 	if writer == nil {
 		return errors.New("nil writer")
 	}
-	return clt.downloadMaterialToWriter(mediaId, writer)
+	_, err := clt.downloadMaterialToWriter(mediaId, writer)
+	return err
 }
 
-// 下载多媒体到 io.Writer.
-func (clt *Client) downloadMaterialToWriter(mediaId string, writer io.Writer) (err error) {
+// 下载多媒体到 io.Writer, 返回 http 响应头.
+func (clt *Client) downloadMaterialToWriter(mediaId string, writer io.Writer) (header http.Header, err error) {
 	var request = struct {
 		MediaId string `json:"media_id"`
 	}{
@@ -60,18 +62,20 @@ func (clt *Client) downloadMaterialToWriter(mediaId string, writer io.Writer) (e
 RETRY:
 	finalURL := "https://api.weixin.qq.com/cgi-bin/material/get_material?access_token=" + url.QueryEscape(token)
 
-	httpResp, err := clt.HttpClient.Post(finalURL, "application/json; charset=utf-8", bytes.NewReader(requestBody))
+	httpResp, err := clt.MediaClient().Post(finalURL, "application/json; charset=utf-8", bytes.NewReader(requestBody))
 	if err != nil {
 		return
 	}
 	defer httpResp.Body.Close()
 
 	if httpResp.StatusCode != http.StatusOK {
-		return fmt.Errorf("http.Status: %s", httpResp.Status)
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
 	}
 
 	ContentType, _, _ := mime.ParseMediaType(httpResp.Header.Get("Content-Type"))
 	if ContentType != "text/plain" && ContentType != "application/json" { // 返回的是媒体流
+		header = httpResp.Header
 		_, err = io.Copy(writer, httpResp.Body)
 		return
 	}
@@ -25,34 +25,34 @@ const (
 )
 
 // 上传多媒体图片
-func (clt *Client) UploadImage(filepath string) (mediaId string, err error) {
-	return clt.uploadMaterial(MaterialTypeImage, filepath)
+func (clt *Client) UploadImage(filepath string, opts ...mp.CallOption) (mediaId string, err error) {
+	return clt.uploadMaterial(MaterialTypeImage, filepath, opts...)
 }
 
 // 上传多媒体缩略图
-func (clt *Client) UploadThumb(filepath string) (mediaId string, err error) {
-	return clt.uploadMaterial(MaterialTypeThumb, filepath)
+func (clt *Client) UploadThumb(filepath string, opts ...mp.CallOption) (mediaId string, err error) {
+	return clt.uploadMaterial(MaterialTypeThumb, filepath, opts...)
 }
 
 // 上传多媒体语音
-func (clt *Client) UploadVoice(filepath string) (mediaId string, err error) {
-	return clt.uploadMaterial(MaterialTypeVoice, filepath)
+func (clt *Client) UploadVoice(filepath string, opts ...mp.CallOption) (mediaId string, err error) {
+	return clt.uploadMaterial(MaterialTypeVoice, filepath, opts...)
 }
 
 // 上传多媒体
-func (clt *Client) uploadMaterial(materialType, _filepath string) (mediaId string, err error) {
+func (clt *Client) uploadMaterial(materialType, _filepath string, opts ...mp.CallOption) (mediaId string, err error) {
 	file, err := os.Open(_filepath)
 	if err != nil {
 		return
 	}
 	defer file.Close()
 
-	return clt.uploadMaterialFromReader(materialType, filepath.Base(_filepath), file)
+	return clt.uploadMaterialFromReader(materialType, filepath.Base(_filepath), file, opts...)
 }
 
 // 上传多媒体图片
 //  NOTE: 参数 filename 不是文件路径, 是指定 multipart/form-data 里面文件名称
-func (clt *Client) UploadImageFromReader(filename string, reader io.Reader) (mediaId string, err error) {
+func (clt *Client) UploadImageFromReader(filename string, reader io.Reader, opts ...mp.CallOption) (mediaId string, err error) {
 	if filename == "" {
 		err = errors.New("empty filename")
 		return
@@ -61,12 +61,12 @@ func (clt *Client) UploadImageFromReader(filename string, reader io.Reader) (med
 		err = errors.New("nil reader")
 		return
 	}
-	return clt.uploadMaterialFromReader(MaterialTypeImage, filename, reader)
+	return clt.uploadMaterialFromReader(MaterialTypeImage, filename, reader, opts...)
 }
 
 // 上传多媒体缩略图
 //  NOTE: 参数 filename 不是文件路径, 是指定 multipart/form-data 里面文件名称
-func (clt *Client) UploadThumbFromReader(filename string, reader io.Reader) (mediaId string, err error) {
+func (clt *Client) UploadThumbFromReader(filename string, reader io.Reader, opts ...mp.CallOption) (mediaId string, err error) {
 	if filename == "" {
 		err = errors.New("empty filename")
 		return
@@ -75,12 +75,12 @@ func (clt *Client) UploadThumbFromReader(filename string, reader io.Reader) (med
 		err = errors.New("nil reader")
 		return
 	}
-	return clt.uploadMaterialFromReader(MaterialTypeThumb, filename, reader)
+	return clt.uploadMaterialFromReader(MaterialTypeThumb, filename, reader, opts...)
 }
 
 // 上传多媒体语音
 //  NOTE: 参数 filename 不是文件路径, 是指定 multipart/form-data 里面文件名称
-func (clt *Client) UploadVoiceFromReader(filename string, reader io.Reader) (mediaId string, err error) {
+func (clt *Client) UploadVoiceFromReader(filename string, reader io.Reader, opts ...mp.CallOption) (mediaId string, err error) {
 	if filename == "" {
 		err = errors.New("empty filename")
 		return
@@ -89,10 +89,10 @@ func (clt *Client) UploadVoiceFromReader(filename string, reader io.Reader) (med
 		err = errors.New("nil reader")
 		return
 	}
-	return clt.uploadMaterialFromReader(MaterialTypeVoice, filename, reader)
+	return clt.uploadMaterialFromReader(MaterialTypeVoice, filename, reader, opts...)
 }
 
-func (clt *Client) uploadMaterialFromReader(materialType, filename string, reader io.Reader) (mediaId string, err error) {
+func (clt *Client) uploadMaterialFromReader(materialType, filename string, reader io.Reader, opts ...mp.CallOption) (mediaId string, err error) {
 	var result struct {
 		mp.Error
 		MediaId string `json:"media_id"`
@@ -100,7 +100,7 @@ func (clt *Client) uploadMaterialFromReader(materialType, filename string, reade
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/material/add_material?type=" +
 		url.QueryEscape(materialType) + "&access_token="
-	if err = clt.UploadFromReader(incompleteURL, "media", filename, reader, "", nil, &result); err != nil {
+	if err = clt.UploadFromReader(incompleteURL, "media", filename, reader, "", nil, &result, opts...); err != nil {
 		return
 	}
 
@@ -115,19 +115,19 @@ func (clt *Client) uploadMaterialFromReader(materialType, filename string, reade
 // =============================================================================
 
 // 上传多媒体视频
-func (clt *Client) UploadVideo(_filepath string, title, introduction string) (mediaId string, err error) {
+func (clt *Client) UploadVideo(_filepath string, title, introduction string, opts ...mp.CallOption) (mediaId string, err error) {
 	file, err := os.Open(_filepath)
 	if err != nil {
 		return
 	}
 	defer file.Close()
 
-	return clt.uploadVideoFromReader(filepath.Base(_filepath), file, title, introduction)
+	return clt.uploadVideoFromReader(filepath.Base(_filepath), file, title, introduction, opts...)
 }
 
 // 上传多媒体缩视频
 //  NOTE: 参数 filename 不是文件路径, 是指定 multipart/form-data 里面文件名称
-func (clt *Client) UploadVideoFromReader(filename string, reader io.Reader, title, introduction string) (mediaId string, err error) {
+func (clt *Client) UploadVideoFromReader(filename string, reader io.Reader, title, introduction string, opts ...mp.CallOption) (mediaId string, err error) {
 	if filename == "" {
 		err = errors.New("empty filename")
 		return
@@ -136,11 +136,11 @@ func (clt *Client) UploadVideoFromReader(filename string, reader io.Reader, titl
 		err = errors.New("nil reader")
 		return
 	}
-	return clt.uploadVideoFromReader(filename, reader, title, introduction)
+	return clt.uploadVideoFromReader(filename, reader, title, introduction, opts...)
 }
 
 func (clt *Client) uploadVideoFromReader(filename string, reader io.Reader,
-	title, introduction string) (mediaId string, err error) {
+	title, introduction string, opts ...mp.CallOption) (mediaId string, err error) {
 
 	var desc = struct {
 		Title        string `json:"title"`
@@ -161,7 +161,7 @@ func (clt *Client) uploadVideoFromReader(filename string, reader io.Reader,
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/material/add_material?type=video&access_token="
-	if err = clt.UploadFromReader(incompleteURL, "media", filename, reader, "description", descBytes, &result); err != nil {
+	if err = clt.UploadFromReader(incompleteURL, "media", filename, reader, "description", descBytes, &result, opts...); err != nil {
 		return
 	}
 
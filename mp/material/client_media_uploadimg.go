@@ -0,0 +1,92 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package material
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 上传图文消息内的图片, 返回图片的 URL.
+//  图片仅支持 jpg/png 格式, 大小必须在 1MB 以下; 返回的 URL 可以放在图文消息的 content 里, 不占用素材库的数量限制.
+func (clt *Client) UploadArticleImage(_filepath string, opts ...mp.CallOption) (imageURL string, err error) {
+	file, err := os.Open(_filepath)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	return clt.uploadArticleImageFromReader(filepath.Base(_filepath), file, opts...)
+}
+
+// 上传图文消息内的图片, 返回图片的 URL.
+//  NOTE: 参数 filename 不是文件路径, 是指定 multipart/form-data 里面文件名称
+func (clt *Client) UploadArticleImageFromReader(filename string, reader io.Reader, opts ...mp.CallOption) (imageURL string, err error) {
+	if filename == "" {
+		err = errors.New("empty filename")
+		return
+	}
+	if reader == nil {
+		err = errors.New("nil reader")
+		return
+	}
+	return clt.uploadArticleImageFromReader(filename, reader, opts...)
+}
+
+func (clt *Client) uploadArticleImageFromReader(filename string, reader io.Reader, opts ...mp.CallOption) (imageURL string, err error) {
+	var result struct {
+		mp.Error
+		URL string `json:"url"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/media/uploadimg?access_token="
+	if err = clt.UploadFromReader(incompleteURL, "media", filename, reader, "", nil, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	imageURL = result.URL
+	return
+}
+
+// 返回一个 ImageUploader, 用 httpClient 下载外部图片以后通过 UploadArticleImageFromReader 上传到微信服务器.
+//  httpClient 为 nil 时使用 http.DefaultClient.
+func (clt *Client) ArticleImageUploader(httpClient *http.Client, opts ...mp.CallOption) ImageUploader {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return func(src string) (imageURL string, err error) {
+		httpResp, err := httpClient.Get(src)
+		if err != nil {
+			return
+		}
+		defer httpResp.Body.Close()
+
+		if httpResp.StatusCode != http.StatusOK {
+			err = fmt.Errorf("http.Status: %s", httpResp.Status)
+			return
+		}
+
+		filename := "image.jpg"
+		if u, err := url.Parse(src); err == nil {
+			if base := path.Base(u.Path); path.Ext(base) != "" {
+				filename = base
+			}
+		}
+		return clt.uploadArticleImageFromReader(filename, httpResp.Body, opts...)
+	}
+}
@@ -0,0 +1,64 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package material
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 视频素材的信息.
+//  get_material 对于视频素材返回的不是媒体流, 而是视频的下载地址.
+type VideoMaterial struct {
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	DownURL     string `json:"down_url"`
+}
+
+// 获取视频素材的信息.
+func (clt *Client) GetVideo(mediaId string, opts ...mp.CallOption) (info *VideoMaterial, err error) {
+	var request = struct {
+		MediaId string `json:"media_id"`
+	}{
+		MediaId: mediaId,
+	}
+
+	var result struct {
+		mp.Error
+		VideoMaterial
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/material/get_material?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	info = &result.VideoMaterial
+	return
+}
+
+// 下载视频素材到文件, 支持断点续传, 返回文件的大小.
+//  中途失败会从已经下载的位置继续下载, 参考 mp.RangeDownloader.
+func (clt *Client) DownloadVideo(mediaId, filepath string, opts ...mp.CallOption) (size int64, err error) {
+	info, err := clt.GetVideo(mediaId, opts...)
+	if err != nil {
+		return
+	}
+	if info.DownURL == "" {
+		err = errors.New("empty down_url")
+		return
+	}
+
+	downloader := mp.RangeDownloader{
+		HttpClient: clt.MediaClient(),
+	}
+	return downloader.DownloadTo(info.DownURL, filepath)
+}
@@ -6,6 +6,7 @@
 package material
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -37,7 +38,7 @@ func (article *Article) SetShowCoverPic(b bool) {
 }
 
 // 新增永久图文素材.
-func (clt *Client) AddNews(news News) (mediaId string, err error) {
+func (clt *Client) AddNews(news News, opts ...mp.CallOption) (mediaId string, err error) {
 	if len(news) == 0 {
 		err = errors.New("图文素材是空的")
 		return
@@ -59,7 +60,7 @@ func (clt *Client) AddNews(news News) (mediaId string, err error) {
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/material/add_news?access_token="
-	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
 		return
 	}
 
@@ -95,7 +96,7 @@ func (clt *Client) AddNews(news News) (mediaId string, err error) {
 //}
 
 // 获取永久图文素材.
-func (clt *Client) GetNews(mediaId string) (news News, err error) {
+func (clt *Client) GetNews(mediaId string, opts ...mp.CallOption) (news News, err error) {
 	var request = struct {
 		MediaId string `json:"media_id"`
 	}{
@@ -108,7 +109,7 @@ func (clt *Client) GetNews(mediaId string) (news News, err error) {
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/material/get_material?access_token="
-	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
 		return
 	}
 
@@ -136,7 +137,7 @@ type NewsInfo struct {
 //  TotalCount:   该类型的素材的总数
 //  ItemCount:    本次调用获取的素材的数量
 //  Items:        本次调用获取的素材
-func (clt *Client) BatchGetNews(offset, count int) (TotalCount, ItemCount int, Items []NewsInfo, err error) {
+func (clt *Client) BatchGetNews(offset, count int, opts ...mp.CallOption) (TotalCount, ItemCount int, Items []NewsInfo, err error) {
 	var request = struct {
 		MaterialType string `json:"type"`
 		Offset       int    `json:"offset"`
@@ -155,7 +156,7 @@ func (clt *Client) BatchGetNews(offset, count int) (TotalCount, ItemCount int, I
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/material/batchget_material?access_token="
-	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
 		return
 	}
 
@@ -168,3 +169,49 @@ func (clt *Client) BatchGetNews(offset, count int) (TotalCount, ItemCount int, I
 	Items = result.Items
 	return
 }
+
+// 流式获取图文素材列表, 每个图文素材回调一次 fn, 参数参考 BatchGetNews.
+//  fn 返回非 nil 的 error 会终止获取并返回该 error.
+func (clt *Client) BatchGetNewsStream(offset, count int, fn func(info *NewsInfo) error, opts ...mp.CallOption) (TotalCount, ItemCount int, err error) {
+	if fn == nil {
+		err = errors.New("nil fn")
+		return
+	}
+
+	var request = struct {
+		MaterialType string `json:"type"`
+		Offset       int    `json:"offset"`
+		Count        int    `json:"count"`
+	}{
+		MaterialType: MaterialTypeNews,
+		Offset:       offset,
+		Count:        count,
+	}
+
+	var result struct {
+		mp.Error
+		TotalCount int `json:"total_count"`
+		ItemCount  int `json:"item_count"`
+	}
+
+	onItem := func(dec *json.Decoder) (err error) {
+		var info NewsInfo
+		if err = dec.Decode(&info); err != nil {
+			return
+		}
+		return fn(&info)
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/material/batchget_material?access_token="
+	if err = clt.PostJSONStream(incompleteURL, &request, []string{"item"}, onItem, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	TotalCount = result.TotalCount
+	ItemCount = result.ItemCount
+	return
+}
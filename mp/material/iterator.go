@@ -0,0 +1,178 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package material
+
+import (
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	MaterialPageSizeLimit = 20 // 每次拉取的素材个数最大值为 20
+)
+
+// 素材遍历器, 用法同 mp.Pager.
+type MaterialIterator struct {
+	pager *mp.Pager
+	total int
+	items []MaterialInfo
+}
+
+func (iter *MaterialIterator) HasNext() bool     { return iter.pager.HasNext() }
+func (iter *MaterialIterator) Cursor() mp.Cursor { return iter.pager.Cursor() }
+
+// 该类型的素材的总数, 调用 NextPage 之后才有效.
+func (iter *MaterialIterator) Total() int { return iter.total }
+
+func (iter *MaterialIterator) NextPage() (items []MaterialInfo, err error) {
+	if err = iter.pager.NextPage(); err != nil {
+		return
+	}
+	items = iter.items
+	return
+}
+
+// 获取素材遍历器, 从 offset 开始每次拉取 count 个素材; count <= 0 时使用 MaterialPageSizeLimit.
+//  materialType: 素材的类型，图片（image）、视频（video）、语音 （voice）
+func (clt *Client) MaterialIterator(materialType string, offset, count int, opts ...mp.CallOption) *MaterialIterator {
+	if count <= 0 || count > MaterialPageSizeLimit {
+		count = MaterialPageSizeLimit
+	}
+	iter := &MaterialIterator{}
+	fetch := func(offset, count int) (n, total int, err error) {
+		total, n, items, err := clt.BatchGetMaterial(materialType, offset, count, opts...)
+		if err != nil {
+			return
+		}
+		iter.total = total
+		iter.items = items
+		return
+	}
+	iter.pager = mp.NewPager(mp.Cursor{Offset: offset}, mp.OffsetPageFunc(count, fetch))
+	return iter
+}
+
+// 图文素材遍历器, 用法同 mp.Pager.
+type NewsIterator struct {
+	pager *mp.Pager
+	total int
+	items []NewsInfo
+}
+
+func (iter *NewsIterator) HasNext() bool     { return iter.pager.HasNext() }
+func (iter *NewsIterator) Cursor() mp.Cursor { return iter.pager.Cursor() }
+
+// 图文素材的总数, 调用 NextPage 之后才有效.
+func (iter *NewsIterator) Total() int { return iter.total }
+
+func (iter *NewsIterator) NextPage() (items []NewsInfo, err error) {
+	if err = iter.pager.NextPage(); err != nil {
+		return
+	}
+	items = iter.items
+	return
+}
+
+// 获取图文素材遍历器, 从 offset 开始每次拉取 count 个图文素材; count <= 0 时使用 MaterialPageSizeLimit.
+func (clt *Client) NewsIterator(offset, count int, opts ...mp.CallOption) *NewsIterator {
+	if count <= 0 || count > MaterialPageSizeLimit {
+		count = MaterialPageSizeLimit
+	}
+	iter := &NewsIterator{}
+	fetch := func(offset, count int) (n, total int, err error) {
+		total, n, items, err := clt.BatchGetNews(offset, count, opts...)
+		if err != nil {
+			return
+		}
+		iter.total = total
+		iter.items = items
+		return
+	}
+	iter.pager = mp.NewPager(mp.Cursor{Offset: offset}, mp.OffsetPageFunc(count, fetch))
+	return iter
+}
+
+// 图文素材里的一篇文章.
+type NewsArticle struct {
+	MediaId    string // 所属图文素材的 media_id
+	Index      int    // 在图文素材里的序号, 从 0 开始, 同 UpdateNews 的 index
+	UpdateTime int64  // 所属图文素材的最后更新时间
+	Article
+}
+
+// 图文素材文章遍历器, 把图文素材展开成一篇篇的文章, 用于建立索引, 搜索之类的场景:
+//
+//  for iter.HasNext() {
+//      article, err := iter.Next()
+//      if err == mp.ErrNoMorePage {
+//          break
+//      }
+//      if err != nil {
+//          // TODO: 增加你的代码
+//      }
+//      // TODO: 增加你的代码
+//  }
+//
+//  最后一页可能是空的, 所以 HasNext 返回 true 时 Next 也可能返回 mp.ErrNoMorePage.
+type NewsArticleIterator struct {
+	news     *NewsIterator
+	articles []NewsArticle
+	offsets  []int // articles 对应的图文素材的位置
+}
+
+func (iter *NewsArticleIterator) HasNext() bool {
+	return len(iter.articles) > 0 || iter.news.HasNext()
+}
+
+// 下一篇文章的位置, 可以保存下来以后用它创建新的遍历器继续遍历;
+// 位置是以图文素材为单位的, 如果当前的图文素材还没有遍历完, 继续遍历时会从它的第一篇文章开始.
+func (iter *NewsArticleIterator) Cursor() mp.Cursor {
+	if len(iter.articles) > 0 {
+		return mp.Cursor{Offset: iter.offsets[0]}
+	}
+	return iter.news.Cursor()
+}
+
+// 图文素材的总数(不是文章的总数), 调用 Next 之后才有效.
+func (iter *NewsArticleIterator) Total() int { return iter.news.Total() }
+
+// 返回下一篇文章, 没有更多的文章时返回 mp.ErrNoMorePage.
+//  拉取下一页失败时位置不变, 可以再次调用重试.
+func (iter *NewsArticleIterator) Next() (article *NewsArticle, err error) {
+	for len(iter.articles) == 0 {
+		if !iter.news.HasNext() {
+			err = mp.ErrNoMorePage
+			return
+		}
+		offset := iter.news.Cursor().Offset
+		items, err := iter.news.NextPage()
+		if err != nil {
+			return nil, err
+		}
+		for j, item := range items {
+			for i := range item.Content.Articles {
+				iter.articles = append(iter.articles, NewsArticle{
+					MediaId:    item.MediaId,
+					Index:      i,
+					UpdateTime: item.UpdateTime,
+					Article:    item.Content.Articles[i],
+				})
+				iter.offsets = append(iter.offsets, offset+j)
+			}
+		}
+	}
+
+	article = &iter.articles[0]
+	iter.articles = iter.articles[1:]
+	iter.offsets = iter.offsets[1:]
+	return
+}
+
+// 获取图文素材文章遍历器, 从第 offset 个图文素材开始, 每次拉取 count 个图文素材; count <= 0 时使用 MaterialPageSizeLimit.
+func (clt *Client) NewsArticleIterator(offset, count int, opts ...mp.CallOption) *NewsArticleIterator {
+	return &NewsArticleIterator{
+		news: clt.NewsIterator(offset, count, opts...),
+	}
+}
@@ -0,0 +1,506 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package material
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	ArticleContentRuneLimit = 20000   // 图文消息的 content 必须少于 2 万字符
+	ArticleContentByteLimit = 1 << 20 // 图文消息的 content 必须小于 1M
+)
+
+// 把外部图片上传到微信服务器, 返回微信服务器上的 URL, 一般用 Client.ArticleImageUploader.
+type ImageUploader func(src string) (imageURL string, err error)
+
+// 默认允许的标签, 参考公众平台编辑器能生成的 HTML.
+var DefaultArticleTags = []string{
+	"a", "b", "blockquote", "br", "code", "em", "figcaption", "figure", "h1", "h2", "h3", "h4", "h5", "h6",
+	"hr", "i", "img", "li", "ol", "p", "pre", "s", "section", "span", "strike", "strong", "sub", "sup",
+	"table", "tbody", "td", "tfoot", "th", "thead", "tr", "u", "ul",
+}
+
+var (
+	DefaultArticleImageHosts = []string{"mmbiz.qpic.cn", "mmbiz.qlogo.cn"} // 微信服务器的图片域名
+	DefaultArticleLinkHosts  = []string{"mp.weixin.qq.com"}                // 图文消息里只能链接到公众号文章
+)
+
+// 连同内容一起删除的标签
+var droppedWithContent = map[string]bool{
+	"script": true, "style": true, "iframe": true, "frame": true, "frameset": true, "object": true, "applet": true,
+	"noscript": true, "template": true, "textarea": true, "select": true, "svg": true, "math": true,
+}
+
+// 没有结束标签的元素
+var voidElements = map[string]bool{
+	"br": true, "hr": true, "img": true, "input": true, "embed": true, "meta": true, "link": true, "col": true,
+	"area": true, "base": true, "source": true, "track": true, "wbr": true, "param": true,
+}
+
+// 标签特有的属性; 所有标签都允许 style, align 和 data-* 属性.
+var articleTagAttrs = map[string][]string{
+	"a":     {"href", "title"},
+	"img":   {"src", "alt", "width", "height"},
+	"table": {"width", "border", "cellpadding", "cellspacing"},
+	"td":    {"width", "colspan", "rowspan", "valign"},
+	"th":    {"width", "colspan", "rowspan", "valign"},
+	"ol":    {"start", "type"},
+}
+
+// 图文消息 content 的清理和校验.
+//  微信只支持有限的 HTML 标签, 图片必须使用微信服务器上的 URL(mmbiz.qpic.cn), 否则图片无法显示或者接口报错.
+//  处理规则:
+//  1. 删除注释, script, style, iframe 等标签(连同内容);
+//  2. 不支持的标签只删除标签本身, 保留里面的内容;
+//  3. 删除不支持的属性, 事件属性(onclick 等) 和带有 javascript: 的属性;
+//  4. 外部图片通过 Uploader 上传到微信服务器并替换 URL;
+//  5. 链接到其他域名的 <a> 标签去掉, 保留里面的内容.
+type ContentSanitizer struct {
+	Tags       []string // 允许的标签, 为 nil 时使用 DefaultArticleTags
+	ImageHosts []string // 允许的图片域名(包括子域名), 为 nil 时使用 DefaultArticleImageHosts
+	LinkHosts  []string // 允许链接的域名(包括子域名), 为 nil 时使用 DefaultArticleLinkHosts
+
+	// 外部图片的上传函数, 为 nil 时外部图片保持不变, 记录在 SanitizeReport.ExternalImages 里.
+	Uploader ImageUploader
+}
+
+// 清理的结果
+type SanitizeReport struct {
+	RemovedTags     []string          // 删除的标签名称(去重)
+	RemovedAttrs    []string          // 删除的属性, 格式为 "tag.attr"(去重)
+	UnwrappedLinks  []string          // 去掉的链接
+	RewrittenImages map[string]string // 替换的图片, 原 URL(// 开头的补全为 https:) --> 微信服务器的 URL
+	ExternalImages  []string          // 没有替换的外部图片
+}
+
+// 是否没有做任何修改, 并且没有外部图片
+func (report *SanitizeReport) Clean() bool {
+	return len(report.RemovedTags) == 0 && len(report.RemovedAttrs) == 0 && len(report.UnwrappedLinks) == 0 &&
+		len(report.RewrittenImages) == 0 && len(report.ExternalImages) == 0
+}
+
+func (report *SanitizeReport) String() string {
+	var parts []string
+	if len(report.RemovedTags) > 0 {
+		parts = append(parts, "unsupported tags: "+strings.Join(report.RemovedTags, ", "))
+	}
+	if len(report.RemovedAttrs) > 0 {
+		parts = append(parts, "unsupported attributes: "+strings.Join(report.RemovedAttrs, ", "))
+	}
+	if len(report.UnwrappedLinks) > 0 {
+		parts = append(parts, "unsupported links: "+strings.Join(report.UnwrappedLinks, ", "))
+	}
+	if len(report.ExternalImages) > 0 {
+		parts = append(parts, "external images: "+strings.Join(report.ExternalImages, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+func addUnique(list []string, s string) []string {
+	for _, v := range list {
+		if v == s {
+			return list
+		}
+	}
+	return append(list, s)
+}
+
+func hostAllowed(host string, hosts []string) bool {
+	host = strings.ToLower(host)
+	for _, h := range hosts {
+		if host == h || strings.HasSuffix(host, "."+h) {
+			return true
+		}
+	}
+	return false
+}
+
+// 清理 content, 返回清理以后的 HTML. Uploader 返回错误时停止处理并返回该错误.
+func (s *ContentSanitizer) Sanitize(content string) (out string, report *SanitizeReport, err error) {
+	tags := s.Tags
+	if tags == nil {
+		tags = DefaultArticleTags
+	}
+	allowedTags := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		allowedTags[strings.ToLower(tag)] = true
+	}
+	imageHosts := s.ImageHosts
+	if imageHosts == nil {
+		imageHosts = DefaultArticleImageHosts
+	}
+	linkHosts := s.LinkHosts
+	if linkHosts == nil {
+		linkHosts = DefaultArticleLinkHosts
+	}
+
+	report = &SanitizeReport{}
+	var buf strings.Builder
+	buf.Grow(len(content))
+
+	var (
+		dropTag   string // 正在删除内容的标签
+		dropDepth int
+		unwrapped []bool // 每个打开的 <a> 是否被去掉了, 用于处理对应的 </a>
+	)
+
+	i := 0
+	for i < len(content) {
+		j := strings.IndexByte(content[i:], '<')
+		if j < 0 {
+			if dropTag == "" {
+				buf.WriteString(content[i:])
+			}
+			break
+		}
+		if dropTag == "" {
+			buf.WriteString(content[i : i+j])
+		}
+		i += j
+
+		tok, n := parseTag(content[i:])
+		if n == 0 { // 不是标签, 转义 <
+			if dropTag == "" {
+				buf.WriteString("&lt;")
+			}
+			i++
+			continue
+		}
+		i += n
+
+		if tok == nil { // 注释, <!DOCTYPE> 等
+			continue
+		}
+
+		if dropTag != "" {
+			if tok.name == dropTag {
+				if tok.closing {
+					dropDepth--
+				} else if !tok.selfClosing {
+					dropDepth++
+				}
+				if dropDepth == 0 {
+					dropTag = ""
+				}
+			}
+			continue
+		}
+
+		if droppedWithContent[tok.name] {
+			report.RemovedTags = addUnique(report.RemovedTags, tok.name)
+			if tok.closing || tok.selfClosing {
+				continue
+			}
+			if tok.name == "script" || tok.name == "style" { // 内容是纯文本, 直接找结束标签
+				end := indexFold(content[i:], "</"+tok.name)
+				if end < 0 {
+					i = len(content)
+					continue
+				}
+				i += end
+				if k := strings.IndexByte(content[i:], '>'); k >= 0 {
+					i += k + 1
+				} else {
+					i = len(content)
+				}
+				continue
+			}
+			dropTag, dropDepth = tok.name, 1
+			continue
+		}
+
+		if !allowedTags[tok.name] {
+			report.RemovedTags = addUnique(report.RemovedTags, tok.name)
+			continue
+		}
+
+		if tok.name == "a" {
+			if tok.closing {
+				if len(unwrapped) > 0 {
+					drop := unwrapped[len(unwrapped)-1]
+					unwrapped = unwrapped[:len(unwrapped)-1]
+					if drop {
+						continue
+					}
+				}
+				buf.WriteString("</a>")
+				continue
+			}
+			href := tok.attr("href")
+			if href != "" {
+				u, err := url.Parse(href)
+				if err != nil || !hostAllowed(u.Host, linkHosts) {
+					report.UnwrappedLinks = addUnique(report.UnwrappedLinks, href)
+					unwrapped = append(unwrapped, true)
+					continue
+				}
+			}
+			unwrapped = append(unwrapped, false)
+		}
+
+		if tok.closing {
+			if !voidElements[tok.name] {
+				buf.WriteString("</" + tok.name + ">")
+			}
+			continue
+		}
+
+		if tok.name == "img" {
+			if err = s.rewriteImage(tok, imageHosts, report); err != nil {
+				return "", report, err
+			}
+		}
+		s.writeTag(&buf, tok, report)
+	}
+
+	sort.Strings(report.RemovedTags)
+	sort.Strings(report.RemovedAttrs)
+	return buf.String(), report, nil
+}
+
+// 处理 <img> 的 src 和 data-src
+func (s *ContentSanitizer) rewriteImage(tok *tagToken, imageHosts []string, report *SanitizeReport) error {
+	for k := range tok.attrs {
+		attr := &tok.attrs[k]
+		if attr.name != "src" && attr.name != "data-src" {
+			continue
+		}
+		src := attr.value
+		if src == "" {
+			continue
+		}
+		if strings.HasPrefix(src, "//") {
+			src = "https:" + src
+		}
+		u, err := url.Parse(src)
+		if err == nil && hostAllowed(u.Host, imageHosts) {
+			continue
+		}
+		if newURL, ok := report.RewrittenImages[src]; ok {
+			attr.value = newURL
+			continue
+		}
+		if s.Uploader == nil || err != nil || (u.Scheme != "http" && u.Scheme != "https") {
+			report.ExternalImages = addUnique(report.ExternalImages, attr.value)
+			continue
+		}
+		newURL, err := s.Uploader(src)
+		if err != nil {
+			return fmt.Errorf("upload image %s: %v", attr.value, err)
+		}
+		if report.RewrittenImages == nil {
+			report.RewrittenImages = make(map[string]string)
+		}
+		report.RewrittenImages[src] = newURL
+		attr.value = newURL
+	}
+	return nil
+}
+
+func attrAllowed(tag, name string) bool {
+	switch {
+	case name == "style", name == "align":
+		return true
+	case strings.HasPrefix(name, "data-"):
+		return true
+	}
+	for _, attr := range articleTagAttrs[tag] {
+		if attr == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *ContentSanitizer) writeTag(buf *strings.Builder, tok *tagToken, report *SanitizeReport) {
+	buf.WriteString("<" + tok.name)
+	for _, attr := range tok.attrs {
+		lower := strings.ToLower(attr.value)
+		if !attrAllowed(tok.name, attr.name) || strings.Contains(lower, "javascript:") || strings.Contains(lower, "expression(") {
+			report.RemovedAttrs = addUnique(report.RemovedAttrs, tok.name+"."+attr.name)
+			continue
+		}
+		buf.WriteString(" " + attr.name + `="` + strings.Replace(attr.value, `"`, "&quot;", -1) + `"`)
+	}
+	if voidElements[tok.name] {
+		buf.WriteString(" />")
+	} else {
+		buf.WriteString(">")
+	}
+}
+
+// 校验 content 是否可以直接提交: 长度在限制以内, 不需要清理, 并且没有外部图片.
+func (s *ContentSanitizer) Validate(content string) error {
+	if n := utf8.RuneCountInString(content); n >= ArticleContentRuneLimit {
+		return fmt.Errorf("content too long: %d characters", n)
+	}
+	if len(content) >= ArticleContentByteLimit {
+		return fmt.Errorf("content too large: %d bytes", len(content))
+	}
+	validator := *s
+	validator.Uploader = nil
+	_, report, err := validator.Sanitize(content)
+	if err != nil {
+		return err
+	}
+	if !report.Clean() {
+		return errors.New("invalid content: " + report.String())
+	}
+	return nil
+}
+
+// 清理 news 里所有文章的 Content.
+func (s *ContentSanitizer) SanitizeNews(news News) (reports []*SanitizeReport, err error) {
+	reports = make([]*SanitizeReport, len(news))
+	for i := range news {
+		content, report, err := s.Sanitize(news[i].Content)
+		if err != nil {
+			return nil, err
+		}
+		news[i].Content = content
+		reports[i] = report
+	}
+	return
+}
+
+// =============================================================================
+
+type tagAttr struct {
+	name  string // 小写
+	value string // 没有解码 HTML 实体
+}
+
+type tagToken struct {
+	name        string // 小写
+	closing     bool   // </tag>
+	selfClosing bool   // <tag />
+	attrs       []tagAttr
+}
+
+func (tok *tagToken) attr(name string) string {
+	for _, attr := range tok.attrs {
+		if attr.name == name {
+			return attr.value
+		}
+	}
+	return ""
+}
+
+func isTagNameByte(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' || c == '-' || c == ':'
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r' || c == '\f'
+}
+
+// 解析 s 开头的标签, s[0] == '<'.
+//  n == 0 表示不是标签; tok == nil 表示注释, <!DOCTYPE> 等需要忽略的内容.
+func parseTag(s string) (tok *tagToken, n int) {
+	if strings.HasPrefix(s, "<!--") {
+		end := strings.Index(s[4:], "-->")
+		if end < 0 {
+			return nil, len(s)
+		}
+		return nil, 4 + end + 3
+	}
+	if len(s) < 2 {
+		return nil, 0
+	}
+	if s[1] == '!' || s[1] == '?' {
+		end := strings.IndexByte(s, '>')
+		if end < 0 {
+			return nil, len(s)
+		}
+		return nil, end + 1
+	}
+
+	i := 1
+	tok = &tagToken{}
+	if s[i] == '/' {
+		tok.closing = true
+		i++
+	}
+	start := i
+	for i < len(s) && isTagNameByte(s[i]) {
+		i++
+	}
+	if i == start || !('a' <= s[start]|0x20 && s[start]|0x20 <= 'z') {
+		return nil, 0
+	}
+	tok.name = strings.ToLower(s[start:i])
+
+	for i < len(s) {
+		for i < len(s) && isSpace(s[i]) {
+			i++
+		}
+		if i >= len(s) {
+			break
+		}
+		switch s[i] {
+		case '>':
+			return tok, i + 1
+		case '/':
+			i++
+			if i < len(s) && s[i] == '>' {
+				tok.selfClosing = true
+				return tok, i + 1
+			}
+			continue
+		}
+
+		start = i
+		for i < len(s) && !isSpace(s[i]) && s[i] != '=' && s[i] != '>' && s[i] != '/' {
+			i++
+		}
+		attr := tagAttr{name: strings.ToLower(s[start:i])}
+		for i < len(s) && isSpace(s[i]) {
+			i++
+		}
+		if i < len(s) && s[i] == '=' {
+			i++
+			for i < len(s) && isSpace(s[i]) {
+				i++
+			}
+			if i < len(s) && (s[i] == '"' || s[i] == '\'') {
+				quote := s[i]
+				end := strings.IndexByte(s[i+1:], quote)
+				if end < 0 {
+					return tok, len(s)
+				}
+				attr.value = s[i+1 : i+1+end]
+				i += 1 + end + 1
+			} else {
+				start = i
+				for i < len(s) && !isSpace(s[i]) && s[i] != '>' {
+					i++
+				}
+				attr.value = s[start:i]
+			}
+		}
+		if attr.name != "" && !tok.closing {
+			tok.attrs = append(tok.attrs, attr)
+		}
+	}
+	return tok, len(s)
+}
+
+// 忽略大小写查找 substr(ASCII)
+func indexFold(s, substr string) int {
+	n := len(substr)
+	for i := 0; i+n <= len(s); i++ {
+		if strings.EqualFold(s[i:i+n], substr) {
+			return i
+		}
+	}
+	return -1
+}
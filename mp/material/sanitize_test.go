@@ -0,0 +1,149 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package material
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestContentSanitizerSanitize(t *testing.T) {
+	tests := []struct {
+		name        string
+		content     string
+		want        string
+		wantTags    []string
+		wantAttrs   []string
+		wantLinks   []string
+		wantExtImgs []string
+	}{
+		{
+			"支持的内容不修改",
+			`<p style="color:red">你好<br/><strong>世界</strong></p>`,
+			`<p style="color:red">你好<br /><strong>世界</strong></p>`,
+			nil, nil, nil, nil,
+		},
+		{
+			"删除 script 和注释",
+			`<p>a</p><!-- 注释 --><SCRIPT>alert("</p>")</script ><p>b</p>`,
+			`<p>a</p><p>b</p>`,
+			[]string{"script"}, nil, nil, nil,
+		},
+		{
+			"嵌套的 iframe 连同内容删除",
+			`<iframe><iframe>x</iframe>y</iframe>z`,
+			`z`,
+			[]string{"iframe"}, nil, nil, nil,
+		},
+		{
+			"不支持的标签保留内容",
+			`<div><font color="red">文字</font></div>`,
+			`文字`,
+			[]string{"div", "font"}, nil, nil, nil,
+		},
+		{
+			"删除事件属性和 javascript:",
+			`<p onclick="x()" class="c">a</p><a href="javascript:alert(1)">b</a>`,
+			`<p>a</p>b`,
+			nil, []string{"p.class", "p.onclick"}, []string{"javascript:alert(1)"}, nil,
+		},
+		{
+			"删除带 expression 的 style",
+			`<span style="width:expression(alert(1))">a</span>`,
+			`<span>a</span>`,
+			nil, []string{"span.style"}, nil, nil,
+		},
+		{
+			"去掉外部链接保留内容",
+			`<a href="https://example.com/">外部</a><a href="https://mp.weixin.qq.com/s/abc">文章</a>`,
+			`外部<a href="https://mp.weixin.qq.com/s/abc">文章</a>`,
+			nil, nil, []string{"https://example.com/"}, nil,
+		},
+		{
+			"外部图片没有 Uploader",
+			`<img src="http://example.com/a.png"><img src="https://mmbiz.qpic.cn/a.png">`,
+			`<img src="http://example.com/a.png" /><img src="https://mmbiz.qpic.cn/a.png" />`,
+			nil, nil, nil, []string{"http://example.com/a.png"},
+		},
+		{
+			"转义不是标签的 <",
+			`1 < 2 <p title='"'>a</p>`,
+			`1 &lt; 2 <p>a</p>`,
+			nil, []string{"p.title"}, nil, nil,
+		},
+	}
+	for _, tt := range tests {
+		var s ContentSanitizer
+		out, report, err := s.Sanitize(tt.content)
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		if out != tt.want {
+			t.Errorf("%s:\nhave %s\nwant %s", tt.name, out, tt.want)
+		}
+		if !reflect.DeepEqual(report.RemovedTags, tt.wantTags) || !reflect.DeepEqual(report.RemovedAttrs, tt.wantAttrs) ||
+			!reflect.DeepEqual(report.UnwrappedLinks, tt.wantLinks) || !reflect.DeepEqual(report.ExternalImages, tt.wantExtImgs) {
+			t.Errorf("%s: have report %+v", tt.name, report)
+		}
+		if clean := tt.wantTags == nil && tt.wantAttrs == nil && tt.wantLinks == nil && tt.wantExtImgs == nil; report.Clean() != clean {
+			t.Errorf("%s: have Clean() %v, want %v", tt.name, report.Clean(), clean)
+		}
+	}
+}
+
+func TestContentSanitizerUploader(t *testing.T) {
+	var uploaded []string
+	s := ContentSanitizer{
+		Uploader: func(src string) (string, error) {
+			uploaded = append(uploaded, src)
+			return "https://mmbiz.qpic.cn/" + src[strings.LastIndexByte(src, '/')+1:], nil
+		},
+	}
+	content := `<img src="//example.com/a.png" data-src="https://example.com/a.png"><img src="data:image/png;base64,xx">`
+	out, report, err := s.Sanitize(content)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `<img src="https://mmbiz.qpic.cn/a.png" data-src="https://mmbiz.qpic.cn/a.png" /><img src="data:image/png;base64,xx" />`
+	if out != want {
+		t.Errorf("have %s, want %s", out, want)
+	}
+	if len(uploaded) != 1 {
+		t.Errorf("相同的图片上传了 %d 次", len(uploaded))
+	}
+	if !reflect.DeepEqual(report.ExternalImages, []string{"data:image/png;base64,xx"}) {
+		t.Errorf("have ExternalImages %q", report.ExternalImages)
+	}
+
+	s.Uploader = func(src string) (string, error) { return "", errors.New("upload failed") }
+	if _, _, err = s.Sanitize(content); err == nil {
+		t.Error("Uploader 返回错误时应该返回错误")
+	}
+}
+
+func TestContentSanitizerValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr bool
+	}{
+		{"合法的内容", `<p>你好</p>`, false},
+		{"需要清理", `<p onclick="x()">你好</p>`, true},
+		{"外部图片", `<img src="https://example.com/a.png" />`, true},
+		{"超过字数", strings.Repeat("字", ArticleContentRuneLimit), true},
+	}
+	s := ContentSanitizer{
+		Uploader: func(src string) (string, error) { return "https://mmbiz.qpic.cn/a.png", nil },
+	}
+	for _, tt := range tests {
+		if err := s.Validate(tt.content); (err != nil) != tt.wantErr {
+			t.Errorf("%s: have %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
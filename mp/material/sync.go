@@ -0,0 +1,419 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package material
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	DefaultSyncManifestFile = "manifest.json"          // 默认的清单文件, 在同步的目录下
+	DefaultSyncStateFile    = ".wechat-materials.json" // 默认的状态文件, 在同步的目录下
+)
+
+// 清单文件里的一个素材, 用来指定素材类型和视频的标题, 描述.
+type SyncManifestAsset struct {
+	File         string `json:"file"`                   // 相对同步目录的路径, 用 / 分隔
+	Type         string `json:"type,omitempty"`         // MaterialTypeImage, MaterialTypeThumb, MaterialTypeVoice, MaterialTypeVideo; 为空时按扩展名判断
+	Title        string `json:"title,omitempty"`        // 视频的标题, 为空时使用文件名
+	Introduction string `json:"introduction,omitempty"` // 视频的描述
+	Ignore       bool   `json:"ignore,omitempty"`       // 不同步这个文件
+}
+
+// 清单文件的格式
+type SyncManifest struct {
+	Assets []SyncManifestAsset `json:"assets"`
+}
+
+// 状态文件里记录的已上传素材
+type SyncedAsset struct {
+	MediaId    string    `json:"media_id"`
+	Type       string    `json:"type"`
+	Digest     string    `json:"digest"` // 文件内容和视频标题, 描述的 sha256
+	Size       int64     `json:"size"`
+	UploadedAt time.Time `json:"uploaded_at"`
+}
+
+// 状态文件的格式
+type SyncState struct {
+	Assets map[string]SyncedAsset `json:"assets"` // 相对同步目录的路径 --> 已上传的素材
+
+	// 文件修改以后被替换掉, 还没有删除成功的旧素材: media_id --> 原来的文件路径.
+	// 删除成功之前一直记录在这里, 避免删除失败以后素材泄漏, 一直占用素材的数量限制.
+	PendingDeletes map[string]string `json:"pending_deletes,omitempty"`
+}
+
+// 同步计划里的一个素材
+type SyncItem struct {
+	File     string
+	Manifest SyncManifestAsset
+	Digest   string
+	Size     int64
+	Old      *SyncedAsset // 修改和孤立的素材在状态文件里的记录, 新的素材为 nil
+}
+
+// 同步计划, 类似于 terraform plan 的结果.
+type SyncPlan struct {
+	Create    []SyncItem // 新的文件, 需要上传
+	Update    []SyncItem // 修改过的文件, 需要重新上传并删除原来的素材
+	Unchanged []string   // 没有修改的文件
+	Orphans   []SyncItem // 状态文件里有但是目录里已经没有的素材
+
+	// 之前 Apply 时删除失败的旧素材(见 SyncState.PendingDeletes), 不管 deleteOrphans 都会删除
+	PendingDeletes []SyncItem
+}
+
+// 是否需要上传或者删除
+func (plan *SyncPlan) Empty() bool {
+	return len(plan.Create) == 0 && len(plan.Update) == 0 && len(plan.Orphans) == 0 && len(plan.PendingDeletes) == 0
+}
+
+// 把本地目录里的图片, 语音, 视频同步为永久素材, 上传的结果记录在状态文件里.
+//
+//  syncer := material.NewSyncer(clt, "./assets")
+//  plan, err := syncer.Plan()
+//  if err != nil {
+//      // TODO: 增加你的代码
+//  }
+//  // 检查 plan 以后再执行
+//  if err = syncer.Apply(plan, false); err != nil {
+//      // TODO: 增加你的代码
+//  }
+//  mediaId := syncer.State().Assets["images/logo.png"].MediaId
+type Syncer struct {
+	Client       *Client
+	Dir          string
+	ManifestFile string // 清单文件的路径, 为空时使用 Dir 下的 DefaultSyncManifestFile; 清单文件可以不存在
+	StateFile    string // 状态文件的路径, 为空时使用 Dir 下的 DefaultSyncStateFile
+
+	state *SyncState
+}
+
+func NewSyncer(clt *Client, dir string) *Syncer {
+	if clt == nil {
+		panic("nil Client")
+	}
+	return &Syncer{
+		Client: clt,
+		Dir:    dir,
+	}
+}
+
+func (s *Syncer) manifestFile() string {
+	if s.ManifestFile != "" {
+		return s.ManifestFile
+	}
+	return filepath.Join(s.Dir, DefaultSyncManifestFile)
+}
+
+func (s *Syncer) stateFile() string {
+	if s.StateFile != "" {
+		return s.StateFile
+	}
+	return filepath.Join(s.Dir, DefaultSyncStateFile)
+}
+
+// 返回状态文件的内容, 没有状态文件时返回空的 SyncState.
+func (s *Syncer) State() *SyncState {
+	if s.state == nil {
+		if err := s.loadState(); err != nil {
+			return &SyncState{Assets: make(map[string]SyncedAsset)}
+		}
+	}
+	return s.state
+}
+
+func (s *Syncer) loadState() error {
+	state := &SyncState{}
+	data, err := ioutil.ReadFile(s.stateFile())
+	switch {
+	case os.IsNotExist(err):
+	case err != nil:
+		return err
+	default:
+		if err = json.Unmarshal(data, state); err != nil {
+			return err
+		}
+	}
+	if state.Assets == nil {
+		state.Assets = make(map[string]SyncedAsset)
+	}
+	s.state = state
+	return nil
+}
+
+// 先写临时文件再重命名, 避免进程中途退出把状态文件写坏.
+func (s *Syncer) saveState() (err error) {
+	data, err := json.MarshalIndent(s.state, "", "\t")
+	if err != nil {
+		return
+	}
+	path := s.stateFile()
+	tmp := path + ".tmp"
+	if err = ioutil.WriteFile(tmp, data, 0644); err != nil {
+		return
+	}
+	return os.Rename(tmp, path)
+}
+
+func (s *Syncer) loadManifest() (assets map[string]SyncManifestAsset, err error) {
+	assets = make(map[string]SyncManifestAsset)
+	data, err := ioutil.ReadFile(s.manifestFile())
+	if os.IsNotExist(err) {
+		return assets, nil
+	}
+	if err != nil {
+		return
+	}
+	var manifest SyncManifest
+	if err = json.Unmarshal(data, &manifest); err != nil {
+		return
+	}
+	for _, asset := range manifest.Assets {
+		assets[filepath.ToSlash(filepath.Clean(asset.File))] = asset
+	}
+	return
+}
+
+// 根据扩展名判断素材类型, 不支持的扩展名返回 "".
+func syncMaterialType(file string) string {
+	switch strings.ToLower(filepath.Ext(file)) {
+	case ".jpg", ".jpeg", ".png", ".gif", ".bmp":
+		return MaterialTypeImage
+	case ".mp3", ".amr", ".wma", ".wav":
+		return MaterialTypeVoice
+	case ".mp4":
+		return MaterialTypeVideo
+	}
+	return ""
+}
+
+func syncDigest(path string, asset *SyncManifestAsset) (digest string, size int64, err error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	h := sha256.New()
+	if size, err = io.Copy(h, file); err != nil {
+		return
+	}
+	if asset.Type == MaterialTypeVideo {
+		fmt.Fprintf(h, "\x00%s\x00%s", asset.Title, asset.Introduction)
+	}
+	return hex.EncodeToString(h.Sum(nil)), size, nil
+}
+
+// 扫描目录, 对比状态文件, 生成同步计划. 以 . 开头的文件和目录, 清单文件, 不支持的扩展名会被忽略.
+func (s *Syncer) Plan() (plan *SyncPlan, err error) {
+	if err = s.loadState(); err != nil {
+		return
+	}
+	manifest, err := s.loadManifest()
+	if err != nil {
+		return
+	}
+
+	plan = &SyncPlan{}
+	seen := make(map[string]bool)
+	manifestFile, _ := filepath.Abs(s.manifestFile())
+	stateFile, _ := filepath.Abs(s.stateFile())
+
+	err = filepath.Walk(s.Dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if path != s.Dir && strings.HasPrefix(info.Name(), ".") {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if info.IsDir() {
+			return nil
+		}
+		if abs, _ := filepath.Abs(path); abs == manifestFile || abs == stateFile {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.Dir, path)
+		if err != nil {
+			return err
+		}
+		rel = filepath.ToSlash(rel)
+
+		asset := manifest[rel]
+		asset.File = rel
+		if asset.Ignore {
+			return nil
+		}
+		if asset.Type == "" {
+			if asset.Type = syncMaterialType(rel); asset.Type == "" {
+				return nil
+			}
+		}
+		if asset.Type == MaterialTypeVideo && asset.Title == "" {
+			asset.Title = strings.TrimSuffix(filepath.Base(rel), filepath.Ext(rel))
+		}
+
+		digest, size, err := syncDigest(path, &asset)
+		if err != nil {
+			return err
+		}
+		seen[rel] = true
+
+		item := SyncItem{File: rel, Manifest: asset, Digest: digest, Size: size}
+		old, ok := s.state.Assets[rel]
+		switch {
+		case !ok:
+			plan.Create = append(plan.Create, item)
+		case old.Digest != digest || old.Type != asset.Type:
+			item.Old = &old
+			plan.Update = append(plan.Update, item)
+		default:
+			plan.Unchanged = append(plan.Unchanged, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	for file, old := range s.state.Assets {
+		if !seen[file] {
+			old := old
+			plan.Orphans = append(plan.Orphans, SyncItem{File: file, Old: &old})
+		}
+	}
+	sort.Slice(plan.Orphans, func(i, j int) bool { return plan.Orphans[i].File < plan.Orphans[j].File })
+
+	for mediaId, file := range s.state.PendingDeletes {
+		plan.PendingDeletes = append(plan.PendingDeletes, SyncItem{File: file, Old: &SyncedAsset{MediaId: mediaId}})
+	}
+	sort.Slice(plan.PendingDeletes, func(i, j int) bool {
+		return plan.PendingDeletes[i].Old.MediaId < plan.PendingDeletes[j].Old.MediaId
+	})
+	return
+}
+
+func (s *Syncer) upload(item *SyncItem, opts ...mp.CallOption) (mediaId string, err error) {
+	path := filepath.Join(s.Dir, filepath.FromSlash(item.File))
+	switch item.Manifest.Type {
+	case MaterialTypeImage:
+		return s.Client.UploadImage(path, opts...)
+	case MaterialTypeThumb:
+		return s.Client.UploadThumb(path, opts...)
+	case MaterialTypeVoice:
+		return s.Client.UploadVoice(path, opts...)
+	case MaterialTypeVideo:
+		return s.Client.UploadVideo(path, item.Manifest.Title, item.Manifest.Introduction, opts...)
+	}
+	return "", errors.New("unsupported material type: " + item.Manifest.Type)
+}
+
+// 执行同步计划: 上传新的和修改过的文件, 删除修改过的文件原来的素材; deleteOrphans 为 true 时删除孤立的素材.
+//  每个素材处理成功以后马上更新状态文件, 中途失败的话重新 Plan 和 Apply 就可以继续;
+//  删除失败的旧素材记录在 SyncState.PendingDeletes 里, 下一次 Apply 时再删除.
+func (s *Syncer) Apply(plan *SyncPlan, deleteOrphans bool, opts ...mp.CallOption) (err error) {
+	if s.state == nil {
+		if err = s.loadState(); err != nil {
+			return
+		}
+	}
+
+	for _, item := range plan.PendingDeletes {
+		if err = s.deletePending(item.Old.MediaId, opts...); err != nil {
+			return fmt.Errorf("delete old material of %s: %v", item.File, err)
+		}
+	}
+
+	items := make([]SyncItem, 0, len(plan.Create)+len(plan.Update))
+	items = append(items, plan.Create...)
+	items = append(items, plan.Update...)
+	for i := range items {
+		item := &items[i]
+		mediaId, err := s.upload(item, opts...)
+		if err != nil {
+			return fmt.Errorf("upload %s: %v", item.File, err)
+		}
+		s.state.Assets[item.File] = SyncedAsset{
+			MediaId:    mediaId,
+			Type:       item.Manifest.Type,
+			Digest:     item.Digest,
+			Size:       item.Size,
+			UploadedAt: time.Now(),
+		}
+		// 新的 media_id 和待删除的旧 media_id 一起保存, 删除成功以后才去掉旧的
+		if item.Old != nil && item.Old.MediaId != "" {
+			if s.state.PendingDeletes == nil {
+				s.state.PendingDeletes = make(map[string]string)
+			}
+			s.state.PendingDeletes[item.Old.MediaId] = item.File
+		}
+		if err = s.saveState(); err != nil {
+			return err
+		}
+		if item.Old != nil && item.Old.MediaId != "" {
+			if err = s.deletePending(item.Old.MediaId, opts...); err != nil {
+				return fmt.Errorf("delete old material of %s: %v", item.File, err)
+			}
+		}
+	}
+
+	if deleteOrphans {
+		for _, item := range plan.Orphans {
+			if err = s.deleteMaterial(item.Old.MediaId, opts...); err != nil {
+				return fmt.Errorf("delete orphan %s: %v", item.File, err)
+			}
+			delete(s.state.Assets, item.File)
+			if err = s.saveState(); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// 删除 SyncState.PendingDeletes 里的素材, 成功以后从状态文件里去掉.
+func (s *Syncer) deletePending(mediaId string, opts ...mp.CallOption) (err error) {
+	if err = s.deleteMaterial(mediaId, opts...); err != nil {
+		return
+	}
+	delete(s.state.PendingDeletes, mediaId)
+	return s.saveState()
+}
+
+// 删除素材, 素材已经不存在时不返回错误.
+func (s *Syncer) deleteMaterial(mediaId string, opts ...mp.CallOption) error {
+	err := s.Client.DeleteMaterial(mediaId, opts...)
+	if e, ok := err.(*mp.Error); ok && e.ErrCode == mp.ErrCodeInvalidMediaId {
+		return nil
+	}
+	return err
+}
+
+// Plan 以后马上 Apply.
+func (s *Syncer) Sync(deleteOrphans bool, opts ...mp.CallOption) (plan *SyncPlan, err error) {
+	if plan, err = s.Plan(); err != nil {
+		return
+	}
+	err = s.Apply(plan, deleteOrphans, opts...)
+	return
+}
@@ -0,0 +1,118 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package material
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+type testTokenServer struct{}
+
+func (testTokenServer) Token() (string, error)        { return "ACCESS_TOKEN", nil }
+func (testTokenServer) TokenRefresh() (string, error) { return "ACCESS_TOKEN", nil }
+
+// 模拟素材接口的服务器, failDelete 为 true 时删除素材返回错误.
+type testMaterialServer struct {
+	mutex      sync.Mutex
+	uploads    int
+	deleted    []string
+	failDelete bool
+}
+
+func (srv *testMaterialServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	srv.mutex.Lock()
+	defer srv.mutex.Unlock()
+
+	switch r.URL.Path {
+	case "/cgi-bin/material/add_material":
+		srv.uploads++
+		fmt.Fprintf(w, `{"media_id":"media-%d"}`, srv.uploads)
+	case "/cgi-bin/material/del_material":
+		if srv.failDelete {
+			fmt.Fprintf(w, `{"errcode":%d,"errmsg":"api freq out of limit"}`, mp.ErrCodeAPIFreqLimit)
+			return
+		}
+		var request struct {
+			MediaId string `json:"media_id"`
+		}
+		json.NewDecoder(r.Body).Decode(&request)
+		srv.deleted = append(srv.deleted, request.MediaId)
+		w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func TestSyncerApplyDeleteFailed(t *testing.T) {
+	srv := &testMaterialServer{}
+	httpSrv := httptest.NewServer(srv)
+	defer httpSrv.Close()
+	transport, err := mp.NewBaseURLTransport(httpSrv.URL, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	httpClient := &http.Client{Transport: transport}
+	clt := NewClientWithMediaHttpClient(testTokenServer{}, httpClient, httpClient)
+
+	dir, err := ioutil.TempDir("", "material_sync")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	logo := filepath.Join(dir, "logo.png")
+
+	ioutil.WriteFile(logo, []byte("v1"), 0644)
+	if _, err = NewSyncer(clt, dir).Sync(false); err != nil {
+		t.Fatal(err)
+	}
+
+	// 修改文件以后删除旧素材失败
+	ioutil.WriteFile(logo, []byte("v2"), 0644)
+	srv.failDelete = true
+	if _, err = NewSyncer(clt, dir).Sync(false); err == nil {
+		t.Fatal("删除失败时 Apply 应该返回错误")
+	}
+
+	syncer := NewSyncer(clt, dir)
+	plan, err := syncer.Plan()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if asset := syncer.State().Assets["logo.png"]; asset.MediaId != "media-2" {
+		t.Errorf("have MediaId %q, want media-2", asset.MediaId)
+	}
+	if len(plan.Update) != 0 || len(plan.PendingDeletes) != 1 || plan.PendingDeletes[0].Old.MediaId != "media-1" {
+		t.Fatalf("删除失败的旧素材没有记录下来: %+v", plan)
+	}
+	if plan.Empty() {
+		t.Error("有待删除的素材时 plan 不应该是空的")
+	}
+
+	// 下一次 Apply 删除旧素材, 不需要 deleteOrphans
+	srv.failDelete = false
+	if err = syncer.Apply(plan, false); err != nil {
+		t.Fatal(err)
+	}
+	if len(srv.deleted) != 1 || srv.deleted[0] != "media-1" {
+		t.Errorf("have deleted %q, want [media-1]", srv.deleted)
+	}
+	if plan, err = NewSyncer(clt, dir).Plan(); err != nil || !plan.Empty() {
+		t.Errorf("删除成功以后 plan 应该是空的: %+v, %v", plan, err)
+	}
+	if srv.uploads != 2 {
+		t.Errorf("上传了 %d 次, want 2", srv.uploads)
+	}
+}
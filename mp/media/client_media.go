@@ -27,7 +27,8 @@ func (clt *Client) DownloadMedia(mediaId, filepath string) (err error) {
 	}
 	defer file.Close()
 
-	return clt.downloadMediaToWriter(mediaId, file)
+	_, err = clt.downloadMediaToWriter(mediaId, file)
+	return
 }
 
 // 下载多媒体到 io.Writer.
@@ -36,11 +37,12 @@ func (clt *Client) DownloadMediaToWriter(mediaId string, writer io.Writer) error
 	if writer == nil {
 		return errors.New("nil writer")
 	}
-	return clt.downloadMediaToWriter(mediaId, writer)
+	_, err := clt.downloadMediaToWriter(mediaId, writer)
+	return err
 }
 
-// 下载多媒体到 io.Writer.
-func (clt *Client) downloadMediaToWriter(mediaId string, writer io.Writer) (err error) {
+// 下载多媒体到 io.Writer, 返回 http 响应头.
+func (clt *Client) downloadMediaToWriter(mediaId string, writer io.Writer) (header http.Header, err error) {
 	token, err := clt.Token()
 	if err != nil {
 		return
@@ -51,18 +53,20 @@ RETRY:
 	finalURL := "https://api.weixin.qq.com/cgi-bin/media/get?media_id=" + url.QueryEscape(mediaId) +
 		"&access_token=" + url.QueryEscape(token)
 
-	httpResp, err := clt.HttpClient.Get(finalURL)
+	httpResp, err := clt.MediaClient().Get(finalURL)
 	if err != nil {
 		return
 	}
 	defer httpResp.Body.Close()
 
 	if httpResp.StatusCode != http.StatusOK {
-		return fmt.Errorf("http.Status: %s", httpResp.Status)
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
 	}
 
 	ContentType, _, _ := mime.ParseMediaType(httpResp.Header.Get("Content-Type"))
 	if ContentType != "text/plain" && ContentType != "application/json" { // 返回的是媒体流
+		header = httpResp.Header
 		_, err = io.Copy(writer, httpResp.Body)
 		return
 	}
@@ -93,7 +97,7 @@ RETRY:
 }
 
 // 创建图文消息素材.
-func (clt *Client) CreateNews(articles []Article) (info *MediaInfo, err error) {
+func (clt *Client) CreateNews(articles []Article, opts ...mp.CallOption) (info *MediaInfo, err error) {
 	if len(articles) == 0 {
 		err = errors.New("图文素材是空的")
 		return
@@ -115,7 +119,7 @@ func (clt *Client) CreateNews(articles []Article) (info *MediaInfo, err error) {
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/media/uploadnews?access_token="
-	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
 		return
 	}
 
@@ -131,7 +135,7 @@ func (clt *Client) CreateNews(articles []Article) (info *MediaInfo, err error) {
 //  mediaId:     通过上传视频文件得到
 //  title:       标题, 可以为空
 //  description: 描述, 可以为空
-func (clt *Client) CreateVideo(mediaId, title, description string) (info *MediaInfo, err error) {
+func (clt *Client) CreateVideo(mediaId, title, description string, opts ...mp.CallOption) (info *MediaInfo, err error) {
 	if mediaId == "" {
 		err = errors.New("empty mediaId")
 		return
@@ -152,7 +156,7 @@ func (clt *Client) CreateVideo(mediaId, title, description string) (info *MediaI
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/media/uploadvideo?access_token="
-	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
 		return
 	}
 
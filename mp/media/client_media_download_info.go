@@ -0,0 +1,62 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package media
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 下载多媒体到 io.Writer, 并返回识别出的格式和服务器建议的文件名.
+func (clt *Client) DownloadMediaWithInfo(mediaId string, writer io.Writer) (info *mp.MediaDownloadInfo, err error) {
+	if writer == nil {
+		err = errors.New("nil writer")
+		return
+	}
+
+	sniffWriter := mp.NewMediaSniffWriter(writer)
+	header, err := clt.downloadMediaToWriter(mediaId, sniffWriter)
+	if err != nil {
+		return
+	}
+	info = sniffWriter.Info(header)
+	return
+}
+
+// 下载多媒体到目录 dir, 返回保存的文件路径.
+//  文件名优先使用服务器通过 Content-Disposition 指定的, 否则为 mediaId + 识别出的扩展名, 参考 mp.MediaDownloadInfo.SuggestFilename.
+func (clt *Client) DownloadMediaToDir(mediaId, dir string) (path string, info *mp.MediaDownloadInfo, err error) {
+	file, err := ioutil.TempFile(dir, ".download-")
+	if err != nil {
+		return
+	}
+	tempPath := file.Name()
+	defer func() {
+		if err != nil {
+			os.Remove(tempPath)
+		}
+	}()
+
+	info, err = clt.DownloadMediaWithInfo(mediaId, file)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return
+	}
+
+	path = filepath.Join(dir, info.SuggestFilename(mediaId))
+	if err = os.Rename(tempPath, path); err != nil {
+		path = ""
+		return
+	}
+	return
+}
@@ -16,34 +16,34 @@ import (
 )
 
 // 上传多媒体图片
-func (clt *Client) UploadImage(filepath string) (info *MediaInfo, err error) {
-	return clt.uploadMedia(MediaTypeImage, filepath)
+func (clt *Client) UploadImage(filepath string, opts ...mp.CallOption) (info *MediaInfo, err error) {
+	return clt.uploadMedia(MediaTypeImage, filepath, opts...)
 }
 
 // 上传多媒体语音
-func (clt *Client) UploadVoice(filepath string) (info *MediaInfo, err error) {
-	return clt.uploadMedia(MediaTypeVoice, filepath)
+func (clt *Client) UploadVoice(filepath string, opts ...mp.CallOption) (info *MediaInfo, err error) {
+	return clt.uploadMedia(MediaTypeVoice, filepath, opts...)
 }
 
 // 上传多媒体视频
-func (clt *Client) UploadVideo(filepath string) (info *MediaInfo, err error) {
-	return clt.uploadMedia(MediaTypeVideo, filepath)
+func (clt *Client) UploadVideo(filepath string, opts ...mp.CallOption) (info *MediaInfo, err error) {
+	return clt.uploadMedia(MediaTypeVideo, filepath, opts...)
 }
 
 // 上传多媒体
-func (clt *Client) uploadMedia(mediaType, _filepath string) (info *MediaInfo, err error) {
+func (clt *Client) uploadMedia(mediaType, _filepath string, opts ...mp.CallOption) (info *MediaInfo, err error) {
 	file, err := os.Open(_filepath)
 	if err != nil {
 		return
 	}
 	defer file.Close()
 
-	return clt.uploadMediaFromReader(mediaType, filepath.Base(_filepath), file)
+	return clt.uploadMediaFromReader(mediaType, filepath.Base(_filepath), file, opts...)
 }
 
 // 上传多媒体图片
 //  NOTE: 参数 filename 不是文件路径, 是指定 multipart/form-data 里面文件名称
-func (clt *Client) UploadImageFromReader(filename string, reader io.Reader) (info *MediaInfo, err error) {
+func (clt *Client) UploadImageFromReader(filename string, reader io.Reader, opts ...mp.CallOption) (info *MediaInfo, err error) {
 	if filename == "" {
 		err = errors.New("empty filename")
 		return
@@ -52,12 +52,12 @@ func (clt *Client) UploadImageFromReader(filename string, reader io.Reader) (inf
 		err = errors.New("nil reader")
 		return
 	}
-	return clt.uploadMediaFromReader(MediaTypeImage, filename, reader)
+	return clt.uploadMediaFromReader(MediaTypeImage, filename, reader, opts...)
 }
 
 // 上传多媒体语音
 //  NOTE: 参数 filename 不是文件路径, 是指定 multipart/form-data 里面文件名称
-func (clt *Client) UploadVoiceFromReader(filename string, reader io.Reader) (info *MediaInfo, err error) {
+func (clt *Client) UploadVoiceFromReader(filename string, reader io.Reader, opts ...mp.CallOption) (info *MediaInfo, err error) {
 	if filename == "" {
 		err = errors.New("empty filename")
 		return
@@ -66,12 +66,12 @@ func (clt *Client) UploadVoiceFromReader(filename string, reader io.Reader) (inf
 		err = errors.New("nil reader")
 		return
 	}
-	return clt.uploadMediaFromReader(MediaTypeVoice, filename, reader)
+	return clt.uploadMediaFromReader(MediaTypeVoice, filename, reader, opts...)
 }
 
 // 上传多媒体视频
 //  NOTE: 参数 filename 不是文件路径, 是指定 multipart/form-data 里面文件名称
-func (clt *Client) UploadVideoFromReader(filename string, reader io.Reader) (info *MediaInfo, err error) {
+func (clt *Client) UploadVideoFromReader(filename string, reader io.Reader, opts ...mp.CallOption) (info *MediaInfo, err error) {
 	if filename == "" {
 		err = errors.New("empty filename")
 		return
@@ -80,10 +80,28 @@ func (clt *Client) UploadVideoFromReader(filename string, reader io.Reader) (inf
 		err = errors.New("nil reader")
 		return
 	}
-	return clt.uploadMediaFromReader(MediaTypeVideo, filename, reader)
+	return clt.uploadMediaFromReader(MediaTypeVideo, filename, reader, opts...)
 }
 
-func (clt *Client) uploadMediaFromReader(mediaType, filename string, reader io.Reader) (info *MediaInfo, err error) {
+// 上传群发用的视频, 返回的 MediaId 可以直接用于群发视频消息.
+//  先上传多媒体视频, 然后通过 CreateVideo 转换, title, description 可以为空.
+func (clt *Client) UploadMassVideo(filepath, title, description string, opts ...mp.CallOption) (info *MediaInfo, err error) {
+	if info, err = clt.UploadVideo(filepath, opts...); err != nil {
+		return
+	}
+	return clt.CreateVideo(info.MediaId, title, description, opts...)
+}
+
+// 上传群发用的视频, 返回的 MediaId 可以直接用于群发视频消息.
+//  NOTE: 参数 filename 不是文件路径, 是指定 multipart/form-data 里面文件名称
+func (clt *Client) UploadMassVideoFromReader(filename string, reader io.Reader, title, description string, opts ...mp.CallOption) (info *MediaInfo, err error) {
+	if info, err = clt.UploadVideoFromReader(filename, reader, opts...); err != nil {
+		return
+	}
+	return clt.CreateVideo(info.MediaId, title, description, opts...)
+}
+
+func (clt *Client) uploadMediaFromReader(mediaType, filename string, reader io.Reader, opts ...mp.CallOption) (info *MediaInfo, err error) {
 	var result struct {
 		mp.Error
 		MediaInfo
@@ -91,7 +109,7 @@ func (clt *Client) uploadMediaFromReader(mediaType, filename string, reader io.R
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/media/upload?type=" +
 		url.QueryEscape(mediaType) + "&access_token="
-	if err = clt.UploadFromReader(incompleteURL, "media", filename, reader, "", nil, &result); err != nil {
+	if err = clt.UploadFromReader(incompleteURL, "media", filename, reader, "", nil, &result, opts...); err != nil {
 		return
 	}
 
@@ -106,19 +124,19 @@ func (clt *Client) uploadMediaFromReader(mediaType, filename string, reader io.R
 // =============================================================================
 
 // 上传多媒体缩略图
-func (clt *Client) UploadThumb(_filepath string) (info *MediaInfo, err error) {
+func (clt *Client) UploadThumb(_filepath string, opts ...mp.CallOption) (info *MediaInfo, err error) {
 	file, err := os.Open(_filepath)
 	if err != nil {
 		return
 	}
 	defer file.Close()
 
-	return clt.uploadThumbFromReader(filepath.Base(_filepath), file)
+	return clt.uploadThumbFromReader(filepath.Base(_filepath), file, opts...)
 }
 
 // 上传多媒体缩略图
 //  NOTE: 参数 filename 不是文件路径, 是指定 multipart/form-data 里面文件名称
-func (clt *Client) UploadThumbFromReader(filename string, reader io.Reader) (info *MediaInfo, err error) {
+func (clt *Client) UploadThumbFromReader(filename string, reader io.Reader, opts ...mp.CallOption) (info *MediaInfo, err error) {
 	if filename == "" {
 		err = errors.New("empty filename")
 		return
@@ -127,10 +145,10 @@ func (clt *Client) UploadThumbFromReader(filename string, reader io.Reader) (inf
 		err = errors.New("nil reader")
 		return
 	}
-	return clt.uploadThumbFromReader(filename, reader)
+	return clt.uploadThumbFromReader(filename, reader, opts...)
 }
 
-func (clt *Client) uploadThumbFromReader(filename string, reader io.Reader) (info *MediaInfo, err error) {
+func (clt *Client) uploadThumbFromReader(filename string, reader io.Reader, opts ...mp.CallOption) (info *MediaInfo, err error) {
 	var result struct {
 		mp.Error
 		MediaType string `json:"type"`
@@ -139,7 +157,7 @@ func (clt *Client) uploadThumbFromReader(filename string, reader io.Reader) (inf
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/media/upload?type=thumb&access_token="
-	if err = clt.UploadFromReader(incompleteURL, "media", filename, reader, "", nil, &result); err != nil {
+	if err = clt.UploadFromReader(incompleteURL, "media", filename, reader, "", nil, &result, opts...); err != nil {
 		return
 	}
 
@@ -0,0 +1,52 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package media
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 获取临时视频素材的下载地址.
+//  media/get 对于视频素材返回的不是媒体流, 而是视频的下载地址.
+func (clt *Client) GetVideoURL(mediaId string, opts ...mp.CallOption) (videoURL string, err error) {
+	var result struct {
+		mp.Error
+		VideoURL string `json:"video_url"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/media/get?media_id=" + url.QueryEscape(mediaId) + "&access_token="
+	if err = clt.GetJSON(incompleteURL, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	videoURL = result.VideoURL
+	return
+}
+
+// 下载临时视频素材到文件, 支持断点续传, 返回文件的大小.
+//  中途失败会从已经下载的位置继续下载, 参考 mp.RangeDownloader.
+func (clt *Client) DownloadVideo(mediaId, filepath string, opts ...mp.CallOption) (size int64, err error) {
+	videoURL, err := clt.GetVideoURL(mediaId, opts...)
+	if err != nil {
+		return
+	}
+	if videoURL == "" {
+		err = errors.New("empty video_url")
+		return
+	}
+
+	downloader := mp.RangeDownloader{
+		HttpClient: clt.MediaClient(),
+	}
+	return downloader.DownloadTo(videoURL, filepath)
+}
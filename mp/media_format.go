@@ -0,0 +1,210 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"bytes"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+	"strings"
+)
+
+// 多媒体文件的种类
+type MediaKind int
+
+const (
+	MediaKindUnknown MediaKind = iota
+	MediaKindImage
+	MediaKindVoice
+	MediaKindVideo
+)
+
+func (kind MediaKind) String() string {
+	switch kind {
+	case MediaKindImage:
+		return "image"
+	case MediaKindVoice:
+		return "voice"
+	case MediaKindVideo:
+		return "video"
+	default:
+		return "unknown"
+	}
+}
+
+// 多媒体文件的格式
+type MediaFormat struct {
+	Kind        MediaKind
+	ContentType string // 比如 image/jpeg
+	Ext         string // 文件扩展名, 包括 ".", 比如 .jpg; 未知的格式为空
+}
+
+var (
+	MediaFormatUnknown = MediaFormat{MediaKindUnknown, "application/octet-stream", ""}
+	MediaFormatJPEG    = MediaFormat{MediaKindImage, "image/jpeg", ".jpg"}
+	MediaFormatPNG     = MediaFormat{MediaKindImage, "image/png", ".png"}
+	MediaFormatGIF     = MediaFormat{MediaKindImage, "image/gif", ".gif"}
+	MediaFormatBMP     = MediaFormat{MediaKindImage, "image/bmp", ".bmp"}
+	MediaFormatAMR     = MediaFormat{MediaKindVoice, "audio/amr", ".amr"}
+	MediaFormatSpeex   = MediaFormat{MediaKindVoice, "audio/speex", ".speex"}
+	MediaFormatMP3     = MediaFormat{MediaKindVoice, "audio/mpeg", ".mp3"}
+	MediaFormatMP4     = MediaFormat{MediaKindVideo, "video/mp4", ".mp4"}
+)
+
+var mediaFormats = []MediaFormat{
+	MediaFormatJPEG,
+	MediaFormatPNG,
+	MediaFormatGIF,
+	MediaFormatBMP,
+	MediaFormatAMR,
+	MediaFormatSpeex,
+	MediaFormatMP3,
+	MediaFormatMP4,
+}
+
+// 根据 Content-Type (比如 image/jpeg) 或者扩展名 (比如 .jpg) 查找格式, 找不到返回 MediaFormatUnknown.
+func LookupMediaFormat(contentTypeOrExt string) MediaFormat {
+	s := strings.ToLower(strings.TrimSpace(contentTypeOrExt))
+	if strings.HasPrefix(s, ".") {
+		switch s {
+		case ".jpeg":
+			s = ".jpg"
+		case ".spx":
+			s = ".speex"
+		}
+		for _, format := range mediaFormats {
+			if format.Ext == s {
+				return format
+			}
+		}
+		return MediaFormatUnknown
+	}
+
+	if mediaType, _, err := mime.ParseMediaType(s); err == nil {
+		s = mediaType
+	}
+	switch s {
+	case "image/jpg", "image/pjpeg":
+		s = "image/jpeg"
+	case "audio/mp3":
+		s = "audio/mpeg"
+	case "audio/x-speex", "audio/ogg":
+		s = "audio/speex"
+	}
+	for _, format := range mediaFormats {
+		if format.ContentType == s {
+			return format
+		}
+	}
+	return MediaFormatUnknown
+}
+
+// 识别内容格式需要的最多字节数
+const mediaSniffLen = 512
+
+// 根据文件内容的开头部分识别格式, 识别不了返回 MediaFormatUnknown.
+func SniffMediaFormat(head []byte) MediaFormat {
+	switch {
+	case bytes.HasPrefix(head, []byte("\xFF\xD8\xFF")):
+		return MediaFormatJPEG
+	case bytes.HasPrefix(head, []byte("\x89PNG\r\n\x1A\n")):
+		return MediaFormatPNG
+	case bytes.HasPrefix(head, []byte("GIF87a")), bytes.HasPrefix(head, []byte("GIF89a")):
+		return MediaFormatGIF
+	case bytes.HasPrefix(head, []byte("BM")) && len(head) >= 14:
+		return MediaFormatBMP
+	case bytes.HasPrefix(head, []byte("#!AMR")):
+		return MediaFormatAMR
+	case bytes.HasPrefix(head, []byte("OggS")) && bytes.Contains(head, []byte("Speex   ")):
+		return MediaFormatSpeex
+	case bytes.HasPrefix(head, []byte("ID3")), len(head) >= 2 && head[0] == 0xFF && head[1]&0xE0 == 0xE0:
+		return MediaFormatMP3
+	case len(head) >= 12 && bytes.Equal(head[4:8], []byte("ftyp")):
+		return MediaFormatMP4
+	}
+	return MediaFormatUnknown
+}
+
+// 多媒体下载的结果信息
+type MediaDownloadInfo struct {
+	Format   MediaFormat
+	Filename string // 服务器通过 Content-Disposition 指定的文件名, 可能为空
+	Size     int64  // 下载的字节数
+}
+
+// 建议保存的文件名; 服务器没有指定文件名时使用 name + 识别出的扩展名.
+func (info *MediaDownloadInfo) SuggestFilename(name string) string {
+	if info.Filename != "" {
+		if filepath.Ext(info.Filename) != "" || info.Format.Ext == "" {
+			return info.Filename
+		}
+		return info.Filename + info.Format.Ext
+	}
+	return name + info.Format.Ext
+}
+
+// 从 Content-Disposition 里获取文件名, 只返回文件名部分, 不包括路径.
+func ContentDispositionFilename(contentDisposition string) string {
+	if contentDisposition == "" {
+		return ""
+	}
+	_, params, err := mime.ParseMediaType(contentDisposition)
+	if err != nil {
+		return ""
+	}
+	filename := params["filename"]
+	if i := strings.LastIndexAny(filename, `/\`); i >= 0 {
+		filename = filename[i+1:]
+	}
+	switch filename = strings.TrimSpace(filename); filename {
+	case ".", "..":
+		return ""
+	}
+	return filename
+}
+
+// 包装 io.Writer, 记录写入的字节数和开头的内容, 用于识别下载的多媒体格式.
+type MediaSniffWriter struct {
+	Writer io.Writer
+	head   []byte
+	n      int64
+}
+
+func NewMediaSniffWriter(writer io.Writer) *MediaSniffWriter {
+	return &MediaSniffWriter{Writer: writer}
+}
+
+func (w *MediaSniffWriter) Write(p []byte) (n int, err error) {
+	if len(w.head) < mediaSniffLen {
+		m := mediaSniffLen - len(w.head)
+		if m > len(p) {
+			m = len(p)
+		}
+		w.head = append(w.head, p[:m]...)
+	}
+	n, err = w.Writer.Write(p)
+	w.n += int64(n)
+	return
+}
+
+// 返回下载的结果信息, header 为 http 响应头.
+//  优先根据内容识别格式, 识别不了再依次根据 Content-Type 和服务器指定的文件名的扩展名识别.
+func (w *MediaSniffWriter) Info(header http.Header) *MediaDownloadInfo {
+	info := &MediaDownloadInfo{
+		Filename: ContentDispositionFilename(header.Get("Content-Disposition")),
+		Size:     w.n,
+	}
+	if info.Format = SniffMediaFormat(w.head); info.Format.Kind != MediaKindUnknown {
+		return info
+	}
+	if info.Format = LookupMediaFormat(header.Get("Content-Type")); info.Format.Kind != MediaKindUnknown {
+		return info
+	}
+	info.Format = LookupMediaFormat(filepath.Ext(info.Filename))
+	return info
+}
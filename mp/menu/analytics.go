@@ -0,0 +1,183 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package menu
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	DefaultClickWindow     = time.Hour // 默认按小时统计
+	DefaultClickMaxWindows = 24 * 7    // 默认保留最近 7 天的统计
+)
+
+// 菜单事件的统计结果
+type ClickStat struct {
+	Event    string `json:"event"`     // 事件类型, 比如 EventTypeClick, EventTypeView
+	EventKey string `json:"event_key"` // 菜单的 KEY 值, VIEW 事件为跳转的 URL
+	Count    int64  `json:"count"`
+}
+
+// 一个时间窗口内的点击次数
+type ClickBucket struct {
+	Start time.Time `json:"start"` // 时间窗口的开始时间
+	Count int64     `json:"count"`
+}
+
+type clickKey struct {
+	event    string
+	eventKey string
+}
+
+// 按时间窗口统计菜单事件(CLICK, VIEW, scancode_push 等)每个 EventKey 的次数, 补充公众平台后台有限的菜单分析.
+//  统计保存在内存中, 超过 MaxWindows 的时间窗口会被丢弃.
+type ClickCollector struct {
+	Window     time.Duration // 时间窗口的长度, 默认为 DefaultClickWindow
+	MaxWindows int           // 保留的时间窗口数量, 默认为 DefaultClickMaxWindows
+
+	// 每次统计一个菜单事件时回调, 一般用于对接 prometheus 之类的监控系统; 可以为 nil.
+	OnClick func(event, eventKey string)
+
+	mutex   sync.Mutex
+	buckets map[int64]map[clickKey]int64 // 时间窗口的开始时间(UnixNano) --> 统计
+}
+
+func NewClickCollector() *ClickCollector {
+	return &ClickCollector{
+		buckets: make(map[int64]map[clickKey]int64),
+	}
+}
+
+func (c *ClickCollector) window() time.Duration {
+	if c.Window > 0 {
+		return c.Window
+	}
+	return DefaultClickWindow
+}
+
+func (c *ClickCollector) maxWindows() int {
+	if c.MaxWindows > 0 {
+		return c.MaxWindows
+	}
+	return DefaultClickMaxWindows
+}
+
+func isMenuEvent(event string) bool {
+	switch event {
+	case EventTypeClick, EventTypeView, EventTypeScanCodePush, EventTypeScanCodeWaitMsg,
+		EventTypePicSysPhoto, EventTypePicPhotoOrAlbum, EventTypePicWeixin, EventTypeLocationSelect:
+		return true
+	}
+	return false
+}
+
+// 统计菜单事件, 其他消息直接忽略, 一般在 mp.MessageHandler 里调用. 返回是否是菜单事件.
+func (c *ClickCollector) HandleEvent(msg *mp.MixedMessage) bool {
+	if msg.MsgType != "event" || !isMenuEvent(msg.Event) {
+		return false
+	}
+	t := time.Now()
+	if msg.CreateTime > 0 {
+		t = time.Unix(msg.CreateTime, 0)
+	}
+	c.Add(msg.Event, msg.EventKey, t)
+	return true
+}
+
+// 给 t 所在的时间窗口增加一次 event/eventKey 的统计.
+func (c *ClickCollector) Add(event, eventKey string, t time.Time) {
+	start := t.Truncate(c.window()).UnixNano()
+	maxWindows := c.maxWindows()
+
+	c.mutex.Lock()
+	if c.buckets == nil {
+		c.buckets = make(map[int64]map[clickKey]int64)
+	}
+	bucket := c.buckets[start]
+	if bucket == nil {
+		bucket = make(map[clickKey]int64)
+		c.buckets[start] = bucket
+		c.pruneLocked(maxWindows)
+	}
+	bucket[clickKey{event: event, eventKey: eventKey}]++
+	c.mutex.Unlock()
+
+	if c.OnClick != nil {
+		c.OnClick(event, eventKey)
+	}
+}
+
+// 只保留最近的 maxWindows 个时间窗口
+func (c *ClickCollector) pruneLocked(maxWindows int) {
+	if len(c.buckets) <= maxWindows {
+		return
+	}
+	starts := make([]int64, 0, len(c.buckets))
+	for start := range c.buckets {
+		starts = append(starts, start)
+	}
+	sort.Slice(starts, func(i, j int) bool { return starts[i] < starts[j] })
+	for _, start := range starts[:len(starts)-maxWindows] {
+		delete(c.buckets, start)
+	}
+}
+
+// 返回时间窗口开始时间在 [from, to) 之间的统计, 按次数从多到少排序.
+func (c *ClickCollector) Stats(from, to time.Time) (stats []ClickStat) {
+	fromNano, toNano := from.Truncate(c.window()).UnixNano(), to.UnixNano()
+
+	counts := make(map[clickKey]int64)
+	c.mutex.Lock()
+	for start, bucket := range c.buckets {
+		if start < fromNano || start >= toNano {
+			continue
+		}
+		for key, count := range bucket {
+			counts[key] += count
+		}
+	}
+	c.mutex.Unlock()
+
+	stats = make([]ClickStat, 0, len(counts))
+	for key, count := range counts {
+		stats = append(stats, ClickStat{Event: key.event, EventKey: key.eventKey, Count: count})
+	}
+	sort.Slice(stats, func(i, j int) bool {
+		if stats[i].Count != stats[j].Count {
+			return stats[i].Count > stats[j].Count
+		}
+		if stats[i].Event != stats[j].Event {
+			return stats[i].Event < stats[j].Event
+		}
+		return stats[i].EventKey < stats[j].EventKey
+	})
+	return
+}
+
+// 返回 eventKey 在 [from, to) 之间每个时间窗口的次数(包括次数为 0 的窗口), 按时间排序.
+//  event 为 "" 时统计所有事件类型.
+func (c *ClickCollector) Series(event, eventKey string, from, to time.Time) (buckets []ClickBucket) {
+	window := c.window()
+	from = from.Truncate(window)
+
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for start := from; start.Before(to); start = start.Add(window) {
+		var count int64
+		for key, n := range c.buckets[start.UnixNano()] {
+			if key.eventKey == eventKey && (event == "" || key.event == event) {
+				count += n
+			}
+		}
+		buckets = append(buckets, ClickBucket{Start: start, Count: count})
+	}
+	return
+}
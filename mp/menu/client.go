@@ -34,11 +34,11 @@ func NewClient(TokenServer mp.TokenServer, HttpClient *http.Client) *Client {
 }
 
 // 创建自定义菜单.
-func (clt *Client) CreateMenu(menu Menu) (err error) {
+func (clt *Client) CreateMenu(menu Menu, opts ...mp.CallOption) (err error) {
 	var result mp.Error
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/menu/create?access_token="
-	if err = clt.PostJSON(incompleteURL, &menu, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &menu, &result, opts...); err != nil {
 		return
 	}
 
@@ -50,11 +50,11 @@ func (clt *Client) CreateMenu(menu Menu) (err error) {
 }
 
 // 删除自定义菜单
-func (clt *Client) DeleteMenu() (err error) {
+func (clt *Client) DeleteMenu(opts ...mp.CallOption) (err error) {
 	var result mp.Error
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/menu/delete?access_token="
-	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+	if err = clt.GetJSON(incompleteURL, &result, opts...); err != nil {
 		return
 	}
 
@@ -66,14 +66,14 @@ func (clt *Client) DeleteMenu() (err error) {
 }
 
 // 获取自定义菜单
-func (clt *Client) GetMenu() (menu Menu, err error) {
+func (clt *Client) GetMenu(opts ...mp.CallOption) (menu Menu, err error) {
 	var result struct {
 		mp.Error
 		Menu Menu `json:"menu"`
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/menu/get?access_token="
-	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+	if err = clt.GetJSON(incompleteURL, &result, opts...); err != nil {
 		return
 	}
 
@@ -14,6 +14,12 @@ import (
 
 type Client struct {
 	mp.WechatClient
+
+	// 可以为 nil; 否则 SendText 发送前用它清理文本, 比如 mp.NewCustomTextSanitizer().
+	TextSanitizer *mp.TextSanitizer
+
+	// 可以为 nil; 否则发送前检查用户的互动时间和发送条数, 超出限制时不请求微信直接返回 *GuardError.
+	SendGuard *SendGuard
 }
 
 // 创建一个新的 Client.
@@ -35,67 +41,105 @@ func NewClient(TokenServer mp.TokenServer, HttpClient *http.Client) *Client {
 }
 
 // 发送客服消息, 文本.
-func (clt *Client) SendText(msg *Text) error {
+func (clt *Client) SendText(msg *Text, opts ...mp.CallOption) error {
 	if msg == nil {
 		return errors.New("msg == nil")
 	}
-	return clt.send(msg)
+	if clt.TextSanitizer != nil {
+		content, err := clt.TextSanitizer.Sanitize(msg.Text.Content)
+		if err != nil {
+			return err
+		}
+		sanitized := *msg // 不修改调用者的 msg
+		sanitized.Text.Content = content
+		msg = &sanitized
+	}
+	return clt.send(msg, opts...)
 }
 
 // 发送客服消息, 图片.
-func (clt *Client) SendImage(msg *Image) error {
+func (clt *Client) SendImage(msg *Image, opts ...mp.CallOption) error {
 	if msg == nil {
 		return errors.New("msg == nil")
 	}
-	return clt.send(msg)
+	return clt.send(msg, opts...)
 }
 
 // 发送客服消息, 语音.
-func (clt *Client) SendVoice(msg *Voice) error {
+func (clt *Client) SendVoice(msg *Voice, opts ...mp.CallOption) error {
 	if msg == nil {
 		return errors.New("msg == nil")
 	}
-	return clt.send(msg)
+	return clt.send(msg, opts...)
 }
 
 // 发送客服消息, 视频.
-func (clt *Client) SendVideo(msg *Video) error {
+func (clt *Client) SendVideo(msg *Video, opts ...mp.CallOption) error {
 	if msg == nil {
 		return errors.New("msg == nil")
 	}
-	return clt.send(msg)
+	return clt.send(msg, opts...)
 }
 
 // 发送客服消息, 音乐.
-func (clt *Client) SendMusic(msg *Music) error {
+func (clt *Client) SendMusic(msg *Music, opts ...mp.CallOption) error {
 	if msg == nil {
 		return errors.New("msg == nil")
 	}
-	return clt.send(msg)
+	return clt.send(msg, opts...)
 }
 
 // 发送客服消息, 图文.
-func (clt *Client) SendNews(msg *News) (err error) {
+func (clt *Client) SendNews(msg *News, opts ...mp.CallOption) (err error) {
 	if msg == nil {
 		return errors.New("msg == nil")
 	}
 	if err = msg.CheckValid(); err != nil {
 		return
 	}
-	return clt.send(msg)
+	return clt.send(msg, opts...)
+}
+
+// 发送客服消息, 小程序卡片.
+func (clt *Client) SendMiniProgramPage(msg *MiniProgramPage, opts ...mp.CallOption) error {
+	if msg == nil {
+		return errors.New("msg == nil")
+	}
+	if msg.MiniProgramPage.PagePath == "" {
+		return errors.New("empty pagepath")
+	}
+	return clt.send(msg, opts...)
 }
 
-func (clt *Client) send(msg interface{}) (err error) {
+func (clt *Client) send(msg interface{}, opts ...mp.CallOption) (err error) {
+	var openid string
+	if clt.SendGuard != nil {
+		if hdr, ok := msg.(interface {
+			toUser() string
+		}); ok {
+			openid = hdr.toUser()
+		}
+		if err = clt.SendGuard.Check(openid); err != nil {
+			return
+		}
+	}
+
 	var result mp.Error
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/message/custom/send?access_token="
-	if err = clt.PostJSON(incompleteURL, msg, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, msg, &result, opts...); err != nil {
 		return
 	}
 
 	if result.ErrCode != mp.ErrCodeOK {
+		if clt.SendGuard != nil {
+			clt.SendGuard.rejected(openid, result.ErrCode)
+		}
 		err = &result
 		return
 	}
+	if clt.SendGuard != nil {
+		clt.SendGuard.Sent(openid)
+	}
 	return
 }
@@ -0,0 +1,273 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package custom
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	DefaultInteractionWindow = 48 * time.Hour // 用户互动以后 48 小时内可以发送客服消息
+	DefaultMaxSendsPerWindow = 20             // 每次互动以后最多发送 20 条客服消息
+)
+
+// 用户的互动记录
+type InteractionRecord struct {
+	LastInteraction time.Time // 最后一次互动的时间
+	SentCount       int       // 最后一次互动以后已经发送的客服消息条数
+}
+
+// 保存用户互动记录的存储接口, 多进程环境可以用 redis 等实现.
+type InteractionStore interface {
+	// 获取用户的互动记录, 没有记录时 ok == false
+	Get(openid string) (rec InteractionRecord, ok bool, err error)
+	// 保存用户的互动记录
+	Set(openid string, rec InteractionRecord) error
+	// SentCount 加上 n, 没有记录时 ok == false; 必须是原子操作, 同时给一个用户发送时不能丢失计数.
+	IncrSent(openid string, n int) (ok bool, err error)
+}
+
+var _ InteractionStore = (*MemoryInteractionStore)(nil)
+
+// InteractionStore 的简单实现, 用于单进程环境; 记录不会过期, 用户很多时请定期 Purge.
+type MemoryInteractionStore struct {
+	rwmutex sync.RWMutex
+	records map[string]InteractionRecord
+}
+
+func NewMemoryInteractionStore() *MemoryInteractionStore {
+	return &MemoryInteractionStore{
+		records: make(map[string]InteractionRecord),
+	}
+}
+
+func (s *MemoryInteractionStore) Get(openid string) (rec InteractionRecord, ok bool, err error) {
+	s.rwmutex.RLock()
+	rec, ok = s.records[openid]
+	s.rwmutex.RUnlock()
+	return
+}
+
+func (s *MemoryInteractionStore) Set(openid string, rec InteractionRecord) error {
+	s.rwmutex.Lock()
+	s.records[openid] = rec
+	s.rwmutex.Unlock()
+	return nil
+}
+
+func (s *MemoryInteractionStore) IncrSent(openid string, n int) (ok bool, err error) {
+	s.rwmutex.Lock()
+	defer s.rwmutex.Unlock()
+
+	rec, ok := s.records[openid]
+	if !ok {
+		return
+	}
+	rec.SentCount += n
+	s.records[openid] = rec
+	return
+}
+
+// 删除最后一次互动早于 before 的记录.
+func (s *MemoryInteractionStore) Purge(before time.Time) {
+	s.rwmutex.Lock()
+	for openid, rec := range s.records {
+		if rec.LastInteraction.Before(before) {
+			delete(s.records, openid)
+		}
+	}
+	s.rwmutex.Unlock()
+}
+
+// SendGuard 拒绝发送时返回的错误.
+type GuardError struct {
+	OpenId          string
+	ErrCode         int       // 对应的微信错误码, mp.ErrCodeResponseTimeLimit 或 mp.ErrCodeResponseCountLimit
+	LastInteraction time.Time // 最后一次互动的时间, 没有互动记录时为零值
+	SentCount       int       // 最后一次互动以后已经发送的条数
+}
+
+func (e *GuardError) Error() string {
+	switch e.ErrCode {
+	case mp.ErrCodeResponseCountLimit:
+		return fmt.Sprintf("custom message to %s exceeds the limit: %d messages sent since %s",
+			e.OpenId, e.SentCount, e.LastInteraction.Format(time.RFC3339))
+	default:
+		if e.LastInteraction.IsZero() {
+			return fmt.Sprintf("custom message to %s is out of interaction window: no interaction", e.OpenId)
+		}
+		return fmt.Sprintf("custom message to %s is out of interaction window: last interaction at %s",
+			e.OpenId, e.LastInteraction.Format(time.RFC3339))
+	}
+}
+
+// 判断 err 是否为用户不在互动时间内, 包括 SendGuard 的检查结果和微信返回的 45015 错误.
+func IsOutOfInteractionWindow(err error) bool {
+	switch e := err.(type) {
+	case *GuardError:
+		return e.ErrCode == mp.ErrCodeResponseTimeLimit
+	case *mp.Error:
+		return e.ErrCode == mp.ErrCodeResponseTimeLimit
+	}
+	return false
+}
+
+// 客服消息的发送检查.
+//  微信只允许在用户互动(发送消息, 点击菜单, 关注, 扫描二维码等)以后的 48 小时内给用户发送客服消息,
+//  并且每次互动以后的发送条数有上限, 超出时返回 45015, 45047 错误.
+//  SendGuard 记录用户的互动时间和发送条数, 在请求微信之前就返回 *GuardError, 避免无效的请求.
+//
+//  用户的互动需要交给 HandleEvent 或者 Interact 记录, 没有互动记录的用户会被拒绝发送.
+type SendGuard struct {
+	Store     InteractionStore
+	Window    time.Duration // 互动以后可以发送的时间, 默认为 DefaultInteractionWindow
+	MaxSends  int           // 每次互动以后最多发送的条数, 默认为 DefaultMaxSendsPerWindow, < 0 表示不限制
+	AllowNone bool          // 是否允许给没有互动记录的用户发送, 一般在刚接入 SendGuard 时设置, 避免拒绝之前互动过的用户
+
+	// 发送以后更新记录失败时的回调, 可以为 nil; 更新失败不影响发送的结果.
+	OnError func(openid string, err error)
+}
+
+// 创建一个新的 SendGuard, store 为 nil 时使用 MemoryInteractionStore.
+func NewSendGuard(store InteractionStore) *SendGuard {
+	if store == nil {
+		store = NewMemoryInteractionStore()
+	}
+	return &SendGuard{
+		Store:    store,
+		Window:   DefaultInteractionWindow,
+		MaxSends: DefaultMaxSendsPerWindow,
+	}
+}
+
+func (g *SendGuard) window() time.Duration {
+	if g.Window > 0 {
+		return g.Window
+	}
+	return DefaultInteractionWindow
+}
+
+func (g *SendGuard) maxSends() int {
+	if g.MaxSends == 0 {
+		return DefaultMaxSendsPerWindow
+	}
+	return g.MaxSends
+}
+
+func (g *SendGuard) onError(openid string, err error) {
+	if g.OnError != nil {
+		g.OnError(openid, err)
+	}
+}
+
+// 记录用户在 t 时刻的一次互动, 重新开始计算发送条数.
+func (g *SendGuard) Interact(openid string, t time.Time) error {
+	rec, ok, err := g.Store.Get(openid)
+	if err != nil {
+		return err
+	}
+	if ok && rec.LastInteraction.After(t) {
+		return nil // 乱序到达的旧事件
+	}
+	return g.Store.Set(openid, InteractionRecord{LastInteraction: t})
+}
+
+// 用户的互动事件
+var interactionEvents = map[string]bool{
+	"subscribe":          true,
+	"SCAN":               true,
+	"CLICK":              true,
+	"VIEW":               true,
+	"scancode_push":      true,
+	"scancode_waitmsg":   true,
+	"pic_sysphoto":       true,
+	"pic_photo_or_album": true,
+	"pic_weixin":         true,
+	"location_select":    true,
+}
+
+// 根据微信推送过来的消息记录用户的互动, 一般在 mp.MessageHandler 里调用.
+//  用户发送的消息和菜单, 关注, 扫描二维码等事件算作互动, 其他事件(比如地理位置上报, 群发结果)忽略.
+func (g *SendGuard) HandleEvent(msg *mp.MixedMessage) error {
+	if msg == nil || msg.FromUserName == "" {
+		return nil
+	}
+	if msg.MsgType == "event" && !interactionEvents[msg.Event] {
+		return nil
+	}
+	t := time.Now()
+	if msg.CreateTime > 0 {
+		t = mp.UnixToTime(msg.CreateTime)
+	}
+	return g.Interact(msg.FromUserName, t)
+}
+
+// 检查现在能否给用户发送客服消息, 不能发送时返回 *GuardError.
+func (g *SendGuard) Check(openid string) error {
+	rec, ok, err := g.Store.Get(openid)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		if g.AllowNone {
+			return nil
+		}
+		return &GuardError{OpenId: openid, ErrCode: mp.ErrCodeResponseTimeLimit}
+	}
+	if time.Since(rec.LastInteraction) >= g.window() {
+		return &GuardError{
+			OpenId:          openid,
+			ErrCode:         mp.ErrCodeResponseTimeLimit,
+			LastInteraction: rec.LastInteraction,
+			SentCount:       rec.SentCount,
+		}
+	}
+	if max := g.maxSends(); max > 0 && rec.SentCount >= max {
+		return &GuardError{
+			OpenId:          openid,
+			ErrCode:         mp.ErrCodeResponseCountLimit,
+			LastInteraction: rec.LastInteraction,
+			SentCount:       rec.SentCount,
+		}
+	}
+	return nil
+}
+
+// 记录发送成功的一条客服消息, 由 Client 在发送成功后调用.
+//  没有互动记录(AllowNone)的用户不计数, 等下一次互动再开始计数.
+func (g *SendGuard) Sent(openid string) {
+	if _, err := g.Store.IncrSent(openid, 1); err != nil {
+		g.onError(openid, err)
+	}
+}
+
+// 微信返回 45015, 45047 时修正本地的记录, 之后的发送直接被 Check 拒绝.
+func (g *SendGuard) rejected(openid string, errCode int) {
+	switch errCode {
+	case mp.ErrCodeResponseTimeLimit:
+		if rec, ok, err := g.Store.Get(openid); err != nil {
+			g.onError(openid, err)
+		} else if ok {
+			rec.LastInteraction = time.Now().Add(-g.window())
+			if err = g.Store.Set(openid, rec); err != nil {
+				g.onError(openid, err)
+			}
+		}
+	case mp.ErrCodeResponseCountLimit:
+		if rec, ok, err := g.Store.Get(openid); err != nil {
+			g.onError(openid, err)
+		} else if max := g.maxSends(); ok && max > rec.SentCount {
+			// 用 IncrSent 而不是 Set, 不会覆盖同时发送的计数和新的互动记录
+			if _, err = g.Store.IncrSent(openid, max-rec.SentCount); err != nil {
+				g.onError(openid, err)
+			}
+		}
+	}
+}
@@ -17,6 +17,8 @@ const (
 	MsgTypeVideo = "video" // 视频消息
 	MsgTypeMusic = "music" // 音乐消息
 	MsgTypeNews  = "news"  // 图文消息
+
+	MsgTypeMiniProgramPage = "miniprogrampage" // 小程序卡片消息
 )
 
 type CommonMessageHeader struct {
@@ -24,6 +26,10 @@ type CommonMessageHeader struct {
 	MsgType string `json:"msgtype"`
 }
 
+func (hdr *CommonMessageHeader) toUser() string {
+	return hdr.ToUser
+}
+
 // 如果需要以某个客服帐号来发消息（在微信6.0.2及以上版本中显示自定义头像），
 // 则需在JSON数据包的后半部分加入 customservice 参数
 type CustomService struct {
@@ -261,3 +267,43 @@ func (this *News) CheckValid() (err error) {
 	}
 	return
 }
+
+// 小程序卡片消息
+type MiniProgramPage struct {
+	CommonMessageHeader
+
+	MiniProgramPage struct {
+		Title        string `json:"title,omitempty"` // 小程序卡片的标题
+		AppId        string `json:"appid,omitempty"` // 小程序的 appid, 公众号发送时必填, 要求小程序已经关联公众号
+		PagePath     string `json:"pagepath"`        // 小程序的页面路径, 支持参数, 比如 pages/index/index?foo=bar
+		ThumbMediaId string `json:"thumb_media_id"`  // 小程序卡片的封面图片, 通过上传多媒体文件得到, 建议大小为 520*416
+	} `json:"miniprogrampage"`
+
+	*CustomService `json:"customservice,omitempty"`
+}
+
+// 新建小程序卡片消息.
+//  appId 为卡片跳转的小程序 appid, 公众号发送时必填, 小程序自己发送时可以为 "";
+//  title 可以为 "";
+//  如果不指定客服则 kfAccount 留空.
+func NewMiniProgramPage(toUser, title, appId, pagePath, thumbMediaId,
+	kfAccount string) (page *MiniProgramPage) {
+
+	page = &MiniProgramPage{
+		CommonMessageHeader: CommonMessageHeader{
+			ToUser:  toUser,
+			MsgType: MsgTypeMiniProgramPage,
+		},
+	}
+	page.MiniProgramPage.Title = title
+	page.MiniProgramPage.AppId = appId
+	page.MiniProgramPage.PagePath = pagePath
+	page.MiniProgramPage.ThumbMediaId = thumbMediaId
+
+	if kfAccount != "" {
+		page.CustomService = &CustomService{
+			KfAccount: kfAccount,
+		}
+	}
+	return
+}
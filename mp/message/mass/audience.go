@@ -0,0 +1,212 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mass
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/message/mass/mass2users"
+	"github.com/chanxuehong/wechat/mp/user"
+)
+
+// 按 openid 列表群发至少需要 mass2users.ToUserCountMin 个用户, 只有一个用户时 Audience.Resolve 返回该错误.
+var ErrSingleRecipient = errors.New("mass send needs at least 2 openids, use preview or custom message for a single user")
+
+// 群发的对象: 若干个标签的粉丝和 openid 列表的并集, 去掉 Exclude 和黑名单里的用户.
+//
+//  plan, err := audience.Resolve(userClient)
+//  if err != nil {
+//      // TODO: 增加你的代码
+//  }
+//  fmt.Println(plan.Count) // 发送前预览人数
+//  msgids, err := plan.Send(
+//      func(tagId int64) (int64, error) {
+//          return mass2groupClient.SendText(mass2group.NewText(tagId, "content"))
+//      },
+//      func(openids []string) (int64, error) {
+//          return mass2usersClient.SendText(mass2users.NewText(openids, "content"))
+//      },
+//  )
+type Audience struct {
+	TagIds           []int64  // 标签 id
+	OpenIds          []string // 直接指定的用户
+	Exclude          []string // 不发送的用户
+	ExcludeBlacklist bool     // 是否去掉黑名单里的用户
+}
+
+// Audience 解析的结果
+type AudiencePlan struct {
+	// 为 true 时按标签群发给 TagId, 否则按 openid 列表分批群发给 Batches.
+	ByTag bool
+	TagId int64
+
+	// 每批不超过 mass2users.ToUserCountLimit 个, 并且不少于 mass2users.ToUserCountMin 个 openid
+	Batches [][]string
+
+	// 预计发送的人数; 按标签群发时为标签下的粉丝数.
+	Count int
+
+	// 只有一个用户(ErrSingleRecipient)时为该用户, 否则为 nil
+	OpenIds []string
+}
+
+// 解析 Audience.
+//  只有一个标签并且不需要排除任何用户时, 按标签群发(一次调用); 否则拉取标签下的所有粉丝, 合并, 去重,
+//  排除以后按 mass2users.ToUserCountLimit 分批, 最后两批会调整大小保证每批至少 mass2users.ToUserCountMin 个.
+//  排除以后只剩一个用户时返回 ErrSingleRecipient, 此时 plan.Batches 为 nil, plan.OpenIds 为该用户,
+//  可以改用预览接口或者客服消息发送.
+func (a *Audience) Resolve(clt *user.Client, opts ...mp.CallOption) (plan *AudiencePlan, err error) {
+	if len(a.TagIds) == 0 && len(a.OpenIds) == 0 {
+		err = errors.New("empty audience")
+		return
+	}
+
+	var blacklist []string
+	if a.ExcludeBlacklist {
+		if blacklist, err = listAll(func(beginOpenId string) ([]string, string, error) {
+			data, err := clt.BlacklistList(beginOpenId, opts...)
+			if err != nil {
+				return nil, "", err
+			}
+			return data.Data.OpenId, data.NextOpenId, nil
+		}); err != nil {
+			return
+		}
+	}
+
+	if len(a.TagIds) == 1 && len(a.OpenIds) == 0 && len(a.Exclude) == 0 && len(blacklist) == 0 {
+		tags, err := clt.TagList(opts...)
+		if err != nil {
+			return nil, err
+		}
+		plan = &AudiencePlan{
+			ByTag: true,
+			TagId: a.TagIds[0],
+		}
+		for _, tag := range tags {
+			if tag.Id == plan.TagId {
+				plan.Count = tag.UserCount
+				break
+			}
+		}
+		return plan, nil
+	}
+
+	excluded := make(map[string]struct{}, len(a.Exclude)+len(blacklist))
+	for _, openid := range a.Exclude {
+		excluded[openid] = struct{}{}
+	}
+	for _, openid := range blacklist {
+		excluded[openid] = struct{}{}
+	}
+
+	var openids []string
+	seen := make(map[string]struct{})
+	add := func(list []string) {
+		for _, openid := range list {
+			if openid == "" {
+				continue
+			}
+			if _, ok := excluded[openid]; ok {
+				continue
+			}
+			if _, ok := seen[openid]; ok {
+				continue
+			}
+			seen[openid] = struct{}{}
+			openids = append(openids, openid)
+		}
+	}
+
+	for _, tagId := range a.TagIds {
+		tagId := tagId
+		members, err := listAll(func(beginOpenId string) ([]string, string, error) {
+			data, err := clt.TagUserList(tagId, beginOpenId, opts...)
+			if err != nil {
+				return nil, "", err
+			}
+			return data.Data.OpenId, data.NextOpenId, nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		add(members)
+	}
+	add(a.OpenIds)
+
+	plan = &AudiencePlan{
+		Count: len(openids),
+	}
+	if len(openids) == 1 {
+		plan.OpenIds = openids
+		return plan, ErrSingleRecipient
+	}
+	for len(openids) > 0 {
+		n := len(openids)
+		if n > mass2users.ToUserCountLimit {
+			n = mass2users.ToUserCountLimit
+			// 剩下的不够一批时从这一批匀过去, 比如 10001 个分成 9999 + 2
+			if rest := len(openids) - n; rest < mass2users.ToUserCountMin {
+				n -= mass2users.ToUserCountMin - rest
+			}
+		}
+		plan.Batches = append(plan.Batches, openids[:n:n])
+		openids = openids[n:]
+	}
+	return
+}
+
+// 拉取全部的 openid, 拉取到的个数不足一页或者 nextOpenId 为空时结束.
+func listAll(list func(beginOpenId string) (openids []string, nextOpenId string, err error)) (all []string, err error) {
+	var beginOpenId string
+	for {
+		openids, nextOpenId, err := list(beginOpenId)
+		if err != nil {
+			return nil, err
+		}
+		all = append(all, openids...)
+		if len(openids) < user.UserPageSizeLimit || nextOpenId == "" {
+			return all, nil
+		}
+		beginOpenId = nextOpenId
+	}
+}
+
+// 按标签群发的函数, 一般用 mass2group.Client 实现.
+type TagSendFunc func(tagId int64) (msgid int64, err error)
+
+// 按 openid 列表群发的函数, 一般用 mass2users.Client 实现.
+type UsersSendFunc func(openids []string) (msgid int64, err error)
+
+// 按照 plan 群发, 返回每次群发的 msgid.
+//  某一批失败时停止发送, 返回已经成功的 msgid 和错误; 重试时请只发送剩下的批次, 或者给每批使用固定的 clientmsgid.
+func (plan *AudiencePlan) Send(toTag TagSendFunc, toUsers UsersSendFunc) (msgids []int64, err error) {
+	if plan.ByTag {
+		if toTag == nil {
+			err = errors.New("nil TagSendFunc")
+			return
+		}
+		msgid, err := toTag(plan.TagId)
+		if err != nil {
+			return nil, err
+		}
+		return []int64{msgid}, nil
+	}
+
+	if toUsers == nil {
+		err = errors.New("nil UsersSendFunc")
+		return
+	}
+	for _, batch := range plan.Batches {
+		msgid, err := toUsers(batch)
+		if err != nil {
+			return msgids, err
+		}
+		msgids = append(msgids, msgid)
+	}
+	return
+}
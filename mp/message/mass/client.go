@@ -38,7 +38,7 @@ func NewClient(TokenServer mp.TokenServer, HttpClient *http.Client) *Client {
 //  只有已经发送成功的消息才能删除删除消息只是将消息的图文详情页失效，已经收到的用户，
 //  还是能在其本地看到消息卡片。 另外，删除群发消息只能删除图文消息和视频消息，
 //  其他类型的消息一经发送，无法删除。
-func (clt *Client) DeleteMass(msgid int64) (err error) {
+func (clt *Client) DeleteMass(msgid int64, opts ...mp.CallOption) (err error) {
 	var request = struct {
 		MsgId int64 `json:"msg_id"`
 	}{
@@ -48,7 +48,7 @@ func (clt *Client) DeleteMass(msgid int64) (err error) {
 	var result mp.Error
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/message/mass/delete?access_token="
-	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
 		return
 	}
 
@@ -65,7 +65,7 @@ type MassStatus struct {
 }
 
 // 查询群发消息发送状态
-func (clt *Client) GetMassStatus(msgid int64) (status *MassStatus, err error) {
+func (clt *Client) GetMassStatus(msgid int64, opts ...mp.CallOption) (status *MassStatus, err error) {
 	var request = struct {
 		MsgId int64 `json:"msg_id"`
 	}{
@@ -78,7 +78,7 @@ func (clt *Client) GetMassStatus(msgid int64) (status *MassStatus, err error) {
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/message/mass/get?access_token="
-	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
 		return
 	}
 
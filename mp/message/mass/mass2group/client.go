@@ -10,6 +10,7 @@ import (
 	"net/http"
 
 	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/media"
 )
 
 type Client struct {
@@ -34,54 +35,76 @@ func NewClient(TokenServer mp.TokenServer, HttpClient *http.Client) *Client {
 	}
 }
 
-func (clt *Client) SendText(msg *Text) (msgid int64, err error) {
+func (clt *Client) SendText(msg *Text, opts ...mp.CallOption) (msgid int64, err error) {
 	if msg == nil {
 		err = errors.New("msg == nil")
 		return
 	}
-	return clt.send(msg)
+	return clt.send(msg, opts...)
 }
 
-func (clt *Client) SendImage(msg *Image) (msgid int64, err error) {
+func (clt *Client) SendImage(msg *Image, opts ...mp.CallOption) (msgid int64, err error) {
 	if msg == nil {
 		err = errors.New("msg == nil")
 		return
 	}
-	return clt.send(msg)
+	return clt.send(msg, opts...)
 }
 
-func (clt *Client) SendVoice(msg *Voice) (msgid int64, err error) {
+func (clt *Client) SendVoice(msg *Voice, opts ...mp.CallOption) (msgid int64, err error) {
 	if msg == nil {
 		err = errors.New("msg == nil")
 		return
 	}
-	return clt.send(msg)
+	return clt.send(msg, opts...)
 }
 
-func (clt *Client) SendVideo(msg *Video) (msgid int64, err error) {
+func (clt *Client) SendVideo(msg *Video, opts ...mp.CallOption) (msgid int64, err error) {
 	if msg == nil {
 		err = errors.New("msg == nil")
 		return
 	}
-	return clt.send(msg)
+	return clt.send(msg, opts...)
 }
 
-func (clt *Client) SendNews(msg *News) (msgid int64, err error) {
+// 群发视频消息, msg.Video.MediaId 为通过 media.Client.UploadVideo 上传的视频.
+//  发送前先通过 media.Client.CreateVideo 转换成群发用的 media_id, title, description 可以为空; 不修改调用者的 msg.
+func (clt *Client) SendUploadedVideo(msg *Video, title, description string, opts ...mp.CallOption) (msgid int64, err error) {
 	if msg == nil {
 		err = errors.New("msg == nil")
 		return
 	}
-	return clt.send(msg)
+
+	mediaClient := media.Client{WechatClient: clt.WechatClient}
+	info, err := mediaClient.CreateVideo(msg.Video.MediaId, title, description, opts...)
+	if err != nil {
+		return
+	}
+
+	sent := *msg
+	sent.Video.MediaId = info.MediaId
+	return clt.send(&sent, opts...)
+}
+
+func (clt *Client) SendNews(msg *News, opts ...mp.CallOption) (msgid int64, err error) {
+	if msg == nil {
+		err = errors.New("msg == nil")
+		return
+	}
+	return clt.send(msg, opts...)
 }
 
-func (clt *Client) send(msg interface{}) (msgid int64, err error) {
+// 根据标签或者全部用户群发消息, 这里按标签群发.
+//
+//wechat:endpoint quota=mass_send
+func (clt *Client) send(msg interface{}, opts ...mp.CallOption) (msgid int64, err error) {
 	var result struct {
 		mp.Error
 		MsgId int64 `json:"msg_id"`
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/message/mass/sendall?access_token="
-	if err = clt.PostJSON(incompleteURL, msg, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, msg, &result, opts...); err != nil {
 		return
 	}
 
@@ -18,6 +18,10 @@ type CommonMessageHeader struct {
 		GroupId int64 `json:"group_id"`
 	} `json:"filter"`
 	MsgType string `json:"msgtype"`
+
+	// 群发消息的去重 id, 可以用 mp.NewClientMsgId 生成, 不超过64个字符;
+	// 24小时内相同 clientmsgid 的群发只会发送一次, 所以重试的时候应该使用同一个值.
+	ClientMsgId string `json:"clientmsgid,omitempty"`
 }
 
 type Text struct {
@@ -18,11 +18,18 @@ const (
 	MsgTypeNews  = "mpnews"
 )
 
-const ToUserCountLimit = 10000
+const (
+	ToUserCountLimit = 10000
+	ToUserCountMin   = 2 // 按 openid 列表群发至少要 2 个用户, 单个用户请用预览接口或者客服消息
+)
 
 type CommonMessageHeader struct {
-	ToUser  []string `json:"touser,omitempty"` // 长度不能超过 ToUserCountLimit
+	ToUser  []string `json:"touser,omitempty"` // 长度在 ToUserCountMin 和 ToUserCountLimit 之间
 	MsgType string   `json:"msgtype"`
+
+	// 群发消息的去重 id, 可以用 mp.NewClientMsgId 生成, 不超过64个字符;
+	// 24小时内相同 clientmsgid 的群发只会发送一次, 所以重试的时候应该使用同一个值.
+	ClientMsgId string `json:"clientmsgid,omitempty"`
 }
 
 func (header *CommonMessageHeader) CheckValid() (err error) {
@@ -30,6 +37,9 @@ func (header *CommonMessageHeader) CheckValid() (err error) {
 	if n <= 0 {
 		return errors.New("用户列表是空的")
 	}
+	if n < ToUserCountMin {
+		return fmt.Errorf("用户列表的长度不能少于 %d, 现在为 %d", ToUserCountMin, n)
+	}
 	if n > ToUserCountLimit {
 		return fmt.Errorf("用户列表的长度不能超过 %d, 现在为 %d", ToUserCountLimit, n)
 	}
@@ -5,6 +5,10 @@
 
 package preview
 
+import (
+	"errors"
+)
+
 const (
 	MsgTypeText  = "text"
 	MsgTypeImage = "image"
@@ -13,9 +17,18 @@ const (
 	MsgTypeNews  = "mpnews"
 )
 
+// 预览的接收者, ToUser 和 ToWxName 二选一, 同时设置时微信以 ToWxName 为准.
 type CommonMessageHeader struct {
-	ToUser  string `json:"touser"`
-	MsgType string `json:"msgtype"`
+	ToUser   string `json:"touser,omitempty"`   // 接收者的 openid
+	ToWxName string `json:"towxname,omitempty"` // 接收者的微信号, 方便编辑直接发给自己预览
+	MsgType  string `json:"msgtype"`
+}
+
+func (header *CommonMessageHeader) CheckValid() (err error) {
+	if header.ToUser == "" && header.ToWxName == "" {
+		return errors.New("touser 和 towxname 不能都为空")
+	}
+	return
 }
 
 type Text struct {
@@ -33,6 +46,15 @@ func NewText(touser, content string) *Text {
 	return &msg
 }
 
+// 按微信号预览, 见 NewText
+func NewTextToWxName(towxname, content string) *Text {
+	var msg Text
+	msg.MsgType = MsgTypeText
+	msg.ToWxName = towxname
+	msg.Text.Content = content
+	return &msg
+}
+
 type Image struct {
 	CommonMessageHeader
 	Image struct {
@@ -48,6 +70,15 @@ func NewImage(touser, mediaId string) *Image {
 	return &msg
 }
 
+// 按微信号预览, 见 NewImage
+func NewImageToWxName(towxname, mediaId string) *Image {
+	var msg Image
+	msg.MsgType = MsgTypeImage
+	msg.ToWxName = towxname
+	msg.Image.MediaId = mediaId
+	return &msg
+}
+
 type Voice struct {
 	CommonMessageHeader
 	Voice struct {
@@ -63,6 +94,15 @@ func NewVoice(touser, mediaId string) *Voice {
 	return &msg
 }
 
+// 按微信号预览, 见 NewVoice
+func NewVoiceToWxName(towxname, mediaId string) *Voice {
+	var msg Voice
+	msg.MsgType = MsgTypeVoice
+	msg.ToWxName = towxname
+	msg.Voice.MediaId = mediaId
+	return &msg
+}
+
 type Video struct {
 	CommonMessageHeader
 	Video struct {
@@ -80,6 +120,15 @@ func NewVideo(touser, mediaId string) *Video {
 	return &msg
 }
 
+// 按微信号预览, 见 NewVideo
+func NewVideoToWxName(towxname, mediaId string) *Video {
+	var msg Video
+	msg.MsgType = MsgTypeVideo
+	msg.ToWxName = towxname
+	msg.Video.MediaId = mediaId
+	return &msg
+}
+
 // 图文消息
 type News struct {
 	CommonMessageHeader
@@ -97,3 +146,12 @@ func NewNews(touser, mediaId string) *News {
 	msg.News.MediaId = mediaId
 	return &msg
 }
+
+// 按微信号预览, 见 NewNews
+func NewNewsToWxName(towxname, mediaId string) *News {
+	var msg News
+	msg.MsgType = MsgTypeNews
+	msg.ToWxName = towxname
+	msg.News.MediaId = mediaId
+	return &msg
+}
@@ -0,0 +1,108 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mass
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 群发速度的级别, 级别越大速度越慢.
+const (
+	SpeedLevel0 = 0 // 80w/分钟
+	SpeedLevel1 = 1 // 60w/分钟
+	SpeedLevel2 = 2 // 45w/分钟
+	SpeedLevel3 = 3 // 30w/分钟
+	SpeedLevel4 = 4 // 10w/分钟
+)
+
+// 各级别对应的每分钟发送的消息数
+var speedLevelMessagesPerMinute = [...]int{
+	SpeedLevel0: 800000,
+	SpeedLevel1: 600000,
+	SpeedLevel2: 450000,
+	SpeedLevel3: 300000,
+	SpeedLevel4: 100000,
+}
+
+// 返回群发速度级别对应的每分钟发送的消息数, 无效的级别返回 0.
+func SpeedLevelMessagesPerMinute(speed int) int {
+	if speed < 0 || speed >= len(speedLevelMessagesPerMinute) {
+		return 0
+	}
+	return speedLevelMessagesPerMinute[speed]
+}
+
+// 返回每分钟最多发送 messagesPerMinute 条消息的最快的级别, 比 SpeedLevel4 还慢时返回 SpeedLevel4.
+func SpeedLevelFor(messagesPerMinute int) int {
+	for speed := SpeedLevel0; speed < SpeedLevel4; speed++ {
+		if speedLevelMessagesPerMinute[speed] <= messagesPerMinute {
+			return speed
+		}
+	}
+	return SpeedLevel4
+}
+
+type MassSpeed struct {
+	Speed     int `json:"speed"`     // 群发速度的级别
+	RealSpeed int `json:"realspeed"` // 群发速度的真实值, 单位: 万/分钟
+}
+
+// 每分钟发送的消息数.
+func (speed *MassSpeed) MessagesPerMinute() int {
+	return speed.RealSpeed * 10000
+}
+
+// 获取群发速度.
+func (clt *Client) GetMassSpeed(opts ...mp.CallOption) (speed *MassSpeed, err error) {
+	var request struct{}
+
+	var result struct {
+		mp.Error
+		MassSpeed
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/message/mass/speed/get?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	speed = &result.MassSpeed
+	return
+}
+
+// 设置群发速度.
+//  speed: 群发速度的级别, SpeedLevel0 ~ SpeedLevel4, 可以用 SpeedLevelFor 根据每分钟的消息数计算
+func (clt *Client) SetMassSpeed(speed int, opts ...mp.CallOption) (err error) {
+	if speed < SpeedLevel0 || speed > SpeedLevel4 {
+		err = errors.New("invalid speed level")
+		return
+	}
+
+	var request = struct {
+		Speed int `json:"speed"`
+	}{
+		Speed: speed,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/message/mass/speed/set?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
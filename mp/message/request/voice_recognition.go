@@ -0,0 +1,101 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package request
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 语音识别接口, 公众号没有开通语音识别(或者微信没有识别出结果)时用来把语音消息转换成文本.
+type SpeechRecognizer interface {
+	// format 为语音格式, 比如 amr, speex; voice 为语音文件的内容.
+	Recognize(format string, voice []byte) (text string, err error)
+}
+
+type SpeechRecognizerFunc func(format string, voice []byte) (text string, err error)
+
+func (fn SpeechRecognizerFunc) Recognize(format string, voice []byte) (text string, err error) {
+	return fn(format, voice)
+}
+
+// 下载语音文件的接口, *media.Client 实现了该接口.
+type VoiceDownloader interface {
+	DownloadMediaToWriter(mediaId string, writer io.Writer) error
+}
+
+// 返回语音消息的文本, 不是语音消息或者没有识别结果时返回空串.
+//  经过 VoiceRecognitionHandler 处理的消息, 微信没有识别结果时返回 SpeechRecognizer 的结果.
+func VoiceText(r *mp.Request) string {
+	if r.MixedMsg == nil || r.MixedMsg.MsgType != MsgTypeVoice {
+		return ""
+	}
+	return r.MixedMsg.Recognition
+}
+
+var _ mp.MessageHandler = (*VoiceRecognitionHandler)(nil)
+
+// 在 Next 之前补全语音消息的识别结果.
+//  语音消息的 Recognition 为空时, 用 Downloader 下载语音, 交给 Recognizer 识别, 结果写入 r.MixedMsg.Recognition,
+//  这样 Next 里的 VoiceText, GetVoice 等都能拿到文本; 其他消息直接交给 Next.
+//
+//  NOTE: 微信服务器等待回复的时间只有 5 秒, Recognizer 比较慢的时候建议用异步的方式回复(比如客服消息).
+type VoiceRecognitionHandler struct {
+	Recognizer SpeechRecognizer
+	Downloader VoiceDownloader
+	Next       mp.MessageHandler
+
+	// 下载或者识别失败时的回调, 可以为 nil; 失败时 Recognition 保持为空, 依然会调用 Next.
+	OnError func(r *mp.Request, err error)
+}
+
+// 创建一个新的 VoiceRecognitionHandler.
+func NewVoiceRecognitionHandler(recognizer SpeechRecognizer, downloader VoiceDownloader, next mp.MessageHandler) *VoiceRecognitionHandler {
+	if recognizer == nil {
+		panic("nil SpeechRecognizer")
+	}
+	if downloader == nil {
+		panic("nil VoiceDownloader")
+	}
+	if next == nil {
+		panic("nil Next")
+	}
+	return &VoiceRecognitionHandler{
+		Recognizer: recognizer,
+		Downloader: downloader,
+		Next:       next,
+	}
+}
+
+func (h *VoiceRecognitionHandler) ServeMessage(w http.ResponseWriter, r *mp.Request) {
+	if msg := r.MixedMsg; msg != nil && msg.MsgType == MsgTypeVoice && msg.Recognition == "" {
+		if text, err := h.recognize(msg); err != nil {
+			if h.OnError != nil {
+				h.OnError(r, err)
+			}
+		} else {
+			msg.Recognition = text
+		}
+	}
+	h.Next.ServeMessage(w, r)
+}
+
+func (h *VoiceRecognitionHandler) recognize(msg *mp.MixedMessage) (text string, err error) {
+	if msg.MediaId == "" {
+		err = errors.New("empty MediaId")
+		return
+	}
+
+	var buf bytes.Buffer
+	if err = h.Downloader.DownloadMediaToWriter(msg.MediaId, &buf); err != nil {
+		return
+	}
+	return h.Recognizer.Recognize(msg.Format, buf.Bytes())
+}
@@ -0,0 +1,8 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 订阅通知接口.
+//  订阅通知是微信用来替代模板消息的消息类型, 用户在订阅了对应的模板以后才能收到通知.
+package subscribe
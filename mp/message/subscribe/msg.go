@@ -0,0 +1,41 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package subscribe
+
+import (
+	"encoding/json"
+
+	wechatjson "github.com/chanxuehong/wechat/json"
+)
+
+// 跳转的小程序
+type MiniProgram struct {
+	AppId    string `json:"appid"`              // 必须, 小程序的 appid, 要求该小程序已和公众号关联
+	PagePath string `json:"pagepath,omitempty"` // 可选, 跳转的小程序页面路径
+}
+
+type SubscribeMessage struct {
+	ToUser      string       `json:"touser"`                // 必须, 接受者OpenID
+	TemplateId  string       `json:"template_id"`           // 必须, 订阅通知的模版ID
+	Page        string       `json:"page,omitempty"`        // 可选, 用户点击后跳转的网页
+	MiniProgram *MiniProgram `json:"miniprogram,omitempty"` // 可选, 跳转的小程序, 优先于 Page
+
+	// 必须, JSON 格式的 []byte, 格式为 {"thing1":{"value":"..."}, "time2":{"value":"..."}}, 见 NewData
+	RawJSONData json.RawMessage `json:"data"`
+}
+
+// 把 name --> value 转换成订阅通知的 data 格式.
+func NewData(values map[string]string) (data json.RawMessage, err error) {
+	type Item struct {
+		Value string `json:"value"`
+	}
+
+	items := make(map[string]Item, len(values))
+	for name, value := range values {
+		items[name] = Item{Value: value}
+	}
+	return wechatjson.Marshal(items)
+}
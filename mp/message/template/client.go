@@ -14,6 +14,12 @@ import (
 
 type Client struct {
 	mp.WechatClient
+
+	// 可以为 nil; 否则 Send 发送前用它清理 data 里每个字段的 value, 一般 Unit 为 mp.LengthRunes.
+	TextSanitizer *mp.TextSanitizer
+
+	// 可以为 nil; 否则 Send 成功以后把发送记录写入 Tracker, 见 Tracker.
+	Tracker *Tracker
 }
 
 // 创建一个新的 Client.
@@ -65,7 +71,7 @@ func (clt *Client) SetIndustry(industryId ...int64) (err error) {
 
 // 从行业模板库选择模板添加到账号后台, 并返回模板id.
 //  templateIdShort: 模板库中模板的编号，有“TM**”和“OPENTMTM**”等形式.
-func (clt *Client) AddTemplate(templateIdShort string) (templateId string, err error) {
+func (clt *Client) AddTemplate(templateIdShort string, opts ...mp.CallOption) (templateId string, err error) {
 	var request = struct {
 		TemplateIdShort string `json:"template_id_short"`
 	}{
@@ -78,7 +84,7 @@ func (clt *Client) AddTemplate(templateIdShort string) (templateId string, err e
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/template/api_add_template?access_token="
-	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
 		return
 	}
 
@@ -91,19 +97,29 @@ func (clt *Client) AddTemplate(templateIdShort string) (templateId string, err e
 }
 
 // 发送模板消息
-func (clt *Client) Send(msg *TemplateMessage) (msgid int64, err error) {
+func (clt *Client) Send(msg *TemplateMessage, opts ...mp.CallOption) (msgid int64, err error) {
 	if msg == nil {
 		err = errors.New("nil TemplateMessage")
 		return
 	}
 
+	if clt.TextSanitizer != nil {
+		data, err := SanitizeData(msg.RawJSONData, clt.TextSanitizer)
+		if err != nil {
+			return 0, err
+		}
+		sanitized := *msg // 不修改调用者的 msg
+		sanitized.RawJSONData = data
+		msg = &sanitized
+	}
+
 	var result struct {
 		mp.Error
 		MsgId int64 `json:"msgid"`
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/message/template/send?access_token="
-	if err = clt.PostJSON(incompleteURL, msg, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, msg, &result, opts...); err != nil {
 		return
 	}
 
@@ -112,5 +128,38 @@ func (clt *Client) Send(msg *TemplateMessage) (msgid int64, err error) {
 		return
 	}
 	msgid = result.MsgId
+	if clt.Tracker != nil {
+		clt.Tracker.Sent(msgid, msg)
+	}
+	return
+}
+
+// 获取模板列表的结果
+type TemplateInfo struct {
+	TemplateId      string `json:"template_id"`      // 模板ID
+	Title           string `json:"title"`            // 模板标题
+	PrimaryIndustry string `json:"primary_industry"` // 模板所属行业的一级行业
+	DeputyIndustry  string `json:"deputy_industry"`  // 模板所属行业的二级行业
+	Content         string `json:"content"`          // 模板内容, 比如 "{{first.DATA}}\n商品名称：{{keyword1.DATA}}"
+	Example         string `json:"example"`          // 模板示例
+}
+
+// 获取已添加至帐号下所有模板列表.
+func (clt *Client) GetAllPrivateTemplate(opts ...mp.CallOption) (templates []TemplateInfo, err error) {
+	var result struct {
+		mp.Error
+		TemplateList []TemplateInfo `json:"template_list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/template/get_all_private_template?access_token="
+	if err = clt.GetJSON(incompleteURL, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	templates = result.TemplateList
 	return
 }
@@ -0,0 +1,59 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package template
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 用 sanitizer 清理模板数据里每个字段的 value, 其他内容保持不变.
+//  rawData 的格式为 {"first":{"value":"...","color":"#173177"}, ...}.
+func SanitizeData(rawData json.RawMessage, sanitizer *mp.TextSanitizer) (data json.RawMessage, err error) {
+	var fields map[string]map[string]json.RawMessage
+	if err = json.Unmarshal(rawData, &fields); err != nil {
+		return
+	}
+
+	for name, field := range fields {
+		rawValue, ok := field["value"]
+		if !ok {
+			continue
+		}
+		var value string
+		if err = json.Unmarshal(rawValue, &value); err != nil {
+			return
+		}
+		if value, err = sanitizer.Sanitize(value); err != nil {
+			return nil, fmt.Errorf("data.%s: %v", name, err)
+		}
+		if field["value"], err = marshalString(value); err != nil {
+			return
+		}
+	}
+
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err = enc.Encode(fields); err != nil {
+		return
+	}
+	data = bytes.TrimSuffix(buf.Bytes(), []byte{'\n'})
+	return
+}
+
+func marshalString(s string) (json.RawMessage, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(s); err != nil {
+		return nil, err
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}), nil
+}
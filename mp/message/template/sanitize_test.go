@@ -0,0 +1,67 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package template
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+func TestSanitizeData(t *testing.T) {
+	sanitizer := &mp.TextSanitizer{
+		Emoji:     mp.EmojiStrip,
+		Unit:      mp.LengthRunes,
+		MaxLength: 4,
+		Truncate:  true,
+	}
+	tests := []struct {
+		name    string
+		rawData string
+		want    string
+		wantErr bool
+	}{
+		{"清理 value", `{"first":{"value":"a\u0000b😀","color":"#173177"}}`, `{"first":{"value":"ab","color":"#173177"}}`, false},
+		{"截断 value", `{"keyword1":{"value":"<订单>已发货"}}`, `{"keyword1":{"value":"<订单>"}}`, false},
+		{"没有 value 的字段不变", `{"remark":{"color":"#173177"}}`, `{"remark":{"color":"#173177"}}`, false},
+		{"value 不是字符串", `{"first":{"value":1}}`, ``, true},
+		{"不是 json", `[]`, ``, true},
+	}
+	for _, tt := range tests {
+		have, err := SanitizeData(json.RawMessage(tt.rawData), sanitizer)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: want error", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		var haveFields, wantFields map[string]map[string]string
+		if err = json.Unmarshal(have, &haveFields); err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		json.Unmarshal([]byte(tt.want), &wantFields)
+		if !reflect.DeepEqual(haveFields, wantFields) {
+			t.Errorf("%s:\nhave %s\nwant %s", tt.name, have, tt.want)
+		}
+	}
+
+	// 不能把 <, >, & 转义为 \u003c, \u003e, \u0026
+	have, err := SanitizeData(json.RawMessage(`{"first":{"value":"a<b>&c"}}`), &mp.TextSanitizer{})
+	if err != nil {
+		t.Error(err)
+		return
+	}
+	if string(have) != `{"first":{"value":"a<b>&c"}}` {
+		t.Errorf("have %s", have)
+	}
+}
@@ -0,0 +1,257 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package template
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 模板消息的发送记录
+type SendRecord struct {
+	MsgId      int64     `json:"msgid"`
+	ToUser     string    `json:"touser"`
+	TemplateId string    `json:"template_id"`
+	SentAt     time.Time `json:"sent_at"`               // 调用发送接口成功的时间
+	Status     string    `json:"status,omitempty"`      // TEMPLATESENDJOBFINISH 事件的 Status, 比如 TemplateSendStatusSuccess; 还没有收到事件时为 ""
+	FinishedAt time.Time `json:"finished_at,omitempty"` // 收到 TEMPLATESENDJOBFINISH 事件的时间
+}
+
+// 把 rec 合并到 old: rec 非零值的字段覆盖 old 的字段;
+// 但是带送达状态的 rec(TEMPLATESENDJOBFINISH 事件补的记录)的 SentAt 只是估计的时间, 不覆盖 old 已有的 SentAt.
+func (old *SendRecord) merge(rec *SendRecord) {
+	if rec.ToUser != "" {
+		old.ToUser = rec.ToUser
+	}
+	if rec.TemplateId != "" {
+		old.TemplateId = rec.TemplateId
+	}
+	if !rec.SentAt.IsZero() && (rec.Status == "" || old.SentAt.IsZero()) {
+		old.SentAt = rec.SentAt
+	}
+	if rec.Status != "" {
+		old.Status = rec.Status
+		old.FinishedAt = rec.FinishedAt
+	}
+}
+
+// 是否已经收到 TEMPLATESENDJOBFINISH 事件
+func (rec *SendRecord) Finished() bool {
+	return rec.Status != ""
+}
+
+// 是否送达成功
+func (rec *SendRecord) Delivered() bool {
+	return rec.Status == TemplateSendStatusSuccess
+}
+
+// 查询发送记录的条件, 零值的字段表示不限制
+type RecordQuery struct {
+	ToUser     string
+	TemplateId string
+	From, To   time.Time // SentAt 的范围: From <= SentAt < To
+	Limit      int       // 最多返回的记录数
+}
+
+func (q *RecordQuery) match(rec *SendRecord) bool {
+	if q.ToUser != "" && rec.ToUser != q.ToUser {
+		return false
+	}
+	if q.TemplateId != "" && rec.TemplateId != q.TemplateId {
+		return false
+	}
+	if !q.From.IsZero() && rec.SentAt.Before(q.From) {
+		return false
+	}
+	if !q.To.IsZero() && !rec.SentAt.Before(q.To) {
+		return false
+	}
+	return true
+}
+
+// 保存模板消息发送记录的存储接口, 多进程环境可以用数据库等实现.
+type RecordStore interface {
+	// 保存发送记录; 记录已经存在时合并(比如事件比 Tracker.Sent 先到达, 见 SendRecord.merge),
+	// 实现需要保证合并是原子的.
+	Upsert(rec *SendRecord) error
+	// 更新发送记录的送达状态, 记录不存在时 ok == false
+	Finish(msgid int64, status string, finishedAt time.Time) (ok bool, err error)
+	// 获取发送记录, 记录不存在时 ok == false
+	Get(msgid int64) (rec SendRecord, ok bool, err error)
+	// 按 SentAt 从新到旧返回满足条件的发送记录
+	Query(q *RecordQuery) (records []SendRecord, err error)
+}
+
+var _ RecordStore = (*MemoryRecordStore)(nil)
+
+// RecordStore 的简单实现, 用于单进程环境; 记录不会过期, 请定期 Purge.
+type MemoryRecordStore struct {
+	rwmutex sync.RWMutex
+	records map[int64]SendRecord
+}
+
+func NewMemoryRecordStore() *MemoryRecordStore {
+	return &MemoryRecordStore{
+		records: make(map[int64]SendRecord),
+	}
+}
+
+func (s *MemoryRecordStore) Upsert(rec *SendRecord) error {
+	s.rwmutex.Lock()
+	defer s.rwmutex.Unlock()
+
+	old, ok := s.records[rec.MsgId]
+	if !ok {
+		s.records[rec.MsgId] = *rec
+		return nil
+	}
+	old.merge(rec)
+	s.records[rec.MsgId] = old
+	return nil
+}
+
+func (s *MemoryRecordStore) Finish(msgid int64, status string, finishedAt time.Time) (ok bool, err error) {
+	s.rwmutex.Lock()
+	defer s.rwmutex.Unlock()
+
+	rec, ok := s.records[msgid]
+	if !ok {
+		return
+	}
+	rec.Status = status
+	rec.FinishedAt = finishedAt
+	s.records[msgid] = rec
+	return
+}
+
+func (s *MemoryRecordStore) Get(msgid int64) (rec SendRecord, ok bool, err error) {
+	s.rwmutex.RLock()
+	rec, ok = s.records[msgid]
+	s.rwmutex.RUnlock()
+	return
+}
+
+func (s *MemoryRecordStore) Query(q *RecordQuery) (records []SendRecord, err error) {
+	s.rwmutex.RLock()
+	for _, rec := range s.records {
+		if q.match(&rec) {
+			records = append(records, rec)
+		}
+	}
+	s.rwmutex.RUnlock()
+
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].SentAt.Equal(records[j].SentAt) {
+			return records[i].SentAt.After(records[j].SentAt)
+		}
+		return records[i].MsgId > records[j].MsgId
+	})
+	if q.Limit > 0 && len(records) > q.Limit {
+		records = records[:q.Limit]
+	}
+	return
+}
+
+// 删除 SentAt 在 before 之前的记录.
+func (s *MemoryRecordStore) Purge(before time.Time) {
+	s.rwmutex.Lock()
+	for msgid, rec := range s.records {
+		if rec.SentAt.Before(before) {
+			delete(s.records, msgid)
+		}
+	}
+	s.rwmutex.Unlock()
+}
+
+// 记录模板消息的发送和送达结果, 用来回答 "用户 X 收到通知了吗".
+//  设置到 Client.Tracker 以后 Send 成功时自动记录, 另外需要在 mp.MessageHandler 里调用 HandleEvent.
+type Tracker struct {
+	Store RecordStore
+
+	// 存储出错时的回调, 可以为 nil; 记录失败不影响 Send 的结果.
+	OnError func(msgid int64, err error)
+}
+
+// 创建一个新的 Tracker, store 为 nil 时使用 MemoryRecordStore.
+func NewTracker(store RecordStore) *Tracker {
+	if store == nil {
+		store = NewMemoryRecordStore()
+	}
+	return &Tracker{
+		Store: store,
+	}
+}
+
+func (t *Tracker) onError(msgid int64, err error) {
+	if t.OnError != nil {
+		t.OnError(msgid, err)
+	}
+}
+
+// 记录一条发送成功的模板消息, 已经收到 TEMPLATESENDJOBFINISH 事件时保留事件的送达状态.
+func (t *Tracker) Sent(msgid int64, msg *TemplateMessage) {
+	rec := &SendRecord{
+		MsgId:      msgid,
+		ToUser:     msg.ToUser,
+		TemplateId: msg.TemplateId,
+		SentAt:     time.Now(),
+	}
+	if err := t.Store.Upsert(rec); err != nil {
+		t.onError(msgid, err)
+	}
+}
+
+// 处理 TEMPLATESENDJOBFINISH 事件, 其他消息直接忽略. 返回是否是 TEMPLATESENDJOBFINISH 事件.
+func (t *Tracker) HandleEvent(msg *mp.MixedMessage) bool {
+	if msg.MsgType != "event" || msg.Event != EventTypeTemplateSendJobFinish {
+		return false
+	}
+	event := GetTemplateSendJobFinishEvent(msg)
+
+	finishedAt := time.Now()
+	if event.CreateTime > 0 {
+		finishedAt = time.Unix(event.CreateTime, 0)
+	}
+	ok, err := t.Store.Finish(event.MsgId, event.Status, finishedAt)
+	if err != nil {
+		t.onError(event.MsgId, err)
+		return true
+	}
+	if !ok {
+		// 没有发送记录(比如 Tracker 启用之前发送的, 或者事件比 Sent 先到达), 用事件的信息补一条;
+		// SentAt 用事件的时间, 否则 RecordQuery 按时间查询时查不到这条记录.
+		// 之后 Sent 的记录合并进来时会覆盖这个 SentAt.
+		rec := &SendRecord{
+			MsgId:      event.MsgId,
+			ToUser:     event.FromUserName,
+			SentAt:     finishedAt,
+			Status:     event.Status,
+			FinishedAt: finishedAt,
+		}
+		if err = t.Store.Upsert(rec); err != nil {
+			t.onError(event.MsgId, err)
+		}
+	}
+	return true
+}
+
+// 获取 msgid 的发送记录.
+func (t *Tracker) Get(msgid int64) (rec SendRecord, ok bool, err error) {
+	return t.Store.Get(msgid)
+}
+
+// 返回用户 openid 最近的 limit 条发送记录, limit <= 0 表示不限制.
+func (t *Tracker) Recent(openid string, limit int) ([]SendRecord, error) {
+	return t.Store.Query(&RecordQuery{ToUser: openid, Limit: limit})
+}
+
+// 按条件查询发送记录.
+func (t *Tracker) Query(q *RecordQuery) ([]SendRecord, error) {
+	return t.Store.Query(q)
+}
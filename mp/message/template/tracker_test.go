@@ -0,0 +1,74 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package template
+
+import (
+	"testing"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+func TestTrackerSentAndHandleEvent(t *testing.T) {
+	const msgid = 100
+	eventTime := time.Unix(1500000000, 0)
+	msg := &TemplateMessage{ToUser: "openid", TemplateId: "template_id"}
+	event := &mp.MixedMessage{}
+	event.MsgType = "event"
+	event.Event = EventTypeTemplateSendJobFinish
+	event.FromUserName = "openid"
+	event.CreateTime = eventTime.Unix()
+	event.MsgID = msgid
+	event.Status = TemplateSendStatusSuccess
+
+	tests := []struct {
+		name        string
+		eventFirst  bool // 事件比 Sent 先到达
+		skipSent    bool // Tracker 启用之前发送的, 没有 Sent
+		wantTemplId string
+	}{
+		{"先 Sent 后事件", false, false, "template_id"},
+		{"事件先到达", true, false, "template_id"},
+		{"只有事件", true, true, ""},
+	}
+	for _, tt := range tests {
+		tracker := NewTracker(nil)
+		before := time.Now()
+		if tt.eventFirst {
+			tracker.HandleEvent(event)
+		}
+		if !tt.skipSent {
+			tracker.Sent(msgid, msg)
+		}
+		if !tt.eventFirst {
+			tracker.HandleEvent(event)
+		}
+
+		rec, ok, err := tracker.Get(msgid)
+		if err != nil || !ok {
+			t.Errorf("%s: Get() = %v, %v", tt.name, ok, err)
+			continue
+		}
+		if !rec.Delivered() || !rec.FinishedAt.Equal(eventTime) {
+			t.Errorf("%s: 送达状态丢失: %+v", tt.name, rec)
+		}
+		if rec.ToUser != "openid" || rec.TemplateId != tt.wantTemplId {
+			t.Errorf("%s: have ToUser %q, TemplateId %q", tt.name, rec.ToUser, rec.TemplateId)
+		}
+		if tt.skipSent {
+			if !rec.SentAt.Equal(eventTime) {
+				t.Errorf("%s: have SentAt %s, want %s", tt.name, rec.SentAt, eventTime)
+			}
+		} else if rec.SentAt.Before(before) {
+			t.Errorf("%s: have SentAt %s, want Sent 的时间", tt.name, rec.SentAt)
+		}
+
+		records, _ := tracker.Query(&RecordQuery{ToUser: "openid", From: eventTime})
+		if len(records) != 1 {
+			t.Errorf("%s: 按时间查询到 %d 条记录, want 1", tt.name, len(records))
+		}
+	}
+}
@@ -0,0 +1,206 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package template
+
+import (
+	"encoding/json"
+	"errors"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+var placeholderRegexp = regexp.MustCompile(`\{\{\s*([0-9A-Za-z_]+)\.DATA\s*\}\}`)
+
+// 返回模板内容里的占位符名称, 比如 "{{first.DATA}}\n商品：{{keyword1.DATA}}" 返回 ["first", "keyword1"]; 按出现的顺序排列.
+func (info *TemplateInfo) Keys() (keys []string) {
+	matches := placeholderRegexp.FindAllStringSubmatch(info.Content, -1)
+	if len(matches) == 0 {
+		return
+	}
+	keys = make([]string, 0, len(matches))
+	seen := make(map[string]bool, len(matches))
+	for _, m := range matches {
+		if !seen[m[1]] {
+			seen[m[1]] = true
+			keys = append(keys, m[1])
+		}
+	}
+	return
+}
+
+// 模板消息校验失败的错误.
+type ValidationError struct {
+	TemplateId string
+	Key        string // 出错的数据字段, 为空表示整条消息的错误
+	Reason     string
+}
+
+func (e *ValidationError) Error() string {
+	if e.Key == "" {
+		return "template " + e.TemplateId + ": " + e.Reason
+	}
+	return "template " + e.TemplateId + ": data." + e.Key + ": " + e.Reason
+}
+
+const (
+	DefaultTemplateCacheTTL = time.Hour
+	DefaultMaxValueLength   = 200 // 超过这个长度的字段微信会截断显示
+)
+
+var colorRegexp = regexp.MustCompile(`^#[0-9A-Fa-f]{6}$`)
+
+// 发送前校验模板消息的 Client.
+//  第一次使用时获取帐号下的模板列表并缓存 TTL 时间, 找不到模板ID时会立即重新获取一次, 以便新添加的模板可以马上使用.
+//  校验的内容: 模板ID是否存在, data 的字段是否和模板的占位符一致, 颜色的格式, 字段值的长度.
+type ValidatingClient struct {
+	*Client
+
+	TTL            time.Duration // 模板列表的缓存时间, 为 0 时使用 DefaultTemplateCacheTTL
+	MaxValueLength int           // 字段值的最大字符数, 为 0 时使用 DefaultMaxValueLength, 小于 0 不校验长度
+	AllowMissing   bool          // 是否允许缺少模板里的字段, 缺少的字段微信显示为空
+
+	mutex     sync.Mutex
+	templates map[string]*TemplateInfo
+	fetchedAt time.Time
+}
+
+// 创建一个新的 ValidatingClient.
+func NewValidatingClient(clt *Client) *ValidatingClient {
+	if clt == nil {
+		panic("nil Client")
+	}
+	return &ValidatingClient{
+		Client: clt,
+	}
+}
+
+// 清空模板列表的缓存, 比如删除或者添加模板之后.
+func (clt *ValidatingClient) Refresh() {
+	clt.mutex.Lock()
+	clt.templates = nil
+	clt.mutex.Unlock()
+}
+
+// 返回帐号下模板ID对应的模板, 不存在时返回 nil, nil.
+func (clt *ValidatingClient) Template(templateId string, opts ...mp.CallOption) (info *TemplateInfo, err error) {
+	ttl := clt.TTL
+	if ttl <= 0 {
+		ttl = DefaultTemplateCacheTTL
+	}
+
+	clt.mutex.Lock()
+	defer clt.mutex.Unlock()
+
+	fresh := clt.templates != nil && time.Since(clt.fetchedAt) < ttl
+	if fresh {
+		if info = clt.templates[templateId]; info != nil {
+			return
+		}
+		if time.Since(clt.fetchedAt) < time.Minute { // 避免错误的模板ID导致频繁获取列表
+			return
+		}
+	}
+
+	list, err := clt.GetAllPrivateTemplate(opts...)
+	if err != nil {
+		return
+	}
+	templates := make(map[string]*TemplateInfo, len(list))
+	for i := range list {
+		templates[list[i].TemplateId] = &list[i]
+	}
+	clt.templates = templates
+	clt.fetchedAt = time.Now()
+
+	info = templates[templateId]
+	return
+}
+
+// 校验模板消息, 校验失败返回 *ValidationError.
+func (clt *ValidatingClient) Validate(msg *TemplateMessage, opts ...mp.CallOption) (err error) {
+	if msg == nil {
+		return errors.New("nil TemplateMessage")
+	}
+	if msg.ToUser == "" {
+		return &ValidationError{TemplateId: msg.TemplateId, Reason: "empty touser"}
+	}
+	if msg.TopColor != "" && !colorRegexp.MatchString(msg.TopColor) {
+		return &ValidationError{TemplateId: msg.TemplateId, Reason: "invalid topcolor " + msg.TopColor + ", want #RRGGBB"}
+	}
+
+	info, err := clt.Template(msg.TemplateId, opts...)
+	if err != nil {
+		return
+	}
+	if info == nil {
+		return &ValidationError{TemplateId: msg.TemplateId, Reason: "template_id not found in the account's template list"}
+	}
+
+	maxLength := clt.MaxValueLength
+	if maxLength == 0 {
+		maxLength = DefaultMaxValueLength
+	}
+	return validateData(info, msg.RawJSONData, maxLength, clt.AllowMissing)
+}
+
+func validateData(info *TemplateInfo, rawData json.RawMessage, maxLength int, allowMissing bool) error {
+	var data map[string]struct {
+		Value *string `json:"value"`
+		Color string  `json:"color"`
+	}
+	if len(rawData) == 0 {
+		return &ValidationError{TemplateId: info.TemplateId, Reason: "empty data"}
+	}
+	if err := json.Unmarshal(rawData, &data); err != nil {
+		return &ValidationError{TemplateId: info.TemplateId, Reason: "invalid data: " + err.Error()}
+	}
+
+	keys := info.Keys()
+	keySet := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		keySet[key] = true
+		if _, ok := data[key]; !ok && !allowMissing {
+			return &ValidationError{TemplateId: info.TemplateId, Key: key, Reason: "missing, template " + info.Title + " needs " + strings.Join(keys, ", ")}
+		}
+	}
+
+	names := make([]string, 0, len(data))
+	for name := range data {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		item := data[name]
+		if !keySet[name] {
+			return &ValidationError{TemplateId: info.TemplateId, Key: name, Reason: "not a placeholder of template " + info.Title + ", want one of " + strings.Join(keys, ", ")}
+		}
+		if item.Value == nil {
+			return &ValidationError{TemplateId: info.TemplateId, Key: name, Reason: "missing value"}
+		}
+		if n := utf8.RuneCountInString(*item.Value); maxLength > 0 && n > maxLength {
+			return &ValidationError{TemplateId: info.TemplateId, Key: name, Reason: "value too long, will be truncated"}
+		}
+		if item.Color != "" && !colorRegexp.MatchString(item.Color) {
+			return &ValidationError{TemplateId: info.TemplateId, Key: name, Reason: "invalid color " + item.Color + ", want #RRGGBB"}
+		}
+	}
+	return nil
+}
+
+// 校验模板消息, 通过后发送.
+func (clt *ValidatingClient) Send(msg *TemplateMessage, opts ...mp.CallOption) (msgid int64, err error) {
+	if err = clt.Validate(msg, opts...); err != nil {
+		return
+	}
+	return clt.Client.Send(msg, opts...)
+}
@@ -139,4 +139,52 @@ type MixedMessage struct {
 	FriendUserName string `xml:"FriendUserName" json:"FriendUserName"`
 	UserCardCode   string `xml:"UserCardCode"   json:"UserCardCode"`
 	OuterId        int64  `xml:"OuterId"        json:"OuterId"`
+	PageId         string `xml:"PageId"         json:"PageId"`
+	IsReturnBack   bool   `xml:"IsReturnBack"   json:"IsReturnBack"`
+	IsChatRoom     bool   `xml:"IsChatRoom"     json:"IsChatRoom"`
+
+	ChosenBeacon struct {
+		UUID     string  `xml:"Uuid"     json:"Uuid"`
+		Major    int     `xml:"Major"    json:"Major"`
+		Minor    int     `xml:"Minor"    json:"Minor"`
+		Distance float64 `xml:"Distance" json:"Distance"`
+	} `xml:"ChosenBeacon" json:"ChosenBeacon"`
+	AroundBeacons []struct {
+		UUID     string  `xml:"Uuid"     json:"Uuid"`
+		Major    int     `xml:"Major"    json:"Major"`
+		Minor    int     `xml:"Minor"    json:"Minor"`
+		Distance float64 `xml:"Distance" json:"Distance"`
+	} `xml:"AroundBeacons>AroundBeacon,omitempty" json:"AroundBeacons,omitempty"`
+
+	DeviceType string `xml:"DeviceType" json:"DeviceType"`
+	DeviceID   string `xml:"DeviceID"   json:"DeviceID"`
+	SessionID  int64  `xml:"SessionID"  json:"SessionID"`
+	OpenID     string `xml:"OpenID"     json:"OpenID"`
+	OpType     int    `xml:"OpType"     json:"OpType"`
+
+	KeyStandard string `xml:"KeyStandard" json:"KeyStandard"`
+	KeyStr      string `xml:"KeyStr"      json:"KeyStr"`
+	Country     string `xml:"Country"     json:"Country"`
+	Province    string `xml:"Province"    json:"Province"`
+	City        string `xml:"City"        json:"City"`
+	Sex         int    `xml:"Sex"         json:"Sex"`
+	Scene       int    `xml:"Scene"       json:"Scene"`
+	ExtInfo     string `xml:"ExtInfo"     json:"ExtInfo"`
+	RegionCode  string `xml:"RegionCode"  json:"RegionCode"`
+	Result      string `xml:"Result"      json:"Result"`
+	ReasonMsg   string `xml:"ReasonMsg"   json:"ReasonMsg"`
+
+	PublishEventInfo struct {
+		PublishId     string `xml:"publish_id"     json:"publish_id"`
+		PublishStatus int    `xml:"publish_status" json:"publish_status"`
+		ArticleId     string `xml:"article_id"     json:"article_id"`
+		ArticleDetail struct {
+			Count int `xml:"count" json:"count"`
+			Items []struct {
+				Idx        int    `xml:"idx"         json:"idx"`
+				ArticleURL string `xml:"article_url" json:"article_url"`
+			} `xml:"item,omitempty" json:"item,omitempty"`
+		} `xml:"article_detail" json:"article_detail"`
+		FailIdx []int `xml:"fail_idx,omitempty" json:"fail_idx,omitempty"`
+	} `xml:"PublishEventInfo" json:"PublishEventInfo"`
 }
@@ -0,0 +1,213 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// 微信服务器等待回复的时间是 5 秒, 默认在 4 秒的时候回调 OnSlow, 留给业务降级(比如先回复空串再用客服消息回复)
+	DefaultSlowHandlerThreshold = 4 * time.Second
+
+	// 微信服务器在 5 秒内没有收到回复会重试 3 次, 默认在 1 分钟内收到相同的消息认为是重试
+	DefaultRetryWindow = time.Minute
+)
+
+// 一类消息(事件)处理的统计信息
+type HandlerStats struct {
+	Count     int64         // 处理的次数
+	Errors    int64         // 失败的次数, 包括 panic 和回复了 4xx/5xx 状态码
+	Retries   int64         // 检测到的微信服务器重试的次数
+	Slow      int64         // 处理时间超过 SlowThreshold 的次数
+	TotalTime time.Duration // 累计的处理时间
+	MaxTime   time.Duration // 最长的处理时间
+}
+
+// 平均处理时间
+func (stats *HandlerStats) AvgTime() time.Duration {
+	if stats.Count == 0 {
+		return 0
+	}
+	return stats.TotalTime / time.Duration(stats.Count)
+}
+
+var _ MessageHandler = (*HandlerMetrics)(nil)
+
+// 包装 MessageHandler, 统计每类消息(事件)的处理时间和失败次数, 并且检测微信服务器的重试(相同的消息再次推送)和处理过慢.
+//  消息按照 MsgType 分类, 事件按照 "event:" + Event 分类.
+type HandlerMetrics struct {
+	Handler MessageHandler
+
+	SlowThreshold time.Duration // 默认为 DefaultSlowHandlerThreshold
+	RetryWindow   time.Duration // 默认为 DefaultRetryWindow
+
+	// 处理时间达到 SlowThreshold 时回调, 此时 handler 还没有返回; 可以为 nil.
+	//  NOTE: OnSlow 在单独的 goroutine 里调用.
+	OnSlow func(r *Request, elapsed time.Duration)
+	// 收到微信服务器重试的消息时回调, 在调用 Handler 之前调用; 可以为 nil.
+	OnRetry func(r *Request)
+	// 每次处理结束的回调, 一般用于对接 prometheus 之类的监控系统; 可以为 nil.
+	OnObserve func(kind string, elapsed time.Duration, failed, retry bool)
+
+	mutex     sync.Mutex
+	stats     map[string]*HandlerStats
+	seen      map[string]time.Time // 消息的 key 及第一次收到的时间
+	lastPrune time.Time
+}
+
+func NewHandlerMetrics(handler MessageHandler) *HandlerMetrics {
+	if handler == nil {
+		panic("mp: nil MessageHandler")
+	}
+	return &HandlerMetrics{
+		Handler: handler,
+	}
+}
+
+func (m *HandlerMetrics) slowThreshold() time.Duration {
+	if m.SlowThreshold > 0 {
+		return m.SlowThreshold
+	}
+	return DefaultSlowHandlerThreshold
+}
+
+func (m *HandlerMetrics) retryWindow() time.Duration {
+	if m.RetryWindow > 0 {
+		return m.RetryWindow
+	}
+	return DefaultRetryWindow
+}
+
+func messageKind(msg *MixedMessage) string {
+	if msg.MsgType == "event" {
+		return "event:" + msg.Event
+	}
+	return msg.MsgType
+}
+
+// 消息用 MsgId 排重, 事件用 FromUserName + CreateTime 排重.
+func messageKey(msg *MixedMessage) string {
+	if msg.MsgId != 0 {
+		return "id:" + strconv.FormatInt(msg.MsgId, 10)
+	}
+	if msg.MsgID != 0 {
+		return "id:" + strconv.FormatInt(msg.MsgID, 10)
+	}
+	return msg.FromUserName + ":" + strconv.FormatInt(msg.CreateTime, 10) + ":" + msg.Event
+}
+
+// 记录 key, 返回是否在 RetryWindow 内见过.
+func (m *HandlerMetrics) markSeen(key string, now time.Time) (retry bool) {
+	window := m.retryWindow()
+
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	if m.seen == nil {
+		m.seen = make(map[string]time.Time)
+	}
+	if now.Sub(m.lastPrune) >= window {
+		for k, t := range m.seen {
+			if now.Sub(t) >= window {
+				delete(m.seen, k)
+			}
+		}
+		m.lastPrune = now
+	}
+	if t, ok := m.seen[key]; ok && now.Sub(t) < window {
+		return true
+	}
+	m.seen[key] = now
+	return false
+}
+
+func (m *HandlerMetrics) observe(kind string, elapsed time.Duration, failed, retry bool) {
+	m.mutex.Lock()
+	if m.stats == nil {
+		m.stats = make(map[string]*HandlerStats)
+	}
+	stats := m.stats[kind]
+	if stats == nil {
+		stats = new(HandlerStats)
+		m.stats[kind] = stats
+	}
+	stats.Count++
+	if failed {
+		stats.Errors++
+	}
+	if retry {
+		stats.Retries++
+	}
+	if elapsed >= m.slowThreshold() {
+		stats.Slow++
+	}
+	stats.TotalTime += elapsed
+	if elapsed > stats.MaxTime {
+		stats.MaxTime = elapsed
+	}
+	m.mutex.Unlock()
+
+	if m.OnObserve != nil {
+		m.OnObserve(kind, elapsed, failed, retry)
+	}
+}
+
+// 返回每类消息(事件)的统计信息.
+func (m *HandlerMetrics) Stats() map[string]HandlerStats {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	stats := make(map[string]HandlerStats, len(m.stats))
+	for kind, s := range m.stats {
+		stats[kind] = *s
+	}
+	return stats
+}
+
+// 记录回复状态码的 http.ResponseWriter
+type statusResponseWriter struct {
+	http.ResponseWriter
+	statusCode int
+}
+
+func (w *statusResponseWriter) WriteHeader(statusCode int) {
+	if w.statusCode == 0 {
+		w.statusCode = statusCode
+	}
+	w.ResponseWriter.WriteHeader(statusCode)
+}
+
+func (m *HandlerMetrics) ServeMessage(w http.ResponseWriter, r *Request) {
+	kind := messageKind(r.MixedMsg)
+	start := time.Now()
+
+	retry := m.markSeen(messageKey(r.MixedMsg), start)
+	if retry && m.OnRetry != nil {
+		m.OnRetry(r)
+	}
+
+	var timer *time.Timer
+	if m.OnSlow != nil {
+		threshold, onSlow := m.slowThreshold(), m.OnSlow
+		timer = time.AfterFunc(threshold, func() { onSlow(r, threshold) })
+	}
+
+	sw := &statusResponseWriter{ResponseWriter: w}
+	failed := true
+	defer func() {
+		if timer != nil {
+			timer.Stop()
+		}
+		m.observe(kind, time.Since(start), failed || sw.statusCode >= 400, retry)
+	}()
+
+	m.Handler.ServeMessage(sw, r)
+	failed = false // 没有 panic
+}
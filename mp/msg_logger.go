@@ -0,0 +1,173 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"encoding/json"
+	"log"
+	"math"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const defaultCoordinateDigits = 2 // 保留 2 位小数, 大约 1 公里
+
+// 日志里个人信息的脱敏规则, 零值表示全部脱敏.
+type PIIMasker struct {
+	KeepOpenId   bool // 不对 openid 脱敏
+	KeepPhone    bool // 不对文本里的手机号码脱敏
+	KeepLocation bool // 不对地理位置脱敏
+
+	// 地理位置脱敏时坐标保留的小数位数, 0 表示默认的 2 位(大约 1 公里)
+	CoordinateDigits int
+}
+
+// openid 只保留前 4 位和后 4 位.
+func (m *PIIMasker) MaskOpenId(openid string) string {
+	if m.KeepOpenId || openid == "" {
+		return openid
+	}
+	if len(openid) <= 8 {
+		return strings.Repeat("*", len(openid))
+	}
+	return openid[:4] + strings.Repeat("*", len(openid)-8) + openid[len(openid)-4:]
+}
+
+var digitsRegexp = regexp.MustCompile(`\d+`)
+
+// 手机号码(可以带 86 前缀)只保留前 3 位和后 4 位.
+func (m *PIIMasker) MaskText(text string) string {
+	if m.KeepPhone || text == "" {
+		return text
+	}
+	return digitsRegexp.ReplaceAllStringFunc(text, func(digits string) string {
+		prefix := ""
+		if len(digits) == 13 && strings.HasPrefix(digits, "86") {
+			prefix, digits = "86", digits[2:]
+		}
+		if len(digits) != 11 || digits[0] != '1' || digits[1] < '3' {
+			return prefix + digits
+		}
+		return prefix + digits[:3] + "****" + digits[7:]
+	})
+}
+
+// 坐标只保留 CoordinateDigits 位小数.
+func (m *PIIMasker) MaskCoordinate(f float64) float64 {
+	if m.KeepLocation {
+		return f
+	}
+	digits := m.CoordinateDigits
+	if digits <= 0 {
+		digits = defaultCoordinateDigits
+	}
+	scale := math.Pow10(digits)
+	return math.Trunc(f*scale) / scale
+}
+
+// 地理位置的描述(地址)全部隐藏.
+func (m *PIIMasker) MaskLabel(label string) string {
+	if m.KeepLocation || label == "" {
+		return label
+	}
+	return "***"
+}
+
+// 一条消息(事件)的日志
+type MessageLogEntry struct {
+	Time      time.Time `json:"time"`
+	WechatId  string    `json:"wechat_id"`
+	MsgType   string    `json:"msg_type"`
+	Event     string    `json:"event,omitempty"`
+	MsgId     int64     `json:"msg_id,omitempty"`
+	OpenId    string    `json:"openid"`
+	Content   string    `json:"content,omitempty"`
+	EventKey  string    `json:"event_key,omitempty"`
+	Latitude  float64   `json:"latitude,omitempty"`
+	Longitude float64   `json:"longitude,omitempty"`
+	Label     string    `json:"label,omitempty"`
+}
+
+// 生成 msg 脱敏以后的日志.
+func (m *PIIMasker) LogEntry(wechatId string, msg *MixedMessage) *MessageLogEntry {
+	entry := &MessageLogEntry{
+		Time:     time.Now(),
+		WechatId: wechatId,
+		MsgType:  msg.MsgType,
+		Event:    msg.Event,
+		MsgId:    msg.MsgId,
+		OpenId:   m.MaskOpenId(msg.FromUserName),
+		Content:  m.MaskText(msg.Content),
+		EventKey: msg.EventKey,
+	}
+	if entry.MsgId == 0 {
+		entry.MsgId = msg.MsgID
+	}
+
+	switch {
+	case msg.LocationX != 0 || msg.LocationY != 0: // 地理位置消息
+		entry.Latitude, entry.Longitude, entry.Label = msg.LocationX, msg.LocationY, msg.Label
+	case msg.Latitude != 0 || msg.Longitude != 0: // 上报地理位置事件
+		entry.Latitude, entry.Longitude = msg.Latitude, msg.Longitude
+	case msg.SendLocationInfo.LocationX != 0 || msg.SendLocationInfo.LocationY != 0: // 弹出地理位置选择器的事件
+		info := &msg.SendLocationInfo
+		entry.Latitude, entry.Longitude, entry.Label = info.LocationX, info.LocationY, info.Label
+	}
+	entry.Latitude = m.MaskCoordinate(entry.Latitude)
+	entry.Longitude = m.MaskCoordinate(entry.Longitude)
+	entry.Label = m.MaskLabel(entry.Label)
+	return entry
+}
+
+var _ MessageHandler = (*MessageLogger)(nil)
+
+// 包装 MessageHandler, 在处理之前记录每条消息(事件)脱敏以后的日志, 用于审计.
+type MessageLogger struct {
+	Handler MessageHandler
+	Masker  *PIIMasker // 为 nil 时全部脱敏
+
+	// 记录日志, 为 nil 时把 MessageLogEntry 编码成 JSON 写入 Logger.
+	//  可以用来对接其他结构化日志库.
+	Sink func(entry *MessageLogEntry)
+	// Sink 为 nil 时使用, 为 nil 时使用 log 包默认的 Logger.
+	Logger *log.Logger
+}
+
+func NewMessageLogger(handler MessageHandler) *MessageLogger {
+	if handler == nil {
+		panic("mp: nil MessageHandler")
+	}
+	return &MessageLogger{
+		Handler: handler,
+	}
+}
+
+func (l *MessageLogger) ServeMessage(w http.ResponseWriter, r *Request) {
+	masker := l.Masker
+	if masker == nil {
+		masker = &PIIMasker{}
+	}
+	entry := masker.LogEntry(r.WechatId, r.MixedMsg)
+
+	switch {
+	case l.Sink != nil:
+		l.Sink(entry)
+	default:
+		data, err := json.Marshal(entry)
+		if err != nil {
+			break
+		}
+		if l.Logger != nil {
+			l.Logger.Printf("mp inbound message: %s", data)
+		} else {
+			log.Printf("mp inbound message: %s", data)
+		}
+	}
+
+	l.Handler.ServeMessage(w, r)
+}
@@ -0,0 +1,11 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 通知发送.
+//  微信正在用订阅通知替代模板消息, 业务代码通过 Notifier.NotifySend 按通知 id 发送,
+//  每个通知用模板消息还是订阅通知由配置决定, 迁移的时候只需要修改配置.
+//
+//  Service 在 Notifier 之上增加了模板渲染和按用户选择通道(包括客服消息), 通知的内容只需要定义一次.
+package notify
@@ -0,0 +1,232 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package notify
+
+import (
+	"errors"
+	"sync"
+
+	wechatjson "github.com/chanxuehong/wechat/json"
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/message/subscribe"
+	"github.com/chanxuehong/wechat/mp/message/template"
+)
+
+// 通知的发送通道
+type Channel int
+
+const (
+	ChannelTemplate  Channel = iota // 模板消息
+	ChannelSubscribe                // 订阅通知
+	ChannelCustom                   // 客服消息(文本), 只有 Service 支持
+)
+
+func (c Channel) String() string {
+	switch c {
+	case ChannelTemplate:
+		return "template"
+	case ChannelSubscribe:
+		return "subscribe"
+	case ChannelCustom:
+		return "custom"
+	default:
+		return "unknown"
+	}
+}
+
+// 一个通知的配置.
+//  NotifySend 的 data 使用业务自己的字段名, 发送时通过 TemplateKeys, SubscribeKeys 转换成模板里的字段名,
+//  没有配置的字段名原样使用; 两个通道都没有的字段可以用 "" 忽略, 比如 SubscribeKeys["first"] = "".
+type Notification struct {
+	Channel Channel // 使用的通道
+
+	// 模板消息的配置
+	TemplateId   string
+	URL          string            // 可选, 用户点击后跳转的URL
+	TemplateKeys map[string]string // 字段名 --> 模板消息的字段名, 比如 "product" --> "keyword1"
+
+	// 订阅通知的配置
+	SubscribeTemplateId string
+	Page                string                 // 可选, 用户点击后跳转的网页
+	MiniProgram         *subscribe.MiniProgram // 可选, 跳转的小程序
+	SubscribeKeys       map[string]string      // 字段名 --> 订阅通知的字段名, 比如 "product" --> "thing1"
+
+	// 订阅通知因为用户没有订阅(43101)发送失败时, 是否改用模板消息发送, 需要配置 TemplateId.
+	FallbackToTemplate bool
+}
+
+// NotifySend 的结果
+type Result struct {
+	Channel  Channel // 实际使用的通道
+	MsgId    int64   // 模板消息的 msgid, 订阅通知没有 msgid
+	FellBack bool    // 是否因为订阅通知失败改用了模板消息
+}
+
+// 按通知 id 发送通知, 根据配置选择模板消息或者订阅通知.
+type Notifier struct {
+	Template  *template.Client  // 使用模板消息时必须
+	Subscribe *subscribe.Client // 使用订阅通知时必须
+
+	rwmutex       sync.RWMutex
+	notifications map[string]Notification
+}
+
+func NewNotifier(templateClient *template.Client, subscribeClient *subscribe.Client) *Notifier {
+	return &Notifier{
+		Template:      templateClient,
+		Subscribe:     subscribeClient,
+		notifications: make(map[string]Notification),
+	}
+}
+
+// 登记通知的配置, 已经存在的会被覆盖.
+func (n *Notifier) Register(notificationId string, notification *Notification) {
+	if notification == nil {
+		panic("nil Notification")
+	}
+	n.rwmutex.Lock()
+	n.notifications[notificationId] = *notification
+	n.rwmutex.Unlock()
+}
+
+// 修改通知使用的通道, 比如迁移完成后把通知切换到订阅通知.
+func (n *Notifier) SetChannel(notificationId string, channel Channel) (err error) {
+	n.rwmutex.Lock()
+	defer n.rwmutex.Unlock()
+
+	notification, ok := n.notifications[notificationId]
+	if !ok {
+		return errors.New("unknown notification: " + notificationId)
+	}
+	notification.Channel = channel
+	n.notifications[notificationId] = notification
+	return
+}
+
+// 返回通知的配置.
+func (n *Notifier) Notification(notificationId string) (notification Notification, ok bool) {
+	n.rwmutex.RLock()
+	notification, ok = n.notifications[notificationId]
+	n.rwmutex.RUnlock()
+	return
+}
+
+// 给用户发送通知.
+//  data: 字段名 --> 字段的值, 字段名见 Notification 的说明.
+func (n *Notifier) NotifySend(openid, notificationId string, data map[string]string, opts ...mp.CallOption) (rst *Result, err error) {
+	if openid == "" {
+		err = errors.New("empty openid")
+		return
+	}
+	notification, ok := n.Notification(notificationId)
+	if !ok {
+		err = errors.New("unknown notification: " + notificationId)
+		return
+	}
+	return n.send(openid, &notification, data, opts...)
+}
+
+func (n *Notifier) send(openid string, notification *Notification, data map[string]string, opts ...mp.CallOption) (rst *Result, err error) {
+	switch notification.Channel {
+	case ChannelTemplate:
+		return n.sendTemplate(openid, notification, data, false, opts...)
+	case ChannelSubscribe:
+		if err = n.sendSubscribe(openid, notification, data, opts...); err == nil {
+			rst = &Result{Channel: ChannelSubscribe}
+			return
+		}
+		if e, ok := err.(*mp.Error); ok && e.ErrCode == mp.ErrCodeUserRefuseToAcceptMsg &&
+			notification.FallbackToTemplate && notification.TemplateId != "" {
+
+			return n.sendTemplate(openid, notification, data, true, opts...)
+		}
+		return
+	default:
+		err = errors.New("unsupported channel: " + notification.Channel.String())
+		return
+	}
+}
+
+func (n *Notifier) sendTemplate(openid string, notification *Notification, data map[string]string,
+	fellBack bool, opts ...mp.CallOption) (rst *Result, err error) {
+
+	if n.Template == nil {
+		err = errors.New("nil template Client")
+		return
+	}
+	if notification.TemplateId == "" {
+		err = errors.New("empty TemplateId")
+		return
+	}
+
+	type Item struct {
+		Value string `json:"value"`
+	}
+	values := mapKeys(data, notification.TemplateKeys)
+	items := make(map[string]Item, len(values))
+	for key, value := range values {
+		items[key] = Item{Value: value}
+	}
+	rawData, err := wechatjson.Marshal(items)
+	if err != nil {
+		return
+	}
+
+	msg := template.TemplateMessage{
+		ToUser:      openid,
+		TemplateId:  notification.TemplateId,
+		URL:         notification.URL,
+		RawJSONData: rawData,
+	}
+	msgid, err := n.Template.Send(&msg, opts...)
+	if err != nil {
+		return
+	}
+	rst = &Result{
+		Channel:  ChannelTemplate,
+		MsgId:    msgid,
+		FellBack: fellBack,
+	}
+	return
+}
+
+func (n *Notifier) sendSubscribe(openid string, notification *Notification, data map[string]string, opts ...mp.CallOption) (err error) {
+	if n.Subscribe == nil {
+		return errors.New("nil subscribe Client")
+	}
+	if notification.SubscribeTemplateId == "" {
+		return errors.New("empty SubscribeTemplateId")
+	}
+
+	rawData, err := subscribe.NewData(mapKeys(data, notification.SubscribeKeys))
+	if err != nil {
+		return
+	}
+
+	msg := subscribe.SubscribeMessage{
+		ToUser:      openid,
+		TemplateId:  notification.SubscribeTemplateId,
+		Page:        notification.Page,
+		MiniProgram: notification.MiniProgram,
+		RawJSONData: rawData,
+	}
+	return n.Subscribe.Send(&msg, opts...)
+}
+
+// 按 keys 转换字段名, 转换成 "" 的字段被忽略.
+func mapKeys(data, keys map[string]string) map[string]string {
+	m := make(map[string]string, len(data))
+	for key, value := range data {
+		if mapped, ok := keys[key]; ok {
+			if mapped == "" {
+				continue
+			}
+			key = mapped
+		}
+		m[key] = value
+	}
+	return m
+}
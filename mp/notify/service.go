@@ -0,0 +1,195 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package notify
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"sync"
+	texttemplate "text/template"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/message/custom"
+)
+
+// Service 的通知定义.
+//  Fields 和 Text 使用 text/template 的语法, 比如 "{{.Product}} x {{.Count}}", 发送时用每个用户的数据渲染.
+type Definition struct {
+	Notification // 模板消息, 订阅通知的配置, Channel 为默认的通道
+
+	Fields map[string]string // 字段名 --> 字段值的模板, 渲染结果按 Notification 的说明发送
+	Text   string            // 客服消息(文本)的模板, 使用 ChannelCustom 时必须
+}
+
+// 用户选择的通知通道, 比如保存在用户的设置里.
+type ChannelPreference interface {
+	// 返回用户对通知选择的通道, ok == false 表示使用通知默认的通道
+	PreferredChannel(openid, notificationId string) (channel Channel, ok bool, err error)
+}
+
+type ChannelPreferenceFunc func(openid, notificationId string) (channel Channel, ok bool, err error)
+
+func (fn ChannelPreferenceFunc) PreferredChannel(openid, notificationId string) (channel Channel, ok bool, err error) {
+	return fn(openid, notificationId)
+}
+
+type compiledDefinition struct {
+	notification Notification
+	fields       map[string]*texttemplate.Template
+	text         *texttemplate.Template
+}
+
+// 通知服务.
+//  通知的模板只需要用 Define 定义一次, Send 时按用户的数据渲染, 并根据 Preference 选择模板消息, 订阅通知或者客服消息发送.
+type Service struct {
+	Notifier   *Notifier
+	Custom     *custom.Client    // 使用 ChannelCustom 时必须
+	Preference ChannelPreference // 可以为 nil, 表示都使用通知默认的通道
+
+	rwmutex     sync.RWMutex
+	definitions map[string]*compiledDefinition
+}
+
+// 创建通知服务, 模板消息和订阅通知通过 notifier 发送.
+func NewService(notifier *Notifier, customClient *custom.Client) *Service {
+	if notifier == nil {
+		panic("nil Notifier")
+	}
+	return &Service{
+		Notifier:    notifier,
+		Custom:      customClient,
+		definitions: make(map[string]*compiledDefinition),
+	}
+}
+
+// 定义通知, 已经存在的会被覆盖; 模板有语法错误时返回错误.
+func (srv *Service) Define(notificationId string, def *Definition) (err error) {
+	if def == nil {
+		return errors.New("nil Definition")
+	}
+
+	compiled := &compiledDefinition{
+		notification: def.Notification,
+		fields:       make(map[string]*texttemplate.Template, len(def.Fields)),
+	}
+	for name, text := range def.Fields {
+		if compiled.fields[name], err = parseTemplate(notificationId+"."+name, text); err != nil {
+			return
+		}
+	}
+	if def.Text != "" {
+		if compiled.text, err = parseTemplate(notificationId+".text", def.Text); err != nil {
+			return
+		}
+	}
+
+	srv.rwmutex.Lock()
+	srv.definitions[notificationId] = compiled
+	srv.rwmutex.Unlock()
+	return
+}
+
+func parseTemplate(name, text string) (*texttemplate.Template, error) {
+	return texttemplate.New(name).Option("missingkey=error").Parse(text)
+}
+
+func (srv *Service) definition(notificationId string) (def *compiledDefinition, err error) {
+	srv.rwmutex.RLock()
+	def = srv.definitions[notificationId]
+	srv.rwmutex.RUnlock()
+
+	if def == nil {
+		err = errors.New("unknown notification: " + notificationId)
+	}
+	return
+}
+
+// 用 data 渲染通知, 返回各字段的值和客服消息的文本, 一般用来预览通知.
+func (srv *Service) Render(notificationId string, data interface{}) (fields map[string]string, text string, err error) {
+	def, err := srv.definition(notificationId)
+	if err != nil {
+		return
+	}
+
+	fields = make(map[string]string, len(def.fields))
+	for name, tpl := range def.fields {
+		if fields[name], err = execute(tpl, data); err != nil {
+			return nil, "", err
+		}
+	}
+	if def.text != nil {
+		if text, err = execute(def.text, data); err != nil {
+			return nil, "", err
+		}
+	}
+	return
+}
+
+func execute(tpl *texttemplate.Template, data interface{}) (string, error) {
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return "", err
+	}
+	return buf.String(), nil
+}
+
+// 给用户发送通知.
+//  data 为渲染模板的数据, 一般是 struct 或者 map[string]interface{}; 通道优先使用 Preference 返回的通道.
+func (srv *Service) Send(openid, notificationId string, data interface{}, opts ...mp.CallOption) (rst *Result, err error) {
+	if openid == "" {
+		err = errors.New("empty openid")
+		return
+	}
+	def, err := srv.definition(notificationId)
+	if err != nil {
+		return
+	}
+
+	notification := def.notification
+	if srv.Preference != nil {
+		channel, ok, err := srv.Preference.PreferredChannel(openid, notificationId)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			notification.Channel = channel
+		}
+	}
+
+	if notification.Channel == ChannelCustom {
+		return srv.sendCustom(openid, notificationId, def, data, opts...)
+	}
+
+	fields := make(map[string]string, len(def.fields))
+	for name, tpl := range def.fields {
+		if fields[name], err = execute(tpl, data); err != nil {
+			return
+		}
+	}
+	return srv.Notifier.send(openid, &notification, fields, opts...)
+}
+
+func (srv *Service) sendCustom(openid, notificationId string, def *compiledDefinition, data interface{}, opts ...mp.CallOption) (rst *Result, err error) {
+	if srv.Custom == nil {
+		err = errors.New("nil custom Client")
+		return
+	}
+	if def.text == nil {
+		err = fmt.Errorf("notification %s has no Text for custom message", notificationId)
+		return
+	}
+
+	text, err := execute(def.text, data)
+	if err != nil {
+		return
+	}
+	if err = srv.Custom.SendText(custom.NewText(openid, text, ""), opts...); err != nil {
+		return
+	}
+	rst = &Result{Channel: ChannelCustom}
+	return
+}
@@ -0,0 +1,116 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"errors"
+)
+
+// 分页的位置.
+//  微信的列表接口有几种分页方式, 不同的方式使用不同的字段:
+//  1. offset/count, begin/count: Offset 为偏移量;
+//  2. pageindex/pagesize: Offset 为页码;
+//  3. next_openid 之类的游标: Next 为游标.
+type Cursor struct {
+	Offset int    `json:"offset,omitempty"`
+	Next   string `json:"next,omitempty"`
+}
+
+// 拉取 cursor 位置的一页数据, 返回下一页的位置和是否还有下一页; 拉取到的数据由 PageFunc 自己保存.
+type PageFunc func(cursor Cursor) (next Cursor, hasMore bool, err error)
+
+var ErrNoMorePage = errors.New("no more page")
+
+// 通用的分页器, 拉取到的数据由 PageFunc 自己保存, NextPage 只返回 error:
+//  HasNext() bool
+//  NextPage() error
+//  Cursor() Cursor
+//
+//  var items []Item
+//  pager := mp.NewPager(mp.Cursor{}, mp.OffsetPageFunc(20, func(offset, count int) (n, total int, err error) {
+//      // TODO: 拉取 offset 开始的 count 条数据, 保存到 items
+//  }))
+//  for pager.HasNext() {
+//      if err := pager.NextPage(); err != nil {
+//          // TODO: 增加你的代码
+//      }
+//  }
+//
+//  NextPage 失败时位置不变, 可以再次调用重试; Cursor 返回下一页的位置, 可以保存下来,
+//  以后用它创建新的 Pager 继续遍历.
+//
+//  各个列表接口的遍历器(user.UserIterator, user.TagUserIterator, dkf.RecordIterator,
+//  material.MaterialIterator, comment.CommentIterator 等)基于 Pager 实现, HasNext 和 Cursor 与 Pager 相同,
+//  但是 NextPage 直接返回这一页的数据, 比如 user.UserIterator:
+//  NextPage() (openids []string, err error)
+//
+//  for iter.HasNext() {
+//      openids, err := iter.NextPage()
+//      if err != nil {
+//          // TODO: 增加你的代码
+//      }
+//      // TODO: 增加你的代码
+//  }
+type Pager struct {
+	fn      PageFunc
+	cursor  Cursor
+	hasMore bool
+}
+
+// 创建一个从 start 位置开始的 Pager.
+func NewPager(start Cursor, fn PageFunc) *Pager {
+	if fn == nil {
+		panic("nil PageFunc")
+	}
+	return &Pager{
+		fn:      fn,
+		cursor:  start,
+		hasMore: true,
+	}
+}
+
+// 是否还有下一页. 第一页还没有拉取时总是返回 true.
+func (p *Pager) HasNext() bool {
+	return p.hasMore
+}
+
+// 拉取下一页, 没有下一页时返回 ErrNoMorePage.
+func (p *Pager) NextPage() (err error) {
+	if !p.hasMore {
+		return ErrNoMorePage
+	}
+	next, hasMore, err := p.fn(p.cursor)
+	if err != nil {
+		return
+	}
+	p.cursor = next
+	p.hasMore = hasMore
+	return
+}
+
+// 下一页的位置.
+func (p *Pager) Cursor() Cursor {
+	return p.cursor
+}
+
+// 返回 offset/count 分页方式的 PageFunc.
+//  fetch 拉取 offset 开始的 count 条数据, 返回实际拉取的条数 n 和总数 total(不知道总数时返回 -1);
+//  拉取的条数为 0, 或者已经拉取到 total, 或者不知道 total 时拉取的条数小于 count, 表示没有下一页了.
+func OffsetPageFunc(count int, fetch func(offset, count int) (n, total int, err error)) PageFunc {
+	return func(cursor Cursor) (next Cursor, hasMore bool, err error) {
+		n, total, err := fetch(cursor.Offset, count)
+		if err != nil {
+			return
+		}
+		next = Cursor{Offset: cursor.Offset + n}
+		if total >= 0 {
+			hasMore = n > 0 && next.Offset < total
+		} else {
+			hasMore = n > 0 && n >= count
+		}
+		return
+	}
+}
@@ -0,0 +1,257 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	DefaultDownloadRetries      = DefaultRetries      // 默认的重试次数
+	DefaultDownloadRetryBackoff = DefaultRetryBackoff // 默认第一次重试前等待的时间, 之后每次加倍
+)
+
+var ErrDownloadSizeMismatch = errors.New("downloaded size mismatch")
+
+// 支持断点续传的下载器, 用于下载视频素材这类比较大的文件(CDN 地址, 比如视频素材的 down_url).
+//  下载的内容先写入 path + ".part", 服务器返回的 ETag(或者 Last-Modified) 写入 path + ".part.validator";
+//  再次下载时用 If-Range 从 .part 的末尾继续下载, 服务器不支持 Range 或者文件已经变了(返回 200 或者不同的 ETag)时重新下载,
+//  没有 .part.validator 的 .part 无法确认是不是同一个文件, 也重新下载;
+//  下载完成并且大小和服务器返回的一致时才重命名为 path.
+type RangeDownloader struct {
+	HttpClient   *http.Client  // 为 nil 时使用 MediaHttpClient
+	MaxRetries   int           // 中途失败时的重试次数, 默认为 DefaultDownloadRetries, 小于 0 表示不重试
+	RetryBackoff time.Duration // 默认为 DefaultDownloadRetryBackoff
+}
+
+func (d *RangeDownloader) httpClient() *http.Client {
+	if d.HttpClient != nil {
+		return d.HttpClient
+	}
+	return MediaHttpClient
+}
+
+// 下载 rawURL 到 path, 返回文件的大小.
+func (d *RangeDownloader) DownloadTo(rawURL, path string) (size int64, err error) {
+	partPath := path + ".part"
+	file, err := os.OpenFile(partPath, os.O_WRONLY|os.O_CREATE, 0644)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	part := &partFile{
+		File:          file,
+		validatorPath: partPath + ".validator",
+	}
+	if validator, err := ioutil.ReadFile(part.validatorPath); err == nil {
+		part.validator = string(validator)
+	}
+
+	policy := RetryPolicy{
+		MaxRetries: d.MaxRetries,
+		Backoff:    d.RetryBackoff,
+	}
+	_, err = policy.Do(context.Background(), func() (err error) {
+		var done bool
+		if done, size, err = d.download(rawURL, part); err == nil && !done {
+			err = io.ErrUnexpectedEOF
+		}
+		return
+	})
+	if err != nil {
+		return
+	}
+
+	if err = file.Close(); err != nil {
+		return
+	}
+	if err = os.Rename(partPath, path); err != nil {
+		return
+	}
+	os.Remove(part.validatorPath)
+	return
+}
+
+// 下载中的 .part 文件
+type partFile struct {
+	*os.File
+	validatorPath string
+	validator     string // .part 对应的 ETag 或者 Last-Modified, 为空表示不知道 .part 是哪个版本的文件
+}
+
+// 返回 If-Range 可以使用的 validator: 强 ETag, 没有的话用 Last-Modified.
+func responseValidator(header http.Header) string {
+	if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+		return etag
+	}
+	return header.Get("Last-Modified")
+}
+
+// 从头开始下载 validator 对应的文件.
+func (part *partFile) reset(validator string) (err error) {
+	if err = truncate(part.File); err != nil {
+		return
+	}
+	if validator == "" {
+		os.Remove(part.validatorPath)
+	} else if err = ioutil.WriteFile(part.validatorPath, []byte(validator), 0644); err != nil {
+		return Permanent(err)
+	}
+	part.validator = validator
+	return
+}
+
+// 从 part 当前的大小继续下载一次; 返回是否下载完成以及完整文件的大小(未知时为 -1).
+func (d *RangeDownloader) download(rawURL string, part *partFile) (done bool, total int64, err error) {
+	offset, err := part.Seek(0, io.SeekEnd)
+	if err != nil {
+		return false, -1, Permanent(err)
+	}
+	if offset > 0 && part.validator == "" { // 不知道 .part 是不是同一个文件
+		if err = truncate(part.File); err != nil {
+			return
+		}
+		offset = 0
+	}
+
+	httpReq, err := http.NewRequest("GET", rawURL, nil)
+	if err != nil {
+		return false, -1, Permanent(err)
+	}
+	if offset > 0 {
+		httpReq.Header.Set("Range", "bytes="+strconv.FormatInt(offset, 10)+"-")
+		httpReq.Header.Set("If-Range", part.validator)
+	}
+
+	httpResp, err := d.httpClient().Do(httpReq)
+	if err != nil {
+		return false, -1, err
+	}
+	defer httpResp.Body.Close()
+
+	total = -1
+	restart := false
+	switch httpResp.StatusCode {
+	case http.StatusPartialContent:
+		if validator := responseValidator(httpResp.Header); offset > 0 && validator != part.validator {
+			// 服务器忽略了 If-Range, 文件已经变了
+			if err = part.reset(""); err != nil {
+				return
+			}
+			return false, -1, errors.New("file changed: " + validator)
+		}
+		start, end, size, ok := parseContentRange(httpResp.Header.Get("Content-Range"))
+		if !ok || start != offset {
+			// 服务器返回的范围不对, 重新下载
+			if err = part.reset(""); err != nil {
+				return
+			}
+			return false, -1, errors.New("invalid Content-Range: " + httpResp.Header.Get("Content-Range"))
+		}
+		total = size
+		if total < 0 {
+			total = end + 1
+		}
+		restart = offset == 0
+	case http.StatusOK: // 不支持 Range 或者文件已经变了(If-Range 不匹配), 从头开始
+		restart = true
+		total = httpResp.ContentLength
+	case http.StatusRequestedRangeNotSatisfiable:
+		// 可能 .part 已经是完整的文件, 也可能服务器上的文件变了
+		if _, _, size, ok := parseContentRange(httpResp.Header.Get("Content-Range")); ok && size == offset &&
+			responseValidator(httpResp.Header) == part.validator {
+			return true, offset, nil
+		}
+		if err = part.reset(""); err != nil {
+			return
+		}
+		return false, -1, errors.New("http.Status: " + httpResp.Status)
+	default:
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		if httpResp.StatusCode >= 400 && httpResp.StatusCode < 500 && httpResp.StatusCode != http.StatusTooManyRequests {
+			err = Permanent(err)
+		}
+		return false, -1, err
+	}
+	if restart {
+		if err = part.reset(responseValidator(httpResp.Header)); err != nil {
+			return
+		}
+		offset = 0
+	}
+
+	n, err := io.Copy(part, httpResp.Body)
+	if err != nil {
+		return false, total, err
+	}
+	written := offset + n
+	if total >= 0 && written != total {
+		if written > total {
+			if err = part.reset(""); err != nil {
+				return
+			}
+		}
+		return false, total, ErrDownloadSizeMismatch
+	}
+	return true, written, nil
+}
+
+func truncate(file *os.File) (err error) {
+	if err = file.Truncate(0); err != nil {
+		return Permanent(err)
+	}
+	if _, err = file.Seek(0, io.SeekStart); err != nil {
+		return Permanent(err)
+	}
+	return
+}
+
+// 解析 Content-Range: bytes start-end/size, size 为 * 时返回 -1; 对于 416 响应格式为 bytes */size.
+func parseContentRange(s string) (start, end, size int64, ok bool) {
+	const prefix = "bytes "
+	if !strings.HasPrefix(s, prefix) {
+		return
+	}
+	s = s[len(prefix):]
+	slash := strings.IndexByte(s, '/')
+	if slash < 0 {
+		return
+	}
+	rangePart, sizePart := s[:slash], s[slash+1:]
+
+	size = -1
+	if sizePart != "*" {
+		var err error
+		if size, err = strconv.ParseInt(sizePart, 10, 64); err != nil {
+			return
+		}
+	}
+	if rangePart == "*" {
+		return -1, -1, size, true
+	}
+	dash := strings.IndexByte(rangePart, '-')
+	if dash < 0 {
+		return
+	}
+	var err error
+	if start, err = strconv.ParseInt(rangePart[:dash], 10, 64); err != nil {
+		return
+	}
+	if end, err = strconv.ParseInt(rangePart[dash+1:], 10, 64); err != nil {
+		return
+	}
+	return start, end, size, true
+}
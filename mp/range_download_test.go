@@ -0,0 +1,156 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestRangeDownloader(t *testing.T) {
+	content := []byte(strings.Repeat("0123456789", 1000))
+	const etag = `"v2"`
+
+	tests := []struct {
+		name          string
+		part          []byte // 已经存在的 .part, nil 表示没有
+		validator     string // 已经存在的 .part.validator, 为空表示没有
+		ignoreIfRange bool   // 服务器忽略 If-Range, 总是返回 206
+		wantRanges    []string
+	}{
+		{"重新下载", nil, "", false, []string{""}},
+		{"继续下载", content[:4000], etag, false, []string{"bytes=4000-"}},
+		{"文件已经变了", []byte("old"), `"v1"`, false, []string{"bytes=3-"}},
+		{"没有 validator 的 .part", content[:4000], "", false, []string{""}},
+		{"忽略 If-Range 的服务器", []byte("old"), `"v1"`, true, []string{"bytes=3-", ""}},
+		{".part 已经是完整的文件", content, etag, false, []string{"bytes=10000-"}},
+	}
+	for _, tt := range tests {
+		var (
+			mutex  sync.Mutex
+			ranges []string
+		)
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mutex.Lock()
+			ranges = append(ranges, r.Header.Get("Range"))
+			mutex.Unlock()
+
+			w.Header().Set("ETag", etag)
+			if tt.ignoreIfRange {
+				r.Header.Del("If-Range")
+			}
+			http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+		}))
+
+		dir, err := ioutil.TempDir("", "range_download")
+		if err != nil {
+			srv.Close()
+			t.Fatal(err)
+		}
+		path := filepath.Join(dir, "video.mp4")
+		if tt.part != nil {
+			ioutil.WriteFile(path+".part", tt.part, 0644)
+		}
+		if tt.validator != "" {
+			ioutil.WriteFile(path+".part.validator", []byte(tt.validator), 0644)
+		}
+
+		d := &RangeDownloader{RetryBackoff: time.Millisecond}
+		size, err := d.DownloadTo(srv.URL, path)
+		srv.Close()
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			os.RemoveAll(dir)
+			continue
+		}
+		if got, _ := ioutil.ReadFile(path); size != int64(len(content)) || !bytes.Equal(got, content) {
+			t.Errorf("%s: 下载的内容不对, size %d, len %d", tt.name, size, len(got))
+		}
+		if files, _ := ioutil.ReadDir(dir); len(files) != 1 {
+			t.Errorf("%s: .part 或者 .part.validator 没有删除", tt.name)
+		}
+		if strings.Join(ranges, ",") != strings.Join(tt.wantRanges, ",") {
+			t.Errorf("%s: have Range %q, want %q", tt.name, ranges, tt.wantRanges)
+		}
+		os.RemoveAll(dir)
+	}
+}
+
+func TestRangeDownloaderResumeAfterError(t *testing.T) {
+	content := []byte(strings.Repeat("0123456789", 1000))
+	var (
+		mutex    sync.Mutex
+		requests int
+		ranges   []string
+	)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mutex.Lock()
+		requests++
+		n := requests
+		ranges = append(ranges, r.Header.Get("Range"))
+		mutex.Unlock()
+
+		w.Header().Set("ETag", `"v1"`)
+		if n == 1 { // 第一次只返回一半的内容然后断开连接
+			w.Header().Set("Content-Length", "10000")
+			w.Write(content[:5000])
+			conn, _, _ := w.(http.Hijacker).Hijack()
+			conn.Close()
+			return
+		}
+		http.ServeContent(w, r, "", time.Time{}, bytes.NewReader(content))
+	}))
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "range_download")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "video.mp4")
+
+	d := &RangeDownloader{RetryBackoff: time.Millisecond}
+	if _, err = d.DownloadTo(srv.URL, path); err != nil {
+		t.Fatal(err)
+	}
+	if got, _ := ioutil.ReadFile(path); !bytes.Equal(got, content) {
+		t.Errorf("下载的内容不对, len %d", len(got))
+	}
+	if len(ranges) != 2 || ranges[0] != "" || !strings.HasPrefix(ranges[1], "bytes=") {
+		t.Errorf("have Range %q, want 第二次从断开的位置继续下载", ranges)
+	}
+}
+
+func TestRangeDownloaderPermanentError(t *testing.T) {
+	var requests int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		http.NotFound(w, r)
+	}))
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "range_download")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	d := &RangeDownloader{RetryBackoff: time.Millisecond}
+	if _, err = d.DownloadTo(srv.URL, filepath.Join(dir, "video.mp4")); err == nil {
+		t.Error("want error")
+	}
+	if requests != 1 {
+		t.Errorf("404 重试了: 请求了 %d 次", requests)
+	}
+}
@@ -0,0 +1,67 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"sync"
+	"time"
+)
+
+// 限流接口, 用于控制调用微信接口的频率.
+type RateLimiter interface {
+	// 阻塞直到允许发起下一次调用
+	Wait()
+}
+
+var _ RateLimiter = (*TokenBucketRateLimiter)(nil)
+
+// 令牌桶限流器, RateLimiter 的简单实现, 可以被多个 Batch 或 goroutine 共享.
+type TokenBucketRateLimiter struct {
+	mutex    sync.Mutex
+	interval time.Duration // 生成一个令牌的时间间隔
+	burst    float64       // 桶的容量
+	tokens   float64       // 当前的令牌数
+	last     time.Time     // 最后一次计算令牌的时间
+}
+
+// 创建一个新的 TokenBucketRateLimiter.
+//  rate:  每秒允许的调用次数, 必须大于 0
+//  burst: 允许的突发调用次数, 小于 1 时按 1 处理
+func NewTokenBucketRateLimiter(rate float64, burst int) *TokenBucketRateLimiter {
+	if rate <= 0 {
+		panic("rate must be greater than 0")
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucketRateLimiter{
+		interval: time.Duration(float64(time.Second) / rate),
+		burst:    float64(burst),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+func (l *TokenBucketRateLimiter) Wait() {
+	l.mutex.Lock()
+	now := time.Now()
+	l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+
+	l.tokens--
+	var wait time.Duration
+	if l.tokens < 0 {
+		wait = time.Duration(-l.tokens * float64(l.interval))
+	}
+	l.mutex.Unlock()
+
+	if wait > 0 {
+		time.Sleep(wait)
+	}
+}
@@ -0,0 +1,29 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 把收到的消息(事件)转发到下游系统, 用于 Go 服务只负责接入的架构.
+//
+// Relay 实现了 mp.MessageHandler, 收到消息后立即回复微信服务器, 然后异步的发布到 Publisher;
+// 同一个用户的消息按照收到的顺序发布, 失败按照指数退避重试.
+//
+//  publisher := relay.NewHTTPPublisher("http://127.0.0.1:8080/wechat/messages", nil)
+//  r := relay.New(publisher, nil)
+//  defer r.Close()
+//
+//  wechatServer := mp.NewDefaultWechatServer(wechatId, token, appId, aesKey, r)
+//
+// Kafka, NATS, Redis streams 等消息队列实现 QueueClient 接口即可, 比如:
+//
+//  type kafkaClient struct{ producer sarama.SyncProducer }
+//
+//  func (c kafkaClient) Publish(topic, key string, value []byte) error {
+//      _, _, err := c.producer.SendMessage(&sarama.ProducerMessage{
+//          Topic: topic, Key: sarama.StringEncoder(key), Value: sarama.ByteEncoder(value),
+//      })
+//      return err
+//  }
+//
+//  publisher := relay.NewQueuePublisher(kafkaClient{producer}, "wechat-messages")
+package relay
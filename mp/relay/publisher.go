@@ -0,0 +1,120 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package relay
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 转发到下游系统的消息
+type Message struct {
+	Key       string           `json:"key"`       // 排序的 key, 等于 MixedMsg.FromUserName
+	WechatId  string           `json:"wechat_id"` // 公众号的原始ID
+	AppId     string           `json:"appid"`     // 公众号的 AppId
+	RawMsgXML string           `json:"raw_msg"`   // "明文"消息的 XML 文本
+	MixedMsg  *mp.MixedMessage `json:"mixed_msg"` // 解析后的消息
+	Attempt   int              `json:"attempt"`   // 第几次发布, 从 1 开始
+}
+
+// 发布消息到下游系统的接口.
+//  NOTE: 同一个 Key 的消息不会并发调用 Publish.
+type Publisher interface {
+	Publish(msg *Message) error
+}
+
+type PublisherFunc func(msg *Message) error
+
+func (fn PublisherFunc) Publish(msg *Message) error {
+	return fn(msg)
+}
+
+var _ Publisher = (*HTTPPublisher)(nil)
+
+// 以 JSON 格式 POST 消息到指定的 URL, 返回 2xx 表示成功.
+type HTTPPublisher struct {
+	URL        string
+	Header     http.Header // 额外的请求头, 比如认证信息, 可以为 nil
+	HttpClient *http.Client
+}
+
+// 创建一个新的 HTTPPublisher.
+//  如果 httpClient == nil 则默认用 http.DefaultClient
+func NewHTTPPublisher(url string, httpClient *http.Client) *HTTPPublisher {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &HTTPPublisher{
+		URL:        url,
+		HttpClient: httpClient,
+	}
+}
+
+func (p *HTTPPublisher) Publish(msg *Message) (err error) {
+	body, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+
+	httpReq, err := http.NewRequest("POST", p.URL, bytes.NewReader(body))
+	if err != nil {
+		return
+	}
+	for k, v := range p.Header {
+		httpReq.Header[k] = v
+	}
+	httpReq.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	httpResp, err := p.HttpClient.Do(httpReq)
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+	io.Copy(ioutil.Discard, httpResp.Body)
+
+	if httpResp.StatusCode < 200 || httpResp.StatusCode >= 300 {
+		return fmt.Errorf("http.Status: %s", httpResp.Status)
+	}
+	return
+}
+
+// 消息队列的客户端接口, Kafka, NATS, Redis streams 等用很少的代码就可以适配.
+//  key 用于分区, 保证同一个用户的消息在同一个分区里有序.
+type QueueClient interface {
+	Publish(topic, key string, value []byte) error
+}
+
+var _ Publisher = (*QueuePublisher)(nil)
+
+// 以 JSON 格式发布消息到消息队列的 topic.
+type QueuePublisher struct {
+	Client QueueClient
+	Topic  string
+}
+
+func NewQueuePublisher(client QueueClient, topic string) *QueuePublisher {
+	if client == nil {
+		panic("nil QueueClient")
+	}
+	return &QueuePublisher{
+		Client: client,
+		Topic:  topic,
+	}
+}
+
+func (p *QueuePublisher) Publish(msg *Message) (err error) {
+	value, err := json.Marshal(msg)
+	if err != nil {
+		return
+	}
+	return p.Client.Publish(p.Topic, msg.Key, value)
+}
@@ -0,0 +1,163 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package relay
+
+import (
+	"context"
+	"errors"
+	"hash/fnv"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+var ErrClosed = errors.New("relay: closed")
+
+const (
+	DefaultMaxRetries = mp.DefaultRetries
+	DefaultRetryDelay = mp.DefaultRetryBackoff
+	MaxRetryDelay     = time.Minute
+)
+
+// Relay 的设置, 零值的字段使用默认值.
+type Options struct {
+	Workers    int           // 并发发布的 worker 数量, 同一个用户的消息由同一个 worker 处理; 默认 8
+	QueueSize  int           // 每个 worker 的队列长度, 队列满了之后 ServeMessage 会阻塞; 默认 1024
+	MaxRetries int           // 发布失败的重试次数; 默认 DefaultMaxRetries, 小于 0 表示不重试
+	RetryDelay time.Duration // 第一次重试的等待时间, 之后每次翻倍, 最多 MaxRetryDelay; 默认 DefaultRetryDelay
+
+	// 重试之后仍然失败(或者 Relay 已经关闭, 不再重试)的消息, 可以为 nil
+	OnError func(msg *Message, err error)
+}
+
+var _ mp.MessageHandler = (*Relay)(nil)
+
+// 转发消息到 Publisher 的 mp.MessageHandler.
+type Relay struct {
+	publisher Publisher
+	options   Options
+
+	queues []chan *Message
+	wg     sync.WaitGroup
+	ctx    context.Context // Close 时取消, 中断等待中的重试
+	cancel context.CancelFunc
+
+	rwmutex sync.RWMutex
+	closed  bool
+}
+
+// 创建一个新的 Relay, options 可以为 nil.
+func New(publisher Publisher, options *Options) *Relay {
+	if publisher == nil {
+		panic("nil Publisher")
+	}
+
+	var opts Options
+	if options != nil {
+		opts = *options
+	}
+	if opts.Workers <= 0 {
+		opts.Workers = 8
+	}
+	if opts.QueueSize <= 0 {
+		opts.QueueSize = 1024
+	}
+	if opts.MaxRetries == 0 {
+		opts.MaxRetries = DefaultMaxRetries
+	}
+	if opts.RetryDelay <= 0 {
+		opts.RetryDelay = DefaultRetryDelay
+	}
+
+	r := &Relay{
+		publisher: publisher,
+		options:   opts,
+		queues:    make([]chan *Message, opts.Workers),
+	}
+	r.ctx, r.cancel = context.WithCancel(context.Background())
+	for i := range r.queues {
+		r.queues[i] = make(chan *Message, opts.QueueSize)
+		r.wg.Add(1)
+		go r.worker(r.queues[i])
+	}
+	return r
+}
+
+// 实现 mp.MessageHandler, 回复空串给微信服务器, 然后异步的转发消息.
+func (r *Relay) ServeMessage(w http.ResponseWriter, req *mp.Request) {
+	msg := &Message{
+		Key:       req.MixedMsg.FromUserName,
+		WechatId:  req.WechatId,
+		AppId:     req.WechatAppId,
+		RawMsgXML: string(req.RawMsgXML),
+		MixedMsg:  req.MixedMsg,
+	}
+	if err := r.Enqueue(msg); err != nil && r.options.OnError != nil {
+		r.options.OnError(msg, err)
+	}
+}
+
+// 把消息加入发布队列, 同一个 Key 的消息按照加入的顺序发布.
+func (r *Relay) Enqueue(msg *Message) error {
+	r.rwmutex.RLock()
+	defer r.rwmutex.RUnlock()
+
+	if r.closed {
+		return ErrClosed
+	}
+	r.queues[r.shard(msg.Key)] <- msg
+	return nil
+}
+
+func (r *Relay) shard(key string) int {
+	h := fnv.New32a()
+	h.Write([]byte(key))
+	return int(h.Sum32() % uint32(len(r.queues)))
+}
+
+// 停止接收新的消息, 并等待已经在队列里的消息发布完成.
+//  Close 之后不再重试: 正在等待重试的消息和队列里发布失败的消息直接交给 Options.OnError.
+func (r *Relay) Close() {
+	r.rwmutex.Lock()
+	if r.closed {
+		r.rwmutex.Unlock()
+		return
+	}
+	r.closed = true
+	for _, queue := range r.queues {
+		close(queue)
+	}
+	r.rwmutex.Unlock()
+
+	r.cancel()
+	r.wg.Wait()
+}
+
+func (r *Relay) worker(queue <-chan *Message) {
+	defer r.wg.Done()
+
+	for msg := range queue {
+		r.publish(msg)
+	}
+}
+
+// 发布消息, 失败按照指数退避重试; 重试期间阻塞该 worker, 以保证同一个用户的消息有序.
+func (r *Relay) publish(msg *Message) {
+	policy := mp.RetryPolicy{
+		MaxRetries: r.options.MaxRetries,
+		Backoff:    r.options.RetryDelay,
+		MaxBackoff: MaxRetryDelay,
+	}
+	_, err := policy.Do(r.ctx, func() error {
+		msg.Attempt++
+		return r.publisher.Publish(msg)
+	})
+	if err != nil && r.options.OnError != nil {
+		r.options.OnError(msg, err)
+	}
+}
@@ -0,0 +1,112 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"net/http"
+)
+
+// 在请求发送到微信服务器(或者代理网关)之前修改请求, 比如增加网关的认证头, 链路追踪的 ID.
+//  req 是原请求的副本, Header 也已经复制, 可以直接修改; 返回 error 时不发送请求, 调用返回该错误.
+type RequestDecorator func(req *http.Request) error
+
+// 这次调用额外使用的 RequestDecorator, 在 WechatClient.RequestDecorator 之后调用.
+func WithRequestDecorator(decorator RequestDecorator) CallOption {
+	return func(o *callOptions) {
+		if decorator != nil {
+			o.decorators = append(o.decorators, decorator)
+		}
+	}
+}
+
+// 返回 httpClient 的一个副本, 发送请求前依次用 decorators 修改请求.
+//  WechatClient.RequestDecorator 不影响 TokenServer 自己的请求, 比如 DefaultTokenServer 获取 access_token,
+//  这时可以把 DecorateHttpClient 返回的 http.Client 传给 NewDefaultTokenServer.
+//  如果 httpClient == nil 则使用 http.DefaultClient.
+func DecorateHttpClient(httpClient *http.Client, decorators ...RequestDecorator) *http.Client {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	o := callOptions{}
+	for _, decorator := range decorators {
+		WithRequestDecorator(decorator)(&o)
+	}
+	return o.httpClient(httpClient)
+}
+
+// 推荐的 User-Agent, 方便企业网关识别本 SDK 的请求; 默认不设置, 使用 Go 默认的 User-Agent.
+const DefaultUserAgent = "chanxuehong-wechat (+https://github.com/chanxuehong/wechat)"
+
+// 返回设置 User-Agent 的 RequestDecorator, 比如 UserAgent(DefaultUserAgent + " myapp/1.0").
+func UserAgent(userAgent string) RequestDecorator {
+	return func(req *http.Request) error {
+		req.Header.Set("User-Agent", userAgent)
+		return nil
+	}
+}
+
+// 返回给所有请求设置 headers 的 RequestDecorator, 已经存在的同名 header 会被覆盖.
+//  headers 会被复制, 之后修改 headers 不影响返回的 RequestDecorator.
+func StaticHeaders(headers http.Header) RequestDecorator {
+	copied := make(http.Header, len(headers))
+	for k, v := range headers {
+		copied[http.CanonicalHeaderKey(k)] = append([]string(nil), v...)
+	}
+	return func(req *http.Request) error {
+		for k, v := range copied {
+			req.Header[k] = append([]string(nil), v...)
+		}
+		return nil
+	}
+}
+
+// 把多个 RequestDecorator 合并成一个, 依次调用, 其中一个返回 error 时停止; nil 会被忽略.
+func ChainRequestDecorators(decorators ...RequestDecorator) RequestDecorator {
+	chain := make([]RequestDecorator, 0, len(decorators))
+	for _, decorator := range decorators {
+		if decorator != nil {
+			chain = append(chain, decorator)
+		}
+	}
+	return func(req *http.Request) error {
+		for _, decorator := range chain {
+			if err := decorator(req); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+// 依次调用 decorators 修改请求, 然后交给 Transport 发送.
+type decoratorTransport struct {
+	transport  http.RoundTripper
+	decorators []RequestDecorator
+}
+
+func (t *decoratorTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	newReq := new(http.Request)
+	*newReq = *req
+	newReq.Header = make(http.Header, len(req.Header))
+	for k, v := range req.Header {
+		newReq.Header[k] = append([]string(nil), v...)
+	}
+
+	for _, decorator := range t.decorators {
+		if err := decorator(newReq); err != nil {
+			if req.Body != nil {
+				req.Body.Close()
+			}
+			return nil, err
+		}
+	}
+
+	transport := t.transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	return transport.RoundTrip(newReq)
+}
@@ -0,0 +1,232 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// 缓存接口返回的 JSON 的存储接口, 多进程环境可以用 redis, memcache 等实现.
+type ResponseCacheStore interface {
+	// 获取缓存, 不存在或者过期时 ok == false
+	Get(key string) (value []byte, ok bool)
+	// 设置缓存
+	Set(key string, value []byte, ttl time.Duration)
+	// 删除所有 key 以 prefix 开头的缓存
+	DeletePrefix(prefix string)
+}
+
+var _ ResponseCacheStore = (*MemoryResponseCacheStore)(nil)
+
+// ResponseCacheStore 的简单实现, 用于单进程环境.
+type MemoryResponseCacheStore struct {
+	rwmutex sync.RWMutex
+	items   map[string]memoryCacheItem
+}
+
+type memoryCacheItem struct {
+	value    []byte
+	expireAt time.Time
+}
+
+func NewMemoryResponseCacheStore() *MemoryResponseCacheStore {
+	return &MemoryResponseCacheStore{
+		items: make(map[string]memoryCacheItem),
+	}
+}
+
+func (s *MemoryResponseCacheStore) Get(key string) (value []byte, ok bool) {
+	s.rwmutex.RLock()
+	item, ok := s.items[key]
+	s.rwmutex.RUnlock()
+
+	if !ok {
+		return
+	}
+	if time.Now().After(item.expireAt) {
+		s.rwmutex.Lock()
+		// 释放读锁以后可能有新的 Set, 重新检查一次
+		if item, ok = s.items[key]; ok && time.Now().After(item.expireAt) {
+			delete(s.items, key)
+		}
+		s.rwmutex.Unlock()
+		return nil, false
+	}
+	return item.value, true
+}
+
+func (s *MemoryResponseCacheStore) Set(key string, value []byte, ttl time.Duration) {
+	s.rwmutex.Lock()
+	s.items[key] = memoryCacheItem{
+		value:    value,
+		expireAt: time.Now().Add(ttl),
+	}
+	s.rwmutex.Unlock()
+}
+
+func (s *MemoryResponseCacheStore) DeletePrefix(prefix string) {
+	s.rwmutex.Lock()
+	for key := range s.items {
+		if strings.HasPrefix(key, prefix) {
+			delete(s.items, key)
+		}
+	}
+	s.rwmutex.Unlock()
+}
+
+// 只读接口的返回结果缓存.
+//  设置 WechatClient.Cache 后, PostJSON 和 GetJSON 对设置了 TTL 的接口会先查缓存, 成功(errcode == 0)的结果会被缓存;
+//  接口按 URL 的 path 区分, 比如 "/cgi-bin/menu/get", 缓存的 key 还包括了 URL 的查询参数(不含 access_token)和请求的 body.
+//
+//  NOTE: 缓存的 key 不区分公众号, 多个公众号共享同一个 ResponseCacheStore 时请用不同的 prefix.
+type ResponseCache struct {
+	store  ResponseCacheStore
+	prefix string
+
+	rwmutex       sync.RWMutex
+	ttls          map[string]time.Duration // endpoint --> ttl
+	invalidations map[string][]string      // 写接口的 endpoint --> 需要失效的只读接口的 endpoint 列表
+}
+
+// 创建一个新的 ResponseCache, 没有设置任何接口的 TTL.
+//  store 为 nil 时使用 MemoryResponseCacheStore
+//  prefix: 缓存 key 的前缀, 用于区分公众号, 可以为空
+func NewResponseCache(store ResponseCacheStore, prefix string) *ResponseCache {
+	if store == nil {
+		store = NewMemoryResponseCacheStore()
+	}
+	return &ResponseCache{
+		store:         store,
+		prefix:        prefix,
+		ttls:          make(map[string]time.Duration),
+		invalidations: make(map[string][]string),
+	}
+}
+
+// 创建一个新的 ResponseCache, 并为常用的只读接口设置 TTL 和失效规则:
+//  标签列表, 自定义菜单, 素材总数 缓存 ttl, 用户基本信息 缓存 ttl/2;
+//  对应的写接口调用成功后自动失效.
+func NewDefaultResponseCache(store ResponseCacheStore, prefix string, ttl time.Duration) *ResponseCache {
+	cache := NewResponseCache(store, prefix)
+
+	cache.SetTTL("/cgi-bin/tags/get", ttl)
+	cache.InvalidateOn("/cgi-bin/tags/create", "/cgi-bin/tags/get")
+	cache.InvalidateOn("/cgi-bin/tags/update", "/cgi-bin/tags/get")
+	cache.InvalidateOn("/cgi-bin/tags/delete", "/cgi-bin/tags/get")
+	cache.InvalidateOn("/cgi-bin/tags/members/batchtagging", "/cgi-bin/tags/get", "/cgi-bin/user/info")
+	cache.InvalidateOn("/cgi-bin/tags/members/batchuntagging", "/cgi-bin/tags/get", "/cgi-bin/user/info")
+
+	cache.SetTTL("/cgi-bin/menu/get", ttl)
+	cache.InvalidateOn("/cgi-bin/menu/create", "/cgi-bin/menu/get")
+	cache.InvalidateOn("/cgi-bin/menu/delete", "/cgi-bin/menu/get")
+
+	cache.SetTTL("/cgi-bin/material/get_materialcount", ttl)
+	cache.InvalidateOn("/cgi-bin/material/add_news", "/cgi-bin/material/get_materialcount")
+	cache.InvalidateOn("/cgi-bin/material/add_material", "/cgi-bin/material/get_materialcount")
+	cache.InvalidateOn("/cgi-bin/material/del_material", "/cgi-bin/material/get_materialcount")
+
+	cache.SetTTL("/cgi-bin/user/info", ttl/2)
+	cache.InvalidateOn("/cgi-bin/user/info/updateremark", "/cgi-bin/user/info")
+	return cache
+}
+
+// 设置接口的缓存时间, ttl <= 0 表示不缓存.
+//  endpoint: 接口 URL 的 path, 比如 "/cgi-bin/menu/get"
+func (cache *ResponseCache) SetTTL(endpoint string, ttl time.Duration) {
+	cache.rwmutex.Lock()
+	if ttl > 0 {
+		cache.ttls[endpoint] = ttl
+	} else {
+		delete(cache.ttls, endpoint)
+	}
+	cache.rwmutex.Unlock()
+}
+
+// 设置失效规则: writeEndpoint 调用成功后, readEndpoints 的缓存全部失效.
+func (cache *ResponseCache) InvalidateOn(writeEndpoint string, readEndpoints ...string) {
+	cache.rwmutex.Lock()
+	cache.invalidations[writeEndpoint] = append(cache.invalidations[writeEndpoint], readEndpoints...)
+	cache.rwmutex.Unlock()
+}
+
+// 使接口的所有缓存失效.
+func (cache *ResponseCache) Invalidate(endpoint string) {
+	cache.store.DeletePrefix(cache.prefix + endpoint + "?")
+}
+
+// 使所有的缓存失效.
+func (cache *ResponseCache) InvalidateAll() {
+	cache.store.DeletePrefix(cache.prefix)
+}
+
+// 返回 incompleteURL 的 path 和 去掉 access_token 之后的查询参数.
+func splitIncompleteURL(incompleteURL string) (endpoint, query string) {
+	s := incompleteURL
+	if i := strings.Index(s, "://"); i >= 0 {
+		s = s[i+3:]
+	}
+	if i := strings.IndexByte(s, '/'); i >= 0 {
+		s = s[i:]
+	} else {
+		s = "/"
+	}
+	if i := strings.IndexByte(s, '?'); i >= 0 {
+		endpoint, query = s[:i], s[i+1:]
+	} else {
+		endpoint = s
+	}
+	query = strings.TrimSuffix(query, "access_token=")
+	return
+}
+
+// 返回缓存的 key 和 ttl, ttl == 0 表示该接口不缓存.
+//  cache == nil 时返回 ttl == 0.
+func (cache *ResponseCache) lookup(incompleteURL string, requestBody []byte) (key string, ttl time.Duration) {
+	if cache == nil {
+		return
+	}
+	endpoint, query := splitIncompleteURL(incompleteURL)
+
+	cache.rwmutex.RLock()
+	ttl = cache.ttls[endpoint]
+	cache.rwmutex.RUnlock()
+
+	if ttl <= 0 {
+		return "", 0
+	}
+	key = cache.prefix + endpoint + "?" + query + "\x00" + string(requestBody)
+	return
+}
+
+// 获取缓存的 JSON
+func (cache *ResponseCache) get(key string) (value []byte, ok bool) {
+	return cache.store.Get(key)
+}
+
+// 接口调用成功后调用, 缓存结果并执行失效规则.
+//  cache == nil 时什么都不做.
+func (cache *ResponseCache) done(incompleteURL, key string, ttl time.Duration, responseBody []byte) {
+	if cache == nil {
+		return
+	}
+	if ttl > 0 && len(responseBody) > 0 {
+		value := make([]byte, len(responseBody))
+		copy(value, responseBody)
+		cache.store.Set(key, value, ttl)
+	}
+
+	endpoint, _ := splitIncompleteURL(incompleteURL)
+
+	cache.rwmutex.RLock()
+	readEndpoints := cache.invalidations[endpoint]
+	cache.rwmutex.RUnlock()
+
+	for _, readEndpoint := range readEndpoints {
+		cache.Invalidate(readEndpoint)
+	}
+}
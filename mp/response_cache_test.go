@@ -0,0 +1,166 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// 用于测试的 TokenServer, 总是返回 testToken.
+type testTokenServer struct{}
+
+func (testTokenServer) Token() (string, error)        { return testToken, nil }
+func (testTokenServer) TokenRefresh() (string, error) { return testToken, nil }
+
+// 返回一个把请求改发到 handler 的 WechatClient.
+func newTestWechatClient(t *testing.T, handler http.Handler) (clt *WechatClient, closeFn func()) {
+	srv := httptest.NewServer(handler)
+	transport, err := NewBaseURLTransport(srv.URL, nil)
+	if err != nil {
+		srv.Close()
+		t.Fatal(err)
+	}
+	clt = &WechatClient{
+		TokenServer: testTokenServer{},
+		HttpClient:  &http.Client{Transport: transport},
+	}
+	return clt, srv.Close
+}
+
+func TestSplitIncompleteURL(t *testing.T) {
+	tests := []struct {
+		incompleteURL string
+		endpoint      string
+		query         string
+	}{
+		{"https://api.weixin.qq.com/cgi-bin/menu/get?access_token=", "/cgi-bin/menu/get", ""},
+		{testIncompleteURL, "/cgi-bin/user/info", "openid=oLVPpjqs9BhvzwPj5A-vTYAX3GLc&lang=zh_CN&"},
+		{"https://api.weixin.qq.com/cgi-bin/tags/get", "/cgi-bin/tags/get", ""},
+		{"api.weixin.qq.com/cgi-bin/tags/get?access_token=", "/cgi-bin/tags/get", ""},
+		{"https://api.weixin.qq.com", "/", ""},
+	}
+	for _, tt := range tests {
+		endpoint, query := splitIncompleteURL(tt.incompleteURL)
+		if endpoint != tt.endpoint || query != tt.query {
+			t.Errorf("splitIncompleteURL(%q):\nhave %q, %q\nwant %q, %q", tt.incompleteURL, endpoint, query, tt.endpoint, tt.query)
+		}
+	}
+}
+
+func TestMemoryResponseCacheStore(t *testing.T) {
+	store := NewMemoryResponseCacheStore()
+	store.Set("a/1", []byte("1"), time.Hour)
+	store.Set("a/2", []byte("2"), time.Hour)
+	store.Set("b/1", []byte("3"), time.Hour)
+	store.Set("expired", []byte("4"), -time.Second)
+	store.DeletePrefix("a/")
+
+	tests := []struct {
+		key   string
+		value string
+		ok    bool
+	}{
+		{"a/1", "", false},
+		{"a/2", "", false},
+		{"b/1", "3", true},
+		{"expired", "", false},
+		{"notfound", "", false},
+	}
+	for _, tt := range tests {
+		value, ok := store.Get(tt.key)
+		if ok != tt.ok || string(value) != tt.value {
+			t.Errorf("Get(%q): have %q, %v, want %q, %v", tt.key, value, ok, tt.value, tt.ok)
+		}
+	}
+	if _, ok := store.items["expired"]; ok {
+		t.Error("过期的缓存没有被删除")
+	}
+}
+
+func TestResponseCacheLookup(t *testing.T) {
+	cache := NewResponseCache(nil, "wx1:")
+	cache.SetTTL("/cgi-bin/user/info", time.Minute)
+	cache.SetTTL("/cgi-bin/menu/get", time.Minute)
+	cache.SetTTL("/cgi-bin/menu/get", 0)
+
+	tests := []struct {
+		incompleteURL string
+		requestBody   string
+		key           string
+		ttl           time.Duration
+	}{
+		{testIncompleteURL, "", "wx1:/cgi-bin/user/info?openid=oLVPpjqs9BhvzwPj5A-vTYAX3GLc&lang=zh_CN&\x00", time.Minute},
+		{testIncompleteURL, `{"a":1}`, "wx1:/cgi-bin/user/info?openid=oLVPpjqs9BhvzwPj5A-vTYAX3GLc&lang=zh_CN&\x00{\"a\":1}", time.Minute},
+		{"https://api.weixin.qq.com/cgi-bin/menu/get?access_token=", "", "", 0},
+		{"https://api.weixin.qq.com/cgi-bin/tags/get?access_token=", "", "", 0},
+	}
+	for _, tt := range tests {
+		key, ttl := cache.lookup(tt.incompleteURL, []byte(tt.requestBody))
+		if key != tt.key || ttl != tt.ttl {
+			t.Errorf("lookup(%q, %q):\nhave %q, %s\nwant %q, %s", tt.incompleteURL, tt.requestBody, key, ttl, tt.key, tt.ttl)
+		}
+	}
+
+	var nilCache *ResponseCache
+	if _, ttl := nilCache.lookup(testIncompleteURL, nil); ttl != 0 {
+		t.Errorf("nil ResponseCache: have ttl %s, want 0", ttl)
+	}
+}
+
+func TestWechatClientResponseCache(t *testing.T) {
+	var menuGets, tagGets int32
+	clt, closeFn := newTestWechatClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/cgi-bin/menu/get":
+			atomic.AddInt32(&menuGets, 1)
+			w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+		case "/cgi-bin/tags/get":
+			if atomic.AddInt32(&tagGets, 1) == 1 {
+				w.Write([]byte(`{"errcode":45009,"errmsg":"reach max api daily quota limit"}`))
+				return
+			}
+			w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+		default:
+			w.Write([]byte(`{"errcode":0,"errmsg":"ok"}`))
+		}
+	}))
+	defer closeFn()
+	clt.Cache = NewDefaultResponseCache(nil, "", time.Minute)
+
+	const (
+		menuGetURL    = "https://api.weixin.qq.com/cgi-bin/menu/get?access_token="
+		menuCreateURL = "https://api.weixin.qq.com/cgi-bin/menu/create?access_token="
+		tagsGetURL    = "https://api.weixin.qq.com/cgi-bin/tags/get?access_token="
+	)
+	tests := []struct {
+		name      string
+		call      func() error
+		wantMenus int32
+		wantTags  int32
+	}{
+		{"第一次读取菜单", func() error { return clt.GetJSON(menuGetURL, &Error{}) }, 1, 0},
+		{"再次读取菜单命中缓存", func() error { return clt.GetJSON(menuGetURL, &Error{}) }, 1, 0},
+		{"WithNoCache 不读缓存", func() error { return clt.GetJSON(menuGetURL, &Error{}, WithNoCache()) }, 2, 0},
+		{"创建菜单使缓存失效", func() error { return clt.PostJSON(menuCreateURL, struct{}{}, &Error{}) }, 2, 0},
+		{"失效后重新读取", func() error { return clt.GetJSON(menuGetURL, &Error{}) }, 3, 0},
+		{"出错的结果不缓存", func() error { return clt.GetJSON(tagsGetURL, &Error{}) }, 3, 1},
+		{"出错后重新读取", func() error { return clt.GetJSON(tagsGetURL, &Error{}) }, 3, 2},
+		{"成功后命中缓存", func() error { return clt.GetJSON(tagsGetURL, &Error{}) }, 3, 2},
+	}
+	for _, tt := range tests {
+		tt.call()
+		if have := atomic.LoadInt32(&menuGets); have != tt.wantMenus {
+			t.Errorf("%s: 菜单接口请求了 %d 次, want %d", tt.name, have, tt.wantMenus)
+		}
+		if have := atomic.LoadInt32(&tagGets); have != tt.wantTags {
+			t.Errorf("%s: 标签接口请求了 %d 次, want %d", tt.name, have, tt.wantTags)
+		}
+	}
+}
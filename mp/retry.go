@@ -0,0 +1,118 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"context"
+	"time"
+)
+
+const (
+	DefaultRetries      = 3           // RetryPolicy 默认的重试次数
+	DefaultRetryBackoff = time.Second // RetryPolicy 默认第一次重试前等待的时间, 之后每次加倍
+)
+
+// 调用失败以后的重试策略, 用于批量处理这类需要在 WechatClient 之外再重试的场景, 零值使用默认的参数.
+//  WechatClient 本身只在网络错误或者 5xx 时重试 IsIdempotent 的接口(见 WechatClient.MaxRetries),
+//  用 RetryPolicy 重试时请给调用加上 CallOptions 返回的选项, 否则两层重试会叠加.
+type RetryPolicy struct {
+	MaxRetries int           // 默认为 DefaultRetries, 小于 0 表示不重试
+	Backoff    time.Duration // 第一次重试前等待的时间, 之后每次加倍, 默认为 DefaultRetryBackoff
+	MaxBackoff time.Duration // 每次等待的最长时间, 0 表示不限制
+	Limiter    RateLimiter   // 每次重试之前调用 Wait, 可以为 nil; 第一次调用之前需要调用方自己 Wait
+}
+
+// 判断 err 是否值得重试.
+//  系统繁忙和调用频率超过限制的微信错误可以重试, 其他的微信错误(比如参数错误, openid 无效)重试也没有用;
+//  用 Permanent 标记的错误(或者实现了 Permanent() bool 并且返回 true 的错误)不重试;
+//  其他不是微信错误的(网络错误, http 状态码错误等)都可以重试.
+func IsRetryable(err error) bool {
+	switch e := err.(type) {
+	case nil:
+		return false
+	case *Error:
+		switch e.Category() {
+		case CategorySystem, CategoryFrequency:
+			return true
+		}
+		return false
+	case interface{ Permanent() bool }:
+		return !e.Permanent()
+	}
+	return err != context.Canceled && err != context.DeadlineExceeded
+}
+
+// 把 err 标记为不需要重试, 之后 IsRetryable(err) 返回 false; err 为 nil 时返回 nil.
+//  自定义的错误类型也可以实现 Permanent() bool 方法达到同样的效果.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err}
+}
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string   { return e.err.Error() }
+func (e *permanentError) Unwrap() error   { return e.err }
+func (e *permanentError) Permanent() bool { return true }
+
+// 在 opts 后面加上 WithMaxRetries(0), 由 RetryPolicy 统一重试; 不修改 opts.
+func (p *RetryPolicy) CallOptions(opts []CallOption) []CallOption {
+	return append(opts[:len(opts):len(opts)], WithMaxRetries(0))
+}
+
+// 调用 fn 直到成功, 返回不能重试的错误(见 IsRetryable), 重试次数用完或者 ctx 结束为止.
+//  attempts 为调用 fn 的次数; ctx 在等待重试时结束的话 err 为 fn 最后一次返回的错误.
+func (p *RetryPolicy) Do(ctx context.Context, fn func() error) (attempts int, err error) {
+	maxRetries := p.MaxRetries
+	switch {
+	case maxRetries == 0:
+		maxRetries = DefaultRetries
+	case maxRetries < 0:
+		maxRetries = 0
+	}
+	for {
+		attempts++
+		if err = fn(); err == nil {
+			return
+		}
+		if attempts > maxRetries || !IsRetryable(err) || ctx.Err() != nil {
+			return
+		}
+
+		timer := time.NewTimer(p.backoff(attempts))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		if p.Limiter != nil {
+			p.Limiter.Wait()
+		}
+	}
+}
+
+// 第 attempts 次调用失败以后等待的时间: Backoff * 2^(attempts-1), 不超过 MaxBackoff.
+func (p *RetryPolicy) backoff(attempts int) time.Duration {
+	backoff := p.Backoff
+	if backoff <= 0 {
+		backoff = DefaultRetryBackoff
+	}
+	for i := 1; i < attempts; i++ {
+		if p.MaxBackoff > 0 && backoff >= p.MaxBackoff {
+			break
+		}
+		backoff *= 2
+	}
+	if p.MaxBackoff > 0 && backoff > p.MaxBackoff {
+		backoff = p.MaxBackoff
+	}
+	return backoff
+}
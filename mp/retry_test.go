@@ -0,0 +1,147 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+type testPermanentError bool
+
+func (e testPermanentError) Error() string   { return "test" }
+func (e testPermanentError) Permanent() bool { return bool(e) }
+
+func TestIsRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"系统繁忙", &Error{ErrCode: ErrCodeSystemBusy}, true},
+		{"频率限制", &Error{ErrCode: ErrCodeAPIFreqLimit}, true},
+		{"参数错误", &Error{ErrCode: ErrCodeInvalidOpenId}, false},
+		{"http 状态码错误", errors.New("http.Status: 502 Bad Gateway"), true},
+		{"Permanent 标记的错误", Permanent(errors.New("http.Status: 404 Not Found")), false},
+		{"Permanent() 返回 false", testPermanentError(false), true},
+		{"Permanent() 返回 true", testPermanentError(true), false},
+		{"ctx 结束", context.Canceled, false},
+	}
+	for _, tt := range tests {
+		if have := IsRetryable(tt.err); have != tt.want {
+			t.Errorf("%s: have %v, want %v", tt.name, have, tt.want)
+		}
+	}
+}
+
+func TestRetryPolicyDo(t *testing.T) {
+	tests := []struct {
+		name         string
+		maxRetries   int
+		errs         []error // 每次调用返回的错误, 超出部分返回 nil
+		wantAttempts int
+		wantErr      bool
+	}{
+		{"第一次成功", 0, nil, 1, false},
+		{"重试以后成功", 0, []error{errors.New("timeout"), &Error{ErrCode: ErrCodeSystemBusy}}, 3, false},
+		{"重试次数用完", 2, []error{errors.New("a"), errors.New("b"), errors.New("c"), errors.New("d")}, 3, true},
+		{"不重试", -1, []error{errors.New("a")}, 1, true},
+		{"不能重试的错误", 0, []error{&Error{ErrCode: ErrCodeInvalidOpenId}}, 1, true},
+	}
+	for _, tt := range tests {
+		policy := RetryPolicy{MaxRetries: tt.maxRetries, Backoff: time.Millisecond}
+		calls := 0
+		attempts, err := policy.Do(context.Background(), func() error {
+			calls++
+			if calls <= len(tt.errs) {
+				return tt.errs[calls-1]
+			}
+			return nil
+		})
+		if attempts != tt.wantAttempts || attempts != calls || (err != nil) != tt.wantErr {
+			t.Errorf("%s: have %d, %v; want %d, wantErr %v", tt.name, attempts, err, tt.wantAttempts, tt.wantErr)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	policy := RetryPolicy{Backoff: time.Hour}
+	if attempts, err := policy.Do(ctx, func() error { return errors.New("timeout") }); attempts != 1 || err == nil {
+		t.Errorf("ctx 结束以后不应该再重试: have %d, %v", attempts, err)
+	}
+}
+
+func TestRetryPolicyCallOptions(t *testing.T) {
+	var hits int32
+	clt, closeFn := newTestWechatClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer closeFn()
+	clt.MaxRetries = 2
+
+	opts := []CallOption{WithNoCache()}
+	policy := RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond}
+	callOpts := policy.CallOptions(opts)
+	attempts, err := policy.Do(context.Background(), func() error {
+		var result Error
+		return clt.PostJSON("https://api.weixin.qq.com/cgi-bin/user/info/updateremark?access_token=", map[string]string{}, &result, callOpts...)
+	})
+	if err == nil || attempts != 3 {
+		t.Errorf("have %d, %v; want 3 attempts", attempts, err)
+	}
+	if hits != 3 {
+		t.Errorf("重试叠加了: 请求了 %d 次, want 3", hits)
+	}
+	if len(opts) != 1 {
+		t.Errorf("CallOptions 修改了 opts")
+	}
+}
+
+func TestWechatClientRetryBackoff(t *testing.T) {
+	var hits int32
+	clt, closeFn := newTestWechatClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&hits, 1)
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer closeFn()
+	clt.MaxRetries = 2
+	clt.RetryBackoff = 20 * time.Millisecond
+
+	start := time.Now()
+	var result Error
+	if err := clt.PostJSON("https://api.weixin.qq.com/cgi-bin/user/info/updateremark?access_token=", map[string]string{}, &result); err == nil {
+		t.Error("want error")
+	}
+	if hits != 3 {
+		t.Errorf("请求了 %d 次, want 3", hits)
+	}
+	if elapsed := time.Since(start); elapsed < 60*time.Millisecond { // 20ms + 40ms
+		t.Errorf("重试之间没有等待: %s", elapsed)
+	}
+}
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	tests := []struct {
+		name     string
+		policy   RetryPolicy
+		attempts int
+		want     time.Duration
+	}{
+		{"默认", RetryPolicy{}, 1, DefaultRetryBackoff},
+		{"每次加倍", RetryPolicy{Backoff: time.Second}, 4, 8 * time.Second},
+		{"不超过 MaxBackoff", RetryPolicy{Backoff: time.Second, MaxBackoff: time.Minute}, 100, time.Minute},
+	}
+	for _, tt := range tests {
+		if have := tt.policy.backoff(tt.attempts); have != tt.want {
+			t.Errorf("%s: have %s, want %s", tt.name, have, tt.want)
+		}
+	}
+}
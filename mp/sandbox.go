@@ -0,0 +1,178 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+var (
+	_ WechatServer           = (*SandboxWechatServer)(nil)
+	_ ToUserNameCheckSkipper = (*SandboxWechatServer)(nil)
+)
+
+// 微信公众平台接口测试帐号(测试号)的 WechatServer.
+//  测试号只支持明文模式, 并且原始ID不方便获取, 所以 SandboxWechatServer 不验证消息的 ToUserName,
+//  其他的签名验证和 DefaultWechatServer 一样.
+//
+//  NOTE: 只用于测试号, 不要用于正式的公众号.
+type SandboxWechatServer struct {
+	token          string
+	appId          string
+	messageHandler MessageHandler
+}
+
+// 创建一个新的 SandboxWechatServer.
+//  token: 测试号管理页面"接口配置信息"里填写的 Token
+func NewSandboxWechatServer(token, appId string, messageHandler MessageHandler) *SandboxWechatServer {
+	if messageHandler == nil {
+		panic("mp: nil messageHandler")
+	}
+	return &SandboxWechatServer{
+		token:          token,
+		appId:          appId,
+		messageHandler: messageHandler,
+	}
+}
+
+func (srv *SandboxWechatServer) WechatId() string {
+	return ""
+}
+
+func (srv *SandboxWechatServer) Token() string {
+	return srv.token
+}
+
+func (srv *SandboxWechatServer) AppId() string {
+	return srv.appId
+}
+
+func (srv *SandboxWechatServer) CurrentAESKey() (key [32]byte) {
+	return
+}
+
+func (srv *SandboxWechatServer) LastAESKey() (key [32]byte) {
+	return
+}
+
+func (srv *SandboxWechatServer) MessageHandler() MessageHandler {
+	return srv.messageHandler
+}
+
+// 测试号不验证消息的 ToUserName.
+func (srv *SandboxWechatServer) SkipToUserNameCheck() bool {
+	return true
+}
+
+var _ http.RoundTripper = (*BaseURLTransport)(nil)
+
+// 把发往微信服务器的请求改发到 BaseURL, 用于 mock 服务器, 代理或者集成测试.
+//  比如 BaseURL 为 http://127.0.0.1:8080/wechat 时,
+//  https://api.weixin.qq.com/cgi-bin/user/get?access_token=TOKEN 会改发到
+//  http://127.0.0.1:8080/wechat/cgi-bin/user/get?access_token=TOKEN
+type BaseURLTransport struct {
+	BaseURL   *url.URL
+	Hosts     []string          // 需要改发的域名, 为空时只改发 api.weixin.qq.com
+	Transport http.RoundTripper // 为 nil 时使用 http.DefaultTransport
+}
+
+// 创建一个新的 BaseURLTransport.
+func NewBaseURLTransport(baseURL string, transport http.RoundTripper) (*BaseURLTransport, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, errors.New("invalid baseURL: " + baseURL)
+	}
+	return &BaseURLTransport{
+		BaseURL:   u,
+		Transport: transport,
+	}, nil
+}
+
+func (t *BaseURLTransport) matchHost(host string) bool {
+	if len(t.Hosts) == 0 {
+		return host == "api.weixin.qq.com"
+	}
+	for _, h := range t.Hosts {
+		if host == h {
+			return true
+		}
+	}
+	return false
+}
+
+func (t *BaseURLTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := t.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	if !t.matchHost(req.URL.Host) {
+		return transport.RoundTrip(req)
+	}
+
+	newURL := *req.URL
+	newURL.Scheme = t.BaseURL.Scheme
+	newURL.Host = t.BaseURL.Host
+	newURL.Path = strings.TrimSuffix(t.BaseURL.Path, "/") + req.URL.Path
+	newURL.RawPath = ""
+
+	newReq := new(http.Request)
+	*newReq = *req
+	newReq.URL = &newURL
+	newReq.Host = ""
+	return transport.RoundTrip(newReq)
+}
+
+// 测试号的快速启动配置, 用于示例代码和集成测试.
+type Sandbox struct {
+	HttpClient  *http.Client          // 主动请求使用的 http.Client, 设置了 baseURL 时会改发到 baseURL
+	TokenServer *DefaultTokenServer   // 测试号的 access_token 中控服务器
+	Server      *SandboxWechatServer  // 测试号的 WechatServer
+	Handler     *WechatServerFrontend // 处理回调的 http.Handler, 注册到"接口配置信息"里填写的 URL
+}
+
+// 创建测试号的快速启动配置.
+//  appId, appSecret, token: 测试号管理页面里的 appID, appsecret 和"接口配置信息"里填写的 Token;
+//  baseURL:                 为空时请求真实的微信服务器, 否则请求改发到 baseURL, 比如 httptest.Server.URL;
+//  messageHandler:          处理回调消息(事件)的 MessageHandler, 一般为 *MessageServeMux.
+//
+//  NOTE: 会同步获取 access_token, 失败会 panic, 同 NewDefaultTokenServer.
+func NewSandbox(appId, appSecret, token, baseURL string, messageHandler MessageHandler) (sandbox *Sandbox, err error) {
+	httpClient := TextHttpClient
+	if baseURL != "" {
+		transport, err := NewBaseURLTransport(baseURL, TextHttpClient.Transport)
+		if err != nil {
+			return nil, err
+		}
+		httpClient = &http.Client{
+			Transport: transport,
+			Timeout:   TextHttpClient.Timeout,
+		}
+	}
+
+	server := NewSandboxWechatServer(token, appId, messageHandler)
+	sandbox = &Sandbox{
+		HttpClient:  httpClient,
+		TokenServer: NewDefaultTokenServer(appId, appSecret, httpClient),
+		Server:      server,
+		Handler:     NewWechatServerFrontend(server, nil),
+	}
+	return
+}
+
+// 返回使用测试号 access_token 的 WechatClient, 一般用于构造各个子包的 Client:
+//  menuClient := menu.NewClient(sandbox.TokenServer, sandbox.HttpClient)
+func (sandbox *Sandbox) WechatClient() *WechatClient {
+	return &WechatClient{
+		TokenServer: sandbox.TokenServer,
+		HttpClient:  sandbox.HttpClient,
+	}
+}
@@ -0,0 +1,91 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package scan
+
+import (
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	EventTypeUserScanProduct             = "user_scan_product"               // 打开商品主页事件
+	EventTypeUserScanProductEnterSession = "user_scan_product_enter_session" // 进入公众号事件
+	EventTypeUserScanProductAsync        = "user_scan_product_async"         // 地理位置信息异步推送事件
+	EventTypeUserScanProductVerifyAction = "user_scan_product_verify_action" // 商品审核结果事件
+	EventTypeSubscribeScanProduct        = "subscribe_scan_product"          // 用户在商品主页中关注公众号事件
+)
+
+// 打开商品主页事件, 进入公众号事件, 地理位置信息异步推送事件
+type UserScanProductEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	mp.CommonMessageHeader
+
+	Event       string `xml:"Event"       json:"Event"`
+	KeyStandard string `xml:"KeyStandard" json:"KeyStandard"` // 商品编码标准
+	KeyStr      string `xml:"KeyStr"      json:"KeyStr"`      // 商品编码内容
+	Country     string `xml:"Country"     json:"Country"`     // 用户在微信内设置的国家
+	Province    string `xml:"Province"    json:"Province"`    // 用户在微信内设置的省份
+	City        string `xml:"City"        json:"City"`        // 用户在微信内设置的城市
+	Sex         int    `xml:"Sex"         json:"Sex"`         // 用户的性别, 1 男性, 2 女性, 0 未知
+	Scene       int    `xml:"Scene"       json:"Scene"`       // 打开商品主页的场景, 1 扫码, 2 其他打开场景
+	ExtInfo     string `xml:"ExtInfo"     json:"ExtInfo"`     // 调用"获取商品二维码接口"时传入的 extinfo
+	RegionCode  string `xml:"RegionCode"  json:"RegionCode"`  // 用户的实时地理位置信息(目前只精确到省一级), 仅异步推送事件有效
+}
+
+func GetUserScanProductEvent(msg *mp.MixedMessage) *UserScanProductEvent {
+	return &UserScanProductEvent{
+		CommonMessageHeader: msg.CommonMessageHeader,
+		Event:               msg.Event,
+		KeyStandard:         msg.KeyStandard,
+		KeyStr:              msg.KeyStr,
+		Country:             msg.Country,
+		Province:            msg.Province,
+		City:                msg.City,
+		Sex:                 msg.Sex,
+		Scene:               msg.Scene,
+		ExtInfo:             msg.ExtInfo,
+		RegionCode:          msg.RegionCode,
+	}
+}
+
+// 商品审核结果事件
+type VerifyActionEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	mp.CommonMessageHeader
+
+	Event       string `xml:"Event"       json:"Event"`
+	KeyStandard string `xml:"KeyStandard" json:"KeyStandard"`
+	KeyStr      string `xml:"KeyStr"      json:"KeyStr"`
+	Result      string `xml:"Result"      json:"Result"`    // 审核结果, verify_ok 审核通过, verify_not_pass 审核未通过
+	ReasonMsg   string `xml:"ReasonMsg"   json:"ReasonMsg"` // 审核未通过的原因
+}
+
+func GetVerifyActionEvent(msg *mp.MixedMessage) *VerifyActionEvent {
+	return &VerifyActionEvent{
+		CommonMessageHeader: msg.CommonMessageHeader,
+		Event:               msg.Event,
+		KeyStandard:         msg.KeyStandard,
+		KeyStr:              msg.KeyStr,
+		Result:              msg.Result,
+		ReasonMsg:           msg.ReasonMsg,
+	}
+}
+
+// 用户在商品主页中关注公众号事件
+type SubscribeScanProductEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	mp.CommonMessageHeader
+
+	Event    string `xml:"Event"    json:"Event"`
+	EventKey string `xml:"EventKey" json:"EventKey"` // 格式为 "scanbarcode|keystandard|keystr" 或 "scanimage|keystandard|keystr"
+}
+
+func GetSubscribeScanProductEvent(msg *mp.MixedMessage) *SubscribeScanProductEvent {
+	return &SubscribeScanProductEvent{
+		CommonMessageHeader: msg.CommonMessageHeader,
+		Event:               msg.Event,
+		EventKey:            msg.EventKey,
+	}
+}
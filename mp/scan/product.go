@@ -0,0 +1,432 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package scan
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 商品编码标准
+const (
+	KeyStandardEAN13  = "ean13" // 条码
+	KeyStandardEAN8   = "ean8"
+	KeyStandardQRCode = "qrcode" // 二维码
+)
+
+// 商品状态
+const (
+	ProductStatusOn    = "on"    // 发布
+	ProductStatusOff   = "off"   // 取消发布
+	ProductStatusCheck = "check" // 审核中
+)
+
+// 商品主页的基本信息
+type BaseInfo struct {
+	Title             string   `json:"title"`                         // 商品名称
+	ThumbURL          string   `json:"thumb_url"`                     // 商品图片
+	BrandTag          string   `json:"brand_tag"`                     // 商品主页的品牌名称
+	CategoryId        int64    `json:"category_id"`                   // 商品类目ID
+	StoreMgrType      string   `json:"store_mgr_type,omitempty"`      // 销售渠道, auto 自动获取, custom 自定义
+	StoreVendorIdList []string `json:"store_vendorid_list,omitempty"` // 商户ID列表, store_mgr_type 为 custom 时有效
+	Color             string   `json:"color,omitempty"`               // 主页的主色调
+}
+
+// 商品的详情信息
+type DetailInfo struct {
+	BannerList []struct {
+		Link string `json:"link"`
+	} `json:"banner_list,omitempty"` // 商品图片
+	DetailList []struct {
+		Title string `json:"title"`
+		Desc  string `json:"desc"`
+	} `json:"detail_list,omitempty"` // 商品详情
+}
+
+// 商品主页上的服务栏目
+type Action struct {
+	Type        string `json:"type"`                   // 类型, link, user, text, product, card, recommend 等
+	Name        string `json:"name,omitempty"`         // 名称
+	Link        string `json:"link,omitempty"`         // 链接
+	Image       string `json:"image,omitempty"`        // 图片
+	ShowType    string `json:"showtype,omitempty"`     // 展示样式, banner 图片, media 图文, text 文字
+	Digest      string `json:"digest,omitempty"`       // 文字描述
+	AppId       string `json:"appid,omitempty"`        // 公众号或小程序 appid
+	CardId      string `json:"cardid,omitempty"`       // 卡券ID
+	RetailPrice string `json:"retail_price,omitempty"` // 建议零售价
+	SalePrice   string `json:"sale_price,omitempty"`   // 售价
+}
+
+// 商品主页的信息
+type BrandInfo struct {
+	BaseInfo   BaseInfo   `json:"base_info"`
+	DetailInfo DetailInfo `json:"detail_info"`
+	ActionInfo struct {
+		ActionList []Action `json:"action_list,omitempty"`
+	} `json:"action_info"`
+	ModuleInfo struct {
+		ModuleList []struct {
+			Name        string `json:"name"`                    // 模块名称, anti_fake 防伪模块
+			NativeShow  string `json:"native_show,omitempty"`   // 是否展示
+			AntiFakeURL string `json:"anti_fake_url,omitempty"` // 防伪信息的查询链接
+		} `json:"module_list,omitempty"`
+	} `json:"module_info"`
+}
+
+// 商品
+type Product struct {
+	KeyStandard string    `json:"keystandard"` // 商品编码标准
+	KeyStr      string    `json:"keystr"`      // 商品编码内容
+	BrandInfo   BrandInfo `json:"brand_info"`
+}
+
+// 获取商户信息, 返回商户的品牌标签列表和认证状态.
+func (clt *Client) GetMerchantInfo(opts ...mp.CallOption) (brandTagList []string, verifiedList []string, err error) {
+	var result struct {
+		mp.Error
+		BrandTagList []string `json:"brand_tag_list"`
+		VerifiedList []struct {
+			VerifiedFirmCode string `json:"verified_firm_code"`
+		} `json:"verified_list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/scan/merchantinfo/get?access_token="
+	if err = clt.GetJSON(incompleteURL, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	brandTagList = result.BrandTagList
+	for _, v := range result.VerifiedList {
+		verifiedList = append(verifiedList, v.VerifiedFirmCode)
+	}
+	return
+}
+
+// 创建商品, 返回商品的 pid.
+func (clt *Client) ProductCreate(product *Product, opts ...mp.CallOption) (pid string, err error) {
+	if product == nil {
+		err = errors.New("nil Product")
+		return
+	}
+
+	var result struct {
+		mp.Error
+		PId string `json:"pid"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/scan/product/create?access_token="
+	if err = clt.PostJSON(incompleteURL, product, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	pid = result.PId
+	return
+}
+
+// 更新商品信息, 更新后需要重新提交审核.
+func (clt *Client) ProductUpdate(product *Product, opts ...mp.CallOption) (pid string, err error) {
+	if product == nil {
+		err = errors.New("nil Product")
+		return
+	}
+
+	var result struct {
+		mp.Error
+		PId string `json:"pid"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/scan/product/update?access_token="
+	if err = clt.PostJSON(incompleteURL, product, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	pid = result.PId
+	return
+}
+
+// 提交审核/取消发布商品.
+//  status: ProductStatusOn 提交审核, ProductStatusOff 取消发布
+func (clt *Client) ProductModStatus(keyStandard, keyStr, status string, opts ...mp.CallOption) (err error) {
+	if keyStandard == "" || keyStr == "" {
+		return errors.New("empty keyStandard or keyStr")
+	}
+	switch status {
+	case ProductStatusOn, ProductStatusOff:
+	default:
+		return errors.New("invalid status: " + status)
+	}
+
+	var request = struct {
+		KeyStandard string `json:"keystandard"`
+		KeyStr      string `json:"keystr"`
+		Status      string `json:"status"`
+	}{
+		KeyStandard: keyStandard,
+		KeyStr:      keyStr,
+		Status:      status,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/scan/product/modstatus?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 查询商品信息.
+func (clt *Client) ProductGet(keyStandard, keyStr string, opts ...mp.CallOption) (product *Product, err error) {
+	if keyStandard == "" || keyStr == "" {
+		err = errors.New("empty keyStandard or keyStr")
+		return
+	}
+
+	var request = struct {
+		KeyStandard string `json:"keystandard"`
+		KeyStr      string `json:"keystr"`
+	}{
+		KeyStandard: keyStandard,
+		KeyStr:      keyStr,
+	}
+
+	var result struct {
+		mp.Error
+		BrandInfo BrandInfo `json:"brand_info"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/scan/product/get?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	product = &Product{
+		KeyStandard: keyStandard,
+		KeyStr:      keyStr,
+		BrandInfo:   result.BrandInfo,
+	}
+	return
+}
+
+// 商品列表中的商品
+type ProductKey struct {
+	KeyStandard string `json:"keystandard"`
+	KeyStr      string `json:"keystr"`
+	Title       string `json:"title"`
+	ThumbURL    string `json:"thumb_url"`
+	CategoryId  int64  `json:"category_id"`
+	Status      string `json:"status"`
+}
+
+// 批量查询商品信息.
+//  offset: 起始位置, 从0开始
+//  limit:  查询数量, 最大100
+//  status: 商品状态, 为空时查询全部
+//  keyStr: 按商品编码内容模糊查询, 可以为空
+func (clt *Client) ProductGetList(offset, limit int, status, keyStr string, opts ...mp.CallOption) (total int, list []ProductKey, err error) {
+	if offset < 0 {
+		err = errors.New("offset should not be less than 0")
+		return
+	}
+	if limit <= 0 || limit > 100 {
+		err = errors.New("limit should be between 1 and 100")
+		return
+	}
+
+	var request = struct {
+		Offset int    `json:"offset"`
+		Limit  int    `json:"limit"`
+		Status string `json:"status,omitempty"`
+		KeyStr string `json:"keystr,omitempty"`
+	}{
+		Offset: offset,
+		Limit:  limit,
+		Status: status,
+		KeyStr: keyStr,
+	}
+
+	var result struct {
+		mp.Error
+		KeyList []ProductKey `json:"key_list"`
+		Total   int          `json:"total"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/scan/product/getlist?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	total = result.Total
+	list = result.KeyList
+	return
+}
+
+// 清除商品信息, 清除后商品主页展示为空.
+func (clt *Client) ProductClear(keyStandard, keyStr string, opts ...mp.CallOption) (err error) {
+	if keyStandard == "" || keyStr == "" {
+		return errors.New("empty keyStandard or keyStr")
+	}
+
+	var request = struct {
+		KeyStandard string `json:"keystandard"`
+		KeyStr      string `json:"keystr"`
+	}{
+		KeyStandard: keyStandard,
+		KeyStr:      keyStr,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/scan/product/clear?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 获取商品二维码, 返回二维码图片的 URL.
+//  extInfo:    附带的自定义信息, 扫码事件中会返回, 可以为空
+//  qrcodeSize: 二维码的尺寸(整型), 数值代表边长像素数, 为 0 时使用默认值 100
+func (clt *Client) ProductGetQRCode(keyStandard, keyStr, extInfo string, qrcodeSize int, opts ...mp.CallOption) (picURL string, err error) {
+	if keyStandard == "" || keyStr == "" {
+		err = errors.New("empty keyStandard or keyStr")
+		return
+	}
+
+	var request = struct {
+		KeyStandard string `json:"keystandard"`
+		KeyStr      string `json:"keystr"`
+		ExtInfo     string `json:"extinfo,omitempty"`
+		QRCodeSize  int    `json:"qrcode_size,omitempty"`
+	}{
+		KeyStandard: keyStandard,
+		KeyStr:      keyStr,
+		ExtInfo:     extInfo,
+		QRCodeSize:  qrcodeSize,
+	}
+
+	var result struct {
+		mp.Error
+		PicURL string `json:"pic_url"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/scan/product/getqrcode?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	picURL = result.PicURL
+	return
+}
+
+// 设置测试人员白名单, 商品发布前白名单内的用户可以看到商品主页.
+//  openIds, userNames: 用户的 openid 和微信号, 至少一个不为空, 总数不超过10个
+func (clt *Client) SetTestWhiteList(openIds, userNames []string, opts ...mp.CallOption) (err error) {
+	if len(openIds) == 0 && len(userNames) == 0 {
+		return errors.New("openIds and userNames cannot both be empty")
+	}
+
+	var request = struct {
+		OpenId   []string `json:"openid,omitempty"`
+		UserName []string `json:"username,omitempty"`
+	}{
+		OpenId:   openIds,
+		UserName: userNames,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/scan/testwhitelist/set?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 检查 wxticket 的结果
+type TicketInfo struct {
+	OpenId      string `json:"openid"`
+	KeyStandard string `json:"keystandard"`
+	KeyStr      string `json:"keystr"`
+	ExtInfo     string `json:"extinfo"`
+	Country     string `json:"country"`
+	Province    string `json:"province"`
+	City        string `json:"city"`
+	Sex         int    `json:"sex"`
+	Scene       int    `json:"scene"` // 打开商品主页的场景, 1 扫码, 2 其他打开场景(如会话, 收藏或朋友圈)
+}
+
+// 检查 wxticket 参数, 获取扫码用户的信息.
+//  ticket: 商品主页跳转到商户自有页面时 URL 上带的 wxticket 参数, 20分钟内有效, 只能检查一次
+func (clt *Client) CheckTicket(ticket string, opts ...mp.CallOption) (info *TicketInfo, err error) {
+	if ticket == "" {
+		err = errors.New("empty ticket")
+		return
+	}
+
+	var request = struct {
+		Ticket string `json:"ticket"`
+	}{
+		Ticket: ticket,
+	}
+
+	var result struct {
+		mp.Error
+		TicketInfo
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/scan/scanticket/check?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	info = &result.TicketInfo
+	return
+}
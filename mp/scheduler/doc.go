@@ -0,0 +1,9 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 定时消息.
+//  Scheduler 接收 "在 T 时刻给用户 Y 发送 X" 的任务, 通过 JobStore 持久化, 到时间后交给对应类型的 Executor 执行,
+//  失败时按指数退避(带随机抖动)重试, 用来替代 SDK 外面的各种 cron 脚本.
+package scheduler
@@ -0,0 +1,110 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package scheduler
+
+import (
+	"encoding/json"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/message/custom"
+	"github.com/chanxuehong/wechat/mp/message/template"
+	"github.com/chanxuehong/wechat/mp/notify"
+)
+
+// 内置的任务类型, 需要先用 Handle 登记对应的 Executor.
+const (
+	KindCustomText = "custom_text" // 客服消息(文本), Payload 为 CustomTextPayload, 见 CustomTextExecutor
+	KindTemplate   = "template"    // 模板消息, Payload 为 template.TemplateMessage, 见 TemplateExecutor
+	KindNotify     = "notify"      // 通知, Payload 为 NotifyPayload, 见 NotifyExecutor
+)
+
+type CustomTextPayload struct {
+	Content   string `json:"content"`
+	KfAccount string `json:"kf_account,omitempty"`
+}
+
+type NotifyPayload struct {
+	NotificationId string                 `json:"notification_id"`
+	Data           map[string]interface{} `json:"data"`
+}
+
+// 用户不在可以接收消息的状态, 重试也不会成功的错误.
+func isPermanentErrCode(errCode int) bool {
+	switch errCode {
+	case mp.ErrCodeInvalidOpenId,
+		mp.ErrCodeRequireSubscribe,
+		mp.ErrCodeResponseTimeLimit,
+		mp.ErrCodeResponseCountLimit,
+		mp.ErrCodeUserRefuseToAcceptMsg:
+		return true
+	}
+	return false
+}
+
+func wrapError(err error) error {
+	switch e := err.(type) {
+	case *mp.Error:
+		if isPermanentErrCode(e.ErrCode) {
+			return Permanent(err)
+		}
+	case *custom.GuardError:
+		return Permanent(err)
+	}
+	return err
+}
+
+// 解析 Payload, Payload 无效时任务不再重试.
+func unmarshalPayload(job *Job, v interface{}) error {
+	if err := json.Unmarshal(job.Payload, v); err != nil {
+		return Permanent(err)
+	}
+	return nil
+}
+
+// 通过 clt 发送 KindCustomText 类型的任务.
+func CustomTextExecutor(clt *custom.Client) Executor {
+	if clt == nil {
+		panic("nil custom Client")
+	}
+	return func(job *Job) error {
+		var payload CustomTextPayload
+		if err := unmarshalPayload(job, &payload); err != nil {
+			return err
+		}
+		return wrapError(clt.SendText(custom.NewText(job.OpenId, payload.Content, payload.KfAccount)))
+	}
+}
+
+// 通过 clt 发送 KindTemplate 类型的任务, 接收者为 job.OpenId.
+func TemplateExecutor(clt *template.Client) Executor {
+	if clt == nil {
+		panic("nil template Client")
+	}
+	return func(job *Job) error {
+		var msg template.TemplateMessage
+		if err := unmarshalPayload(job, &msg); err != nil {
+			return err
+		}
+		msg.ToUser = job.OpenId
+		_, err := clt.Send(&msg)
+		return wrapError(err)
+	}
+}
+
+// 通过 srv 发送 KindNotify 类型的任务.
+func NotifyExecutor(srv *notify.Service) Executor {
+	if srv == nil {
+		panic("nil notify Service")
+	}
+	return func(job *Job) error {
+		var payload NotifyPayload
+		if err := unmarshalPayload(job, &payload); err != nil {
+			return err
+		}
+		_, err := srv.Send(job.OpenId, payload.NotificationId, payload.Data)
+		return wrapError(err)
+	}
+}
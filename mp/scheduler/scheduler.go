@@ -0,0 +1,305 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package scheduler
+
+import (
+	"encoding/json"
+	"errors"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	DefaultMaxAttempts  = 5
+	DefaultRetryBackoff = time.Minute
+	DefaultLease        = 5 * time.Minute
+	DefaultPollInterval = time.Second
+	DefaultBatchSize    = 100
+)
+
+// 执行一种类型的任务, 返回 error 时任务会重试, 返回 Permanent(err) 时不再重试.
+type Executor func(job *Job) error
+
+type permanentError struct {
+	err error
+}
+
+func (e *permanentError) Error() string {
+	return e.err.Error()
+}
+
+// 包装一个不需要重试的错误, 比如用户已经取消关注.
+func Permanent(err error) error {
+	if err == nil {
+		return nil
+	}
+	return &permanentError{err: err}
+}
+
+// 判断 err 是否为 Permanent 包装的错误.
+func IsPermanent(err error) bool {
+	_, ok := err.(*permanentError)
+	return ok
+}
+
+// 定时任务调度器.
+//  任务执行失败时第 n 次重试的间隔为 RetryBackoff * 2^(n-1), 再加上不超过 Jitter 比例的随机抖动,
+//  避免大量任务同时失败以后又同时重试; 失败 MaxAttempts 次或者返回 Permanent 错误以后任务被删除, 并调用 OnFail.
+type Scheduler struct {
+	Store JobStore
+
+	MaxAttempts  int           // 最多执行的次数, 默认为 DefaultMaxAttempts
+	RetryBackoff time.Duration // 第一次重试的间隔, 默认为 DefaultRetryBackoff
+	Jitter       float64       // 重试间隔的随机抖动比例, 0 ~ 1, 默认为 0.2
+	Lease        time.Duration // 任务执行的超时时间, 超时以后任务可能被重新执行, 默认为 DefaultLease
+	PollInterval time.Duration // 查询到期任务的间隔, 默认为 DefaultPollInterval
+	BatchSize    int           // 每次最多取出的任务数, 默认为 DefaultBatchSize
+
+	OnDone func(job *Job)            // 任务执行成功的回调, 可以为 nil
+	OnFail func(job *Job, err error) // 任务最终失败的回调, 可以为 nil
+
+	rwmutex   sync.RWMutex
+	executors map[string]Executor
+
+	randMutex sync.Mutex
+	rand      *rand.Rand
+
+	startOnce sync.Once
+	started   bool
+	stopOnce  sync.Once
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// 创建定时任务调度器, store 为 nil 时使用 MemoryJobStore.
+func NewScheduler(store JobStore) *Scheduler {
+	if store == nil {
+		store = NewMemoryJobStore()
+	}
+	return &Scheduler{
+		Store:     store,
+		Jitter:    0.2,
+		executors: make(map[string]Executor),
+		rand:      rand.New(rand.NewSource(time.Now().UnixNano())),
+		stop:      make(chan struct{}),
+		done:      make(chan struct{}),
+	}
+}
+
+// 登记 kind 类型任务的执行者, 已经存在的会被覆盖.
+func (s *Scheduler) Handle(kind string, executor Executor) {
+	if executor == nil {
+		panic("nil Executor")
+	}
+	s.rwmutex.Lock()
+	s.executors[kind] = executor
+	s.rwmutex.Unlock()
+}
+
+func (s *Scheduler) executor(kind string) Executor {
+	s.rwmutex.RLock()
+	executor := s.executors[kind]
+	s.rwmutex.RUnlock()
+	return executor
+}
+
+// 添加一个在 runAt 时刻执行的任务, payload 会被 json.Marshal 后保存.
+func (s *Scheduler) Schedule(kind, openid string, payload interface{}, runAt time.Time) (job *Job, err error) {
+	if kind == "" {
+		err = errors.New("empty kind")
+		return
+	}
+	if openid == "" {
+		err = errors.New("empty openid")
+		return
+	}
+	if s.executor(kind) == nil {
+		err = errors.New("no Executor for kind: " + kind)
+		return
+	}
+
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return
+	}
+	job = &Job{
+		Id:        mp.NewClientMsgId(),
+		Kind:      kind,
+		OpenId:    openid,
+		Payload:   data,
+		RunAt:     runAt,
+		CreatedAt: time.Now(),
+	}
+	if err = s.Store.Save(job); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// 取消任务.
+func (s *Scheduler) Cancel(id string) error {
+	return s.Store.Remove(id)
+}
+
+func (s *Scheduler) maxAttempts() int {
+	if s.MaxAttempts > 0 {
+		return s.MaxAttempts
+	}
+	return DefaultMaxAttempts
+}
+
+func (s *Scheduler) lease() time.Duration {
+	if s.Lease > 0 {
+		return s.Lease
+	}
+	return DefaultLease
+}
+
+func (s *Scheduler) batchSize() int {
+	if s.BatchSize > 0 {
+		return s.BatchSize
+	}
+	return DefaultBatchSize
+}
+
+// 第 attempts 次失败以后的重试间隔.
+func (s *Scheduler) backoff(attempts int) time.Duration {
+	backoff := s.RetryBackoff
+	if backoff <= 0 {
+		backoff = DefaultRetryBackoff
+	}
+	for i := 1; i < attempts && backoff < 24*time.Hour; i++ {
+		backoff *= 2
+	}
+	if s.Jitter > 0 {
+		s.randMutex.Lock()
+		f := s.rand.Float64()
+		s.randMutex.Unlock()
+		backoff += time.Duration(float64(backoff) * s.Jitter * f)
+	}
+	return backoff
+}
+
+// 执行所有到期的任务, 返回执行的任务数; 一般不用调用, Start 会定期调用.
+func (s *Scheduler) RunOnce() (n int, err error) {
+	now := time.Now()
+	jobs, err := s.Store.Acquire(now, now.Add(s.lease()), s.batchSize())
+	if err != nil {
+		return
+	}
+
+	for _, job := range jobs {
+		n++
+		if err = s.run(job); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// 执行一个任务, 返回的是存储的错误, 执行的错误通过重试和 OnFail 处理.
+func (s *Scheduler) run(job *Job) (err error) {
+	var execErr error
+	if executor := s.executor(job.Kind); executor == nil {
+		execErr = Permanent(errors.New("no Executor for kind: " + job.Kind))
+	} else {
+		execErr = executor(job)
+	}
+
+	if execErr == nil {
+		if err = s.Store.Remove(job.Id); err != nil {
+			return
+		}
+		if s.OnDone != nil {
+			s.OnDone(job)
+		}
+		return
+	}
+
+	job.Attempts++
+	job.LastError = execErr.Error()
+	if IsPermanent(execErr) || job.Attempts >= s.maxAttempts() {
+		if err = s.Store.Remove(job.Id); err != nil {
+			return
+		}
+		if s.OnFail != nil {
+			s.OnFail(job, execErr)
+		}
+		return
+	}
+	job.RunAt = time.Now().Add(s.backoff(job.Attempts))
+	return s.Store.Save(job)
+}
+
+var _ mp.HealthReporter = (*Scheduler)(nil)
+
+// 报告任务队列的长度, 只有 Store 有 Jobs 方法(比如 MemoryJobStore, FileJobStore)时才能报告.
+func (s *Scheduler) ReportHealth(h *mp.Health) {
+	if store, ok := s.Store.(interface {
+		Jobs() []Job
+	}); ok {
+		h.SetQueue("scheduler", len(store.Jobs()))
+	}
+}
+
+// 在后台定期执行到期的任务, onError 为存储的错误回调, 可以为 nil.
+func (s *Scheduler) Start(onError func(error)) {
+	s.startOnce.Do(func() {
+		s.rwmutex.Lock()
+		s.started = true
+		s.rwmutex.Unlock()
+		go s.loop(onError)
+	})
+}
+
+func (s *Scheduler) loop(onError func(error)) {
+	defer close(s.done)
+
+	interval := s.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		// 一批任务执行完以后马上检查下一批, 直到没有到期的任务
+		for {
+			select {
+			case <-s.stop:
+				return
+			default:
+			}
+			n, err := s.RunOnce()
+			if err != nil && onError != nil {
+				onError(err)
+			}
+			if err != nil || n < s.batchSize() {
+				break
+			}
+		}
+		select {
+		case <-ticker.C:
+		case <-s.stop:
+			return
+		}
+	}
+}
+
+// 停止后台执行, 等待正在执行的任务结束. 没有调用过 Start 时直接返回.
+func (s *Scheduler) Stop() {
+	s.stopOnce.Do(func() { close(s.stop) })
+
+	s.rwmutex.RLock()
+	started := s.started
+	s.rwmutex.RUnlock()
+	if started {
+		<-s.done
+	}
+}
@@ -0,0 +1,146 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package scheduler
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+func TestSchedulerBackoff(t *testing.T) {
+	tests := []struct {
+		name         string
+		retryBackoff time.Duration
+		attempts     int
+		want         time.Duration
+	}{
+		{"第一次重试", 0, 1, DefaultRetryBackoff},
+		{"第二次重试", 0, 2, 2 * DefaultRetryBackoff},
+		{"第四次重试", 10 * time.Second, 4, 80 * time.Second},
+		{"不超过一天之后再翻倍", time.Hour, 100, 32 * time.Hour},
+	}
+	for _, tt := range tests {
+		s := NewScheduler(nil)
+		s.Jitter = 0
+		s.RetryBackoff = tt.retryBackoff
+		if have := s.backoff(tt.attempts); have != tt.want {
+			t.Errorf("%s: have %s, want %s", tt.name, have, tt.want)
+		}
+	}
+
+	s := NewScheduler(nil)
+	s.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if have := s.backoff(2); have < 2*time.Minute || have > 3*time.Minute {
+			t.Errorf("Jitter 0.5: have %s, want [2m, 3m]", have)
+			return
+		}
+	}
+}
+
+func TestWrapError(t *testing.T) {
+	tests := []struct {
+		name          string
+		err           error
+		wantPermanent bool
+	}{
+		{"用户拒收", &mp.Error{ErrCode: mp.ErrCodeUserRefuseToAcceptMsg}, true},
+		{"超过回复时间", &mp.Error{ErrCode: mp.ErrCodeResponseTimeLimit}, true},
+		{"超过回复条数", &mp.Error{ErrCode: mp.ErrCodeResponseCountLimit}, true},
+		{"频率限制", &mp.Error{ErrCode: mp.ErrCodeAPIFreqLimit}, false},
+		{"网络错误", errors.New("connection reset"), false},
+	}
+	for _, tt := range tests {
+		if have := IsPermanent(wrapError(tt.err)); have != tt.wantPermanent {
+			t.Errorf("%s: have %v, want %v", tt.name, have, tt.wantPermanent)
+		}
+	}
+}
+
+func TestSchedulerRun(t *testing.T) {
+	errTemporary := errors.New("temporary")
+	tests := []struct {
+		name         string
+		execErr      error
+		attempts     int // 执行之前已经失败的次数
+		wantRemoved  bool
+		wantDone     bool
+		wantFail     bool
+		wantAttempts int
+	}{
+		{"成功", nil, 0, true, true, false, 0},
+		{"失败重试", errTemporary, 0, false, false, false, 1},
+		{"失败次数用完", errTemporary, DefaultMaxAttempts - 1, true, false, true, DefaultMaxAttempts},
+		{"不再重试的错误", Permanent(errTemporary), 0, true, false, true, 1},
+	}
+	for _, tt := range tests {
+		store := NewMemoryJobStore()
+		s := NewScheduler(store)
+		var done, failed bool
+		s.OnDone = func(job *Job) { done = true }
+		s.OnFail = func(job *Job, err error) { failed = true }
+		execErr := tt.execErr
+		s.Handle("test", func(job *Job) error { return execErr })
+
+		job, err := s.Schedule("test", "openid", map[string]string{"a": "b"}, time.Now().Add(-time.Second))
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		job.Attempts = tt.attempts
+		store.Save(job)
+
+		start := time.Now()
+		if n, err := s.RunOnce(); n != 1 || err != nil {
+			t.Errorf("%s: RunOnce() = %d, %v", tt.name, n, err)
+			continue
+		}
+		if done != tt.wantDone || failed != tt.wantFail {
+			t.Errorf("%s: OnDone %v, OnFail %v; want %v, %v", tt.name, done, failed, tt.wantDone, tt.wantFail)
+		}
+
+		jobs := store.Jobs()
+		if tt.wantRemoved {
+			if len(jobs) != 0 {
+				t.Errorf("%s: 任务没有删除", tt.name)
+			}
+			continue
+		}
+		if len(jobs) != 1 {
+			t.Errorf("%s: have %d jobs, want 1", tt.name, len(jobs))
+			continue
+		}
+		if jobs[0].Attempts != tt.wantAttempts || jobs[0].LastError != execErr.Error() {
+			t.Errorf("%s: have Attempts %d, LastError %q", tt.name, jobs[0].Attempts, jobs[0].LastError)
+		}
+		if jobs[0].RunAt.Before(start.Add(DefaultRetryBackoff)) {
+			t.Errorf("%s: 重试时间 %s 太早", tt.name, jobs[0].RunAt)
+		}
+	}
+}
+
+func TestSchedulerSchedule(t *testing.T) {
+	s := NewScheduler(nil)
+	s.Handle("test", func(job *Job) error { return nil })
+
+	tests := []struct {
+		name   string
+		kind   string
+		openid string
+	}{
+		{"空的 kind", "", "openid"},
+		{"空的 openid", "test", ""},
+		{"没有 Executor", "unknown", "openid"},
+	}
+	for _, tt := range tests {
+		if _, err := s.Schedule(tt.kind, tt.openid, nil, time.Now()); err == nil {
+			t.Errorf("%s: want error", tt.name)
+		}
+	}
+}
@@ -0,0 +1,227 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package scheduler
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+)
+
+// 定时任务
+type Job struct {
+	Id        string          `json:"id"`
+	Kind      string          `json:"kind"`    // 任务的类型, 决定由哪个 Executor 执行, 比如 KindCustomText
+	OpenId    string          `json:"openid"`  // 接收者
+	Payload   json.RawMessage `json:"payload"` // 任务的内容, 格式由 Kind 决定
+	RunAt     time.Time       `json:"run_at"`  // 下一次执行的时间
+	CreatedAt time.Time       `json:"created_at"`
+	Attempts  int             `json:"attempts"`             // 已经执行失败的次数
+	LastError string          `json:"last_error,omitempty"` // 最后一次失败的原因
+}
+
+// 保存定时任务的存储接口, 多进程环境可以用数据库, redis 等实现.
+type JobStore interface {
+	// 新增或者更新任务
+	Save(job *Job) error
+	// 删除任务, 任务不存在时不返回错误
+	Remove(id string) error
+	// 取出最多 limit 个 RunAt <= now 的任务, 同时把这些任务的 RunAt 改为 leaseUntil;
+	// 多进程共用一个存储时需要保证这个操作是原子的, 这样同一个任务不会被多个进程同时执行,
+	// 执行任务的进程退出以后, 任务在 leaseUntil 之后会被重新取出.
+	Acquire(now, leaseUntil time.Time, limit int) (jobs []*Job, err error)
+}
+
+var _ JobStore = (*MemoryJobStore)(nil)
+
+// JobStore 的简单实现, 用于单进程环境, 进程退出后任务会丢失.
+type MemoryJobStore struct {
+	mutex sync.Mutex
+	jobs  map[string]Job
+}
+
+func NewMemoryJobStore() *MemoryJobStore {
+	return &MemoryJobStore{
+		jobs: make(map[string]Job),
+	}
+}
+
+func (s *MemoryJobStore) Save(job *Job) error {
+	s.mutex.Lock()
+	s.jobs[job.Id] = *job
+	s.mutex.Unlock()
+	return nil
+}
+
+func (s *MemoryJobStore) Remove(id string) error {
+	s.mutex.Lock()
+	delete(s.jobs, id)
+	s.mutex.Unlock()
+	return nil
+}
+
+func (s *MemoryJobStore) Acquire(now, leaseUntil time.Time, limit int) (jobs []*Job, err error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.acquire(now, leaseUntil, limit), nil
+}
+
+func (s *MemoryJobStore) acquire(now, leaseUntil time.Time, limit int) (jobs []*Job) {
+	for _, job := range s.jobs {
+		if !job.RunAt.After(now) {
+			job := job
+			jobs = append(jobs, &job)
+		}
+	}
+	// 先到期的先执行
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].RunAt.Before(jobs[j].RunAt) })
+	if limit > 0 && len(jobs) > limit {
+		jobs = jobs[:limit]
+	}
+	for _, job := range jobs {
+		leased := *job
+		leased.RunAt = leaseUntil
+		s.jobs[job.Id] = leased
+	}
+	return
+}
+
+// 返回所有的任务, 按 RunAt 排序.
+func (s *MemoryJobStore) Jobs() (jobs []Job) {
+	s.mutex.Lock()
+	jobs = make([]Job, 0, len(s.jobs))
+	for _, job := range s.jobs {
+		jobs = append(jobs, job)
+	}
+	s.mutex.Unlock()
+
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].RunAt.Before(jobs[j].RunAt) })
+	return
+}
+
+var _ JobStore = (*FileJobStore)(nil)
+
+// 把任务保存到本地 JSON 文件的 JobStore, 用于单进程环境, 每次修改都会重写整个文件.
+type FileJobStore struct {
+	path   string
+	memory *MemoryJobStore
+}
+
+// 创建 FileJobStore, 文件存在时加载其中的任务.
+func NewFileJobStore(path string) (s *FileJobStore, err error) {
+	s = &FileJobStore{
+		path:   path,
+		memory: NewMemoryJobStore(),
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return s, nil
+		}
+		return nil, err
+	}
+	var jobs []Job
+	if err = json.Unmarshal(data, &jobs); err != nil {
+		return nil, err
+	}
+	for _, job := range jobs {
+		s.memory.jobs[job.Id] = job
+	}
+	return
+}
+
+func (s *FileJobStore) Save(job *Job) error {
+	s.memory.mutex.Lock()
+	defer s.memory.mutex.Unlock()
+
+	old, ok := s.memory.jobs[job.Id]
+	s.memory.jobs[job.Id] = *job
+	if err := s.flush(); err != nil {
+		if ok {
+			s.memory.jobs[job.Id] = old
+		} else {
+			delete(s.memory.jobs, job.Id)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *FileJobStore) Remove(id string) error {
+	s.memory.mutex.Lock()
+	defer s.memory.mutex.Unlock()
+
+	old, ok := s.memory.jobs[id]
+	if !ok {
+		return nil
+	}
+	delete(s.memory.jobs, id)
+	if err := s.flush(); err != nil {
+		s.memory.jobs[id] = old
+		return err
+	}
+	return nil
+}
+
+func (s *FileJobStore) Acquire(now, leaseUntil time.Time, limit int) (jobs []*Job, err error) {
+	s.memory.mutex.Lock()
+	defer s.memory.mutex.Unlock()
+
+	if jobs = s.memory.acquire(now, leaseUntil, limit); len(jobs) == 0 {
+		return
+	}
+	if err = s.flush(); err != nil {
+		for _, job := range jobs {
+			s.memory.jobs[job.Id] = *job // 恢复原来的 RunAt
+		}
+		return nil, err
+	}
+	return
+}
+
+// 返回所有的任务, 按 RunAt 排序.
+func (s *FileJobStore) Jobs() []Job {
+	return s.memory.Jobs()
+}
+
+// 先写临时文件再改名, 保证写到一半时进程退出也不会破坏原来的文件; 调用者需要持有锁.
+func (s *FileJobStore) flush() (err error) {
+	jobs := make([]Job, 0, len(s.memory.jobs))
+	for _, job := range s.memory.jobs {
+		jobs = append(jobs, job)
+	}
+	sort.Slice(jobs, func(i, j int) bool { return jobs[i].Id < jobs[j].Id })
+
+	data, err := json.Marshal(jobs)
+	if err != nil {
+		return
+	}
+
+	file, err := ioutil.TempFile(filepath.Dir(s.path), filepath.Base(s.path)+".tmp")
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			file.Close()
+			os.Remove(file.Name())
+		}
+	}()
+
+	if _, err = file.Write(data); err != nil {
+		return
+	}
+	if err = file.Close(); err != nil {
+		return
+	}
+	return os.Rename(file.Name(), s.path)
+}
@@ -0,0 +1,127 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package scheduler
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMemoryJobStoreAcquire(t *testing.T) {
+	now := time.Now()
+	leaseUntil := now.Add(time.Minute)
+
+	tests := []struct {
+		name    string
+		limit   int
+		wantIds []string
+	}{
+		{"不限制个数", 0, []string{"b", "a", "c"}},
+		{"先到期的先取出", 2, []string{"b", "a"}},
+	}
+	for _, tt := range tests {
+		store := NewMemoryJobStore()
+		store.Save(&Job{Id: "a", RunAt: now.Add(-time.Second)})
+		store.Save(&Job{Id: "b", RunAt: now.Add(-time.Minute)})
+		store.Save(&Job{Id: "c", RunAt: now})
+		store.Save(&Job{Id: "future", RunAt: now.Add(time.Hour)})
+
+		jobs, err := store.Acquire(now, leaseUntil, tt.limit)
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		if len(jobs) != len(tt.wantIds) {
+			t.Errorf("%s: have %d jobs, want %d", tt.name, len(jobs), len(tt.wantIds))
+			continue
+		}
+		for i, job := range jobs {
+			if job.Id != tt.wantIds[i] {
+				t.Errorf("%s: jobs[%d] have %s, want %s", tt.name, i, job.Id, tt.wantIds[i])
+			}
+		}
+
+		// 取出的任务在 leaseUntil 之前不会再被取出
+		again, _ := store.Acquire(now, leaseUntil, 0)
+		if len(again) != 3-len(jobs) {
+			t.Errorf("%s: 再次取出 %d 个, want %d", tt.name, len(again), 3-len(jobs))
+		}
+		expired, _ := store.Acquire(leaseUntil, leaseUntil.Add(time.Minute), 0)
+		if len(expired) != 3 {
+			t.Errorf("%s: 租约过期以后取出 %d 个, want 3", tt.name, len(expired))
+		}
+	}
+}
+
+func TestFileJobStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "scheduler")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "jobs.json")
+
+	now := time.Now().Round(time.Second)
+	store, err := NewFileJobStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store.Save(&Job{Id: "a", Kind: "test", OpenId: "openid", Payload: []byte(`{"a":1}`), RunAt: now})
+	store.Save(&Job{Id: "b", Kind: "test", RunAt: now.Add(time.Hour)})
+	store.Save(&Job{Id: "c", Kind: "test", RunAt: now})
+	store.Remove("c")
+	store.Remove("notfound")
+	if _, err = store.Acquire(now, now.Add(time.Minute), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// 重新打开以后还原所有的修改
+	store, err = NewFileJobStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		id      string
+		runAt   time.Time
+		payload string
+	}{
+		{"a", now.Add(time.Minute), `{"a":1}`},
+		{"b", now.Add(time.Hour), `null`},
+	}
+	jobs := store.Jobs()
+	if len(jobs) != len(tests) {
+		t.Errorf("have %d jobs, want %d", len(jobs), len(tests))
+		return
+	}
+	for i, tt := range tests {
+		job := jobs[i]
+		if job.Id != tt.id || !job.RunAt.Equal(tt.runAt) || string(job.Payload) != tt.payload {
+			t.Errorf("jobs[%d]: have %s %s %s, want %s %s %s", i, job.Id, job.RunAt, job.Payload, tt.id, tt.runAt, tt.payload)
+		}
+	}
+
+	files, _ := ioutil.ReadDir(dir)
+	if len(files) != 1 {
+		t.Errorf("临时文件没有删除, 目录里有 %d 个文件", len(files))
+	}
+}
+
+func TestNewFileJobStoreInvalidFile(t *testing.T) {
+	file, err := ioutil.TempFile("", "scheduler")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(file.Name())
+	file.WriteString("not json")
+	file.Close()
+
+	if _, err = NewFileJobStore(file.Name()); err == nil {
+		t.Error("want error")
+	}
+}
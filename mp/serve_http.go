@@ -96,9 +96,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, urlValues url.Values,
 			wechatToken := wechatServer.Token()
 
 			// 验证签名
-			msgSignature2 := util.MsgSign(wechatToken, timestampStr, nonce, requestHttpBody.EncryptedMsg)
-			if subtle.ConstantTimeCompare([]byte(msgSignature1), []byte(msgSignature2)) != 1 {
-				err = fmt.Errorf("check signature failed, input: %s, local: %s", msgSignature1, msgSignature2)
+			if err = util.CheckMsgSign(wechatToken, timestampStr, nonce, requestHttpBody.EncryptedMsg, msgSignature1); err != nil {
 				invalidRequestHandler.ServeInvalidRequest(w, r, err)
 				return
 			}
@@ -168,16 +166,8 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, urlValues url.Values,
 
 		case "", "raw": // 明文模式
 			// 首先验证签名
-			if len(signature1) != 40 {
-				err = fmt.Errorf("the length of signature mismatch, have: %d, want: 40", len(signature1))
-				invalidRequestHandler.ServeInvalidRequest(w, r, err)
-				return
-			}
-
 			WechatToken := wechatServer.Token()
-			signature2 := util.Sign(WechatToken, timestampStr, nonce)
-			if subtle.ConstantTimeCompare([]byte(signature1), []byte(signature2)) != 1 {
-				err = fmt.Errorf("check signature failed, input: %s, local: %s", signature1, signature2)
+			if err = util.CheckSign(WechatToken, timestampStr, nonce, signature1); err != nil {
 				invalidRequestHandler.ServeInvalidRequest(w, r, err)
 				return
 			}
@@ -195,18 +185,20 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, urlValues url.Values,
 				return
 			}
 
-			// 安全考虑验证 ToUserName
+			// 安全考虑验证 ToUserName, 实现了 ToUserNameCheckSkipper 的(比如测试号)可以不验证
 			haveToUserName := MixedMsg.ToUserName
-			wantToUserName := wechatServer.WechatId()
-			if len(haveToUserName) != len(wantToUserName) {
-				err = fmt.Errorf("the message's ToUserName mismatch, have: %s, want: %s", haveToUserName, wantToUserName)
-				invalidRequestHandler.ServeInvalidRequest(w, r, err)
-				return
-			}
-			if subtle.ConstantTimeCompare([]byte(haveToUserName), []byte(wantToUserName)) != 1 {
-				err = fmt.Errorf("the message's ToUserName mismatch, have: %s, want: %s", haveToUserName, wantToUserName)
-				invalidRequestHandler.ServeInvalidRequest(w, r, err)
-				return
+			if skipper, ok := wechatServer.(ToUserNameCheckSkipper); !ok || !skipper.SkipToUserNameCheck() {
+				wantToUserName := wechatServer.WechatId()
+				if len(haveToUserName) != len(wantToUserName) {
+					err = fmt.Errorf("the message's ToUserName mismatch, have: %s, want: %s", haveToUserName, wantToUserName)
+					invalidRequestHandler.ServeInvalidRequest(w, r, err)
+					return
+				}
+				if subtle.ConstantTimeCompare([]byte(haveToUserName), []byte(wantToUserName)) != 1 {
+					err = fmt.Errorf("the message's ToUserName mismatch, have: %s, want: %s", haveToUserName, wantToUserName)
+					invalidRequestHandler.ServeInvalidRequest(w, r, err)
+					return
+				}
 			}
 
 			// 成功, 交给 MessageHandler
@@ -240,15 +232,7 @@ func ServeHTTP(w http.ResponseWriter, r *http.Request, urlValues url.Values,
 			return
 		}
 
-		if len(signature1) != 40 {
-			err = fmt.Errorf("the length of signature mismatch, have: %d, want: 40", len(signature1))
-			invalidRequestHandler.ServeInvalidRequest(w, r, err)
-			return
-		}
-
-		signature2 := util.Sign(wechatServer.Token(), timestamp, nonce)
-		if subtle.ConstantTimeCompare([]byte(signature1), []byte(signature2)) != 1 {
-			err = fmt.Errorf("check signature failed, input: %s, local: %s", signature1, signature2)
+		if err = util.CheckSign(wechatServer.Token(), timestamp, nonce, signature1); err != nil {
 			invalidRequestHandler.ServeInvalidRequest(w, r, err)
 			return
 		}
@@ -22,6 +22,13 @@ type WechatServer interface {
 	MessageHandler() MessageHandler // 获取 MessageHandler
 }
 
+// WechatServer 可以选择实现的接口.
+//  ServeHTTP 在明文模式下会验证消息的 ToUserName 等于 WechatId(), 如果 WechatServer 实现了该接口
+//  并且 SkipToUserNameCheck 返回 true 则不验证, 比如测试号的原始ID不方便获取(见 SandboxWechatServer).
+type ToUserNameCheckSkipper interface {
+	SkipToUserNameCheck() bool
+}
+
 var _ WechatServer = (*DefaultWechatServer)(nil)
 
 type DefaultWechatServer struct {
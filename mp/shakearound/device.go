@@ -0,0 +1,269 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package shakearound
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 设备标识.
+//  DeviceId 和 UUID, Major, Minor 二者选其一, 若二者都填, 则以 DeviceId 为准.
+type DeviceIdentifier struct {
+	DeviceId int64  `json:"device_id,omitempty"` // 设备编号
+	UUID     string `json:"uuid,omitempty"`
+	Major    int    `json:"major,omitempty"`
+	Minor    int    `json:"minor,omitempty"`
+}
+
+// 申请设备ID返回的结果
+type DeviceApplyResult struct {
+	ApplyId           int64              `json:"apply_id"`           // 申请的批次ID, 可用在"查询设备列表"接口按批次查询本次申请成功的设备ID
+	AuditStatus       int                `json:"audit_status"`       // 审核状态. 0: 审核未通过, 1: 审核中, 2: 审核已通过
+	AuditComment      string             `json:"audit_comment"`      // 审核备注, 包括审核不通过的原因
+	DeviceIdentifiers []DeviceIdentifier `json:"device_identifiers"` // 指定的设备ID列表
+}
+
+// 申请设备ID.
+//  quantity:    申请的设备ID的数量, 单次新增设备超过500个, 需走人工审核流程
+//  applyReason: 申请理由, 不超过100个汉字或200个英文字母
+//  comment:     备注, 不超过15个汉字或30个英文字母, 可以为空
+//  poiId:       设备关联的门店ID, 关联门店后, 在门店1KM的范围内有优先摇出信息的机会, 可以为 0
+func (clt *Client) DeviceApplyId(quantity int, applyReason, comment string, poiId int64, opts ...mp.CallOption) (rst *DeviceApplyResult, err error) {
+	if quantity <= 0 {
+		err = errors.New("quantity should be greater than 0")
+		return
+	}
+
+	var request = struct {
+		Quantity    int    `json:"quantity"`
+		ApplyReason string `json:"apply_reason"`
+		Comment     string `json:"comment,omitempty"`
+		PoiId       int64  `json:"poi_id,omitempty"`
+	}{
+		Quantity:    quantity,
+		ApplyReason: applyReason,
+		Comment:     comment,
+		PoiId:       poiId,
+	}
+
+	var result struct {
+		mp.Error
+		DeviceApplyResult `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/shakearound/device/applyid?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rst = &result.DeviceApplyResult
+	return
+}
+
+// 编辑设备的备注信息.
+//  comment: 设备的备注信息, 不超过15个汉字或30个英文字母.
+func (clt *Client) DeviceUpdate(deviceIdentifier *DeviceIdentifier, comment string, opts ...mp.CallOption) (err error) {
+	if deviceIdentifier == nil {
+		return errors.New("nil DeviceIdentifier")
+	}
+
+	var request = struct {
+		DeviceIdentifier *DeviceIdentifier `json:"device_identifier,omitempty"`
+		Comment          string            `json:"comment"`
+	}{
+		DeviceIdentifier: deviceIdentifier,
+		Comment:          comment,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/shakearound/device/update?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 配置设备与门店的关联关系.
+//  poiId: 待关联的门店ID
+func (clt *Client) DeviceBindLocation(deviceIdentifier *DeviceIdentifier, poiId int64, opts ...mp.CallOption) (err error) {
+	if deviceIdentifier == nil {
+		return errors.New("nil DeviceIdentifier")
+	}
+
+	var request = struct {
+		DeviceIdentifier *DeviceIdentifier `json:"device_identifier,omitempty"`
+		PoiId            int64             `json:"poi_id"`
+	}{
+		DeviceIdentifier: deviceIdentifier,
+		PoiId:            poiId,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/shakearound/device/bindlocation?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 配置设备与页面的关联关系.
+//  pageIds:  待关联的页面列表
+//  bind:     关联操作标志位, true 为建立关联关系, false 为解除关联关系
+//  isAppend: 新增操作标志位, true 为新增关联关系, false 为覆盖原有的关联关系
+func (clt *Client) DeviceBindPage(deviceIdentifier *DeviceIdentifier, pageIds []int64, bind, isAppend bool, opts ...mp.CallOption) (err error) {
+	if deviceIdentifier == nil {
+		return errors.New("nil DeviceIdentifier")
+	}
+
+	var request = struct {
+		DeviceIdentifier *DeviceIdentifier `json:"device_identifier,omitempty"`
+		PageIds          []int64           `json:"page_ids"`
+		Bind             int               `json:"bind"`
+		Append           int               `json:"append"`
+	}{
+		DeviceIdentifier: deviceIdentifier,
+		PageIds:          pageIds,
+	}
+	if request.PageIds == nil {
+		request.PageIds = make([]int64, 0)
+	}
+	if bind {
+		request.Bind = 1
+	}
+	if isAppend {
+		request.Append = 1
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/shakearound/device/bindpage?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 设备信息
+type Device struct {
+	DeviceId int64  `json:"device_id"`
+	UUID     string `json:"uuid"`
+	Major    int    `json:"major"`
+	Minor    int    `json:"minor"`
+	Status   int    `json:"status"`   // 激活状态, 0: 未激活, 1: 已激活(但不活跃), 2: 活跃
+	PoiId    int64  `json:"poi_id"`   // 门店ID
+	Comment  string `json:"comment"`  // 设备的备注信息
+	PageIds  string `json:"page_ids"` // 与此设备关联的页面ID列表, 用逗号隔开
+}
+
+// 查询设备列表的结果
+type DeviceSearchResult struct {
+	Devices    []Device `json:"devices"`
+	TotalCount int      `json:"total_count"` // 商户名下的设备总量
+}
+
+// 根据设备标识查询设备.
+func (clt *Client) DeviceSearchByIdentifier(deviceIdentifiers []DeviceIdentifier, opts ...mp.CallOption) (rst *DeviceSearchResult, err error) {
+	if len(deviceIdentifiers) == 0 {
+		err = errors.New("empty deviceIdentifiers")
+		return
+	}
+
+	var request = struct {
+		Type              int                `json:"type"`
+		DeviceIdentifiers []DeviceIdentifier `json:"device_identifiers"`
+	}{
+		Type:              1,
+		DeviceIdentifiers: deviceIdentifiers,
+	}
+	return clt.deviceSearch(&request, opts...)
+}
+
+// 分页查询设备.
+//  lastSeen: 前一次查询列表末尾的设备ID, 第一次查询 lastSeen 为 0
+//  count:    待查询的设备数量, 不能超过50个
+func (clt *Client) DeviceSearch(lastSeen int64, count int, opts ...mp.CallOption) (rst *DeviceSearchResult, err error) {
+	if count <= 0 {
+		err = errors.New("count should be greater than 0")
+		return
+	}
+
+	var request = struct {
+		Type     int   `json:"type"`
+		LastSeen int64 `json:"last_seen"`
+		Count    int   `json:"count"`
+	}{
+		Type:     2,
+		LastSeen: lastSeen,
+		Count:    count,
+	}
+	return clt.deviceSearch(&request, opts...)
+}
+
+// 根据申请的批次ID分页查询设备.
+//  applyId:  批次ID, 申请设备ID时所返回的批次ID
+//  lastSeen: 前一次查询列表末尾的设备ID, 第一次查询 lastSeen 为 0
+//  count:    待查询的设备数量, 不能超过50个
+func (clt *Client) DeviceSearchByApplyId(applyId, lastSeen int64, count int, opts ...mp.CallOption) (rst *DeviceSearchResult, err error) {
+	if count <= 0 {
+		err = errors.New("count should be greater than 0")
+		return
+	}
+
+	var request = struct {
+		Type     int   `json:"type"`
+		ApplyId  int64 `json:"apply_id"`
+		LastSeen int64 `json:"last_seen"`
+		Count    int   `json:"count"`
+	}{
+		Type:     3,
+		ApplyId:  applyId,
+		LastSeen: lastSeen,
+		Count:    count,
+	}
+	return clt.deviceSearch(&request, opts...)
+}
+
+func (clt *Client) deviceSearch(request interface{}, opts ...mp.CallOption) (rst *DeviceSearchResult, err error) {
+	var result struct {
+		mp.Error
+		DeviceSearchResult `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/shakearound/device/search?access_token="
+	if err = clt.PostJSON(incompleteURL, request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rst = &result.DeviceSearchResult
+	return
+}
@@ -0,0 +1,47 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package shakearound
+
+import (
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	EventTypeUserShake = "ShakearoundUserShakes" // 摇一摇事件通知
+)
+
+// 摇到的设备信息
+type Beacon struct {
+	UUID     string  `xml:"Uuid"     json:"Uuid"`
+	Major    int     `xml:"Major"    json:"Major"`
+	Minor    int     `xml:"Minor"    json:"Minor"`
+	Distance float64 `xml:"Distance" json:"Distance"` // 设备与用户的距离（浮点数；单位：米）
+}
+
+// 用户进入摇一摇界面, 在"周边"页卡下摇一摇时, 微信会把这个事件推送到开发者填写的URL.
+type UserShakeEvent struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+	mp.CommonMessageHeader
+
+	Event         string   `xml:"Event"                                json:"Event"`                   // 事件, ShakearoundUserShakes
+	ChosenBeacon  Beacon   `xml:"ChosenBeacon"                         json:"ChosenBeacon"`            // 用户摇到的设备
+	AroundBeacons []Beacon `xml:"AroundBeacons>AroundBeacon,omitempty" json:"AroundBeacons,omitempty"` // 摇的过程中, 用户周边的设备; 可能为空
+}
+
+func GetUserShakeEvent(msg *mp.MixedMessage) *UserShakeEvent {
+	event := &UserShakeEvent{
+		CommonMessageHeader: msg.CommonMessageHeader,
+		Event:               msg.Event,
+		ChosenBeacon:        Beacon(msg.ChosenBeacon),
+	}
+	if n := len(msg.AroundBeacons); n > 0 {
+		event.AroundBeacons = make([]Beacon, n)
+		for i := 0; i < n; i++ {
+			event.AroundBeacons[i] = Beacon(msg.AroundBeacons[i])
+		}
+	}
+	return event
+}
@@ -0,0 +1,79 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package shakearound
+
+import (
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	MaterialTypeIcon    = "icon"    // 摇一摇页面展示的 icon 图
+	MaterialTypeLicense = "license" // 申请开通摇一摇周边功能时需上传的资质文件
+)
+
+// 上传在摇一摇页面展示的图片素材, 返回图片的 URL.
+//  materialType: 图片类型, MaterialTypeIcon 或 MaterialTypeLicense, 如果留空 "" 则默认为 MaterialTypeIcon;
+//  素材大小不超过200KB, 格式限定为 jpg,jpeg,png,gif; MaterialTypeIcon 的图片尺寸建议 120px*120px.
+func (clt *Client) MaterialAdd(materialType, _filepath string, opts ...mp.CallOption) (picURL string, err error) {
+	file, err := os.Open(_filepath)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	return clt.materialAddFromReader(materialType, filepath.Base(_filepath), file, opts...)
+}
+
+// 上传在摇一摇页面展示的图片素材, 返回图片的 URL.
+//  NOTE: 参数 filename 不是文件路径, 是指定 multipart/form-data 里面文件名称
+func (clt *Client) MaterialAddFromReader(materialType, filename string, reader io.Reader, opts ...mp.CallOption) (picURL string, err error) {
+	if filename == "" {
+		err = errors.New("empty filename")
+		return
+	}
+	if reader == nil {
+		err = errors.New("nil reader")
+		return
+	}
+	return clt.materialAddFromReader(materialType, filename, reader, opts...)
+}
+
+func (clt *Client) materialAddFromReader(materialType, filename string, reader io.Reader, opts ...mp.CallOption) (picURL string, err error) {
+	switch materialType {
+	case "":
+		materialType = MaterialTypeIcon
+	case MaterialTypeIcon, MaterialTypeLicense:
+	default:
+		err = errors.New("invalid materialType: " + materialType)
+		return
+	}
+
+	var result struct {
+		mp.Error
+		Data struct {
+			PicURL string `json:"pic_url"`
+		} `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/shakearound/material/add?type=" +
+		url.QueryEscape(materialType) + "&access_token="
+	if err = clt.UploadFromReader(incompleteURL, "media", filename, reader, "", nil, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	picURL = result.Data.PicURL
+	return
+}
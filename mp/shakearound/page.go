@@ -0,0 +1,165 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package shakearound
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 摇一摇出来的页面信息
+type Page struct {
+	PageId      int64  `json:"page_id,omitempty"` // 摇周边页面唯一ID, 新增页面时不需要填写
+	Title       string `json:"title"`             // 在摇一摇页面展示的主标题, 不超过6个字
+	Description string `json:"description"`       // 在摇一摇页面展示的副标题, 不超过7个字
+	PageURL     string `json:"page_url"`          // 跳转链接
+	Comment     string `json:"comment,omitempty"` // 页面的备注信息, 不超过15个字
+	IconURL     string `json:"icon_url"`          // 在摇一摇页面展示的图片, 图片需先上传至微信侧服务器, 用"素材管理-上传图片素材"接口上传图片, 返回的图片URL再配置在此处
+}
+
+// 新增页面, 返回页面ID.
+//  page.PageId 不需要填写.
+func (clt *Client) PageAdd(page *Page, opts ...mp.CallOption) (pageId int64, err error) {
+	if page == nil {
+		err = errors.New("nil Page")
+		return
+	}
+	page.PageId = 0
+
+	var result struct {
+		mp.Error
+		Data struct {
+			PageId int64 `json:"page_id"`
+		} `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/shakearound/page/add?access_token="
+	if err = clt.PostJSON(incompleteURL, page, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	pageId = result.Data.PageId
+	return
+}
+
+// 编辑页面信息.
+//  page.PageId 必须填写.
+func (clt *Client) PageUpdate(page *Page, opts ...mp.CallOption) (err error) {
+	if page == nil {
+		return errors.New("nil Page")
+	}
+	if page.PageId == 0 {
+		return errors.New("PageId should not be zero")
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/shakearound/page/update?access_token="
+	if err = clt.PostJSON(incompleteURL, page, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 查询页面列表的结果
+type PageSearchResult struct {
+	Pages      []Page `json:"pages"`
+	TotalCount int    `json:"total_count"` // 商户名下的页面总数
+}
+
+// 根据页面ID查询页面.
+func (clt *Client) PageSearchByIds(pageIds []int64, opts ...mp.CallOption) (rst *PageSearchResult, err error) {
+	if len(pageIds) == 0 {
+		err = errors.New("empty pageIds")
+		return
+	}
+
+	var request = struct {
+		Type    int     `json:"type"`
+		PageIds []int64 `json:"page_ids"`
+	}{
+		Type:    1,
+		PageIds: pageIds,
+	}
+	return clt.pageSearch(&request, opts...)
+}
+
+// 分页查询页面.
+//  begin: 页面列表的起始索引值
+//  count: 待查询的页面数量, 不能超过50个
+func (clt *Client) PageSearch(begin, count int, opts ...mp.CallOption) (rst *PageSearchResult, err error) {
+	if begin < 0 {
+		err = errors.New("begin should not be less than 0")
+		return
+	}
+	if count <= 0 {
+		err = errors.New("count should be greater than 0")
+		return
+	}
+
+	var request = struct {
+		Type  int `json:"type"`
+		Begin int `json:"begin"`
+		Count int `json:"count"`
+	}{
+		Type:  2,
+		Begin: begin,
+		Count: count,
+	}
+	return clt.pageSearch(&request, opts...)
+}
+
+func (clt *Client) pageSearch(request interface{}, opts ...mp.CallOption) (rst *PageSearchResult, err error) {
+	var result struct {
+		mp.Error
+		PageSearchResult `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/shakearound/page/search?access_token="
+	if err = clt.PostJSON(incompleteURL, request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rst = &result.PageSearchResult
+	return
+}
+
+// 删除页面.
+//  在删除页面之前, 需要先解除页面与设备的关联关系.
+func (clt *Client) PageDelete(pageId int64, opts ...mp.CallOption) (err error) {
+	var request = struct {
+		PageId int64 `json:"page_id"`
+	}{
+		PageId: pageId,
+	}
+
+	var result mp.Error
+
+	incompleteURL := "https://api.weixin.qq.com/shakearound/page/delete?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
@@ -0,0 +1,213 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package shakearound
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 摇周边的统计数据
+type StatisticsData struct {
+	FTime   int64 `json:"ftime"`    // 当天0点对应的时间戳
+	ClickPV int   `json:"click_pv"` // 打开摇周边页面的次数
+	ClickUV int   `json:"click_uv"` // 打开摇周边页面的人数
+	ShakePV int   `json:"shake_pv"` // 摇周边的次数
+	ShakeUV int   `json:"shake_uv"` // 摇周边的人数
+}
+
+// 以设备为维度的数据统计.
+//  查询单个设备进行摇周边操作的人数, 次数, 点击摇周边消息的人数, 次数; 查询的最长时间跨度为30天.
+//  beginDate, endDate: 起始日期和结束日期的时间戳, 最长时间跨度为30天
+func (clt *Client) StatisticsDevice(deviceIdentifier *DeviceIdentifier, beginDate, endDate int64, opts ...mp.CallOption) (data []StatisticsData, err error) {
+	if deviceIdentifier == nil {
+		err = errors.New("nil DeviceIdentifier")
+		return
+	}
+
+	var request = struct {
+		DeviceIdentifier *DeviceIdentifier `json:"device_identifier,omitempty"`
+		BeginDate        int64             `json:"begin_date"`
+		EndDate          int64             `json:"end_date"`
+	}{
+		DeviceIdentifier: deviceIdentifier,
+		BeginDate:        beginDate,
+		EndDate:          endDate,
+	}
+
+	var result struct {
+		mp.Error
+		Data []StatisticsData `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/shakearound/statistics/device?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	data = result.Data
+	return
+}
+
+// 以页面为维度的数据统计.
+//  查询单个页面通过摇周边摇出来的人数, 次数, 点击摇周边页面的人数, 次数; 查询的最长时间跨度为30天.
+//  beginDate, endDate: 起始日期和结束日期的时间戳, 最长时间跨度为30天
+func (clt *Client) StatisticsPage(pageId int64, beginDate, endDate int64, opts ...mp.CallOption) (data []StatisticsData, err error) {
+	var request = struct {
+		PageId    int64 `json:"page_id"`
+		BeginDate int64 `json:"begin_date"`
+		EndDate   int64 `json:"end_date"`
+	}{
+		PageId:    pageId,
+		BeginDate: beginDate,
+		EndDate:   endDate,
+	}
+
+	var result struct {
+		mp.Error
+		Data []StatisticsData `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/shakearound/statistics/page?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	data = result.Data
+	return
+}
+
+// 设备的统计数据
+type DeviceStatisticsData struct {
+	DeviceId int64  `json:"device_id"`
+	UUID     string `json:"uuid"`
+	Major    int    `json:"major"`
+	Minor    int    `json:"minor"`
+	StatisticsData
+}
+
+// 批量查询设备统计数据的结果
+type DeviceStatisticsListResult struct {
+	Devices    []DeviceStatisticsData
+	Date       int64 // 所查询的日期时间戳
+	TotalCount int   // 设备总数
+	PageIndex  int   // 所查询的结果页序号
+}
+
+// 批量查询设备统计数据.
+//  查询指定时间商家帐号下的每个设备进行摇周边操作的人数, 次数, 点击摇周边消息的人数, 次数.
+//  date:      指定查询日期时间戳, 单位为秒
+//  pageIndex: 指定查询的结果页序号, 返回结果按摇周边人数降序排序, 每50条记录为一页, 从1开始
+func (clt *Client) StatisticsDeviceList(date int64, pageIndex int, opts ...mp.CallOption) (rst *DeviceStatisticsListResult, err error) {
+	if pageIndex <= 0 {
+		err = errors.New("pageIndex should be greater than 0")
+		return
+	}
+
+	var request = struct {
+		Date      int64 `json:"date"`
+		PageIndex int   `json:"page_index"`
+	}{
+		Date:      date,
+		PageIndex: pageIndex,
+	}
+
+	var result struct {
+		mp.Error
+		Data struct {
+			Devices []DeviceStatisticsData `json:"devices"`
+		} `json:"data"`
+		Date       int64 `json:"date"`
+		TotalCount int   `json:"total_count"`
+		PageIndex  int   `json:"page_index"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/shakearound/statistics/devicelist?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rst = &DeviceStatisticsListResult{
+		Devices:    result.Data.Devices,
+		Date:       result.Date,
+		TotalCount: result.TotalCount,
+		PageIndex:  result.PageIndex,
+	}
+	return
+}
+
+// 页面的统计数据
+type PageStatisticsData struct {
+	PageId int64 `json:"page_id"`
+	StatisticsData
+}
+
+// 批量查询页面统计数据的结果
+type PageStatisticsListResult struct {
+	Pages      []PageStatisticsData
+	Date       int64 // 所查询的日期时间戳
+	TotalCount int   // 页面总数
+	PageIndex  int   // 所查询的结果页序号
+}
+
+// 批量查询页面统计数据.
+//  查询指定时间商家帐号下的每个页面进行摇周边操作的人数, 次数, 点击摇周边消息的人数, 次数.
+//  date:      指定查询日期时间戳, 单位为秒
+//  pageIndex: 指定查询的结果页序号, 返回结果按摇周边人数降序排序, 每50条记录为一页, 从1开始
+func (clt *Client) StatisticsPageList(date int64, pageIndex int, opts ...mp.CallOption) (rst *PageStatisticsListResult, err error) {
+	if pageIndex <= 0 {
+		err = errors.New("pageIndex should be greater than 0")
+		return
+	}
+
+	var request = struct {
+		Date      int64 `json:"date"`
+		PageIndex int   `json:"page_index"`
+	}{
+		Date:      date,
+		PageIndex: pageIndex,
+	}
+
+	var result struct {
+		mp.Error
+		Data struct {
+			Pages []PageStatisticsData `json:"pages"`
+		} `json:"data"`
+		Date       int64 `json:"date"`
+		TotalCount int   `json:"total_count"`
+		PageIndex  int   `json:"page_index"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/shakearound/statistics/pagelist?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rst = &PageStatisticsListResult{
+		Pages:      result.Data.Pages,
+		Date:       result.Date,
+		TotalCount: result.TotalCount,
+		PageIndex:  result.PageIndex,
+	}
+	return
+}
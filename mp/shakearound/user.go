@@ -0,0 +1,65 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package shakearound
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 摇一摇的设备信息
+type BeaconInfo struct {
+	Distance float64 `json:"distance"` // Beacon 信号与手机的距离, 单位为米
+	UUID     string  `json:"uuid"`
+	Major    int     `json:"major"`
+	Minor    int     `json:"minor"`
+}
+
+// 摇周边用户信息
+type ShakeInfo struct {
+	PageId     int64      `json:"page_id"`     // 摇周边页面唯一ID
+	BeaconInfo BeaconInfo `json:"beacon_info"` // 设备信息
+	OpenId     string     `json:"openid"`      // 商户AppID下用户的唯一标识
+	PoiId      int64      `json:"poi_id"`      // 门店ID, 有的话则返回, 反之不会在JSON格式内
+}
+
+// 获取设备信息, 包括UUID, major, minor, 以及距离, openID等信息.
+//  ticket:  摇周边业务的ticket, 可在摇到的URL中得到, ticket生效时间为30分钟, 每一次摇都会重新生成新的ticket
+//  needPoi: 是否需要返回门店 poi_id
+func (clt *Client) UserGetShakeInfo(ticket string, needPoi bool, opts ...mp.CallOption) (info *ShakeInfo, err error) {
+	if ticket == "" {
+		err = errors.New("empty ticket")
+		return
+	}
+
+	var request = struct {
+		Ticket  string `json:"ticket"`
+		NeedPoi int    `json:"need_poi,omitempty"`
+	}{
+		Ticket: ticket,
+	}
+	if needPoi {
+		request.NeedPoi = 1
+	}
+
+	var result struct {
+		mp.Error
+		ShakeInfo `json:"data"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/shakearound/user/getshakeinfo?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	info = &result.ShakeInfo
+	return
+}
@@ -0,0 +1,72 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"net/url"
+
+	"github.com/chanxuehong/wechat/util"
+)
+
+// 下面的函数用于在其他 http 框架(gin, echo, fasthttp 等)里验证微信服务器的回调, 不需要使用 ServeHTTP;
+// query 为回调 URL 的参数, 比如 gin 的 c.Request.URL.Query(), fasthttp 可以用 url.ParseQuery 转换.
+
+// 验证首次接入(GET)的请求, 成功时返回需要原样写回的 echostr.
+func VerifyURL(token string, query url.Values) (echostr string, err error) {
+	signature, timestamp, nonce, echostr, err := parseGetURLQuery(query)
+	if err != nil {
+		return
+	}
+	if err = util.CheckSign(token, timestamp, nonce, signature); err != nil {
+		return "", err
+	}
+	return
+}
+
+// 验证明文模式(POST)的请求签名.
+func VerifySignature(token string, query url.Values) (err error) {
+	signature, timestamp, nonce, _, _, err := parsePostURLQuery(query)
+	if err != nil {
+		return
+	}
+	return util.CheckSign(token, timestamp, nonce, signature)
+}
+
+// 验证安全模式(POST, encrypt_type=aes)的请求签名并解密, 返回消息的明文 XML 和回复时加密需要的 random.
+//  body 为请求的 http body; aesKeys 依次尝试, 一般为当前的 AESKey 和上一次的 AESKey(更换 EncodingAESKey 期间).
+func DecryptRequestBody(token, appId string, query url.Values, body []byte, aesKeys ...[32]byte) (rawMsgXML, random []byte, err error) {
+	if len(aesKeys) == 0 {
+		err = errors.New("no AESKey")
+		return
+	}
+	_, timestamp, nonce, _, msgSignature, err := parsePostURLQuery(query)
+	if err != nil {
+		return
+	}
+
+	var requestHttpBody RequestHttpBody
+	if err = xml.NewDecoder(bytes.NewReader(body)).Decode(&requestHttpBody); err != nil {
+		return
+	}
+	if err = util.CheckMsgSign(token, timestamp, nonce, requestHttpBody.EncryptedMsg, msgSignature); err != nil {
+		return
+	}
+
+	encryptedMsg, err := base64.StdEncoding.DecodeString(requestHttpBody.EncryptedMsg)
+	if err != nil {
+		return
+	}
+	for _, aesKey := range aesKeys {
+		if random, rawMsgXML, err = util.AESDecryptMsg(encryptedMsg, appId, aesKey); err == nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,173 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// 文本里 emoji 的处理方式.
+type EmojiMode int
+
+const (
+	EmojiKeep    EmojiMode = iota // 保留 emoji
+	EmojiStrip                    // 删除 emoji
+	EmojiReplace                  // 把 emoji 替换成 TextSanitizer.EmojiPlaceholder
+)
+
+// 长度的计算方式.
+type LengthUnit int
+
+const (
+	LengthBytes LengthUnit = iota // 按 UTF-8 字节数计算, 比如客服消息的文本
+	LengthRunes                   // 按字符数计算, 比如模板消息的字段
+)
+
+var ErrTextTooLong = errors.New("text too long")
+
+// 发送文本消息前的清理和编码, 避免微信服务器乱码或者拒绝.
+//  处理的顺序:
+//  1. 把 Java/JavaScript 等按 UTF-16 代理对编码出来的 emoji(6 字节的 CESU-8 编码)合并成正常的 UTF-8, 孤立的代理项删除;
+//  2. 非法的 UTF-8 替换成 U+FFFD;
+//  3. 删除除 \n, \t 以外的控制字符和零宽字符(U+200B 等, 保留 emoji 组合需要的 U+200D);
+//  4. 按 Emoji 处理 emoji;
+//  5. 按 Unit 检查长度, 超过 MaxLength 时 Truncate 为 true 则在字符边界截断, 否则返回 ErrTextTooLong.
+type TextSanitizer struct {
+	Emoji            EmojiMode
+	EmojiPlaceholder string // Emoji == EmojiReplace 时的替换文本, 比如 "[表情]"
+
+	Unit      LengthUnit
+	MaxLength int  // 0 表示不限制长度
+	Truncate  bool // 超长时截断, 否则返回错误
+}
+
+// 客服消息文本的默认设置, 微信限制为 2048 字节.
+func NewCustomTextSanitizer() *TextSanitizer {
+	return &TextSanitizer{
+		Unit:      LengthBytes,
+		MaxLength: 2048,
+	}
+}
+
+// 清理文本, 见 TextSanitizer 的说明.
+func (s *TextSanitizer) Sanitize(text string) (string, error) {
+	text = fixSurrogates(text)
+
+	var buf strings.Builder
+	buf.Grow(len(text))
+	for _, r := range text {
+		switch {
+		case r == '\n', r == '\t':
+		case r == '\r':
+			continue
+		case unicode.IsControl(r):
+			continue
+		case r == '\u200b', r == '\u200c', r == '\u2060', r == '\ufeff':
+			continue
+		case isEmoji(r):
+			switch s.Emoji {
+			case EmojiStrip:
+				continue
+			case EmojiReplace:
+				buf.WriteString(s.EmojiPlaceholder)
+				continue
+			}
+		case s.Emoji != EmojiKeep && (r == '\u200d' || r == '\ufe0f' || (r >= 0x1f3fb && r <= 0x1f3ff)):
+			continue // emoji 的组合字符, emoji 已经删除或者替换
+		}
+		buf.WriteRune(r) // 非法的 UTF-8 在 range 时已经是 utf8.RuneError
+	}
+	text = buf.String()
+
+	if s.MaxLength <= 0 || s.length(text) <= s.MaxLength {
+		return text, nil
+	}
+	if !s.Truncate {
+		return "", fmt.Errorf("%v: %d %s, max %d", ErrTextTooLong, s.length(text), s.unitName(), s.MaxLength)
+	}
+	return s.truncate(text), nil
+}
+
+func (s *TextSanitizer) length(text string) int {
+	if s.Unit == LengthRunes {
+		return utf8.RuneCountInString(text)
+	}
+	return len(text)
+}
+
+func (s *TextSanitizer) unitName() string {
+	if s.Unit == LengthRunes {
+		return "runes"
+	}
+	return "bytes"
+}
+
+func (s *TextSanitizer) truncate(text string) string {
+	if s.Unit == LengthRunes {
+		n := 0
+		for i := range text {
+			if n == s.MaxLength {
+				return text[:i]
+			}
+			n++
+		}
+		return text
+	}
+	i := s.MaxLength
+	for i > 0 && !utf8.RuneStart(text[i]) {
+		i--
+	}
+	return text[:i]
+}
+
+// 合并 CESU-8 编码的 UTF-16 代理对, 删除孤立的代理项.
+func fixSurrogates(text string) string {
+	if !strings.Contains(text, "\xed") {
+		return text
+	}
+	var buf strings.Builder
+	buf.Grow(len(text))
+	for i := 0; i < len(text); {
+		if r1, ok := decodeSurrogate(text[i:]); ok {
+			if r2, ok := decodeSurrogate(text[i+3:]); ok && utf16.IsSurrogate(r1) && r1 < 0xdc00 && r2 >= 0xdc00 {
+				buf.WriteRune(utf16.DecodeRune(r1, r2))
+				i += 6
+				continue
+			}
+			i += 3 // 孤立的代理项
+			continue
+		}
+		buf.WriteByte(text[i])
+		i++
+	}
+	return buf.String()
+}
+
+// 按 UTF-8 的规则解码 3 字节的代理项(U+D800 - U+DFFF), 这在标准的 UTF-8 里是非法的.
+func decodeSurrogate(s string) (rune, bool) {
+	if len(s) < 3 || s[0] != 0xed || s[1] < 0xa0 || s[1] > 0xbf || s[2]&0xc0 != 0x80 {
+		return 0, false
+	}
+	return rune(s[0]&0x0f)<<12 | rune(s[1]&0x3f)<<6 | rune(s[2]&0x3f), true
+}
+
+// 常见的 emoji 区段.
+func isEmoji(r rune) bool {
+	switch {
+	case r >= 0x1f000 && r <= 0x1faff: // 麻将, 扑克, 地区指示符(国旗), 各种符号和象形文字, 表情, 交通和地图符号等
+		return !(r >= 0x1f3fb && r <= 0x1f3ff) // 肤色修饰符单独处理
+	case r >= 0x2600 && r <= 0x27bf: // 杂项符号, 装饰符号
+		return true
+	case r >= 0x2b00 && r <= 0x2bff: // 杂项符号和箭头, 比如 ⭐
+		return true
+	}
+	return false
+}
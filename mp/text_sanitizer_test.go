@@ -0,0 +1,64 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTextSanitizer(t *testing.T) {
+	tests := []struct {
+		name      string
+		sanitizer TextSanitizer
+		text      string
+		want      string
+		wantErr   error
+	}{
+		{"普通文本", TextSanitizer{}, "你好, wechat", "你好, wechat", nil},
+		{"控制字符", TextSanitizer{}, "a\x00b\rc\n\td\x7f", "abc\n\td", nil},
+		{"零宽字符", TextSanitizer{}, "a\u200bb\u200cc\u2060d\ufeff", "abcd", nil},
+		{"非法的 UTF-8", TextSanitizer{}, "a\xffb", "a\ufffdb", nil},
+		{"CESU-8 编码的 emoji", TextSanitizer{}, "a\xed\xa0\xbd\xed\xb8\x80b", "a\U0001f600b", nil},
+		{"孤立的代理项", TextSanitizer{}, "a\xed\xa0\xbdb\xed\xb8\x80c", "abc", nil},
+		{"保留 emoji", TextSanitizer{}, "ok👍🏻⭐", "ok👍🏻⭐", nil},
+		{"删除 emoji", TextSanitizer{Emoji: EmojiStrip}, "hi😀👍🏻❤\ufe0f!", "hi!", nil},
+		{"删除 emoji 组合", TextSanitizer{Emoji: EmojiStrip}, "家👨\u200d👩\u200d👧人", "家人", nil},
+		{"替换 emoji", TextSanitizer{Emoji: EmojiReplace, EmojiPlaceholder: "[表情]"}, "好😀", "好[表情]", nil},
+		{"按字节不超长", TextSanitizer{MaxLength: 6}, "你好", "你好", nil},
+		{"按字节超长", TextSanitizer{MaxLength: 5}, "你好", "", ErrTextTooLong},
+		{"按字节截断", TextSanitizer{MaxLength: 5, Truncate: true}, "你好", "你", nil},
+		{"按字符截断", TextSanitizer{Unit: LengthRunes, MaxLength: 2, Truncate: true}, "你好吗", "你好", nil},
+		{"按字符超长", TextSanitizer{Unit: LengthRunes, MaxLength: 2}, "你好吗", "", ErrTextTooLong},
+		{"先清理再计算长度", TextSanitizer{MaxLength: 3}, "a\u200bbc\x00", "abc", nil},
+	}
+	for _, tt := range tests {
+		have, err := tt.sanitizer.Sanitize(tt.text)
+		if tt.wantErr != nil {
+			if err == nil || !strings.HasPrefix(err.Error(), tt.wantErr.Error()) {
+				t.Errorf("%s: have error %v, want %v", tt.name, err, tt.wantErr)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		if have != tt.want {
+			t.Errorf("%s: Sanitize(%q):\nhave %q\nwant %q", tt.name, tt.text, have, tt.want)
+		}
+	}
+}
+
+func TestNewCustomTextSanitizer(t *testing.T) {
+	s := NewCustomTextSanitizer()
+	if _, err := s.Sanitize(strings.Repeat("a", 2048)); err != nil {
+		t.Errorf("2048 字节: %s", err)
+	}
+	if _, err := s.Sanitize(strings.Repeat("a", 2049)); err == nil {
+		t.Error("2049 字节: want error")
+	}
+}
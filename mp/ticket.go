@@ -0,0 +1,50 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+)
+
+const (
+	TicketTypeJSAPI  = "jsapi"   // JS-SDK 的 jsapi_ticket
+	TicketTypeWxCard = "wx_card" // 卡券的 api_ticket
+)
+
+// 获取 type 为 ticketType 的临时票据, 比如 TicketTypeJSAPI, TicketTypeWxCard; 微信以后增加的类型也可以直接使用.
+//  一般不需要直接调用, 请使用 DefaultTicketServer 或者其他中控服务器;
+//  expiresIn 为微信服务器返回的有效时间, 单位为秒.
+func (clt *WechatClient) GetTicket(ticketType string, opts ...CallOption) (ticket string, expiresIn int64, err error) {
+	if ticketType == "" {
+		err = errors.New("empty ticketType")
+		return
+	}
+
+	var result struct {
+		Error
+		Ticket    string `json:"ticket"`
+		ExpiresIn int64  `json:"expires_in"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/ticket/getticket?type=" + url.QueryEscape(ticketType) + "&access_token="
+	if err = clt.GetJSON(incompleteURL, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	if result.ExpiresIn <= 0 {
+		err = errors.New("invalid expires_in: " + strconv.FormatInt(result.ExpiresIn, 10))
+		return
+	}
+	ticket = result.Ticket
+	expiresIn = result.ExpiresIn
+	return
+}
@@ -0,0 +1,121 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"sync"
+	"time"
+)
+
+// access_token 刷新的统计信息
+type TokenMetrics struct {
+	LastRefreshTime     time.Time     // 最后一次成功从微信服务器获取 access_token 的时间, 零值表示还没有成功过
+	SinceLastRefresh    time.Duration // 距离最后一次成功获取 access_token 的时间
+	ExpiresAt           time.Time     // 当前 access_token 在微信服务器上的过期时间(没有扣除缓冲时间)
+	ExpiryMargin        time.Duration // 距离 ExpiresAt 的时间, 小于 0 表示已经过期
+	ConsecutiveFailures int           // 最后一次成功之后连续失败的次数
+	LastError           error         // 最后一次失败的错误, 成功之后清空
+}
+
+// access_token 在即将过期时还没有成功刷新的回调函数, 一般用于报警.
+type TokenStaleFunc func(metrics TokenMetrics)
+
+type tokenMetrics struct {
+	mutex sync.Mutex
+
+	lastRefreshTime     time.Time
+	expiresAt           time.Time
+	consecutiveFailures int
+	lastError           error
+
+	staleThreshold time.Duration
+	onStale        TokenStaleFunc
+	staleTimer     *time.Timer
+}
+
+func (m *tokenMetrics) refreshed(refreshTime time.Time, expiresIn int64) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.lastRefreshTime = refreshTime
+	m.expiresAt = refreshTime.Add(time.Duration(expiresIn) * time.Second)
+	m.consecutiveFailures = 0
+	m.lastError = nil
+	m.resetStaleTimer()
+}
+
+func (m *tokenMetrics) failed(err error) {
+	m.mutex.Lock()
+	m.consecutiveFailures++
+	m.lastError = err
+	m.mutex.Unlock()
+}
+
+func (m *tokenMetrics) snapshot() TokenMetrics {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+	return m.snapshotLocked(time.Now())
+}
+
+func (m *tokenMetrics) snapshotLocked(now time.Time) (metrics TokenMetrics) {
+	metrics = TokenMetrics{
+		LastRefreshTime:     m.lastRefreshTime,
+		ExpiresAt:           m.expiresAt,
+		ConsecutiveFailures: m.consecutiveFailures,
+		LastError:           m.lastError,
+	}
+	if !m.lastRefreshTime.IsZero() {
+		metrics.SinceLastRefresh = now.Sub(m.lastRefreshTime)
+		metrics.ExpiryMargin = m.expiresAt.Sub(now)
+	}
+	return
+}
+
+func (m *tokenMetrics) setOnStale(threshold time.Duration, fn TokenStaleFunc) {
+	m.mutex.Lock()
+	defer m.mutex.Unlock()
+
+	m.staleThreshold = threshold
+	m.onStale = fn
+	m.resetStaleTimer()
+}
+
+// 在 expiresAt - staleThreshold 的时候检查, 如果这期间没有成功刷新则回调 onStale.
+// 每次成功刷新都会重新设置定时器, 所以定时器触发就表示没有成功刷新.
+func (m *tokenMetrics) resetStaleTimer() {
+	if m.staleTimer != nil {
+		m.staleTimer.Stop()
+		m.staleTimer = nil
+	}
+	if m.onStale == nil || m.lastRefreshTime.IsZero() {
+		return
+	}
+
+	lastRefreshTime := m.lastRefreshTime
+	m.staleTimer = time.AfterFunc(m.expiresAt.Add(-m.staleThreshold).Sub(time.Now()), func() {
+		m.mutex.Lock()
+		if !m.lastRefreshTime.Equal(lastRefreshTime) || m.onStale == nil {
+			m.mutex.Unlock()
+			return
+		}
+		onStale := m.onStale
+		metrics := m.snapshotLocked(time.Now())
+		m.mutex.Unlock()
+
+		onStale(metrics)
+	})
+}
+
+// 返回 access_token 刷新的统计信息.
+func (srv *DefaultTokenServer) Metrics() TokenMetrics {
+	return srv.metrics.snapshot()
+}
+
+// 设置 access_token 过期前 threshold 时间内还没有成功刷新的回调函数, fn 为 nil 表示取消.
+//  NOTE: fn 在单独的 goroutine 里调用, 每个 access_token 至多回调一次.
+func (srv *DefaultTokenServer) OnTokenStale(threshold time.Duration, fn TokenStaleFunc) {
+	srv.metrics.setOnStale(threshold, fn)
+}
@@ -59,6 +59,8 @@ type DefaultTokenServer struct {
 		sync.RWMutex
 		Token string
 	}
+
+	metrics tokenMetrics
 }
 
 // 创建一个新的 DefaultTokenServer.
@@ -153,32 +155,17 @@ NEW_TICK_DURATION:
 	}
 }
 
-type tokenInfo struct {
-	Token     string `json:"access_token"`
-	ExpiresIn int64  `json:"expires_in"` // 有效时间, seconds
-}
-
-// 从微信服务器获取 access_token.
-func (srv *DefaultTokenServer) getToken() (token tokenInfo, cached bool, err error) {
-	srv.tokenGet.Lock()
-	defer srv.tokenGet.Unlock()
-
-	timeNowUnix := time.Now().Unix()
-
-	// 在收敛周期内直接返回最近一次获取的 access_token,
-	// 这里的收敛时间设定为2秒, 因为在同一个进程内, 收敛周期为2个http周期
-	if n := srv.tokenGet.LastTimestamp; timeNowUnix >= n && timeNowUnix < n+2 {
-		token = tokenInfo{
-			Token:     srv.tokenGet.LastTokenInfo.Token,
-			ExpiresIn: srv.tokenGet.LastTokenInfo.ExpiresIn + n - timeNowUnix,
-		}
-		cached = true
-		return
+// 从微信服务器获取 access_token, expiresIn 为微信返回的有效时间(秒), 没有扣除网络延时的缓冲.
+//  DefaultTokenServer, tokenservice.Service 等 TokenServer 的实现都用它获取 access_token;
+//  如果 httpClient == nil 则默认用 http.DefaultClient.
+func FetchToken(appId, appSecret string, httpClient *http.Client) (token string, expiresIn int64, err error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
 	}
 
 	_url := "https://api.weixin.qq.com/cgi-bin/token?grant_type=client_credential&appid=" +
-		url.QueryEscape(srv.appId) + "&secret=" + url.QueryEscape(srv.appSecret)
-	httpResp, err := srv.httpClient.Get(_url)
+		url.QueryEscape(appId) + "&secret=" + url.QueryEscape(appSecret)
+	httpResp, err := httpClient.Get(_url)
 	if err != nil {
 		return
 	}
@@ -193,13 +180,12 @@ func (srv *DefaultTokenServer) getToken() (token tokenInfo, cached bool, err err
 		Error
 		tokenInfo
 	}
-
 	respBody, err := ioutil.ReadAll(httpResp.Body)
 	if err != nil {
 		return
 	}
 
-	debugPrefix := "mp.DefaultTokenServer.getToken"
+	debugPrefix := "mp.FetchToken"
 	if _, file, line, ok := runtime.Caller(1); ok {
 		debugPrefix += fmt.Sprintf("(called at %s:%d)", file, line)
 	}
@@ -214,25 +200,63 @@ func (srv *DefaultTokenServer) getToken() (token tokenInfo, cached bool, err err
 		err = &result.Error
 		return
 	}
+	if result.ExpiresIn <= 0 {
+		err = errors.New("invalid expires_in: " + strconv.FormatInt(result.ExpiresIn, 10))
+		return
+	}
+	return result.Token, result.ExpiresIn, nil
+}
+
+type tokenInfo struct {
+	Token     string `json:"access_token"`
+	ExpiresIn int64  `json:"expires_in"` // 有效时间, seconds
+}
+
+// 从微信服务器获取 access_token.
+func (srv *DefaultTokenServer) getToken() (token tokenInfo, cached bool, err error) {
+	srv.tokenGet.Lock()
+	defer srv.tokenGet.Unlock()
+
+	timeNowUnix := time.Now().Unix()
+
+	// 在收敛周期内直接返回最近一次获取的 access_token,
+	// 这里的收敛时间设定为2秒, 因为在同一个进程内, 收敛周期为2个http周期
+	if n := srv.tokenGet.LastTimestamp; timeNowUnix >= n && timeNowUnix < n+2 {
+		token = tokenInfo{
+			Token:     srv.tokenGet.LastTokenInfo.Token,
+			ExpiresIn: srv.tokenGet.LastTokenInfo.ExpiresIn + n - timeNowUnix,
+		}
+		cached = true
+		return
+	}
+
+	defer func() {
+		if err != nil {
+			srv.metrics.failed(err)
+		}
+	}()
+
+	token.Token, token.ExpiresIn, err = FetchToken(srv.appId, srv.appSecret, srv.httpClient)
+	if err != nil {
+		return
+	}
+
+	expiresIn := token.ExpiresIn
 
 	// 由于网络的延时, access_token 过期时间留了一个缓冲区
 	switch {
-	case result.ExpiresIn > 60*60:
-		result.ExpiresIn -= 60 * 10
-	case result.ExpiresIn > 60*30:
-		result.ExpiresIn -= 60 * 5
-	case result.ExpiresIn > 60*5:
-		result.ExpiresIn -= 60
-	case result.ExpiresIn > 60:
-		result.ExpiresIn -= 10
-	case result.ExpiresIn > 0:
-	default:
-		err = errors.New("invalid expires_in: " + strconv.FormatInt(result.ExpiresIn, 10))
-		return
+	case token.ExpiresIn > 60*60:
+		token.ExpiresIn -= 60 * 10
+	case token.ExpiresIn > 60*30:
+		token.ExpiresIn -= 60 * 5
+	case token.ExpiresIn > 60*5:
+		token.ExpiresIn -= 60
+	case token.ExpiresIn > 60:
+		token.ExpiresIn -= 10
 	}
 
-	srv.tokenGet.LastTokenInfo = result.tokenInfo
+	srv.tokenGet.LastTokenInfo = token
 	srv.tokenGet.LastTimestamp = timeNowUnix
-	token = result.tokenInfo
+	srv.metrics.refreshed(time.Now(), expiresIn)
 	return
 }
@@ -57,6 +57,8 @@ type DefaultTokenServer struct {
 		sync.RWMutex
 		Token string
 	}
+
+	metrics tokenMetrics
 }
 
 // 创建一个新的 DefaultTokenServer.
@@ -151,6 +153,46 @@ NEW_TICK_DURATION:
 	}
 }
 
+// 从微信服务器获取 access_token, expiresIn 为微信返回的有效时间(秒), 没有扣除网络延时的缓冲.
+//  DefaultTokenServer, tokenservice.Service 等 TokenServer 的实现都用它获取 access_token;
+//  如果 httpClient == nil 则默认用 http.DefaultClient.
+func FetchToken(appId, appSecret string, httpClient *http.Client) (token string, expiresIn int64, err error) {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	_url := "https://api.weixin.qq.com/cgi-bin/token?grant_type=client_credential&appid=" +
+		url.QueryEscape(appId) + "&secret=" + url.QueryEscape(appSecret)
+	httpResp, err := httpClient.Get(_url)
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
+	}
+
+	var result struct {
+		Error
+		tokenInfo
+	}
+	if err = json.NewDecoder(httpResp.Body).Decode(&result); err != nil {
+		return
+	}
+
+	if result.ErrCode != ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	if result.ExpiresIn <= 0 {
+		err = errors.New("invalid expires_in: " + strconv.FormatInt(result.ExpiresIn, 10))
+		return
+	}
+	return result.Token, result.ExpiresIn, nil
+}
+
 type tokenInfo struct {
 	Token     string `json:"access_token"`
 	ExpiresIn int64  `json:"expires_in"` // 有效时间, seconds
@@ -174,51 +216,33 @@ func (srv *DefaultTokenServer) getToken() (token tokenInfo, cached bool, err err
 		return
 	}
 
-	_url := "https://api.weixin.qq.com/cgi-bin/token?grant_type=client_credential&appid=" +
-		url.QueryEscape(srv.appId) + "&secret=" + url.QueryEscape(srv.appSecret)
-	httpResp, err := srv.httpClient.Get(_url)
-	if err != nil {
-		return
-	}
-	defer httpResp.Body.Close()
-
-	if httpResp.StatusCode != http.StatusOK {
-		err = fmt.Errorf("http.Status: %s", httpResp.Status)
-		return
-	}
-
-	var result struct {
-		Error
-		tokenInfo
-	}
+	defer func() {
+		if err != nil {
+			srv.metrics.failed(err)
+		}
+	}()
 
-	if err = json.NewDecoder(httpResp.Body).Decode(&result); err != nil {
+	token.Token, token.ExpiresIn, err = FetchToken(srv.appId, srv.appSecret, srv.httpClient)
+	if err != nil {
 		return
 	}
 
-	if result.ErrCode != ErrCodeOK {
-		err = &result.Error
-		return
-	}
+	expiresIn := token.ExpiresIn
 
 	// 由于网络的延时, access_token 过期时间留了一个缓冲区
 	switch {
-	case result.ExpiresIn > 60*60:
-		result.ExpiresIn -= 60 * 10
-	case result.ExpiresIn > 60*30:
-		result.ExpiresIn -= 60 * 5
-	case result.ExpiresIn > 60*5:
-		result.ExpiresIn -= 60
-	case result.ExpiresIn > 60:
-		result.ExpiresIn -= 10
-	case result.ExpiresIn > 0:
-	default:
-		err = errors.New("invalid expires_in: " + strconv.FormatInt(result.ExpiresIn, 10))
-		return
+	case token.ExpiresIn > 60*60:
+		token.ExpiresIn -= 60 * 10
+	case token.ExpiresIn > 60*30:
+		token.ExpiresIn -= 60 * 5
+	case token.ExpiresIn > 60*5:
+		token.ExpiresIn -= 60
+	case token.ExpiresIn > 60:
+		token.ExpiresIn -= 10
 	}
 
-	srv.tokenGet.LastTokenInfo = result.tokenInfo
+	srv.tokenGet.LastTokenInfo = token
 	srv.tokenGet.LastTimestamp = timeNowUnix
-	token = result.tokenInfo
+	srv.metrics.refreshed(time.Now(), expiresIn)
 	return
 }
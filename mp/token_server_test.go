@@ -0,0 +1,63 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestFetchToken(t *testing.T) {
+	tests := []struct {
+		name          string
+		status        int
+		body          string
+		wantToken     string
+		wantExpiresIn int64
+		wantErrCode   int
+		wantErr       bool
+	}{
+		{"成功", http.StatusOK, `{"access_token":"ACCESS_TOKEN","expires_in":7200}`, "ACCESS_TOKEN", 7200, 0, false},
+		{"AppSecret 错误", http.StatusOK, `{"errcode":40001,"errmsg":"invalid credential"}`, "", 0, ErrCodeInvalidCredential, true},
+		{"expires_in 无效", http.StatusOK, `{"access_token":"ACCESS_TOKEN","expires_in":0}`, "", 0, 0, true},
+		{"http 状态码错误", http.StatusBadGateway, ``, "", 0, 0, true},
+		{"不是 json", http.StatusOK, `<html></html>`, "", 0, 0, true},
+	}
+	for _, tt := range tests {
+		var query string
+		srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			query = r.URL.RawQuery
+			w.WriteHeader(tt.status)
+			w.Write([]byte(tt.body))
+		}))
+		transport, err := NewBaseURLTransport(srv.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		token, expiresIn, err := FetchToken("appid", "se&cret", &http.Client{Transport: transport})
+		srv.Close()
+		if query != "grant_type=client_credential&appid=appid&secret=se%26cret" {
+			t.Errorf("%s: 查询参数不对: %s", tt.name, query)
+		}
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: want error", tt.name)
+			} else if e, ok := err.(*Error); tt.wantErrCode != 0 && (!ok || e.ErrCode != tt.wantErrCode) {
+				t.Errorf("%s: have error %v, want errcode %d", tt.name, err, tt.wantErrCode)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		if token != tt.wantToken || expiresIn != tt.wantExpiresIn {
+			t.Errorf("%s: have %q, %d, want %q, %d", tt.name, token, expiresIn, tt.wantToken, tt.wantExpiresIn)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"sync"
+)
+
+var urlBufferPool = sync.Pool{
+	New: func() interface{} {
+		buf := make([]byte, 0, 512)
+		return &buf
+	},
+}
+
+// 返回 incompleteURL + url.QueryEscape(token), 结果和直接拼接一样.
+//  整个过程只有最终生成 string 时的一次内存分配, 用于每个请求都要调用的热路径:
+//  token 不需要转义时直接拼接, 否则在复用的 []byte 上转义拼接.
+func TokenURL(incompleteURL, token string) string {
+	if !shouldQueryEscape(token) {
+		return incompleteURL + token
+	}
+
+	bufPtr := urlBufferPool.Get().(*[]byte)
+	buf := append((*bufPtr)[:0], incompleteURL...)
+	buf = appendQueryEscape(buf, token)
+	finalURL := string(buf)
+
+	if cap(buf) <= 4<<10 { // 太大的不放回去
+		*bufPtr = buf
+		urlBufferPool.Put(bufPtr)
+	}
+	return finalURL
+}
+
+func shouldQueryEscape(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if !isQueryUnreserved(s[i]) {
+			return true
+		}
+	}
+	return false
+}
+
+func isQueryUnreserved(c byte) bool {
+	return 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9' ||
+		c == '-' || c == '_' || c == '.' || c == '~'
+}
+
+// 同 url.QueryEscape, 但是结果 append 到 dst 后面.
+func appendQueryEscape(dst []byte, s string) []byte {
+	const upperhex = "0123456789ABCDEF"
+
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case isQueryUnreserved(c):
+			dst = append(dst, c)
+		case c == ' ':
+			dst = append(dst, '+')
+		default:
+			dst = append(dst, '%', upperhex[c>>4], upperhex[c&15])
+		}
+	}
+	return dst
+}
@@ -0,0 +1,90 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package mp
+
+import (
+	"fmt"
+	"net/url"
+	"testing"
+)
+
+const (
+	testIncompleteURL = "https://api.weixin.qq.com/cgi-bin/user/info?openid=oLVPpjqs9BhvzwPj5A-vTYAX3GLc&lang=zh_CN&access_token="
+	testToken         = "ACCESS_TOKEN-ACCESS_TOKEN-ACCESS_TOKEN-ACCESS_TOKEN-ACCESS_TOKEN-ACCESS_TOKEN-ACCESS_TOKEN-ACCESS_TOKEN"
+	testEscapeToken   = "ACCESS*TOKEN/ACCESS*TOKEN/ACCESS*TOKEN/ACCESS*TOKEN/ACCESS*TOKEN/ACCESS*TOKEN/ACCESS*TOKEN/ACCESS*TOKEN"
+)
+
+func TestTokenURL(t *testing.T) {
+	tokens := []string{
+		"",
+		testToken,
+		testEscapeToken,
+		"a b+c/d?e=f&g*h~i.j",
+		"中文\x00\xff",
+	}
+	for _, token := range tokens {
+		have := TokenURL(testIncompleteURL, token)
+		want := testIncompleteURL + url.QueryEscape(token)
+		if have != want {
+			t.Errorf("TokenURL(%q):\nhave %s\nwant %s", token, have, want)
+		}
+	}
+}
+
+func TestTokenURLAllocs(t *testing.T) {
+	for _, token := range []string{testToken, testEscapeToken} {
+		allocs := testing.AllocsPerRun(100, func() {
+			TokenURL(testIncompleteURL, token)
+		})
+		if allocs > 1 {
+			t.Errorf("TokenURL(%q) allocs: have %v, want <= 1", token, allocs)
+		}
+	}
+}
+
+func BenchmarkTokenURL(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		TokenURL(testIncompleteURL, testToken)
+	}
+}
+
+func BenchmarkTokenURLConcat(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = testIncompleteURL + url.QueryEscape(testToken)
+	}
+}
+
+func BenchmarkTokenURLSprintf(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = fmt.Sprintf("%s%s", testIncompleteURL, url.QueryEscape(testToken))
+	}
+}
+
+func BenchmarkTokenURLEscape(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		TokenURL(testIncompleteURL, testEscapeToken)
+	}
+}
+
+func BenchmarkTokenURLEscapeConcat(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		_ = testIncompleteURL + url.QueryEscape(testEscapeToken)
+	}
+}
+
+func BenchmarkTokenURLParallel(b *testing.B) {
+	b.ReportAllocs()
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			TokenURL(testIncompleteURL, testToken)
+		}
+	})
+}
@@ -0,0 +1,20 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 在同一个 goroutine 里统一刷新 access_token, jsapi_ticket 和卡券 api_ticket.
+//
+// 刷新按照依赖顺序进行(ticket 依赖 access_token), access_token 刷新失败时不会去获取 ticket;
+// 三者保存在同一个 Storage 里, 调用一次 Close 即可停止刷新.
+//
+//  srv, err := tokenservice.New(appId, appSecret, nil, nil)
+//  if err != nil {
+//      // TODO: 增加你的代码
+//  }
+//  defer srv.Close()
+//
+//  menuClient := menu.NewClient(srv.TokenServer(), nil)
+//  jsapiTicketServer := srv.JSAPITicketServer() // 实现了 jssdk.TicketServer
+//  cardTicketServer := srv.CardTicketServer()   // 实现了 card.TicketServer
+package tokenservice
@@ -0,0 +1,252 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package tokenservice
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/card"
+	"github.com/chanxuehong/wechat/mp/jssdk"
+)
+
+const (
+	refreshMargin   = 5 * time.Minute  // 过期前多久刷新
+	retryInterval   = 10 * time.Second // 刷新失败后多久重试
+	convergenceTime = 5 * time.Second  // 收敛时间, 这期间的刷新请求直接返回最近一次获取的结果
+)
+
+// 需要定时刷新的值, 按照依赖顺序排列
+type item struct {
+	key   string // Storage 的 key
+	fetch func() (value string, expiresIn int64, err error)
+
+	mutex     sync.Mutex // 保证同一时间只有一个 fetch
+	lastFetch time.Time  // 最后一次成功 fetch 的时间
+	retryAt   time.Time  // 刷新失败后下一次重试的时间
+}
+
+// 统一刷新 access_token, jsapi_ticket 和卡券 api_ticket 的服务.
+type Service struct {
+	appId      string
+	appSecret  string
+	httpClient *http.Client
+	storage    Storage
+
+	wechatClient mp.WechatClient // 用于获取 ticket, TokenServer 为 tokenServer
+
+	token       *item
+	jsapiTicket *item
+	cardTicket  *item
+	items       []*item // 依赖顺序: token, jsapiTicket, cardTicket
+
+	resetChan chan struct{} // 通知 daemon 重新计算下一次刷新的时间
+	closeChan chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+}
+
+// 创建一个新的 Service, 同步获取 access_token 和 ticket 并启动刷新的 goroutine.
+//  如果 storage == nil 则默认使用 NewMemoryStorage();
+//  如果 httpClient == nil 则默认使用 http.DefaultClient.
+func New(appId, appSecret string, storage Storage, httpClient *http.Client) (srv *Service, err error) {
+	if appId == "" {
+		return nil, errors.New("empty appId")
+	}
+	if storage == nil {
+		storage = NewMemoryStorage()
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	srv = &Service{
+		appId:      appId,
+		appSecret:  appSecret,
+		httpClient: httpClient,
+		storage:    storage,
+		resetChan:  make(chan struct{}, 1),
+		closeChan:  make(chan struct{}),
+	}
+	srv.wechatClient = mp.WechatClient{
+		TokenServer: (*tokenServer)(srv),
+		HttpClient:  httpClient,
+	}
+	srv.token = &item{key: appId + ":access_token", fetch: srv.fetchToken}
+	srv.jsapiTicket = &item{key: appId + ":jsapi_ticket", fetch: srv.fetchTicketFunc(mp.TicketTypeJSAPI)}
+	srv.cardTicket = &item{key: appId + ":wx_card_ticket", fetch: srv.fetchTicketFunc(mp.TicketTypeWxCard)}
+	srv.items = []*item{srv.token, srv.jsapiTicket, srv.cardTicket}
+
+	for _, it := range srv.items {
+		if _, err = srv.get(it); err != nil {
+			return nil, err
+		}
+	}
+
+	srv.wg.Add(1)
+	go srv.daemon()
+	return
+}
+
+// 停止刷新, 可以重复调用.
+func (srv *Service) Close() {
+	srv.closeOnce.Do(func() {
+		close(srv.closeChan)
+	})
+	srv.wg.Wait()
+}
+
+// 返回 access_token 的中控服务器.
+func (srv *Service) TokenServer() mp.TokenServer {
+	return (*tokenServer)(srv)
+}
+
+// 返回 jsapi_ticket 的中控服务器.
+func (srv *Service) JSAPITicketServer() jssdk.TicketServer {
+	return (*jsapiTicketServer)(srv)
+}
+
+// 返回卡券 api_ticket 的中控服务器.
+func (srv *Service) CardTicketServer() card.TicketServer {
+	return (*cardTicketServer)(srv)
+}
+
+type tokenServer Service
+
+func (srv *tokenServer) Token() (string, error) {
+	return (*Service)(srv).get(srv.token)
+}
+
+func (srv *tokenServer) TokenRefresh() (string, error) {
+	return (*Service)(srv).refresh(srv.token)
+}
+
+type jsapiTicketServer Service
+
+func (srv *jsapiTicketServer) Ticket() (string, error) {
+	return (*Service)(srv).get(srv.jsapiTicket)
+}
+
+func (srv *jsapiTicketServer) TicketRefresh() (string, error) {
+	return (*Service)(srv).refresh(srv.jsapiTicket)
+}
+
+type cardTicketServer Service
+
+func (srv *cardTicketServer) Ticket() (string, error) {
+	return (*Service)(srv).get(srv.cardTicket)
+}
+
+func (srv *cardTicketServer) TicketRefresh() (string, error) {
+	return (*Service)(srv).refresh(srv.cardTicket)
+}
+
+// 从 Storage 获取, 没有或者已经过期则刷新.
+func (srv *Service) get(it *item) (value string, err error) {
+	value, expiresAt, err := srv.storage.Get(it.key)
+	if err == nil && value != "" && time.Now().Before(expiresAt) {
+		return
+	}
+	return srv.refresh(it)
+}
+
+// 从微信服务器刷新, 收敛时间内直接返回 Storage 里的值.
+func (srv *Service) refresh(it *item) (value string, err error) {
+	it.mutex.Lock()
+	defer it.mutex.Unlock()
+
+	if time.Since(it.lastFetch) < convergenceTime {
+		var expiresAt time.Time
+		if value, expiresAt, err = srv.storage.Get(it.key); err == nil && value != "" && time.Now().Before(expiresAt) {
+			return
+		}
+	}
+
+	fetchTime := time.Now()
+	value, expiresIn, err := it.fetch()
+	if err != nil {
+		it.retryAt = fetchTime.Add(retryInterval)
+		return
+	}
+	if err = srv.storage.Set(it.key, value, fetchTime.Add(time.Duration(expiresIn)*time.Second)); err != nil {
+		return
+	}
+	it.lastFetch = fetchTime
+	it.retryAt = time.Time{}
+
+	select {
+	case srv.resetChan <- struct{}{}:
+	default:
+	}
+	return
+}
+
+// 返回 it 下一次需要刷新的时间.
+func (srv *Service) refreshAt(it *item) (at time.Time) {
+	it.mutex.Lock()
+	retryAt, lastFetch := it.retryAt, it.lastFetch
+	it.mutex.Unlock()
+	if !retryAt.IsZero() {
+		return retryAt
+	}
+
+	value, expiresAt, err := srv.storage.Get(it.key)
+	if err != nil || value == "" {
+		return time.Now()
+	}
+	at = expiresAt.Add(-refreshMargin)
+
+	// 有效期比 refreshMargin 还短的时候, 至少间隔一个收敛时间, 避免空转
+	if floor := lastFetch.Add(convergenceTime); at.Before(floor) {
+		at = floor
+	}
+	return
+}
+
+func (srv *Service) daemon() {
+	defer srv.wg.Done()
+
+	for {
+		var next time.Time
+		for i, it := range srv.items {
+			if at := srv.refreshAt(it); i == 0 || at.Before(next) {
+				next = at
+			}
+		}
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-srv.closeChan:
+			timer.Stop()
+			return
+		case <-srv.resetChan:
+			timer.Stop()
+		case <-timer.C:
+			now := time.Now()
+			for i, it := range srv.items {
+				if srv.refreshAt(it).After(now) {
+					continue
+				}
+				if _, err := srv.refresh(it); err != nil && i == 0 {
+					break // access_token 刷新失败, 不去获取依赖它的 ticket
+				}
+			}
+		}
+	}
+}
+
+func (srv *Service) fetchToken() (token string, expiresIn int64, err error) {
+	return mp.FetchToken(srv.appId, srv.appSecret, srv.httpClient)
+}
+
+func (srv *Service) fetchTicketFunc(ticketType string) func() (string, int64, error) {
+	return func() (string, int64, error) {
+		return srv.wechatClient.GetTicket(ticketType)
+	}
+}
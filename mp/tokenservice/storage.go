@@ -0,0 +1,61 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package tokenservice
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var ErrNotFound = errors.New("tokenservice: value not found")
+
+// access_token 和 ticket 的存储接口, 多进程共享时可以用 redis 等实现.
+type Storage interface {
+	// 获取 key 对应的值和过期时间, 如果没有则返回 ErrNotFound.
+	Get(key string) (value string, expiresAt time.Time, err error)
+
+	// 保存 key 对应的值和过期时间.
+	Set(key, value string, expiresAt time.Time) (err error)
+}
+
+var _ Storage = (*MemoryStorage)(nil)
+
+// Storage 的简单实现, 用于单进程环境.
+type MemoryStorage struct {
+	rwmutex sync.RWMutex
+	values  map[string]memoryValue
+}
+
+type memoryValue struct {
+	value     string
+	expiresAt time.Time
+}
+
+func NewMemoryStorage() *MemoryStorage {
+	return &MemoryStorage{
+		values: make(map[string]memoryValue),
+	}
+}
+
+func (s *MemoryStorage) Get(key string) (value string, expiresAt time.Time, err error) {
+	s.rwmutex.RLock()
+	v, ok := s.values[key]
+	s.rwmutex.RUnlock()
+
+	if !ok {
+		err = ErrNotFound
+		return
+	}
+	return v.value, v.expiresAt, nil
+}
+
+func (s *MemoryStorage) Set(key, value string, expiresAt time.Time) (err error) {
+	s.rwmutex.Lock()
+	s.values[key] = memoryValue{value: value, expiresAt: expiresAt}
+	s.rwmutex.Unlock()
+	return
+}
@@ -0,0 +1,126 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package user
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	ChangeOpenIdLimit = 100 // 每次最多转换 100 个 openid
+)
+
+// 一个 openid 的转换结果
+type ChangeOpenIdResult struct {
+	OriOpenId string `json:"ori_openid"` // 原帐号的 openid
+	NewOpenId string `json:"new_openid"` // 新帐号的 openid, 转换失败时为空
+	ErrMsg    string `json:"err_msg"`    // 转换的结果, 成功为 "ok", 比如 "ori_openid error" 表示原 openid 不正确
+}
+
+// 是否转换成功.
+func (rst *ChangeOpenIdResult) OK() bool {
+	return rst.ErrMsg == "ok" && rst.NewOpenId != ""
+}
+
+// 公众号迁移(主体变更)后, 把原帐号的 openid 转换成新帐号的 openid.
+//  fromAppId:  原帐号的 appid;
+//  openIdList: 原帐号的 openid 列表, 每次最多 ChangeOpenIdLimit 个, 更多的请使用 ChangeOpenIdAll.
+//  NOTE: 需要在迁移完成后 15 天内调用, 并且必须由新帐号调用.
+func (clt *Client) ChangeOpenId(fromAppId string, openIdList []string, opts ...mp.CallOption) (list []ChangeOpenIdResult, err error) {
+	if fromAppId == "" {
+		err = errors.New("empty fromAppId")
+		return
+	}
+	if len(openIdList) == 0 {
+		err = errors.New("empty openIdList")
+		return
+	}
+	if len(openIdList) > ChangeOpenIdLimit {
+		err = errors.New("the length of openIdList should not be greater than 100")
+		return
+	}
+
+	var request = struct {
+		FromAppId  string   `json:"from_appid"`
+		OpenIdList []string `json:"openid_list"`
+	}{
+		FromAppId:  fromAppId,
+		OpenIdList: openIdList,
+	}
+
+	var result struct {
+		mp.Error
+		ResultList []ChangeOpenIdResult `json:"result_list"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/changeopenid?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	list = result.ResultList
+	return
+}
+
+// ChangeOpenIdAll 的转换报告
+type ChangeOpenIdReport struct {
+	Results []ChangeOpenIdResult // 所有 openid 的转换结果, 顺序和请求的一致
+	Mapping map[string]string    // 转换成功的 原openid --> 新openid
+	Failed  []ChangeOpenIdResult // 转换失败的 openid, 包括因为请求出错没有结果的(ErrMsg 为错误信息)
+}
+
+// 转换任意数量的 openid, 自动按 ChangeOpenIdLimit 分批.
+//  某一批请求出错时, 这一批的 openid 记录到 report.Failed, 然后继续转换后面的; err 返回最后一次请求的错误.
+func (clt *Client) ChangeOpenIdAll(fromAppId string, openIdList []string, opts ...mp.CallOption) (report *ChangeOpenIdReport, err error) {
+	report = &ChangeOpenIdReport{
+		Results: make([]ChangeOpenIdResult, 0, len(openIdList)),
+		Mapping: make(map[string]string, len(openIdList)),
+	}
+
+	for begin := 0; begin < len(openIdList); begin += ChangeOpenIdLimit {
+		end := begin + ChangeOpenIdLimit
+		if end > len(openIdList) {
+			end = len(openIdList)
+		}
+		batch := openIdList[begin:end]
+
+		list, batchErr := clt.ChangeOpenId(fromAppId, batch, opts...)
+		if batchErr != nil {
+			err = batchErr
+			for _, openid := range batch {
+				rst := ChangeOpenIdResult{OriOpenId: openid, ErrMsg: batchErr.Error()}
+				report.Results = append(report.Results, rst)
+				report.Failed = append(report.Failed, rst)
+			}
+			continue
+		}
+
+		// 微信返回的结果一般和请求的顺序一致, 这里按 ori_openid 对应, 没有结果的也记为失败
+		got := make(map[string]ChangeOpenIdResult, len(list))
+		for _, rst := range list {
+			got[rst.OriOpenId] = rst
+		}
+		for _, openid := range batch {
+			rst, ok := got[openid]
+			if !ok {
+				rst = ChangeOpenIdResult{OriOpenId: openid, ErrMsg: "no result"}
+			}
+			report.Results = append(report.Results, rst)
+			if rst.OK() {
+				report.Mapping[rst.OriOpenId] = rst.NewOpenId
+			} else {
+				report.Failed = append(report.Failed, rst)
+			}
+		}
+	}
+	return
+}
@@ -22,7 +22,7 @@ type Group struct {
 
 // 创建分组.
 //  name: 分组名字（30个字符以内）
-func (clt *Client) GroupCreate(name string) (group *Group, err error) {
+func (clt *Client) GroupCreate(name string, opts ...mp.CallOption) (group *Group, err error) {
 	if name == "" {
 		err = errors.New("empty name")
 		return
@@ -41,7 +41,7 @@ func (clt *Client) GroupCreate(name string) (group *Group, err error) {
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/groups/create?access_token="
-	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
 		return
 	}
 
@@ -55,7 +55,7 @@ func (clt *Client) GroupCreate(name string) (group *Group, err error) {
 }
 
 // 查询所有分组.
-func (clt *Client) GroupList() (groups []Group, err error) {
+func (clt *Client) GroupList(opts ...mp.CallOption) (groups []Group, err error) {
 	var result = struct {
 		mp.Error
 		Groups []Group `json:"groups"`
@@ -64,7 +64,7 @@ func (clt *Client) GroupList() (groups []Group, err error) {
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/groups/get?access_token="
-	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+	if err = clt.GetJSON(incompleteURL, &result, opts...); err != nil {
 		return
 	}
 
@@ -78,7 +78,7 @@ func (clt *Client) GroupList() (groups []Group, err error) {
 
 // 修改分组名.
 //  name: 分组名字（30个字符以内）.
-func (clt *Client) GroupUpdate(groupId int64, newName string) (err error) {
+func (clt *Client) GroupUpdate(groupId int64, newName string, opts ...mp.CallOption) (err error) {
 	if newName == "" {
 		err = errors.New("empty newName")
 		return
@@ -96,7 +96,7 @@ func (clt *Client) GroupUpdate(groupId int64, newName string) (err error) {
 	var result mp.Error
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/groups/update?access_token="
-	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
 		return
 	}
 
@@ -108,7 +108,7 @@ func (clt *Client) GroupUpdate(groupId int64, newName string) (err error) {
 }
 
 // 查询用户所在分组.
-func (clt *Client) UserInWhichGroup(openId string) (groupId int64, err error) {
+func (clt *Client) UserInWhichGroup(openId string, opts ...mp.CallOption) (groupId int64, err error) {
 	var request = struct {
 		OpenId string `json:"openid"`
 	}{
@@ -121,7 +121,7 @@ func (clt *Client) UserInWhichGroup(openId string) (groupId int64, err error) {
 	}
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/groups/getid?access_token="
-	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
 		return
 	}
 
@@ -134,7 +134,7 @@ func (clt *Client) UserInWhichGroup(openId string) (groupId int64, err error) {
 }
 
 // 移动用户分组.
-func (clt *Client) MoveUserToGroup(openId string, toGroupId int64) (err error) {
+func (clt *Client) MoveUserToGroup(openId string, toGroupId int64, opts ...mp.CallOption) (err error) {
 	var request = struct {
 		OpenId    string `json:"openid"`
 		ToGroupId int64  `json:"to_groupid"`
@@ -146,7 +146,7 @@ func (clt *Client) MoveUserToGroup(openId string, toGroupId int64) (err error) {
 	var result mp.Error
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/groups/members/update?access_token="
-	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
 		return
 	}
 
@@ -158,7 +158,7 @@ func (clt *Client) MoveUserToGroup(openId string, toGroupId int64) (err error) {
 }
 
 // 批量移动用户分组.
-func (clt *Client) MoveUsersToGroup(openIdList []string, toGroupId int64) (err error) {
+func (clt *Client) MoveUsersToGroup(openIdList []string, toGroupId int64, opts ...mp.CallOption) (err error) {
 	if len(openIdList) <= 0 {
 		return
 	}
@@ -174,7 +174,7 @@ func (clt *Client) MoveUsersToGroup(openIdList []string, toGroupId int64) (err e
 	var result mp.Error
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/groups/members/batchupdate?access_token="
-	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
 		return
 	}
 
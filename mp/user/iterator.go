@@ -5,6 +5,10 @@
 
 package user
 
+import (
+	"github.com/chanxuehong/wechat/mp"
+)
+
 const (
 	UserPageSizeLimit = 10000 // 每次拉取的 OPENID 个数最大值为 10000
 )
@@ -26,8 +30,10 @@ const (
 type UserIterator struct {
 	lastUserListData *UserListResult // 最近一次获取的用户数据
 
-	wechatClient   *Client // 关联的微信 Client
-	nextPageCalled bool    // NextPage() 是否调用过
+	beginOpenId    string          // 创建时的 beginOpenId
+	wechatClient   *Client         // 关联的微信 Client
+	opts           []mp.CallOption // 创建时的 opts, 每次拉取都使用
+	nextPageCalled bool            // NextPage() 是否调用过
 }
 
 func (iter *UserIterator) Total() int {
@@ -72,7 +78,7 @@ func (iter *UserIterator) NextPage() (openids []string, err error) {
 	}
 
 	// 不是第一次调用的都要从服务器拉取数据
-	data, err := iter.wechatClient.UserList(iter.lastUserListData.NextOpenId)
+	data, err := iter.wechatClient.UserList(iter.lastUserListData.NextOpenId, iter.opts...)
 	if err != nil {
 		return
 	}
@@ -82,17 +88,62 @@ func (iter *UserIterator) NextPage() (openids []string, err error) {
 	return
 }
 
+// 下一页的位置, Cursor.Next 为下一页的 beginOpenId, 可以用来创建新的 UserIterator 继续遍历.
+func (iter *UserIterator) Cursor() mp.Cursor {
+	if !iter.nextPageCalled {
+		return mp.Cursor{Next: iter.beginOpenId}
+	}
+	return mp.Cursor{Next: iter.lastUserListData.NextOpenId}
+}
+
 // 获取用户遍历器, beginOpenId 表示开始遍历用户, 如果 beginOpenId == "" 则表示从头遍历.
-func (clt *Client) UserIterator(beginOpenId string) (iter *UserIterator, err error) {
-	data, err := clt.UserList(beginOpenId)
+func (clt *Client) UserIterator(beginOpenId string, opts ...mp.CallOption) (iter *UserIterator, err error) {
+	data, err := clt.UserList(beginOpenId, opts...)
 	if err != nil {
 		return
 	}
 
 	iter = &UserIterator{
 		lastUserListData: data,
+		beginOpenId:      beginOpenId,
 		wechatClient:     clt,
+		opts:             opts,
 		nextPageCalled:   false,
 	}
 	return
 }
+
+// 标签下粉丝的遍历器, 用法同 mp.Pager, Cursor.Next 为下一页的 beginOpenId.
+type TagUserIterator struct {
+	pager   *mp.Pager
+	openids []string
+}
+
+func (iter *TagUserIterator) HasNext() bool     { return iter.pager.HasNext() }
+func (iter *TagUserIterator) Cursor() mp.Cursor { return iter.pager.Cursor() }
+
+func (iter *TagUserIterator) NextPage() (openids []string, err error) {
+	if err = iter.pager.NextPage(); err != nil {
+		return
+	}
+	openids = iter.openids
+	return
+}
+
+// 获取标签下粉丝的遍历器, 每次拉取 UserPageSizeLimit 个.
+//  beginOpenId: 第一个拉取的OPENID, 为空默认从头开始拉取
+func (clt *Client) TagUserIterator(tagId int64, beginOpenId string, opts ...mp.CallOption) *TagUserIterator {
+	iter := &TagUserIterator{}
+	fn := func(cursor mp.Cursor) (next mp.Cursor, hasMore bool, err error) {
+		data, err := clt.TagUserList(tagId, cursor.Next, opts...)
+		if err != nil {
+			return
+		}
+		iter.openids = data.Data.OpenId
+		next = mp.Cursor{Next: data.NextOpenId}
+		hasMore = data.GotCount >= UserPageSizeLimit && data.NextOpenId != ""
+		return
+	}
+	iter.pager = mp.NewPager(mp.Cursor{Next: beginOpenId}, fn)
+	return iter
+}
@@ -0,0 +1,35 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package oauth2
+
+import (
+	"net/http"
+)
+
+// 创建移动应用(开放平台)微信登录使用的 Client.
+//  移动应用通过微信 SDK 拉起微信授权, 拿到 code 后传给服务器, 服务器端的流程:
+//  1. Client.Exchange(code) 换取 access_token, openid 和 unionid;
+//  2. access_token 过期后 Client.TokenRefresh() 刷新, refresh_token 有效期为30天;
+//  3. Client.CheckAccessTokenValid() 检验 access_token 是否有效;
+//  4. Client.UserInfo("") 获取用户信息.
+//  也可以使用 NewTokenCache(NewAppConfig(appId, appSecret), store, httpClient) 按 openid 缓存 token.
+//
+//  appId, appSecret: 开放平台移动应用的 AppID 和 AppSecret, 不是公众号的; AppSecret 只能保存在服务器, 不能放在客户端.
+func NewAppClient(appId, appSecret string, httpClient *http.Client) *Client {
+	return &Client{
+		OAuth2Config: NewAppConfig(appId, appSecret),
+		HttpClient:   httpClient,
+	}
+}
+
+// 移动应用微信登录的 OAuth2Config, 移动应用没有 RedirectURL, scope 为 snsapi_userinfo.
+func NewAppConfig(appId, appSecret string) *OAuth2Config {
+	return &OAuth2Config{
+		AppId:     appId,
+		AppSecret: appSecret,
+		Scope:     ScopeUserInfo,
+	}
+}
@@ -44,8 +44,9 @@ type OAuth2Token struct {
 	RefreshToken string
 	ExpiresAt    int64 // 过期时间, unixtime, 分布式系统要求时间同步, 建议使用 NTP
 
-	OpenId string
-	Scopes []string // 用户授权的作用域
+	OpenId  string
+	UnionId string   // 只有网站应用和移动应用, 或者公众号绑定到开放平台帐号并且 scope 为 snsapi_userinfo 时才有
+	Scopes  []string // 用户授权的作用域
 }
 
 // 判断授权的 OAuth2Token.AccessToken 是否过期, 过期返回 true, 否则返回 false
@@ -196,6 +197,7 @@ func (clt *Client) updateToken(tk *OAuth2Token, url string) (err error) {
 		ExpiresIn    int64  `json:"expires_in"`    // access_token接口调用凭证超时时间，单位（秒）
 		OpenId       string `json:"openid"`        // 用户唯一标识，请注意，在未关注公众号时，用户访问公众号的网页，也会产生一个用户和公众号唯一的OpenID
 		Scope        string `json:"scope"`         // 用户授权的作用域，使用逗号（,）分隔
+		UnionId      string `json:"unionid"`       // 用户统一标识, 针对一个微信开放平台帐号下的应用, 同一用户的 unionid 是唯一的
 	}
 
 	if err = json.NewDecoder(httpResp.Body).Decode(&result); err != nil {
@@ -230,6 +232,9 @@ func (clt *Client) updateToken(tk *OAuth2Token, url string) (err error) {
 	}
 	tk.ExpiresAt = time.Now().Unix() + result.ExpiresIn
 
+	if result.OpenId != tk.OpenId || result.UnionId != "" { // 刷新 access_token 时不返回 unionid
+		tk.UnionId = result.UnionId
+	}
 	tk.OpenId = result.OpenId
 
 	strs := strings.Split(result.Scope, ",")
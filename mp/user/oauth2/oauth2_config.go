@@ -13,7 +13,7 @@ type OAuth2Config struct {
 	AppId, AppSecret string
 
 	// 应用授权作用域，多个作用域用逗号（,）分隔;
-	// 目前有 snsapi_base, snsapi_userinfo.
+	// 目前有 snsapi_base, snsapi_userinfo; 网站应用微信登录为 snsapi_login, 见 QRConnectURL.
 	Scope string
 
 	// 用户授权后跳转的目的地址
@@ -22,9 +22,9 @@ import (
 )
 
 const (
-	Language_zh_CN = "zh_CN" // 简体中文
-	Language_zh_TW = "zh_TW" // 繁体中文
-	Language_en    = "en"    // 英文
+	Language_zh_CN = mp.Language_zh_CN // 简体中文
+	Language_zh_TW = mp.Language_zh_TW // 繁体中文
+	Language_en    = mp.Language_en    // 英文
 )
 
 const (
@@ -91,14 +91,9 @@ func (info *UserInfo) HeadImageSize() (size int, err error) {
 // 获取用户信息(需scope为 snsapi_userinfo).
 //  NOTE:
 //  1. Client 需要指定 OAuth2Config, OAuth2Token
-//  2. lang 可能的取值是 zh_CN, zh_TW, en, 如果留空 "" 则默认为 zh_CN.
+//  2. lang 可能的取值是 zh_CN, zh_TW, en, 如果留空 "" 则默认为 zh_CN; 其他写法(比如 zh-TW, en-US)见 mp.NormalizeLanguage.
 func (clt *Client) UserInfo(lang string) (info *UserInfo, err error) {
-	switch lang {
-	case "":
-		lang = Language_zh_CN
-	case Language_zh_CN, Language_zh_TW, Language_en:
-	default:
-		err = errors.New("错误的 lang 参数")
+	if lang, err = mp.NormalizeLanguage(lang); err != nil {
 		return
 	}
 
@@ -0,0 +1,62 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package oauth2
+
+import (
+	"net/url"
+)
+
+const (
+	ScopeBase     = "snsapi_base"     // 公众号网页授权, 不弹出授权页面, 只能获取 openid
+	ScopeUserInfo = "snsapi_userinfo" // 公众号网页授权, 弹出授权页面, 可以获取用户基本信息
+	ScopeLogin    = "snsapi_login"    // 开放平台网站应用微信登录, 扫码授权
+)
+
+// 构造网站应用(开放平台)微信登录的扫码授权地址, 在 PC 浏览器里打开.
+//  和公众号网页授权的区别:
+//  1. appId 是开放平台网站应用的 AppID, 不是公众号的 AppID;
+//  2. scope 固定为 snsapi_login;
+//  3. 用户扫码确认后跳转到 redirect_uri?code=CODE&state=STATE, 之后用 code 换取 access_token,
+//     刷新 access_token, 获取用户信息的流程和公众号网页授权一样, 使用 Client 即可, 注意 Client 的 OAuth2Config 要使用网站应用的 AppID 和 AppSecret.
+//  redirectURL: 授权后重定向的回调链接地址, 域名必须是网站应用的授权回调域
+//  state:       重定向后会带上state参数，用于保持请求和回调的状态，防止csrf攻击
+func QRConnectURL(appId, redirectURL, state string) string {
+	return "https://open.weixin.qq.com/connect/qrconnect" +
+		"?appid=" + url.QueryEscape(appId) +
+		"&redirect_uri=" + url.QueryEscape(redirectURL) +
+		"&response_type=code&scope=" + ScopeLogin +
+		"&state=" + url.QueryEscape(state) +
+		"#wechat_redirect"
+}
+
+// 网站应用微信登录的扫码授权地址, cfg.Scope 会被忽略.
+func (cfg *OAuth2Config) QRConnectURL(state string) string {
+	return QRConnectURL(cfg.AppId, cfg.RedirectURL, state)
+}
+
+// 在自己的页面里内嵌二维码登录时(http://res.wx.qq.com/connect/zh_CN/htmledition/js/wxLogin.js)
+// 传给 new WxLogin(...) 的参数, 用 json.Marshal 序列化后输出到页面.
+type QRConnectJSConfig struct {
+	SelfRedirect bool   `json:"self_redirect"`   // true: 手机点击确认登录后可以在 iframe 内跳转到 redirect_uri; false: 在 top window 跳转
+	Id           string `json:"id"`              // 第三方页面显示二维码的容器id
+	AppId        string `json:"appid"`           // 网站应用的 AppID
+	Scope        string `json:"scope"`           // 固定为 snsapi_login
+	RedirectURI  string `json:"redirect_uri"`    // 重定向地址, 不需要 urlencode, WxLogin 会处理
+	State        string `json:"state,omitempty"` // 用于保持请求和回调的状态，防止csrf攻击
+	Style        string `json:"style,omitempty"` // 提供 "black", "white" 可选, 默认为黑色文字描述
+	Href         string `json:"href,omitempty"`  // 自定义样式链接, 必须是 https 的 css 文件
+}
+
+// 返回内嵌二维码登录的参数, containerId 为页面上显示二维码的容器id.
+func (cfg *OAuth2Config) QRConnectJSConfig(containerId, state string) *QRConnectJSConfig {
+	return &QRConnectJSConfig{
+		Id:          containerId,
+		AppId:       cfg.AppId,
+		Scope:       ScopeLogin,
+		RedirectURI: cfg.RedirectURL,
+		State:       state,
+	}
+}
@@ -0,0 +1,193 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package oauth2
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 没有保存 openid 对应的 OAuth2Token, 或者 refresh_token 已经失效, 需要重新引导用户授权.
+var ErrTokenNotFound = errors.New("oauth2 token not found")
+
+// OAuth2Token 的存储接口, 以 openid 为 key, 可以用 redis, 数据库等实现以便多个进程共享.
+type TokenStore interface {
+	// 获取 openid 对应的 OAuth2Token, 不存在时返回 ErrTokenNotFound.
+	Get(openid string) (token *OAuth2Token, err error)
+	// 保存 OAuth2Token, key 为 token.OpenId.
+	Put(token *OAuth2Token) error
+	Delete(openid string) error
+}
+
+var _ TokenStore = (*MemoryTokenStore)(nil)
+
+// 内存里的 TokenStore, 只适用于单进程.
+type MemoryTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]OAuth2Token
+}
+
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{
+		tokens: make(map[string]OAuth2Token),
+	}
+}
+
+func (s *MemoryTokenStore) Get(openid string) (token *OAuth2Token, err error) {
+	s.mu.RLock()
+	tk, ok := s.tokens[openid]
+	s.mu.RUnlock()
+	if !ok {
+		return nil, ErrTokenNotFound
+	}
+	tk.Scopes = append([]string(nil), tk.Scopes...)
+	return &tk, nil
+}
+
+func (s *MemoryTokenStore) Put(token *OAuth2Token) error {
+	if token == nil || token.OpenId == "" {
+		return errors.New("empty openid")
+	}
+	tk := *token
+	tk.Scopes = append([]string(nil), tk.Scopes...)
+	s.mu.Lock()
+	s.tokens[tk.OpenId] = tk
+	s.mu.Unlock()
+	return nil
+}
+
+func (s *MemoryTokenStore) Delete(openid string) error {
+	s.mu.Lock()
+	delete(s.tokens, openid)
+	s.mu.Unlock()
+	return nil
+}
+
+// 以 openid 为 key 缓存网页授权的 OAuth2Token, access_token 过期时自动用 refresh_token 刷新,
+// 这样在 refresh_token 的有效期(30天)内用户再次访问网页时不需要重新跳转授权.
+//
+//  // 授权回调
+//  token, err := cache.Exchange(code)
+//  // 记住 token.OpenId (比如写入 cookie), 以后直接:
+//  info, err := cache.GetUserInfoCached(openid, "")
+//  if err == oauth2.ErrTokenNotFound {
+//      // 跳转到 cache.Config.AuthCodeURL(state) 重新授权
+//  }
+//
+//  NOTE: 同一个 openid 的并发请求可能会同时刷新 access_token, 刷新后 refresh_token 不变, 所以结果是一样的.
+type TokenCache struct {
+	Config     *OAuth2Config
+	Store      TokenStore
+	HttpClient *http.Client // 如果 HttpClient == nil 则默认用 http.DefaultClient
+}
+
+// 创建 TokenCache, store 为 nil 时使用 NewMemoryTokenStore().
+func NewTokenCache(config *OAuth2Config, store TokenStore, httpClient *http.Client) *TokenCache {
+	if config == nil {
+		panic("nil OAuth2Config")
+	}
+	if store == nil {
+		store = NewMemoryTokenStore()
+	}
+	return &TokenCache{
+		Config:     config,
+		Store:      store,
+		HttpClient: httpClient,
+	}
+}
+
+// 通过 code 换取 OAuth2Token 并保存.
+func (c *TokenCache) Exchange(code string) (token *OAuth2Token, err error) {
+	clt := &Client{
+		OAuth2Config: c.Config,
+		HttpClient:   c.HttpClient,
+	}
+	if token, err = clt.Exchange(code); err != nil {
+		return
+	}
+	if err = c.Store.Put(token); err != nil {
+		return nil, err
+	}
+	return
+}
+
+// 获取 openid 对应的有效的 OAuth2Token, access_token 过期时会自动刷新.
+//  没有缓存或者 refresh_token 失效时返回 ErrTokenNotFound.
+func (c *TokenCache) Token(openid string) (token *OAuth2Token, err error) {
+	clt, err := c.Client(openid)
+	if err != nil {
+		return
+	}
+	if clt.accessTokenExpired() {
+		if err = c.refresh(clt); err != nil {
+			return
+		}
+	}
+	token = clt.OAuth2Token
+	return
+}
+
+// 返回使用 openid 对应的 OAuth2Token 的 Client.
+//  NOTE: 通过返回的 Client 调用接口时如果刷新了 access_token, 需要自己调用 Store.Put 保存.
+func (c *TokenCache) Client(openid string) (clt *Client, err error) {
+	if openid == "" {
+		return nil, ErrTokenNotFound
+	}
+	token, err := c.Store.Get(openid)
+	if err != nil {
+		return
+	}
+	clt = &Client{
+		OAuth2Config: c.Config,
+		OAuth2Token:  token,
+		HttpClient:   c.HttpClient,
+	}
+	return
+}
+
+// 使用缓存的 OAuth2Token 获取用户信息(需scope为 snsapi_userinfo), 参考 Client.UserInfo.
+//  access_token 过期或者失效时自动刷新并重试一次; 没有缓存或者 refresh_token 失效时返回 ErrTokenNotFound.
+func (c *TokenCache) GetUserInfoCached(openid, lang string) (info *UserInfo, err error) {
+	clt, err := c.Client(openid)
+	if err != nil {
+		return
+	}
+	if clt.accessTokenExpired() {
+		if err = c.refresh(clt); err != nil {
+			return
+		}
+	}
+
+	info, err = clt.UserInfo(lang)
+	if e, ok := err.(*mp.Error); ok && (e.ErrCode == mp.ErrCodeInvalidCredential || e.ErrCode == mp.ErrCodeTimeout) {
+		if err = c.refresh(clt); err != nil {
+			return
+		}
+		info, err = clt.UserInfo(lang)
+	}
+	return
+}
+
+// 刷新 clt.OAuth2Token 并保存; refresh_token 失效时删除缓存并返回 ErrTokenNotFound.
+func (c *TokenCache) refresh(clt *Client) (err error) {
+	openid := clt.OpenId
+	if _, err = clt.TokenRefresh(); err != nil {
+		if e, ok := err.(*mp.Error); ok && (e.ErrCode == mp.ErrCodeInvalidRefreshToken || e.ErrCode == mp.ErrCodeRefreshTokenTimeout) {
+			if err = c.Store.Delete(openid); err != nil {
+				return
+			}
+			return ErrTokenNotFound
+		}
+		return
+	}
+	if clt.OpenId == "" {
+		clt.OpenId = openid
+	}
+	return c.Store.Put(clt.OAuth2Token)
+}
@@ -0,0 +1,248 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package user
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+const (
+	RemarkRuneLimit = 30 // 备注名的长度限制, 30个字符
+
+	DefaultRemarkImportRetries      = mp.DefaultRetries
+	DefaultRemarkImportRetryBackoff = mp.DefaultRetryBackoff
+)
+
+// 批量导入备注名的数据格式
+type RemarkImportFormat int
+
+const (
+	RemarkImportAuto  RemarkImportFormat = iota // 根据内容自动判断, 第一个非空白字符为 '{' 时为 JSONL, 否则为 CSV
+	RemarkImportCSV                             // 每行 openid,remark; 第一行为 openid,remark 时作为表头跳过
+	RemarkImportJSONL                           // 每行一个 {"openid":"...","remark":"..."}
+)
+
+// 导入的一行数据及其结果
+type RemarkRow struct {
+	Row      int    `json:"-"` // 第几条数据, 从 1 开始, 不包括 CSV 的表头
+	OpenId   string `json:"openid"`
+	Remark   string `json:"remark"`
+	Attempts int    `json:"-"` // 调用接口的次数, 数据格式错误时为 0
+	Err      error  `json:"-"` // 失败的原因, 成功时为 nil
+}
+
+// 批量导入备注名的结果
+type RemarkImportReport struct {
+	Total     int         // 处理的数据条数
+	Succeeded int         // 成功的条数
+	Failures  []RemarkRow // 失败的数据
+}
+
+func (report *RemarkImportReport) Failed() int { return len(report.Failures) }
+
+// 批量导入备注名, 用于把 CRM 等系统里的客户名称同步到公众号.
+//  一条数据失败不会影响其他数据, 失败的数据记录到 RemarkImportReport.Failures;
+//  接口返回系统繁忙, 调用频率超过限制或者网络错误时会重试, 见 mp.IsRetryable.
+type RemarkImporter struct {
+	Client       *Client
+	Format       RemarkImportFormat
+	Limiter      mp.RateLimiter       // 可以为 nil, 表示不限流
+	MaxRetries   int                  // 默认为 DefaultRemarkImportRetries, 小于 0 表示不重试
+	RetryBackoff time.Duration        // 第一次重试前等待的时间, 之后每次加倍, 默认为 DefaultRemarkImportRetryBackoff
+	OnRow        func(row *RemarkRow) // 每处理完一条数据调用一次, 可以为 nil, 可以用来记录进度
+}
+
+func NewRemarkImporter(clt *Client, limiter mp.RateLimiter) *RemarkImporter {
+	if clt == nil {
+		panic("nil Client")
+	}
+	return &RemarkImporter{
+		Client:  clt,
+		Limiter: limiter,
+	}
+}
+
+// 使用默认参数从 reader 导入备注名, 参考 RemarkImporter.
+func (clt *Client) UpdateRemarks(ctx context.Context, reader io.Reader, opts ...mp.CallOption) (report *RemarkImportReport, err error) {
+	return NewRemarkImporter(clt, nil).Import(ctx, reader, opts...)
+}
+
+// 从 reader 导入备注名.
+//  err 只在读取 reader 失败或者 ctx 结束时返回, 此时 report 为已经处理的数据的结果.
+func (im *RemarkImporter) Import(ctx context.Context, reader io.Reader, opts ...mp.CallOption) (report *RemarkImportReport, err error) {
+	if reader == nil {
+		return nil, errors.New("nil reader")
+	}
+
+	br := bufio.NewReader(reader)
+	if bom, _ := br.Peek(3); bytes.Equal(bom, []byte("\xEF\xBB\xBF")) { // Excel 导出的 CSV 带有 UTF-8 BOM
+		br.Discard(3)
+	}
+	format := im.Format
+	if format == RemarkImportAuto {
+		if format, err = detectRemarkImportFormat(br); err != nil {
+			return nil, err
+		}
+	}
+
+	var next func() (row RemarkRow, err error)
+	switch format {
+	case RemarkImportCSV:
+		next = csvRemarkRows(br)
+	case RemarkImportJSONL:
+		next = jsonlRemarkRows(br)
+	default:
+		return nil, fmt.Errorf("unknown RemarkImportFormat: %d", format)
+	}
+
+	report = &RemarkImportReport{}
+	for n := 1; ; n++ {
+		if err = ctx.Err(); err != nil {
+			return
+		}
+		row, err := next()
+		if err == io.EOF {
+			return report, nil
+		}
+		if err != nil {
+			if _, ok := err.(*remarkRowError); !ok {
+				return report, err
+			}
+		}
+		row.Row = n
+		if err != nil {
+			row.Err = err.(*remarkRowError).err
+		} else {
+			im.apply(ctx, &row, opts...)
+		}
+
+		report.Total++
+		if row.Err == nil {
+			report.Succeeded++
+		} else {
+			report.Failures = append(report.Failures, row)
+		}
+		if im.OnRow != nil {
+			im.OnRow(&row)
+		}
+	}
+}
+
+func (im *RemarkImporter) apply(ctx context.Context, row *RemarkRow, opts ...mp.CallOption) {
+	if row.OpenId == "" {
+		row.Err = errors.New("empty openid")
+		return
+	}
+	if utf8.RuneCountInString(row.Remark) > RemarkRuneLimit {
+		row.Err = fmt.Errorf("remark longer than %d characters", RemarkRuneLimit)
+		return
+	}
+
+	if im.Limiter != nil {
+		im.Limiter.Wait()
+	}
+	policy := mp.RetryPolicy{
+		MaxRetries: im.MaxRetries,
+		Backoff:    im.RetryBackoff,
+		Limiter:    im.Limiter,
+	}
+	opts = policy.CallOptions(opts)
+	row.Attempts, row.Err = policy.Do(ctx, func() error {
+		return im.Client.UserUpdateRemark(row.OpenId, row.Remark, opts...)
+	})
+}
+
+// 某一条数据格式错误, 不影响后续的数据
+type remarkRowError struct {
+	err error
+}
+
+func (e *remarkRowError) Error() string { return e.err.Error() }
+
+func detectRemarkImportFormat(br *bufio.Reader) (RemarkImportFormat, error) {
+	for n := 1; ; n++ {
+		buf, err := br.Peek(n)
+		if len(buf) < n {
+			if err == io.EOF {
+				return RemarkImportCSV, nil
+			}
+			return 0, err
+		}
+		switch c := buf[n-1]; {
+		case c == '{':
+			return RemarkImportJSONL, nil
+		case c == ' ', c == '\t', c == '\r', c == '\n': // 空白
+		default:
+			return RemarkImportCSV, nil
+		}
+	}
+}
+
+func csvRemarkRows(reader io.Reader) func() (RemarkRow, error) {
+	r := csv.NewReader(reader)
+	r.FieldsPerRecord = -1
+	r.TrimLeadingSpace = true
+	first := true
+	return func() (row RemarkRow, err error) {
+		for {
+			record, err := r.Read()
+			if err != nil {
+				if _, ok := err.(*csv.ParseError); ok {
+					return row, &remarkRowError{err}
+				}
+				return row, err
+			}
+			if first {
+				first = false
+				if len(record) > 0 && strings.EqualFold(strings.TrimSpace(record[0]), "openid") {
+					continue // 表头
+				}
+			}
+			if len(record) != 2 {
+				return row, &remarkRowError{fmt.Errorf("expected 2 fields (openid,remark), got %d", len(record))}
+			}
+			row.OpenId = strings.TrimSpace(record[0])
+			row.Remark = strings.TrimSpace(record[1])
+			return row, nil
+		}
+	}
+}
+
+func jsonlRemarkRows(br *bufio.Reader) func() (RemarkRow, error) {
+	return func() (row RemarkRow, err error) {
+		for {
+			line, err := br.ReadBytes('\n')
+			if err != nil && (err != io.EOF || len(line) == 0) {
+				return row, err
+			}
+			line = bytes.TrimSpace(line)
+			if len(line) == 0 {
+				if err == io.EOF {
+					return row, err
+				}
+				continue
+			}
+			if err := json.Unmarshal(line, &row); err != nil {
+				return row, &remarkRowError{err}
+			}
+			row.OpenId = strings.TrimSpace(row.OpenId)
+			row.Remark = strings.TrimSpace(row.Remark)
+			return row, nil
+		}
+	}
+}
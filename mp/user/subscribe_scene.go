@@ -0,0 +1,97 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package user
+
+import (
+	"strconv"
+)
+
+// 用户关注的渠道来源, 对应用户基本信息里的 subscribe_scene.
+type SubscribeScene string
+
+const (
+	SubscribeSceneSearch              = SubscribeScene("ADD_SCENE_SEARCH")               // 公众号搜索
+	SubscribeSceneAccountMigration    = SubscribeScene("ADD_SCENE_ACCOUNT_MIGRATION")    // 公众号迁移
+	SubscribeSceneProfileCard         = SubscribeScene("ADD_SCENE_PROFILE_CARD")         // 名片分享
+	SubscribeSceneQRCode              = SubscribeScene("ADD_SCENE_QR_CODE")              // 扫描二维码
+	SubscribeSceneProfileLink         = SubscribeScene("ADD_SCENE_PROFILE_LINK")         // 图文页内名称点击
+	SubscribeSceneProfileItem         = SubscribeScene("ADD_SCENE_PROFILE_ITEM")         // 图文页右上角菜单
+	SubscribeScenePaid                = SubscribeScene("ADD_SCENE_PAID")                 // 支付后关注
+	SubscribeSceneWechatAdvertisement = SubscribeScene("ADD_SCENE_WECHAT_ADVERTISEMENT") // 微信广告
+	SubscribeSceneReprint             = SubscribeScene("ADD_SCENE_REPRINT")              // 他人转载
+	SubscribeSceneLivestream          = SubscribeScene("ADD_SCENE_LIVESTREAM")           // 视频号直播
+	SubscribeSceneChannels            = SubscribeScene("ADD_SCENE_CHANNELS")             // 视频号
+	SubscribeSceneWXA                 = SubscribeScene("ADD_SCENE_WXA")                  // 小程序关注
+	SubscribeSceneOthers              = SubscribeScene("ADD_SCENE_OTHERS")               // 其他
+)
+
+var subscribeSceneDescriptions = map[SubscribeScene]string{
+	SubscribeSceneSearch:              "公众号搜索",
+	SubscribeSceneAccountMigration:    "公众号迁移",
+	SubscribeSceneProfileCard:         "名片分享",
+	SubscribeSceneQRCode:              "扫描二维码",
+	SubscribeSceneProfileLink:         "图文页内名称点击",
+	SubscribeSceneProfileItem:         "图文页右上角菜单",
+	SubscribeScenePaid:                "支付后关注",
+	SubscribeSceneWechatAdvertisement: "微信广告",
+	SubscribeSceneReprint:             "他人转载",
+	SubscribeSceneLivestream:          "视频号直播",
+	SubscribeSceneChannels:            "视频号",
+	SubscribeSceneWXA:                 "小程序关注",
+	SubscribeSceneOthers:              "其他",
+}
+
+// 是否是已知的渠道来源.
+func (scene SubscribeScene) Known() bool {
+	_, ok := subscribeSceneDescriptions[scene]
+	return ok
+}
+
+// 渠道来源的中文描述, 未知的来源返回原始值.
+func (scene SubscribeScene) Description() string {
+	if desc, ok := subscribeSceneDescriptions[scene]; ok {
+		return desc
+	}
+	return string(scene)
+}
+
+// 是否通过扫描二维码关注.
+func (info *UserInfo) SubscribedViaQRCode() bool {
+	return info.SubscribeScene == SubscribeSceneQRCode
+}
+
+// 是否通过公众号搜索关注.
+func (info *UserInfo) SubscribedViaSearch() bool {
+	return info.SubscribeScene == SubscribeSceneSearch
+}
+
+// 是否通过微信广告关注.
+func (info *UserInfo) SubscribedViaAdvertisement() bool {
+	return info.SubscribeScene == SubscribeSceneWechatAdvertisement
+}
+
+// 是否通过名片分享或者他人转载关注.
+func (info *UserInfo) SubscribedViaShare() bool {
+	return info.SubscribeScene == SubscribeSceneProfileCard || info.SubscribeScene == SubscribeSceneReprint
+}
+
+// 是否通过图文消息页面关注(图文页内名称点击, 图文页右上角菜单).
+func (info *UserInfo) SubscribedViaArticle() bool {
+	return info.SubscribeScene == SubscribeSceneProfileLink || info.SubscribeScene == SubscribeSceneProfileItem
+}
+
+// 二维码的场景值, 字符串形式的场景值(qr_scene_str)优先, 没有则返回整数场景值(qr_scene)的字符串形式;
+// 不是通过扫描二维码关注的返回 "".
+//  和 account 包创建二维码时的场景值对应, 可以用来做关注的渠道统计.
+func (info *UserInfo) QRSceneKey() string {
+	if info.QRSceneStr != "" {
+		return info.QRSceneStr
+	}
+	if info.QRScene != 0 {
+		return strconv.FormatInt(info.QRScene, 10)
+	}
+	return ""
+}
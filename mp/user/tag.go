@@ -0,0 +1,105 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package user
+
+import (
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 用户标签
+type Tag struct {
+	Id        int64  `json:"id"`    // 标签id, 由微信分配
+	Name      string `json:"name"`  // 标签名, UTF8编码
+	UserCount int    `json:"count"` // 此标签下粉丝数
+}
+
+// 获取公众号已创建的标签.
+func (clt *Client) TagList(opts ...mp.CallOption) (tags []Tag, err error) {
+	var result struct {
+		mp.Error
+		Tags []Tag `json:"tags"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/tags/get?access_token="
+	if err = clt.GetJSON(incompleteURL, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	tags = result.Tags
+	return
+}
+
+// 获取标签下粉丝列表的结果
+type TagUserListResult struct {
+	GotCount int `json:"count"` // 这次获取的粉丝数量
+
+	Data struct {
+		OpenId []string `json:"openid,omitempty"`
+	} `json:"data"` // 粉丝列表
+
+	// 拉取列表最后一个用户的openid, 如果 next_openid == "" 则表示没有了用户数据
+	NextOpenId string `json:"next_openid"`
+}
+
+// 获取标签下粉丝列表, 每次最多拉取 UserPageSizeLimit 个.
+//  beginOpenId: 第一个拉取的OPENID, 为空默认从头开始拉取
+func (clt *Client) TagUserList(tagId int64, beginOpenId string, opts ...mp.CallOption) (data *TagUserListResult, err error) {
+	var request = struct {
+		TagId      int64  `json:"tagid"`
+		NextOpenId string `json:"next_openid"`
+	}{
+		TagId:      tagId,
+		NextOpenId: beginOpenId,
+	}
+
+	var result struct {
+		mp.Error
+		TagUserListResult
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/user/tag/get?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	data = &result.TagUserListResult
+	return
+}
+
+// 获取公众号的黑名单列表, 每次最多拉取 UserPageSizeLimit 个.
+//  beginOpenId: 第一个拉取的OPENID, 为空默认从头开始拉取
+func (clt *Client) BlacklistList(beginOpenId string, opts ...mp.CallOption) (data *UserListResult, err error) {
+	var request = struct {
+		BeginOpenId string `json:"begin_openid"`
+	}{
+		BeginOpenId: beginOpenId,
+	}
+
+	var result struct {
+		mp.Error
+		UserListResult
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/tags/members/getblacklist?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	data = &result.UserListResult
+	return
+}
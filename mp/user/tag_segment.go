@@ -0,0 +1,144 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package user
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 遍历标签下的全部粉丝, 对每个 openid 调用 fn; fn 返回错误时停止遍历并返回该错误.
+//  beginOpenId: 第一个拉取的OPENID, 为空默认从头开始拉取
+//  需要断点续传时请用 TagUserIterator, 它的 Cursor 可以保存下来.
+func (clt *Client) TagUserStream(tagId int64, beginOpenId string, fn func(openid string) error, opts ...mp.CallOption) (err error) {
+	if fn == nil {
+		return errors.New("nil fn")
+	}
+	iter := clt.TagUserIterator(tagId, beginOpenId, opts...)
+	for iter.HasNext() {
+		openids, err := iter.NextPage()
+		if err != nil {
+			return err
+		}
+		for _, openid := range openids {
+			if openid == "" {
+				continue
+			}
+			if err = fn(openid); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// 获取标签下全部粉丝的集合.
+func (clt *Client) tagUserSet(tagId int64, opts ...mp.CallOption) (set map[string]struct{}, err error) {
+	set = make(map[string]struct{})
+	err = clt.TagUserStream(tagId, "", func(openid string) error {
+		set[openid] = struct{}{}
+		return nil
+	}, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return
+}
+
+// 遍历属于 tagIds 中任意一个标签的粉丝(并集), 每个 openid 只调用 fn 一次.
+//  需要在内存里记录已经遍历过的 openid.
+func (clt *Client) TagUnion(tagIds []int64, fn func(openid string) error, opts ...mp.CallOption) (err error) {
+	if fn == nil {
+		return errors.New("nil fn")
+	}
+	seen := make(map[string]struct{})
+	for _, tagId := range tagIds {
+		err = clt.TagUserStream(tagId, "", func(openid string) error {
+			if _, ok := seen[openid]; ok {
+				return nil
+			}
+			seen[openid] = struct{}{}
+			return fn(openid)
+		}, opts...)
+		if err != nil {
+			return
+		}
+	}
+	return
+}
+
+// 遍历同时属于 tagIds 中所有标签的粉丝(交集).
+//  除最后一个标签外的粉丝需要加载到内存, 所以请把粉丝数最多的标签放在最后, 粉丝数最少的标签放在最前.
+func (clt *Client) TagIntersection(tagIds []int64, fn func(openid string) error, opts ...mp.CallOption) (err error) {
+	if fn == nil {
+		return errors.New("nil fn")
+	}
+	switch len(tagIds) {
+	case 0:
+		return nil
+	case 1:
+		return clt.TagUserStream(tagIds[0], "", fn, opts...)
+	}
+
+	set, err := clt.tagUserSet(tagIds[0], opts...)
+	if err != nil {
+		return
+	}
+	for _, tagId := range tagIds[1 : len(tagIds)-1] {
+		if len(set) == 0 {
+			return nil
+		}
+		next := make(map[string]struct{}, len(set))
+		err = clt.TagUserStream(tagId, "", func(openid string) error {
+			if _, ok := set[openid]; ok {
+				next[openid] = struct{}{}
+			}
+			return nil
+		}, opts...)
+		if err != nil {
+			return
+		}
+		set = next
+	}
+	if len(set) == 0 {
+		return nil
+	}
+	return clt.TagUserStream(tagIds[len(tagIds)-1], "", func(openid string) error {
+		if _, ok := set[openid]; !ok {
+			return nil
+		}
+		delete(set, openid) // 防止重复
+		return fn(openid)
+	}, opts...)
+}
+
+// 遍历属于标签 tagId 但是不属于 excludeTagIds 中任何一个标签的粉丝(差集).
+//  excludeTagIds 里的标签的粉丝需要加载到内存, tagId 的粉丝是流式遍历的.
+func (clt *Client) TagDifference(tagId int64, excludeTagIds []int64, fn func(openid string) error, opts ...mp.CallOption) (err error) {
+	if fn == nil {
+		return errors.New("nil fn")
+	}
+	excluded := make(map[string]struct{})
+	for _, excludeTagId := range excludeTagIds {
+		if excludeTagId == tagId {
+			return nil
+		}
+		err = clt.TagUserStream(excludeTagId, "", func(openid string) error {
+			excluded[openid] = struct{}{}
+			return nil
+		}, opts...)
+		if err != nil {
+			return
+		}
+	}
+	return clt.TagUserStream(tagId, "", func(openid string) error {
+		if _, ok := excluded[openid]; ok {
+			return nil
+		}
+		return fn(openid)
+	}, opts...)
+}
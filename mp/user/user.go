@@ -6,19 +6,21 @@
 package user
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/chanxuehong/wechat/mp"
 )
 
 const (
-	Language_zh_CN = "zh_CN" // 简体中文
-	Language_zh_TW = "zh_TW" // 繁体中文
-	Language_en    = "en"    // 英文
+	Language_zh_CN = mp.Language_zh_CN // 简体中文
+	Language_zh_TW = mp.Language_zh_TW // 繁体中文
+	Language_en    = mp.Language_en    // 英文
 )
 
 const (
@@ -48,6 +50,18 @@ type UserInfo struct {
 
 	// 备注名
 	Remark string `json:"remark,omitempty"`
+
+	// 用户关注的渠道来源, 参考 SubscribeScene
+	SubscribeScene SubscribeScene `json:"subscribe_scene,omitempty"`
+
+	// 二维码扫码场景(开发者自定义), 只有通过扫描二维码关注时才有值
+	QRScene    int64  `json:"qr_scene,omitempty"`
+	QRSceneStr string `json:"qr_scene_str,omitempty"` // 二维码扫码场景描述(开发者自定义)
+}
+
+// 返回北京时间的关注时间.
+func (info *UserInfo) SubscribeAt() time.Time {
+	return mp.UnixToTime(info.SubscribeTime)
 }
 
 var ErrNoHeadImage = errors.New("没有头像")
@@ -89,19 +103,14 @@ func (info *UserInfo) HeadImageSize() (size int, err error) {
 var ErrUserNotSubscriber = errors.New("用户没有订阅公众号")
 
 // 获取用户基本信息, 如果用户没有订阅公众号, 返回 ErrUserNotSubscriber 错误.
-//  lang 可以是 zh_CN, zh_TW, en, 如果留空 "" 则默认为 zh_CN.
-func (clt *Client) UserInfo(openId string, lang string) (userinfo *UserInfo, err error) {
+//  lang 可以是 zh_CN, zh_TW, en, 如果留空 "" 则默认为 zh_CN; 其他写法(比如 zh-TW, en-US)见 mp.NormalizeLanguage.
+func (clt *Client) UserInfo(openId string, lang string, opts ...mp.CallOption) (userinfo *UserInfo, err error) {
 	if openId == "" {
 		err = errors.New("empty openId")
 		return
 	}
 
-	switch lang {
-	case "":
-		lang = Language_zh_CN
-	case Language_zh_CN, Language_zh_TW, Language_en:
-	default:
-		err = errors.New("invalid lang: " + lang)
+	if lang, err = mp.NormalizeLanguage(lang); err != nil {
 		return
 	}
 
@@ -113,7 +122,7 @@ func (clt *Client) UserInfo(openId string, lang string) (userinfo *UserInfo, err
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/user/info?openid=" + url.QueryEscape(openId) +
 		"&lang=" + url.QueryEscape(lang) + "&access_token="
-	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+	if err = clt.GetJSON(incompleteURL, &result, opts...); err != nil {
 		return
 	}
 
@@ -131,7 +140,7 @@ func (clt *Client) UserInfo(openId string, lang string) (userinfo *UserInfo, err
 
 // 开发者可以通过该接口对指定用户设置备注名.
 //  NOTE: 该接口暂时开放给微信认证的服务号.
-func (clt *Client) UserUpdateRemark(openId, remark string) (err error) {
+func (clt *Client) UserUpdateRemark(openId, remark string, opts ...mp.CallOption) (err error) {
 	var request = struct {
 		OpenId string `json:"openid"`
 		Remark string `json:"remark"`
@@ -143,7 +152,7 @@ func (clt *Client) UserUpdateRemark(openId, remark string) (err error) {
 	var result mp.Error
 
 	incompleteURL := "https://api.weixin.qq.com/cgi-bin/user/info/updateremark?access_token="
-	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
 		return
 	}
 
@@ -168,7 +177,40 @@ type UserListResult struct {
 }
 
 // 获取关注者列表, 每次最多能获取 10000 个用户, 如果 beginOpenId == "" 则表示从头获取
-func (clt *Client) UserList(beginOpenId string) (data *UserListResult, err error) {
+func (clt *Client) UserList(beginOpenId string, opts ...mp.CallOption) (data *UserListResult, err error) {
+	var result struct {
+		mp.Error
+		UserListResult
+	}
+
+	var incompleteURL string
+	if beginOpenId == "" {
+		incompleteURL = "https://api.weixin.qq.com/cgi-bin/user/get?access_token="
+	} else {
+		incompleteURL = "https://api.weixin.qq.com/cgi-bin/user/get?next_openid=" +
+			url.QueryEscape(beginOpenId) + "&access_token="
+	}
+
+	if err = clt.GetJSON(incompleteURL, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	data = &result.UserListResult
+	return
+}
+
+// 流式获取关注者列表, 每个 OPENID 回调一次 fn, 适用于关注者非常多的公众号.
+//  返回的 data.Data.OpenId 为空, 其他字段同 UserList; fn 返回非 nil 的 error 会终止获取并返回该 error.
+func (clt *Client) UserListStream(beginOpenId string, fn func(openid string) error, opts ...mp.CallOption) (data *UserListResult, err error) {
+	if fn == nil {
+		err = errors.New("nil fn")
+		return
+	}
+
 	var result struct {
 		mp.Error
 		UserListResult
@@ -182,7 +224,14 @@ func (clt *Client) UserList(beginOpenId string) (data *UserListResult, err error
 			url.QueryEscape(beginOpenId) + "&access_token="
 	}
 
-	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+	onItem := func(dec *json.Decoder) (err error) {
+		var openid string
+		if err = dec.Decode(&openid); err != nil {
+			return
+		}
+		return fn(openid)
+	}
+	if err = clt.GetJSONStream(incompleteURL, []string{"data", "openid"}, onItem, &result, opts...); err != nil {
 		return
 	}
 
@@ -0,0 +1,361 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package user
+
+import (
+	"bufio"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/message/request"
+)
+
+// 关注者的变化
+type FollowerDelta struct {
+	Subscribed   []string  // 新增的关注者 openid 列表, 已排序
+	Unsubscribed []string  // 取消关注的 openid 列表, 已排序
+	Time         time.Time // 检测到变化的时间
+	FromEvent    bool      // 是否由关注/取消关注事件产生, 否则由全量对比产生
+}
+
+// 保存关注者快照的检查点, 进程重启后从检查点继续对比, 不需要重新全量导出.
+type FollowerCheckpoint interface {
+	// 读取上一次保存的关注者快照; 从来没有保存过时返回 nil, nil.
+	Load() (openids []string, err error)
+	// 保存关注者快照.
+	Save(openids []string) error
+}
+
+var _ FollowerCheckpoint = FileFollowerCheckpoint("")
+
+// 把关注者快照保存到本地文件的 FollowerCheckpoint, 每行一个 openid.
+type FileFollowerCheckpoint string
+
+func (path FileFollowerCheckpoint) Load() (openids []string, err error) {
+	file, err := os.Open(string(path))
+	if err != nil {
+		if os.IsNotExist(err) {
+			err = nil
+		}
+		return
+	}
+	defer file.Close()
+
+	openids = make([]string, 0, 1024)
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		if line := scanner.Text(); line != "" {
+			openids = append(openids, line)
+		}
+	}
+	err = scanner.Err()
+	return
+}
+
+// 先写临时文件再改名, 保证写到一半时进程退出也不会破坏原来的快照.
+func (path FileFollowerCheckpoint) Save(openids []string) (err error) {
+	file, err := ioutil.TempFile(filepath.Dir(string(path)), filepath.Base(string(path))+".tmp")
+	if err != nil {
+		return
+	}
+	defer func() {
+		if err != nil {
+			file.Close()
+			os.Remove(file.Name())
+		}
+	}()
+
+	w := bufio.NewWriter(file)
+	for _, openid := range openids {
+		w.WriteString(openid)
+		w.WriteByte('\n')
+	}
+	if err = w.Flush(); err != nil {
+		return
+	}
+	if err = file.Close(); err != nil {
+		return
+	}
+	return os.Rename(file.Name(), string(path))
+}
+
+// 关注者变化监视器.
+//  定期全量拉取关注者列表和检查点里的快照对比, 把新增和取消的关注者通过回调通知出去,
+//  同时也可以把关注/取消关注事件交给 HandleEvent 实时通知, 定期对比用来补上丢失的事件.
+//
+//  第一次运行(检查点里没有快照)时所有关注者都作为 Subscribed 通知一次.
+//  事件产生的变化在下一次全量对比后才写入检查点, 所以进程异常退出后重启可能会重复通知这部分变化,
+//  回调需要能处理重复的通知.
+type FollowerWatcher struct {
+	clt        *Client
+	checkpoint FollowerCheckpoint
+	interval   time.Duration
+	onDelta    func(*FollowerDelta) error
+
+	mutex     sync.Mutex
+	loaded    bool
+	followers map[string]struct{}
+	syncing   bool
+	pending   map[string]bool // 全量拉取期间收到的事件, openid --> 是否关注
+
+	syncMutex sync.Mutex // 保证同时只有一个 Sync 在运行
+	startOnce sync.Once
+	started   bool
+	stopOnce  sync.Once
+	stop      chan struct{}
+	done      chan struct{}
+}
+
+// 创建关注者变化监视器.
+//  interval: 全量对比的间隔, 关注者很多时拉取一次需要很长时间, 建议不要小于 1 小时;
+//  onDelta:  变化的回调, 返回 error 时这次对比的结果不会写入检查点, 下一次对比会重新通知.
+func NewFollowerWatcher(clt *Client, checkpoint FollowerCheckpoint, interval time.Duration,
+	onDelta func(*FollowerDelta) error) *FollowerWatcher {
+
+	if clt == nil {
+		panic("nil Client")
+	}
+	if checkpoint == nil {
+		panic("nil FollowerCheckpoint")
+	}
+	if interval <= 0 {
+		panic("interval should be greater than 0")
+	}
+	if onDelta == nil {
+		panic("nil onDelta")
+	}
+	return &FollowerWatcher{
+		clt:        clt,
+		checkpoint: checkpoint,
+		interval:   interval,
+		onDelta:    onDelta,
+		stop:       make(chan struct{}),
+		done:       make(chan struct{}),
+	}
+}
+
+func (w *FollowerWatcher) load() (err error) {
+	if w.loaded {
+		return
+	}
+	openids, err := w.checkpoint.Load()
+	if err != nil {
+		return
+	}
+	w.followers = make(map[string]struct{}, len(openids))
+	for _, openid := range openids {
+		w.followers[openid] = struct{}{}
+	}
+	w.loaded = true
+	return
+}
+
+// 处理关注/取消关注事件, 其他消息直接忽略, 一般在 mp.MessageHandler 里调用.
+func (w *FollowerWatcher) HandleEvent(msg *mp.MixedMessage) (err error) {
+	if msg.MsgType != request.MsgTypeEvent {
+		return
+	}
+	var subscribed bool
+	switch msg.Event {
+	case request.EventTypeSubscribe:
+		subscribed = true
+	case request.EventTypeUnsubscribe:
+		subscribed = false
+	default:
+		return
+	}
+	openid := msg.FromUserName
+	if openid == "" {
+		return
+	}
+
+	w.mutex.Lock()
+	if err = w.load(); err != nil {
+		w.mutex.Unlock()
+		return
+	}
+	if w.syncing {
+		w.pending[openid] = subscribed
+	}
+	_, exists := w.followers[openid]
+	if exists == subscribed {
+		w.mutex.Unlock()
+		return
+	}
+	if subscribed {
+		w.followers[openid] = struct{}{}
+	} else {
+		delete(w.followers, openid)
+	}
+	w.mutex.Unlock()
+
+	delta := FollowerDelta{
+		Time:      time.Now(),
+		FromEvent: true,
+	}
+	if subscribed {
+		delta.Subscribed = []string{openid}
+	} else {
+		delta.Unsubscribed = []string{openid}
+	}
+	return w.onDelta(&delta)
+}
+
+// 立即全量拉取一次关注者列表并和快照对比, 有变化时调用 onDelta, 然后保存检查点.
+//  返回的 delta 为 nil 表示没有变化.
+func (w *FollowerWatcher) Sync() (delta *FollowerDelta, err error) {
+	w.syncMutex.Lock()
+	defer w.syncMutex.Unlock()
+
+	w.mutex.Lock()
+	if err = w.load(); err != nil {
+		w.mutex.Unlock()
+		return
+	}
+	w.syncing = true
+	w.pending = make(map[string]bool)
+	sizeHint := len(w.followers)
+	w.mutex.Unlock()
+
+	defer func() {
+		w.mutex.Lock()
+		w.syncing = false
+		w.pending = nil
+		w.mutex.Unlock()
+	}()
+
+	current, err := w.fetch(sizeHint)
+	if err != nil {
+		return
+	}
+
+	w.mutex.Lock()
+	// 拉取期间收到的事件比拉取的结果新
+	for openid, subscribed := range w.pending {
+		if subscribed {
+			current[openid] = struct{}{}
+		} else {
+			delete(current, openid)
+		}
+	}
+	var d FollowerDelta
+	for openid := range current {
+		if _, ok := w.followers[openid]; !ok {
+			d.Subscribed = append(d.Subscribed, openid)
+		}
+	}
+	for openid := range w.followers {
+		if _, ok := current[openid]; !ok {
+			d.Unsubscribed = append(d.Unsubscribed, openid)
+		}
+	}
+	w.mutex.Unlock()
+
+	if len(d.Subscribed) > 0 || len(d.Unsubscribed) > 0 {
+		sort.Strings(d.Subscribed)
+		sort.Strings(d.Unsubscribed)
+		d.Time = time.Now()
+		if err = w.onDelta(&d); err != nil {
+			return
+		}
+		delta = &d
+	}
+
+	openids := make([]string, 0, len(current))
+	for openid := range current {
+		openids = append(openids, openid)
+	}
+	sort.Strings(openids)
+	if err = w.checkpoint.Save(openids); err != nil {
+		return
+	}
+
+	w.mutex.Lock()
+	// 对比结束到这里之间收到的事件已经更新了 w.followers, 不能直接覆盖
+	for openid, subscribed := range w.pending {
+		if subscribed {
+			current[openid] = struct{}{}
+		} else {
+			delete(current, openid)
+		}
+	}
+	w.followers = current
+	w.mutex.Unlock()
+	return
+}
+
+// 全量拉取关注者列表, sizeHint 为预计的关注者数量.
+//  拉取期间 HandleEvent 会修改 w.followers, 所以这里不能访问 w.followers.
+func (w *FollowerWatcher) fetch(sizeHint int) (followers map[string]struct{}, err error) {
+	followers = make(map[string]struct{}, sizeHint)
+	fn := func(openid string) error {
+		followers[openid] = struct{}{}
+		return nil
+	}
+
+	var nextOpenId string
+	for {
+		rst, err := w.clt.UserListStream(nextOpenId, fn)
+		if err != nil {
+			return nil, err
+		}
+		if rst.GotCount == 0 || rst.NextOpenId == "" || rst.NextOpenId == nextOpenId {
+			return followers, nil
+		}
+		nextOpenId = rst.NextOpenId
+	}
+}
+
+// 启动后台 goroutine, 立即对比一次, 之后每隔 interval 对比一次, 直到调用 Stop.
+//  onError: 对比出错时的回调, 可以为 nil.
+func (w *FollowerWatcher) Start(onError func(error)) {
+	w.startOnce.Do(func() {
+		w.mutex.Lock()
+		w.started = true
+		w.mutex.Unlock()
+		go w.run(onError)
+	})
+}
+
+func (w *FollowerWatcher) run(onError func(error)) {
+	defer close(w.done)
+
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-w.stop:
+			return
+		default:
+		}
+		if _, err := w.Sync(); err != nil && onError != nil {
+			onError(err)
+		}
+		select {
+		case <-ticker.C:
+		case <-w.stop:
+			return
+		}
+	}
+}
+
+// 停止后台对比, 等待正在进行的对比结束. 没有调用过 Start 时直接返回.
+func (w *FollowerWatcher) Stop() {
+	w.stopOnce.Do(func() { close(w.stop) })
+
+	w.mutex.Lock()
+	started := w.started
+	w.mutex.Unlock()
+	if started {
+		<-w.done
+	}
+}
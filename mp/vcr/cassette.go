@@ -0,0 +1,62 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package vcr
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+// 一次请求和响应
+type Interaction struct {
+	Method       string      `json:"method"`
+	URL          string      `json:"url"`                    // 脱敏后的 URL
+	RequestBody  string      `json:"request_body,omitempty"` // 脱敏后的请求, 只记录 JSON 请求
+	StatusCode   int         `json:"status_code"`
+	Header       http.Header `json:"header,omitempty"`
+	ResponseBody string      `json:"response_body"` // 脱敏后的响应
+
+	// 为 true 表示 ResponseBody 是 base64 编码的二进制数据, 比如下载的多媒体文件
+	ResponseBodyBase64 bool `json:"response_body_base64,omitempty"`
+}
+
+// fixture 文件的内容
+type Cassette struct {
+	Interactions []*Interaction `json:"interactions"`
+}
+
+// 从文件读取 Cassette.
+func LoadCassette(path string) (cassette *Cassette, err error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return
+	}
+	cassette = new(Cassette)
+	if err = json.Unmarshal(data, cassette); err != nil {
+		cassette = nil
+		return
+	}
+	return
+}
+
+// 保存 Cassette 到文件, 目录不存在会自动创建.
+func (cassette *Cassette) Save(path string) (err error) {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false) // URL 里的 & 保持原样, 方便阅读
+	encoder.SetIndent("", "\t")
+	if err = encoder.Encode(cassette); err != nil {
+		return
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return
+	}
+	return ioutil.WriteFile(path, buf.Bytes(), 0644)
+}
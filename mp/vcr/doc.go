@@ -0,0 +1,19 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 录制和回放微信服务器的 http 响应, 用于集成测试.
+//
+// 第一次在本地用真实的帐号以 ModeRecord 运行测试, 微信服务器的响应脱敏后保存到 fixture 文件;
+// CI 里以 ModeReplay 运行, 直接从 fixture 文件返回响应, 不需要帐号和网络.
+//
+//  recorder, err := vcr.New("testdata/menu_get.json", vcr.ModeReplay, nil)
+//  if err != nil {
+//      // TODO: 增加你的代码
+//  }
+//  defer recorder.Stop()
+//
+//  clt := menu.NewClient(tokenServer, recorder.HttpClient())
+//  // TODO: 增加你的代码
+package vcr
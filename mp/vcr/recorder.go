@@ -0,0 +1,224 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package vcr
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"unicode/utf8"
+)
+
+type Mode int
+
+const (
+	ModeReplay         Mode = iota // 只从 fixture 文件回放, 找不到匹配的请求返回错误
+	ModeRecord                     // 请求真实的微信服务器, 并把响应录制到 fixture 文件(覆盖)
+	ModeReplayOrRecord             // fixture 文件存在则回放, 否则录制
+)
+
+var _ http.RoundTripper = (*Recorder)(nil)
+
+// 录制和回放的 http.RoundTripper.
+type Recorder struct {
+	mode      Mode
+	path      string
+	transport http.RoundTripper
+	sanitizer *Sanitizer
+
+	mutex    sync.Mutex
+	cassette *Cassette
+	replayed []bool // 回放模式下每个 Interaction 是否已经回放过
+}
+
+// 创建一个新的 Recorder.
+//  path:      fixture 文件的路径
+//  transport: 录制时实际发送请求的 http.RoundTripper, 为 nil 时使用 http.DefaultTransport
+func New(path string, mode Mode, transport http.RoundTripper) (recorder *Recorder, err error) {
+	if path == "" {
+		err = errors.New("empty path")
+		return
+	}
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	if mode == ModeReplayOrRecord {
+		if _, err = os.Stat(path); err == nil {
+			mode = ModeReplay
+		} else if os.IsNotExist(err) {
+			mode = ModeRecord
+			err = nil
+		} else {
+			return
+		}
+	}
+
+	recorder = &Recorder{
+		mode:      mode,
+		path:      path,
+		transport: transport,
+		sanitizer: NewSanitizer(DefaultSensitiveKeys...),
+	}
+	switch mode {
+	case ModeReplay:
+		if recorder.cassette, err = LoadCassette(path); err != nil {
+			recorder = nil
+			return
+		}
+		recorder.replayed = make([]bool, len(recorder.cassette.Interactions))
+	case ModeRecord:
+		recorder.cassette = new(Cassette)
+	default:
+		recorder = nil
+		err = fmt.Errorf("invalid mode: %d", mode)
+	}
+	return
+}
+
+// 设置脱敏规则, 默认为 NewSanitizer(DefaultSensitiveKeys...).
+//  NOTE: 录制和回放要使用相同的规则, 并且要在发送请求之前设置.
+func (recorder *Recorder) SetSanitizer(sanitizer *Sanitizer) {
+	if sanitizer == nil {
+		sanitizer = NewSanitizer()
+	}
+	recorder.mutex.Lock()
+	recorder.sanitizer = sanitizer
+	recorder.mutex.Unlock()
+}
+
+// 当前的模式, ModeReplayOrRecord 会被解析为 ModeReplay 或 ModeRecord.
+func (recorder *Recorder) Mode() Mode {
+	return recorder.mode
+}
+
+// 返回使用该 Recorder 的 http.Client.
+func (recorder *Recorder) HttpClient() *http.Client {
+	return &http.Client{Transport: recorder}
+}
+
+// 结束录制或回放, 录制模式下保存 fixture 文件.
+func (recorder *Recorder) Stop() error {
+	if recorder.mode != ModeRecord {
+		return nil
+	}
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+	return recorder.cassette.Save(recorder.path)
+}
+
+func (recorder *Recorder) RoundTrip(req *http.Request) (resp *http.Response, err error) {
+	var reqBody []byte
+	if req.Body != nil {
+		if reqBody, err = ioutil.ReadAll(req.Body); err != nil {
+			return
+		}
+		req.Body.Close()
+		req.Body = ioutil.NopCloser(bytes.NewReader(reqBody))
+	}
+
+	recorder.mutex.Lock()
+	sanitizer := recorder.sanitizer
+	recorder.mutex.Unlock()
+
+	interaction := &Interaction{
+		Method: req.Method,
+		URL:    sanitizer.URL(req.URL.String()),
+	}
+	if isJSON(req.Header.Get("Content-Type")) {
+		interaction.RequestBody = sanitizer.Body(string(reqBody))
+	}
+
+	if recorder.mode == ModeReplay {
+		return recorder.replay(req, interaction)
+	}
+
+	if resp, err = recorder.transport.RoundTrip(req); err != nil {
+		return
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	interaction.StatusCode = resp.StatusCode
+	interaction.Header = http.Header{}
+	if contentType := resp.Header.Get("Content-Type"); contentType != "" {
+		interaction.Header.Set("Content-Type", contentType)
+	}
+	if contentDisposition := resp.Header.Get("Content-Disposition"); contentDisposition != "" {
+		interaction.Header.Set("Content-Disposition", contentDisposition)
+	}
+	if utf8.Valid(respBody) {
+		interaction.ResponseBody = sanitizer.Body(string(respBody))
+	} else {
+		interaction.ResponseBody = base64.StdEncoding.EncodeToString(respBody)
+		interaction.ResponseBodyBase64 = true
+	}
+
+	recorder.mutex.Lock()
+	recorder.cassette.Interactions = append(recorder.cassette.Interactions, interaction)
+	recorder.mutex.Unlock()
+	return
+}
+
+// 按照录制的顺序查找第一个没有回放过的匹配的请求; 如果都回放过了, 则使用最后一个匹配的请求.
+func (recorder *Recorder) replay(req *http.Request, want *Interaction) (resp *http.Response, err error) {
+	recorder.mutex.Lock()
+	defer recorder.mutex.Unlock()
+
+	matched := -1
+	for i, have := range recorder.cassette.Interactions {
+		if have.Method != want.Method || have.URL != want.URL || have.RequestBody != want.RequestBody {
+			continue
+		}
+		matched = i
+		if !recorder.replayed[i] {
+			break
+		}
+	}
+	if matched < 0 {
+		err = fmt.Errorf("vcr: no recorded interaction for %s %s", want.Method, want.URL)
+		return
+	}
+	recorder.replayed[matched] = true
+
+	interaction := recorder.cassette.Interactions[matched]
+	respBody := []byte(interaction.ResponseBody)
+	if interaction.ResponseBodyBase64 {
+		if respBody, err = base64.StdEncoding.DecodeString(interaction.ResponseBody); err != nil {
+			return
+		}
+	}
+	header := http.Header{}
+	for k, v := range interaction.Header {
+		header[k] = v
+	}
+	resp = &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.StatusCode, http.StatusText(interaction.StatusCode)),
+		StatusCode:    interaction.StatusCode,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(respBody)),
+		ContentLength: int64(len(respBody)),
+		Request:       req,
+	}
+	return
+}
+
+func isJSON(contentType string) bool {
+	return strings.Contains(strings.ToLower(contentType), "json")
+}
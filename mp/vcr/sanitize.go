@@ -0,0 +1,77 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package vcr
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// 脱敏后的值
+const Filtered = "FILTERED"
+
+// 默认需要脱敏的 URL 参数和 JSON 字段
+var DefaultSensitiveKeys = []string{
+	"access_token",
+	"secret",
+	"appsecret",
+	"refresh_token",
+	"component_access_token",
+	"component_appsecret",
+	"component_verify_ticket",
+	"authorizer_access_token",
+	"authorizer_refresh_token",
+	"ticket",
+	"code",
+	"session_key",
+}
+
+// 脱敏规则
+type Sanitizer struct {
+	keys   map[string]bool
+	bodyRE *regexp.Regexp
+}
+
+// 创建一个新的 Sanitizer, keys 为需要脱敏的 URL 参数和 JSON 字段, 一般为 DefaultSensitiveKeys.
+func NewSanitizer(keys ...string) *Sanitizer {
+	sanitizer := &Sanitizer{
+		keys: make(map[string]bool, len(keys)),
+	}
+	quoted := make([]string, 0, len(keys))
+	for _, key := range keys {
+		sanitizer.keys[key] = true
+		quoted = append(quoted, regexp.QuoteMeta(key))
+	}
+	if len(quoted) > 0 {
+		sanitizer.bodyRE = regexp.MustCompile(`"(` + strings.Join(quoted, "|") + `)"(\s*:\s*)"(?:[^"\\]|\\.)*"`)
+	}
+	return sanitizer
+}
+
+// 脱敏 URL 的参数, 参数按照名称排序, 以便回放时匹配.
+func (sanitizer *Sanitizer) URL(rawURL string) string {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return rawURL
+	}
+	query := u.Query()
+	for key := range query {
+		if sanitizer.keys[key] {
+			query.Set(key, Filtered)
+		}
+	}
+	u.RawQuery = query.Encode() // Encode 是按 key 排序的
+	return u.String()
+}
+
+// 脱敏 JSON 里的字符串字段.
+func (sanitizer *Sanitizer) Body(body string) string {
+	if sanitizer.bodyRE == nil {
+		return body
+	}
+	return sanitizer.bodyRE.ReplaceAllString(body, `"$1"$2"`+Filtered+`"`)
+}
@@ -0,0 +1,31 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 关注后的欢迎流程.
+//  Engine 在收到关注事件以后, 按照 Flow 里配置的步骤(延迟, 条件)通过 scheduler.Scheduler 依次发送消息,
+//  可以根据关注的场景值(带参数二维码)给不同渠道的用户配置不同的流程.
+//
+//  使用示例:
+//      s := scheduler.NewScheduler(scheduler.NewMemoryJobStore())
+//      s.Handle(scheduler.KindCustomText, scheduler.CustomTextExecutor(customClient))
+//      s.Start(nil)
+//
+//      engine := welcome.NewEngine(s)
+//      engine.AddFlow(&welcome.Flow{
+//          Name:      "campaign",
+//          Condition: welcome.ScenePrefix("campaign_"),
+//          Steps: []welcome.Step{
+//              welcome.TextStep(0, "感谢关注"),
+//              welcome.TextStep(time.Hour, "这是活动的详细介绍..."),
+//          },
+//      })
+//      engine.AddFlow(&welcome.Flow{
+//          Name:  "default",
+//          Steps: []welcome.Step{welcome.TextStep(0, "欢迎关注")},
+//      })
+//
+//      // 在 mp.MessageHandler 里
+//      engine.HandleEvent(r.MixedMsg)
+package welcome
@@ -0,0 +1,131 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package welcome
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/mp/message/request"
+	"github.com/chanxuehong/wechat/mp/scheduler"
+)
+
+// 收到关注事件时, 选择第一个满足条件的 Flow, 把它的步骤添加到 Scheduler.
+//  用户在步骤执行前取消关注的话, 发送会得到用户未关注的错误, scheduler 不会重试.
+type Engine struct {
+	Scheduler *scheduler.Scheduler
+
+	rwmutex sync.RWMutex
+	flows   []*Flow
+}
+
+func NewEngine(s *scheduler.Scheduler) *Engine {
+	if s == nil {
+		panic("nil Scheduler")
+	}
+	return &Engine{
+		Scheduler: s,
+	}
+}
+
+// 添加流程, 按照添加的顺序匹配.
+func (e *Engine) AddFlow(flow *Flow) {
+	if flow == nil {
+		panic("nil Flow")
+	}
+	e.rwmutex.Lock()
+	e.flows = append(e.flows, flow)
+	e.rwmutex.Unlock()
+}
+
+// 删除名称为 name 的流程.
+func (e *Engine) RemoveFlow(name string) {
+	e.rwmutex.Lock()
+	defer e.rwmutex.Unlock()
+
+	flows := e.flows[:0]
+	for _, flow := range e.flows {
+		if flow.Name != name {
+			flows = append(flows, flow)
+		}
+	}
+	for i := len(flows); i < len(e.flows); i++ {
+		e.flows[i] = nil
+	}
+	e.flows = flows
+}
+
+func (e *Engine) flow(sub *Subscription) *Flow {
+	e.rwmutex.RLock()
+	defer e.rwmutex.RUnlock()
+
+	for _, flow := range e.flows {
+		if flow.match(sub) {
+			return flow
+		}
+	}
+	return nil
+}
+
+// 处理关注事件, 其他消息直接忽略, 一般在 mp.MessageHandler 里调用.
+//  返回添加到 Scheduler 的任务, 没有满足条件的流程时返回 nil.
+func (e *Engine) HandleEvent(msg *mp.MixedMessage) (jobs []*scheduler.Job, err error) {
+	if msg.MsgType != request.MsgTypeEvent || msg.Event != request.EventTypeSubscribe {
+		return
+	}
+	if msg.FromUserName == "" {
+		return
+	}
+
+	sub := &Subscription{
+		OpenId:       msg.FromUserName,
+		Ticket:       msg.Ticket,
+		SubscribedAt: time.Now(),
+	}
+	if msg.CreateTime > 0 {
+		sub.SubscribedAt = time.Unix(msg.CreateTime, 0)
+	}
+	const prefix = "qrscene_"
+	if strings.HasPrefix(msg.EventKey, prefix) {
+		sub.Scene = msg.EventKey[len(prefix):]
+	}
+	return e.Start(sub)
+}
+
+// 给 sub 启动第一个满足条件的流程, 也可以用于补发(比如处理消息失败以后).
+func (e *Engine) Start(sub *Subscription) (jobs []*scheduler.Job, err error) {
+	if sub == nil || sub.OpenId == "" {
+		err = errors.New("empty openid")
+		return
+	}
+	flow := e.flow(sub)
+	if flow == nil {
+		return
+	}
+
+	for _, step := range flow.Steps {
+		if step.Condition != nil && !step.Condition(sub) {
+			continue
+		}
+		payload := step.Payload
+		if fn, ok := payload.(PayloadFunc); ok {
+			payload = fn(sub)
+		}
+		job, err := e.Scheduler.Schedule(step.Kind, sub.OpenId, payload, sub.SubscribedAt.Add(step.Delay))
+		if err != nil {
+			// 已经添加的任务取消掉, 避免用户只收到流程的一部分
+			for _, job := range jobs {
+				e.Scheduler.Cancel(job.Id)
+			}
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return
+}
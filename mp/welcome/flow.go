@@ -0,0 +1,112 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package welcome
+
+import (
+	"strings"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp/scheduler"
+)
+
+// 一次关注的信息
+type Subscription struct {
+	OpenId       string
+	Scene        string    // 扫描带参数二维码关注时的场景值(去掉了 qrscene_ 前缀), 否则为 ""
+	Ticket       string    // 扫描带参数二维码关注时二维码的 ticket
+	SubscribedAt time.Time // 关注的时间
+}
+
+// 是否是扫描带参数二维码关注的
+func (sub *Subscription) FromQRCode() bool {
+	return sub.Ticket != "" || sub.Scene != ""
+}
+
+// 判断流程或者步骤是否适用于某次关注
+type Condition func(sub *Subscription) bool
+
+// 场景值等于 scenes 之一
+func SceneIs(scenes ...string) Condition {
+	return func(sub *Subscription) bool {
+		for _, scene := range scenes {
+			if sub.Scene == scene {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// 场景值以 prefix 开头
+func ScenePrefix(prefix string) Condition {
+	return func(sub *Subscription) bool {
+		return sub.FromQRCode() && strings.HasPrefix(sub.Scene, prefix)
+	}
+}
+
+// 扫描带参数二维码关注
+func FromQRCode() Condition {
+	return func(sub *Subscription) bool {
+		return sub.FromQRCode()
+	}
+}
+
+// 不满足 cond
+func Not(cond Condition) Condition {
+	return func(sub *Subscription) bool {
+		return !cond(sub)
+	}
+}
+
+// 流程中的一个步骤, 对应 scheduler 里的一个任务.
+type Step struct {
+	Delay     time.Duration // 相对关注时间的延迟
+	Condition Condition     // 可以为 nil, 表示总是执行
+	Kind      string        // 任务的类型, 比如 scheduler.KindCustomText
+	// 任务的内容, 如果是 PayloadFunc 则每次关注都调用它生成内容
+	Payload interface{}
+}
+
+// 根据关注信息生成任务的内容
+type PayloadFunc func(sub *Subscription) interface{}
+
+// 发送文本客服消息的步骤, 需要给 Scheduler 登记 scheduler.CustomTextExecutor.
+func TextStep(delay time.Duration, content string) Step {
+	return Step{
+		Delay:   delay,
+		Kind:    scheduler.KindCustomText,
+		Payload: &scheduler.CustomTextPayload{Content: content},
+	}
+}
+
+// 发送通知的步骤, 需要给 Scheduler 登记 scheduler.NotifyExecutor.
+func NotifyStep(delay time.Duration, notificationId string, data map[string]interface{}) Step {
+	return Step{
+		Delay: delay,
+		Kind:  scheduler.KindNotify,
+		Payload: &scheduler.NotifyPayload{
+			NotificationId: notificationId,
+			Data:           data,
+		},
+	}
+}
+
+// 只在满足 cond 时执行的步骤
+func (step Step) If(cond Condition) Step {
+	step.Condition = cond
+	return step
+}
+
+// 欢迎流程
+type Flow struct {
+	Name      string
+	Condition Condition // 可以为 nil, 表示适用于所有的关注
+	Steps     []Step
+}
+
+func (flow *Flow) match(sub *Subscription) bool {
+	return flow.Condition == nil || flow.Condition(sub)
+}
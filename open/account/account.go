@@ -0,0 +1,119 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package account
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 创建开放平台帐号并绑定公众号/小程序, 返回开放平台帐号 appid.
+//  appId: 公众号或小程序的 appid
+func (clt *Client) Create(appId string, opts ...mp.CallOption) (openAppId string, err error) {
+	if appId == "" {
+		err = errors.New("empty appId")
+		return
+	}
+
+	var request = struct {
+		AppId string `json:"appid"`
+	}{
+		AppId: appId,
+	}
+
+	var result struct {
+		mp.Error
+		OpenAppId string `json:"open_appid"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/open/create?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	openAppId = result.OpenAppId
+	return
+}
+
+// 将公众号/小程序绑定到开放平台帐号下.
+//  appId:     公众号或小程序的 appid
+//  openAppId: 开放平台帐号 appid
+func (clt *Client) Bind(appId, openAppId string, opts ...mp.CallOption) (err error) {
+	return clt.bindOrUnbind("https://api.weixin.qq.com/cgi-bin/open/bind?access_token=", appId, openAppId, opts...)
+}
+
+// 将公众号/小程序从开放平台帐号下解绑.
+//  appId:     公众号或小程序的 appid
+//  openAppId: 开放平台帐号 appid
+func (clt *Client) Unbind(appId, openAppId string, opts ...mp.CallOption) (err error) {
+	return clt.bindOrUnbind("https://api.weixin.qq.com/cgi-bin/open/unbind?access_token=", appId, openAppId, opts...)
+}
+
+func (clt *Client) bindOrUnbind(incompleteURL, appId, openAppId string, opts ...mp.CallOption) (err error) {
+	if appId == "" {
+		return errors.New("empty appId")
+	}
+	if openAppId == "" {
+		return errors.New("empty openAppId")
+	}
+
+	var request = struct {
+		AppId     string `json:"appid"`
+		OpenAppId string `json:"open_appid"`
+	}{
+		AppId:     appId,
+		OpenAppId: openAppId,
+	}
+
+	var result mp.Error
+
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 获取公众号/小程序所绑定的开放平台帐号 appid.
+//  appId: 公众号或小程序的 appid
+func (clt *Client) Get(appId string, opts ...mp.CallOption) (openAppId string, err error) {
+	if appId == "" {
+		err = errors.New("empty appId")
+		return
+	}
+
+	var request = struct {
+		AppId string `json:"appid"`
+	}{
+		AppId: appId,
+	}
+
+	var result struct {
+		mp.Error
+		OpenAppId string `json:"open_appid"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/open/get?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	openAppId = result.OpenAppId
+	return
+}
@@ -0,0 +1,8 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 开放平台帐号管理, 把公众号/小程序绑定到同一个开放平台帐号下, 以便获取相同的 unionid.
+//  接口使用公众号/小程序的 access_token 调用, 第三方平台可以使用 component.NewAuthorizerTokenServer 代授权方调用.
+package account
@@ -0,0 +1,206 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package component
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 获取预授权码 pre_auth_code, 用于构造授权页面 URL.
+//  expiresIn: 有效期, 单位为秒
+func (clt *Client) CreatePreAuthCode(opts ...mp.CallOption) (preAuthCode string, expiresIn int64, err error) {
+	var request = struct {
+		ComponentAppId string `json:"component_appid"`
+	}{
+		ComponentAppId: clt.appId,
+	}
+
+	var result struct {
+		mp.Error
+		PreAuthCode string `json:"pre_auth_code"`
+		ExpiresIn   int64  `json:"expires_in"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/component/api_create_preauthcode?component_access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	preAuthCode = result.PreAuthCode
+	expiresIn = result.ExpiresIn
+	return
+}
+
+const (
+	AuthTypeMP          = 1 // 商户点击链接后, 手机端仅展示公众号
+	AuthTypeMiniProgram = 2 // 商户点击链接后, 手机端仅展示小程序
+	AuthTypeAll         = 3 // 商户点击链接后, 手机端展示公众号和小程序
+)
+
+// 构造 PC 端授权页面的 URL.
+//  preAuthCode: 预授权码, 由 CreatePreAuthCode 获取
+//  redirectURI: 授权成功后回调的 URL, 回调时会带上 auth_code 和 expires_in 参数
+//  authType:    要授权的帐号类型, AuthTypeMP, AuthTypeMiniProgram 或 AuthTypeAll, 为 0 时不指定
+//  bizAppId:    指定授权唯一的小程序或公众号, 可以为空
+func AuthURL(componentAppId, preAuthCode, redirectURI string, authType int, bizAppId string) string {
+	return "https://mp.weixin.qq.com/cgi-bin/componentloginpage?component_appid=" + url.QueryEscape(componentAppId) +
+		"&pre_auth_code=" + url.QueryEscape(preAuthCode) +
+		"&redirect_uri=" + url.QueryEscape(redirectURI) +
+		authURLExtraQuery(authType, bizAppId)
+}
+
+// 构造移动端授权页面的 URL, 需要在微信客户端内打开.
+//  参数同 AuthURL.
+func MobileAuthURL(componentAppId, preAuthCode, redirectURI string, authType int, bizAppId string) string {
+	return "https://mp.weixin.qq.com/safe/bindcomponent?action=bindcomponent&no_scan=1&component_appid=" + url.QueryEscape(componentAppId) +
+		"&pre_auth_code=" + url.QueryEscape(preAuthCode) +
+		"&redirect_uri=" + url.QueryEscape(redirectURI) +
+		authURLExtraQuery(authType, bizAppId) + "#wechat_redirect"
+}
+
+func authURLExtraQuery(authType int, bizAppId string) (query string) {
+	if authType != 0 {
+		query += "&auth_type=" + strconv.Itoa(authType)
+	}
+	if bizAppId != "" {
+		query += "&biz_appid=" + url.QueryEscape(bizAppId)
+	}
+	return
+}
+
+// 创建预授权码并构造 PC 端授权页面的 URL.
+func (clt *Client) AuthURL(redirectURI string, authType int, bizAppId string) (authURL string, err error) {
+	preAuthCode, _, err := clt.CreatePreAuthCode()
+	if err != nil {
+		return
+	}
+	authURL = AuthURL(clt.appId, preAuthCode, redirectURI, authType, bizAppId)
+	return
+}
+
+// 创建预授权码并构造移动端授权页面的 URL.
+func (clt *Client) MobileAuthURL(redirectURI string, authType int, bizAppId string) (authURL string, err error) {
+	preAuthCode, _, err := clt.CreatePreAuthCode()
+	if err != nil {
+		return
+	}
+	authURL = MobileAuthURL(clt.appId, preAuthCode, redirectURI, authType, bizAppId)
+	return
+}
+
+// 授权给第三方平台的权限集
+type FuncInfo struct {
+	FuncscopeCategory struct {
+		Id int `json:"id"`
+	} `json:"funcscope_category"`
+}
+
+// 授权信息
+type AuthorizationInfo struct {
+	AuthorizerAppId        string     `json:"authorizer_appid"`         // 授权方 appid
+	AuthorizerAccessToken  string     `json:"authorizer_access_token"`  // 授权方的接口调用凭据
+	ExpiresIn              int64      `json:"expires_in"`               // authorizer_access_token 的有效期, 单位为秒
+	AuthorizerRefreshToken string     `json:"authorizer_refresh_token"` // 刷新令牌, 需要妥善保存
+	FuncInfo               []FuncInfo `json:"func_info"`                // 授权给第三方平台的权限集
+}
+
+// 使用授权码换取授权方的授权信息(authorizer_access_token, authorizer_refresh_token 等).
+//  authorizer_refresh_token 会自动保存到 Client 的 RefreshTokenStorage.
+//  authCode: 授权成功回调 URL 中的 auth_code, 或者 InfoType 为 authorized 推送中的 AuthorizationCode
+func (clt *Client) QueryAuth(authCode string, opts ...mp.CallOption) (info *AuthorizationInfo, err error) {
+	if authCode == "" {
+		err = errors.New("empty authCode")
+		return
+	}
+
+	var request = struct {
+		ComponentAppId    string `json:"component_appid"`
+		AuthorizationCode string `json:"authorization_code"`
+	}{
+		ComponentAppId:    clt.appId,
+		AuthorizationCode: authCode,
+	}
+
+	var result struct {
+		mp.Error
+		AuthorizationInfo AuthorizationInfo `json:"authorization_info"`
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/component/api_query_auth?component_access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	info = &result.AuthorizationInfo
+	if info.AuthorizerAppId != "" && info.AuthorizerRefreshToken != "" {
+		err = clt.refreshTokenStorage.SetRefreshToken(info.AuthorizerAppId, info.AuthorizerRefreshToken)
+	}
+	return
+}
+
+// 刷新授权方的 authorizer_access_token 的结果
+type AuthorizerToken struct {
+	AuthorizerAccessToken  string `json:"authorizer_access_token"`
+	ExpiresIn              int64  `json:"expires_in"`
+	AuthorizerRefreshToken string `json:"authorizer_refresh_token"`
+}
+
+// 使用 authorizer_refresh_token 获取(刷新)授权方的 authorizer_access_token.
+//  如果返回了新的 authorizer_refresh_token 会自动保存到 Client 的 RefreshTokenStorage.
+//  authorizerAppId: 授权方 appid
+//  refreshToken:    授权方的 authorizer_refresh_token
+func (clt *Client) RefreshAuthorizerToken(authorizerAppId, refreshToken string, opts ...mp.CallOption) (token *AuthorizerToken, err error) {
+	if authorizerAppId == "" {
+		err = errors.New("empty authorizerAppId")
+		return
+	}
+	if refreshToken == "" {
+		err = errors.New("empty refreshToken")
+		return
+	}
+
+	var request = struct {
+		ComponentAppId         string `json:"component_appid"`
+		AuthorizerAppId        string `json:"authorizer_appid"`
+		AuthorizerRefreshToken string `json:"authorizer_refresh_token"`
+	}{
+		ComponentAppId:         clt.appId,
+		AuthorizerAppId:        authorizerAppId,
+		AuthorizerRefreshToken: refreshToken,
+	}
+
+	var result struct {
+		mp.Error
+		AuthorizerToken
+	}
+
+	incompleteURL := "https://api.weixin.qq.com/cgi-bin/component/api_authorizer_token?component_access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result, opts...); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	token = &result.AuthorizerToken
+	if token.AuthorizerRefreshToken != "" && token.AuthorizerRefreshToken != refreshToken {
+		err = clt.refreshTokenStorage.SetRefreshToken(authorizerAppId, token.AuthorizerRefreshToken)
+	}
+	return
+}
@@ -0,0 +1,143 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package component
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+var _ mp.TokenServer = (*AuthorizerTokenServer)(nil)
+
+// 授权方 authorizer_access_token 中控服务器, 实现了 mp.TokenServer 接口.
+//  通过 Client.RefreshAuthorizerToken 获取 authorizer_access_token,
+//  authorizer_refresh_token 从 Client 的 RefreshTokenStorage 读取, 刷新后写回.
+type AuthorizerTokenServer struct {
+	componentClient *Client
+	authorizerAppId string
+
+	tokenGet struct {
+		sync.Mutex
+		LastToken     string // 最后一次成功从微信服务器获取的 authorizer_access_token
+		LastTimestamp int64  // 最后一次成功从微信服务器获取 authorizer_access_token 的时间戳
+	}
+
+	tokenCache struct {
+		sync.RWMutex
+		Token     string
+		ExpiresAt int64 // 过期时间戳, 已经扣除了缓冲时间
+	}
+}
+
+// 创建授权方的 authorizer_access_token 中控服务器.
+//  authorizerAppId: 授权方 appid
+func NewAuthorizerTokenServer(componentClient *Client, authorizerAppId string) *AuthorizerTokenServer {
+	if componentClient == nil {
+		panic("nil component Client")
+	}
+	if authorizerAppId == "" {
+		panic("empty authorizerAppId")
+	}
+
+	return &AuthorizerTokenServer{
+		componentClient: componentClient,
+		authorizerAppId: authorizerAppId,
+	}
+}
+
+// 授权方 appid
+func (srv *AuthorizerTokenServer) AuthorizerAppId() string {
+	return srv.authorizerAppId
+}
+
+func (srv *AuthorizerTokenServer) Token() (token string, err error) {
+	srv.tokenCache.RLock()
+	token = srv.tokenCache.Token
+	expiresAt := srv.tokenCache.ExpiresAt
+	srv.tokenCache.RUnlock()
+
+	if token != "" && time.Now().Unix() < expiresAt {
+		return
+	}
+	return srv.TokenRefresh()
+}
+
+func (srv *AuthorizerTokenServer) TokenRefresh() (token string, err error) {
+	srv.tokenGet.Lock()
+	defer srv.tokenGet.Unlock()
+
+	timeNowUnix := time.Now().Unix()
+
+	// 在收敛周期内直接返回最近一次获取的 authorizer_access_token
+	if n := srv.tokenGet.LastTimestamp; timeNowUnix >= n && timeNowUnix < n+5 {
+		token = srv.tokenGet.LastToken
+		return
+	}
+
+	refreshToken, err := srv.componentClient.refreshTokenStorage.GetRefreshToken(srv.authorizerAppId)
+	if err != nil {
+		srv.resetCache()
+		return
+	}
+
+	// RefreshAuthorizerToken 会把新的 authorizer_refresh_token 写回 RefreshTokenStorage
+	authorizerToken, err := srv.componentClient.RefreshAuthorizerToken(srv.authorizerAppId, refreshToken)
+	if err != nil {
+		srv.resetCache()
+		return
+	}
+	if authorizerToken.AuthorizerAccessToken == "" {
+		srv.resetCache()
+		err = errors.New("empty authorizer_access_token")
+		return
+	}
+
+	// 由于网络的延时, authorizer_access_token 过期时间留了一个缓冲区
+	expiresIn := authorizerToken.ExpiresIn
+	switch {
+	case expiresIn > 60*60:
+		expiresIn -= 60 * 10
+	case expiresIn > 60*5:
+		expiresIn -= 60
+	case expiresIn > 60:
+		expiresIn -= 10
+	}
+
+	srv.tokenGet.LastToken = authorizerToken.AuthorizerAccessToken
+	srv.tokenGet.LastTimestamp = timeNowUnix
+
+	srv.tokenCache.Lock()
+	srv.tokenCache.Token = authorizerToken.AuthorizerAccessToken
+	srv.tokenCache.ExpiresAt = timeNowUnix + expiresIn
+	srv.tokenCache.Unlock()
+
+	token = authorizerToken.AuthorizerAccessToken
+	return
+}
+
+func (srv *AuthorizerTokenServer) resetCache() {
+	srv.tokenCache.Lock()
+	srv.tokenCache.Token = ""
+	srv.tokenCache.ExpiresAt = 0
+	srv.tokenCache.Unlock()
+}
+
+// 创建代授权方调用接口的 mp.WechatClient.
+//  返回的 WechatClient 使用 authorizer_access_token, 现有的各个接口封装都可以直接使用, 比如:
+//
+//  clt := component.NewAuthorizerClient(componentClient, authorizerAppId)
+//  menuClient := &menu.Client{WechatClient: *clt}
+//
+//  或者使用 NewAuthorizerTokenServer 创建 mp.TokenServer 再传给各个包的 NewClient.
+func NewAuthorizerClient(componentClient *Client, authorizerAppId string) *mp.WechatClient {
+	return &mp.WechatClient{
+		TokenServer: NewAuthorizerTokenServer(componentClient, authorizerAppId),
+		HttpClient:  componentClient.HttpClient,
+	}
+}
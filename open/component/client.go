@@ -0,0 +1,61 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package component
+
+import (
+	"net/http"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+// 第三方平台的 api 客户端, 使用 component_access_token 调用接口.
+type Client struct {
+	mp.WechatClient
+	appId string // 第三方平台 appid
+
+	refreshTokenStorage RefreshTokenStorage
+}
+
+// 创建一个新的 Client.
+//  appId:       第三方平台 appid
+//  TokenServer: component_access_token 中控服务器, 一般为 ComponentTokenServer
+//  如果 HttpClient == nil 则默认用 http.DefaultClient
+//  默认使用 MemoryRefreshTokenStorage 保存授权方的 authorizer_refresh_token, 可以通过 SetRefreshTokenStorage 修改.
+func NewClient(appId string, TokenServer mp.TokenServer, HttpClient *http.Client) *Client {
+	if TokenServer == nil {
+		panic("TokenServer == nil")
+	}
+	if HttpClient == nil {
+		HttpClient = http.DefaultClient
+	}
+
+	return &Client{
+		WechatClient: mp.WechatClient{
+			TokenServer: TokenServer,
+			HttpClient:  HttpClient,
+		},
+		appId:               appId,
+		refreshTokenStorage: NewMemoryRefreshTokenStorage(),
+	}
+}
+
+// 第三方平台 appid
+func (clt *Client) AppId() string {
+	return clt.appId
+}
+
+// 设置保存授权方 authorizer_refresh_token 的 RefreshTokenStorage.
+func (clt *Client) SetRefreshTokenStorage(storage RefreshTokenStorage) {
+	if storage == nil {
+		panic("nil RefreshTokenStorage")
+	}
+	clt.refreshTokenStorage = storage
+}
+
+// 保存授权方 authorizer_refresh_token 的 RefreshTokenStorage.
+func (clt *Client) RefreshTokenStorage() RefreshTokenStorage {
+	return clt.refreshTokenStorage
+}
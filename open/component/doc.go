@@ -0,0 +1,14 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 微信开放平台第三方平台(component)的 golang 封装.
+//
+//  第三方平台的 token 生命周期:
+//  1. 微信服务器每隔10分钟向"授权事件接收URL"推送 component_verify_ticket, 由 EventServer 接收并保存到 TicketStorage;
+//  2. ComponentTokenServer 使用 component_verify_ticket 获取并缓存 component_access_token;
+//  3. Client 使用 component_access_token 创建 pre_auth_code, 构造授权页面 URL, 引导公众号/小程序管理员授权;
+//  4. 授权成功后使用 authorization_code 调用 Client.QueryAuth 换取 authorizer_access_token 和 authorizer_refresh_token;
+//  5. authorizer_access_token 过期后使用 Client.RefreshAuthorizerToken 刷新.
+package component
@@ -0,0 +1,122 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package component
+
+import (
+	"net/http"
+	"net/url"
+)
+
+const (
+	InfoTypeComponentVerifyTicket = "component_verify_ticket" // 推送 component_verify_ticket
+	InfoTypeAuthorized            = "authorized"              // 授权成功通知
+	InfoTypeUnauthorized          = "unauthorized"            // 取消授权通知
+	InfoTypeUpdateAuthorized      = "updateauthorized"        // 授权更新通知
+)
+
+// 微信服务器推送到"授权事件接收URL"的消息(解密后), 包含所有 InfoType 的字段
+type MixedMessage struct {
+	XMLName struct{} `xml:"xml" json:"-"`
+
+	AppId      string `xml:"AppId"      json:"AppId"`      // 第三方平台 appid
+	CreateTime int64  `xml:"CreateTime" json:"CreateTime"` // 时间戳
+	InfoType   string `xml:"InfoType"   json:"InfoType"`
+
+	ComponentVerifyTicket string `xml:"ComponentVerifyTicket" json:"ComponentVerifyTicket"` // InfoTypeComponentVerifyTicket
+
+	AuthorizerAppid              string `xml:"AuthorizerAppid"              json:"AuthorizerAppid"`              // 授权方 appid
+	AuthorizationCode            string `xml:"AuthorizationCode"            json:"AuthorizationCode"`            // 授权码, 可用于 Client.QueryAuth
+	AuthorizationCodeExpiredTime int64  `xml:"AuthorizationCodeExpiredTime" json:"AuthorizationCodeExpiredTime"` // 授权码过期时间戳
+	PreAuthCode                  string `xml:"PreAuthCode"                  json:"PreAuthCode"`                  // 预授权码
+}
+
+// 推送 component_verify_ticket
+type ComponentVerifyTicket struct {
+	AppId                 string `xml:"AppId"                 json:"AppId"`
+	CreateTime            int64  `xml:"CreateTime"            json:"CreateTime"`
+	InfoType              string `xml:"InfoType"              json:"InfoType"`
+	ComponentVerifyTicket string `xml:"ComponentVerifyTicket" json:"ComponentVerifyTicket"`
+}
+
+func GetComponentVerifyTicket(msg *MixedMessage) *ComponentVerifyTicket {
+	return &ComponentVerifyTicket{
+		AppId:                 msg.AppId,
+		CreateTime:            msg.CreateTime,
+		InfoType:              msg.InfoType,
+		ComponentVerifyTicket: msg.ComponentVerifyTicket,
+	}
+}
+
+// 授权成功通知(InfoTypeAuthorized) 和 授权更新通知(InfoTypeUpdateAuthorized)
+type AuthorizedEvent struct {
+	AppId                        string `xml:"AppId"                        json:"AppId"`
+	CreateTime                   int64  `xml:"CreateTime"                   json:"CreateTime"`
+	InfoType                     string `xml:"InfoType"                     json:"InfoType"`
+	AuthorizerAppid              string `xml:"AuthorizerAppid"              json:"AuthorizerAppid"`
+	AuthorizationCode            string `xml:"AuthorizationCode"            json:"AuthorizationCode"`
+	AuthorizationCodeExpiredTime int64  `xml:"AuthorizationCodeExpiredTime" json:"AuthorizationCodeExpiredTime"`
+	PreAuthCode                  string `xml:"PreAuthCode"                  json:"PreAuthCode"`
+}
+
+func GetAuthorizedEvent(msg *MixedMessage) *AuthorizedEvent {
+	return &AuthorizedEvent{
+		AppId:                        msg.AppId,
+		CreateTime:                   msg.CreateTime,
+		InfoType:                     msg.InfoType,
+		AuthorizerAppid:              msg.AuthorizerAppid,
+		AuthorizationCode:            msg.AuthorizationCode,
+		AuthorizationCodeExpiredTime: msg.AuthorizationCodeExpiredTime,
+		PreAuthCode:                  msg.PreAuthCode,
+	}
+}
+
+// 取消授权通知(InfoTypeUnauthorized)
+type UnauthorizedEvent struct {
+	AppId           string `xml:"AppId"           json:"AppId"`
+	CreateTime      int64  `xml:"CreateTime"      json:"CreateTime"`
+	InfoType        string `xml:"InfoType"        json:"InfoType"`
+	AuthorizerAppid string `xml:"AuthorizerAppid" json:"AuthorizerAppid"`
+}
+
+func GetUnauthorizedEvent(msg *MixedMessage) *UnauthorizedEvent {
+	return &UnauthorizedEvent{
+		AppId:           msg.AppId,
+		CreateTime:      msg.CreateTime,
+		InfoType:        msg.InfoType,
+		AuthorizerAppid: msg.AuthorizerAppid,
+	}
+}
+
+// 授权事件请求的上下文
+type Request struct {
+	HttpRequest *http.Request // 可以为 nil, 因为某些 http 框架没有提供此参数
+
+	QueryValues  url.Values // 回调请求 URL 的查询参数集合
+	TimeStamp    int64      // 回调请求 URL 中的时间戳
+	Nonce        string     // 回调请求 URL 中的随机数
+	MsgSignature string     // 回调请求 URL 中的消息体签名
+
+	RawMsgXML []byte        // 解密后的"明文" xml 消息体
+	MixedMsg  *MixedMessage // RawMsgXML 解析后的消息
+}
+
+// 授权事件处理接口
+//  NOTE: 处理完毕后需要回复字符串 "success", 可以调用 WriteSuccess.
+type EventHandler interface {
+	ServeEvent(w http.ResponseWriter, r *Request)
+}
+
+type EventHandlerFunc func(http.ResponseWriter, *Request)
+
+func (fn EventHandlerFunc) ServeEvent(w http.ResponseWriter, r *Request) {
+	fn(w, r)
+}
+
+// 回复微信服务器 "success"
+func WriteSuccess(w http.ResponseWriter) (err error) {
+	_, err = w.Write([]byte("success"))
+	return
+}
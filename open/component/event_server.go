@@ -0,0 +1,159 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package component
+
+import (
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/chanxuehong/wechat/mp"
+	"github.com/chanxuehong/wechat/util"
+)
+
+var _ http.Handler = (*EventServer)(nil)
+
+// 第三方平台"授权事件接收URL"的 http.Handler.
+//  InfoTypeComponentVerifyTicket 由 EventServer 自动保存到 TicketStorage,
+//  然后所有的 InfoType 都交给 EventHandler(一般为 EventServeMux) 处理, 如果 EventHandler == nil 则直接回复 "success".
+type EventServer struct {
+	appId  string
+	token  string
+	aesKey [32]byte
+
+	ticketStorage         TicketStorage
+	eventHandler          EventHandler
+	invalidRequestHandler mp.InvalidRequestHandler
+}
+
+// 创建一个新的 EventServer.
+//  appId:          第三方平台的 appid
+//  token:          第三方平台的消息校验 Token
+//  encodedAESKey:  第三方平台的消息加解密 Key
+//  invalidRequestHandler 可以为 nil, 此时默认使用 mp.DefaultInvalidRequestHandler.
+func NewEventServer(appId, token, encodedAESKey string, ticketStorage TicketStorage,
+	eventHandler EventHandler, invalidRequestHandler mp.InvalidRequestHandler) (srv *EventServer) {
+
+	if ticketStorage == nil {
+		panic("nil TicketStorage")
+	}
+	aesKey, err := util.AESKeyDecode(encodedAESKey)
+	if err != nil {
+		panic(err)
+	}
+	if invalidRequestHandler == nil {
+		invalidRequestHandler = mp.DefaultInvalidRequestHandler
+	}
+
+	srv = &EventServer{
+		appId:                 appId,
+		token:                 token,
+		ticketStorage:         ticketStorage,
+		eventHandler:          eventHandler,
+		invalidRequestHandler: invalidRequestHandler,
+	}
+	copy(srv.aesKey[:], aesKey)
+	return
+}
+
+// 授权事件推送过来的 http body
+type requestHttpBody struct {
+	XMLName      struct{} `xml:"xml"`
+	AppId        string   `xml:"AppId"`
+	EncryptedMsg string   `xml:"Encrypt"`
+}
+
+func (srv *EventServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != "POST" {
+		srv.invalidRequestHandler.ServeInvalidRequest(w, r, errors.New("Not Support Request Method: "+r.Method))
+		return
+	}
+
+	queryValues := r.URL.Query()
+
+	msgSignature1 := queryValues.Get("msg_signature")
+	if len(msgSignature1) != 40 {
+		err := fmt.Errorf("the length of msg_signature mismatch, have: %d, want: 40", len(msgSignature1))
+		srv.invalidRequestHandler.ServeInvalidRequest(w, r, err)
+		return
+	}
+
+	timestampStr := queryValues.Get("timestamp")
+	timestamp, err := strconv.ParseInt(timestampStr, 10, 64)
+	if err != nil {
+		err = errors.New("can not parse timestamp to int64: " + timestampStr)
+		srv.invalidRequestHandler.ServeInvalidRequest(w, r, err)
+		return
+	}
+
+	nonce := queryValues.Get("nonce")
+
+	var httpBody requestHttpBody
+	if err = xml.NewDecoder(r.Body).Decode(&httpBody); err != nil {
+		srv.invalidRequestHandler.ServeInvalidRequest(w, r, err)
+		return
+	}
+
+	// 验证签名
+	msgSignature2 := util.MsgSign(srv.token, timestampStr, nonce, httpBody.EncryptedMsg)
+	if subtle.ConstantTimeCompare([]byte(msgSignature1), []byte(msgSignature2)) != 1 {
+		err = fmt.Errorf("check signature failed, input: %s, local: %s", msgSignature1, msgSignature2)
+		srv.invalidRequestHandler.ServeInvalidRequest(w, r, err)
+		return
+	}
+
+	// 解密
+	encryptedMsgBytes, err := base64.StdEncoding.DecodeString(httpBody.EncryptedMsg)
+	if err != nil {
+		srv.invalidRequestHandler.ServeInvalidRequest(w, r, err)
+		return
+	}
+	_, rawMsgXML, err := util.AESDecryptMsg(encryptedMsgBytes, srv.appId, srv.aesKey)
+	if err != nil {
+		srv.invalidRequestHandler.ServeInvalidRequest(w, r, err)
+		return
+	}
+
+	var mixedMsg MixedMessage
+	if err = xml.Unmarshal(rawMsgXML, &mixedMsg); err != nil {
+		srv.invalidRequestHandler.ServeInvalidRequest(w, r, err)
+		return
+	}
+
+	// 安全考虑验证 AppId
+	if mixedMsg.AppId != srv.appId {
+		err = fmt.Errorf("the message's AppId mismatch, have: %s, want: %s", mixedMsg.AppId, srv.appId)
+		srv.invalidRequestHandler.ServeInvalidRequest(w, r, err)
+		return
+	}
+
+	if mixedMsg.InfoType == InfoTypeComponentVerifyTicket {
+		if err = srv.ticketStorage.SetTicket(mixedMsg.ComponentVerifyTicket); err != nil {
+			srv.invalidRequestHandler.ServeInvalidRequest(w, r, err)
+			return
+		}
+	}
+
+	if srv.eventHandler == nil {
+		WriteSuccess(w)
+		return
+	}
+	srv.eventHandler.ServeEvent(w, &Request{
+		HttpRequest: r,
+
+		QueryValues:  queryValues,
+		TimeStamp:    timestamp,
+		Nonce:        nonce,
+		MsgSignature: msgSignature1,
+
+		RawMsgXML: rawMsgXML,
+		MixedMsg:  &mixedMsg,
+	})
+}
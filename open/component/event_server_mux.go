@@ -0,0 +1,111 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package component
+
+import (
+	"net/http"
+	"sync"
+)
+
+var _ EventHandler = (*EventServeMux)(nil)
+
+// EventServeMux 实现了一个简单的授权事件路由器, 根据 InfoType 分发, 同时也是一个 EventHandler.
+//  没有找到对应的 EventHandler 时直接回复 "success".
+type EventServeMux struct {
+	rwmutex             sync.RWMutex
+	eventHandlers       map[string]EventHandler
+	defaultEventHandler EventHandler
+}
+
+func NewEventServeMux() *EventServeMux {
+	return &EventServeMux{
+		eventHandlers: make(map[string]EventHandler),
+	}
+}
+
+// 注册 EventHandler, 处理特定 InfoType 的授权事件.
+func (mux *EventServeMux) EventHandle(infoType string, handler EventHandler) {
+	if infoType == "" {
+		panic("component: invalid infoType")
+	}
+	if handler == nil {
+		panic("component: nil handler")
+	}
+
+	mux.rwmutex.Lock()
+	defer mux.rwmutex.Unlock()
+
+	if mux.eventHandlers == nil {
+		mux.eventHandlers = make(map[string]EventHandler)
+	}
+	mux.eventHandlers[infoType] = handler
+}
+
+// 注册 EventHandlerFunc, 处理特定 InfoType 的授权事件.
+func (mux *EventServeMux) EventHandleFunc(infoType string, handler func(http.ResponseWriter, *Request)) {
+	mux.EventHandle(infoType, EventHandlerFunc(handler))
+}
+
+// 注册 EventHandler, 处理未知 InfoType 的授权事件.
+func (mux *EventServeMux) DefaultEventHandle(handler EventHandler) {
+	if handler == nil {
+		panic("component: nil handler")
+	}
+
+	mux.rwmutex.Lock()
+	defer mux.rwmutex.Unlock()
+
+	mux.defaultEventHandler = handler
+}
+
+// 注册 EventHandlerFunc, 处理未知 InfoType 的授权事件.
+func (mux *EventServeMux) DefaultEventHandleFunc(handler func(http.ResponseWriter, *Request)) {
+	mux.DefaultEventHandle(EventHandlerFunc(handler))
+}
+
+// 处理授权成功通知, handler 的参数是解析好的 AuthorizedEvent.
+func (mux *EventServeMux) AuthorizedHandleFunc(handler func(http.ResponseWriter, *Request, *AuthorizedEvent)) {
+	mux.EventHandleFunc(InfoTypeAuthorized, func(w http.ResponseWriter, r *Request) {
+		handler(w, r, GetAuthorizedEvent(r.MixedMsg))
+	})
+}
+
+// 处理授权更新通知, handler 的参数是解析好的 AuthorizedEvent.
+func (mux *EventServeMux) UpdateAuthorizedHandleFunc(handler func(http.ResponseWriter, *Request, *AuthorizedEvent)) {
+	mux.EventHandleFunc(InfoTypeUpdateAuthorized, func(w http.ResponseWriter, r *Request) {
+		handler(w, r, GetAuthorizedEvent(r.MixedMsg))
+	})
+}
+
+// 处理取消授权通知, handler 的参数是解析好的 UnauthorizedEvent.
+func (mux *EventServeMux) UnauthorizedHandleFunc(handler func(http.ResponseWriter, *Request, *UnauthorizedEvent)) {
+	mux.EventHandleFunc(InfoTypeUnauthorized, func(w http.ResponseWriter, r *Request) {
+		handler(w, r, GetUnauthorizedEvent(r.MixedMsg))
+	})
+}
+
+// 处理 component_verify_ticket 推送, ticket 在此之前已经被 EventServer 保存到 TicketStorage.
+func (mux *EventServeMux) ComponentVerifyTicketHandleFunc(handler func(http.ResponseWriter, *Request, *ComponentVerifyTicket)) {
+	mux.EventHandleFunc(InfoTypeComponentVerifyTicket, func(w http.ResponseWriter, r *Request) {
+		handler(w, r, GetComponentVerifyTicket(r.MixedMsg))
+	})
+}
+
+// EventServeMux 实现了 EventHandler 接口.
+func (mux *EventServeMux) ServeEvent(w http.ResponseWriter, r *Request) {
+	mux.rwmutex.RLock()
+	handler, ok := mux.eventHandlers[r.MixedMsg.InfoType]
+	if !ok {
+		handler = mux.defaultEventHandler
+	}
+	mux.rwmutex.RUnlock()
+
+	if handler == nil {
+		WriteSuccess(w)
+		return
+	}
+	handler.ServeEvent(w, r)
+}
@@ -0,0 +1,62 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package component
+
+import (
+	"errors"
+	"sync"
+)
+
+var ErrRefreshTokenNotFound = errors.New("authorizer_refresh_token not found")
+
+// 授权方 authorizer_refresh_token 的存储接口.
+//  authorizer_refresh_token 只会在授权的时候返回一次, 丢失后需要授权方重新授权, 请使用持久化存储实现该接口.
+type RefreshTokenStorage interface {
+	// 获取授权方的 authorizer_refresh_token, 如果没有则返回 ErrRefreshTokenNotFound.
+	GetRefreshToken(authorizerAppId string) (refreshToken string, err error)
+
+	// 保存授权方的 authorizer_refresh_token.
+	SetRefreshToken(authorizerAppId, refreshToken string) (err error)
+}
+
+var _ RefreshTokenStorage = (*MemoryRefreshTokenStorage)(nil)
+
+// RefreshTokenStorage 的简单实现, 用于单进程环境和测试.
+type MemoryRefreshTokenStorage struct {
+	rwmutex sync.RWMutex
+	tokens  map[string]string
+}
+
+func NewMemoryRefreshTokenStorage() *MemoryRefreshTokenStorage {
+	return &MemoryRefreshTokenStorage{
+		tokens: make(map[string]string),
+	}
+}
+
+func (s *MemoryRefreshTokenStorage) GetRefreshToken(authorizerAppId string) (refreshToken string, err error) {
+	s.rwmutex.RLock()
+	refreshToken = s.tokens[authorizerAppId]
+	s.rwmutex.RUnlock()
+
+	if refreshToken == "" {
+		err = ErrRefreshTokenNotFound
+	}
+	return
+}
+
+func (s *MemoryRefreshTokenStorage) SetRefreshToken(authorizerAppId, refreshToken string) (err error) {
+	if authorizerAppId == "" {
+		return errors.New("empty authorizerAppId")
+	}
+	if refreshToken == "" {
+		return errors.New("empty refreshToken")
+	}
+
+	s.rwmutex.Lock()
+	s.tokens[authorizerAppId] = refreshToken
+	s.rwmutex.Unlock()
+	return
+}
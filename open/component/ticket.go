@@ -0,0 +1,58 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package component
+
+import (
+	"errors"
+	"sync"
+)
+
+var ErrTicketNotFound = errors.New("component_verify_ticket not found")
+
+// component_verify_ticket 存储接口.
+//  多进程环境下需要使用共享存储(比如 redis, 数据库)实现该接口.
+type TicketStorage interface {
+	// 获取最近一次微信服务器推送的 component_verify_ticket,
+	// 如果没有则返回 ErrTicketNotFound.
+	GetTicket() (ticket string, err error)
+
+	// 保存微信服务器推送的 component_verify_ticket.
+	SetTicket(ticket string) (err error)
+}
+
+var _ TicketStorage = (*MemoryTicketStorage)(nil)
+
+// TicketStorage 的简单实现, 用于单进程环境.
+type MemoryTicketStorage struct {
+	rwmutex sync.RWMutex
+	ticket  string
+}
+
+func NewMemoryTicketStorage() *MemoryTicketStorage {
+	return &MemoryTicketStorage{}
+}
+
+func (s *MemoryTicketStorage) GetTicket() (ticket string, err error) {
+	s.rwmutex.RLock()
+	ticket = s.ticket
+	s.rwmutex.RUnlock()
+
+	if ticket == "" {
+		err = ErrTicketNotFound
+	}
+	return
+}
+
+func (s *MemoryTicketStorage) SetTicket(ticket string) (err error) {
+	if ticket == "" {
+		return errors.New("empty ticket")
+	}
+
+	s.rwmutex.Lock()
+	s.ticket = ticket
+	s.rwmutex.Unlock()
+	return
+}
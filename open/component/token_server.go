@@ -0,0 +1,180 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package component
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/mp"
+)
+
+var _ mp.TokenServer = (*ComponentTokenServer)(nil)
+
+// component_access_token 中控服务器, 实现了 mp.TokenServer 接口.
+//  NOTE:
+//  1. 用于单进程环境, 多进程环境请自行实现 mp.TokenServer 接口;
+//  2. component_access_token 依赖 component_verify_ticket, 所以 ComponentTokenServer 是惰性获取的,
+//     第一次调用 Token() 的时候才去微信服务器获取, 请确保此时 TicketStorage 里已经有 ticket.
+type ComponentTokenServer struct {
+	appId         string
+	appSecret     string
+	ticketStorage TicketStorage
+	httpClient    *http.Client
+
+	tokenGet struct {
+		sync.Mutex
+		LastToken     string // 最后一次成功从微信服务器获取的 component_access_token
+		LastTimestamp int64  // 最后一次成功从微信服务器获取 component_access_token 的时间戳
+	}
+
+	tokenCache struct {
+		sync.RWMutex
+		Token     string
+		ExpiresAt int64 // 过期时间戳, 已经扣除了缓冲时间
+	}
+}
+
+// 创建一个新的 ComponentTokenServer.
+//  appId, appSecret: 第三方平台的 appid 和 appsecret
+//  如果 httpClient == nil 则默认使用 http.DefaultClient.
+func NewComponentTokenServer(appId, appSecret string, ticketStorage TicketStorage,
+	httpClient *http.Client) (srv *ComponentTokenServer) {
+
+	if ticketStorage == nil {
+		panic("nil TicketStorage")
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &ComponentTokenServer{
+		appId:         appId,
+		appSecret:     appSecret,
+		ticketStorage: ticketStorage,
+		httpClient:    httpClient,
+	}
+}
+
+func (srv *ComponentTokenServer) Token() (token string, err error) {
+	srv.tokenCache.RLock()
+	token = srv.tokenCache.Token
+	expiresAt := srv.tokenCache.ExpiresAt
+	srv.tokenCache.RUnlock()
+
+	if token != "" && time.Now().Unix() < expiresAt {
+		return
+	}
+	return srv.TokenRefresh()
+}
+
+func (srv *ComponentTokenServer) TokenRefresh() (token string, err error) {
+	token, expiresIn, cached, err := srv.getToken()
+	if err != nil {
+		srv.tokenCache.Lock()
+		srv.tokenCache.Token = ""
+		srv.tokenCache.ExpiresAt = 0
+		srv.tokenCache.Unlock()
+		return
+	}
+	if !cached {
+		srv.tokenCache.Lock()
+		srv.tokenCache.Token = token
+		srv.tokenCache.ExpiresAt = time.Now().Unix() + expiresIn
+		srv.tokenCache.Unlock()
+	}
+	return
+}
+
+// 从微信服务器获取 component_access_token.
+func (srv *ComponentTokenServer) getToken() (token string, expiresIn int64, cached bool, err error) {
+	srv.tokenGet.Lock()
+	defer srv.tokenGet.Unlock()
+
+	timeNowUnix := time.Now().Unix()
+
+	// 在收敛周期内直接返回最近一次获取的 component_access_token
+	if n := srv.tokenGet.LastTimestamp; timeNowUnix >= n && timeNowUnix < n+5 {
+		token = srv.tokenGet.LastToken
+		cached = true
+		return
+	}
+
+	ticket, err := srv.ticketStorage.GetTicket()
+	if err != nil {
+		return
+	}
+
+	var request = struct {
+		ComponentAppId     string `json:"component_appid"`
+		ComponentAppSecret string `json:"component_appsecret"`
+		VerifyTicket       string `json:"component_verify_ticket"`
+	}{
+		ComponentAppId:     srv.appId,
+		ComponentAppSecret: srv.appSecret,
+		VerifyTicket:       ticket,
+	}
+
+	requestBody, err := json.Marshal(&request)
+	if err != nil {
+		return
+	}
+
+	_url := "https://api.weixin.qq.com/cgi-bin/component/api_component_token"
+	httpResp, err := srv.httpClient.Post(_url, "application/json; charset=utf-8", bytes.NewReader(requestBody))
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
+	}
+
+	var result struct {
+		mp.Error
+		Token     string `json:"component_access_token"`
+		ExpiresIn int64  `json:"expires_in"`
+	}
+
+	if err = json.NewDecoder(httpResp.Body).Decode(&result); err != nil {
+		return
+	}
+
+	if result.ErrCode != mp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+
+	// 由于网络的延时, component_access_token 过期时间留了一个缓冲区
+	switch {
+	case result.ExpiresIn > 60*60:
+		result.ExpiresIn -= 60 * 10
+	case result.ExpiresIn > 60*30:
+		result.ExpiresIn -= 60 * 5
+	case result.ExpiresIn > 60*5:
+		result.ExpiresIn -= 60
+	case result.ExpiresIn > 60:
+		result.ExpiresIn -= 10
+	case result.ExpiresIn > 0:
+	default:
+		err = errors.New("invalid expires_in: " + strconv.FormatInt(result.ExpiresIn, 10))
+		return
+	}
+
+	srv.tokenGet.LastToken = result.Token
+	srv.tokenGet.LastTimestamp = timeNowUnix
+	token = result.Token
+	expiresIn = result.ExpiresIn
+	return
+}
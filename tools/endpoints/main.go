@@ -0,0 +1,382 @@
+// 扫描 mp, miniprogram, open, work 目录下的源代码, 生成已经封装的接口列表 mp/endpoints_gen.go
+//
+//  cd mp && go generate
+//
+// 只收集 api.weixin.qq.com 的接口(企业微信 qyapi.weixin.qq.com 的 path 会和公众号的重名);
+// Idempotency 和 Class 取自 mp 包里的 idempotencyRegistry 和 endpointClassRegistry,
+// QuotaClass 取自封装函数注释里的指令, 值必须是 mp 包里 QuotaClass 开头的常量:
+//
+//  //wechat:endpoint quota=wxacode
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	root   = flag.String("root", "..", "仓库的根目录")
+	dirs   = flag.String("dirs", "mp,miniprogram,open,work", "需要扫描的目录, 相对于 root, 逗号分隔")
+	output = flag.String("o", "endpoints_gen.go", "输出的文件")
+)
+
+const directivePrefix = "//wechat:endpoint "
+
+var apiPrefixes = []string{
+	"https://api.weixin.qq.com/",
+	"http://api.weixin.qq.com/",
+}
+
+type endpoint struct {
+	Path       string
+	Name       string
+	Methods    []string
+	QuotaClass string // mp 包里常量的名字
+}
+
+// mp 包里登记的表, key 为 URL 的 path(以 / 结尾的表示前缀), value 为表达式的源代码.
+type registry map[string]string
+
+// 精确匹配优先, 然后是最长的前缀匹配, 同 mp.EndpointClass.
+func (r registry) lookup(path string) string {
+	if v, ok := r[path]; ok {
+		return v
+	}
+	for i := len(path) - 1; i >= 0; i-- {
+		if path[i] != '/' {
+			continue
+		}
+		if v, ok := r[path[:i+1]]; ok {
+			return v
+		}
+	}
+	return ""
+}
+
+func main() {
+	flag.Parse()
+
+	mpDir := filepath.Join(*root, "mp")
+	idempotencies, err := parseRegistry(filepath.Join(mpDir, "idempotency.go"), "idempotencyRegistry")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	classes, err := parseRegistry(filepath.Join(mpDir, "concurrency_limit.go"), "endpointClassRegistry")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	quotaClasses, err := parseConsts(filepath.Join(mpDir, "endpoint_registry.go"), "QuotaClass")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	endpoints := make(map[string]*endpoint)
+	fset := token.NewFileSet()
+	for _, dir := range strings.Split(*dirs, ",") {
+		if dir = strings.TrimSpace(dir); dir == "" {
+			continue
+		}
+		err = filepath.Walk(filepath.Join(*root, dir), func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				if name := info.Name(); name == "testdata" || strings.HasPrefix(name, "_") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+				return nil
+			}
+			file, err := parser.ParseFile(fset, path, nil, parser.ParseComments)
+			if err != nil {
+				return err
+			}
+			rel, err := filepath.Rel(*root, filepath.Dir(path))
+			if err != nil {
+				return err
+			}
+			pkg := filepath.ToSlash(rel)
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Body == nil {
+					continue
+				}
+				method := pkg + "." + funcName(fn)
+				quotaClass, err := directiveQuotaClass(fn.Doc, quotaClasses)
+				if err != nil {
+					return fmt.Errorf("%s: %s: %v", fset.Position(fn.Pos()), method, err)
+				}
+				ast.Inspect(fn.Body, func(node ast.Node) bool {
+					lit, ok := node.(*ast.BasicLit)
+					if !ok || lit.Kind != token.STRING {
+						return true
+					}
+					s, err := strconv.Unquote(lit.Value)
+					if err != nil {
+						return true
+					}
+					apiPath, ok := endpointPath(s)
+					if !ok {
+						return true
+					}
+					ep := endpoints[apiPath]
+					if ep == nil {
+						ep = &endpoint{Path: apiPath}
+						endpoints[apiPath] = ep
+					}
+					if ep.Name == "" {
+						ep.Name = docName(fn.Doc)
+					}
+					if quotaClass != "" {
+						ep.QuotaClass = quotaClass
+					}
+					for _, m := range ep.Methods {
+						if m == method {
+							return true
+						}
+					}
+					ep.Methods = append(ep.Methods, method)
+					return true
+				})
+			}
+			return nil
+		})
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+
+	paths := make([]string, 0, len(endpoints))
+	for path := range endpoints {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	var buf bytes.Buffer
+	buf.WriteString("// Code generated by tools/endpoints; DO NOT EDIT.\n\n")
+	buf.WriteString("package mp\n\n")
+	buf.WriteString("// 本 SDK 已经封装的接口, 由 tools/endpoints 扫描源代码生成.\n")
+	buf.WriteString("var generatedEndpoints = []Endpoint{\n")
+	for _, path := range paths {
+		ep := endpoints[path]
+		sort.Strings(ep.Methods)
+		fmt.Fprintf(&buf, "\t{Path: %q, Name: %q, Methods: []string{", ep.Path, ep.Name)
+		for i, m := range ep.Methods {
+			if i > 0 {
+				buf.WriteString(", ")
+			}
+			fmt.Fprintf(&buf, "%q", m)
+		}
+		buf.WriteString("}")
+		if ep.QuotaClass != "" {
+			fmt.Fprintf(&buf, ", QuotaClass: %s", ep.QuotaClass)
+		}
+		if v := idempotencies.lookup(ep.Path); v != "" {
+			fmt.Fprintf(&buf, ", Idempotency: %s", v)
+		}
+		if v := classes.lookup(ep.Path); v != "" {
+			fmt.Fprintf(&buf, ", Class: %s", v)
+		}
+		buf.WriteString("},\n")
+	}
+	buf.WriteString("}\n")
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err = ioutil.WriteFile(*output, src, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// 解析 file 里的包级变量 name, 返回其中 map 字面量的内容.
+func parseRegistry(filename, name string) (r registry, err error) {
+	file, err := parser.ParseFile(token.NewFileSet(), filename, nil, 0)
+	if err != nil {
+		return
+	}
+	src, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return
+	}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.VAR {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, ident := range vs.Names {
+				if ident.Name != name || i >= len(vs.Values) {
+					continue
+				}
+				ast.Inspect(vs.Values[i], func(node ast.Node) bool {
+					lit, ok := node.(*ast.CompositeLit)
+					if !ok {
+						return true
+					}
+					if _, ok = lit.Type.(*ast.MapType); !ok {
+						return true
+					}
+					r = make(registry, len(lit.Elts))
+					for _, elt := range lit.Elts {
+						kv, ok := elt.(*ast.KeyValueExpr)
+						if !ok {
+							continue
+						}
+						key, ok := kv.Key.(*ast.BasicLit)
+						if !ok || key.Kind != token.STRING {
+							continue
+						}
+						path, err := strconv.Unquote(key.Value)
+						if err != nil {
+							continue
+						}
+						r[path] = string(src[kv.Value.Pos()-1 : kv.Value.End()-1])
+					}
+					return false
+				})
+				if r == nil {
+					return nil, fmt.Errorf("%s: %s is not a map literal", filename, name)
+				}
+				return r, nil
+			}
+		}
+	}
+	return nil, fmt.Errorf("%s: %s not found", filename, name)
+}
+
+// 解析 file 里名字以 prefix 开头的字符串常量, 返回 值 --> 常量的名字.
+func parseConsts(filename, prefix string) (consts map[string]string, err error) {
+	file, err := parser.ParseFile(token.NewFileSet(), filename, nil, 0)
+	if err != nil {
+		return
+	}
+	consts = make(map[string]string)
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			vs := spec.(*ast.ValueSpec)
+			for i, ident := range vs.Names {
+				if !strings.HasPrefix(ident.Name, prefix) || i >= len(vs.Values) {
+					continue
+				}
+				lit, ok := vs.Values[i].(*ast.BasicLit)
+				if !ok || lit.Kind != token.STRING {
+					continue
+				}
+				value, err := strconv.Unquote(lit.Value)
+				if err != nil {
+					return nil, err
+				}
+				consts[value] = ident.Name
+			}
+		}
+	}
+	return consts, nil
+}
+
+// 从注释里的 //wechat:endpoint 指令取出 quota=xxx, 返回对应常量的名字.
+func directiveQuotaClass(doc *ast.CommentGroup, quotaClasses map[string]string) (name string, err error) {
+	if doc == nil {
+		return
+	}
+	for _, comment := range doc.List {
+		if !strings.HasPrefix(comment.Text, directivePrefix) {
+			continue
+		}
+		for _, field := range strings.Fields(comment.Text[len(directivePrefix):]) {
+			i := strings.IndexByte(field, '=')
+			if i < 0 || field[:i] != "quota" {
+				return "", fmt.Errorf("unknown directive %q", field)
+			}
+			if name = quotaClasses[field[i+1:]]; name == "" {
+				return "", fmt.Errorf("unknown quota class %q", field[i+1:])
+			}
+		}
+	}
+	return
+}
+
+// 返回 Client.UserInfo 或者 UserInfo 这样的名称.
+func funcName(fn *ast.FuncDecl) string {
+	if fn.Recv == nil || len(fn.Recv.List) == 0 {
+		return fn.Name.Name
+	}
+	typ := fn.Recv.List[0].Type
+	if star, ok := typ.(*ast.StarExpr); ok {
+		typ = star.X
+	}
+	if ident, ok := typ.(*ast.Ident); ok {
+		return ident.Name + "." + fn.Name.Name
+	}
+	return fn.Name.Name
+}
+
+// 从 URL 字符串里取出接口的 path.
+func endpointPath(s string) (path string, ok bool) {
+	for _, prefix := range apiPrefixes {
+		if strings.HasPrefix(s, prefix) {
+			path = s[len(prefix)-1:]
+			if i := strings.IndexAny(path, "?#"); i >= 0 {
+				path = path[:i]
+			}
+			return path, path != "/"
+		}
+	}
+	return
+}
+
+// 注释的第一句作为接口的名称.
+func docName(doc *ast.CommentGroup) string {
+	if doc == nil {
+		return ""
+	}
+	text := strings.TrimSpace(doc.Text())
+	if i := strings.IndexByte(text, '\n'); i >= 0 {
+		text = text[:i]
+	}
+	text = strings.TrimSpace(text)
+	// 括号里的逗号不算, 比如 "换取授权信息(authorizer_access_token, authorizer_refresh_token 等)"
+	depth := 0
+	for i, r := range text {
+		switch r {
+		case '(', '（':
+			depth++
+		case ')', '）':
+			depth--
+		}
+		if depth > 0 {
+			continue
+		}
+		rest := text[i:]
+		if strings.HasPrefix(rest, "。") || strings.HasPrefix(rest, ". ") || strings.HasPrefix(rest, ", ") {
+			text = text[:i]
+			break
+		}
+	}
+	return strings.TrimRight(text, ".。:：")
+}
@@ -0,0 +1,233 @@
+// 根据 tools/errcode/errcode.txt 生成 mp/errcode.go
+//
+//  cd tools/errcode && go run main.go
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// 返回码对应的常量名称, 生成 ErrCodeXxx 和 ErrXxx; errcode.txt 里新增的返回码需要在这里增加名称.
+var names = map[int]string{
+	-1:    "SystemBusy",
+	0:     "OK",
+	40001: "InvalidCredential",
+	40002: "InvalidGrantType",
+	40003: "InvalidOpenId",
+	40004: "InvalidMediaType",
+	40005: "InvalidFileType",
+	40006: "InvalidFileSize",
+	40007: "InvalidMediaId",
+	40008: "InvalidMessageType",
+	40009: "InvalidImageSize",
+	40010: "InvalidVoiceSize",
+	40011: "InvalidVideoSize",
+	40012: "InvalidThumbSize",
+	40013: "InvalidAppId",
+	40014: "InvalidAccessToken",
+	40015: "InvalidMenuType",
+	40016: "InvalidButtonCount",
+	40017: "InvalidButtonType",
+	40018: "InvalidButtonNameLength",
+	40019: "InvalidButtonKeyLength",
+	40020: "InvalidButtonURLLength",
+	40021: "InvalidMenuVersion",
+	40022: "InvalidSubMenuLevel",
+	40023: "InvalidSubButtonCount",
+	40024: "InvalidSubButtonType",
+	40025: "InvalidSubButtonNameLength",
+	40026: "InvalidSubButtonKeyLength",
+	40027: "InvalidSubButtonURLLength",
+	40028: "InvalidMenuUser",
+	40029: "InvalidOAuthCode",
+	40030: "InvalidRefreshToken",
+	40031: "InvalidOpenIdList",
+	40032: "InvalidOpenIdListLength",
+	40033: "InvalidCharacter",
+	40035: "InvalidParameter",
+	40038: "InvalidRequestFormat",
+	40039: "InvalidURLLength",
+	40050: "InvalidGroupId",
+	40051: "InvalidGroupName",
+	41001: "MissingAccessToken",
+	41002: "MissingAppId",
+	41003: "MissingRefreshToken",
+	41004: "MissingSecret",
+	41005: "MissingMediaData",
+	41006: "MissingMediaId",
+	41007: "MissingSubMenu",
+	41008: "MissingOAuthCode",
+	41009: "MissingOpenId",
+	42001: "Timeout",
+	42002: "RefreshTokenTimeout",
+	42003: "OAuthCodeTimeout",
+	43001: "RequireGET",
+	43002: "RequirePOST",
+	43003: "RequireHTTPS",
+	43004: "RequireSubscribe",
+	43005: "RequireFriend",
+	43101: "UserRefuseToAcceptMsg",
+	44001: "EmptyMedia",
+	44002: "EmptyPostData",
+	44003: "EmptyNews",
+	44004: "EmptyText",
+	45001: "MediaSizeLimit",
+	45002: "ContentSizeLimit",
+	45003: "TitleSizeLimit",
+	45004: "DescriptionSizeLimit",
+	45005: "URLSizeLimit",
+	45006: "PicURLSizeLimit",
+	45007: "VoicePlaytimeLimit",
+	45008: "ArticleCountLimit",
+	45009: "APIFreqLimit",
+	45010: "MenuCountLimit",
+	45015: "ResponseTimeLimit",
+	45016: "SystemGroupReadOnly",
+	45017: "GroupNameTooLong",
+	45018: "GroupCountLimit",
+	45047: "ResponseCountLimit",
+	46001: "MediaNotExist",
+	46002: "MenuVersionNotExist",
+	46003: "MenuNotExist",
+	46004: "UserNotExist",
+	47001: "ParseError",
+	48001: "APIUnauthorized",
+	50001: "UserUnauthorized",
+	61450: "SystemError",
+	61451: "InvalidKfParameter",
+	61452: "InvalidKfAccount",
+	61453: "KfAccountExisted",
+	61454: "InvalidKfAccountLength",
+	61455: "InvalidKfAccountCharacter",
+	61456: "KfAccountCountLimit",
+	61457: "InvalidKfHeadImgType",
+	61500: "InvalidDateFormat",
+	61501: "InvalidDateRange",
+}
+
+// 返回码的分类, 对应 mp.ErrorCategory 的常量名称
+func category(code int) string {
+	switch {
+	case code == 0:
+		return "CategoryNone"
+	case code == -1, code == 61450:
+		return "CategorySystem"
+	case code == 40001, code == 40002, code == 40013, code == 40014,
+		code == 40029, code == 40030, code == 41001, code == 41002,
+		code == 41003, code == 41004, code == 41008, code/1000 == 42:
+		return "CategoryCredential"
+	case code == 45009:
+		return "CategoryFrequency"
+	case code/1000 == 45:
+		return "CategoryLimit"
+	case code/1000 == 46:
+		return "CategoryNotFound"
+	case code == 43004, code == 43005, code == 43101, code == 48001, code == 50001:
+		return "CategoryPermission"
+	default:
+		return "CategoryParameter"
+	}
+}
+
+type errcode struct {
+	Code    int
+	Name    string
+	Message string
+}
+
+func main() {
+	input := flag.String("table", "errcode.txt", "返回码列表, 每行一个: 返回码<TAB>说明")
+	output := flag.String("o", "../../mp/errcode.go", "生成的 go 文件")
+	flag.Parse()
+
+	errcodes, err := readTable(*input)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	sort.Slice(errcodes, func(i, j int) bool { return errcodes[i].Code < errcodes[j].Code })
+
+	var buf bytes.Buffer
+	buf.WriteString(`// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// Code generated by tools/errcode; DO NOT EDIT.
+
+package mp
+
+// 全局返回码
+const (
+`)
+	for _, e := range errcodes {
+		fmt.Fprintf(&buf, "\tErrCode%s = %d // %s\n", e.Name, e.Code, e.Message)
+	}
+	buf.WriteString(")\n\n// 全局返回码对应的 error, 可以用 errors.Is(err, ErrXxx) 判断\nvar (\n")
+	for _, e := range errcodes {
+		if e.Code == 0 {
+			continue
+		}
+		fmt.Fprintf(&buf, "\tErr%s = &Error{ErrCode: ErrCode%s, ErrMsg: %q}\n", e.Name, e.Name, e.Message)
+	}
+	buf.WriteString(")\n\nvar errcodeTable = map[int]errcodeInfo{\n")
+	for _, e := range errcodes {
+		fmt.Fprintf(&buf, "\tErrCode%s: {%q, %s},\n", e.Name, e.Message, category(e.Code))
+	}
+	buf.WriteString("}\n")
+
+	code, err := format.Source(buf.Bytes())
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if err = ioutil.WriteFile(*output, code, 0644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// 读取返回码列表, 以 # 开头的行和空行忽略.
+func readTable(filename string) (errcodes []errcode, err error) {
+	src, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return
+	}
+
+	seen := make(map[int]bool)
+	scanner := bufio.NewScanner(bytes.NewReader(src))
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		i := strings.IndexByte(line, '\t')
+		if i < 0 {
+			return nil, fmt.Errorf("%s:%d: want errcode<TAB>message", filename, lineNo)
+		}
+		code, err := strconv.Atoi(strings.TrimSpace(line[:i]))
+		if err != nil {
+			return nil, fmt.Errorf("%s:%d: %s", filename, lineNo, err)
+		}
+		if seen[code] {
+			return nil, fmt.Errorf("%s:%d: duplicate errcode %d", filename, lineNo, code)
+		}
+		seen[code] = true
+		name, ok := names[code]
+		if !ok {
+			return nil, fmt.Errorf("%s:%d: no name for errcode %d, please add it to names", filename, lineNo, code)
+		}
+		errcodes = append(errcodes, errcode{Code: code, Name: name, Message: strings.TrimSpace(line[i+1:])})
+	}
+	err = scanner.Err()
+	return
+}
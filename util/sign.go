@@ -7,7 +7,9 @@ package util
 
 import (
 	"crypto/sha1"
+	"crypto/subtle"
 	"encoding/hex"
+	"fmt"
 	"sort"
 )
 
@@ -43,3 +45,29 @@ func MsgSign(token, timestamp, nonce, encryptedMsg string) (signature string) {
 	hashsum := sha1.Sum(buf)
 	return hex.EncodeToString(hashsum[:])
 }
+
+// 验证微信公众号 明文模式/URL认证 签名, 签名不一致时返回错误.
+//  signature 为 URL 参数中的 signature.
+func CheckSign(token, timestamp, nonce, signature string) (err error) {
+	if len(signature) != 40 {
+		return fmt.Errorf("the length of signature mismatch, have: %d, want: 40", len(signature))
+	}
+	signature2 := Sign(token, timestamp, nonce)
+	if subtle.ConstantTimeCompare([]byte(signature), []byte(signature2)) != 1 {
+		return fmt.Errorf("check signature failed, input: %s, local: %s", signature, signature2)
+	}
+	return
+}
+
+// 验证微信公众号/企业号 密文模式消息签名, 签名不一致时返回错误.
+//  msgSignature 为 URL 参数中的 msg_signature, encryptedMsg 为消息体中 Encrypt 的内容.
+func CheckMsgSign(token, timestamp, nonce, encryptedMsg, msgSignature string) (err error) {
+	if len(msgSignature) != 40 {
+		return fmt.Errorf("the length of msg_signature mismatch, have: %d, want: 40", len(msgSignature))
+	}
+	msgSignature2 := MsgSign(token, timestamp, nonce, encryptedMsg)
+	if subtle.ConstantTimeCompare([]byte(msgSignature), []byte(msgSignature2)) != 1 {
+		return fmt.Errorf("check signature failed, input: %s, local: %s", msgSignature, msgSignature2)
+	}
+	return
+}
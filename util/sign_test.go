@@ -0,0 +1,38 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package util
+
+import (
+	"testing"
+)
+
+func TestCheckSign(t *testing.T) {
+	signature := Sign("token", "1409735669", "nonce")
+	if err := CheckSign("token", "1409735669", "nonce", signature); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := CheckSign("token2", "1409735669", "nonce", signature); err == nil {
+		t.Error("签名不一致时应该返回错误")
+		return
+	}
+	if err := CheckSign("token", "1409735669", "nonce", signature[:39]); err == nil {
+		t.Error("签名长度不对时应该返回错误")
+		return
+	}
+}
+
+func TestCheckMsgSign(t *testing.T) {
+	msgSignature := MsgSign("token", "1409735669", "nonce", "encrypted")
+	if err := CheckMsgSign("token", "1409735669", "nonce", "encrypted", msgSignature); err != nil {
+		t.Error(err)
+		return
+	}
+	if err := CheckMsgSign("token", "1409735669", "nonce", "encrypted2", msgSignature); err == nil {
+		t.Error("签名不一致时应该返回错误")
+		return
+	}
+}
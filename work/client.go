@@ -0,0 +1,44 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package work
+
+import (
+	"net/http"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 企业微信应用的 api 客户端.
+type Client struct {
+	corp.CorpClient
+	agentId int64
+}
+
+// 创建一个新的 Client.
+//  agentId:     应用ID, 发送应用消息时使用
+//  TokenServer: 一般为该应用的 AgentTokenServer
+//  如果 HttpClient == nil 则默认用 http.DefaultClient
+func NewClient(agentId int64, TokenServer corp.TokenServer, HttpClient *http.Client) *Client {
+	if TokenServer == nil {
+		panic("TokenServer == nil")
+	}
+	if HttpClient == nil {
+		HttpClient = http.DefaultClient
+	}
+
+	return &Client{
+		CorpClient: corp.CorpClient{
+			TokenServer: TokenServer,
+			HttpClient:  HttpClient,
+		},
+		agentId: agentId,
+	}
+}
+
+// 应用ID
+func (clt *Client) AgentId() int64 {
+	return clt.agentId
+}
@@ -0,0 +1,126 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package work
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/chanxuehong/wechat/corp"
+	"github.com/chanxuehong/wechat/util"
+)
+
+// 企业微信回调消息的加解密.
+//  企业内部应用的 receiveid 为 corpid, 第三方应用为 suiteid; 解密出来的明文可以用 corp.MixedMessage 解析.
+type Crypto struct {
+	token     string
+	receiveId string
+	aesKey    [32]byte
+}
+
+// 创建一个新的 Crypto.
+//  token, encodedAESKey: 应用"接收消息"设置的 Token 和 EncodingAESKey
+//  receiveId:            企业ID 或 第三方应用的 suiteid
+func NewCrypto(token, encodedAESKey, receiveId string) (c *Crypto, err error) {
+	aesKey, err := util.AESKeyDecode(encodedAESKey)
+	if err != nil {
+		return
+	}
+	c = &Crypto{
+		token:     token,
+		receiveId: receiveId,
+	}
+	copy(c.aesKey[:], aesKey)
+	return
+}
+
+// 验证回调 URL, 返回需要原样回复给微信服务器的 echostr 明文.
+//  msgSignature, timestamp, nonce, echostr: 回调 URL 上的查询参数
+func (c *Crypto) VerifyURL(msgSignature, timestamp, nonce, echostr string) (echo []byte, err error) {
+	return c.decrypt(msgSignature, timestamp, nonce, echostr)
+}
+
+// 回调消息的 http body
+type encryptedRequestBody struct {
+	XMLName      struct{} `xml:"xml"`
+	ToUserName   string   `xml:"ToUserName"`
+	AgentId      string   `xml:"AgentID"`
+	EncryptedMsg string   `xml:"Encrypt"`
+}
+
+// 解密回调消息, 返回明文 xml.
+//  msgSignature, timestamp, nonce: 回调 URL 上的查询参数
+//  body:                           回调请求的 http body
+func (c *Crypto) DecryptMsg(msgSignature, timestamp, nonce string, body []byte) (rawMsgXML []byte, err error) {
+	var requestBody encryptedRequestBody
+	if err = xml.Unmarshal(body, &requestBody); err != nil {
+		return
+	}
+	return c.decrypt(msgSignature, timestamp, nonce, requestBody.EncryptedMsg)
+}
+
+// 解密回调消息并解析为 corp.MixedMessage.
+func (c *Crypto) DecryptMixedMsg(msgSignature, timestamp, nonce string, body []byte) (msg *corp.MixedMessage, err error) {
+	rawMsgXML, err := c.DecryptMsg(msgSignature, timestamp, nonce, body)
+	if err != nil {
+		return
+	}
+	var mixedMsg corp.MixedMessage
+	if err = xml.Unmarshal(rawMsgXML, &mixedMsg); err != nil {
+		return
+	}
+	msg = &mixedMsg
+	return
+}
+
+func (c *Crypto) decrypt(msgSignature, timestamp, nonce, encryptedMsg string) (rawMsg []byte, err error) {
+	if len(msgSignature) != 40 {
+		err = fmt.Errorf("the length of msg_signature mismatch, have: %d, want: 40", len(msgSignature))
+		return
+	}
+
+	wantSignature := util.MsgSign(c.token, timestamp, nonce, encryptedMsg)
+	if subtle.ConstantTimeCompare([]byte(msgSignature), []byte(wantSignature)) != 1 {
+		err = fmt.Errorf("check signature failed, input: %s, local: %s", msgSignature, wantSignature)
+		return
+	}
+
+	encryptedMsgBytes, err := base64.StdEncoding.DecodeString(encryptedMsg)
+	if err != nil {
+		return
+	}
+	_, rawMsg, err = util.AESDecryptMsg(encryptedMsgBytes, c.receiveId, c.aesKey)
+	return
+}
+
+// 加密被动回复的消息, 返回回复给微信服务器的 http body.
+//  rawMsgXML: 回复消息的明文 xml
+//  timestamp, nonce: 一般用回调 URL 上的参数
+func (c *Crypto) EncryptMsg(rawMsgXML []byte, timestamp int64, nonce string) (body []byte, err error) {
+	if len(rawMsgXML) == 0 {
+		err = errors.New("empty rawMsgXML")
+		return
+	}
+
+	var random [16]byte
+	if _, err = rand.Read(random[:]); err != nil {
+		return
+	}
+
+	encryptedMsg := base64.StdEncoding.EncodeToString(util.AESEncryptMsg(random[:], rawMsgXML, c.receiveId, c.aesKey))
+	responseBody := corp.ResponseHttpBody{
+		EncryptedMsg: encryptedMsg,
+		MsgSignature: util.MsgSign(c.token, strconv.FormatInt(timestamp, 10), nonce, encryptedMsg),
+		TimeStamp:    timestamp,
+		Nonce:        nonce,
+	}
+	return xml.Marshal(&responseBody)
+}
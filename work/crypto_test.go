@@ -0,0 +1,151 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package work
+
+import (
+	"encoding/base64"
+	"encoding/xml"
+	"strconv"
+	"testing"
+
+	"github.com/chanxuehong/wechat/corp"
+	"github.com/chanxuehong/wechat/util"
+)
+
+const (
+	testToken         = "QDG6eK"
+	testEncodedAESKey = "jWmYm7qr5nMoAUwZRjGtBxmz3KA1tkAj3ykkR6q2B2C"
+	testCorpId        = "wx5823bf96d3bd56c7"
+)
+
+func testCrypto(t *testing.T, receiveId string) *Crypto {
+	c, err := NewCrypto(testToken, testEncodedAESKey, receiveId)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+// 模拟企业微信服务器加密 msg.
+func testEncrypt(t *testing.T, receiveId string, msg []byte) string {
+	aesKey, err := util.AESKeyDecode(testEncodedAESKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var key [32]byte
+	copy(key[:], aesKey)
+	return base64.StdEncoding.EncodeToString(util.AESEncryptMsg([]byte("0123456789abcdef"), msg, receiveId, key))
+}
+
+func TestNewCrypto(t *testing.T) {
+	tests := []struct {
+		name          string
+		encodedAESKey string
+		wantErr       bool
+	}{
+		{"正确的 EncodingAESKey", testEncodedAESKey, false},
+		{"长度不对", testEncodedAESKey[:42], true},
+		{"空的 EncodingAESKey", "", true},
+	}
+	for _, tt := range tests {
+		_, err := NewCrypto(testToken, tt.encodedAESKey, testCorpId)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("%s: have err %v, wantErr %v", tt.name, err, tt.wantErr)
+		}
+	}
+}
+
+func TestCryptoVerifyURL(t *testing.T) {
+	const timestamp, nonce = "1409659589", "263014780"
+	echostr := testEncrypt(t, testCorpId, []byte("1616140317555161061"))
+	signature := util.MsgSign(testToken, timestamp, nonce, echostr)
+	otherCorp := testEncrypt(t, "wx0000000000000000", []byte("1616140317555161061"))
+
+	tests := []struct {
+		name      string
+		signature string
+		timestamp string
+		echostr   string
+		wantErr   bool
+	}{
+		{"正确的签名", signature, timestamp, echostr, false},
+		{"签名长度不对", signature[:39], timestamp, echostr, true},
+		{"签名不对", util.MsgSign("token", timestamp, nonce, echostr), timestamp, echostr, true},
+		{"timestamp 被篡改", signature, "1409659590", echostr, true},
+		{"receiveid 不对", util.MsgSign(testToken, timestamp, nonce, otherCorp), timestamp, otherCorp, true},
+		{"echostr 不是 base64", util.MsgSign(testToken, timestamp, nonce, "!!!"), timestamp, "!!!", true},
+	}
+	c := testCrypto(t, testCorpId)
+	for _, tt := range tests {
+		echo, err := c.VerifyURL(tt.signature, tt.timestamp, nonce, tt.echostr)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: want error", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		if string(echo) != "1616140317555161061" {
+			t.Errorf("%s: have %q", tt.name, echo)
+		}
+	}
+}
+
+func TestCryptoEncryptDecryptMsg(t *testing.T) {
+	const rawMsgXML = "<xml><ToUserName><![CDATA[wx5823bf96d3bd56c7]]></ToUserName><FromUserName><![CDATA[mycreate]]></FromUserName>" +
+		"<CreateTime>1409659813</CreateTime><MsgType><![CDATA[text]]></MsgType><Content><![CDATA[hello]]></Content>" +
+		"<MsgId>4561255354251345929</MsgId><AgentID>218</AgentID></xml>"
+
+	tests := []struct {
+		name      string
+		encryptId string // 加密所用的 receiveid
+		decryptId string // 解密所用的 receiveid
+		wantErr   bool
+	}{
+		{"企业内部应用", testCorpId, testCorpId, false},
+		{"第三方应用", "suite0123456789", "suite0123456789", false},
+		{"receiveid 不对", testCorpId, "suite0123456789", true},
+	}
+	for _, tt := range tests {
+		body, err := testCrypto(t, tt.encryptId).EncryptMsg([]byte(rawMsgXML), 1409659813, "1372623149")
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		var responseBody corp.ResponseHttpBody
+		if err = xml.Unmarshal(body, &responseBody); err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		if responseBody.TimeStamp != 1409659813 || responseBody.Nonce != "1372623149" {
+			t.Errorf("%s: have %+v", tt.name, responseBody)
+			continue
+		}
+
+		msg, err := testCrypto(t, tt.decryptId).DecryptMixedMsg(responseBody.MsgSignature,
+			strconv.FormatInt(responseBody.TimeStamp, 10), responseBody.Nonce, body)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("%s: want error", tt.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		if msg.ToUserName != testCorpId || msg.FromUserName != "mycreate" || msg.MsgType != "text" {
+			t.Errorf("%s: have %+v", tt.name, msg.CommonMessageHeader)
+		}
+	}
+
+	if _, err := testCrypto(t, testCorpId).EncryptMsg(nil, 1409659813, "1372623149"); err == nil {
+		t.Error("空的明文: want error")
+	}
+}
@@ -0,0 +1,256 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package work
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 部门
+type Department struct {
+	Id       int64  `json:"id"`
+	Name     string `json:"name"`
+	NameEn   string `json:"name_en,omitempty"`
+	ParentId int64  `json:"parentid"`
+	Order    int64  `json:"order"` // 在父部门中的次序值, order 值大的排序靠前
+}
+
+// 获取指定部门及其下的子部门(递归).
+//  id: 部门id, 为 0 时获取全量组织架构
+func (clt *Client) DepartmentList(id int64) (departments []Department, err error) {
+	var result struct {
+		corp.Error
+		Departments []Department `json:"department"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/department/list?access_token="
+	if id != 0 {
+		incompleteURL = "https://qyapi.weixin.qq.com/cgi-bin/department/list?id=" +
+			strconv.FormatInt(id, 10) + "&access_token="
+	}
+	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	departments = result.Departments
+	return
+}
+
+// 成员
+type Member struct {
+	UserId         string  `json:"userid"`
+	Name           string  `json:"name"`
+	Department     []int64 `json:"department"`        // 成员所属部门id列表
+	Order          []int64 `json:"order"`             // 部门内的排序值, 数量与 Department 一致
+	Position       string  `json:"position"`          // 职务信息
+	Mobile         string  `json:"mobile"`            // 手机号码
+	Gender         string  `json:"gender"`            // 性别, 0 表示未定义, 1 表示男性, 2 表示女性
+	Email          string  `json:"email"`             // 邮箱
+	IsLeaderInDept []int   `json:"is_leader_in_dept"` // 在所在的部门内是否为上级, 数量与 Department 一致
+	Avatar         string  `json:"avatar"`            // 头像url
+	Telephone      string  `json:"telephone"`         // 座机
+	Alias          string  `json:"alias"`             // 别名
+	Status         int     `json:"status"`            // 激活状态: 1=已激活, 2=已禁用, 4=未激活, 5=退出企业
+	MainDepartment int64   `json:"main_department"`   // 主部门
+	OpenUserId     string  `json:"open_userid"`       // 全局唯一, 仅第三方应用可获取
+}
+
+// 读取成员.
+func (clt *Client) MemberGet(userId string) (member *Member, err error) {
+	if userId == "" {
+		err = errors.New("empty userId")
+		return
+	}
+
+	var result struct {
+		corp.Error
+		Member
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/user/get?userid=" +
+		url.QueryEscape(userId) + "&access_token="
+	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	member = &result.Member
+	return
+}
+
+// 获取部门成员详情.
+//  departmentId: 获取的部门id
+//  fetchChild:   是否递归获取子部门下面的成员
+func (clt *Client) MemberList(departmentId int64, fetchChild bool) (members []Member, err error) {
+	var result struct {
+		corp.Error
+		UserList []Member `json:"userlist"`
+	}
+
+	var fetchChildStr string
+	if fetchChild {
+		fetchChildStr = "1"
+	} else {
+		fetchChildStr = "0"
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/user/list" +
+		"?department_id=" + strconv.FormatInt(departmentId, 10) +
+		"&fetch_child=" + fetchChildStr +
+		"&access_token="
+	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	members = result.UserList
+	return
+}
+
+// 通讯录快照
+type Directory struct {
+	Departments []Department
+	Members     []Member
+}
+
+// 拉取 rootDepartmentId 部门下的全部组织架构和成员, 用于把企业微信通讯录同步到本地.
+//  rootDepartmentId: 一般为 1(根部门)
+func (clt *Client) SyncDirectory(rootDepartmentId int64) (dir *Directory, err error) {
+	departments, err := clt.DepartmentList(rootDepartmentId)
+	if err != nil {
+		return
+	}
+	members, err := clt.MemberList(rootDepartmentId, true)
+	if err != nil {
+		return
+	}
+	dir = &Directory{
+		Departments: departments,
+		Members:     members,
+	}
+	return
+}
+
+// 异步任务完成后的回调设置, 为 nil 时使用应用的回调 URL
+type BatchCallback struct {
+	URL            string `json:"url,omitempty"`
+	Token          string `json:"token,omitempty"`
+	EncodingAESKey string `json:"encodingaeskey,omitempty"`
+}
+
+// 增量更新成员, 文件中的成员会被新增或更新, 文件中没有的成员不会被删除.
+//  mediaId:  上传的 csv 文件的 media_id
+//  toInvite: 是否邀请新建的成员使用企业微信
+func (clt *Client) BatchSyncUser(mediaId string, toInvite bool, callback *BatchCallback) (jobId string, err error) {
+	return clt.batchJob("https://qyapi.weixin.qq.com/cgi-bin/batch/syncuser?access_token=", mediaId, &toInvite, callback)
+}
+
+// 全量覆盖成员, 文件中没有的成员会被删除.
+//  mediaId:  上传的 csv 文件的 media_id
+//  toInvite: 是否邀请新建的成员使用企业微信
+func (clt *Client) BatchReplaceUser(mediaId string, toInvite bool, callback *BatchCallback) (jobId string, err error) {
+	return clt.batchJob("https://qyapi.weixin.qq.com/cgi-bin/batch/replaceuser?access_token=", mediaId, &toInvite, callback)
+}
+
+// 全量覆盖部门, 文件中没有的部门会被删除(部门下有成员时不会被删除).
+//  mediaId: 上传的 csv 文件的 media_id
+func (clt *Client) BatchReplaceParty(mediaId string, callback *BatchCallback) (jobId string, err error) {
+	return clt.batchJob("https://qyapi.weixin.qq.com/cgi-bin/batch/replaceparty?access_token=", mediaId, nil, callback)
+}
+
+func (clt *Client) batchJob(incompleteURL, mediaId string, toInvite *bool, callback *BatchCallback) (jobId string, err error) {
+	if mediaId == "" {
+		err = errors.New("empty mediaId")
+		return
+	}
+
+	var request = struct {
+		MediaId  string         `json:"media_id"`
+		ToInvite *bool          `json:"to_invite,omitempty"`
+		Callback *BatchCallback `json:"callback,omitempty"`
+	}{
+		MediaId:  mediaId,
+		ToInvite: toInvite,
+		Callback: callback,
+	}
+
+	var result struct {
+		corp.Error
+		JobId string `json:"jobid"`
+	}
+
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	jobId = result.JobId
+	return
+}
+
+const (
+	BatchJobStatusPending    = 1 // 任务开始
+	BatchJobStatusProcessing = 2 // 处理中
+	BatchJobStatusDone       = 3 // 已完成
+)
+
+// 异步任务的结果
+type BatchJobResult struct {
+	Status     int    `json:"status"`     // BatchJobStatusPending, BatchJobStatusProcessing, BatchJobStatusDone
+	Type       string `json:"type"`       // sync_user, replace_user, invite_user, replace_party
+	Total      int    `json:"total"`      // 任务运行总条数
+	Percentage int    `json:"percentage"` // 目前运行百分比
+	Result     []struct {
+		UserId  string `json:"userid,omitempty"`
+		Action  int    `json:"action,omitempty"` // replace_party 的操作类型, 1 新建部门, 2 更改部门, 3 删除部门
+		PartyId int64  `json:"partyid,omitempty"`
+		ErrCode int    `json:"errcode"`
+		ErrMsg  string `json:"errmsg"`
+	} `json:"result"` // 详细的处理结果
+}
+
+// 获取异步任务结果.
+func (clt *Client) BatchGetResult(jobId string) (rst *BatchJobResult, err error) {
+	if jobId == "" {
+		err = errors.New("empty jobId")
+		return
+	}
+
+	var result struct {
+		corp.Error
+		BatchJobResult
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/batch/getresult?jobid=" +
+		url.QueryEscape(jobId) + "&access_token="
+	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	rst = &result.BatchJobResult
+	return
+}
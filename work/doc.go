@@ -0,0 +1,10 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 企业微信(work.weixin.qq.com) api 的 golang 封装.
+//
+//  企业微信是企业号的升级版, 主动调用的接口仍然是 qyapi.weixin.qq.com, 所以 Client 复用了 corp.CorpClient;
+//  与企业号不同的是, 企业微信每个应用都有自己的 secret, 需要为每个应用分别获取 access_token, 见 AgentTokenServer.
+package work
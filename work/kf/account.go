@@ -0,0 +1,184 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package kf
+
+import (
+	"errors"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 客服帐号
+type Account struct {
+	OpenKfId        string `json:"open_kfid"`
+	Name            string `json:"name"`
+	Avatar          string `json:"avatar"`
+	ManagePrivilege bool   `json:"manage_privilege"` // 当前调用接口的应用身份, 是否有该客服帐号的管理权限
+}
+
+// 添加客服帐号, 返回 open_kfid.
+//  name:    客服名称, 不多于16个字符
+//  mediaId: 客服头像的临时素材 media_id
+func (clt *Client) AccountAdd(name, mediaId string) (openKfId string, err error) {
+	if name == "" {
+		err = errors.New("empty name")
+		return
+	}
+	if mediaId == "" {
+		err = errors.New("empty mediaId")
+		return
+	}
+
+	var request = struct {
+		Name    string `json:"name"`
+		MediaId string `json:"media_id"`
+	}{
+		Name:    name,
+		MediaId: mediaId,
+	}
+
+	var result struct {
+		corp.Error
+		OpenKfId string `json:"open_kfid"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/kf/account/add?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	openKfId = result.OpenKfId
+	return
+}
+
+// 删除客服帐号.
+func (clt *Client) AccountDelete(openKfId string) (err error) {
+	if openKfId == "" {
+		return errors.New("empty openKfId")
+	}
+
+	var request = struct {
+		OpenKfId string `json:"open_kfid"`
+	}{
+		OpenKfId: openKfId,
+	}
+	return clt.post("https://qyapi.weixin.qq.com/cgi-bin/kf/account/del?access_token=", &request)
+}
+
+// 修改客服帐号, name 和 mediaId 为空时表示不修改.
+func (clt *Client) AccountUpdate(openKfId, name, mediaId string) (err error) {
+	if openKfId == "" {
+		return errors.New("empty openKfId")
+	}
+
+	var request = struct {
+		OpenKfId string `json:"open_kfid"`
+		Name     string `json:"name,omitempty"`
+		MediaId  string `json:"media_id,omitempty"`
+	}{
+		OpenKfId: openKfId,
+		Name:     name,
+		MediaId:  mediaId,
+	}
+	return clt.post("https://qyapi.weixin.qq.com/cgi-bin/kf/account/update?access_token=", &request)
+}
+
+// 获取客服帐号列表.
+//  offset: 分页的偏移量, 从0开始
+//  limit:  分页的大小, 取值范围 1~100
+func (clt *Client) AccountList(offset, limit int) (accounts []Account, err error) {
+	if offset < 0 {
+		err = errors.New("offset should not be less than 0")
+		return
+	}
+	if limit <= 0 || limit > 100 {
+		err = errors.New("limit should be between 1 and 100")
+		return
+	}
+
+	var request = struct {
+		Offset int `json:"offset"`
+		Limit  int `json:"limit"`
+	}{
+		Offset: offset,
+		Limit:  limit,
+	}
+
+	var result struct {
+		corp.Error
+		AccountList []Account `json:"account_list"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/kf/account/list?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	accounts = result.AccountList
+	return
+}
+
+// 获取客服帐号链接, 网页中打开该链接即可进入客服会话.
+//  scene: 场景值, 可以为空, 不为空时需要满足 ValidateScene; 返回的链接上会带 enc_scene 参数
+//  可以用 WithSceneParam 在返回的链接上附加自定义参数.
+func (clt *Client) AddContactWay(openKfId, scene string) (contactURL string, err error) {
+	if openKfId == "" {
+		err = errors.New("empty openKfId")
+		return
+	}
+	if scene != "" {
+		if err = ValidateScene(scene); err != nil {
+			return
+		}
+	}
+
+	var request = struct {
+		OpenKfId string `json:"open_kfid"`
+		Scene    string `json:"scene,omitempty"`
+	}{
+		OpenKfId: openKfId,
+		Scene:    scene,
+	}
+
+	var result struct {
+		corp.Error
+		URL string `json:"url"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/kf/add_contact_way?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	contactURL = result.URL
+	return
+}
+
+func (clt *Client) post(incompleteURL string, request interface{}) (err error) {
+	var result corp.Error
+
+	if err = clt.PostJSON(incompleteURL, request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
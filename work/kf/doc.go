@@ -0,0 +1,8 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 微信客服(kf.weixin.qq.com) 接口, 包括客服帐号, 接待人员管理以及客服链接的生成.
+//  接口使用企业微信"微信客服"应用的 secret 获取的 access_token, 可以使用 work.NewAgentTokenServer.
+package kf
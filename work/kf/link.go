@@ -0,0 +1,84 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package kf
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// 客服链接的场景值最大长度
+const (
+	MaxSceneLength      = 32
+	MaxSceneParamLength = 128
+)
+
+// 校验场景值, 场景值只能由字母, 数字, "_" 和 "-" 组成, 不超过32个字节.
+func ValidateScene(scene string) error {
+	if scene == "" {
+		return errors.New("empty scene")
+	}
+	if len(scene) > MaxSceneLength {
+		return errors.New("the length of scene must not exceed 32 bytes")
+	}
+	for i := 0; i < len(scene); i++ {
+		switch c := scene[i]; {
+		case c >= '0' && c <= '9', c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c == '_', c == '-':
+		default:
+			return errors.New("invalid character in scene: " + scene)
+		}
+	}
+	return nil
+}
+
+// 在客服链接上附加 scene_param 参数, 用户进入会话后的事件里会原样返回.
+//  contactURL: AddContactWay 返回的客服链接, 一般已经带了 enc_scene 参数
+//  sceneParam: 自定义参数, urlencode 之前不超过128个字节
+func WithSceneParam(contactURL, sceneParam string) (link string, err error) {
+	if contactURL == "" {
+		err = errors.New("empty contactURL")
+		return
+	}
+	if len(sceneParam) > MaxSceneParamLength {
+		err = errors.New("the length of sceneParam must not exceed 128 bytes")
+		return
+	}
+	if sceneParam == "" {
+		link = contactURL
+		return
+	}
+	sep := "?"
+	if strings.Contains(contactURL, "?") {
+		sep = "&"
+	}
+	link = contactURL + sep + "scene_param=" + url.QueryEscape(sceneParam)
+	return
+}
+
+// 校验客服链接, 要求是 https://work.weixin.qq.com/kfid/ 开头的链接.
+//  返回链接中的 kfid(open_kfid 的短链标识) 以及 scene_param.
+func ParseContactURL(contactURL string) (kfid, sceneParam string, err error) {
+	u, err := url.Parse(contactURL)
+	if err != nil {
+		return
+	}
+	if u.Scheme != "https" || u.Host != "work.weixin.qq.com" || !strings.HasPrefix(u.Path, "/kfid/") {
+		err = errors.New("not a kf contact url: " + contactURL)
+		return
+	}
+	kfid = strings.TrimPrefix(u.Path, "/kfid/")
+	if kfid == "" {
+		err = errors.New("empty kfid in contact url: " + contactURL)
+		return
+	}
+	sceneParam = u.Query().Get("scene_param")
+	if len(sceneParam) > MaxSceneParamLength {
+		err = errors.New("the length of scene_param must not exceed 128 bytes")
+		return
+	}
+	return
+}
@@ -0,0 +1,103 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package kf
+
+import (
+	"errors"
+	"net/url"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+// 接待人员的操作结果
+type ServicerResult struct {
+	UserId       string `json:"userid,omitempty"`
+	DepartmentId int64  `json:"department_id,omitempty"`
+	ErrCode      int    `json:"errcode"`
+	ErrMsg       string `json:"errmsg"`
+}
+
+// 接待人员
+type Servicer struct {
+	UserId       string `json:"userid,omitempty"`
+	DepartmentId int64  `json:"department_id,omitempty"`
+	Status       int    `json:"status"`    // 接待人员的接待状态, 0 接待中, 1 停止接待
+	StopType     int    `json:"stop_type"` // 接待人员的接待状态为"停止接待"的子类型, 0 停止接待, 1 暂时挂起
+}
+
+// 添加接待人员.
+//  userIds:       接待人员的 userid 列表, 最多100个
+//  departmentIds: 接待人员部门的 id 列表, 最多20个
+func (clt *Client) ServicerAdd(openKfId string, userIds []string, departmentIds []int64) (results []ServicerResult, err error) {
+	return clt.servicer("https://qyapi.weixin.qq.com/cgi-bin/kf/servicer/add?access_token=", openKfId, userIds, departmentIds)
+}
+
+// 删除接待人员.
+func (clt *Client) ServicerDelete(openKfId string, userIds []string, departmentIds []int64) (results []ServicerResult, err error) {
+	return clt.servicer("https://qyapi.weixin.qq.com/cgi-bin/kf/servicer/del?access_token=", openKfId, userIds, departmentIds)
+}
+
+func (clt *Client) servicer(incompleteURL, openKfId string, userIds []string, departmentIds []int64) (results []ServicerResult, err error) {
+	if openKfId == "" {
+		err = errors.New("empty openKfId")
+		return
+	}
+	if len(userIds) == 0 && len(departmentIds) == 0 {
+		err = errors.New("userIds and departmentIds cannot both be empty")
+		return
+	}
+
+	var request = struct {
+		OpenKfId         string   `json:"open_kfid"`
+		UserIdList       []string `json:"userid_list,omitempty"`
+		DepartmentIdList []int64  `json:"department_id_list,omitempty"`
+	}{
+		OpenKfId:         openKfId,
+		UserIdList:       userIds,
+		DepartmentIdList: departmentIds,
+	}
+
+	var result struct {
+		corp.Error
+		ResultList []ServicerResult `json:"result_list"`
+	}
+
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	results = result.ResultList
+	return
+}
+
+// 获取接待人员列表.
+func (clt *Client) ServicerList(openKfId string) (servicers []Servicer, err error) {
+	if openKfId == "" {
+		err = errors.New("empty openKfId")
+		return
+	}
+
+	var result struct {
+		corp.Error
+		ServicerList []Servicer `json:"servicer_list"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/kf/servicer/list?open_kfid=" + url.QueryEscape(openKfId) + "&access_token="
+	if err = clt.GetJSON(incompleteURL, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	servicers = result.ServicerList
+	return
+}
@@ -0,0 +1,237 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package work
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+const (
+	MsgTypeText     = "text"
+	MsgTypeImage    = "image"
+	MsgTypeFile     = "file"
+	MsgTypeTextCard = "textcard"
+	MsgTypeNews     = "news"
+	MsgTypeMarkdown = "markdown"
+)
+
+// 应用消息的接收者, ToUser, ToParty, ToTag 不能同时为空, ToAll 为 true 时忽略其他参数.
+type Recipients struct {
+	ToUser  []string // 成员ID列表, 最多支持1000个
+	ToParty []string // 部门ID列表, 最多支持100个
+	ToTag   []string // 标签ID列表, 最多支持100个
+	ToAll   bool     // 向该企业应用的全部成员发送
+}
+
+func (to *Recipients) check() error {
+	if to == nil {
+		return errors.New("nil Recipients")
+	}
+	if !to.ToAll && len(to.ToUser) == 0 && len(to.ToParty) == 0 && len(to.ToTag) == 0 {
+		return errors.New("empty Recipients")
+	}
+	return nil
+}
+
+// 发送应用消息的结果, 无效的接收者不会导致发送失败
+type SendResult struct {
+	InvalidUser  []string // 不合法的成员ID
+	InvalidParty []string // 不合法的部门ID
+	InvalidTag   []string // 不合法的标签ID
+	MsgId        string   // 消息ID, 用于撤回应用消息
+}
+
+// 文本卡片消息
+type TextCard struct {
+	Title       string `json:"title"`            // 标题, 不超过128个字节
+	Description string `json:"description"`      // 描述, 不超过512个字节, 支持 div 标签的 class 属性 gray, normal, highlight
+	URL         string `json:"url"`              // 点击后跳转的链接
+	BtnTxt      string `json:"btntxt,omitempty"` // 按钮文字, 默认为"详情"
+}
+
+// 图文消息的文章
+type Article struct {
+	Title       string `json:"title"`                 // 标题, 不超过128个字节
+	Description string `json:"description,omitempty"` // 描述, 不超过512个字节
+	URL         string `json:"url,omitempty"`         // 点击后跳转的链接
+	PicURL      string `json:"picurl,omitempty"`      // 图文消息的图片链接
+}
+
+// 发送文本消息.
+//  safe: 是否是保密消息
+func (clt *Client) SendText(to *Recipients, content string, safe bool) (r *SendResult, err error) {
+	if content == "" {
+		err = errors.New("empty content")
+		return
+	}
+	var body = struct {
+		Content string `json:"content"`
+	}{
+		Content: content,
+	}
+	return clt.send(to, MsgTypeText, &body, safe)
+}
+
+// 发送 markdown 消息, 目前仅支持 markdown 语法的子集.
+func (clt *Client) SendMarkdown(to *Recipients, content string) (r *SendResult, err error) {
+	if content == "" {
+		err = errors.New("empty content")
+		return
+	}
+	var body = struct {
+		Content string `json:"content"`
+	}{
+		Content: content,
+	}
+	return clt.send(to, MsgTypeMarkdown, &body, false)
+}
+
+// 发送图片消息.
+//  mediaId: 图片的临时素材 media_id
+func (clt *Client) SendImage(to *Recipients, mediaId string, safe bool) (r *SendResult, err error) {
+	if mediaId == "" {
+		err = errors.New("empty mediaId")
+		return
+	}
+	var body = struct {
+		MediaId string `json:"media_id"`
+	}{
+		MediaId: mediaId,
+	}
+	return clt.send(to, MsgTypeImage, &body, safe)
+}
+
+// 发送文件消息.
+//  mediaId: 文件的临时素材 media_id
+func (clt *Client) SendFile(to *Recipients, mediaId string, safe bool) (r *SendResult, err error) {
+	if mediaId == "" {
+		err = errors.New("empty mediaId")
+		return
+	}
+	var body = struct {
+		MediaId string `json:"media_id"`
+	}{
+		MediaId: mediaId,
+	}
+	return clt.send(to, MsgTypeFile, &body, safe)
+}
+
+// 发送文本卡片消息.
+func (clt *Client) SendTextCard(to *Recipients, card *TextCard) (r *SendResult, err error) {
+	if card == nil {
+		err = errors.New("nil TextCard")
+		return
+	}
+	if card.Title == "" || card.Description == "" || card.URL == "" {
+		err = errors.New("TextCard's Title, Description and URL must not be empty")
+		return
+	}
+	return clt.send(to, MsgTypeTextCard, card, false)
+}
+
+// 发送图文消息.
+//  articles: 1~8 条图文
+func (clt *Client) SendNews(to *Recipients, articles []Article) (r *SendResult, err error) {
+	if n := len(articles); n <= 0 || n > 8 {
+		err = errors.New("the number of articles must be between 1 and 8")
+		return
+	}
+	var body = struct {
+		Articles []Article `json:"articles"`
+	}{
+		Articles: articles,
+	}
+	return clt.send(to, MsgTypeNews, &body, false)
+}
+
+func (clt *Client) send(to *Recipients, msgType string, body interface{}, safe bool) (r *SendResult, err error) {
+	if err = to.check(); err != nil {
+		return
+	}
+
+	var request = map[string]interface{}{
+		"msgtype": msgType,
+		"agentid": clt.agentId,
+		msgType:   body,
+	}
+	if to.ToAll {
+		request["touser"] = "@all"
+	} else {
+		if len(to.ToUser) > 0 {
+			request["touser"] = strings.Join(to.ToUser, "|")
+		}
+		if len(to.ToParty) > 0 {
+			request["toparty"] = strings.Join(to.ToParty, "|")
+		}
+		if len(to.ToTag) > 0 {
+			request["totag"] = strings.Join(to.ToTag, "|")
+		}
+	}
+	if safe {
+		request["safe"] = 1
+	}
+
+	var result struct {
+		corp.Error
+		InvalidUser  string `json:"invaliduser"`
+		InvalidParty string `json:"invalidparty"`
+		InvalidTag   string `json:"invalidtag"`
+		MsgId        string `json:"msgid"`
+	}
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/message/send?access_token="
+	if err = clt.PostJSON(incompleteURL, request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	r = &SendResult{
+		InvalidUser:  splitIds(result.InvalidUser),
+		InvalidParty: splitIds(result.InvalidParty),
+		InvalidTag:   splitIds(result.InvalidTag),
+		MsgId:        result.MsgId,
+	}
+	return
+}
+
+// 撤回24小时内通过 Send* 发送的应用消息.
+func (clt *Client) RecallMessage(msgId string) (err error) {
+	if msgId == "" {
+		return errors.New("empty msgId")
+	}
+
+	var request = struct {
+		MsgId string `json:"msgid"`
+	}{
+		MsgId: msgId,
+	}
+
+	var result corp.Error
+
+	incompleteURL := "https://qyapi.weixin.qq.com/cgi-bin/message/recall?access_token="
+	if err = clt.PostJSON(incompleteURL, &request, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+func splitIds(s string) []string {
+	if s == "" {
+		return nil
+	}
+	return strings.Split(s, "|")
+}
@@ -0,0 +1,8 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+// 企业微信群机器人.
+//  群机器人通过 webhook 地址上的 key 鉴权, 不需要 access_token, 适合告警等场景往企业微信群里推送消息.
+package robot
@@ -0,0 +1,242 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package robot
+
+import (
+	"bytes"
+	"crypto/md5"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+
+	"github.com/chanxuehong/wechat/corp"
+	wechatjson "github.com/chanxuehong/wechat/json"
+)
+
+// 群机器人客户端
+type Robot struct {
+	key        string
+	httpClient *http.Client
+}
+
+// 创建一个新的 Robot.
+//  key: 群机器人 webhook 地址上的 key 参数
+//  如果 httpClient == nil 则默认用 http.DefaultClient
+func NewRobot(key string, httpClient *http.Client) *Robot {
+	if key == "" {
+		panic("empty key")
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &Robot{
+		key:        key,
+		httpClient: httpClient,
+	}
+}
+
+// @提醒, 用于文本消息
+type Mention struct {
+	UserIds []string // userid 列表, "@all" 表示提醒所有人
+	Mobiles []string // 手机号列表, "@all" 表示提醒所有人
+}
+
+// 发送文本消息.
+//  mention 可以为 nil
+func (r *Robot) SendText(content string, mention *Mention) (err error) {
+	if content == "" {
+		return errors.New("empty content")
+	}
+
+	var text = struct {
+		Content             string   `json:"content"`
+		MentionedList       []string `json:"mentioned_list,omitempty"`
+		MentionedMobileList []string `json:"mentioned_mobile_list,omitempty"`
+	}{
+		Content: content,
+	}
+	if mention != nil {
+		text.MentionedList = mention.UserIds
+		text.MentionedMobileList = mention.Mobiles
+	}
+	return r.send("text", &text)
+}
+
+// 发送 markdown 消息, 可以在 content 里面用 <@userid> 提醒成员.
+func (r *Robot) SendMarkdown(content string) (err error) {
+	if content == "" {
+		return errors.New("empty content")
+	}
+
+	var markdown = struct {
+		Content string `json:"content"`
+	}{
+		Content: content,
+	}
+	return r.send("markdown", &markdown)
+}
+
+// 发送图片消息.
+//  image: 图片内容, jpg 或 png 格式, 图片(base64编码前)最大不能超过2M
+func (r *Robot) SendImage(image []byte) (err error) {
+	if len(image) == 0 {
+		return errors.New("empty image")
+	}
+
+	sum := md5.Sum(image)
+	var img = struct {
+		Base64 string `json:"base64"`
+		MD5    string `json:"md5"`
+	}{
+		Base64: base64.StdEncoding.EncodeToString(image),
+		MD5:    hex.EncodeToString(sum[:]),
+	}
+	return r.send("image", &img)
+}
+
+// 图文消息的文章
+type Article struct {
+	Title       string `json:"title"`                 // 标题, 不超过128个字节
+	Description string `json:"description,omitempty"` // 描述, 不超过512个字节
+	URL         string `json:"url"`                   // 点击后跳转的链接
+	PicURL      string `json:"picurl,omitempty"`      // 图文消息的图片链接
+}
+
+// 发送图文消息.
+//  articles: 1~8 条图文
+func (r *Robot) SendNews(articles []Article) (err error) {
+	if n := len(articles); n <= 0 || n > 8 {
+		return errors.New("the number of articles must be between 1 and 8")
+	}
+
+	var news = struct {
+		Articles []Article `json:"articles"`
+	}{
+		Articles: articles,
+	}
+	return r.send("news", &news)
+}
+
+// 发送文件消息.
+//  mediaId: 通过 UploadFile 上传文件获取的 media_id
+func (r *Robot) SendFile(mediaId string) (err error) {
+	if mediaId == "" {
+		return errors.New("empty mediaId")
+	}
+
+	var file = struct {
+		MediaId string `json:"media_id"`
+	}{
+		MediaId: mediaId,
+	}
+	return r.send("file", &file)
+}
+
+func (r *Robot) send(msgType string, body interface{}) (err error) {
+	request := map[string]interface{}{
+		"msgtype": msgType,
+		msgType:   body,
+	}
+
+	requestBody, err := wechatjson.Marshal(request) // markdown 里的 <font> 等不能被转义
+	if err != nil {
+		return
+	}
+
+	_url := "https://qyapi.weixin.qq.com/cgi-bin/webhook/send?key=" + url.QueryEscape(r.key)
+	httpResp, err := r.httpClient.Post(_url, "application/json; charset=utf-8", bytes.NewReader(requestBody))
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	var result corp.Error
+	if err = decodeResponse(httpResp, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result
+		return
+	}
+	return
+}
+
+// 上传文件, 获取发送文件消息用的 media_id, 文件大小在5B~20M之间, media_id 3天内有效.
+func (r *Robot) UploadFile(_filepath string) (mediaId string, err error) {
+	file, err := os.Open(_filepath)
+	if err != nil {
+		return
+	}
+	defer file.Close()
+
+	return r.UploadFileFromReader(filepath.Base(_filepath), file)
+}
+
+// 上传文件, 获取发送文件消息用的 media_id.
+//  NOTE: 参数 filename 不是文件路径, 是指定 multipart/form-data 里面文件名称
+func (r *Robot) UploadFileFromReader(filename string, reader io.Reader) (mediaId string, err error) {
+	if filename == "" {
+		err = errors.New("empty filename")
+		return
+	}
+	if reader == nil {
+		err = errors.New("nil reader")
+		return
+	}
+
+	var buf bytes.Buffer
+	multipartWriter := multipart.NewWriter(&buf)
+	partWriter, err := multipartWriter.CreateFormFile("media", filename)
+	if err != nil {
+		return
+	}
+	if _, err = io.Copy(partWriter, reader); err != nil {
+		return
+	}
+	if err = multipartWriter.Close(); err != nil {
+		return
+	}
+
+	_url := "https://qyapi.weixin.qq.com/cgi-bin/webhook/upload_media?type=file&key=" + url.QueryEscape(r.key)
+	httpResp, err := r.httpClient.Post(_url, multipartWriter.FormDataContentType(), &buf)
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	var result struct {
+		corp.Error
+		MediaId string `json:"media_id"`
+	}
+	if err = decodeResponse(httpResp, &result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+	mediaId = result.MediaId
+	return
+}
+
+func decodeResponse(httpResp *http.Response, response interface{}) (err error) {
+	if httpResp.StatusCode != http.StatusOK {
+		io.Copy(ioutil.Discard, httpResp.Body)
+		return fmt.Errorf("http.Status: %s", httpResp.Status)
+	}
+	return json.NewDecoder(httpResp.Body).Decode(response)
+}
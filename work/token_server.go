@@ -0,0 +1,201 @@
+// @description wechat 是腾讯微信公众平台 api 的 golang 语言封装
+// @link        https://github.com/chanxuehong/wechat for the canonical source repository
+// @license     https://github.com/chanxuehong/wechat/blob/master/LICENSE
+// @authors     chanxuehong(chanxuehong@gmail.com)
+
+package work
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/chanxuehong/wechat/corp"
+)
+
+var _ corp.TokenServer = (*AgentTokenServer)(nil)
+
+// 企业微信应用的 access_token 中控服务器, 实现了 corp.TokenServer 接口.
+//  NOTE:
+//  1. 用于单进程环境;
+//  2. 与 corp.DefaultTokenServer 不同, AgentTokenServer 是惰性获取 access_token 的, 不启动 goroutine,
+//     所以每个 corpid+corpsecret(每个应用) 都可以创建一个 AgentTokenServer.
+type AgentTokenServer struct {
+	corpId     string
+	corpSecret string
+	httpClient *http.Client
+
+	tokenGet struct {
+		sync.Mutex
+		LastToken     string // 最后一次成功从微信服务器获取的 access_token
+		LastTimestamp int64  // 最后一次成功从微信服务器获取 access_token 的时间戳
+	}
+
+	tokenCache struct {
+		sync.RWMutex
+		Token     string
+		ExpiresAt int64 // 过期时间戳, 已经扣除了缓冲时间
+	}
+}
+
+// 创建一个新的 AgentTokenServer.
+//  corpId:     企业ID
+//  corpSecret: 应用的凭证密钥
+//  如果 httpClient == nil 则默认使用 http.DefaultClient.
+func NewAgentTokenServer(corpId, corpSecret string, httpClient *http.Client) *AgentTokenServer {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &AgentTokenServer{
+		corpId:     corpId,
+		corpSecret: corpSecret,
+		httpClient: httpClient,
+	}
+}
+
+func (srv *AgentTokenServer) Token() (token string, err error) {
+	srv.tokenCache.RLock()
+	token = srv.tokenCache.Token
+	expiresAt := srv.tokenCache.ExpiresAt
+	srv.tokenCache.RUnlock()
+
+	if token != "" && time.Now().Unix() < expiresAt {
+		return
+	}
+	return srv.TokenRefresh()
+}
+
+func (srv *AgentTokenServer) TokenRefresh() (token string, err error) {
+	srv.tokenGet.Lock()
+	defer srv.tokenGet.Unlock()
+
+	timeNowUnix := time.Now().Unix()
+
+	// 在收敛周期内直接返回最近一次获取的 access_token
+	if n := srv.tokenGet.LastTimestamp; timeNowUnix >= n && timeNowUnix < n+5 {
+		token = srv.tokenGet.LastToken
+		return
+	}
+
+	token, expiresIn, err := srv.getToken()
+	if err != nil {
+		srv.tokenCache.Lock()
+		srv.tokenCache.Token = ""
+		srv.tokenCache.ExpiresAt = 0
+		srv.tokenCache.Unlock()
+		return
+	}
+
+	srv.tokenGet.LastToken = token
+	srv.tokenGet.LastTimestamp = timeNowUnix
+
+	srv.tokenCache.Lock()
+	srv.tokenCache.Token = token
+	srv.tokenCache.ExpiresAt = timeNowUnix + expiresIn
+	srv.tokenCache.Unlock()
+	return
+}
+
+// 从微信服务器获取 access_token.
+func (srv *AgentTokenServer) getToken() (token string, expiresIn int64, err error) {
+	_url := "https://qyapi.weixin.qq.com/cgi-bin/gettoken?corpid=" +
+		url.QueryEscape(srv.corpId) + "&corpsecret=" + url.QueryEscape(srv.corpSecret)
+	httpResp, err := srv.httpClient.Get(_url)
+	if err != nil {
+		return
+	}
+	defer httpResp.Body.Close()
+
+	if httpResp.StatusCode != http.StatusOK {
+		err = fmt.Errorf("http.Status: %s", httpResp.Status)
+		return
+	}
+
+	var result struct {
+		corp.Error
+		Token     string `json:"access_token"`
+		ExpiresIn int64  `json:"expires_in"`
+	}
+
+	if err = json.NewDecoder(httpResp.Body).Decode(&result); err != nil {
+		return
+	}
+
+	if result.ErrCode != corp.ErrCodeOK {
+		err = &result.Error
+		return
+	}
+
+	// 由于网络的延时, access_token 过期时间留了一个缓冲区
+	switch {
+	case result.ExpiresIn > 60*60:
+		result.ExpiresIn -= 60 * 10
+	case result.ExpiresIn > 60*30:
+		result.ExpiresIn -= 60 * 5
+	case result.ExpiresIn > 60*5:
+		result.ExpiresIn -= 60
+	case result.ExpiresIn > 60:
+		result.ExpiresIn -= 10
+	case result.ExpiresIn > 0:
+	default:
+		err = errors.New("invalid expires_in: " + strconv.FormatInt(result.ExpiresIn, 10))
+		return
+	}
+
+	token = result.Token
+	expiresIn = result.ExpiresIn
+	return
+}
+
+// 按应用管理 AgentTokenServer, 同一个企业下每个应用一个.
+type AgentTokenServers struct {
+	corpId     string
+	httpClient *http.Client
+
+	rwmutex sync.RWMutex
+	servers map[int64]*AgentTokenServer
+}
+
+// 如果 httpClient == nil 则默认使用 http.DefaultClient.
+func NewAgentTokenServers(corpId string, httpClient *http.Client) *AgentTokenServers {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+
+	return &AgentTokenServers{
+		corpId:     corpId,
+		httpClient: httpClient,
+		servers:    make(map[int64]*AgentTokenServer),
+	}
+}
+
+// 添加(替换)应用的 secret, 返回该应用的 AgentTokenServer.
+func (s *AgentTokenServers) Add(agentId int64, corpSecret string) *AgentTokenServer {
+	srv := NewAgentTokenServer(s.corpId, corpSecret, s.httpClient)
+
+	s.rwmutex.Lock()
+	s.servers[agentId] = srv
+	s.rwmutex.Unlock()
+	return srv
+}
+
+// 获取应用的 AgentTokenServer, 如果没有添加过返回 nil.
+func (s *AgentTokenServers) Get(agentId int64) *AgentTokenServer {
+	s.rwmutex.RLock()
+	srv := s.servers[agentId]
+	s.rwmutex.RUnlock()
+	return srv
+}
+
+// 删除应用的 AgentTokenServer.
+func (s *AgentTokenServers) Delete(agentId int64) {
+	s.rwmutex.Lock()
+	delete(s.servers, agentId)
+	s.rwmutex.Unlock()
+}